@@ -16,8 +16,10 @@ import (
 	"webhook-processor/internal/application/usecases"
 	"webhook-processor/internal/config"
 	"webhook-processor/internal/infrastructure/database"
+	"webhook-processor/internal/infrastructure/logging"
 	"webhook-processor/internal/infrastructure/repositories"
 	infraServices "webhook-processor/internal/infrastructure/services"
+	"webhook-processor/internal/infrastructure/storage"
 	httpTransport "webhook-processor/internal/transport/http"
 )
 
@@ -30,7 +32,7 @@ func main() {
 	}
 
 	// Setup logger
-	logger := setupLogger()
+	logger, logLevel := setupLogger(cfg.Log.Level)
 	level.Info(logger).Log("msg", "starting webhook API server")
 
 	// Initialize database
@@ -42,7 +44,20 @@ func main() {
 	level.Info(logger).Log("msg", "database connection established")
 
 	// Initialize repositories
-	webhookQueueRepo, err := repositories.NewWebhookQueueRepository(db)
+	bodyStore, err := storage.NewBodyStore(cfg.Database)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to create response body store", "error", err)
+		os.Exit(1)
+	}
+	webhookQueueRepo, err := repositories.NewWebhookQueueRepository(db, repositories.QueueRepositoryOptions{
+		MaxLockingTxns:         cfg.Database.MaxLockingTxns,
+		MaxStoredResponseBytes: cfg.Database.MaxStoredResponseBytes,
+		MaxStoredErrorBytes:    cfg.Database.MaxStoredErrorBytes,
+		BodyStore:              bodyStore,
+		ClaimTTL:               cfg.Claim.TTL,
+		AttemptBatchSize:       cfg.Database.AttemptBatchSize,
+		AttemptBatchWait:       cfg.Database.AttemptBatchWait,
+	}, nil, logger)
 	if err != nil {
 		level.Error(logger).Log("msg", "failed to create webhook queue repository", "error", err)
 		os.Exit(1)
@@ -52,9 +67,14 @@ func main() {
 		level.Error(logger).Log("msg", "failed to create webhook config repository", "error", err)
 		os.Exit(1)
 	}
+	workerHeartbeatRepo, err := repositories.NewWorkerHeartbeatRepository(db)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to create worker heartbeat repository", "error", err)
+		os.Exit(1)
+	}
 
 	// Initialize infrastructure services
-	webhookInfraService := infraServices.NewWebhookService(cfg.HTTPClient)
+	webhookInfraService := infraServices.NewWebhookService(cfg.HTTPClient, nil)
 
 	// Initialize use cases
 	webhookProcessor := usecases.NewWebhookProcessor(
@@ -63,15 +83,24 @@ func main() {
 		webhookInfraService,
 		logger,
 	)
+	webhookProcessor.SetConfigLookupRetry(cfg.ConfigLookup.Attempts, cfg.ConfigLookup.Backoff)
+	webhookProcessor.SetURLNormalization(cfg.WebhookURL.Normalize, cfg.WebhookURL.SortQuery)
+	webhookProcessor.SetWorkerHeartbeats(workerHeartbeatRepo)
+	webhookProcessor.SetClaimTTL(cfg.Claim.TTL)
+	webhookProcessor.SetEventPublisher(infraServices.NewNoopEventPublisher())
+	if bp := cfg.Backpressure; bp.HighWaterMark > 0 {
+		webhookProcessor.EnableBackpressure(int64(bp.HighWaterMark), int64(bp.LowWaterMark), bp.CheckInterval, bp.RetryAfter)
+	}
 
 	// Initialize application services
-	appService := services.NewWebhookApplicationService(webhookProcessor)
+	appService := services.NewWebhookApplicationService(webhookProcessor, cfg.Health)
 
 	// Create HTTP transport service
-	httpService := httpTransport.NewService(appService)
+	httpService := httpTransport.NewService(appService, cfg)
 
 	// Create HTTP handler with all routes and middleware
-	router := httpTransport.NewHTTPHandler(httpService, log.With(logger, "component", "http"))
+	router := httpTransport.NewHTTPHandler(httpService, log.With(logger, "component", "http"),
+		cfg.HTTPServer.AdminToken, cfg.HTTPServer.MaxConcurrentProbes)
 
 	// Setup HTTP server
 	httpServer := &http.Server{
@@ -91,6 +120,18 @@ func main() {
 		}
 	}()
 
+	// Reload the runtime-safe settings on SIGHUP; only the log level applies to the API server
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	go func() {
+		for range hupChan {
+			level.Info(logger).Log("msg", "SIGHUP received, reloading configuration")
+			if reloadConfig(cfg, logger) {
+				logLevel.SetLevel(cfg.Log.Level)
+			}
+		}
+	}()
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -110,9 +151,33 @@ func main() {
 	level.Info(logger).Log("msg", "HTTP server shutdown complete")
 }
 
-// setupLogger creates and configures a logger with default settings
-func setupLogger() log.Logger {
-	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout))
-	logger = log.With(logger, "ts", log.DefaultTimestampUTC, "caller", log.DefaultCaller)
-	return logger
+// setupLogger creates a text format logger filtered at minLevel; the level can be changed at runtime
+func setupLogger(minLevel string) (log.Logger, *logging.LevelLogger) {
+	leveled, err := logging.NewLevelLogger(log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout)), minLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to set up logger: %v\n", err)
+		os.Exit(1)
+	}
+	logger := log.With(leveled, "ts", log.DefaultTimestampUTC, "caller", log.DefaultCaller)
+	return logger, leveled
+}
+
+// reloadConfig re-runs LoadConfig and applies the reloadable settings to cfg, logging what changed
+// Settings that need a restart are left untouched; it returns false if the new configuration is invalid
+func reloadConfig(cfg *config.Config, logger log.Logger) bool {
+	next, err := config.LoadConfig()
+	if err != nil {
+		level.Error(logger).Log("msg", "config reload failed, keeping current configuration", "error", err)
+		return false
+	}
+
+	changed, ignored := cfg.ApplyReload(next)
+	for _, change := range changed {
+		level.Info(logger).Log("msg", "config setting reloaded", "change", change)
+	}
+	for _, section := range ignored {
+		level.Warn(logger).Log("msg", "config change needs a restart, ignored", "section", section)
+	}
+	level.Info(logger).Log("msg", "config reload complete", "changed", len(changed), "ignored", len(ignored))
+	return true
 }
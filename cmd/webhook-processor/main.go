@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -9,18 +11,24 @@ import (
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"webhook-processor/internal/application/usecases"
 	"webhook-processor/internal/application/workers"
 	"webhook-processor/internal/config"
 	"webhook-processor/internal/infrastructure/database"
+	"webhook-processor/internal/infrastructure/limits"
+	"webhook-processor/internal/infrastructure/logging"
 	"webhook-processor/internal/infrastructure/metrics"
 	"webhook-processor/internal/infrastructure/repositories"
 	"webhook-processor/internal/infrastructure/services"
+	"webhook-processor/internal/infrastructure/storage"
+	httpTransport "webhook-processor/internal/transport/http"
 )
 
 func main() {
+	restoreQueue := flag.String("restore-queue", "", "restore the queue backup at this path into the database, then exit")
+	flag.Parse()
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -29,9 +37,12 @@ func main() {
 	}
 
 	// Setup logger
-	logger := setupLogger()
+	logger, logLevel := setupLogger(cfg.Log.Level)
 	level.Info(logger).Log("msg", "starting webhook processor", "version", "1.0.0")
 
+	// Log open file limits before opening any connections
+	limits.CheckFileLimit(logger, limits.RequiredFileDescriptors(cfg), cfg.Resources.RaiseFileLimit)
+
 	// Initialize database
 	db, err := database.NewDatabase(cfg)
 	if err != nil {
@@ -40,8 +51,24 @@ func main() {
 	}
 	level.Info(logger).Log("msg", "database connection established")
 
+	// Initialize metrics
+	webhookMetrics := metrics.NewWebhookMetrics()
+
 	// Initialize repositories
-	webhookQueueRepo, err := repositories.NewWebhookQueueRepository(db)
+	bodyStore, err := storage.NewBodyStore(cfg.Database)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to create response body store", "error", err)
+		os.Exit(1)
+	}
+	webhookQueueRepo, err := repositories.NewWebhookQueueRepository(db, repositories.QueueRepositoryOptions{
+		MaxLockingTxns:         cfg.Database.MaxLockingTxns,
+		MaxStoredResponseBytes: cfg.Database.MaxStoredResponseBytes,
+		MaxStoredErrorBytes:    cfg.Database.MaxStoredErrorBytes,
+		BodyStore:              bodyStore,
+		ClaimTTL:               cfg.Claim.TTL,
+		AttemptBatchSize:       cfg.Database.AttemptBatchSize,
+		AttemptBatchWait:       cfg.Database.AttemptBatchWait,
+	}, webhookMetrics, logger)
 	if err != nil {
 		level.Error(logger).Log("msg", "failed to create webhook queue repository", "error", err)
 		os.Exit(1)
@@ -51,12 +78,14 @@ func main() {
 		level.Error(logger).Log("msg", "failed to create webhook config repository", "error", err)
 		os.Exit(1)
 	}
-
-	// Initialize metrics
-	webhookMetrics := metrics.NewWebhookMetrics()
+	workerHeartbeatRepo, err := repositories.NewWorkerHeartbeatRepository(db)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to create worker heartbeat repository", "error", err)
+		os.Exit(1)
+	}
 
 	// Initialize services
-	webhookService := services.NewWebhookService(cfg.HTTPClient)
+	webhookService := services.NewWebhookService(cfg.HTTPClient, webhookMetrics)
 
 	// Initialize use cases
 	webhookProcessor := usecases.NewWebhookProcessor(
@@ -65,10 +94,52 @@ func main() {
 		webhookService,
 		logger,
 	)
+	webhookProcessor.SetMaxRetryDelay(cfg.Retry.MaxDelay)
+	webhookProcessor.SetSeededJitter(cfg.Retry.SeededJitter)
+	webhookProcessor.SetDNSNotFoundAttempts(cfg.Retry.DNSNotFoundAttempts)
+	webhookProcessor.SetThrottleGrace(cfg.Retry.ThrottleGrace)
+	webhookProcessor.SetStoreRequests(cfg.Database.StoreRequestBodies)
+	webhookProcessor.SetMetrics(webhookMetrics)
+	webhookProcessor.SetWorkerHeartbeats(workerHeartbeatRepo)
+	webhookProcessor.SetClaimTTL(cfg.Claim.TTL)
+	webhookProcessor.SetStatusCallbacks(services.NewStatusCallbackService(cfg.HTTPClient))
+	webhookProcessor.SetFailureNotifier(services.NewFailureNotifier(cfg.FailureAlert, cfg.HTTPClient, logger))
+	webhookProcessor.SetEventPublisher(services.NewNoopEventPublisher())
+
+	// Restore a queue backup into this database instead of processing webhooks
+	if *restoreQueue != "" {
+		if err := restoreQueueBackup(webhookProcessor, *restoreQueue); err != nil {
+			level.Error(logger).Log("msg", "failed to restore queue backup", "path", *restoreQueue, "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Back workers off while the database is unreachable
+	sqlDB, err := db.DB()
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to get database handle", "error", err)
+		os.Exit(1)
+	}
+	dbHealth := workers.NewDBHealth(sqlDB.PingContext, logger, cfg.DBHealth, webhookMetrics)
+	if err := dbHealth.Start(); err != nil {
+		level.Error(logger).Log("msg", "failed to start DB health check", "error", err)
+		os.Exit(1)
+	}
+
+	// Warn about worker retry levels no active config's retry policy can reach
+	activeWorkers := cfg.WorkerPool.ActiveWorkers()
+	workerLevels := make([]int, 0, len(activeWorkers))
+	for _, worker := range activeWorkers {
+		workerLevels = append(workerLevels, worker.RetryLevel)
+	}
+	if _, err := webhookProcessor.CheckRetryLevelReachability(context.Background(), workerLevels); err != nil {
+		level.Warn(logger).Log("msg", "failed to check worker retry level reachability", "error", err)
+	}
 
 	// Initialize worker pool
-	workerPoolConfig := config.GetDefaultWorkerPoolConfig()
-	workerPool := workers.NewWorkerPool(webhookProcessor, logger, workerPoolConfig, webhookMetrics)
+	workerPool := workers.NewWorkerPool(webhookProcessor, logger, cfg.WorkerPool, webhookMetrics)
+	workerPool.SetDBHealth(dbHealth)
 
 	// Start worker pool
 	if err := workerPool.Start(); err != nil {
@@ -77,15 +148,61 @@ func main() {
 	}
 	level.Info(logger).Log("msg", "worker pool started successfully")
 
-	// Start metrics server
+	// Start consistency checker
+	consistencyChecker := workers.NewConsistencyChecker(webhookProcessor, logger, cfg.Reconciliation, webhookMetrics)
+	if err := consistencyChecker.Start(); err != nil {
+		level.Error(logger).Log("msg", "failed to start consistency checker", "error", err)
+		os.Exit(1)
+	}
+
+	// Start claim reaper
+	claimReaper := workers.NewClaimReaper(webhookProcessor, logger, cfg.Claim, webhookMetrics)
+	if err := claimReaper.Start(); err != nil {
+		level.Error(logger).Log("msg", "failed to start claim reaper", "error", err)
+		os.Exit(1)
+	}
+
+	// Start queue backup
+	var queueBackup *workers.QueueBackup
+	if cfg.QueueBackup.Dir != "" {
+		backupStore, err := storage.NewDirectoryObjectClient(cfg.QueueBackup.Dir)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to create queue backup store", "error", err)
+			os.Exit(1)
+		}
+		queueBackup = workers.NewQueueBackup(webhookProcessor, backupStore, logger, cfg.QueueBackup)
+		if err := queueBackup.Start(); err != nil {
+			level.Error(logger).Log("msg", "failed to start queue backup", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Start metrics, readiness and drain server
 	go func() {
-		http.Handle("/metrics", promhttp.Handler())
+		handler := httpTransport.NewProcessorHandler(workerPool, workerPool, webhookQueueRepo, logger, cfg.HTTPServer.AdminToken)
 		level.Info(logger).Log("msg", "starting metrics server", "port", 8081)
-		if err := http.ListenAndServe(":8081", nil); err != nil {
+		if err := http.ListenAndServe(":8081", handler); err != nil {
 			level.Error(logger).Log("msg", "metrics server failed", "error", err)
 		}
 	}()
 
+	// Reload the runtime-safe settings on SIGHUP
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	go func() {
+		for range hupChan {
+			level.Info(logger).Log("msg", "SIGHUP received, reloading configuration")
+			if !reloadConfig(cfg, logger) {
+				continue
+			}
+			logLevel.SetLevel(cfg.Log.Level)
+			webhookProcessor.SetMaxRetryDelay(cfg.Retry.MaxDelay)
+			if err := workerPool.UpdatePollIntervals(cfg.WorkerPool); err != nil {
+				level.Error(logger).Log("msg", "failed to apply worker poll intervals", "error", err)
+			}
+		}
+	}()
+
 	// Setup graceful shutdown
 
 	// Handle shutdown signals
@@ -96,6 +213,23 @@ func main() {
 	<-sigChan
 	level.Info(logger).Log("msg", "shutdown signal received, stopping worker pool")
 
+	// Stop consistency checker
+	if err := consistencyChecker.Stop(); err != nil {
+		level.Error(logger).Log("msg", "failed to stop consistency checker", "error", err)
+	}
+
+	// Stop claim reaper
+	if err := claimReaper.Stop(); err != nil {
+		level.Error(logger).Log("msg", "failed to stop claim reaper", "error", err)
+	}
+
+	// Stop queue backup
+	if queueBackup != nil {
+		if err := queueBackup.Stop(); err != nil {
+			level.Error(logger).Log("msg", "failed to stop queue backup", "error", err)
+		}
+	}
+
 	// Stop worker pool
 	if err := workerPool.Stop(); err != nil {
 		level.Error(logger).Log("msg", "failed to stop worker pool", "error", err)
@@ -103,19 +237,56 @@ func main() {
 		level.Info(logger).Log("msg", "worker pool stopped successfully")
 	}
 
-	// Close database connection
-	if sqlDB, err := db.DB(); err == nil {
-		sqlDB.Close()
+	// Stop DB health check
+	if err := dbHealth.Stop(); err != nil {
+		level.Error(logger).Log("msg", "failed to stop DB health check", "error", err)
 	}
 
+	// Close database connection
+	sqlDB.Close()
+
 	level.Info(logger).Log("msg", "webhook processor shutdown complete")
 }
 
-// setupLogger creates and configures a logger with default settings
-func setupLogger() log.Logger {
-	// Use text format logger with info level by default
-	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout))
-	logger = log.With(logger, "ts", log.DefaultTimestampUTC, "caller", log.DefaultCaller)
-	logger = level.NewFilter(logger, level.AllowInfo())
-	return logger
+// restoreQueueBackup imports the queue backup file at path
+func restoreQueueBackup(processor *usecases.WebhookProcessor, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open queue backup: %w", err)
+	}
+	defer file.Close()
+
+	_, err = processor.ImportQueue(context.Background(), file)
+	return err
+}
+
+// setupLogger creates a text format logger filtered at minLevel; the level can be changed at runtime
+func setupLogger(minLevel string) (log.Logger, *logging.LevelLogger) {
+	leveled, err := logging.NewLevelLogger(log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout)), minLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to set up logger: %v\n", err)
+		os.Exit(1)
+	}
+	logger := log.With(leveled, "ts", log.DefaultTimestampUTC, "caller", log.DefaultCaller)
+	return logger, leveled
+}
+
+// reloadConfig re-runs LoadConfig and applies the reloadable settings to cfg, logging what changed
+// Settings that need a restart are left untouched; it returns false if the new configuration is invalid
+func reloadConfig(cfg *config.Config, logger log.Logger) bool {
+	next, err := config.LoadConfig()
+	if err != nil {
+		level.Error(logger).Log("msg", "config reload failed, keeping current configuration", "error", err)
+		return false
+	}
+
+	changed, ignored := cfg.ApplyReload(next)
+	for _, change := range changed {
+		level.Info(logger).Log("msg", "config setting reloaded", "change", change)
+	}
+	for _, section := range ignored {
+		level.Warn(logger).Log("msg", "config change needs a restart, ignored", "section", section)
+	}
+	level.Info(logger).Log("msg", "config reload complete", "changed", len(changed), "ignored", len(ignored))
+	return true
 }
@@ -0,0 +1,8 @@
+-- Drop per-attempt effective timeout columns from webhook_queue
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_6_timeout_ms;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_5_timeout_ms;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_4_timeout_ms;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_3_timeout_ms;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_2_timeout_ms;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_1_timeout_ms;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_0_timeout_ms;
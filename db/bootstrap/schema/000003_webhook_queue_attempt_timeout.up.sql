@@ -0,0 +1,9 @@
+-- Add per-attempt effective timeout columns to webhook_queue
+-- Records the timeout applied to each attempt when per-attempt timeout growth is enabled
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_0_timeout_ms BIGINT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_1_timeout_ms BIGINT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_2_timeout_ms BIGINT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_3_timeout_ms BIGINT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_4_timeout_ms BIGINT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_5_timeout_ms BIGINT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_6_timeout_ms BIGINT;
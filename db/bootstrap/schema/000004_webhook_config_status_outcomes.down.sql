@@ -0,0 +1,2 @@
+-- Drop per-config response status to outcome overrides from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS status_outcomes;
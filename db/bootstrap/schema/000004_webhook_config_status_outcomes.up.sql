@@ -0,0 +1,3 @@
+-- Add per-config response status to outcome overrides to webhook_configs
+-- Maps HTTP status codes to success, retry or fail; codes not listed use the default handling
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS status_outcomes JSONB;
@@ -0,0 +1,2 @@
+-- Drop per-config transport settings from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS transport_settings;
@@ -0,0 +1,3 @@
+-- Add per-config transport settings to webhook_configs
+-- Configs with identical settings share a dedicated HTTP client; NULL uses the shared default client
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS transport_settings JSONB;
@@ -0,0 +1,2 @@
+-- Drop per-config request body compression flag from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS compress_request;
@@ -0,0 +1,2 @@
+-- Add per-config request body compression flag to webhook_configs
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS compress_request BOOLEAN NOT NULL DEFAULT FALSE;
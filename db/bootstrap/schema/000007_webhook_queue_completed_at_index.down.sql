@@ -0,0 +1,2 @@
+-- Drop the completion time index used by the consistency check
+DROP INDEX IF EXISTS idx_webhook_queue_completed_at;
@@ -0,0 +1,2 @@
+-- Index completed webhooks by completion time for the periodic consistency check
+CREATE INDEX IF NOT EXISTS idx_webhook_queue_completed_at ON webhook_queue(completed_at) WHERE status = 'COMPLETED';
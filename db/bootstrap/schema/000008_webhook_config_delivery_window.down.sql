@@ -0,0 +1,2 @@
+-- Drop per-config delivery window from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS delivery_window;
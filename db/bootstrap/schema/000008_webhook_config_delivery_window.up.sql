@@ -0,0 +1,3 @@
+-- Add per-config delivery window to webhook_configs
+-- Deliveries due outside the window are rescheduled to its next opening; NULL means any time
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS delivery_window JSONB;
@@ -0,0 +1,3 @@
+-- Drop metadata labels from webhook_queue
+DROP INDEX IF EXISTS idx_webhook_queue_metadata;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS metadata;
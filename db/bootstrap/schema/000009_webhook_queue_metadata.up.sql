@@ -0,0 +1,4 @@
+-- Add caller-supplied metadata labels (e.g. tenant, source) to webhook_queue
+-- Stored as JSONB and indexed with GIN so containment filters (metadata @> '{"tenant":"acme"}') stay fast
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS metadata JSONB;
+CREATE INDEX IF NOT EXISTS idx_webhook_queue_metadata ON webhook_queue USING GIN (metadata);
@@ -0,0 +1,2 @@
+-- Drop per-config delivery statistics rollup
+DROP TABLE IF EXISTS webhook_config_stats;
@@ -0,0 +1,11 @@
+-- Create per-config delivery statistics rollup
+-- Updated in the same transaction as a webhook reaching COMPLETED or FAILED
+CREATE TABLE IF NOT EXISTS webhook_config_stats (
+    config_id BIGINT PRIMARY KEY REFERENCES webhook_configs(id),
+    total_delivered BIGINT NOT NULL DEFAULT 0,
+    total_failed BIGINT NOT NULL DEFAULT 0,
+    last_success_at TIMESTAMP,
+    last_failure_at TIMESTAMP,
+    success_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT NOW() NOT NULL
+);
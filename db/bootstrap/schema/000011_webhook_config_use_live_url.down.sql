@@ -0,0 +1,2 @@
+-- Drop per-config live URL flag from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS use_live_url;
@@ -0,0 +1,2 @@
+-- Add per-config flag to deliver to the config's current URL instead of the URL pinned at creation
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS use_live_url BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+-- Drop per-attempt error class columns from webhook_queue
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_6_error_class;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_5_error_class;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_4_error_class;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_3_error_class;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_2_error_class;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_1_error_class;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_0_error_class;
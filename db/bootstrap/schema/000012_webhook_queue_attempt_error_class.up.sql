@@ -0,0 +1,9 @@
+-- Add per-attempt error class columns to webhook_queue
+-- Categorizes each failed attempt (timeout, dns, connection, tls, http_status, internal) alongside the raw error
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_0_error_class VARCHAR(32);
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_1_error_class VARCHAR(32);
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_2_error_class VARCHAR(32);
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_3_error_class VARCHAR(32);
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_4_error_class VARCHAR(32);
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_5_error_class VARCHAR(32);
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_6_error_class VARCHAR(32);
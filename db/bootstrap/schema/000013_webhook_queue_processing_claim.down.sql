@@ -0,0 +1,4 @@
+-- Drop processing claim columns from webhook_queue
+DROP INDEX IF EXISTS idx_webhook_queue_claim_expires_at;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS claim_expires_at;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS claimed_by;
@@ -0,0 +1,5 @@
+-- Add processing claim columns to webhook_queue
+-- A worker claims a row until claim_expires_at; the reaper returns rows with expired claims to PENDING
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS claimed_by VARCHAR(100);
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS claim_expires_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_webhook_queue_claim_expires_at ON webhook_queue(claim_expires_at) WHERE status = 'PROCESSING';
@@ -0,0 +1,2 @@
+-- Drop per-config Accept header from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS accept_header;
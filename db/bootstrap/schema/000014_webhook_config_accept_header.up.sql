@@ -0,0 +1,2 @@
+-- Add per-config Accept header; NULL sends the default application/json and an empty string omits the header
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS accept_header VARCHAR(255);
@@ -0,0 +1,3 @@
+-- Drop per-config default flag from webhook_configs
+DROP INDEX IF EXISTS idx_webhook_configs_default_event_type;
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS is_default;
@@ -0,0 +1,4 @@
+-- Add per-config flag marking the default config for its event type
+-- Creates without a config ID resolve to the active default; at most one config per event type may be the default
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS is_default BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_configs_default_event_type ON webhook_configs(event_type) WHERE is_default = true AND deleted_at IS NULL;
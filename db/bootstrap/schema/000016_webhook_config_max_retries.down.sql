@@ -0,0 +1,2 @@
+-- Drop per-config retry limit from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS max_retries;
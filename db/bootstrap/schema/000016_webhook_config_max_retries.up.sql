@@ -0,0 +1,2 @@
+-- Add per-config retry limit; NULL uses the global maximum and 0 fails after the first attempt
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS max_retries INTEGER CHECK (max_retries >= 0);
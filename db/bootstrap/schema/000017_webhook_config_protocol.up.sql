@@ -0,0 +1,2 @@
+-- Add per-config delivery protocol; 'grpc' configs are delivered with the DeliveryService.Deliver RPC
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS protocol VARCHAR(16) NOT NULL DEFAULT 'http';
@@ -0,0 +1,2 @@
+-- Drop per-config payload envelope flag from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS wrap_payload;
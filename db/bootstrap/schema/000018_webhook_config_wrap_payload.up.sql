@@ -0,0 +1,2 @@
+-- Add per-config flag to POST the event in the standard JSON envelope instead of the raw payload
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS wrap_payload BOOLEAN NOT NULL DEFAULT FALSE;
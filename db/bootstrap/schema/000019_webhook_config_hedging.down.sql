@@ -0,0 +1,3 @@
+-- Drop per-config idempotency flag and hedge delay from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS hedge_after_ms;
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS idempotent;
@@ -0,0 +1,3 @@
+-- Add per-config idempotency flag and first-attempt hedge delay to webhook_configs
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS idempotent BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS hedge_after_ms INTEGER CHECK (hedge_after_ms >= 0);
@@ -0,0 +1,2 @@
+-- Drop the count of 429 responses rescheduled without consuming a retry
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS throttled_count;
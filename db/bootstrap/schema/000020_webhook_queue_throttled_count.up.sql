@@ -0,0 +1,2 @@
+-- Count 429 responses rescheduled without consuming a retry
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS throttled_count INTEGER NOT NULL DEFAULT 0;
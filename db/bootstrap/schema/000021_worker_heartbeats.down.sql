@@ -0,0 +1,2 @@
+-- Drop worker heartbeats
+DROP TABLE IF EXISTS worker_heartbeats;
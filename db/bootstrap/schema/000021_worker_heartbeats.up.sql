@@ -0,0 +1,9 @@
+-- Create worker heartbeats, upserted periodically by every running worker across instances
+-- A row whose last_seen_at stops advancing belongs to a worker that died without cleaning up
+CREATE TABLE IF NOT EXISTS worker_heartbeats (
+    worker_id VARCHAR(100) PRIMARY KEY,
+    retry_level INTEGER NOT NULL,
+    host VARCHAR(255) NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    last_seen_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Drop per-config retry schedule from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS retry_schedule_ms;
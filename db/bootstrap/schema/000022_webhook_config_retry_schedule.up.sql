@@ -0,0 +1,2 @@
+-- Add per-config retry schedule; a JSON array of delays in milliseconds, NULL uses the global backoff
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS retry_schedule_ms JSONB;
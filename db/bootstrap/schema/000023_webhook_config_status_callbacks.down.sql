@@ -0,0 +1,3 @@
+-- Drop per-config status callback URLs from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS on_failure_url;
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS on_success_url;
@@ -0,0 +1,3 @@
+-- Add per-config status callback URLs, posted when a webhook is delivered or permanently fails
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS on_success_url TEXT;
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS on_failure_url TEXT;
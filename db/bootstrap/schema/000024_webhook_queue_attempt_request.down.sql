@@ -0,0 +1,15 @@
+-- Drop per-attempt request columns from webhook_queue
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_6_request_headers;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_6_request_body;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_5_request_headers;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_5_request_body;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_4_request_headers;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_4_request_body;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_3_request_headers;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_3_request_body;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_2_request_headers;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_2_request_body;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_1_request_headers;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_1_request_body;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_0_request_headers;
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_0_request_body;
@@ -0,0 +1,16 @@
+-- Add per-attempt request columns to webhook_queue
+-- Records what each attempt sent (body and redacted headers) when request storage is enabled
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_0_request_body TEXT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_0_request_headers JSONB;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_1_request_body TEXT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_1_request_headers JSONB;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_2_request_body TEXT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_2_request_headers JSONB;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_3_request_body TEXT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_3_request_headers JSONB;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_4_request_body TEXT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_4_request_headers JSONB;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_5_request_body TEXT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_5_request_headers JSONB;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_6_request_body TEXT;
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_6_request_headers JSONB;
@@ -0,0 +1,3 @@
+-- Drop per-config HTTP method and payload template from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS payload_template;
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS http_method;
@@ -0,0 +1,7 @@
+-- Add per-config HTTP method and payload template to webhook_configs
+-- Existing configs keep the method they were delivered with: a bodyless GET unless they wrap the payload
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS http_method VARCHAR(10);
+UPDATE webhook_configs SET http_method = CASE WHEN wrap_payload THEN 'POST' ELSE 'GET' END WHERE http_method IS NULL;
+ALTER TABLE webhook_configs ALTER COLUMN http_method SET DEFAULT 'POST';
+ALTER TABLE webhook_configs ALTER COLUMN http_method SET NOT NULL;
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS payload_template TEXT;
@@ -0,0 +1,2 @@
+-- Drop the creation payload from webhook_queue
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS payload;
@@ -0,0 +1,2 @@
+-- Add the request body supplied at creation to webhook_queue, delivered by configs that send a body
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS payload TEXT;
@@ -0,0 +1,2 @@
+-- Drop the per-config signing secret from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS signing_secret;
@@ -0,0 +1,2 @@
+-- Add the per-config secret deliveries are signed with to webhook_configs; NULL or empty sends unsigned requests
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS signing_secret TEXT;
@@ -0,0 +1,2 @@
+-- Drop custom request headers from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS headers;
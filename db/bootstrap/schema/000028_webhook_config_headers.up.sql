@@ -0,0 +1,2 @@
+-- Add custom request headers sent with every delivery of a config to webhook_configs
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS headers JSONB;
@@ -0,0 +1,2 @@
+-- Drop the attempts of retry levels above the fixed columns from webhook_queue
+ALTER TABLE webhook_queue DROP COLUMN IF EXISTS retry_attempts;
@@ -0,0 +1,3 @@
+-- Add the attempts of retry levels above the fixed retry_0 through retry_6 columns to webhook_queue,
+-- recorded for configs whose max_retries raises their limit; existing rows keep their attempts in place
+ALTER TABLE webhook_queue ADD COLUMN IF NOT EXISTS retry_attempts JSONB;
@@ -0,0 +1,2 @@
+-- Drop per-config ambiguous error policy from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS ambiguous_error_policy;
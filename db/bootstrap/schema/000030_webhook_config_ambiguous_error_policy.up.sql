@@ -0,0 +1,4 @@
+-- Add per-config policy for retrying errors that leave delivery unknown, such as a reset after the request was sent
+-- NULL keeps retrying them
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS ambiguous_error_policy VARCHAR(32)
+    CHECK (ambiguous_error_policy IN ('retry', 'fail', 'retry-if-idempotent'));
@@ -0,0 +1,2 @@
+-- Drop per-config first retry delay from webhook_configs
+ALTER TABLE webhook_configs DROP COLUMN IF EXISTS first_retry_delay_ms;
@@ -0,0 +1,2 @@
+-- Add per-config delay before the first retry, replacing the global backoff's first delay; NULL keeps it
+ALTER TABLE webhook_configs ADD COLUMN IF NOT EXISTS first_retry_delay_ms INTEGER CHECK (first_retry_delay_ms >= 1000);
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+// EventStatusResult aggregates the delivery of one event across every config it fanned out to
+type EventStatusResult struct {
+	EventID string `json:"event_id"`
+
+	// AllSucceeded is true once every receiver has the event; AnyFailed once any receiver never will
+	AllSucceeded bool `json:"all_succeeded"`
+	AnyFailed    bool `json:"any_failed"`
+	// Pending is true while any receiver is still being delivered to, so the aggregate can still change
+	Pending bool `json:"pending"`
+
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	InFlight  int `json:"in_flight"` // PENDING or PROCESSING
+
+	Webhooks []EventWebhook `json:"webhooks"`
+}
+
+// EventWebhook is the delivery of an event to one config
+type EventWebhook struct {
+	QueueID        uuid.UUID           `json:"queue_id"`
+	ConfigID       int64               `json:"config_id"`
+	Status         enums.WebhookStatus `json:"status"`
+	RetryCount     int                 `json:"retry_count"`
+	LastHTTPStatus int                 `json:"last_http_status,omitempty"`
+	LastError      string              `json:"last_error,omitempty"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// GetEventStatus aggregates the statuses of every webhook an event fanned out to
+func (s *webhookApplicationServiceImpl) GetEventStatus(ctx context.Context, eventID string) (*EventStatusResult, error) {
+	webhooks, err := s.webhookProcessor.GetWebhooksByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EventStatusResult{
+		EventID:  eventID,
+		Total:    len(webhooks),
+		Webhooks: make([]EventWebhook, 0, len(webhooks)),
+	}
+	for _, webhook := range webhooks {
+		switch webhook.Status {
+		case enums.WebhookStatusCompleted:
+			result.Succeeded++
+		case enums.WebhookStatusFailed:
+			result.Failed++
+		default:
+			result.InFlight++
+		}
+		result.Webhooks = append(result.Webhooks, EventWebhook{
+			QueueID:        webhook.QueueID,
+			ConfigID:       webhook.ConfigID,
+			Status:         webhook.Status,
+			RetryCount:     webhook.RetryCount,
+			LastHTTPStatus: webhook.LastHTTPStatus,
+			LastError:      webhook.LastError,
+			CompletedAt:    webhook.CompletedAt,
+			UpdatedAt:      webhook.UpdatedAt,
+		})
+	}
+	result.AllSucceeded = result.Succeeded == result.Total
+	result.AnyFailed = result.Failed > 0
+	result.Pending = result.InFlight > 0
+
+	return result, nil
+}
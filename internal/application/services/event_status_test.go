@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookApplicationService_GetEventStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	processor := usecases.NewWebhookProcessor(mockQueueRepo, mocks.NewMockWebhookConfigRepository(ctrl),
+		mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+	service := NewWebhookApplicationService(processor, testHealthConfig)
+
+	// delivery returns the webhook of event evt-1 for configID in status
+	delivery := func(configID int64, status enums.WebhookStatus) *entities.WebhookQueue {
+		return &entities.WebhookQueue{
+			QueueID:   uuid.New(),
+			EventID:   "evt-1",
+			ConfigID:  configID,
+			Status:    status,
+			UpdatedAt: time.Now().UTC(),
+		}
+	}
+
+	t.Run("should report all succeeded when every config has the event", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().GetByEventID(ctx, "evt-1").Return([]*entities.WebhookQueue{
+			delivery(1, enums.WebhookStatusCompleted),
+			delivery(2, enums.WebhookStatusCompleted),
+		}, nil)
+
+		result, err := service.GetEventStatus(ctx, "evt-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "evt-1", result.EventID)
+		assert.True(t, result.AllSucceeded)
+		assert.False(t, result.AnyFailed)
+		assert.False(t, result.Pending)
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, 2, result.Succeeded)
+		require.Len(t, result.Webhooks, 2)
+		assert.Equal(t, int64(1), result.Webhooks[0].ConfigID)
+	})
+
+	t.Run("should report a partial failure with per-config detail", func(t *testing.T) {
+		ctx := context.Background()
+		failed := delivery(2, enums.WebhookStatusFailed)
+		failed.RetryCount = 6
+		failed.LastHTTPStatus = 503
+		failed.LastError = "max retries exceeded"
+		mockQueueRepo.EXPECT().GetByEventID(ctx, "evt-1").Return([]*entities.WebhookQueue{
+			delivery(1, enums.WebhookStatusCompleted),
+			failed,
+		}, nil)
+
+		result, err := service.GetEventStatus(ctx, "evt-1")
+
+		require.NoError(t, err)
+		assert.False(t, result.AllSucceeded)
+		assert.True(t, result.AnyFailed)
+		assert.False(t, result.Pending)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, failed.QueueID, result.Webhooks[1].QueueID)
+		assert.Equal(t, enums.WebhookStatusFailed, result.Webhooks[1].Status)
+		assert.Equal(t, 6, result.Webhooks[1].RetryCount)
+		assert.Equal(t, 503, result.Webhooks[1].LastHTTPStatus)
+		assert.Equal(t, "max retries exceeded", result.Webhooks[1].LastError)
+	})
+
+	t.Run("should report pending while any config is still being delivered to", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().GetByEventID(ctx, "evt-1").Return([]*entities.WebhookQueue{
+			delivery(1, enums.WebhookStatusCompleted),
+			delivery(2, enums.WebhookStatusPending),
+			delivery(3, enums.WebhookStatusProcessing),
+		}, nil)
+
+		result, err := service.GetEventStatus(ctx, "evt-1")
+
+		require.NoError(t, err)
+		assert.False(t, result.AllSucceeded)
+		assert.False(t, result.AnyFailed)
+		assert.True(t, result.Pending)
+		assert.Equal(t, 2, result.InFlight)
+	})
+
+	t.Run("should return not found for an unknown event", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().GetByEventID(ctx, "evt-missing").Return(nil, nil)
+
+		result, err := service.GetEventStatus(ctx, "evt-missing")
+
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrEventNotFound))
+	})
+}
@@ -0,0 +1,344 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal\application\usecases\processor_port.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal\application\usecases\processor_port.go -destination internal\application\services\mock_processor_port_test.go -package services
+//
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	usecases "webhook-processor/internal/application/usecases"
+	entities "webhook-processor/internal/domain/entities"
+	enums "webhook-processor/internal/domain/enums"
+	repositories "webhook-processor/internal/domain/repositories"
+	services "webhook-processor/internal/domain/services"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProcessorPort is a mock of ProcessorPort interface.
+type MockProcessorPort struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcessorPortMockRecorder
+	isgomock struct{}
+}
+
+// MockProcessorPortMockRecorder is the mock recorder for MockProcessorPort.
+type MockProcessorPortMockRecorder struct {
+	mock *MockProcessorPort
+}
+
+// NewMockProcessorPort creates a new mock instance.
+func NewMockProcessorPort(ctrl *gomock.Controller) *MockProcessorPort {
+	mock := &MockProcessorPort{ctrl: ctrl}
+	mock.recorder = &MockProcessorPortMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcessorPort) EXPECT() *MockProcessorPortMockRecorder {
+	return m.recorder
+}
+
+// BulkUpdateStatus mocks base method.
+func (m *MockProcessorPort) BulkUpdateStatus(ctx context.Context, filter repositories.WebhookQueueFilter, newStatus enums.WebhookStatus, reason string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateStatus", ctx, filter, newStatus, reason)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateStatus indicates an expected call of BulkUpdateStatus.
+func (mr *MockProcessorPortMockRecorder) BulkUpdateStatus(ctx, filter, newStatus, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateStatus", reflect.TypeOf((*MockProcessorPort)(nil).BulkUpdateStatus), ctx, filter, newStatus, reason)
+}
+
+// ClaimTTL mocks base method.
+func (m *MockProcessorPort) ClaimTTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// ClaimTTL indicates an expected call of ClaimTTL.
+func (mr *MockProcessorPortMockRecorder) ClaimTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTTL", reflect.TypeOf((*MockProcessorPort)(nil).ClaimTTL))
+}
+
+// CreateConfig mocks base method.
+func (m *MockProcessorPort) CreateConfig(ctx context.Context, config *entities.WebhookConfig) (*entities.WebhookConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConfig", ctx, config)
+	ret0, _ := ret[0].(*entities.WebhookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConfig indicates an expected call of CreateConfig.
+func (mr *MockProcessorPortMockRecorder) CreateConfig(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConfig", reflect.TypeOf((*MockProcessorPort)(nil).CreateConfig), ctx, config)
+}
+
+// CreateWebhookEntry mocks base method.
+func (m *MockProcessorPort) CreateWebhookEntry(ctx context.Context, eventType enums.EventType, eventID string, configID int64, metadata map[string]string, payload string) (*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEntry", ctx, eventType, eventID, configID, metadata, payload)
+	ret0, _ := ret[0].(*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookEntry indicates an expected call of CreateWebhookEntry.
+func (mr *MockProcessorPortMockRecorder) CreateWebhookEntry(ctx, eventType, eventID, configID, metadata, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEntry", reflect.TypeOf((*MockProcessorPort)(nil).CreateWebhookEntry), ctx, eventType, eventID, configID, metadata, payload)
+}
+
+// DeleteConfig mocks base method.
+func (m *MockProcessorPort) DeleteConfig(ctx context.Context, configID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConfig", ctx, configID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteConfig indicates an expected call of DeleteConfig.
+func (mr *MockProcessorPortMockRecorder) DeleteConfig(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConfig", reflect.TypeOf((*MockProcessorPort)(nil).DeleteConfig), ctx, configID)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockProcessorPort) DeleteWebhook(ctx context.Context, queueID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, queueID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockProcessorPortMockRecorder) DeleteWebhook(ctx, queueID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockProcessorPort)(nil).DeleteWebhook), ctx, queueID)
+}
+
+// FindOldestOverdueWebhook mocks base method.
+func (m *MockProcessorPort) FindOldestOverdueWebhook(ctx context.Context, asOf time.Time) (*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOldestOverdueWebhook", ctx, asOf)
+	ret0, _ := ret[0].(*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOldestOverdueWebhook indicates an expected call of FindOldestOverdueWebhook.
+func (mr *MockProcessorPortMockRecorder) FindOldestOverdueWebhook(ctx, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOldestOverdueWebhook", reflect.TypeOf((*MockProcessorPort)(nil).FindOldestOverdueWebhook), ctx, asOf)
+}
+
+// ForceFailWebhook mocks base method.
+func (m *MockProcessorPort) ForceFailWebhook(ctx context.Context, queueID uuid.UUID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceFailWebhook", ctx, queueID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceFailWebhook indicates an expected call of ForceFailWebhook.
+func (mr *MockProcessorPortMockRecorder) ForceFailWebhook(ctx, queueID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceFailWebhook", reflect.TypeOf((*MockProcessorPort)(nil).ForceFailWebhook), ctx, queueID, reason)
+}
+
+// GetConfigStats mocks base method.
+func (m *MockProcessorPort) GetConfigStats(ctx context.Context, configID int64) (*entities.WebhookConfigStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigStats", ctx, configID)
+	ret0, _ := ret[0].(*entities.WebhookConfigStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigStats indicates an expected call of GetConfigStats.
+func (mr *MockProcessorPortMockRecorder) GetConfigStats(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigStats", reflect.TypeOf((*MockProcessorPort)(nil).GetConfigStats), ctx, configID)
+}
+
+// GetWebhookByQueueID mocks base method.
+func (m *MockProcessorPort) GetWebhookByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookByQueueID", ctx, queueID)
+	ret0, _ := ret[0].(*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookByQueueID indicates an expected call of GetWebhookByQueueID.
+func (mr *MockProcessorPortMockRecorder) GetWebhookByQueueID(ctx, queueID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookByQueueID", reflect.TypeOf((*MockProcessorPort)(nil).GetWebhookByQueueID), ctx, queueID)
+}
+
+// GetWebhooksByEventID mocks base method.
+func (m *MockProcessorPort) GetWebhooksByEventID(ctx context.Context, eventID string) ([]*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksByEventID", ctx, eventID)
+	ret0, _ := ret[0].([]*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooksByEventID indicates an expected call of GetWebhooksByEventID.
+func (mr *MockProcessorPortMockRecorder) GetWebhooksByEventID(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksByEventID", reflect.TypeOf((*MockProcessorPort)(nil).GetWebhooksByEventID), ctx, eventID)
+}
+
+// ListConfigs mocks base method.
+func (m *MockProcessorPort) ListConfigs(ctx context.Context) ([]*entities.WebhookConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConfigs", ctx)
+	ret0, _ := ret[0].([]*entities.WebhookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConfigs indicates an expected call of ListConfigs.
+func (mr *MockProcessorPortMockRecorder) ListConfigs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConfigs", reflect.TypeOf((*MockProcessorPort)(nil).ListConfigs), ctx)
+}
+
+// ListProcessingWebhooks mocks base method.
+func (m *MockProcessorPort) ListProcessingWebhooks(ctx context.Context, limit int) ([]usecases.ProcessingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProcessingWebhooks", ctx, limit)
+	ret0, _ := ret[0].([]usecases.ProcessingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProcessingWebhooks indicates an expected call of ListProcessingWebhooks.
+func (mr *MockProcessorPortMockRecorder) ListProcessingWebhooks(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProcessingWebhooks", reflect.TypeOf((*MockProcessorPort)(nil).ListProcessingWebhooks), ctx, limit)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockProcessorPort) ListWebhooks(ctx context.Context, filter repositories.WebhookQueueFilter, limit, offset int) ([]*entities.WebhookQueue, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*entities.WebhookQueue)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockProcessorPortMockRecorder) ListWebhooks(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockProcessorPort)(nil).ListWebhooks), ctx, filter, limit, offset)
+}
+
+// ListWorkerHeartbeats mocks base method.
+func (m *MockProcessorPort) ListWorkerHeartbeats(ctx context.Context) ([]*entities.WorkerHeartbeat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkerHeartbeats", ctx)
+	ret0, _ := ret[0].([]*entities.WorkerHeartbeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkerHeartbeats indicates an expected call of ListWorkerHeartbeats.
+func (mr *MockProcessorPortMockRecorder) ListWorkerHeartbeats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkerHeartbeats", reflect.TypeOf((*MockProcessorPort)(nil).ListWorkerHeartbeats), ctx)
+}
+
+// PingConfig mocks base method.
+func (m *MockProcessorPort) PingConfig(ctx context.Context, config *entities.WebhookConfig) (*services.PingResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingConfig", ctx, config)
+	ret0, _ := ret[0].(*services.PingResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PingConfig indicates an expected call of PingConfig.
+func (mr *MockProcessorPortMockRecorder) PingConfig(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingConfig", reflect.TypeOf((*MockProcessorPort)(nil).PingConfig), ctx, config)
+}
+
+// ProcessByQueueID mocks base method.
+func (m *MockProcessorPort) ProcessByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessByQueueID", ctx, queueID)
+	ret0, _ := ret[0].(*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessByQueueID indicates an expected call of ProcessByQueueID.
+func (mr *MockProcessorPortMockRecorder) ProcessByQueueID(ctx, queueID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessByQueueID", reflect.TypeOf((*MockProcessorPort)(nil).ProcessByQueueID), ctx, queueID)
+}
+
+// RemainingRetrySchedule mocks base method.
+func (m *MockProcessorPort) RemainingRetrySchedule(webhook *entities.WebhookQueue) []usecases.ProjectedRetry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemainingRetrySchedule", webhook)
+	ret0, _ := ret[0].([]usecases.ProjectedRetry)
+	return ret0
+}
+
+// RemainingRetrySchedule indicates an expected call of RemainingRetrySchedule.
+func (mr *MockProcessorPortMockRecorder) RemainingRetrySchedule(webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemainingRetrySchedule", reflect.TypeOf((*MockProcessorPort)(nil).RemainingRetrySchedule), webhook)
+}
+
+// ReplayTo mocks base method.
+func (m *MockProcessorPort) ReplayTo(ctx context.Context, filter repositories.WebhookQueueFilter, overrideURL string) ([]usecases.ReplayResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayTo", ctx, filter, overrideURL)
+	ret0, _ := ret[0].([]usecases.ReplayResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayTo indicates an expected call of ReplayTo.
+func (mr *MockProcessorPortMockRecorder) ReplayTo(ctx, filter, overrideURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayTo", reflect.TypeOf((*MockProcessorPort)(nil).ReplayTo), ctx, filter, overrideURL)
+}
+
+// UpdateConfig mocks base method.
+func (m *MockProcessorPort) UpdateConfig(ctx context.Context, config *entities.WebhookConfig) (*entities.WebhookConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConfig", ctx, config)
+	ret0, _ := ret[0].(*entities.WebhookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateConfig indicates an expected call of UpdateConfig.
+func (mr *MockProcessorPortMockRecorder) UpdateConfig(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfig", reflect.TypeOf((*MockProcessorPort)(nil).UpdateConfig), ctx, config)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxListedProcessingWebhooks bounds one processing listing; more in flight than this is itself the finding
+const maxListedProcessingWebhooks = 1000
+
+// ProcessingWebhooksResult lists the webhooks currently in PROCESSING, for diagnosing hung deliveries
+type ProcessingWebhooksResult struct {
+	Timestamp  time.Time           `json:"timestamp"`
+	ClaimTTLMs int64               `json:"claim_ttl_ms"` // Claims held longer than this are overdue
+	Count      int                 `json:"count"`
+	Overdue    int                 `json:"overdue"`
+	Webhooks   []ProcessingWebhook `json:"webhooks"`
+}
+
+// ProcessingWebhook is one webhook in PROCESSING and the worker holding it
+type ProcessingWebhook struct {
+	QueueID        uuid.UUID  `json:"queue_id"`
+	ConfigID       int64      `json:"config_id"`
+	WebhookURL     string     `json:"webhook_url"`
+	RetryLevel     int        `json:"retry_level"`
+	ClaimedBy      string     `json:"claimed_by,omitempty"`
+	ClaimedAt      time.Time  `json:"claimed_at"`
+	ClaimExpiresAt *time.Time `json:"claim_expires_at,omitempty"`
+	ClaimAgeMs     int64      `json:"claim_age_ms"`
+
+	// Overdue marks a webhook held past the claim TTL, which the claim reaper will return to PENDING
+	Overdue bool `json:"overdue"`
+}
+
+// GetProcessingWebhooks lists the webhooks in PROCESSING, oldest first, flagging overdue claims
+func (s *webhookApplicationServiceImpl) GetProcessingWebhooks(ctx context.Context) (*ProcessingWebhooksResult, error) {
+	processing, err := s.webhookProcessor.ListProcessingWebhooks(ctx, maxListedProcessingWebhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProcessingWebhooksResult{
+		Timestamp:  s.now(),
+		ClaimTTLMs: s.webhookProcessor.ClaimTTL().Milliseconds(),
+		Count:      len(processing),
+		Webhooks:   make([]ProcessingWebhook, 0, len(processing)),
+	}
+	for _, p := range processing {
+		webhook := ProcessingWebhook{
+			QueueID:        p.Webhook.QueueID,
+			ConfigID:       p.Webhook.ConfigID,
+			WebhookURL:     redactURL(p.Webhook.WebhookURL),
+			RetryLevel:     p.Webhook.CurrentRetryLevel(),
+			ClaimedAt:      p.ClaimedAt,
+			ClaimExpiresAt: p.Webhook.ClaimExpiresAt,
+			ClaimAgeMs:     p.ClaimAge.Milliseconds(),
+			Overdue:        p.Overdue,
+		}
+		if p.Webhook.ClaimedBy != nil {
+			webhook.ClaimedBy = *p.Webhook.ClaimedBy
+		}
+		if p.Overdue {
+			result.Overdue++
+		}
+		result.Webhooks = append(result.Webhooks, webhook)
+	}
+
+	return result, nil
+}
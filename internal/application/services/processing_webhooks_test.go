@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookApplicationService_GetProcessingWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	processor := usecases.NewWebhookProcessor(mockQueueRepo, mocks.NewMockWebhookConfigRepository(ctrl),
+		mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+	processor.SetClaimTTL(5 * time.Minute)
+
+	service := NewWebhookApplicationService(processor, testHealthConfig)
+
+	// claimed returns a PROCESSING webhook claimed by workerID roughly age ago
+	claimed := func(workerID string, age time.Duration) *entities.WebhookQueue {
+		expiresAt := time.Now().UTC().Add(-age).Add(5 * time.Minute)
+		return &entities.WebhookQueue{
+			QueueID:        uuid.New(),
+			ConfigID:       7,
+			WebhookURL:     "https://hooks.example.com/in?token=secret",
+			Status:         enums.WebhookStatusProcessing,
+			RetryCount:     2,
+			ClaimedBy:      &workerID,
+			ClaimExpiresAt: &expiresAt,
+		}
+	}
+
+	t.Run("should list processing webhooks and count the overdue ones", func(t *testing.T) {
+		ctx := context.Background()
+		fresh := claimed("retry-2-a", 30*time.Second)
+		hung := claimed("retry-2-b", time.Hour)
+		mockQueueRepo.EXPECT().List(ctx, gomock.Any(), maxListedProcessingWebhooks).
+			Return([]*entities.WebhookQueue{fresh, hung}, nil)
+
+		result, err := service.GetProcessingWebhooks(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(300000), result.ClaimTTLMs)
+		assert.Equal(t, 2, result.Count)
+		assert.Equal(t, 1, result.Overdue)
+
+		require.Len(t, result.Webhooks, 2)
+		assert.Equal(t, fresh.QueueID, result.Webhooks[0].QueueID)
+		assert.Equal(t, "retry-2-a", result.Webhooks[0].ClaimedBy)
+		assert.Equal(t, 2, result.Webhooks[0].RetryLevel)
+		assert.False(t, result.Webhooks[0].Overdue)
+		assert.NotContains(t, result.Webhooks[0].WebhookURL, "secret")
+
+		assert.Equal(t, "retry-2-b", result.Webhooks[1].ClaimedBy)
+		assert.True(t, result.Webhooks[1].Overdue)
+		assert.GreaterOrEqual(t, result.Webhooks[1].ClaimAgeMs, time.Hour.Milliseconds())
+	})
+
+	t.Run("should return an empty list when nothing is processing", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().List(ctx, gomock.Any(), maxListedProcessingWebhooks).Return(nil, nil)
+
+		result, err := service.GetProcessingWebhooks(ctx)
+
+		require.NoError(t, err)
+		assert.NotNil(t, result.Webhooks)
+		assert.Empty(t, result.Webhooks)
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().List(ctx, gomock.Any(), maxListedProcessingWebhooks).Return(nil, errors.New("connection refused"))
+
+		_, err := service.GetProcessingWebhooks(ctx)
+
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
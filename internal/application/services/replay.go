@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+)
+
+// ReplayToCommand represents a command to re-send matching webhooks to a different URL
+type ReplayToCommand struct {
+	ConfigID      *int64                `json:"config_id,omitempty"`
+	Statuses      []enums.WebhookStatus `json:"statuses,omitempty"`
+	CreatedBefore *time.Time            `json:"created_before,omitempty"`
+	Metadata      map[string]string     `json:"metadata,omitempty"`
+	URL           string                `json:"url" validate:"required"`
+}
+
+// ReplayToResult represents the outcome of replaying webhooks to a different URL
+type ReplayToResult struct {
+	Success   bool                    `json:"success"`
+	Message   string                  `json:"message"`
+	Replayed  int                     `json:"replayed"`
+	Delivered int                     `json:"delivered"`
+	Failed    int                     `json:"failed"`
+	Results   []usecases.ReplayResult `json:"results"`
+}
+
+// ReplayTo re-sends the webhooks matching the command's filter to its URL without touching the originals
+func (s *webhookApplicationServiceImpl) ReplayTo(ctx context.Context, cmd ReplayToCommand) (*ReplayToResult, error) {
+	filter := repositories.WebhookQueueFilter{
+		ConfigID:      cmd.ConfigID,
+		Statuses:      cmd.Statuses,
+		CreatedBefore: cmd.CreatedBefore,
+		Metadata:      cmd.Metadata,
+	}
+
+	results, err := s.webhookProcessor.ReplayTo(ctx, filter, cmd.URL)
+	if err != nil {
+		return &ReplayToResult{
+			Success: false,
+			Message: "Failed to replay webhooks: " + err.Error(),
+		}, err
+	}
+
+	result := &ReplayToResult{
+		Success:  true,
+		Replayed: len(results),
+		Results:  results,
+	}
+	for _, r := range results {
+		if r.Success {
+			result.Delivered++
+		} else {
+			result.Failed++
+		}
+	}
+	result.Message = fmt.Sprintf("Replayed %d webhooks: %d delivered, %d failed", result.Replayed, result.Delivered, result.Failed)
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	domainservices "webhook-processor/internal/domain/services"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookApplicationService_ReplayTo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+	service := NewWebhookApplicationService(processor, testHealthConfig)
+
+	t.Run("should count delivered and failed replays", func(t *testing.T) {
+		ctx := context.Background()
+		configID := int64(3)
+		mockQueueRepo.EXPECT().List(ctx, gomock.Any(), gomock.Any()).Return([]*entities.WebhookQueue{
+			{QueueID: uuid.New(), ConfigID: configID, Status: enums.WebhookStatusCompleted},
+			{QueueID: uuid.New(), ConfigID: configID, Status: enums.WebhookStatusCompleted},
+		}, nil)
+		mockConfigRepo.EXPECT().GetByID(ctx, configID).Return(nil, nil)
+		gomock.InOrder(
+			mockWebhookService.EXPECT().SendWebhook(ctx, gomock.Any()).Return(&domainservices.WebhookResponse{StatusCode: 204}, nil),
+			mockWebhookService.EXPECT().SendWebhook(ctx, gomock.Any()).Return(&domainservices.WebhookResponse{StatusCode: 404}, nil),
+		)
+
+		result, err := service.ReplayTo(ctx, ReplayToCommand{ConfigID: &configID, URL: "https://new.example.com/in"})
+
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, 2, result.Replayed)
+		assert.Equal(t, 1, result.Delivered)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, "Replayed 2 webhooks: 1 delivered, 1 failed", result.Message)
+	})
+
+	t.Run("should reject a replay without a filter", func(t *testing.T) {
+		result, err := service.ReplayTo(context.Background(), ReplayToCommand{URL: "https://new.example.com/in"})
+
+		assert.ErrorIs(t, err, ErrInvalidReplay)
+		assert.False(t, result.Success)
+	})
+}
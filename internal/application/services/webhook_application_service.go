@@ -2,10 +2,16 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+
 	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
 	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
 )
 
 // WebhookApplicationService defines the application service interface for webhook operations
@@ -16,15 +22,108 @@ type WebhookApplicationService interface {
 
 	// GetHealth returns service health status
 	GetHealth(ctx context.Context) (*HealthResult, error)
+
+	// BulkUpdateStatus moves all webhooks matching a filter to a new status (admin operation)
+	BulkUpdateStatus(ctx context.Context, cmd BulkUpdateStatusCommand) (*BulkUpdateStatusResult, error)
+
+	// GetConfigStats returns the delivery statistics rollup for a webhook config
+	GetConfigStats(ctx context.Context, configID int64) (*ConfigStatsResult, error)
+
+	// GetEventStatus aggregates the delivery of an event across every config it fanned out to
+	GetEventStatus(ctx context.Context, eventID string) (*EventStatusResult, error)
+
+	// GetWebhookStatus returns a webhook's current status, attempt timings and remaining retries
+	GetWebhookStatus(ctx context.Context, queueID uuid.UUID) (*WebhookStatusResult, error)
+
+	// ListWebhooks returns one page of the webhooks matching a query, newest first
+	ListWebhooks(ctx context.Context, query ListWebhooksQuery) (*WebhookListResult, error)
+
+	// ExportWebhook returns a webhook's full lifecycle as one document with secrets redacted
+	ExportWebhook(ctx context.Context, queueID uuid.UUID) (*WebhookExportResult, error)
+
+	// ForceFailWebhook marks a pending or in-flight webhook as failed with an operator-supplied reason (admin operation)
+	ForceFailWebhook(ctx context.Context, cmd ForceFailWebhookCommand) (*ForceFailWebhookResult, error)
+
+	// ProcessWebhookNow runs one delivery attempt for a pending webhook immediately (admin operation)
+	ProcessWebhookNow(ctx context.Context, queueID uuid.UUID) (*ProcessWebhookResult, error)
+
+	// DeleteWebhook permanently erases a webhook and its stored responses (admin operation)
+	DeleteWebhook(ctx context.Context, queueID uuid.UUID) (*DeleteWebhookResult, error)
+
+	// GetWorkerCluster returns the workers alive across instances, from the heartbeats they record
+	GetWorkerCluster(ctx context.Context) (*WorkerClusterResult, error)
+
+	// GetProcessingWebhooks lists the webhooks in PROCESSING and how long they have been held (admin operation)
+	GetProcessingWebhooks(ctx context.Context) (*ProcessingWebhooksResult, error)
+
+	// ReplayTo re-sends matching webhooks once to a different URL without touching them (admin operation)
+	ReplayTo(ctx context.Context, cmd ReplayToCommand) (*ReplayToResult, error)
+
+	// CreateConfig registers a new webhook config (admin operation)
+	CreateConfig(ctx context.Context, cmd SaveConfigCommand) (*ConfigResult, error)
+
+	// UpdateConfig replaces the managed fields of a webhook config (admin operation)
+	UpdateConfig(ctx context.Context, cmd SaveConfigCommand) (*ConfigResult, error)
+
+	// DeleteConfig soft-deletes a webhook config (admin operation)
+	DeleteConfig(ctx context.Context, configID int64) (*DeleteConfigResult, error)
+
+	// ListConfigs returns every webhook config that has not been deleted (admin operation)
+	ListConfigs(ctx context.Context) (*ConfigListResult, error)
 }
 
+// ErrInvalidBulkUpdate is returned when a bulk status update is rejected as invalid
+var ErrInvalidBulkUpdate = usecases.ErrInvalidBulkUpdate
+
+// ErrConfigNotFound is returned when the requested webhook config does not exist
+var ErrConfigNotFound = usecases.ErrConfigNotFound
+
+// ErrEventNotFound is returned when no webhook was created for the requested event ID
+var ErrEventNotFound = usecases.ErrEventNotFound
+
+// ErrWebhookNotFound is returned when no webhook has the requested queue ID
+var ErrWebhookNotFound = usecases.ErrWebhookNotFound
+
+// ErrInvalidForceFail is returned when a force-fail request is rejected as invalid
+var ErrInvalidForceFail = usecases.ErrInvalidForceFail
+
+// ErrInvalidReplay is returned when a replay request is rejected as invalid
+var ErrInvalidReplay = usecases.ErrInvalidReplay
+
+// ErrInvalidWebhookURL is returned when a create is rejected because its config's URL cannot be delivered to
+var ErrInvalidWebhookURL = usecases.ErrInvalidWebhookURL
+
+// ErrWebhookStatusConflict is returned when a webhook's current status does not allow the requested change
+var ErrWebhookStatusConflict = usecases.ErrWebhookStatusConflict
+
+// BacklogFullError is returned when a create is rejected because the pending backlog is too deep
+type BacklogFullError = usecases.BacklogFullError
+
 // Commands (Input DTOs)
 
 // CreateWebhookCommand represents a command to create a webhook
 type CreateWebhookCommand struct {
-	EventType enums.EventType `json:"event_type" validate:"required"`
-	EventID   string          `json:"event_id"`
-	ConfigID  int64           `json:"config_id" validate:"required,min=1"`
+	EventType enums.EventType   `json:"event_type" validate:"required"`
+	EventID   string            `json:"event_id"`
+	ConfigID  int64             `json:"config_id" validate:"omitempty,min=1"` // Zero uses the event type's default config
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Payload   json.RawMessage   `json:"payload,omitempty"` // Request body for configs that send one
+}
+
+// BulkUpdateStatusCommand represents a command to move matching webhooks to a new status
+type BulkUpdateStatusCommand struct {
+	ConfigID      *int64                `json:"config_id,omitempty"`
+	Statuses      []enums.WebhookStatus `json:"statuses,omitempty"`
+	CreatedBefore *time.Time            `json:"created_before,omitempty"`
+	Metadata      map[string]string     `json:"metadata,omitempty"`
+	Status        enums.WebhookStatus   `json:"status" validate:"required"`
+	Reason        string                `json:"reason" validate:"required"`
+}
+
+// ForceFailWebhookCommand represents a command to mark one webhook as failed
+type ForceFailWebhookCommand struct {
+	QueueID uuid.UUID `json:"queue_id" validate:"required"`
+	Reason  string    `json:"reason" validate:"required"`
 }
 
 // Results (Output DTOs)
@@ -44,19 +143,68 @@ type HealthResult struct {
 	Timestamp    time.Time         `json:"timestamp"`
 	Dependencies map[string]string `json:"dependencies"`
 	Uptime       time.Duration     `json:"uptime"`
+	BacklogAge   time.Duration     `json:"backlog_age"` // How long the oldest due pending webhook has waited
+}
+
+// BulkUpdateStatusResult represents the result of a bulk status update
+type BulkUpdateStatusResult struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Updated int64  `json:"updated"`
+}
+
+// ForceFailWebhookResult represents the result of force-failing a webhook
+type ForceFailWebhookResult struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	QueueID string              `json:"queue_id"`
+	Status  enums.WebhookStatus `json:"status,omitempty"`
+}
+
+// ProcessWebhookResult represents the state of a webhook after an on-demand delivery attempt
+type ProcessWebhookResult struct {
+	Success        bool                `json:"success"`
+	Message        string              `json:"message"`
+	QueueID        string              `json:"queue_id"`
+	Status         enums.WebhookStatus `json:"status,omitempty"`
+	RetryCount     int                 `json:"retry_count"`
+	NextRetryAt    *time.Time          `json:"next_retry_at,omitempty"` // Set while the webhook is pending another attempt
+	LastHTTPStatus int                 `json:"last_http_status,omitempty"`
+	LastError      string              `json:"last_error,omitempty"`
+}
+
+// DeleteWebhookResult represents the result of permanently deleting a webhook
+type DeleteWebhookResult struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	QueueID string `json:"queue_id"`
+}
+
+// ConfigStatsResult represents the delivery statistics of a webhook config
+type ConfigStatsResult struct {
+	ConfigID       int64      `json:"config_id"`
+	TotalDelivered int64      `json:"total_delivered"`
+	TotalFailed    int64      `json:"total_failed"`
+	LastSuccessAt  *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt  *time.Time `json:"last_failure_at,omitempty"`
+	SuccessRate    float64    `json:"success_rate"`
 }
 
 // webhookApplicationServiceImpl implements WebhookApplicationService
 type webhookApplicationServiceImpl struct {
-	webhookProcessor *usecases.WebhookProcessor
+	webhookProcessor usecases.ProcessorPort
+	healthConfig     config.HealthConfig
 	startTime        time.Time
+	now              func() time.Time
 }
 
 // NewWebhookApplicationService creates a new webhook application service
-func NewWebhookApplicationService(webhookProcessor *usecases.WebhookProcessor) WebhookApplicationService {
+func NewWebhookApplicationService(webhookProcessor usecases.ProcessorPort, healthConfig config.HealthConfig) WebhookApplicationService {
 	return &webhookApplicationServiceImpl{
 		webhookProcessor: webhookProcessor,
+		healthConfig:     healthConfig,
 		startTime:        time.Now().UTC(),
+		now:              func() time.Time { return time.Now().UTC() },
 	}
 }
 
@@ -71,7 +219,7 @@ func (s *webhookApplicationServiceImpl) CreateWebhook(ctx context.Context, cmd C
 	}
 
 	// Call use case
-	err := s.webhookProcessor.CreateWebhookEntry(ctx, cmd.EventType, cmd.EventID, cmd.ConfigID)
+	webhook, err := s.webhookProcessor.CreateWebhookEntry(ctx, cmd.EventType, cmd.EventID, cmd.ConfigID, cmd.Metadata, string(cmd.Payload))
 	if err != nil {
 		return &CreateWebhookResult{
 			Success: false,
@@ -82,20 +230,145 @@ func (s *webhookApplicationServiceImpl) CreateWebhook(ctx context.Context, cmd C
 	return &CreateWebhookResult{
 		Success:   true,
 		Message:   "Webhook created successfully",
-		CreatedAt: time.Now().UTC(),
+		QueueID:   webhook.QueueID.String(),
+		CreatedAt: webhook.CreatedAt,
 	}, nil
 }
 
 // GetHealth returns service health status
+// Status is "degraded" when the backlog has been waiting longer than the configured threshold
 func (s *webhookApplicationServiceImpl) GetHealth(ctx context.Context) (*HealthResult, error) {
-	return &HealthResult{
+	now := s.now()
+	result := &HealthResult{
 		Status:    "healthy",
 		Version:   "1.0.0",
-		Timestamp: time.Now().UTC(),
+		Timestamp: now,
 		Dependencies: map[string]string{
 			"database": "connected",
 			"workers":  "running",
+			"backlog":  "ok",
 		},
 		Uptime: time.Since(s.startTime),
+	}
+
+	oldest, err := s.webhookProcessor.FindOldestOverdueWebhook(ctx, now)
+	if err != nil {
+		result.Status = "unhealthy"
+		result.Dependencies["database"] = "error"
+		result.Dependencies["backlog"] = "unknown"
+		return result, nil
+	}
+
+	if oldest != nil {
+		result.BacklogAge = now.Sub(oldest.NextRetryAt)
+		if s.healthConfig.BacklogDegradedAge > 0 && result.BacklogAge > s.healthConfig.BacklogDegradedAge {
+			result.Status = "degraded"
+			result.Dependencies["backlog"] = "degraded"
+		}
+	}
+
+	return result, nil
+}
+
+// BulkUpdateStatus moves all webhooks matching the command's filter to the requested status
+func (s *webhookApplicationServiceImpl) BulkUpdateStatus(ctx context.Context, cmd BulkUpdateStatusCommand) (*BulkUpdateStatusResult, error) {
+	filter := repositories.WebhookQueueFilter{
+		ConfigID:      cmd.ConfigID,
+		Statuses:      cmd.Statuses,
+		CreatedBefore: cmd.CreatedBefore,
+		Metadata:      cmd.Metadata,
+	}
+
+	updated, err := s.webhookProcessor.BulkUpdateStatus(ctx, filter, cmd.Status, cmd.Reason)
+	if err != nil {
+		return &BulkUpdateStatusResult{
+			Success: false,
+			Message: "Failed to update webhook status: " + err.Error(),
+		}, err
+	}
+
+	return &BulkUpdateStatusResult{
+		Success: true,
+		Message: fmt.Sprintf("Updated %d webhooks to %s", updated, cmd.Status),
+		Updated: updated,
+	}, nil
+}
+
+// ForceFailWebhook marks the command's webhook as failed, provided it has not finished
+func (s *webhookApplicationServiceImpl) ForceFailWebhook(ctx context.Context, cmd ForceFailWebhookCommand) (*ForceFailWebhookResult, error) {
+	if err := s.webhookProcessor.ForceFailWebhook(ctx, cmd.QueueID, cmd.Reason); err != nil {
+		return &ForceFailWebhookResult{
+			Success: false,
+			Message: "Failed to force-fail webhook: " + err.Error(),
+			QueueID: cmd.QueueID.String(),
+		}, err
+	}
+
+	return &ForceFailWebhookResult{
+		Success: true,
+		Message: "Webhook marked as failed",
+		QueueID: cmd.QueueID.String(),
+		Status:  enums.WebhookStatusFailed,
+	}, nil
+}
+
+// ProcessWebhookNow claims a pending webhook outside the poll schedule and reports the attempt's outcome
+func (s *webhookApplicationServiceImpl) ProcessWebhookNow(ctx context.Context, queueID uuid.UUID) (*ProcessWebhookResult, error) {
+	webhook, err := s.webhookProcessor.ProcessByQueueID(ctx, queueID)
+	if err != nil {
+		return &ProcessWebhookResult{
+			Success: false,
+			Message: "Failed to process webhook: " + err.Error(),
+			QueueID: queueID.String(),
+		}, err
+	}
+
+	result := &ProcessWebhookResult{
+		Success:        true,
+		Message:        fmt.Sprintf("Webhook processed, now %s", webhook.Status),
+		QueueID:        queueID.String(),
+		Status:         webhook.Status,
+		RetryCount:     webhook.RetryCount,
+		LastHTTPStatus: webhook.LastHTTPStatus,
+		LastError:      webhook.LastError,
+	}
+	if webhook.Status == enums.WebhookStatusPending {
+		nextRetryAt := webhook.NextRetryAt
+		result.NextRetryAt = &nextRetryAt
+	}
+	return result, nil
+}
+
+// DeleteWebhook permanently erases a webhook unless an attempt is in flight
+func (s *webhookApplicationServiceImpl) DeleteWebhook(ctx context.Context, queueID uuid.UUID) (*DeleteWebhookResult, error) {
+	if err := s.webhookProcessor.DeleteWebhook(ctx, queueID); err != nil {
+		return &DeleteWebhookResult{
+			Success: false,
+			Message: "Failed to delete webhook: " + err.Error(),
+			QueueID: queueID.String(),
+		}, err
+	}
+
+	return &DeleteWebhookResult{
+		Success: true,
+		Message: "Webhook permanently deleted",
+		QueueID: queueID.String(),
+	}, nil
+}
+
+// GetConfigStats returns the delivery statistics rollup for a webhook config
+func (s *webhookApplicationServiceImpl) GetConfigStats(ctx context.Context, configID int64) (*ConfigStatsResult, error) {
+	stats, err := s.webhookProcessor.GetConfigStats(ctx, configID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConfigStatsResult{
+		ConfigID:       stats.ConfigID,
+		TotalDelivered: stats.TotalDelivered,
+		TotalFailed:    stats.TotalFailed,
+		LastSuccessAt:  stats.LastSuccessAt,
+		LastFailureAt:  stats.LastFailureAt,
+		SuccessRate:    stats.SuccessRate,
 	}, nil
 }
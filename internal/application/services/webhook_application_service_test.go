@@ -2,20 +2,26 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/go-kit/log"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
 	"webhook-processor/internal/domain/entities"
 	"webhook-processor/internal/domain/enums"
 	"webhook-processor/internal/mocks"
 )
 
+// testHealthConfig is the health configuration shared by the application service tests
+var testHealthConfig = config.HealthConfig{BacklogDegradedAge: 15 * time.Minute}
+
 func TestWebhookApplicationService_CreateWebhook(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -26,7 +32,7 @@ func TestWebhookApplicationService_CreateWebhook(t *testing.T) {
 	logger := log.NewNopLogger()
 
 	processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
-	service := NewWebhookApplicationService(processor)
+	service := NewWebhookApplicationService(processor, testHealthConfig)
 
 	t.Run("should create webhook successfully", func(t *testing.T) {
 		ctx := context.Background()
@@ -47,9 +53,14 @@ func TestWebhookApplicationService_CreateWebhook(t *testing.T) {
 			}, nil).
 			Times(1)
 
+		queueID := uuid.New()
 		mockQueueRepo.EXPECT().
 			Create(ctx, gomock.Any()).
-			Return(nil).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
+				// Simulate the BeforeCreate hook assigning the queue ID
+				webhook.QueueID = queueID
+				return nil
+			}).
 			Times(1)
 
 		// Execute
@@ -61,7 +72,7 @@ func TestWebhookApplicationService_CreateWebhook(t *testing.T) {
 		assert.True(t, result.Success)
 		assert.Equal(t, "Webhook created successfully", result.Message)
 		assert.False(t, result.CreatedAt.IsZero())
-		assert.Empty(t, result.QueueID) // QueueID is not returned by current implementation
+		assert.Equal(t, queueID.String(), result.QueueID)
 	})
 
 	t.Run("should return error for invalid event type", func(t *testing.T) {
@@ -146,18 +157,17 @@ func TestWebhookApplicationService_CreateWebhook(t *testing.T) {
 		assert.True(t, result.Success)
 	})
 
-	t.Run("should handle zero config ID", func(t *testing.T) {
+	t.Run("should handle zero config ID without a default config", func(t *testing.T) {
 		ctx := context.Background()
 		cmd := CreateWebhookCommand{
 			EventType: enums.EventTypeCredit,
 			EventID:   "test-event-123",
-			ConfigID:  0, // Zero config ID
+			ConfigID:  0, // Zero config ID resolves to the event type's default
 		}
 
-		// Mock the processor to return error for zero config ID
 		mockConfigRepo.EXPECT().
-			GetByID(ctx, int64(0)).
-			Return(nil, nil). // No config found
+			GetDefaultForEventType(ctx, enums.EventTypeCredit).
+			Return(nil, nil). // No default config
 			Times(1)
 
 		// Execute
@@ -181,7 +191,9 @@ func TestWebhookApplicationService_GetHealth(t *testing.T) {
 	logger := log.NewNopLogger()
 
 	processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
-	service := NewWebhookApplicationService(processor)
+	service := NewWebhookApplicationService(processor, testHealthConfig)
+
+	mockQueueRepo.EXPECT().FindOldestOverdue(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 
 	t.Run("should return health status", func(t *testing.T) {
 		ctx := context.Background()
@@ -228,6 +240,143 @@ func TestWebhookApplicationService_GetHealth(t *testing.T) {
 	})
 }
 
+func TestWebhookApplicationService_GetHealth_Backlog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+	service := NewWebhookApplicationService(processor, testHealthConfig).(*webhookApplicationServiceImpl)
+
+	// Fake clock so backlog age is deterministic
+	fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return fixedNow }
+
+	t.Run("should report degraded when the oldest pending webhook exceeds the threshold", func(t *testing.T) {
+		ctx := context.Background()
+		oldPending := &entities.WebhookQueue{
+			ID:          1,
+			Status:      enums.WebhookStatusPending,
+			NextRetryAt: fixedNow.Add(-time.Hour),
+		}
+
+		mockQueueRepo.EXPECT().FindOldestOverdue(ctx, fixedNow).Return(oldPending, nil).Times(1)
+
+		result, err := service.GetHealth(ctx)
+
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, "degraded", result.Status)
+		assert.Equal(t, "degraded", result.Dependencies["backlog"])
+		assert.Equal(t, time.Hour, result.BacklogAge)
+	})
+
+	t.Run("should stay healthy when the backlog is within the threshold", func(t *testing.T) {
+		ctx := context.Background()
+		recentPending := &entities.WebhookQueue{
+			ID:          2,
+			Status:      enums.WebhookStatusPending,
+			NextRetryAt: fixedNow.Add(-time.Minute),
+		}
+
+		mockQueueRepo.EXPECT().FindOldestOverdue(ctx, fixedNow).Return(recentPending, nil).Times(1)
+
+		result, err := service.GetHealth(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "healthy", result.Status)
+		assert.Equal(t, "ok", result.Dependencies["backlog"])
+		assert.Equal(t, time.Minute, result.BacklogAge)
+	})
+
+	t.Run("should report zero backlog age when nothing is overdue", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().FindOldestOverdue(ctx, fixedNow).Return(nil, nil).Times(1)
+
+		result, err := service.GetHealth(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "healthy", result.Status)
+		assert.Equal(t, time.Duration(0), result.BacklogAge)
+	})
+
+	t.Run("should report unhealthy when the backlog query fails", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().FindOldestOverdue(ctx, fixedNow).Return(nil, errors.New("connection refused")).Times(1)
+
+		result, err := service.GetHealth(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "unhealthy", result.Status)
+		assert.Equal(t, "error", result.Dependencies["database"])
+	})
+}
+
+func TestWebhookApplicationService_ProcessWebhookNow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProcessor := NewMockProcessorPort(ctrl)
+	service := NewWebhookApplicationService(mockProcessor, testHealthConfig)
+
+	t.Run("should report the next retry of a webhook still pending after the attempt", func(t *testing.T) {
+		ctx := context.Background()
+		queueID := uuid.New()
+		nextRetryAt := time.Now().UTC().Add(time.Minute)
+		mockProcessor.EXPECT().ProcessByQueueID(ctx, queueID).Return(&entities.WebhookQueue{
+			QueueID:        queueID,
+			Status:         enums.WebhookStatusPending,
+			RetryCount:     1,
+			NextRetryAt:    nextRetryAt,
+			LastHTTPStatus: 503,
+		}, nil)
+
+		result, err := service.ProcessWebhookNow(ctx, queueID)
+
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, enums.WebhookStatusPending, result.Status)
+		assert.Equal(t, 1, result.RetryCount)
+		assert.Equal(t, 503, result.LastHTTPStatus)
+		require.NotNil(t, result.NextRetryAt)
+		assert.Equal(t, nextRetryAt, *result.NextRetryAt)
+	})
+
+	t.Run("should not report a next retry for a completed webhook", func(t *testing.T) {
+		ctx := context.Background()
+		queueID := uuid.New()
+		mockProcessor.EXPECT().ProcessByQueueID(ctx, queueID).Return(&entities.WebhookQueue{
+			QueueID:     queueID,
+			Status:      enums.WebhookStatusCompleted,
+			NextRetryAt: time.Now().UTC(),
+		}, nil)
+
+		result, err := service.ProcessWebhookNow(ctx, queueID)
+
+		require.NoError(t, err)
+		assert.Equal(t, enums.WebhookStatusCompleted, result.Status)
+		assert.Nil(t, result.NextRetryAt)
+	})
+
+	t.Run("should return the processor's conflict error", func(t *testing.T) {
+		ctx := context.Background()
+		queueID := uuid.New()
+		mockProcessor.EXPECT().ProcessByQueueID(ctx, queueID).Return(nil, ErrWebhookStatusConflict)
+
+		result, err := service.ProcessWebhookNow(ctx, queueID)
+
+		assert.ErrorIs(t, err, ErrWebhookStatusConflict)
+		assert.False(t, result.Success)
+		assert.Equal(t, queueID.String(), result.QueueID)
+	})
+}
+
 func TestCreateWebhookCommand_Validation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -357,7 +506,7 @@ func TestWebhookApplicationService_Integration(t *testing.T) {
 	logger := log.NewNopLogger()
 
 	processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
-	service := NewWebhookApplicationService(processor)
+	service := NewWebhookApplicationService(processor, testHealthConfig)
 
 	t.Run("should handle complete webhook creation flow", func(t *testing.T) {
 		ctx := context.Background()
@@ -402,6 +551,7 @@ func TestWebhookApplicationService_Integration(t *testing.T) {
 		}
 
 		// Test health check
+		mockQueueRepo.EXPECT().FindOldestOverdue(ctx, gomock.Any()).Return(nil, nil).Times(1)
 		health, err := service.GetHealth(ctx)
 		assert.NoError(t, err)
 		assert.Equal(t, "healthy", health.Status)
@@ -419,7 +569,7 @@ func BenchmarkWebhookApplicationService_CreateWebhook(b *testing.B) {
 	logger := log.NewNopLogger()
 
 	processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
-	service := NewWebhookApplicationService(processor)
+	service := NewWebhookApplicationService(processor, testHealthConfig)
 
 	config := &entities.WebhookConfig{
 		ID:         1,
@@ -453,7 +603,9 @@ func BenchmarkWebhookApplicationService_GetHealth(b *testing.B) {
 	logger := log.NewNopLogger()
 
 	processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
-	service := NewWebhookApplicationService(processor)
+	service := NewWebhookApplicationService(processor, testHealthConfig)
+
+	mockQueueRepo.EXPECT().FindOldestOverdue(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 
 	ctx := context.Background()
 
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+)
+
+// ErrInvalidConfig is returned when a webhook config is rejected as invalid
+var ErrInvalidConfig = usecases.ErrInvalidConfig
+
+// ErrUnreachableConfig is returned when a save requires a config's URL to be reachable and it is not
+var ErrUnreachableConfig = usecases.ErrUnreachableConfig
+
+// SaveConfigCommand creates a webhook config, or replaces the managed fields of an existing one
+type SaveConfigCommand struct {
+	ID         int64           `json:"-"` // Zero creates a config
+	Name       string          `json:"name" validate:"required"`
+	EventType  enums.EventType `json:"event_type" validate:"required"`
+	WebhookURL string          `json:"webhook_url" validate:"required"`
+	TimeoutMs  int             `json:"timeout_ms" validate:"required,min=1"`
+	IsActive   *bool           `json:"is_active,omitempty"` // Omitted means active
+
+	Validate         bool `json:"-"` // Ping the URL before saving and report whether it answered
+	RequireReachable bool `json:"-"` // Ping the URL and refuse to save when it does not answer
+}
+
+// ConfigReachability is how a config's URL answered the ping made when it was saved
+type ConfigReachability struct {
+	Reachable  bool   `json:"reachable"`
+	StatusCode int    `json:"status_code,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ConfigResult is a webhook config as managed through the API
+// Delivery settings such as headers and retry policy are managed in the database
+type ConfigResult struct {
+	ID         int64           `json:"id"`
+	Name       string          `json:"name"`
+	EventType  enums.EventType `json:"event_type"`
+	WebhookURL string          `json:"webhook_url"`
+	TimeoutMs  int             `json:"timeout_ms"`
+	IsActive   bool            `json:"is_active"`
+	IsDefault  bool            `json:"is_default"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+
+	Reachability *ConfigReachability `json:"reachability,omitempty"` // Set when the save asked for a ping
+}
+
+// ConfigListResult lists every webhook config that has not been deleted
+type ConfigListResult struct {
+	Configs []ConfigResult `json:"configs"`
+}
+
+// DeleteConfigResult represents the result of deleting a webhook config
+type DeleteConfigResult struct {
+	Success  bool   `json:"success"`
+	Message  string `json:"message"`
+	ConfigID int64  `json:"config_id"`
+}
+
+// newConfigResult summarizes a config for the API
+func newConfigResult(config *entities.WebhookConfig) ConfigResult {
+	return ConfigResult{
+		ID:         config.ID,
+		Name:       config.Name,
+		EventType:  config.EventType,
+		WebhookURL: config.WebhookURL,
+		TimeoutMs:  config.TimeoutMs,
+		IsActive:   config.IsActive,
+		IsDefault:  config.IsDefault,
+		CreatedAt:  config.CreatedAt,
+		UpdatedAt:  config.UpdatedAt,
+	}
+}
+
+// toEntity converts the command to the config it saves
+func (cmd SaveConfigCommand) toEntity() *entities.WebhookConfig {
+	return &entities.WebhookConfig{
+		ID:         cmd.ID,
+		Name:       cmd.Name,
+		EventType:  cmd.EventType,
+		WebhookURL: cmd.WebhookURL,
+		TimeoutMs:  cmd.TimeoutMs,
+		IsActive:   cmd.IsActive == nil || *cmd.IsActive,
+	}
+}
+
+// checkReachability pings the config's URL when the command asks for it, returning nil when it does not
+// An unreachable URL is only an error when the command requires it to be reachable
+func (s *webhookApplicationServiceImpl) checkReachability(ctx context.Context, cmd SaveConfigCommand) (*ConfigReachability, error) {
+	if !cmd.Validate && !cmd.RequireReachable {
+		return nil, nil
+	}
+
+	ping, err := s.webhookProcessor.PingConfig(ctx, cmd.toEntity())
+	switch {
+	case errors.Is(err, ErrUnreachableConfig) && cmd.RequireReachable:
+		return nil, err
+	case errors.Is(err, ErrUnreachableConfig):
+		return &ConfigReachability{Error: err.Error()}, nil
+	case err != nil:
+		return nil, err
+	}
+	return &ConfigReachability{Reachable: true, StatusCode: ping.StatusCode, DurationMs: ping.Duration.Milliseconds()}, nil
+}
+
+// CreateConfig registers a new webhook config, returning it with its ID
+// The URL is pinged first when the command asks for it; a required ping that fails stores nothing
+func (s *webhookApplicationServiceImpl) CreateConfig(ctx context.Context, cmd SaveConfigCommand) (*ConfigResult, error) {
+	cmd.ID = 0
+	reachability, err := s.checkReachability(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.webhookProcessor.CreateConfig(ctx, cmd.toEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	result := newConfigResult(config)
+	result.Reachability = reachability
+	return &result, nil
+}
+
+// UpdateConfig replaces the managed fields of a webhook config
+// The URL is pinged first when the command asks for it; a required ping that fails changes nothing
+func (s *webhookApplicationServiceImpl) UpdateConfig(ctx context.Context, cmd SaveConfigCommand) (*ConfigResult, error) {
+	if cmd.ID <= 0 {
+		return nil, fmt.Errorf("%w: config id must be positive", ErrInvalidConfig)
+	}
+	reachability, err := s.checkReachability(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.webhookProcessor.UpdateConfig(ctx, cmd.toEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	result := newConfigResult(config)
+	result.Reachability = reachability
+	return &result, nil
+}
+
+// DeleteConfig soft-deletes a webhook config
+func (s *webhookApplicationServiceImpl) DeleteConfig(ctx context.Context, configID int64) (*DeleteConfigResult, error) {
+	if err := s.webhookProcessor.DeleteConfig(ctx, configID); err != nil {
+		return nil, err
+	}
+
+	return &DeleteConfigResult{
+		Success:  true,
+		Message:  "Webhook config deleted; webhooks already queued for it are still delivered",
+		ConfigID: configID,
+	}, nil
+}
+
+// ListConfigs returns every webhook config that has not been deleted, ordered by ID
+func (s *webhookApplicationServiceImpl) ListConfigs(ctx context.Context) (*ConfigListResult, error) {
+	configs, err := s.webhookProcessor.ListConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConfigListResult{Configs: make([]ConfigResult, 0, len(configs))}
+	for _, config := range configs {
+		result.Configs = append(result.Configs, newConfigResult(config))
+	}
+	return result, nil
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	domainservices "webhook-processor/internal/domain/services"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookApplicationService_Configs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	processor := usecases.NewWebhookProcessor(mocks.NewMockWebhookQueueRepository(ctrl), mockConfigRepo,
+		mockWebhookService, log.NewNopLogger())
+	service := NewWebhookApplicationService(processor, testHealthConfig)
+
+	cmd := SaveConfigCommand{
+		Name:       "Ledger",
+		EventType:  enums.EventTypeCredit,
+		WebhookURL: "https://ledger.example.com/hooks",
+		TimeoutMs:  5000,
+	}
+
+	t.Run("should create an active config by default and return its ID", func(t *testing.T) {
+		ctx := context.Background()
+		createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockConfigRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, config *entities.WebhookConfig) error {
+			assert.True(t, config.IsActive)
+			config.ID, config.CreatedAt, config.UpdatedAt = 12, createdAt, createdAt
+			return nil
+		})
+
+		result, err := service.CreateConfig(ctx, cmd)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), result.ID)
+		assert.Equal(t, createdAt, result.CreatedAt)
+		assert.Equal(t, "https://ledger.example.com/hooks", result.WebhookURL)
+	})
+
+	t.Run("should keep a config inactive when asked", func(t *testing.T) {
+		ctx := context.Background()
+		inactive := false
+		update := cmd
+		update.ID = 4
+		update.IsActive = &inactive
+		mockConfigRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, config *entities.WebhookConfig) (bool, error) {
+			assert.False(t, config.IsActive)
+			return true, nil
+		})
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(4)).Return(&entities.WebhookConfig{ID: 4, Name: "Ledger"}, nil)
+
+		result, err := service.UpdateConfig(ctx, update)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), result.ID)
+		assert.False(t, result.IsActive)
+	})
+
+	t.Run("should reject an invalid config", func(t *testing.T) {
+		invalid := cmd
+		invalid.TimeoutMs = 0
+
+		result, err := service.CreateConfig(context.Background(), invalid)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+
+	t.Run("should ping the URL with the config's timeout and report it reachable when validating", func(t *testing.T) {
+		ctx := context.Background()
+		validate := cmd
+		validate.Validate = true
+		mockWebhookService.EXPECT().Ping(ctx, "https://ledger.example.com/hooks", 5*time.Second).
+			Return(&domainservices.PingResult{StatusCode: 405, Duration: 120 * time.Millisecond}, nil)
+		mockConfigRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		result, err := service.CreateConfig(ctx, validate)
+
+		require.NoError(t, err)
+		assert.Equal(t, &ConfigReachability{Reachable: true, StatusCode: 405, DurationMs: 120}, result.Reachability)
+	})
+
+	t.Run("should still save a config whose URL is unreachable when only validating", func(t *testing.T) {
+		ctx := context.Background()
+		validate := cmd
+		validate.ID = 4
+		validate.Validate = true
+		mockWebhookService.EXPECT().Ping(ctx, gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+		mockConfigRepo.EXPECT().Update(ctx, gomock.Any()).Return(true, nil)
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(4)).Return(&entities.WebhookConfig{ID: 4, Name: "Ledger"}, nil)
+
+		result, err := service.UpdateConfig(ctx, validate)
+
+		require.NoError(t, err)
+		require.NotNil(t, result.Reachability)
+		assert.False(t, result.Reachability.Reachable)
+		assert.Contains(t, result.Reachability.Error, "connection refused")
+	})
+
+	t.Run("should refuse to save a config whose URL is unreachable when reachability is required", func(t *testing.T) {
+		ctx := context.Background()
+		required := cmd
+		required.RequireReachable = true
+		mockWebhookService.EXPECT().Ping(ctx, gomock.Any(), gomock.Any()).Return(nil, errors.New("no such host"))
+
+		result, err := service.CreateConfig(ctx, required)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrUnreachableConfig)
+		assert.ErrorContains(t, err, "no such host")
+	})
+
+	t.Run("should not ping or report reachability unless asked", func(t *testing.T) {
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		result, err := service.CreateConfig(ctx, cmd)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Reachability)
+	})
+
+	t.Run("should report a missing config on delete", func(t *testing.T) {
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().Delete(ctx, int64(99)).Return(false, nil)
+
+		result, err := service.DeleteConfig(ctx, 99)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrConfigNotFound)
+	})
+
+	t.Run("should list configs with an empty list rather than null", func(t *testing.T) {
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().List(ctx).Return(nil, nil)
+
+		result, err := service.ListConfigs(ctx)
+
+		require.NoError(t, err)
+		assert.NotNil(t, result.Configs)
+		assert.Empty(t, result.Configs)
+	})
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+)
+
+// redactedValue replaces secrets in exported documents
+const redactedValue = "REDACTED"
+
+// WebhookExportResult is a self-contained record of one webhook's lifecycle for support tickets
+type WebhookExportResult struct {
+	ExportedAt time.Time `json:"exported_at"`
+
+	QueueID    string            `json:"queue_id"`
+	EventType  enums.EventType   `json:"event_type"`
+	EventID    string            `json:"event_id"`
+	ConfigID   int64             `json:"config_id"`
+	WebhookURL string            `json:"webhook_url"` // URL pinned at creation, redacted
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Payload    string            `json:"payload,omitempty"`
+
+	// Final (or current) state
+	Status         enums.WebhookStatus `json:"status"`
+	RetryCount     int                 `json:"retry_count"`
+	ThrottledCount int                 `json:"throttled_count,omitempty"`
+	NextRetryAt    time.Time           `json:"next_retry_at"`
+	LastError      string              `json:"last_error,omitempty"`
+	LastHTTPStatus int                 `json:"last_http_status,omitempty"`
+
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	ProcessingStartedAt *time.Time `json:"processing_started_at,omitempty"`
+	CompletedAt         *time.Time `json:"completed_at,omitempty"`
+
+	Attempts []entities.WebhookAttempt `json:"attempts"`
+
+	// RemainingSchedule projects the retries left under the current policy; empty once the webhook is done
+	RemainingSchedule []usecases.ProjectedRetry `json:"remaining_schedule"`
+
+	// Config is the webhook's config as it is now; nil if the config no longer exists
+	Config *ConfigSnapshot `json:"config,omitempty"`
+}
+
+// ConfigSnapshot is a redacted copy of the delivery settings of a webhook config
+type ConfigSnapshot struct {
+	ID              int64                         `json:"id"`
+	Name            string                        `json:"name"`
+	EventType       enums.EventType               `json:"event_type"`
+	WebhookURL      string                        `json:"webhook_url"`
+	IsActive        bool                          `json:"is_active"`
+	TimeoutMs       int                           `json:"timeout_ms"`
+	UseLiveURL      bool                          `json:"use_live_url"`
+	CompressRequest bool                          `json:"compress_request"`
+	AcceptHeader    *string                       `json:"accept_header,omitempty"`
+	Headers         map[string]string             `json:"headers,omitempty"` // Values redacted
+	IsDefault       bool                          `json:"is_default"`
+	MaxRetries      *int                          `json:"max_retries,omitempty"`
+	RetryScheduleMs []int                         `json:"retry_schedule_ms,omitempty"`
+	Protocol        enums.DeliveryProtocol        `json:"protocol,omitempty"`
+	WrapPayload     bool                          `json:"wrap_payload"`
+	HTTPMethod      string                        `json:"http_method"`
+	PayloadTemplate *string                       `json:"payload_template,omitempty"`
+	SigningSecret   string                        `json:"signing_secret,omitempty"`
+	Idempotent      bool                          `json:"idempotent"`
+	HedgeAfterMs    *int                          `json:"hedge_after_ms,omitempty"`
+	OnSuccessURL    string                        `json:"on_success_url,omitempty"`
+	OnFailureURL    string                        `json:"on_failure_url,omitempty"`
+	StatusOutcomes  map[int]enums.ResponseOutcome `json:"status_outcomes,omitempty"`
+	Transport       *entities.TransportSettings   `json:"transport,omitempty"`
+	DeliveryWindow  *entities.DeliveryWindow      `json:"delivery_window,omitempty"`
+}
+
+// ExportWebhook returns a webhook's full lifecycle as one document with secrets redacted
+func (s *webhookApplicationServiceImpl) ExportWebhook(ctx context.Context, queueID uuid.UUID) (*WebhookExportResult, error) {
+	webhook, err := s.webhookProcessor.GetWebhookByQueueID(ctx, queueID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := webhook.Attempts()
+	if attempts == nil {
+		attempts = []entities.WebhookAttempt{}
+	}
+
+	return &WebhookExportResult{
+		ExportedAt:          s.now(),
+		QueueID:             webhook.QueueID.String(),
+		EventType:           webhook.EventType,
+		EventID:             webhook.EventID,
+		ConfigID:            webhook.ConfigID,
+		WebhookURL:          redactURL(webhook.WebhookURL),
+		Metadata:            webhook.Metadata,
+		Payload:             webhook.Payload,
+		Status:              webhook.Status,
+		RetryCount:          webhook.RetryCount,
+		ThrottledCount:      webhook.ThrottledCount,
+		NextRetryAt:         webhook.NextRetryAt,
+		LastError:           webhook.LastError,
+		LastHTTPStatus:      webhook.LastHTTPStatus,
+		CreatedAt:           webhook.CreatedAt,
+		UpdatedAt:           webhook.UpdatedAt,
+		ProcessingStartedAt: webhook.ProcessingStartedAt,
+		CompletedAt:         webhook.CompletedAt,
+		Attempts:            attempts,
+		RemainingSchedule:   s.webhookProcessor.RemainingRetrySchedule(webhook),
+		Config:              snapshotConfig(webhook.Config),
+	}, nil
+}
+
+// snapshotConfig copies a config for export, redacting credentials in its URLs
+func snapshotConfig(config *entities.WebhookConfig) *ConfigSnapshot {
+	if config == nil {
+		return nil
+	}
+
+	snapshot := &ConfigSnapshot{
+		ID:              config.ID,
+		Name:            config.Name,
+		EventType:       config.EventType,
+		WebhookURL:      redactURL(config.WebhookURL),
+		IsActive:        config.IsActive,
+		TimeoutMs:       config.TimeoutMs,
+		UseLiveURL:      config.UseLiveURL,
+		CompressRequest: config.CompressRequest,
+		AcceptHeader:    config.AcceptHeader,
+		IsDefault:       config.IsDefault,
+		MaxRetries:      config.MaxRetries,
+		RetryScheduleMs: config.RetryScheduleMs,
+		Protocol:        config.Protocol,
+		WrapPayload:     config.WrapPayload,
+		HTTPMethod:      config.Method(),
+		PayloadTemplate: config.PayloadTemplate,
+		Idempotent:      config.Idempotent,
+		HedgeAfterMs:    config.HedgeAfterMs,
+		OnSuccessURL:    redactURL(config.OnSuccessURL),
+		OnFailureURL:    redactURL(config.OnFailureURL),
+		StatusOutcomes:  config.StatusOutcomes,
+		DeliveryWindow:  config.DeliveryWindow,
+	}
+	if config.SigningSecret != "" {
+		snapshot.SigningSecret = redactedValue
+	}
+	if len(config.Headers) > 0 {
+		// Custom headers usually carry credentials, so only their names are exported
+		snapshot.Headers = make(map[string]string, len(config.Headers))
+		for name := range config.Headers {
+			snapshot.Headers[name] = redactedValue
+		}
+	}
+	if config.Transport != nil {
+		transport := *config.Transport
+		transport.ProxyURL = redactURL(transport.ProxyURL)
+		snapshot.Transport = &transport
+	}
+	return snapshot
+}
+
+// redactURL masks the password and every query value of a URL, which commonly carry tokens
+// An unparseable URL is redacted entirely
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return redactedValue
+	}
+
+	if parsed.User != nil {
+		if _, hasPassword := parsed.User.Password(); hasPassword {
+			parsed.User = url.UserPassword(parsed.User.Username(), redactedValue)
+		}
+	}
+	if parsed.RawQuery != "" {
+		query := parsed.Query()
+		for key := range query {
+			query[key] = []string{redactedValue}
+		}
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
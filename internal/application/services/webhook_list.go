@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+)
+
+const (
+	// defaultWebhookPageSize is the page size of a listing that does not ask for one
+	defaultWebhookPageSize = 50
+
+	// MaxWebhookPageSize bounds one listing page so a query cannot scan the whole queue at once
+	MaxWebhookPageSize = 500
+)
+
+// ErrInvalidListQuery is returned when a webhook listing is rejected as invalid
+var ErrInvalidListQuery = errors.New("invalid webhook list query")
+
+// ListWebhooksQuery selects one page of webhooks; unset filters match everything
+type ListWebhooksQuery struct {
+	Status        enums.WebhookStatus
+	EventType     enums.EventType
+	ConfigID      *int64
+	CreatedAfter  *time.Time // Inclusive
+	CreatedBefore *time.Time
+	Limit         int // Defaults to defaultWebhookPageSize and is capped at MaxWebhookPageSize
+	Offset        int
+}
+
+// WebhookListResult is one page of webhooks, newest first, with where it sits in the full result
+type WebhookListResult struct {
+	Items      []WebhookListItem `json:"items"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// Pagination describes a page of a listing
+type Pagination struct {
+	Limit   int   `json:"limit"` // The page size applied, after defaulting and capping
+	Offset  int   `json:"offset"`
+	Total   int64 `json:"total"` // Every webhook matching the filters
+	HasMore bool  `json:"has_more"`
+}
+
+// WebhookListItem is the summary of one listed webhook; GET /webhooks/{queueID} has the full detail
+type WebhookListItem struct {
+	QueueID        uuid.UUID           `json:"queue_id"`
+	EventType      enums.EventType     `json:"event_type"`
+	EventID        string              `json:"event_id,omitempty"`
+	ConfigID       int64               `json:"config_id"`
+	Status         enums.WebhookStatus `json:"status"`
+	RetryCount     int                 `json:"retry_count"`
+	LastHTTPStatus int                 `json:"last_http_status,omitempty"`
+	LastError      string              `json:"last_error,omitempty"`
+	NextRetryAt    *time.Time          `json:"next_retry_at,omitempty"` // Only while PENDING
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+}
+
+// ListWebhooks returns one page of the webhooks matching the query, newest first
+func (s *webhookApplicationServiceImpl) ListWebhooks(ctx context.Context, query ListWebhooksQuery) (*WebhookListResult, error) {
+	if query.Status != "" && !query.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidListQuery, query.Status)
+	}
+	if query.EventType != "" {
+		if err := query.EventType.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidListQuery, err)
+		}
+	}
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && !query.CreatedAfter.Before(*query.CreatedBefore) {
+		return nil, fmt.Errorf("%w: created_after must be before created_before", ErrInvalidListQuery)
+	}
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, fmt.Errorf("%w: limit and offset cannot be negative", ErrInvalidListQuery)
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultWebhookPageSize
+	}
+	limit = min(limit, MaxWebhookPageSize)
+
+	filter := repositories.WebhookQueueFilter{
+		ConfigID:      query.ConfigID,
+		EventType:     query.EventType,
+		CreatedAfter:  query.CreatedAfter,
+		CreatedBefore: query.CreatedBefore,
+	}
+	if query.Status != "" {
+		filter.Statuses = []enums.WebhookStatus{query.Status}
+	}
+
+	webhooks, total, err := s.webhookProcessor.ListWebhooks(ctx, filter, limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WebhookListResult{
+		Items: make([]WebhookListItem, 0, len(webhooks)),
+		Pagination: Pagination{
+			Limit:   limit,
+			Offset:  query.Offset,
+			Total:   total,
+			HasMore: int64(query.Offset+len(webhooks)) < total,
+		},
+	}
+	for _, webhook := range webhooks {
+		item := WebhookListItem{
+			QueueID:        webhook.QueueID,
+			EventType:      webhook.EventType,
+			EventID:        webhook.EventID,
+			ConfigID:       webhook.ConfigID,
+			Status:         webhook.Status,
+			RetryCount:     webhook.RetryCount,
+			LastHTTPStatus: webhook.LastHTTPStatus,
+			LastError:      webhook.LastError,
+			CreatedAt:      webhook.CreatedAt,
+			UpdatedAt:      webhook.UpdatedAt,
+			CompletedAt:    webhook.CompletedAt,
+		}
+		if webhook.Status == enums.WebhookStatusPending {
+			nextRetryAt := webhook.NextRetryAt
+			item.NextRetryAt = &nextRetryAt
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookApplicationService_ListWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	processor := usecases.NewWebhookProcessor(mockQueueRepo, mocks.NewMockWebhookConfigRepository(ctrl),
+		mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+	service := NewWebhookApplicationService(processor, testHealthConfig)
+
+	t.Run("should pass every filter to the repository and report the page", func(t *testing.T) {
+		ctx := context.Background()
+		configID := int64(7)
+		after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		before := after.Add(24 * time.Hour)
+		failed := &entities.WebhookQueue{
+			QueueID:        uuid.New(),
+			EventType:      enums.EventTypeCredit,
+			ConfigID:       configID,
+			Status:         enums.WebhookStatusFailed,
+			RetryCount:     6,
+			LastHTTPStatus: 503,
+			NextRetryAt:    after,
+		}
+		mockQueueRepo.EXPECT().ListPage(ctx, repositories.WebhookQueueFilter{
+			ConfigID:      &configID,
+			Statuses:      []enums.WebhookStatus{enums.WebhookStatusFailed},
+			EventType:     enums.EventTypeCredit,
+			CreatedAfter:  &after,
+			CreatedBefore: &before,
+		}, 10, 20).Return([]*entities.WebhookQueue{failed}, int64(31), nil)
+
+		result, err := service.ListWebhooks(ctx, ListWebhooksQuery{
+			Status:        enums.WebhookStatusFailed,
+			EventType:     enums.EventTypeCredit,
+			ConfigID:      &configID,
+			CreatedAfter:  &after,
+			CreatedBefore: &before,
+			Limit:         10,
+			Offset:        20,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, failed.QueueID, result.Items[0].QueueID)
+		assert.Equal(t, 503, result.Items[0].LastHTTPStatus)
+		assert.Nil(t, result.Items[0].NextRetryAt, "only pending webhooks report a next retry")
+		assert.Equal(t, Pagination{Limit: 10, Offset: 20, Total: 31, HasMore: true}, result.Pagination)
+	})
+
+	t.Run("should default the page size and cap it at the maximum", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().ListPage(ctx, repositories.WebhookQueueFilter{}, defaultWebhookPageSize, 0).Return(nil, int64(0), nil)
+		mockQueueRepo.EXPECT().ListPage(ctx, repositories.WebhookQueueFilter{}, MaxWebhookPageSize, 0).Return(nil, int64(0), nil)
+
+		unset, err := service.ListWebhooks(ctx, ListWebhooksQuery{})
+		require.NoError(t, err)
+		capped, err := service.ListWebhooks(ctx, ListWebhooksQuery{Limit: 10000})
+		require.NoError(t, err)
+
+		assert.Equal(t, defaultWebhookPageSize, unset.Pagination.Limit)
+		assert.Equal(t, MaxWebhookPageSize, capped.Pagination.Limit)
+	})
+
+	t.Run("should return an empty page for an offset past the end", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().ListPage(ctx, gomock.Any(), 50, 100).Return([]*entities.WebhookQueue{}, int64(12), nil)
+
+		result, err := service.ListWebhooks(ctx, ListWebhooksQuery{Offset: 100})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result.Items)
+		assert.Empty(t, result.Items)
+		assert.Equal(t, int64(12), result.Pagination.Total)
+		assert.False(t, result.Pagination.HasMore)
+	})
+
+	t.Run("should reject an invalid query without listing", func(t *testing.T) {
+		after := time.Now().UTC()
+		for name, query := range map[string]ListWebhooksQuery{
+			"unknown status":     {Status: enums.WebhookStatus("LOST")},
+			"unknown event type": {EventType: enums.EventType("REFUND")},
+			"empty time range":   {CreatedAfter: &after, CreatedBefore: &after},
+			"negative offset":    {Offset: -1},
+			"negative page size": {Limit: -5},
+		} {
+			t.Run(name, func(t *testing.T) {
+				result, err := service.ListWebhooks(context.Background(), query)
+
+				assert.Nil(t, result)
+				assert.ErrorIs(t, err, ErrInvalidListQuery)
+			})
+		}
+	})
+
+	t.Run("should return the repository error", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().ListPage(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("connection refused"))
+
+		result, err := service.ListWebhooks(ctx, ListWebhooksQuery{})
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/enums"
+)
+
+// WebhookStatusResult is the current state of one webhook and the timing of its attempts
+type WebhookStatusResult struct {
+	QueueID        uuid.UUID           `json:"queue_id"`
+	EventType      enums.EventType     `json:"event_type"`
+	EventID        string              `json:"event_id,omitempty"`
+	ConfigID       int64               `json:"config_id"`
+	Status         enums.WebhookStatus `json:"status"`
+	RetryCount     int                 `json:"retry_count"`
+	LastHTTPStatus int                 `json:"last_http_status,omitempty"`
+	LastError      string              `json:"last_error,omitempty"`
+	NextRetryAt    *time.Time          `json:"next_retry_at,omitempty"` // Only while PENDING
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+
+	Attempts          []AttemptTiming           `json:"attempts"`
+	RemainingSchedule []usecases.ProjectedRetry `json:"remaining_schedule"`
+}
+
+// AttemptTiming is when one attempt ran and how it ended, without the bodies an export carries
+type AttemptTiming struct {
+	RetryLevel  int        `json:"retry_level"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DurationMs  *int64     `json:"duration_ms,omitempty"`
+	HTTPStatus  *int       `json:"http_status,omitempty"`
+	ErrorClass  *string    `json:"error_class,omitempty"`
+}
+
+// GetWebhookStatus returns a webhook's current status, attempt timings and remaining retries
+func (s *webhookApplicationServiceImpl) GetWebhookStatus(ctx context.Context, queueID uuid.UUID) (*WebhookStatusResult, error) {
+	webhook, err := s.webhookProcessor.GetWebhookByQueueID(ctx, queueID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WebhookStatusResult{
+		QueueID:           webhook.QueueID,
+		EventType:         webhook.EventType,
+		EventID:           webhook.EventID,
+		ConfigID:          webhook.ConfigID,
+		Status:            webhook.Status,
+		RetryCount:        webhook.RetryCount,
+		LastHTTPStatus:    webhook.LastHTTPStatus,
+		LastError:         webhook.LastError,
+		CreatedAt:         webhook.CreatedAt,
+		UpdatedAt:         webhook.UpdatedAt,
+		CompletedAt:       webhook.CompletedAt,
+		Attempts:          []AttemptTiming{},
+		RemainingSchedule: s.webhookProcessor.RemainingRetrySchedule(webhook),
+	}
+	if webhook.Status == enums.WebhookStatusPending {
+		nextRetryAt := webhook.NextRetryAt
+		result.NextRetryAt = &nextRetryAt
+	}
+	for _, attempt := range webhook.Attempts() {
+		result.Attempts = append(result.Attempts, AttemptTiming{
+			RetryLevel:  attempt.RetryLevel,
+			StartedAt:   attempt.StartedAt,
+			CompletedAt: attempt.CompletedAt,
+			DurationMs:  attempt.DurationMs,
+			HTTPStatus:  attempt.HTTPStatus,
+			ErrorClass:  attempt.ErrorClass,
+		})
+	}
+
+	return result, nil
+}
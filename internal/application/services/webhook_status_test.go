@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookApplicationService_GetWebhookStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+
+	processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+	service := NewWebhookApplicationService(processor, testHealthConfig)
+	ctx := context.Background()
+
+	t.Run("should return the status, attempt timings and remaining retries of a pending webhook", func(t *testing.T) {
+		queueID := uuid.New()
+		startedAt := time.Now().Add(-time.Minute).UTC()
+		nextRetryAt := time.Now().Add(time.Minute).UTC()
+		status, durationMs, body := 503, int64(80), "unavailable"
+		maxRetries := 2
+		mockQueueRepo.EXPECT().GetByQueueID(ctx, queueID).Return(&entities.WebhookQueue{
+			QueueID:            queueID,
+			ConfigID:           3,
+			Status:             enums.WebhookStatusPending,
+			RetryCount:         1,
+			NextRetryAt:        nextRetryAt,
+			LastHTTPStatus:     503,
+			LastError:          "HTTP 503: Service Unavailable",
+			Retry0StartedAt:    &startedAt,
+			Retry0DurationMs:   &durationMs,
+			Retry0HTTPStatus:   &status,
+			Retry0ResponseBody: &body,
+		}, nil)
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(3)).Return(&entities.WebhookConfig{ID: 3, MaxRetries: &maxRetries}, nil)
+
+		result, err := service.GetWebhookStatus(ctx, queueID)
+
+		require.NoError(t, err)
+		assert.Equal(t, enums.WebhookStatusPending, result.Status)
+		assert.Equal(t, 1, result.RetryCount)
+		assert.Equal(t, 503, result.LastHTTPStatus)
+		require.NotNil(t, result.NextRetryAt)
+		assert.Equal(t, nextRetryAt, *result.NextRetryAt)
+		require.Len(t, result.Attempts, 1)
+		assert.Equal(t, AttemptTiming{RetryLevel: 0, StartedAt: &startedAt, DurationMs: &durationMs, HTTPStatus: &status}, result.Attempts[0])
+		require.Len(t, result.RemainingSchedule, 1)
+		assert.Equal(t, 2, result.RemainingSchedule[0].RetryLevel)
+	})
+
+	t.Run("should leave out the next retry of a finished webhook", func(t *testing.T) {
+		queueID := uuid.New()
+		mockQueueRepo.EXPECT().GetByQueueID(ctx, queueID).
+			Return(&entities.WebhookQueue{QueueID: queueID, ConfigID: 3, Status: enums.WebhookStatusCompleted, NextRetryAt: time.Now()}, nil)
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(3)).Return(nil, nil)
+
+		result, err := service.GetWebhookStatus(ctx, queueID)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.NextRetryAt)
+		assert.Empty(t, result.Attempts)
+		assert.Empty(t, result.RemainingSchedule)
+	})
+
+	t.Run("should return not found for an unknown queue ID", func(t *testing.T) {
+		queueID := uuid.New()
+		mockQueueRepo.EXPECT().GetByQueueID(ctx, queueID).Return(nil, nil)
+
+		result, err := service.GetWebhookStatus(ctx, queueID)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+// WorkerClusterResult summarizes the worker heartbeats recorded by every instance sharing the database
+type WorkerClusterResult struct {
+	Timestamp    time.Time     `json:"timestamp"`
+	StaleAfter   time.Duration `json:"stale_after"` // Heartbeats older than this belong to dead workers
+	LiveWorkers  int           `json:"live_workers"`
+	StaleWorkers int           `json:"stale_workers"`
+
+	// LiveByRetryLevel counts live workers for every retry level; a 0 means nothing is processing that level
+	LiveByRetryLevel map[int]int `json:"live_by_retry_level"`
+
+	Instances []WorkerInstance `json:"instances"`
+}
+
+// WorkerInstance groups the heartbeats recorded from one host
+type WorkerInstance struct {
+	Host         string         `json:"host"`
+	LiveWorkers  int            `json:"live_workers"`
+	StaleWorkers int            `json:"stale_workers"`
+	Workers      []WorkerStatus `json:"workers"`
+}
+
+// WorkerStatus is one worker's latest heartbeat
+type WorkerStatus struct {
+	WorkerID   string    `json:"worker_id"`
+	RetryLevel int       `json:"retry_level"`
+	StartedAt  time.Time `json:"started_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Stale      bool      `json:"stale"`
+}
+
+// GetWorkerCluster aggregates worker heartbeats across instances by host
+func (s *webhookApplicationServiceImpl) GetWorkerCluster(ctx context.Context) (*WorkerClusterResult, error) {
+	heartbeats, err := s.webhookProcessor.ListWorkerHeartbeats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	result := &WorkerClusterResult{
+		Timestamp:        now,
+		StaleAfter:       s.healthConfig.WorkerStaleAfter,
+		LiveByRetryLevel: make(map[int]int, enums.MaxRetryAttempts+1),
+		Instances:        []WorkerInstance{},
+	}
+	for level := 0; level <= enums.MaxRetryAttempts; level++ {
+		result.LiveByRetryLevel[level] = 0
+	}
+
+	// Heartbeats arrive ordered by host, so each host's workers are contiguous
+	for _, heartbeat := range heartbeats {
+		if len(result.Instances) == 0 || result.Instances[len(result.Instances)-1].Host != heartbeat.Host {
+			result.Instances = append(result.Instances, WorkerInstance{Host: heartbeat.Host})
+		}
+		instance := &result.Instances[len(result.Instances)-1]
+
+		stale := heartbeat.IsStale(now, s.healthConfig.WorkerStaleAfter)
+		if stale {
+			instance.StaleWorkers++
+			result.StaleWorkers++
+		} else {
+			instance.LiveWorkers++
+			result.LiveWorkers++
+			result.LiveByRetryLevel[heartbeat.RetryLevel]++
+		}
+		instance.Workers = append(instance.Workers, WorkerStatus{
+			WorkerID:   heartbeat.WorkerID,
+			RetryLevel: heartbeat.RetryLevel,
+			StartedAt:  heartbeat.StartedAt,
+			LastSeenAt: heartbeat.LastSeenAt,
+			Stale:      stale,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookApplicationService_GetWorkerCluster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHeartbeatRepo := mocks.NewMockWorkerHeartbeatRepository(ctrl)
+	processor := usecases.NewWebhookProcessor(mocks.NewMockWebhookQueueRepository(ctrl),
+		mocks.NewMockWebhookConfigRepository(ctrl), mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+	processor.SetWorkerHeartbeats(mockHeartbeatRepo)
+
+	service := NewWebhookApplicationService(processor, config.HealthConfig{WorkerStaleAfter: time.Minute}).(*webhookApplicationServiceImpl)
+
+	// Fake clock so heartbeat ages are deterministic
+	fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return fixedNow }
+
+	t.Run("should group heartbeats by host and flag stale workers", func(t *testing.T) {
+		ctx := context.Background()
+		startedAt := fixedNow.Add(-time.Hour)
+		mockHeartbeatRepo.EXPECT().List(ctx).Return([]*entities.WorkerHeartbeat{
+			{WorkerID: "retry-0-a", RetryLevel: 0, Host: "processor-a", StartedAt: startedAt, LastSeenAt: fixedNow.Add(-10 * time.Second)},
+			{WorkerID: "retry-1-a", RetryLevel: 1, Host: "processor-a", StartedAt: startedAt, LastSeenAt: fixedNow.Add(-time.Minute)},
+			{WorkerID: "retry-0-b", RetryLevel: 0, Host: "processor-b", StartedAt: startedAt, LastSeenAt: fixedNow.Add(-61 * time.Second)},
+		}, nil)
+
+		result, err := service.GetWorkerCluster(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, fixedNow, result.Timestamp)
+		assert.Equal(t, time.Minute, result.StaleAfter)
+		assert.Equal(t, 2, result.LiveWorkers)
+		assert.Equal(t, 1, result.StaleWorkers)
+		assert.Equal(t, map[int]int{0: 1, 1: 1, 2: 0, 3: 0, 4: 0, 5: 0, 6: 0}, result.LiveByRetryLevel)
+
+		require.Len(t, result.Instances, 2)
+		assert.Equal(t, "processor-a", result.Instances[0].Host)
+		assert.Equal(t, 2, result.Instances[0].LiveWorkers)
+		assert.Equal(t, 0, result.Instances[0].StaleWorkers)
+		assert.False(t, result.Instances[0].Workers[1].Stale, "a heartbeat exactly stale-after old is still live")
+
+		assert.Equal(t, "processor-b", result.Instances[1].Host)
+		assert.Equal(t, 0, result.Instances[1].LiveWorkers)
+		assert.Equal(t, 1, result.Instances[1].StaleWorkers)
+		assert.True(t, result.Instances[1].Workers[0].Stale)
+	})
+
+	t.Run("should report no instances when no worker has recorded a heartbeat", func(t *testing.T) {
+		ctx := context.Background()
+		mockHeartbeatRepo.EXPECT().List(ctx).Return(nil, nil)
+
+		result, err := service.GetWorkerCluster(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Instances)
+		assert.NotNil(t, result.Instances)
+		assert.Equal(t, 0, result.LiveWorkers)
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		ctx := context.Background()
+		mockHeartbeatRepo.EXPECT().List(ctx).Return(nil, errors.New("connection refused"))
+
+		_, err := service.GetWorkerCluster(ctx)
+
+		assert.ErrorContains(t, err, "failed to list worker heartbeats: connection refused")
+	})
+}
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+// BacklogFullError rejects a create while the pending backlog is above the high-water mark
+type BacklogFullError struct {
+	Pending    int64
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *BacklogFullError) Error() string {
+	return fmt.Sprintf("webhook backlog is full: %d pending, retry after %s", e.Pending, e.RetryAfter)
+}
+
+// backlogGate sheds creates once the pending count reaches the high-water mark and keeps
+// shedding until it drains to the low-water mark, so acceptance does not flap at the threshold
+type backlogGate struct {
+	count         func(ctx context.Context) (int64, error)
+	highWaterMark int64
+	lowWaterMark  int64
+	checkInterval time.Duration // How long a pending count is reused before querying again
+	retryAfter    time.Duration
+	logger        log.Logger
+	now           func() time.Time
+
+	mu        sync.Mutex
+	pending   int64
+	checkedAt time.Time
+	shedding  bool
+}
+
+// admit returns a BacklogFullError while shedding; a failed count keeps the previous state
+func (g *backlogGate) admit(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	if g.checkedAt.IsZero() || now.Sub(g.checkedAt) >= g.checkInterval {
+		pending, err := g.count(ctx)
+		if err != nil {
+			g.logger.Log("level", "warn", "msg", "failed to count pending backlog, keeping admission state",
+				"shedding", g.shedding, "error", err)
+		} else {
+			g.pending = pending
+			g.checkedAt = now
+			g.transition()
+		}
+	}
+
+	if g.shedding {
+		return &BacklogFullError{Pending: g.pending, RetryAfter: g.retryAfter}
+	}
+	return nil
+}
+
+// transition applies the water marks to the latest pending count
+func (g *backlogGate) transition() {
+	switch {
+	case !g.shedding && g.pending >= g.highWaterMark:
+		g.shedding = true
+		g.logger.Log("level", "warn", "msg", "backlog above high-water mark, rejecting new webhooks",
+			"pending", g.pending, "high_water_mark", g.highWaterMark)
+	case g.shedding && g.pending <= g.lowWaterMark:
+		g.shedding = false
+		g.logger.Log("level", "info", "msg", "backlog below low-water mark, accepting new webhooks",
+			"pending", g.pending, "low_water_mark", g.lowWaterMark)
+	}
+}
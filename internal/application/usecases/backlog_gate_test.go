@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/mocks"
+)
+
+func TestBacklogGate_Admit(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	var pending int64
+	var countErr error
+	queries := 0
+	gate := &backlogGate{
+		count: func(ctx context.Context) (int64, error) {
+			queries++
+			return pending, countErr
+		},
+		highWaterMark: 1000,
+		lowWaterMark:  500,
+		checkInterval: 5 * time.Second,
+		retryAfter:    30 * time.Second,
+		logger:        log.NewNopLogger(),
+		now:           func() time.Time { return now },
+	}
+
+	// advance moves the clock past the cache interval and sets the next pending count
+	advance := func(count int64) {
+		now = now.Add(gate.checkInterval)
+		pending = count
+	}
+
+	t.Run("should admit below the high-water mark", func(t *testing.T) {
+		advance(999)
+
+		assert.NoError(t, gate.admit(ctx))
+	})
+
+	t.Run("should reject once the high-water mark is crossed", func(t *testing.T) {
+		advance(1000)
+
+		err := gate.admit(ctx)
+
+		var backlogFull *BacklogFullError
+		require.ErrorAs(t, err, &backlogFull)
+		assert.Equal(t, int64(1000), backlogFull.Pending)
+		assert.Equal(t, 30*time.Second, backlogFull.RetryAfter)
+	})
+
+	t.Run("should keep rejecting between the water marks", func(t *testing.T) {
+		advance(700)
+
+		assert.Error(t, gate.admit(ctx))
+	})
+
+	t.Run("should reuse the cached count within the check interval", func(t *testing.T) {
+		before := queries
+		pending = 0
+
+		assert.Error(t, gate.admit(ctx))
+		assert.Equal(t, before, queries)
+	})
+
+	t.Run("should keep the previous state when counting fails", func(t *testing.T) {
+		advance(0)
+		countErr = errors.New("connection refused")
+		defer func() { countErr = nil }()
+
+		assert.Error(t, gate.admit(ctx))
+	})
+
+	t.Run("should resume admitting at the low-water mark", func(t *testing.T) {
+		advance(500)
+
+		assert.NoError(t, gate.admit(ctx))
+	})
+
+	t.Run("should keep admitting until the high-water mark again", func(t *testing.T) {
+		advance(900)
+
+		assert.NoError(t, gate.admit(ctx))
+	})
+}
+
+func TestWebhookProcessor_CreateWebhookEntry_Backpressure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+	processor.EnableBackpressure(100, 50, time.Minute, 10*time.Second)
+
+	t.Run("should reject without touching the config or queue when the backlog is full", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().CountByStatus(ctx, enums.WebhookStatusPending).Return(int64(150), nil)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-1", 1, nil, "")
+
+		var backlogFull *BacklogFullError
+		assert.ErrorAs(t, err, &backlogFull)
+	})
+}
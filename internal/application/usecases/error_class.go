@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+// classifyAttemptError categorizes a failed delivery attempt by inspecting the send error
+// A nil error with a non-success outcome means the endpoint answered with an unwanted status
+func classifyAttemptError(err error, outcome enums.ResponseOutcome) enums.ErrorClass {
+	if err == nil {
+		if outcome == enums.ResponseOutcomeSuccess {
+			return enums.ErrorClassNone
+		}
+		return enums.ErrorClassHTTPStatus
+	}
+
+	// DNS errors can also report a timeout, so they are checked first
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return enums.ErrorClassDNS
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return enums.ErrorClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return enums.ErrorClassTimeout
+	}
+
+	if isTLSError(err) {
+		return enums.ErrorClassTLS
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return enums.ErrorClassConnection
+	}
+
+	return enums.ErrorClassInternal
+}
+
+// isDNSNotFound reports whether err is a DNS lookup that found no such host,
+// as opposed to a resolver timeout or outage that may clear on its own
+func isDNSNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// isAmbiguousSendError reports whether err is the connection being reset or closed while the response was
+// awaited, after the request was written, so the receiver may already have processed the delivery
+// Resets while dialing or writing the request leave nothing for the receiver to process
+func isAmbiguousSendError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op != "read" {
+		return false
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isTLSError reports whether err came from the TLS handshake or certificate verification
+func isTLSError(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	return errors.As(err, &verifyErr) || errors.As(err, &recordErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+func TestIsAmbiguousSendError(t *testing.T) {
+	sendErr := func(err error) error {
+		return fmt.Errorf("failed to send webhook request: %w",
+			&url.Error{Op: "Post", URL: "https://example.com/hook", Err: err})
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "reset while reading the response is ambiguous", err: sendErr(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), expected: true},
+		{name: "connection closed before a response is ambiguous", err: sendErr(io.EOF), expected: true},
+		{name: "reset while writing the request is not ambiguous", err: sendErr(&net.OpError{Op: "write", Net: "tcp", Err: syscall.ECONNRESET}), expected: false},
+		{name: "connection refused is not ambiguous", err: sendErr(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), expected: false},
+		{name: "timeout is not ambiguous", err: sendErr(context.DeadlineExceeded), expected: false},
+		{name: "no error is not ambiguous", err: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isAmbiguousSendError(tt.err))
+		})
+	}
+}
+
+func TestClassifyAttemptError(t *testing.T) {
+	// sendErr wraps err the way the webhook service and net/http do
+	sendErr := func(err error) error {
+		return fmt.Errorf("failed to send webhook request: %w",
+			&url.Error{Op: "Get", URL: "https://example.com/hook", Err: err})
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		outcome  enums.ResponseOutcome
+		expected enums.ErrorClass
+	}{
+		{
+			name:     "successful response has no class",
+			outcome:  enums.ResponseOutcomeSuccess,
+			expected: enums.ErrorClassNone,
+		},
+		{
+			name:     "HTTP 500 is an http_status error",
+			outcome:  enums.ResponseOutcomeRetry,
+			expected: enums.ErrorClassHTTPStatus,
+		},
+		{
+			name:     "non-retryable response is an http_status error",
+			outcome:  enums.ResponseOutcomeFail,
+			expected: enums.ErrorClassHTTPStatus,
+		},
+		{
+			name:     "context deadline exceeded is a timeout",
+			err:      sendErr(context.DeadlineExceeded),
+			outcome:  enums.ResponseOutcomeRetry,
+			expected: enums.ErrorClassTimeout,
+		},
+		{
+			name:     "DNS lookup failure is a dns error",
+			err:      sendErr(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}}),
+			outcome:  enums.ResponseOutcomeRetry,
+			expected: enums.ErrorClassDNS,
+		},
+		{
+			name:     "DNS timeout stays a dns error",
+			err:      sendErr(&net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}),
+			outcome:  enums.ResponseOutcomeRetry,
+			expected: enums.ErrorClassDNS,
+		},
+		{
+			name:     "connection refused is a connection error",
+			err:      sendErr(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}),
+			outcome:  enums.ResponseOutcomeRetry,
+			expected: enums.ErrorClassConnection,
+		},
+		{
+			name:     "unknown certificate authority is a tls error",
+			err:      sendErr(x509.UnknownAuthorityError{}),
+			outcome:  enums.ResponseOutcomeRetry,
+			expected: enums.ErrorClassTLS,
+		},
+		{
+			name:     "unrecognized error is internal",
+			err:      fmt.Errorf("failed to get HTTP client: %w", errors.New("no supported cipher suites")),
+			outcome:  enums.ResponseOutcomeRetry,
+			expected: enums.ErrorClassInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyAttemptError(tt.err, tt.outcome))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/domain/services"
+)
+
+// ProcessorPort is the subset of WebhookProcessor that the application service depends on
+// Its mock is generated into the services package, since internal/mocks is imported by this package's tests
+type ProcessorPort interface {
+	// CreateWebhookEntry queues a webhook for an event, resolving a zero configID to the default config
+	CreateWebhookEntry(ctx context.Context, eventType enums.EventType, eventID string, configID int64, metadata map[string]string, payload string) (*entities.WebhookQueue, error)
+
+	// GetConfigStats returns the delivery statistics rollup for a config
+	GetConfigStats(ctx context.Context, configID int64) (*entities.WebhookConfigStats, error)
+
+	// GetWebhooksByEventID returns the webhooks an event fanned out to
+	GetWebhooksByEventID(ctx context.Context, eventID string) ([]*entities.WebhookQueue, error)
+
+	// GetWebhookByQueueID returns a webhook with its current config attached
+	GetWebhookByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error)
+
+	// RemainingRetrySchedule projects the retries a webhook has left under its current policy
+	RemainingRetrySchedule(webhook *entities.WebhookQueue) []ProjectedRetry
+
+	// ProcessByQueueID runs one delivery attempt for a pending webhook immediately
+	ProcessByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error)
+
+	// FindOldestOverdueWebhook returns the pending webhook that has been due the longest as of asOf
+	FindOldestOverdueWebhook(ctx context.Context, asOf time.Time) (*entities.WebhookQueue, error)
+
+	// BulkUpdateStatus moves every webhook matching the filter to newStatus
+	BulkUpdateStatus(ctx context.Context, filter repositories.WebhookQueueFilter, newStatus enums.WebhookStatus, reason string) (int64, error)
+
+	// ForceFailWebhook marks a pending or in-flight webhook as failed
+	ForceFailWebhook(ctx context.Context, queueID uuid.UUID, reason string) error
+
+	// DeleteWebhook permanently erases a webhook and its stored responses
+	DeleteWebhook(ctx context.Context, queueID uuid.UUID) error
+
+	// ListWorkerHeartbeats returns the heartbeats of every worker across instances
+	ListWorkerHeartbeats(ctx context.Context) ([]*entities.WorkerHeartbeat, error)
+
+	// ListProcessingWebhooks returns up to limit webhooks in PROCESSING, oldest first
+	ListProcessingWebhooks(ctx context.Context, limit int) ([]ProcessingWebhook, error)
+
+	// ListWebhooks returns one page of webhooks matching the filter, newest first, with the total match count
+	ListWebhooks(ctx context.Context, filter repositories.WebhookQueueFilter, limit, offset int) ([]*entities.WebhookQueue, int64, error)
+
+	// ClaimTTL returns how long worker claims last
+	ClaimTTL() time.Duration
+
+	// ReplayTo re-sends matching webhooks once to a different URL
+	ReplayTo(ctx context.Context, filter repositories.WebhookQueueFilter, overrideURL string) ([]ReplayResult, error)
+
+	// CreateConfig validates and stores a new webhook config
+	CreateConfig(ctx context.Context, config *entities.WebhookConfig) (*entities.WebhookConfig, error)
+
+	// UpdateConfig validates and replaces a config's name, event type, URL, timeout and active flag
+	UpdateConfig(ctx context.Context, config *entities.WebhookConfig) (*entities.WebhookConfig, error)
+
+	// PingConfig checks that a valid config's URL answers within its timeout
+	PingConfig(ctx context.Context, config *entities.WebhookConfig) (*services.PingResult, error)
+
+	// DeleteConfig soft-deletes a config
+	DeleteConfig(ctx context.Context, configID int64) error
+
+	// ListConfigs returns every config that has not been deleted
+	ListConfigs(ctx context.Context) ([]*entities.WebhookConfig, error)
+}
+
+var _ ProcessorPort = (*WebhookProcessor)(nil)
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+)
+
+// QueueBackupStatuses are the statuses a queue backup keeps: every webhook not yet delivered,
+// including failed ones an operator may still retry
+var QueueBackupStatuses = []enums.WebhookStatus{
+	enums.WebhookStatusPending,
+	enums.WebhookStatusProcessing,
+	enums.WebhookStatusFailed,
+}
+
+// Queue backup format written on the first line of every backup
+const (
+	queueBackupFormat  = "webhook-queue-backup"
+	queueBackupVersion = 1
+)
+
+// QueueBackupHeader is the first line of a queue backup; one webhook per line follows it
+type QueueBackupHeader struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// QueueImportResult counts what a queue import did with the webhooks in a backup
+type QueueImportResult struct {
+	Restored       int `json:"restored"`
+	AlreadyPresent int `json:"already_present"` // Queue ID already in the queue, left as it was
+	MissingConfig  int `json:"missing_config"`  // Config does not exist, so the webhook could not be delivered
+}
+
+// ExportQueue writes every webhook in QueueBackupStatuses to w as JSON lines after a header line,
+// reading batchSize rows at a time, and returns how many webhooks it wrote
+// Rows change while the export runs, so a webhook is written as it was when its batch was read
+func (wp *WebhookProcessor) ExportQueue(ctx context.Context, w io.Writer, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("export batch size must be positive, got %d", batchSize)
+	}
+
+	encoder := json.NewEncoder(w)
+	header := QueueBackupHeader{Format: queueBackupFormat, Version: queueBackupVersion, ExportedAt: time.Now().UTC()}
+	if err := encoder.Encode(header); err != nil {
+		return 0, fmt.Errorf("failed to write queue backup header: %w", err)
+	}
+
+	filter := repositories.WebhookQueueFilter{Statuses: QueueBackupStatuses}
+	var exported int
+	var afterID int64
+	for {
+		webhooks, err := wp.webhookQueueRepo.ListAfter(ctx, filter, afterID, batchSize)
+		if err != nil {
+			return exported, fmt.Errorf("failed to read webhooks for backup: %w", err)
+		}
+		for _, webhook := range webhooks {
+			if err := encoder.Encode(webhook); err != nil {
+				return exported, fmt.Errorf("failed to write webhook %s to backup: %w", webhook.QueueID, err)
+			}
+			exported++
+			afterID = webhook.ID
+		}
+		if len(webhooks) < batchSize {
+			return exported, nil
+		}
+	}
+}
+
+// ImportQueue restores the webhooks in a backup written by ExportQueue
+// Webhooks whose queue ID is already present are left alone, so an interrupted import can be rerun;
+// those whose config does not exist are skipped, since they could never be delivered
+// A PROCESSING webhook's claim belonged to a worker of the old instance, so it is restored as PENDING
+func (wp *WebhookProcessor) ImportQueue(ctx context.Context, r io.Reader) (*QueueImportResult, error) {
+	decoder := json.NewDecoder(r)
+
+	var header QueueBackupHeader
+	if err := decoder.Decode(&header); err != nil {
+		return nil, fmt.Errorf("failed to read queue backup header: %w", err)
+	}
+	if header.Format != queueBackupFormat || header.Version != queueBackupVersion {
+		return nil, fmt.Errorf("unsupported queue backup: format %q version %d", header.Format, header.Version)
+	}
+
+	result := &QueueImportResult{}
+	configExists := make(map[int64]bool)
+	for {
+		var webhook entities.WebhookQueue
+		if err := decoder.Decode(&webhook); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return result, fmt.Errorf("failed to read webhook %d of queue backup: %w", result.total()+1, err)
+		}
+
+		exists, ok := configExists[webhook.ConfigID]
+		if !ok {
+			config, err := wp.webhookConfigRepo.GetByID(ctx, webhook.ConfigID)
+			if err != nil {
+				return result, fmt.Errorf("failed to get webhook config %d: %w", webhook.ConfigID, err)
+			}
+			exists = config != nil
+			configExists[webhook.ConfigID] = exists
+		}
+		if !exists {
+			wp.logger.Log("level", "warn", "msg", "skipping backed-up webhook whose config does not exist",
+				"queue_id", webhook.QueueID, "config_id", webhook.ConfigID)
+			result.MissingConfig++
+			continue
+		}
+
+		if webhook.Status == enums.WebhookStatusProcessing {
+			webhook.Status = enums.WebhookStatusPending
+			webhook.ClaimedBy = nil
+			webhook.ClaimExpiresAt = nil
+		}
+
+		restored, err := wp.webhookQueueRepo.Restore(ctx, &webhook)
+		if err != nil {
+			return result, err
+		}
+		if restored {
+			result.Restored++
+		} else {
+			result.AlreadyPresent++
+		}
+	}
+
+	wp.logger.Log("level", "info", "msg", "queue backup imported", "exported_at", header.ExportedAt,
+		"restored", result.Restored, "already_present", result.AlreadyPresent, "missing_config", result.MissingConfig)
+
+	return result, nil
+}
+
+// total is how many webhooks the import has read so far
+func (r *QueueImportResult) total() int {
+	return r.Restored + r.AlreadyPresent + r.MissingConfig
+}
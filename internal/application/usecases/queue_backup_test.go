@@ -0,0 +1,216 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/mocks"
+)
+
+// backupQueue is an in-memory queue behind a mocked repository, listing rows in ID order
+// and restoring rows under new IDs the way the database does
+type backupQueue struct {
+	rows []*entities.WebhookQueue
+}
+
+// expectListAfter serves ListAfter from the queue's rows, honoring the status filter
+func (q *backupQueue) expectListAfter(repo *mocks.MockWebhookQueueRepository) {
+	repo.EXPECT().ListAfter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, filter repositories.WebhookQueueFilter, afterID int64, limit int) ([]*entities.WebhookQueue, error) {
+			var batch []*entities.WebhookQueue
+			for _, row := range q.rows {
+				if row.ID > afterID && len(batch) < limit && slices.Contains(filter.Statuses, row.Status) {
+					batch = append(batch, row)
+				}
+			}
+			return batch, nil
+		})
+}
+
+// expectRestore serves Restore by appending rows whose queue ID is not yet present
+func (q *backupQueue) expectRestore(repo *mocks.MockWebhookQueueRepository) {
+	repo.EXPECT().Restore(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, webhook *entities.WebhookQueue) (bool, error) {
+			for _, row := range q.rows {
+				if row.QueueID == webhook.QueueID {
+					return false, nil
+				}
+			}
+			restored := *webhook
+			restored.ID = int64(len(q.rows) + 1000)
+			q.rows = append(q.rows, &restored)
+			return true, nil
+		})
+}
+
+// byQueueID indexes the queue's rows by queue ID
+func (q *backupQueue) byQueueID() map[uuid.UUID]*entities.WebhookQueue {
+	rows := make(map[uuid.UUID]*entities.WebhookQueue, len(q.rows))
+	for _, row := range q.rows {
+		rows[row.QueueID] = row
+	}
+	return rows
+}
+
+func TestWebhookProcessor_QueueBackup(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	startedAt := createdAt.Add(time.Minute)
+	serverError := 503
+	errorMsg := "HTTP 503: Service Unavailable"
+	claimedBy := "worker-1-level-0"
+	claimExpiresAt := createdAt.Add(5 * time.Minute)
+
+	// sourceRows is the queue being backed up: pending webhooks at different stages, plus one of
+	// every other status
+	sourceRows := func() []*entities.WebhookQueue {
+		rows := []*entities.WebhookQueue{
+			{EventType: enums.EventTypeCredit, EventID: "txn-1", ConfigID: 1, WebhookURL: "https://a.example.com/hook",
+				Metadata: map[string]string{"tenant": "acme"}, Payload: `{"amount":100}`, Status: enums.WebhookStatusPending},
+			{EventType: enums.EventTypeDebit, EventID: "txn-2", ConfigID: 2, WebhookURL: "https://b.example.com/hook",
+				Status: enums.WebhookStatusPending, RetryCount: 1, LastError: errorMsg, LastHTTPStatus: serverError,
+				Retry0StartedAt: &startedAt, Retry0HTTPStatus: &serverError, Retry0Error: &errorMsg},
+			{EventType: enums.EventTypeCredit, EventID: "txn-3", ConfigID: 1, WebhookURL: "https://a.example.com/hook",
+				Status: enums.WebhookStatusPending, ThrottledCount: 2},
+			{EventType: enums.EventTypeCredit, EventID: "txn-4", ConfigID: 1, WebhookURL: "https://a.example.com/hook",
+				Status: enums.WebhookStatusCompleted},
+			{EventType: enums.EventTypeCredit, EventID: "txn-5", ConfigID: 2, WebhookURL: "https://b.example.com/hook",
+				Status: enums.WebhookStatusProcessing, ClaimedBy: &claimedBy, ClaimExpiresAt: &claimExpiresAt},
+			{EventType: enums.EventTypeDebit, EventID: "txn-6", ConfigID: 2, WebhookURL: "https://b.example.com/hook",
+				Status: enums.WebhookStatusFailed, LastError: "max retries exceeded"},
+		}
+		for i, row := range rows {
+			row.ID = int64(i + 1)
+			row.QueueID = uuid.New()
+			row.CreatedAt = createdAt
+			row.UpdatedAt = createdAt
+			row.NextRetryAt = createdAt.Add(time.Duration(i) * time.Minute)
+		}
+		return rows
+	}
+
+	setup := func(t *testing.T) (*WebhookProcessor, *mocks.MockWebhookQueueRepository, *mocks.MockWebhookConfigRepository) {
+		ctrl := gomock.NewController(t)
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		return processor, mockQueueRepo, mockConfigRepo
+	}
+
+	// exportRows exports rows in batches of batchSize, returning the backup
+	exportRows := func(t *testing.T, rows []*entities.WebhookQueue, batchSize int) (*bytes.Buffer, int) {
+		processor, mockQueueRepo, _ := setup(t)
+		(&backupQueue{rows: rows}).expectListAfter(mockQueueRepo)
+
+		var backup bytes.Buffer
+		exported, err := processor.ExportQueue(context.Background(), &backup, batchSize)
+		require.NoError(t, err)
+		return &backup, exported
+	}
+
+	// importInto imports backup into target, whose configs all exist
+	importInto := func(t *testing.T, target *backupQueue, backup []byte) *QueueImportResult {
+		processor, mockQueueRepo, mockConfigRepo := setup(t)
+		target.expectRestore(mockQueueRepo)
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).AnyTimes().
+			DoAndReturn(func(_ context.Context, id int64) (*entities.WebhookConfig, error) {
+				return &entities.WebhookConfig{ID: id}, nil
+			})
+
+		result, err := processor.ImportQueue(context.Background(), bytes.NewReader(backup))
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("should round-trip pending webhooks into an empty queue", func(t *testing.T) {
+		source := sourceRows()
+		backup, exported := exportRows(t, source, 2)
+		assert.Equal(t, 5, exported, "every webhook but the completed one")
+
+		target := &backupQueue{}
+		result := importInto(t, target, backup.Bytes())
+
+		assert.Equal(t, &QueueImportResult{Restored: 5}, result)
+		restored := target.byQueueID()
+		for _, original := range source[:3] {
+			require.Contains(t, restored, original.QueueID)
+			want := *original
+			want.ID = restored[original.QueueID].ID
+			assert.Equal(t, &want, restored[original.QueueID], "webhook %s", original.EventID)
+		}
+		assert.NotContains(t, restored, source[3].QueueID, "completed webhooks are not backed up")
+		assert.Equal(t, enums.WebhookStatusFailed, restored[source[5].QueueID].Status)
+	})
+
+	t.Run("should restore a processing webhook as pending without its claim", func(t *testing.T) {
+		source := sourceRows()
+		backup, _ := exportRows(t, source, 100)
+
+		target := &backupQueue{}
+		importInto(t, target, backup.Bytes())
+
+		restored := target.byQueueID()[source[4].QueueID]
+		require.NotNil(t, restored)
+		assert.Equal(t, enums.WebhookStatusPending, restored.Status)
+		assert.Nil(t, restored.ClaimedBy)
+		assert.Nil(t, restored.ClaimExpiresAt)
+		assert.Equal(t, source[4].NextRetryAt, restored.NextRetryAt)
+	})
+
+	t.Run("should leave webhooks already present when the import is rerun", func(t *testing.T) {
+		backup, _ := exportRows(t, sourceRows(), 100)
+		target := &backupQueue{}
+		importInto(t, target, backup.Bytes())
+
+		result := importInto(t, target, backup.Bytes())
+
+		assert.Equal(t, &QueueImportResult{AlreadyPresent: 5}, result)
+		assert.Len(t, target.rows, 5)
+	})
+
+	t.Run("should skip webhooks whose config does not exist", func(t *testing.T) {
+		backup, _ := exportRows(t, sourceRows(), 100)
+		processor, mockQueueRepo, mockConfigRepo := setup(t)
+		target := &backupQueue{}
+		target.expectRestore(mockQueueRepo)
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&entities.WebhookConfig{ID: 1}, nil)
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(nil, nil)
+
+		result, err := processor.ImportQueue(context.Background(), backup)
+
+		require.NoError(t, err)
+		assert.Equal(t, &QueueImportResult{Restored: 2, MissingConfig: 3}, result)
+	})
+
+	t.Run("should reject a file that is not a queue backup", func(t *testing.T) {
+		processor, _, _ := setup(t)
+
+		result, err := processor.ImportQueue(context.Background(), strings.NewReader(`{"format":"something-else","version":1}`))
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "unsupported queue backup")
+	})
+
+	t.Run("should return a failed read without finishing the export", func(t *testing.T) {
+		processor, mockQueueRepo, _ := setup(t)
+		mockQueueRepo.EXPECT().ListAfter(gomock.Any(), gomock.Any(), int64(0), 100).Return(nil, errors.New("connection refused"))
+
+		exported, err := processor.ExportQueue(context.Background(), &bytes.Buffer{}, 100)
+
+		assert.Zero(t, exported)
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+)
+
+// ErrInvalidReplay is returned when a replay request is rejected before anything is sent
+var ErrInvalidReplay = errors.New("invalid replay request")
+
+// maxReplayWebhooks bounds one replay; replays send synchronously, so larger migrations run in batches
+const maxReplayWebhooks = 100
+
+// ReplayResult is the outcome of re-sending one webhook to a replay URL
+type ReplayResult struct {
+	QueueID    uuid.UUID `json:"queue_id"`
+	Success    bool      `json:"success"`
+	HTTPStatus int       `json:"http_status,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// ReplayTo re-sends up to maxReplayWebhooks webhooks matching the filter to overrideURL, oldest first
+// Each webhook is sent once, best-effort: nothing is retried and the original rows are never written,
+// so their status, attempts and schedule are untouched. Each send is judged by its config's rules
+func (wp *WebhookProcessor) ReplayTo(ctx context.Context, filter repositories.WebhookQueueFilter, overrideURL string) ([]ReplayResult, error) {
+	if filter.IsEmpty() {
+		return nil, fmt.Errorf("%w: filter must narrow the selection", ErrInvalidReplay)
+	}
+	if !wp.isValidWebhookURL(overrideURL) {
+		return nil, fmt.Errorf("%w: invalid replay URL %q", ErrInvalidReplay, overrideURL)
+	}
+
+	webhooks, err := wp.webhookQueueRepo.List(ctx, filter, maxReplayWebhooks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks to replay: %w", err)
+	}
+
+	configs := make(map[int64]*entities.WebhookConfig)
+	results := make([]ReplayResult, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if err := ctx.Err(); err != nil {
+			return results, fmt.Errorf("replay interrupted after %d of %d webhooks: %w", len(results), len(webhooks), err)
+		}
+
+		config, ok := configs[webhook.ConfigID]
+		if !ok {
+			// A missing config only loses the config's outcome rules; the replay still goes out
+			config, err = wp.webhookConfigRepo.GetByID(ctx, webhook.ConfigID)
+			if err != nil {
+				wp.logger.Log("level", "warn", "msg", "failed to get webhook config for replay",
+					"config_id", webhook.ConfigID, "error", err)
+			}
+			configs[webhook.ConfigID] = config
+		}
+
+		results = append(results, wp.replayOne(ctx, webhook, config, overrideURL))
+	}
+
+	wp.logger.Log("level", "info", "msg", "replayed webhooks to override URL",
+		"replayed", len(results), "override_url", overrideURL)
+
+	return results, nil
+}
+
+// replayOne sends a copy of webhook to overrideURL, leaving the original entity and row as they were
+func (wp *WebhookProcessor) replayOne(ctx context.Context, webhook *entities.WebhookQueue, config *entities.WebhookConfig, overrideURL string) ReplayResult {
+	replay := *webhook
+	replay.WebhookURL = overrideURL
+	replay.Config = config
+
+	startedAt := time.Now()
+	response, err := wp.webhookService.SendWebhook(ctx, &replay)
+	result := ReplayResult{
+		QueueID:    webhook.QueueID,
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}
+	if response != nil {
+		result.HTTPStatus = response.StatusCode
+	}
+
+	outcome := wp.resolveOutcome(config, response, err)
+	result.Success = outcome == enums.ResponseOutcomeSuccess
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case !result.Success && response != nil:
+		result.Error = fmt.Sprintf("HTTP %d: %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+	return result
+}
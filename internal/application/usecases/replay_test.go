@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/domain/services"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookProcessor_ReplayTo(t *testing.T) {
+	const overrideURL = "https://new-receiver.example.com/webhook"
+	configID := int64(3)
+	filter := repositories.WebhookQueueFilter{ConfigID: &configID}
+
+	// setup returns a processor whose queue repository only expects the listing, so any write fails the test
+	setup := func(t *testing.T) (*WebhookProcessor, *mocks.MockWebhookQueueRepository, *mocks.MockWebhookConfigRepository, *mocks.MockWebhookService) {
+		ctrl := gomock.NewController(t)
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+		return processor, mockQueueRepo, mockConfigRepo, mockWebhookService
+	}
+
+	newWebhook := func(status enums.WebhookStatus) *entities.WebhookQueue {
+		return &entities.WebhookQueue{
+			ID:         1,
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeCredit,
+			ConfigID:   configID,
+			WebhookURL: "https://old-receiver.example.com/webhook",
+			Status:     status,
+			RetryCount: 2,
+		}
+	}
+
+	t.Run("should send each webhook to the override URL and leave the originals untouched", func(t *testing.T) {
+		processor, mockQueueRepo, mockConfigRepo, mockWebhookService := setup(t)
+		ctx := context.Background()
+		delivered := newWebhook(enums.WebhookStatusCompleted)
+		rejected := newWebhook(enums.WebhookStatusFailed)
+		original := *delivered
+
+		mockQueueRepo.EXPECT().List(ctx, filter, maxReplayWebhooks).Return([]*entities.WebhookQueue{delivered, rejected}, nil)
+		mockConfigRepo.EXPECT().GetByID(ctx, configID).Return(&entities.WebhookConfig{ID: configID}, nil).Times(1)
+
+		var sentTo []string
+		mockWebhookService.EXPECT().SendWebhook(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
+				sentTo = append(sentTo, webhook.WebhookURL)
+				if webhook.QueueID == rejected.QueueID {
+					return &services.WebhookResponse{StatusCode: 500}, nil
+				}
+				return &services.WebhookResponse{StatusCode: 200}, nil
+			}).Times(2)
+
+		results, err := processor.ReplayTo(ctx, filter, overrideURL)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{overrideURL, overrideURL}, sentTo)
+		require.Len(t, results, 2)
+		assert.Equal(t, ReplayResult{QueueID: delivered.QueueID, Success: true, HTTPStatus: 200, DurationMs: results[0].DurationMs}, results[0])
+		assert.False(t, results[1].Success)
+		assert.Equal(t, 500, results[1].HTTPStatus)
+		assert.Equal(t, "HTTP 500: Internal Server Error", results[1].Error)
+		assert.Equal(t, original, *delivered, "the original webhook must not be modified")
+	})
+
+	t.Run("should report a send error without retrying", func(t *testing.T) {
+		processor, mockQueueRepo, mockConfigRepo, mockWebhookService := setup(t)
+		ctx := context.Background()
+		webhook := newWebhook(enums.WebhookStatusCompleted)
+
+		mockQueueRepo.EXPECT().List(ctx, filter, maxReplayWebhooks).Return([]*entities.WebhookQueue{webhook}, nil)
+		mockConfigRepo.EXPECT().GetByID(ctx, configID).Return(nil, errors.New("connection refused"))
+		mockWebhookService.EXPECT().SendWebhook(ctx, gomock.Any()).Return(nil, errors.New("dial tcp: connection refused")).Times(1)
+
+		results, err := processor.ReplayTo(ctx, filter, overrideURL)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Success)
+		assert.Equal(t, "dial tcp: connection refused", results[0].Error)
+	})
+
+	t.Run("should reject an unfiltered replay", func(t *testing.T) {
+		processor, _, _, _ := setup(t)
+
+		_, err := processor.ReplayTo(context.Background(), repositories.WebhookQueueFilter{}, overrideURL)
+
+		assert.ErrorIs(t, err, ErrInvalidReplay)
+	})
+
+	t.Run("should reject an invalid override URL", func(t *testing.T) {
+		processor, _, _, _ := setup(t)
+
+		_, err := processor.ReplayTo(context.Background(), filter, "not a url")
+
+		assert.ErrorIs(t, err, ErrInvalidReplay)
+	})
+
+	t.Run("should wrap a listing error", func(t *testing.T) {
+		processor, mockQueueRepo, _, _ := setup(t)
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().List(ctx, filter, maxReplayWebhooks).Return(nil, errors.New("connection refused"))
+
+		_, err := processor.ReplayTo(ctx, filter, overrideURL)
+
+		assert.ErrorContains(t, err, "failed to list webhooks to replay")
+	})
+}
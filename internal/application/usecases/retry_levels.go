@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+// RetryLevelReachability reports which worker retry levels the active configs' retry policies can route work to
+type RetryLevelReachability struct {
+	// MaxReachableLevel is the highest retry level any active config lets a webhook reach
+	MaxReachableLevel int
+
+	// Unreachable lists worker levels above MaxReachableLevel; their workers never get work
+	Unreachable []int
+
+	// Unserved lists levels above enums.MaxRetryAttempts, up to MaxReachableLevel, that no worker polls
+	// although the last fixed level is polled; webhooks handed on to them stay pending
+	Unserved []int
+}
+
+// CheckRetryLevelReachability compares workerLevels against the active configs' retry limits and logs
+// a warning for each level no webhook can reach, since its workers only add idle polling load, and for
+// each reachable extended level without a worker, since webhooks retrying at it are never claimed.
+// A webhook at retry count N is claimed by the level-N worker and a config allowing L retries reaches
+// levels 0..L, so backoff only changes when a level is reached, never whether it is.
+// With no active configs nothing is reported, as every config may still be created with the global limit
+func (wp *WebhookProcessor) CheckRetryLevelReachability(ctx context.Context, workerLevels []int) (*RetryLevelReachability, error) {
+	configs, err := wp.webhookConfigRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active webhook configs: %w", err)
+	}
+
+	reachability := &RetryLevelReachability{MaxReachableLevel: enums.MaxRetryAttempts}
+	if len(configs) == 0 {
+		return reachability, nil
+	}
+
+	reachability.MaxReachableLevel = 0
+	for _, config := range configs {
+		reachability.MaxReachableLevel = max(reachability.MaxReachableLevel, config.RetryLimit())
+	}
+
+	for _, level := range workerLevels {
+		if level > reachability.MaxReachableLevel && !slices.Contains(reachability.Unreachable, level) {
+			reachability.Unreachable = append(reachability.Unreachable, level)
+		}
+	}
+	slices.Sort(reachability.Unreachable)
+
+	// Only the instance running the last fixed level hands webhooks on to extended levels, so instances
+	// split by WORKER_RETRY_LEVELS are not warned about levels another instance serves
+	if slices.Contains(workerLevels, enums.MaxRetryAttempts) {
+		for level := enums.MaxRetryAttempts + 1; level <= reachability.MaxReachableLevel; level++ {
+			if !slices.Contains(workerLevels, level) {
+				reachability.Unserved = append(reachability.Unserved, level)
+			}
+		}
+	}
+
+	for _, level := range reachability.Unreachable {
+		wp.logger.Log("level", "warn", "msg", "worker retry level is unreachable by every active config's retry policy",
+			"retry_level", level, "max_reachable_level", reachability.MaxReachableLevel, "active_configs", len(configs))
+	}
+	for _, level := range reachability.Unserved {
+		wp.logger.Log("level", "warn", "msg", "retry level reachable by an active config has no worker",
+			"retry_level", level, "max_reachable_level", reachability.MaxReachableLevel, "active_configs", len(configs))
+	}
+
+	return reachability, nil
+}
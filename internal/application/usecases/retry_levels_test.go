@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookProcessor_CheckRetryLevelReachability(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	allLevels := []int{0, 1, 2, 3, 4, 5, 6}
+
+	setup := func(t *testing.T) (*WebhookProcessor, *mocks.MockWebhookConfigRepository, *bytes.Buffer) {
+		ctrl := gomock.NewController(t)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		var logs bytes.Buffer
+		processor := NewWebhookProcessor(mocks.NewMockWebhookQueueRepository(ctrl), mockConfigRepo,
+			mocks.NewMockWebhookService(ctrl), log.NewLogfmtLogger(&logs))
+		return processor, mockConfigRepo, &logs
+	}
+
+	t.Run("should warn about worker levels above every active config's retry limit", func(t *testing.T) {
+		processor, mockConfigRepo, logs := setup(t)
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().ListActive(ctx).Return([]*entities.WebhookConfig{
+			{ID: 1, MaxRetries: intPtr(2)},
+			{ID: 2, RetryScheduleMs: []int{1000, 5000, 30000}},
+		}, nil)
+
+		reachability, err := processor.CheckRetryLevelReachability(ctx, []int{6, 0, 4, 3, 4})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, reachability.MaxReachableLevel)
+		assert.Equal(t, []int{4, 6}, reachability.Unreachable)
+		lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], "level=warn")
+		assert.Contains(t, lines[0], "retry_level=4")
+		assert.Contains(t, lines[0], "max_reachable_level=3")
+		assert.Contains(t, lines[1], "retry_level=6")
+	})
+
+	t.Run("should not warn when a config uses the global retry limit", func(t *testing.T) {
+		processor, mockConfigRepo, logs := setup(t)
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().ListActive(ctx).Return([]*entities.WebhookConfig{
+			{ID: 1, MaxRetries: intPtr(1)},
+			{ID: 2},
+		}, nil)
+
+		reachability, err := processor.CheckRetryLevelReachability(ctx, allLevels)
+
+		require.NoError(t, err)
+		assert.Equal(t, enums.MaxRetryAttempts, reachability.MaxReachableLevel)
+		assert.Empty(t, reachability.Unreachable)
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("should warn about levels a raised retry limit reaches without a worker", func(t *testing.T) {
+		processor, mockConfigRepo, logs := setup(t)
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().ListActive(ctx).Return([]*entities.WebhookConfig{
+			{ID: 1, MaxRetries: intPtr(9)},
+		}, nil)
+
+		reachability, err := processor.CheckRetryLevelReachability(ctx, append(allLevels, 7))
+
+		require.NoError(t, err)
+		assert.Equal(t, 9, reachability.MaxReachableLevel)
+		assert.Empty(t, reachability.Unreachable)
+		assert.Equal(t, []int{8, 9}, reachability.Unserved)
+		assert.Contains(t, logs.String(), "retry level reachable by an active config has no worker")
+	})
+
+	t.Run("should not report extended levels to an instance without the last fixed level", func(t *testing.T) {
+		processor, mockConfigRepo, logs := setup(t)
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().ListActive(ctx).Return([]*entities.WebhookConfig{
+			{ID: 1, MaxRetries: intPtr(9)},
+		}, nil)
+
+		reachability, err := processor.CheckRetryLevelReachability(ctx, []int{0})
+
+		require.NoError(t, err)
+		assert.Empty(t, reachability.Unserved)
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("should not warn when there are no active configs", func(t *testing.T) {
+		processor, mockConfigRepo, logs := setup(t)
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().ListActive(ctx).Return(nil, nil)
+
+		reachability, err := processor.CheckRetryLevelReachability(ctx, allLevels)
+
+		require.NoError(t, err)
+		assert.Empty(t, reachability.Unreachable)
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("should return an error when the configs cannot be listed", func(t *testing.T) {
+		processor, mockConfigRepo, _ := setup(t)
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().ListActive(ctx).Return(nil, errors.New("connection refused"))
+
+		reachability, err := processor.CheckRetryLevelReachability(ctx, allLevels)
+
+		assert.Nil(t, reachability)
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
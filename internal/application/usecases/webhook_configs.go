@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/services"
+)
+
+// ErrInvalidConfig is returned when a webhook config is rejected before it is stored
+var ErrInvalidConfig = errors.New("invalid webhook config")
+
+// validateConfig checks the fields a config needs before webhooks can be delivered with it
+func (wp *WebhookProcessor) validateConfig(config *entities.WebhookConfig) error {
+	if strings.TrimSpace(config.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidConfig)
+	}
+	if err := config.EventType.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if strings.TrimSpace(config.WebhookURL) == "" {
+		return fmt.Errorf("%w: webhook URL is required", ErrInvalidConfig)
+	}
+	if !wp.isValidWebhookURL(config.WebhookURL) {
+		return fmt.Errorf("%w: webhook URL %q must be an absolute http, https or grpc URL", ErrInvalidConfig, config.WebhookURL)
+	}
+	if config.TimeoutMs <= 0 {
+		return fmt.Errorf("%w: timeout must be positive", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// ErrUnreachableConfig is returned when a config's URL does not answer a ping
+var ErrUnreachableConfig = errors.New("webhook URL is unreachable")
+
+// PingConfig checks that the receiver at a valid config's URL answers within the config's timeout
+// An invalid config is rejected with ErrInvalidConfig before anything is sent
+func (wp *WebhookProcessor) PingConfig(ctx context.Context, config *entities.WebhookConfig) (*services.PingResult, error) {
+	if err := wp.validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	result, err := wp.webhookService.Ping(ctx, config.WebhookURL, time.Duration(config.TimeoutMs)*time.Millisecond)
+	if err != nil {
+		wp.logger.Log("level", "info", "msg", "webhook config URL did not answer a ping",
+			"config_id", config.ID, "webhook_url", config.WebhookURL, "error", err)
+		return nil, fmt.Errorf("%w: %v", ErrUnreachableConfig, err)
+	}
+	return result, nil
+}
+
+// CreateConfig validates and stores a new webhook config, returning it with its ID
+func (wp *WebhookProcessor) CreateConfig(ctx context.Context, config *entities.WebhookConfig) (*entities.WebhookConfig, error) {
+	if err := wp.validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	if err := wp.webhookConfigRepo.Create(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to create webhook config: %w", err)
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook config created",
+		"config_id", config.ID, "event_type", config.EventType, "is_active", config.IsActive)
+
+	return config, nil
+}
+
+// UpdateConfig validates and replaces a config's name, event type, URL, timeout and active flag,
+// returning the stored config; its other delivery settings are kept
+func (wp *WebhookProcessor) UpdateConfig(ctx context.Context, config *entities.WebhookConfig) (*entities.WebhookConfig, error) {
+	if err := wp.validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	found, err := wp.webhookConfigRepo.Update(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook config: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %d", ErrConfigNotFound, config.ID)
+	}
+
+	updated, err := wp.webhookConfigRepo.GetByID(ctx, config.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook config: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: %d", ErrConfigNotFound, config.ID)
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook config updated",
+		"config_id", config.ID, "event_type", config.EventType, "is_active", config.IsActive)
+
+	return updated, nil
+}
+
+// DeleteConfig soft-deletes a config so no new webhooks are created for it
+// Webhooks already queued for it are still delivered
+func (wp *WebhookProcessor) DeleteConfig(ctx context.Context, configID int64) error {
+	found, err := wp.webhookConfigRepo.Delete(ctx, configID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook config: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: %d", ErrConfigNotFound, configID)
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook config deleted", "config_id", configID)
+
+	return nil
+}
+
+// ListConfigs returns every config that has not been deleted, ordered by ID
+func (wp *WebhookProcessor) ListConfigs(ctx context.Context) ([]*entities.WebhookConfig, error) {
+	configs, err := wp.webhookConfigRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook configs: %w", err)
+	}
+	return configs, nil
+}
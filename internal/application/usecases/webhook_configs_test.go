@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookProcessor_ConfigManagement(t *testing.T) {
+	setup := func(t *testing.T) (*WebhookProcessor, *mocks.MockWebhookConfigRepository) {
+		ctrl := gomock.NewController(t)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		processor := NewWebhookProcessor(mocks.NewMockWebhookQueueRepository(ctrl), mockConfigRepo,
+			mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		return processor, mockConfigRepo
+	}
+
+	validConfig := func() *entities.WebhookConfig {
+		return &entities.WebhookConfig{
+			Name:       "Ledger",
+			EventType:  enums.EventTypeCredit,
+			WebhookURL: "https://ledger.example.com/hooks",
+			TimeoutMs:  5000,
+			IsActive:   true,
+		}
+	}
+
+	t.Run("should reject an invalid config without storing it", func(t *testing.T) {
+		for name, mutate := range map[string]func(config *entities.WebhookConfig){
+			"empty name":         func(config *entities.WebhookConfig) { config.Name = " " },
+			"unknown event type": func(config *entities.WebhookConfig) { config.EventType = "REFUND" },
+			"empty URL":          func(config *entities.WebhookConfig) { config.WebhookURL = "" },
+			"relative URL":       func(config *entities.WebhookConfig) { config.WebhookURL = "/hooks" },
+			"zero timeout":       func(config *entities.WebhookConfig) { config.TimeoutMs = 0 },
+			"negative timeout":   func(config *entities.WebhookConfig) { config.TimeoutMs = -1 },
+		} {
+			t.Run(name, func(t *testing.T) {
+				processor, _ := setup(t)
+				config := validConfig()
+				mutate(config)
+
+				created, createErr := processor.CreateConfig(context.Background(), config)
+				updated, updateErr := processor.UpdateConfig(context.Background(), config)
+
+				assert.Nil(t, created)
+				assert.ErrorIs(t, createErr, ErrInvalidConfig)
+				assert.Nil(t, updated)
+				assert.ErrorIs(t, updateErr, ErrInvalidConfig)
+
+				ping, pingErr := processor.PingConfig(context.Background(), config)
+				assert.Nil(t, ping)
+				assert.ErrorIs(t, pingErr, ErrInvalidConfig)
+			})
+		}
+	})
+
+	t.Run("should create a valid config and return its ID", func(t *testing.T) {
+		processor, mockConfigRepo := setup(t)
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, config *entities.WebhookConfig) error {
+			config.ID = 12
+			return nil
+		})
+
+		created, err := processor.CreateConfig(ctx, validConfig())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), created.ID)
+	})
+
+	t.Run("should return the stored config after an update", func(t *testing.T) {
+		processor, mockConfigRepo := setup(t)
+		ctx := context.Background()
+		config := validConfig()
+		config.ID = 4
+		stored := *config
+		stored.Headers = map[string]string{"X-Tenant": "acme"}
+		mockConfigRepo.EXPECT().Update(ctx, config).Return(true, nil)
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(4)).Return(&stored, nil)
+
+		updated, err := processor.UpdateConfig(ctx, config)
+
+		require.NoError(t, err)
+		assert.Equal(t, "acme", updated.Headers["X-Tenant"], "settings outside the update are kept")
+	})
+
+	t.Run("should report a missing config on update and delete", func(t *testing.T) {
+		processor, mockConfigRepo := setup(t)
+		ctx := context.Background()
+		config := validConfig()
+		config.ID = 99
+		mockConfigRepo.EXPECT().Update(ctx, config).Return(false, nil)
+		mockConfigRepo.EXPECT().Delete(ctx, int64(99)).Return(false, nil)
+
+		_, updateErr := processor.UpdateConfig(ctx, config)
+		deleteErr := processor.DeleteConfig(ctx, 99)
+
+		assert.ErrorIs(t, updateErr, ErrConfigNotFound)
+		assert.ErrorIs(t, deleteErr, ErrConfigNotFound)
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		processor, mockConfigRepo := setup(t)
+		ctx := context.Background()
+		mockConfigRepo.EXPECT().Delete(ctx, int64(4)).Return(false, errors.New("connection refused"))
+		mockConfigRepo.EXPECT().List(ctx).Return(nil, errors.New("connection refused"))
+
+		deleteErr := processor.DeleteConfig(ctx, 4)
+		configs, listErr := processor.ListConfigs(ctx)
+
+		assert.ErrorContains(t, deleteErr, "connection refused")
+		assert.Nil(t, configs)
+		assert.ErrorContains(t, listErr, "connection refused")
+	})
+}
@@ -2,25 +2,118 @@ package usecases
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-kit/log"
+	"github.com/google/uuid"
 
 	"webhook-processor/internal/domain/entities"
 	"webhook-processor/internal/domain/enums"
 	"webhook-processor/internal/domain/repositories"
 	"webhook-processor/internal/domain/services"
+	"webhook-processor/internal/infrastructure/metrics"
 )
 
+// ErrConfigNotFound is returned when a webhook config does not exist
+var ErrConfigNotFound = errors.New("webhook config not found")
+
+// ErrWebhookNotFound is returned when no webhook has the requested queue ID
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// ErrEventNotFound is returned when no webhook was created for the requested event ID
+var ErrEventNotFound = errors.New("event not found")
+
+// ErrInvalidBulkUpdate is returned when a bulk status update is rejected before touching the database
+var ErrInvalidBulkUpdate = errors.New("invalid bulk status update")
+
+// ErrInvalidForceFail is returned when a force-fail request is rejected before touching the database
+var ErrInvalidForceFail = errors.New("invalid force-fail request")
+
+// ErrInvalidWebhookURL is returned when a create is rejected because its config's URL cannot be delivered to
+var ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+
+// ErrNoActiveConfigs is returned when an event fans out to no configs because none are active for its type
+var ErrNoActiveConfigs = errors.New("no active webhook configs")
+
+// ErrWebhookStatusConflict is returned when a webhook's current status does not allow the requested change
+var ErrWebhookStatusConflict = errors.New("webhook status conflict")
+
+// minRetryDelay is the shortest backoff before a retry
+const minRetryDelay = time.Minute
+
+// retryJitterFraction is how far jitter may move a backoff delay either way, as a fraction of the delay
+const retryJitterFraction = 0.25
+
+// minRescheduleDelay is the shortest delay any reschedule may have; sooner ones are clamped forward
+const minRescheduleDelay = time.Second
+
 // WebhookProcessor handles webhook processing logic
 type WebhookProcessor struct {
 	webhookQueueRepo  repositories.WebhookQueueRepository
 	webhookConfigRepo repositories.WebhookConfigRepository
 	webhookService    services.WebhookService
 	logger            log.Logger
+	now               func() time.Time
+
+	// maxRetryDelay caps how far out NextRetryAt is scheduled in nanoseconds; 0 leaves it uncapped
+	// Atomic so it can be changed by a config reload while workers are scheduling retries
+	maxRetryDelay atomic.Int64
+
+	// seededJitter derives retry jitter from the queue ID and retry count so schedules are reproducible
+	seededJitter bool
+
+	// dnsNotFoundAttempts fails a webhook whose host has not resolved for this many consecutive attempts;
+	// 0 retries DNS failures like any other send error
+	dnsNotFoundAttempts int
+
+	// throttleGrace is how many 429 responses per webhook are rescheduled without consuming a retry
+	throttleGrace int
+
+	// workerHeartbeats records which workers are alive across instances; nil disables heartbeats
+	workerHeartbeats repositories.WorkerHeartbeatRepository
+
+	// statusCallbacks sends configs' on-success and on-failure callbacks; nil sends none
+	statusCallbacks services.StatusCallbackService
+
+	// storeRequests records each attempt's sent body and redacted headers alongside its response
+	storeRequests bool
+
+	// failureNotifier alerts on-call when a webhook permanently fails; nil sends no alerts
+	failureNotifier services.FailureNotifier
+
+	// eventPublisher reports every state transition to the audit topic; nil publishes nothing
+	eventPublisher services.EventPublisher
+
+	// normalizeURLs stores each created webhook's URL in canonical form; normalizeQuery also sorts its query
+	normalizeURLs  bool
+	normalizeQuery bool
+
+	// created wakes level-0 workers in this process when a webhook is created; nil when disabled
+	created chan struct{}
+
+	// backlogGate rejects creates while the pending backlog is too deep; nil admits everything
+	backlogGate *backlogGate
+
+	// metrics records processing anomalies; nil when the caller does not expose metrics (e.g. the API)
+	metrics *metrics.WebhookMetrics
+
+	// configLookupAttempts bounds how often a create tries a config lookup that fails with an error;
+	// each retry waits configLookupBackoff, doubled per attempt
+	configLookupAttempts int
+	configLookupBackoff  time.Duration
+
+	// claimTTL is how long a worker's claim lasts; claims are renewed while a delivery runs and
+	// listed PROCESSING webhooks are flagged once theirs expires. 0 renews nothing
+	claimTTL time.Duration
 }
 
 // NewWebhookProcessor creates a new webhook processor
@@ -35,84 +128,429 @@ func NewWebhookProcessor(
 		webhookConfigRepo: webhookConfigRepo,
 		webhookService:    webhookService,
 		logger:            logger,
+		now:               func() time.Time { return time.Now().UTC() },
+
+		configLookupAttempts: 1,
 	}
 }
 
-// CreateWebhookEntry creates a new webhook queue entry for processing
-func (wp *WebhookProcessor) CreateWebhookEntry(ctx context.Context, eventType enums.EventType, eventID string, configID int64) error {
-	// Get webhook config
-	config, err := wp.webhookConfigRepo.GetByID(ctx, configID)
-	if err != nil {
-		return fmt.Errorf("failed to get webhook config: %w", err)
+// SetMaxRetryDelay caps how far in the future a retry may be scheduled
+func (wp *WebhookProcessor) SetMaxRetryDelay(maxDelay time.Duration) {
+	wp.maxRetryDelay.Store(int64(maxDelay))
+}
+
+// SetSeededJitter derives each retry's jitter from the webhook's queue ID and retry count instead of
+// the global random source, so replaying a webhook reproduces its schedule; call before processing starts
+func (wp *WebhookProcessor) SetSeededJitter(enabled bool) {
+	wp.seededJitter = enabled
+}
+
+// SetDNSNotFoundAttempts fails a webhook once attempts consecutive attempts could not resolve its host
+// and the latest found no such host, since a mistyped hostname never resolves; transient DNS errors
+// are still retried. 0 disables the fast failure; call before processing starts
+func (wp *WebhookProcessor) SetDNSNotFoundAttempts(attempts int) {
+	wp.dnsNotFoundAttempts = attempts
+}
+
+// SetThrottleGrace reschedules up to grace 429 responses per webhook at the same retry level, so
+// a rate-limited receiver cannot exhaust the retry budget; 0 counts every 429 as a failed attempt
+// Retry-After is honored either way; call before processing starts
+func (wp *WebhookProcessor) SetThrottleGrace(grace int) {
+	wp.throttleGrace = grace
+}
+
+// SetWorkerHeartbeats stores worker heartbeats in repo so live workers can be listed across instances
+func (wp *WebhookProcessor) SetWorkerHeartbeats(repo repositories.WorkerHeartbeatRepository) {
+	wp.workerHeartbeats = repo
+}
+
+// SetClaimTTL sets how long worker claims last, so deliveries can renew them and listed PROCESSING
+// webhooks can be flagged; call before processing starts
+func (wp *WebhookProcessor) SetClaimTTL(ttl time.Duration) {
+	wp.claimTTL = ttl
+}
+
+// ClaimTTL returns how long worker claims last; 0 when not set
+func (wp *WebhookProcessor) ClaimTTL() time.Duration {
+	return wp.claimTTL
+}
+
+// SetStatusCallbacks sends a config's on-success and on-failure callbacks through sender when its
+// webhooks are delivered or permanently fail
+func (wp *WebhookProcessor) SetStatusCallbacks(sender services.StatusCallbackService) {
+	wp.statusCallbacks = sender
+}
+
+// SetStoreRequests records what each attempt sent, body and redacted headers, with the attempt;
+// off by default as it can double the stored size of every attempt. Call before processing starts
+func (wp *WebhookProcessor) SetStoreRequests(enabled bool) {
+	wp.storeRequests = enabled
+}
+
+// SetFailureNotifier alerts through notifier whenever a webhook permanently fails
+func (wp *WebhookProcessor) SetFailureNotifier(notifier services.FailureNotifier) {
+	wp.failureNotifier = notifier
+}
+
+// SetEventPublisher reports each webhook state transition through publisher, for audit consumers
+func (wp *WebhookProcessor) SetEventPublisher(publisher services.EventPublisher) {
+	wp.eventPublisher = publisher
+}
+
+// SetURLNormalization validates each created webhook's URL and stores it normalized, so equivalent
+// URLs are stored and logged alike; sortQuery also sorts the query parameters. Call before creates start
+func (wp *WebhookProcessor) SetURLNormalization(enabled, sortQuery bool) {
+	wp.normalizeURLs = enabled
+	wp.normalizeQuery = enabled && sortQuery
+}
+
+// EnableCreateSignal signals CreatedSignal after every successful create, so level-0 workers sharing
+// this processor pick the webhook up without waiting for their next poll; call before workers start
+// Signals are coalesced once buffer of them are waiting
+func (wp *WebhookProcessor) EnableCreateSignal(buffer int) {
+	if buffer < 1 {
+		buffer = 1
 	}
+	wp.created = make(chan struct{}, buffer)
+}
 
-	if config == nil {
-		return fmt.Errorf("webhook config not found: %d", configID)
+// CreatedSignal receives after webhooks are created, or is nil (never receives) when not enabled
+func (wp *WebhookProcessor) CreatedSignal() <-chan struct{} {
+	return wp.created
+}
+
+// SetMetrics enables recording of processing anomalies such as dropped attempt detail
+func (wp *WebhookProcessor) SetMetrics(webhookMetrics *metrics.WebhookMetrics) {
+	wp.metrics = webhookMetrics
+}
+
+// SetConfigLookupRetry retries a create's config lookup up to attempts times in total when it fails
+// with an error, waiting backoff before the first retry and doubling it for each one after
+// A config that does not exist is never retried
+func (wp *WebhookProcessor) SetConfigLookupRetry(attempts int, backoff time.Duration) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	wp.configLookupAttempts = attempts
+	wp.configLookupBackoff = backoff
+}
+
+// EnableBackpressure rejects creates with a BacklogFullError once the pending count reaches
+// highWaterMark, until it drains to lowWaterMark; the count is cached for checkInterval
+func (wp *WebhookProcessor) EnableBackpressure(highWaterMark, lowWaterMark int64, checkInterval, retryAfter time.Duration) {
+	wp.backlogGate = &backlogGate{
+		count: func(ctx context.Context) (int64, error) {
+			return wp.webhookQueueRepo.CountByStatus(ctx, enums.WebhookStatusPending)
+		},
+		highWaterMark: highWaterMark,
+		lowWaterMark:  lowWaterMark,
+		checkInterval: checkInterval,
+		retryAfter:    retryAfter,
+		logger:        wp.logger,
+		now:           wp.now,
 	}
+}
+
+// CreateWebhookEntry creates a new webhook queue entry for processing and returns it with its queue ID
+// A zero configID resolves to the event type's active default config
+// metadata is optional and stored as labels for later filtering; payload is the optional request body
+func (wp *WebhookProcessor) CreateWebhookEntry(ctx context.Context, eventType enums.EventType, eventID string, configID int64, metadata map[string]string, payload string) (*entities.WebhookQueue, error) {
+	if wp.backlogGate != nil {
+		if err := wp.backlogGate.admit(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := entities.ValidateMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	// Get webhook config
+	config, err := wp.resolveConfig(ctx, eventType, configID)
+	if err != nil {
+		return nil, err
+	}
+	configID = config.ID
 
 	if !config.IsActive {
-		return fmt.Errorf("webhook config is not active: %d", configID)
+		return nil, fmt.Errorf("webhook config is not active: %d", configID)
 	}
 
 	// Create webhook queue entry
-	webhook := &entities.WebhookQueue{
+	webhook, err := wp.newPendingWebhook(config, eventType, eventID, metadata, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wp.webhookQueueRepo.Create(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook queue entry: %w", err)
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook entry created",
+		"queue_id", webhook.QueueID, "event_type", eventType, "event_id", eventID)
+	wp.publishTransition(ctx, entities.TransitionCreated, webhook, "", enums.WebhookStatusPending)
+	wp.wakeWorkers()
+
+	return webhook, nil
+}
+
+// CreateWebhookEntriesForEvent fans an event out to every active config for its type, creating one
+// webhook per config in a single transaction so either all are queued or none are
+// Returns ErrNoActiveConfigs when no active config is registered for the event type
+func (wp *WebhookProcessor) CreateWebhookEntriesForEvent(ctx context.Context, eventType enums.EventType, eventID string) ([]*entities.WebhookQueue, error) {
+	if wp.backlogGate != nil {
+		if err := wp.backlogGate.admit(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	configs, err := wp.webhookConfigRepo.GetActiveByEventType(ctx, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active webhook configs: %w", err)
+	}
+
+	webhooks := make([]*entities.WebhookQueue, 0, len(configs))
+	for _, config := range configs {
+		// Checked again, as for a single create, so an inactive config never receives webhooks
+		if !config.IsActive {
+			continue
+		}
+		webhook, err := wp.newPendingWebhook(config, eventType, eventID, nil, "")
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if len(webhooks) == 0 {
+		return nil, fmt.Errorf("%w: event type %s", ErrNoActiveConfigs, eventType)
+	}
+
+	if err := wp.webhookQueueRepo.CreateBatch(ctx, webhooks); err != nil {
+		return nil, fmt.Errorf("failed to create webhook queue entries: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		wp.publishTransition(ctx, entities.TransitionCreated, webhook, "", enums.WebhookStatusPending)
+	}
+	wp.logger.Log("level", "info", "msg", "webhook entries created for event",
+		"event_type", eventType, "event_id", eventID, "webhooks", len(webhooks))
+	wp.wakeWorkers()
+
+	return webhooks, nil
+}
+
+// newPendingWebhook builds a webhook due now for config, normalizing its URL when enabled
+func (wp *WebhookProcessor) newPendingWebhook(config *entities.WebhookConfig, eventType enums.EventType, eventID string, metadata map[string]string, payload string) (*entities.WebhookQueue, error) {
+	webhookURL := config.WebhookURL
+	if wp.normalizeURLs {
+		var err error
+		if webhookURL, err = entities.NormalizeWebhookURL(webhookURL, wp.normalizeQuery); err != nil {
+			return nil, fmt.Errorf("%w: config %d: %v", ErrInvalidWebhookURL, config.ID, err)
+		}
+	}
+
+	now := wp.now()
+	return &entities.WebhookQueue{
 		EventType:   eventType,
 		EventID:     eventID,
-		ConfigID:    configID,
-		WebhookURL:  config.WebhookURL,
+		ConfigID:    config.ID,
+		WebhookURL:  webhookURL,
+		Metadata:    metadata,
+		Payload:     payload,
 		Status:      enums.WebhookStatusPending,
 		RetryCount:  0,
-		NextRetryAt: time.Now().UTC(),
-		CreatedAt:   time.Now().UTC(),
-		UpdatedAt:   time.Now().UTC(),
+		NextRetryAt: now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// wakeWorkers signals that new webhooks were created, when workers wake on create
+func (wp *WebhookProcessor) wakeWorkers() {
+	if wp.created != nil {
+		select {
+		case wp.created <- struct{}{}:
+		default:
+			// Enough workers are already being woken; the webhook is also found by polling
+		}
 	}
+}
 
-	if err := wp.webhookQueueRepo.Create(ctx, webhook); err != nil {
-		return fmt.Errorf("failed to create webhook queue entry: %w", err)
+// resolveConfig loads the requested config, or the event type's default when configID is zero
+func (wp *WebhookProcessor) resolveConfig(ctx context.Context, eventType enums.EventType, configID int64) (*entities.WebhookConfig, error) {
+	if configID == 0 {
+		config, err := wp.lookupConfig(ctx, func() (*entities.WebhookConfig, error) {
+			return wp.webhookConfigRepo.GetDefaultForEventType(ctx, eventType)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default webhook config: %w", err)
+		}
+		if config == nil {
+			return nil, fmt.Errorf("%w: no default config for event type %s", ErrConfigNotFound, eventType)
+		}
+		return config, nil
 	}
 
-	wp.logger.Log("level", "info", "msg", "webhook entry created",
-		"queue_id", webhook.QueueID, "event_type", eventType, "event_id", eventID)
+	config, err := wp.lookupConfig(ctx, func() (*entities.WebhookConfig, error) {
+		return wp.webhookConfigRepo.GetByID(ctx, configID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook config: %w", err)
+	}
+	if config == nil {
+		return nil, fmt.Errorf("%w: %d", ErrConfigNotFound, configID)
+	}
+	return config, nil
+}
 
-	return nil
+// lookupConfig runs a config lookup, retrying errors with backoff up to the configured attempts
+// A nil config without an error means not found and is returned as-is
+func (wp *WebhookProcessor) lookupConfig(ctx context.Context, lookup func() (*entities.WebhookConfig, error)) (*entities.WebhookConfig, error) {
+	backoff := wp.configLookupBackoff
+	for attempt := 1; ; attempt++ {
+		config, err := lookup()
+		if err == nil || attempt >= wp.configLookupAttempts {
+			return config, err
+		}
+
+		wp.logger.Log("level", "warn", "msg", "webhook config lookup failed, retrying",
+			"attempt", attempt, "max_attempts", wp.configLookupAttempts, "backoff", backoff, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w (gave up retrying: %v)", err, ctx.Err())
+		}
+		backoff *= 2
+	}
 }
 
 // ProcessWebhook processes a single webhook
 func (wp *WebhookProcessor) ProcessWebhook(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error {
 	wp.logger.Log("level", "info", "msg", "processing webhook",
 		"queue_id", webhook.QueueID, "worker_id", workerID, "retry_count", webhook.RetryCount)
+	wp.publishTransition(ctx, entities.TransitionProcessingStarted, webhook, enums.WebhookStatusPending, enums.WebhookStatusProcessing)
+
+	// An empty or malformed URL will never succeed - fail immediately without consuming retries
+	if !wp.isValidWebhookURL(webhook.WebhookURL) {
+		return wp.failWithoutAttempt(ctx, webhook, workerID, "invalid webhook URL")
+	}
+
+	// Load the webhook's config for per-config delivery behaviour; fall back to defaults if unavailable
+	config, configErr := wp.webhookConfigRepo.GetByID(ctx, webhook.ConfigID)
+	if configErr != nil {
+		wp.logger.Log("level", "warn", "msg", "failed to load webhook config, using defaults",
+			"queue_id", webhook.QueueID, "config_id", webhook.ConfigID, "error", configErr)
+	}
+	webhook.Config = config
+
+	// Live-URL configs deliver to the config's current URL; an inactive config cancels the delivery
+	if config != nil && config.UseLiveURL {
+		if !config.IsActive {
+			return wp.failWithoutAttempt(ctx, webhook, workerID, "webhook config is inactive")
+		}
+		if config.WebhookURL != webhook.WebhookURL {
+			wp.logger.Log("level", "info", "msg", "using live webhook URL from config",
+				"queue_id", webhook.QueueID, "config_id", config.ID,
+				"pinned_url", webhook.WebhookURL, "live_url", config.WebhookURL)
+			webhook.WebhookURL = config.WebhookURL
+			if !wp.isValidWebhookURL(webhook.WebhookURL) {
+				return wp.failWithoutAttempt(ctx, webhook, workerID, "invalid webhook URL")
+			}
+		}
+	}
+
+	// Hold the delivery until the config's delivery window opens, without consuming a retry
+	if config != nil && config.DeliveryWindow != nil {
+		if nextOpening, deferred := wp.deliveryWindowOpening(config, webhook); deferred {
+			return wp.deferToDeliveryWindow(ctx, webhook, workerID, nextOpening)
+		}
+	}
 
 	// Record attempt start
 	attemptStartTime := time.Now().UTC()
 
 	wp.logger.Log("level", "debug", "msg", "recording retry attempt",
-		"queue_id", webhook.QueueID, "retry_level", webhook.RetryCount,
+		"queue_id", webhook.QueueID, "retry_level", webhook.CurrentRetryLevel(),
 		"retry_count", webhook.RetryCount, "started_at", attemptStartTime)
 
-	// Send webhook
+	// Send webhook, keeping the claim alive however long the timeout, hedge and host spacing make it
+	stopHolding := wp.holdClaim(ctx, webhook, workerID)
 	response, err := wp.webhookService.SendWebhook(ctx, webhook)
+	stopHolding()
+
+	// A request held back by host spacing never left, so it is not an attempt
+	if errors.Is(err, services.ErrSpacingWaitCancelled) {
+		var untilSlot time.Duration
+		if response != nil {
+			untilSlot = response.RetryAfter
+		}
+		return wp.deferForHostSpacing(ctx, webhook, workerID, untilSlot)
+	}
+
 	attemptEndTime := time.Now().UTC()
 	durationMs := attemptEndTime.Sub(attemptStartTime).Milliseconds()
 
 	var httpStatus int
 	var responseBody string
+	var timeoutMs int64
+	var request *entities.AttemptRequest
 	if response != nil {
 		httpStatus = response.StatusCode
 		responseBody = response.Body
+		timeoutMs = response.Timeout.Milliseconds()
+		if wp.storeRequests {
+			request = response.Request
+		}
+		if wp.metrics != nil {
+			wp.metrics.RecordDeliveryDurationRatio(webhook.ConfigID, response.Duration, response.Timeout)
+		}
 	}
 
+	outcome := wp.resolveOutcome(config, response, err)
+
 	var errorMsg string
 	if err != nil {
 		errorMsg = err.Error()
-	} else if response != nil && !wp.isSuccessfulResponse(response.StatusCode) {
-		// HTTP request succeeded but got non-2xx status code - treat as error
+	} else if response != nil && outcome != enums.ResponseOutcomeSuccess {
+		// HTTP request succeeded but the status code is not treated as success - treat as error
 		errorMsg = fmt.Sprintf("HTTP %d: %s", response.StatusCode, http.StatusText(response.StatusCode))
 	}
 
+	errorClass := classifyAttemptError(err, outcome)
+
+	// A host that keeps resolving to nothing will not start resolving on a later retry
+	if errorClass == enums.ErrorClassDNS {
+		notFound := isDNSNotFound(err)
+		if wp.metrics != nil {
+			wp.metrics.RecordDNSError(notFound)
+		}
+		if notFound && wp.dnsNotFoundPersists(webhook) {
+			outcome = enums.ResponseOutcomeFail
+		}
+	}
+
+	// A reset after the request went out may follow a delivery the receiver already processed;
+	// the config decides whether that risk of a duplicate is worth a retry
+	if outcome == enums.ResponseOutcomeRetry && isAmbiguousSendError(err) && !config.RetriesAmbiguousErrors() {
+		wp.logger.Log("level", "warn", "msg", "not retrying ambiguous send error under config policy",
+			"queue_id", webhook.QueueID, "config_id", webhook.ConfigID,
+			"policy", config.AmbiguousErrorPolicy, "idempotent", config.Idempotent, "error", err)
+		outcome = enums.ResponseOutcomeFail
+	}
+
 	// Update retry attempt in database
-	if updateErr := wp.webhookQueueRepo.UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, attemptStartTime, &attemptEndTime, durationMs, httpStatus, responseBody, errorMsg); updateErr != nil {
-		wp.logger.Log("level", "error", "msg", "failed to update retry attempt",
-			"queue_id", webhook.QueueID, "error", updateErr)
+	// A failed write doesn't stop processing; the terminal write below still carries the last status
+	if updateErr := wp.webhookQueueRepo.UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.CurrentRetryLevel(), attemptStartTime, &attemptEndTime, durationMs, timeoutMs, httpStatus, responseBody, errorMsg, errorClass, request); errors.Is(updateErr, repositories.ErrClaimLost) {
+		return wp.abandonLostClaim(webhook, workerID, updateErr)
+	} else if updateErr != nil {
+		wp.logger.Log("level", "error", "msg", "failed to update retry attempt, attempt detail dropped",
+			"queue_id", webhook.QueueID, "retry_level", webhook.CurrentRetryLevel(), "error", updateErr)
+		if wp.metrics != nil {
+			wp.metrics.RecordAttemptDetailDropped(webhook.CurrentRetryLevel())
+		}
+	} else {
+		wp.publishTransition(ctx, entities.TransitionAttemptRecorded, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusProcessing)
 	}
 
 	// Update webhook's last status for tracking
@@ -122,9 +560,12 @@ func (wp *WebhookProcessor) ProcessWebhook(ctx context.Context, webhook *entitie
 	}
 
 	// Check if webhook was successful
-	if err == nil && response != nil && wp.isSuccessfulResponse(response.StatusCode) {
+	if outcome == enums.ResponseOutcomeSuccess {
 		// Mark as completed with the start time of this successful attempt
-		if err := wp.webhookQueueRepo.MarkCompleted(ctx, webhook.ID, attemptStartTime); err != nil {
+		if err := wp.webhookQueueRepo.MarkCompleted(ctx, webhook.ID, workerID, attemptStartTime, httpStatus); err != nil {
+			if errors.Is(err, repositories.ErrClaimLost) {
+				return wp.abandonLostClaim(webhook, workerID, err)
+			}
 			wp.logger.Log("level", "error", "msg", "failed to mark webhook as completed",
 				"queue_id", webhook.QueueID, "error", err)
 			return err
@@ -132,13 +573,27 @@ func (wp *WebhookProcessor) ProcessWebhook(ctx context.Context, webhook *entitie
 
 		wp.logger.Log("level", "info", "msg", "webhook completed successfully",
 			"queue_id", webhook.QueueID, "status_code", response.StatusCode, "retry_count", webhook.RetryCount)
+		wp.publishTransition(ctx, entities.TransitionCompleted, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusCompleted)
+		wp.sendStatusCallback(ctx, webhook, entities.StatusCallbackSucceeded, "")
 
 		return nil
 	}
 
+	// A receiver asking us to slow down has not failed; retry at the same level while grace remains
+	if outcome == enums.ResponseOutcomeRetry && httpStatus == http.StatusTooManyRequests && webhook.ThrottledCount < wp.throttleGrace {
+		return wp.rescheduleThrottled(ctx, webhook, workerID, response.RetryAfter)
+	}
+
 	// Check if we should retry
-	if webhook.CanRetry() {
-		nextRetryAt := wp.calculateNextRetryTime(webhook.RetryCount)
+	if outcome == enums.ResponseOutcomeRetry && webhook.CanRetry() {
+		nextRetryAt := wp.calculateNextRetryTime(webhook.Config, webhook.QueueID, webhook.RetryCount)
+		if response != nil && response.RetryAfter > 0 {
+			// Never retry sooner than the receiver asked
+			if earliest := wp.retryAfterTime(response.RetryAfter); earliest.After(nextRetryAt) {
+				nextRetryAt = earliest
+			}
+		}
+		nextRetryAt = wp.clampNextRetryAt(webhook, nextRetryAt)
 
 		// Update webhook for next retry - preserve all existing fields
 		webhook.RetryCount = webhook.RetryCount + 1
@@ -146,7 +601,10 @@ func (wp *WebhookProcessor) ProcessWebhook(ctx context.Context, webhook *entitie
 		webhook.Status = enums.WebhookStatusPending
 		webhook.UpdatedAt = time.Now().UTC()
 
-		if err := wp.webhookQueueRepo.Update(ctx, webhook); err != nil {
+		if err := wp.webhookQueueRepo.Update(ctx, webhook, workerID); err != nil {
+			if errors.Is(err, repositories.ErrClaimLost) {
+				return wp.abandonLostClaim(webhook, workerID, err)
+			}
 			wp.logger.Log("level", "error", "msg", "failed to update webhook for retry",
 				"queue_id", webhook.QueueID, "error", err)
 			return err
@@ -154,19 +612,27 @@ func (wp *WebhookProcessor) ProcessWebhook(ctx context.Context, webhook *entitie
 
 		wp.logger.Log("level", "info", "msg", "webhook scheduled for retry",
 			"queue_id", webhook.QueueID, "retry_count", webhook.RetryCount, "next_retry_at", nextRetryAt)
+		wp.publishTransition(ctx, entities.TransitionRetryScheduled, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusPending)
 
 		return nil
 	}
 
 	// Mark as permanently failed
 	finalErrorMsg := "max retries exceeded"
-	if err != nil {
+	if outcome == enums.ResponseOutcomeFail && err != nil {
+		finalErrorMsg = fmt.Sprintf("non-retryable error: %s", err.Error())
+	} else if outcome == enums.ResponseOutcomeFail {
+		finalErrorMsg = fmt.Sprintf("non-retryable response: HTTP %d", response.StatusCode)
+	} else if err != nil {
 		finalErrorMsg = fmt.Sprintf("max retries exceeded: %s", err.Error())
 	} else if response != nil {
 		finalErrorMsg = fmt.Sprintf("max retries exceeded: HTTP %d", response.StatusCode)
 	}
 
-	if err := wp.webhookQueueRepo.MarkFailed(ctx, webhook.ID, finalErrorMsg); err != nil {
+	if err := wp.webhookQueueRepo.MarkFailed(ctx, webhook.ID, workerID, finalErrorMsg, httpStatus); err != nil {
+		if errors.Is(err, repositories.ErrClaimLost) {
+			return wp.abandonLostClaim(webhook, workerID, err)
+		}
 		wp.logger.Log("level", "error", "msg", "failed to mark webhook as failed",
 			"queue_id", webhook.QueueID, "error", err)
 		return err
@@ -174,47 +640,496 @@ func (wp *WebhookProcessor) ProcessWebhook(ctx context.Context, webhook *entitie
 
 	wp.logger.Log("level", "error", "msg", "webhook permanently failed",
 		"queue_id", webhook.QueueID, "error", finalErrorMsg)
+	wp.publishTransition(ctx, entities.TransitionFailed, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusFailed)
+	wp.sendStatusCallback(ctx, webhook, entities.StatusCallbackFailed, finalErrorMsg)
+	wp.notifyFailure(ctx, webhook, finalErrorMsg)
 
 	return nil
 }
 
+// rescheduleThrottled puts a throttled webhook back to PENDING at its current retry level, after the
+// receiver's Retry-After or else the level's usual backoff; the attempt detail at that level is overwritten
+func (wp *WebhookProcessor) rescheduleThrottled(ctx context.Context, webhook *entities.WebhookQueue, workerID string, retryAfter time.Duration) error {
+	nextRetryAt := wp.calculateNextRetryTime(webhook.Config, webhook.QueueID, webhook.RetryCount)
+	if retryAfter > 0 {
+		nextRetryAt = wp.retryAfterTime(retryAfter)
+	}
+	nextRetryAt = wp.clampNextRetryAt(webhook, nextRetryAt)
+
+	webhook.ThrottledCount++
+	webhook.NextRetryAt = nextRetryAt
+	webhook.Status = enums.WebhookStatusPending
+	webhook.UpdatedAt = time.Now().UTC()
+
+	if err := wp.webhookQueueRepo.Update(ctx, webhook, workerID); err != nil {
+		if errors.Is(err, repositories.ErrClaimLost) {
+			return wp.abandonLostClaim(webhook, workerID, err)
+		}
+		wp.logger.Log("level", "error", "msg", "failed to reschedule throttled webhook",
+			"queue_id", webhook.QueueID, "error", err)
+		return err
+	}
+	if wp.metrics != nil {
+		wp.metrics.RecordThrottledReschedule(webhook.CurrentRetryLevel())
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook throttled, rescheduled without consuming a retry",
+		"queue_id", webhook.QueueID, "retry_count", webhook.RetryCount,
+		"throttled_count", webhook.ThrottledCount, "next_retry_at", nextRetryAt)
+	wp.publishTransition(ctx, entities.TransitionRetryScheduled, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusPending)
+
+	return nil
+}
+
+// clampNextRetryAt moves a reschedule that is not at least minRescheduleDelay in the future forward,
+// so a bad calculation or setting cannot make workers re-process the webhook in a tight loop
+func (wp *WebhookProcessor) clampNextRetryAt(webhook *entities.WebhookQueue, nextRetryAt time.Time) time.Time {
+	earliest := wp.now().Add(minRescheduleDelay)
+	if !nextRetryAt.Before(earliest) {
+		return nextRetryAt
+	}
+
+	wp.logger.Log("level", "warn", "msg", "next retry was scheduled too soon, clamped forward",
+		"queue_id", webhook.QueueID, "retry_count", webhook.RetryCount,
+		"next_retry_at", nextRetryAt, "clamped_to", earliest)
+	return earliest
+}
+
+// retryAfterTime returns when a receiver's Retry-After delay ends, capped like any other retry delay
+func (wp *WebhookProcessor) retryAfterTime(retryAfter time.Duration) time.Time {
+	if maxDelay := time.Duration(wp.maxRetryDelay.Load()); maxDelay > 0 && retryAfter > maxDelay {
+		retryAfter = maxDelay
+	}
+	return wp.now().Add(retryAfter)
+}
+
+// dnsNotFoundPersists reports whether the current not-found attempt, together with the DNS failures
+// recorded just before it, reaches the configured number of consecutive unresolved attempts
+func (wp *WebhookProcessor) dnsNotFoundPersists(webhook *entities.WebhookQueue) bool {
+	if wp.dnsNotFoundAttempts <= 0 {
+		return false
+	}
+
+	consecutive := 1
+	attempts := webhook.Attempts()
+	for i := len(attempts) - 1; i >= 0; i-- {
+		if attempts[i].RetryLevel >= webhook.CurrentRetryLevel() {
+			continue
+		}
+		if attempts[i].ErrorClass == nil || enums.ErrorClass(*attempts[i].ErrorClass) != enums.ErrorClassDNS {
+			break
+		}
+		consecutive++
+	}
+	return consecutive >= wp.dnsNotFoundAttempts
+}
+
+// failWithoutAttempt marks a webhook as failed before any delivery is attempted
+func (wp *WebhookProcessor) failWithoutAttempt(ctx context.Context, webhook *entities.WebhookQueue, workerID, reason string) error {
+	if err := wp.webhookQueueRepo.MarkFailed(ctx, webhook.ID, workerID, reason, 0); err != nil {
+		if errors.Is(err, repositories.ErrClaimLost) {
+			return wp.abandonLostClaim(webhook, workerID, err)
+		}
+		wp.logger.Log("level", "error", "msg", "failed to mark webhook as failed",
+			"queue_id", webhook.QueueID, "error", err)
+		return err
+	}
+
+	wp.logger.Log("level", "error", "msg", "webhook failed without delivery attempt",
+		"queue_id", webhook.QueueID, "webhook_url", webhook.WebhookURL, "reason", reason)
+	wp.publishTransition(ctx, entities.TransitionFailed, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusFailed)
+	wp.sendStatusCallback(ctx, webhook, entities.StatusCallbackFailed, reason)
+	wp.notifyFailure(ctx, webhook, reason)
+	return nil
+}
+
+// holdClaim renews workerID's claim every third of the claim TTL until the returned func is called,
+// so the reaper cannot hand a delivery still in flight to another worker
+func (wp *WebhookProcessor) holdClaim(ctx context.Context, webhook *entities.WebhookQueue, workerID string) func() {
+	if wp.claimTTL <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(wp.claimTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := wp.webhookQueueRepo.ExtendClaim(ctx, webhook.ID, workerID); err != nil {
+				wp.logger.Log("level", "warn", "msg", "failed to renew webhook claim",
+					"queue_id", webhook.QueueID, "worker_id", workerID, "error", err)
+				if errors.Is(err, repositories.ErrClaimLost) {
+					return
+				}
+			}
+		}
+	}()
+
+	// Waiting for the renewer means no renewal can land after the attempt's outcome is written
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// abandonLostClaim ends an attempt whose claim was reclaimed while it ran; the webhook's new claimant
+// records the outcome, so nothing is published, counted or notified for this one
+func (wp *WebhookProcessor) abandonLostClaim(webhook *entities.WebhookQueue, workerID string, err error) error {
+	wp.logger.Log("level", "warn", "msg", "webhook claim lost, abandoning the attempt's outcome",
+		"queue_id", webhook.QueueID, "worker_id", workerID, "error", err)
+	return nil
+}
+
+// publishTransition reports a state transition to the event publisher, if one is set
+func (wp *WebhookProcessor) publishTransition(ctx context.Context, eventType entities.TransitionEventType, webhook *entities.WebhookQueue, oldStatus, newStatus enums.WebhookStatus) {
+	if wp.eventPublisher == nil {
+		return
+	}
+	// Detached from worker cancellation so the transitions of a drained webhook are still reported
+	wp.eventPublisher.Publish(context.WithoutCancel(ctx), entities.NewTransitionEvent(eventType, webhook, oldStatus, newStatus, wp.now()))
+}
+
+// notifyFailure hands a permanently failed webhook to the failure notifier, if one is set
+func (wp *WebhookProcessor) notifyFailure(ctx context.Context, webhook *entities.WebhookQueue, reason string) {
+	if wp.failureNotifier == nil {
+		return
+	}
+	wp.failureNotifier.NotifyFailure(ctx, entities.NewFailureNotification(webhook, reason, wp.now()))
+}
+
+// sendStatusCallback posts the callback the webhook's config sets for a terminal transition, if any
+// Callbacks are best-effort: a failure is logged and counted but never changes the webhook's outcome.
+// They are posted directly rather than enqueued, so a callback can never cause another callback
+func (wp *WebhookProcessor) sendStatusCallback(ctx context.Context, webhook *entities.WebhookQueue, event entities.StatusCallbackEvent, reason string) {
+	if wp.statusCallbacks == nil {
+		return
+	}
+	callbackURL := webhook.Config.StatusCallbackURL(event)
+	if callbackURL == "" {
+		return
+	}
+
+	// Detached from worker cancellation so a callback for a drained webhook still goes out
+	callback := entities.NewStatusCallback(event, webhook, reason, wp.now())
+	err := wp.statusCallbacks.SendStatusCallback(context.WithoutCancel(ctx), callbackURL, callback)
+	if wp.metrics != nil {
+		wp.metrics.RecordStatusCallback(string(event), err == nil)
+	}
+	if err != nil {
+		wp.logger.Log("level", "warn", "msg", "status callback failed",
+			"queue_id", webhook.QueueID, "config_id", webhook.ConfigID, "event", event, "error", err)
+		return
+	}
+
+	wp.logger.Log("level", "debug", "msg", "status callback sent",
+		"queue_id", webhook.QueueID, "config_id", webhook.ConfigID, "event", event)
+}
+
+// deliveryWindowOpening returns the next window opening when delivery must wait
+// An invalid window is logged and ignored so deliveries are not blocked by bad config
+func (wp *WebhookProcessor) deliveryWindowOpening(config *entities.WebhookConfig, webhook *entities.WebhookQueue) (time.Time, bool) {
+	now := wp.now()
+
+	open, err := config.DeliveryWindow.Allows(now)
+	if err == nil && open {
+		return time.Time{}, false
+	}
+
+	var nextOpening time.Time
+	if err == nil {
+		nextOpening, err = config.DeliveryWindow.NextOpening(now)
+	}
+	if err != nil {
+		wp.logger.Log("level", "warn", "msg", "ignoring invalid delivery window",
+			"queue_id", webhook.QueueID, "config_id", config.ID, "error", err)
+		return time.Time{}, false
+	}
+	return nextOpening, true
+}
+
+// deferToDeliveryWindow reschedules a webhook to the next delivery window opening
+func (wp *WebhookProcessor) deferToDeliveryWindow(ctx context.Context, webhook *entities.WebhookQueue, workerID string, nextOpening time.Time) error {
+	nextOpening = wp.clampNextRetryAt(webhook, nextOpening)
+
+	webhook.Status = enums.WebhookStatusPending
+	webhook.NextRetryAt = nextOpening
+	webhook.UpdatedAt = wp.now()
+
+	if err := wp.webhookQueueRepo.Update(ctx, webhook, workerID); err != nil {
+		if errors.Is(err, repositories.ErrClaimLost) {
+			return wp.abandonLostClaim(webhook, workerID, err)
+		}
+		wp.logger.Log("level", "error", "msg", "failed to reschedule webhook to delivery window",
+			"queue_id", webhook.QueueID, "error", err)
+		return err
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook outside delivery window, rescheduled",
+		"queue_id", webhook.QueueID, "next_retry_at", nextOpening)
+	wp.publishTransition(ctx, entities.TransitionRetryScheduled, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusPending)
+
+	return nil
+}
+
+// deferForHostSpacing puts a webhook whose request was never sent back to PENDING at its current retry
+// level, due once the host's spacing slot comes up; no attempt is recorded and no retry consumed
+func (wp *WebhookProcessor) deferForHostSpacing(ctx context.Context, webhook *entities.WebhookQueue, workerID string, untilSlot time.Duration) error {
+	nextRetryAt := wp.now().Add(max(untilSlot, minRescheduleDelay))
+
+	webhook.Status = enums.WebhookStatusPending
+	webhook.NextRetryAt = nextRetryAt
+	webhook.UpdatedAt = wp.now()
+
+	if err := wp.webhookQueueRepo.Update(ctx, webhook, workerID); err != nil {
+		if errors.Is(err, repositories.ErrClaimLost) {
+			return wp.abandonLostClaim(webhook, workerID, err)
+		}
+		wp.logger.Log("level", "error", "msg", "failed to reschedule webhook held back by host spacing",
+			"queue_id", webhook.QueueID, "error", err)
+		return err
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook held back by host spacing, rescheduled without an attempt",
+		"queue_id", webhook.QueueID, "retry_count", webhook.RetryCount, "next_retry_at", nextRetryAt)
+	wp.publishTransition(ctx, entities.TransitionRetryScheduled, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusPending)
+
+	return nil
+}
+
+// resolveOutcome decides how an attempt ends: per-config status overrides win,
+// otherwise 2xx is success and everything else (including transport errors) is retried
+// A payload template that does not render fails at once, since every retry would render it the same way
+func (wp *WebhookProcessor) resolveOutcome(config *entities.WebhookConfig, response *services.WebhookResponse, sendErr error) enums.ResponseOutcome {
+	if errors.Is(sendErr, services.ErrPayloadTemplate) {
+		return enums.ResponseOutcomeFail
+	}
+	if sendErr != nil || response == nil {
+		return enums.ResponseOutcomeRetry
+	}
+
+	if config != nil {
+		if outcome, ok := config.OutcomeForStatus(response.StatusCode); ok && outcome.IsValid() {
+			return outcome
+		}
+	}
+
+	if response.Outcome.IsValid() {
+		return response.Outcome
+	}
+
+	if wp.isSuccessfulResponse(response.StatusCode) {
+		return enums.ResponseOutcomeSuccess
+	}
+	return enums.ResponseOutcomeRetry
+}
+
+// isValidWebhookURL checks that the URL is an absolute http(s) URL, or a grpc URL for gRPC receivers, with a host
+func (wp *WebhookProcessor) isValidWebhookURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https" || parsed.Scheme == "grpc") && parsed.Host != ""
+}
+
 // isSuccessfulResponse checks if the HTTP status code indicates success
 func (wp *WebhookProcessor) isSuccessfulResponse(statusCode int) bool {
 	return statusCode >= 200 && statusCode < 300
 }
 
-// calculateNextRetryTime calculates the next retry time with simplified progression: 1min, 5min, 10min, 30min
-func (wp *WebhookProcessor) calculateNextRetryTime(retryCount int) time.Time {
-	var baseDelay time.Duration
+// calculateNextRetryTime calculates the next retry time from the config's retry schedule when it has one,
+// and otherwise with simplified progression: 1min, 5min, 10min, 30min
+func (wp *WebhookProcessor) calculateNextRetryTime(config *entities.WebhookConfig, queueID uuid.UUID, retryCount int) time.Time {
+	// An explicit schedule or first-retry delay is followed as written, without jitter or the global minimum delay
+	if delay, ok := fixedRetryDelay(config, retryCount); ok {
+		return wp.now().Add(wp.capRetryDelay(delay))
+	}
+	if config != nil && len(config.RetryScheduleMs) > 0 {
+		if err := config.ValidateRetrySchedule(); err != nil {
+			wp.logger.Log("level", "warn", "msg", "ignoring invalid retry schedule, using global backoff",
+				"queue_id", queueID, "config_id", config.ID, "error", err)
+		}
+	}
+	if config != nil && retryCount == 0 {
+		if err := config.ValidateFirstRetryDelay(); err != nil {
+			wp.logger.Log("level", "warn", "msg", "ignoring invalid first retry delay, using global backoff",
+				"queue_id", queueID, "config_id", config.ID, "error", err)
+		}
+	}
+
+	baseDelay := backoffDelay(retryCount)
+
+	// Add random jitter (±25% of the base delay) to prevent thundering herd
+	jitterRange := float64(baseDelay) * retryJitterFraction
+	jitter := time.Duration(wp.jitterSample(queueID, retryCount)*jitterRange*2 - jitterRange)
+
+	return wp.now().Add(wp.boundBackoffDelay(baseDelay + jitter))
+}
+
+// fixedRetryDelay returns the config's exact delay after a failed attempt at retryCount: its schedule's
+// entry, or for the first retry its first-retry delay; false means the global backoff applies
+func fixedRetryDelay(config *entities.WebhookConfig, retryCount int) (time.Duration, bool) {
+	if delay, ok := config.RetryDelay(retryCount); ok {
+		return delay, true
+	}
+	if retryCount == 0 {
+		return config.FirstRetryDelay()
+	}
+	return 0, false
+}
 
+// backoffDelay returns the global policy's base delay after a failed attempt at retryCount
+func backoffDelay(retryCount int) time.Duration {
 	// Simplified retry progression aligned with worker polling intervals
 	switch retryCount {
 	case 0: // Next retry will be level 1
-		baseDelay = 1 * time.Minute // 1 minute delay
+		return 1 * time.Minute // 1 minute delay
 	case 1: // Next retry will be level 2
-		baseDelay = 5 * time.Minute // 5 minute delay
+		return 5 * time.Minute // 5 minute delay
 	case 2: // Next retry will be level 3
-		baseDelay = 10 * time.Minute // 10 minute delay
+		return 10 * time.Minute // 10 minute delay
 	case 3: // Next retry will be level 4
-		baseDelay = 30 * time.Minute // 30 minute delay
+		return 30 * time.Minute // 30 minute delay
 	case 4: // Next retry will be level 5
-		baseDelay = 60 * time.Minute // 1 hour delay
+		return 60 * time.Minute // 1 hour delay
 	case 5: // Next retry will be level 6 (final)
-		baseDelay = 120 * time.Minute // 2 hour delay
-	default: // Fallback for any edge cases
-		baseDelay = 4 * time.Hour
+		return 120 * time.Minute // 2 hour delay
+	default: // Level 6 onwards, reached only under a config's raised retry limit
+		return 4 * time.Hour
 	}
+}
 
-	// Add random jitter (±25% of the base delay) to prevent thundering herd
-	jitterRange := float64(baseDelay) * 0.25
-	jitter := time.Duration(rand.Float64()*jitterRange*2 - jitterRange)
+// boundBackoffDelay applies the global minimum delay and the max retry delay to a jittered backoff delay
+func (wp *WebhookProcessor) boundBackoffDelay(delay time.Duration) time.Duration {
+	if delay < minRetryDelay {
+		delay = minRetryDelay // Minimum 1 minute delay
+	}
+	return wp.capRetryDelay(delay)
+}
 
-	finalDelay := baseDelay + jitter
-	if finalDelay < time.Minute {
-		finalDelay = time.Minute // Minimum 1 minute delay
+// capRetryDelay limits a delay to the configured max retry delay, if one is set
+func (wp *WebhookProcessor) capRetryDelay(delay time.Duration) time.Duration {
+	if maxDelay := time.Duration(wp.maxRetryDelay.Load()); maxDelay > 0 && delay > maxDelay {
+		return maxDelay // Never disappear for longer than operators expect
 	}
+	return delay
+}
+
+// ProjectedRetry is the expected delay before one future retry, measured from the failure of the attempt
+// before it; a backoff delay is given as the range its jitter can fall in
+type ProjectedRetry struct {
+	RetryLevel int   `json:"retry_level"`
+	MinDelayMs int64 `json:"min_delay_ms"`
+	MaxDelayMs int64 `json:"max_delay_ms"`
+}
 
-	return time.Now().UTC().Add(finalDelay)
+// RemainingRetrySchedule projects the retries a webhook has left under its current policy, with the
+// config attached by the caller; completed and failed webhooks have none
+func (wp *WebhookProcessor) RemainingRetrySchedule(webhook *entities.WebhookQueue) []ProjectedRetry {
+	schedule := []ProjectedRetry{}
+	if webhook.Status == enums.WebhookStatusCompleted || webhook.Status == enums.WebhookStatusFailed {
+		return schedule
+	}
+
+	for retryCount := webhook.RetryCount; retryCount < webhook.Config.RetryLimit(); retryCount++ {
+		var minDelay, maxDelay time.Duration
+		if delay, ok := fixedRetryDelay(webhook.Config, retryCount); ok {
+			minDelay = wp.capRetryDelay(delay)
+			maxDelay = minDelay
+		} else {
+			baseDelay := backoffDelay(retryCount)
+			jitterRange := time.Duration(float64(baseDelay) * retryJitterFraction)
+			minDelay = wp.boundBackoffDelay(baseDelay - jitterRange)
+			maxDelay = wp.boundBackoffDelay(baseDelay + jitterRange)
+		}
+		schedule = append(schedule, ProjectedRetry{
+			RetryLevel: retryCount + 1,
+			MinDelayMs: minDelay.Milliseconds(),
+			MaxDelayMs: maxDelay.Milliseconds(),
+		})
+	}
+	return schedule
+}
+
+// jitterSample returns a value in [0, 1) for spreading a retry, random unless seeded jitter is enabled
+func (wp *WebhookProcessor) jitterSample(queueID uuid.UUID, retryCount int) float64 {
+	if !wp.seededJitter {
+		return rand.Float64()
+	}
+	return seededJitterSample(queueID, retryCount)
+}
+
+// seededJitterSample hashes the queue ID and retry count into a value in [0, 1)
+// Different webhooks land on different values, so load still spreads across them
+func seededJitterSample(queueID uuid.UUID, retryCount int) float64 {
+	hash := fnv.New64a()
+	hash.Write(queueID[:])
+	var level [8]byte
+	binary.BigEndian.PutUint64(level[:], uint64(retryCount))
+	hash.Write(level[:])
+	return float64(hash.Sum64()>>11) / (1 << 53)
+}
+
+// GetConfigStats returns the delivery statistics rollup for a config
+// A config with no terminal deliveries yet returns zeroed stats
+func (wp *WebhookProcessor) GetConfigStats(ctx context.Context, configID int64) (*entities.WebhookConfigStats, error) {
+	config, err := wp.webhookConfigRepo.GetByID(ctx, configID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook config: %w", err)
+	}
+	if config == nil {
+		return nil, fmt.Errorf("%w: %d", ErrConfigNotFound, configID)
+	}
+
+	stats, err := wp.webhookConfigRepo.GetStats(ctx, configID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook config stats: %w", err)
+	}
+	if stats == nil {
+		stats = &entities.WebhookConfigStats{ConfigID: configID}
+	}
+	return stats, nil
+}
+
+// GetWebhooksByEventID returns the webhooks an event fanned out to, one per config, ordered by config
+func (wp *WebhookProcessor) GetWebhooksByEventID(ctx context.Context, eventID string) ([]*entities.WebhookQueue, error) {
+	webhooks, err := wp.webhookQueueRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks for event: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, eventID)
+	}
+	return webhooks, nil
+}
+
+// GetWebhookByQueueID returns a webhook with its current config attached (nil if the config is gone)
+func (wp *WebhookProcessor) GetWebhookByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error) {
+	webhook, err := wp.webhookQueueRepo.GetByQueueID(ctx, queueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	if webhook == nil {
+		return nil, fmt.Errorf("%w: %s", ErrWebhookNotFound, queueID)
+	}
+
+	config, err := wp.webhookConfigRepo.GetByID(ctx, webhook.ConfigID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook config: %w", err)
+	}
+	webhook.Config = config
+
+	return webhook, nil
 }
 
 // GetNextWebhookForProcessing atomically gets and locks ONE webhook for a specific retry level
@@ -222,11 +1137,285 @@ func (wp *WebhookProcessor) GetNextWebhookForProcessing(ctx context.Context, wor
 	return wp.webhookQueueRepo.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
 }
 
-// ResetWebhookToPending resets a webhook back to pending status (for atomic processing)
-func (wp *WebhookProcessor) ResetWebhookToPending(ctx context.Context, webhook *entities.WebhookQueue) error {
-	// Update only the necessary fields while preserving all other data
-	webhook.Status = enums.WebhookStatusPending
-	webhook.UpdatedAt = time.Now().UTC()
+// ManualWorkerID identifies claims taken by on-demand processing rather than a pool worker
+const ManualWorkerID = "manual"
 
-	return wp.webhookQueueRepo.Update(ctx, webhook)
+// ProcessByQueueID claims one PENDING webhook regardless of its schedule, runs a single attempt with
+// the normal state transitions and returns the webhook as stored afterwards
+// A webhook held by another worker, or already finished, is rejected with ErrWebhookStatusConflict
+func (wp *WebhookProcessor) ProcessByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error) {
+	webhook, err := wp.webhookQueueRepo.ClaimByQueueID(ctx, queueID, ManualWorkerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook: %w", err)
+	}
+	if webhook == nil {
+		current, err := wp.webhookQueueRepo.GetByQueueID(ctx, queueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get webhook: %w", err)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("%w: %s", ErrWebhookNotFound, queueID)
+		}
+		if current.Status == enums.WebhookStatusCompleted || current.Status == enums.WebhookStatusFailed {
+			return nil, fmt.Errorf("%w: webhook %s is already %s", ErrWebhookStatusConflict, queueID, current.Status)
+		}
+		return nil, fmt.Errorf("%w: webhook %s is held by another worker", ErrWebhookStatusConflict, queueID)
+	}
+
+	if err := wp.ProcessWebhook(ctx, webhook, ManualWorkerID); err != nil {
+		return nil, fmt.Errorf("failed to process webhook: %w", err)
+	}
+
+	processed, err := wp.webhookQueueRepo.GetByQueueID(ctx, queueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get processed webhook: %w", err)
+	}
+	if processed == nil {
+		return nil, fmt.Errorf("%w: %s", ErrWebhookNotFound, queueID)
+	}
+	return processed, nil
+}
+
+// FindOldestOverdueWebhook returns the pending webhook that has been due the longest as of asOf
+func (wp *WebhookProcessor) FindOldestOverdueWebhook(ctx context.Context, asOf time.Time) (*entities.WebhookQueue, error) {
+	return wp.webhookQueueRepo.FindOldestOverdue(ctx, asOf)
+}
+
+// ReclaimExpiredClaims returns PROCESSING webhooks whose worker claim has expired to PENDING
+func (wp *WebhookProcessor) ReclaimExpiredClaims(ctx context.Context) (int64, error) {
+	reclaimed, err := wp.webhookQueueRepo.ReclaimExpiredClaims(ctx, wp.now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to reclaim expired claims: %w", err)
+	}
+	return reclaimed, nil
+}
+
+// FindCompletionAnomalies returns COMPLETED webhooks finished since the given time that have no successful attempt
+// Attempts whose status the config maps to success are not anomalies
+func (wp *WebhookProcessor) FindCompletionAnomalies(ctx context.Context, since time.Time, limit int) ([]*entities.WebhookQueue, error) {
+	candidates, err := wp.webhookQueueRepo.FindCompletedWithoutSuccess(ctx, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find completed webhooks without success: %w", err)
+	}
+
+	configs := make(map[int64]*entities.WebhookConfig)
+	var anomalies []*entities.WebhookQueue
+	for _, webhook := range candidates {
+		config, ok := configs[webhook.ConfigID]
+		if !ok {
+			config, err = wp.webhookConfigRepo.GetByID(ctx, webhook.ConfigID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get webhook config %d: %w", webhook.ConfigID, err)
+			}
+			configs[webhook.ConfigID] = config
+		}
+
+		if !wp.hasMappedSuccess(config, webhook) {
+			anomalies = append(anomalies, webhook)
+		}
+	}
+	return anomalies, nil
+}
+
+// hasMappedSuccess reports whether any recorded attempt status is mapped to success by the config
+func (wp *WebhookProcessor) hasMappedSuccess(config *entities.WebhookConfig, webhook *entities.WebhookQueue) bool {
+	if config == nil {
+		return false
+	}
+	for _, status := range webhook.AttemptHTTPStatuses() {
+		if outcome, ok := config.OutcomeForStatus(status); ok && outcome == enums.ResponseOutcomeSuccess {
+			return true
+		}
+	}
+	return false
+}
+
+// BulkUpdateStatus moves every webhook matching the filter to newStatus for administrative operations
+// Unscoped filters, missing reasons and disallowed transitions are rejected with ErrInvalidBulkUpdate
+func (wp *WebhookProcessor) BulkUpdateStatus(ctx context.Context, filter repositories.WebhookQueueFilter, newStatus enums.WebhookStatus, reason string) (int64, error) {
+	if reason == "" {
+		return 0, fmt.Errorf("%w: a reason is required", ErrInvalidBulkUpdate)
+	}
+	if filter.IsEmpty() {
+		return 0, fmt.Errorf("%w: filter must narrow the selection", ErrInvalidBulkUpdate)
+	}
+	if len(newStatus.BulkTransitionSources()) == 0 {
+		return 0, fmt.Errorf("%w: cannot bulk transition to %s", ErrInvalidBulkUpdate, newStatus)
+	}
+	for _, status := range filter.Statuses {
+		if !status.CanBulkTransitionTo(newStatus) {
+			return 0, fmt.Errorf("%w: cannot bulk transition from %s to %s", ErrInvalidBulkUpdate, status, newStatus)
+		}
+	}
+
+	updated, err := wp.webhookQueueRepo.BulkUpdateStatus(ctx, filter, newStatus, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bulk update webhook status: %w", err)
+	}
+
+	wp.logger.Log("level", "info", "msg", "bulk updated webhook status",
+		"new_status", newStatus, "updated", updated, "reason", reason)
+
+	return updated, nil
+}
+
+// ForceFailWebhook marks a PENDING or PROCESSING webhook as FAILED for an operator, stopping its retries
+// An attempt in flight is abandoned once it finishes; a finished webhook is rejected with ErrWebhookStatusConflict
+func (wp *WebhookProcessor) ForceFailWebhook(ctx context.Context, queueID uuid.UUID, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("%w: a reason is required", ErrInvalidForceFail)
+	}
+
+	previous, err := wp.webhookQueueRepo.ForceFail(ctx, queueID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to force-fail webhook: %w", err)
+	}
+	if previous == "" {
+		webhook, err := wp.webhookQueueRepo.GetByQueueID(ctx, queueID)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook: %w", err)
+		}
+		if webhook == nil {
+			return fmt.Errorf("%w: %s", ErrWebhookNotFound, queueID)
+		}
+		return fmt.Errorf("%w: webhook %s is already %s", ErrWebhookStatusConflict, queueID, webhook.Status)
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook force-failed",
+		"queue_id", queueID, "previous_status", previous, "reason", reason)
+
+	// Only read back for the retry count the event carries, when events are published at all
+	if wp.eventPublisher != nil {
+		webhook, err := wp.webhookQueueRepo.GetByQueueID(ctx, queueID)
+		if err != nil || webhook == nil {
+			wp.logger.Log("level", "warn", "msg", "failed to read force-failed webhook, cancellation not published",
+				"queue_id", queueID, "error", err)
+			return nil
+		}
+		wp.publishTransition(ctx, entities.TransitionCancelled, webhook, previous, enums.WebhookStatusFailed)
+	}
+
+	return nil
+}
+
+// DeleteWebhook permanently erases a webhook and its stored responses, e.g. for a right-to-erasure request
+// A webhook with an attempt in flight is rejected with ErrWebhookStatusConflict
+func (wp *WebhookProcessor) DeleteWebhook(ctx context.Context, queueID uuid.UUID) error {
+	deleted, err := wp.webhookQueueRepo.HardDelete(ctx, queueID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if !deleted {
+		webhook, err := wp.webhookQueueRepo.GetByQueueID(ctx, queueID)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook: %w", err)
+		}
+		if webhook == nil {
+			return fmt.Errorf("%w: %s", ErrWebhookNotFound, queueID)
+		}
+		return fmt.Errorf("%w: webhook %s has an attempt in flight, retry once it finishes", ErrWebhookStatusConflict, queueID)
+	}
+
+	wp.logger.Log("level", "info", "msg", "webhook permanently deleted", "queue_id", queueID)
+
+	return nil
+}
+
+// RecordWorkerHeartbeat writes a worker's heartbeat; a no-op when heartbeats are not stored
+func (wp *WebhookProcessor) RecordWorkerHeartbeat(ctx context.Context, heartbeat *entities.WorkerHeartbeat) error {
+	if wp.workerHeartbeats == nil {
+		return nil
+	}
+	return wp.workerHeartbeats.Upsert(ctx, heartbeat)
+}
+
+// RemoveWorkerHeartbeat removes a stopped worker's heartbeat; a no-op when heartbeats are not stored
+func (wp *WebhookProcessor) RemoveWorkerHeartbeat(ctx context.Context, workerID string) error {
+	if wp.workerHeartbeats == nil {
+		return nil
+	}
+	return wp.workerHeartbeats.Delete(ctx, workerID)
+}
+
+// ListWorkerHeartbeats returns the heartbeats of every worker across instances
+func (wp *WebhookProcessor) ListWorkerHeartbeats(ctx context.Context) ([]*entities.WorkerHeartbeat, error) {
+	if wp.workerHeartbeats == nil {
+		return nil, fmt.Errorf("worker heartbeats are not enabled")
+	}
+	heartbeats, err := wp.workerHeartbeats.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worker heartbeats: %w", err)
+	}
+	return heartbeats, nil
+}
+
+// ListWebhooks returns one page of webhooks matching the filter, newest first, with the total match count
+func (wp *WebhookProcessor) ListWebhooks(ctx context.Context, filter repositories.WebhookQueueFilter, limit, offset int) ([]*entities.WebhookQueue, int64, error) {
+	webhooks, total, err := wp.webhookQueueRepo.ListPage(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooks, total, nil
+}
+
+// ProcessingWebhook is a webhook currently in PROCESSING with how long its worker has held it
+type ProcessingWebhook struct {
+	Webhook   *entities.WebhookQueue
+	ClaimedAt time.Time
+	ClaimAge  time.Duration
+
+	// Overdue marks a claim the reaper would reclaim: its worker has held it past the claim TTL
+	Overdue bool
+}
+
+// ListProcessingWebhooks returns up to limit webhooks in PROCESSING, oldest first, aged as of now
+// A claim is dated from the row's last update, which renewals leave alone, and is overdue once it expires
+func (wp *WebhookProcessor) ListProcessingWebhooks(ctx context.Context, limit int) ([]ProcessingWebhook, error) {
+	webhooks, err := wp.webhookQueueRepo.List(ctx, repositories.WebhookQueueFilter{
+		Statuses: []enums.WebhookStatus{enums.WebhookStatusProcessing},
+	}, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processing webhooks: %w", err)
+	}
+
+	now := wp.now()
+	processing := make([]ProcessingWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		claimedAt := webhook.UpdatedAt
+		overdue := wp.claimTTL > 0 && now.Sub(claimedAt) > wp.claimTTL
+		if webhook.ClaimExpiresAt != nil {
+			overdue = now.After(*webhook.ClaimExpiresAt)
+		}
+		processing = append(processing, ProcessingWebhook{
+			Webhook:   webhook,
+			ClaimedAt: claimedAt,
+			ClaimAge:  max(now.Sub(claimedAt), 0),
+			Overdue:   overdue,
+		})
+	}
+	return processing, nil
+}
+
+// ResetWebhookToPending resets a webhook claimed by workerID back to pending status (for atomic processing)
+// The row is reset as stored, not from webhook, whose retry count ProcessWebhook may already have
+// advanced for an attempt it never recorded
+func (wp *WebhookProcessor) ResetWebhookToPending(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error {
+	stored, err := wp.webhookQueueRepo.GetByQueueID(ctx, webhook.QueueID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook to reset: %w", err)
+	}
+	if stored == nil {
+		return fmt.Errorf("%w: %s", ErrWebhookNotFound, webhook.QueueID)
+	}
+
+	stored.Status = enums.WebhookStatusPending
+	stored.UpdatedAt = wp.now()
+	if err := wp.webhookQueueRepo.Update(ctx, stored, workerID); err != nil {
+		return err
+	}
+
+	webhook.Status = stored.Status
+	webhook.RetryCount = stored.RetryCount
+	webhook.UpdatedAt = stored.UpdatedAt
+	return nil
 }
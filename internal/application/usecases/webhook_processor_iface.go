@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+
+	"webhook-processor/internal/domain/entities"
+)
+
+// WebhookProcessorIface is the subset of WebhookProcessor that workers depend on
+type WebhookProcessorIface interface {
+	// GetNextWebhookForProcessing locks and returns the next due webhook for a retry level
+	GetNextWebhookForProcessing(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error)
+
+	// ProcessWebhook delivers a locked webhook and records the outcome
+	ProcessWebhook(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error
+
+	// ResetWebhookToPending hands a webhook locked by workerID back to PENDING with its stored retry count
+	ResetWebhookToPending(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error
+
+	// RecordWorkerHeartbeat writes a worker's heartbeat
+	RecordWorkerHeartbeat(ctx context.Context, heartbeat *entities.WorkerHeartbeat) error
+
+	// RemoveWorkerHeartbeat removes a stopped worker's heartbeat
+	RemoveWorkerHeartbeat(ctx context.Context, workerID string) error
+}
+
+var _ WebhookProcessorIface = (*WebhookProcessor)(nil)
@@ -3,21 +3,136 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"webhook-processor/internal/domain/entities"
 	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
 	"webhook-processor/internal/domain/services"
+	"webhook-processor/internal/infrastructure/metrics"
 	"webhook-processor/internal/mocks"
 )
 
+// testMetrics is shared because Prometheus collectors can only be registered once per process
+var testMetrics = metrics.NewWebhookMetrics()
+
+// testWebhookURL is where webhooks built by testWebhook are delivered unless their config says otherwise
+const testWebhookURL = "https://example.com/webhook"
+
+// processorMocks are the mocked dependencies of a processor built by newTestProcessor
+type processorMocks struct {
+	ctrl       *gomock.Controller
+	queueRepo  *mocks.MockWebhookQueueRepository
+	configRepo *mocks.MockWebhookConfigRepository
+	service    *mocks.MockWebhookService
+}
+
+// newTestProcessor returns a processor on fresh mocks, whose expectations are checked when t ends
+func newTestProcessor(t *testing.T) (*WebhookProcessor, *processorMocks) {
+	ctrl := gomock.NewController(t)
+	m := &processorMocks{
+		ctrl:       ctrl,
+		queueRepo:  mocks.NewMockWebhookQueueRepository(ctrl),
+		configRepo: mocks.NewMockWebhookConfigRepository(ctrl),
+		service:    mocks.NewMockWebhookService(ctrl),
+	}
+	return NewWebhookProcessor(m.queueRepo, m.configRepo, m.service, log.NewNopLogger()), m
+}
+
+// testWebhook returns a credit webhook claimed for processing at retryCount
+// A nil config stands for config 1 delivering to testWebhookURL
+func testWebhook(retryCount int, config *entities.WebhookConfig) *entities.WebhookQueue {
+	webhook := &entities.WebhookQueue{
+		ID:         1,
+		QueueID:    uuid.New(),
+		EventType:  enums.EventTypeCredit,
+		ConfigID:   1,
+		WebhookURL: testWebhookURL,
+		Status:     enums.WebhookStatusProcessing,
+		RetryCount: retryCount,
+		Config:     config,
+	}
+	if config != nil {
+		webhook.ConfigID = config.ID
+		if config.WebhookURL != "" {
+			webhook.WebhookURL = config.WebhookURL
+		}
+	}
+	return webhook
+}
+
+// attemptDetailDropped reads the dropped attempt detail counter for a retry level
+func attemptDetailDropped(t *testing.T, retryLevel string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "webhook_attempt_detail_dropped_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "retry_level" && label.GetValue() == retryLevel {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// deliveryDurationRatio reads the sample count and sum of the delivery duration ratio histogram for a config
+func deliveryDurationRatio(t *testing.T, configID string) (uint64, float64) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "webhook_delivery_duration_ratio" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "config_id" && label.GetValue() == configID {
+					return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+				}
+			}
+		}
+	}
+	return 0, 0
+}
+
+// dnsErrors reads the DNS error counter for a kind of DNS failure
+func dnsErrors(t *testing.T, kind string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "webhook_dns_errors_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "kind" && label.GetValue() == kind {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
 func TestWebhookProcessor_CreateWebhookEntry(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -34,6 +149,7 @@ func TestWebhookProcessor_CreateWebhookEntry(t *testing.T) {
 		eventType := enums.EventTypeCredit
 		eventID := "test-event-123"
 		configID := int64(1)
+		queueID := uuid.New()
 
 		// Mock webhook config
 		config := &entities.WebhookConfig{
@@ -67,16 +183,19 @@ func TestWebhookProcessor_CreateWebhookEntry(t *testing.T) {
 
 				// Simulate database setting ID and QueueID
 				webhook.ID = 1
-				webhook.QueueID = uuid.New()
+				webhook.QueueID = queueID
 				return nil
 			}).
 			Times(1)
 
 		// Execute
-		err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID)
+		webhook, err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID, nil, "")
 
 		// Assert
 		assert.NoError(t, err)
+		require.NotNil(t, webhook)
+		assert.Equal(t, queueID, webhook.QueueID)
+		assert.Equal(t, int64(1), webhook.ID)
 	})
 
 	t.Run("should return error when config not found", func(t *testing.T) {
@@ -92,7 +211,7 @@ func TestWebhookProcessor_CreateWebhookEntry(t *testing.T) {
 			Times(1)
 
 		// Execute
-		err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID)
+		_, err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID, nil, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -122,7 +241,7 @@ func TestWebhookProcessor_CreateWebhookEntry(t *testing.T) {
 			Times(1)
 
 		// Execute
-		err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID)
+		_, err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID, nil, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -142,7 +261,7 @@ func TestWebhookProcessor_CreateWebhookEntry(t *testing.T) {
 			Times(1)
 
 		// Execute
-		err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID)
+		_, err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID, nil, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -177,12 +296,353 @@ func TestWebhookProcessor_CreateWebhookEntry(t *testing.T) {
 			Times(1)
 
 		// Execute
-		err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID)
+		_, err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID, nil, "")
 
 		// Assert
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create webhook queue entry")
 	})
+
+	t.Run("should store metadata on the created entry", func(t *testing.T) {
+		ctx := context.Background()
+		metadata := map[string]string{"tenant": "acme", "source": "api"}
+
+		mockConfigRepo.EXPECT().
+			GetByID(ctx, int64(1)).
+			Return(&entities.WebhookConfig{ID: 1, WebhookURL: "https://example.com/webhook", IsActive: true}, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
+				assert.Equal(t, metadata, webhook.Metadata)
+				return nil
+			}).
+			Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "test-event-123", 1, metadata, "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should store the payload on the created entry", func(t *testing.T) {
+		ctx := context.Background()
+		payload := `{"transaction_id":"tx_123","amount":100}`
+
+		mockConfigRepo.EXPECT().
+			GetByID(ctx, int64(1)).
+			Return(&entities.WebhookConfig{ID: 1, WebhookURL: "https://example.com/webhook", IsActive: true}, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
+				assert.Equal(t, payload, webhook.Payload)
+				return nil
+			}).
+			Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "tx_123", 1, nil, payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should date the created entry by the processor's clock", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		processor.now = func() time.Time { return fixedNow }
+		ctx := context.Background()
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).
+			Return(&entities.WebhookConfig{ID: 1, WebhookURL: "https://example.com/webhook", IsActive: true}, nil)
+		m.queueRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
+			assert.Equal(t, fixedNow, webhook.NextRetryAt)
+			assert.Equal(t, fixedNow, webhook.CreatedAt)
+			assert.Equal(t, fixedNow, webhook.UpdatedAt)
+			return nil
+		})
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "test-event-123", 1, nil, "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject invalid metadata before loading the config", func(t *testing.T) {
+		metadata := map[string]string{"": "no key"}
+
+		_, err := processor.CreateWebhookEntry(context.Background(), enums.EventTypeCredit, "test-event-123", 1, metadata, "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid metadata")
+	})
+}
+
+func TestWebhookProcessor_CreateWebhookEntriesForEvent(t *testing.T) {
+	ledger := &entities.WebhookConfig{ID: 3, EventType: enums.EventTypeCredit, WebhookURL: "https://ledger.example.com/hook", IsActive: true}
+	fraud := &entities.WebhookConfig{ID: 5, EventType: enums.EventTypeCredit, WebhookURL: "https://fraud.example.com/hook", IsActive: true}
+	retired := &entities.WebhookConfig{ID: 8, EventType: enums.EventTypeCredit, WebhookURL: "https://retired.example.com/hook", IsActive: false}
+
+	// assignQueueIDs stands in for the insert, giving every webhook an ID and queue ID
+	assignQueueIDs := func(_ context.Context, webhooks []*entities.WebhookQueue) error {
+		for i, webhook := range webhooks {
+			webhook.ID = int64(i + 1)
+			webhook.QueueID = uuid.New()
+		}
+		return nil
+	}
+
+	t.Run("should queue one pending webhook per active config in one batch", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		ctx := context.Background()
+		m.configRepo.EXPECT().GetActiveByEventType(ctx, enums.EventTypeCredit).Return([]*entities.WebhookConfig{ledger, fraud}, nil)
+		m.queueRepo.EXPECT().CreateBatch(ctx, gomock.Len(2)).DoAndReturn(assignQueueIDs)
+
+		webhooks, err := processor.CreateWebhookEntriesForEvent(ctx, enums.EventTypeCredit, "txn-123")
+
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		for i, config := range []*entities.WebhookConfig{ledger, fraud} {
+			assert.Equal(t, config.ID, webhooks[i].ConfigID)
+			assert.Equal(t, config.WebhookURL, webhooks[i].WebhookURL)
+			assert.Equal(t, "txn-123", webhooks[i].EventID)
+			assert.Equal(t, enums.WebhookStatusPending, webhooks[i].Status)
+			assert.NotEqual(t, uuid.Nil, webhooks[i].QueueID)
+		}
+	})
+
+	t.Run("should return ErrNoActiveConfigs without creating anything when none match", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		ctx := context.Background()
+		m.configRepo.EXPECT().GetActiveByEventType(ctx, enums.EventTypeDebit).Return([]*entities.WebhookConfig{}, nil)
+
+		webhooks, err := processor.CreateWebhookEntriesForEvent(ctx, enums.EventTypeDebit, "txn-123")
+
+		assert.Nil(t, webhooks)
+		assert.ErrorIs(t, err, ErrNoActiveConfigs)
+	})
+
+	t.Run("should skip inactive configs among active ones", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		ctx := context.Background()
+		m.configRepo.EXPECT().GetActiveByEventType(ctx, enums.EventTypeCredit).Return([]*entities.WebhookConfig{ledger, retired, fraud}, nil)
+		m.queueRepo.EXPECT().CreateBatch(ctx, gomock.Len(2)).DoAndReturn(assignQueueIDs)
+
+		webhooks, err := processor.CreateWebhookEntriesForEvent(ctx, enums.EventTypeCredit, "txn-123")
+
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		assert.Equal(t, []int64{3, 5}, []int64{webhooks[0].ConfigID, webhooks[1].ConfigID})
+	})
+
+	t.Run("should return ErrNoActiveConfigs when every match is inactive", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		ctx := context.Background()
+		m.configRepo.EXPECT().GetActiveByEventType(ctx, enums.EventTypeCredit).Return([]*entities.WebhookConfig{retired}, nil)
+
+		_, err := processor.CreateWebhookEntriesForEvent(ctx, enums.EventTypeCredit, "txn-123")
+
+		assert.ErrorIs(t, err, ErrNoActiveConfigs)
+	})
+
+	t.Run("should create none when the batch insert fails", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		ctx := context.Background()
+		m.configRepo.EXPECT().GetActiveByEventType(ctx, enums.EventTypeCredit).Return([]*entities.WebhookConfig{ledger, fraud}, nil)
+		m.queueRepo.EXPECT().CreateBatch(ctx, gomock.Len(2)).Return(errors.New("connection refused"))
+
+		webhooks, err := processor.CreateWebhookEntriesForEvent(ctx, enums.EventTypeCredit, "txn-123")
+
+		assert.Nil(t, webhooks)
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
+
+func TestWebhookProcessor_CreateWebhookEntry_DefaultConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	explicitConfig := &entities.WebhookConfig{ID: 3, EventType: enums.EventTypeCredit, WebhookURL: "https://explicit.example.com/hook", IsActive: true}
+	defaultConfig := &entities.WebhookConfig{ID: 9, EventType: enums.EventTypeCredit, WebhookURL: "https://default.example.com/hook", IsActive: true, IsDefault: true}
+
+	t.Run("should use an explicit config without looking up the default", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(3)).Return(explicitConfig, nil).Times(1)
+		mockQueueRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
+				assert.Equal(t, int64(3), webhook.ConfigID)
+				assert.Equal(t, explicitConfig.WebhookURL, webhook.WebhookURL)
+				return nil
+			}).
+			Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-explicit", 3, nil, "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should resolve the event type's default config when no config is given", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockConfigRepo.EXPECT().GetDefaultForEventType(ctx, enums.EventTypeCredit).Return(defaultConfig, nil).Times(1)
+		mockQueueRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
+				assert.Equal(t, int64(9), webhook.ConfigID)
+				assert.Equal(t, defaultConfig.WebhookURL, webhook.WebhookURL)
+				return nil
+			}).
+			Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-default", 0, nil, "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return config not found when no default is available", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockConfigRepo.EXPECT().GetDefaultForEventType(ctx, enums.EventTypeDebit).Return(nil, nil).Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeDebit, "evt-none", 0, nil, "")
+
+		assert.ErrorIs(t, err, ErrConfigNotFound)
+		assert.Contains(t, err.Error(), "no default config for event type DEBIT")
+	})
+
+	t.Run("should return repository error from the default lookup", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockConfigRepo.EXPECT().GetDefaultForEventType(ctx, enums.EventTypeCredit).Return(nil, errors.New("database error")).Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-error", 0, nil, "")
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrConfigNotFound)
+		assert.Contains(t, err.Error(), "failed to get default webhook config")
+	})
+}
+
+func TestWebhookProcessor_CreateWebhookEntry_ConfigLookupRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+	processor.SetConfigLookupRetry(3, time.Millisecond)
+
+	config := &entities.WebhookConfig{ID: 1, EventType: enums.EventTypeCredit, WebhookURL: "https://example.com/hook", IsActive: true}
+
+	t.Run("should succeed when a transient lookup failure clears on retry", func(t *testing.T) {
+		ctx := context.Background()
+
+		gomock.InOrder(
+			mockConfigRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, errors.New("connection reset by peer")),
+			mockConfigRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil),
+		)
+		mockQueueRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-retry", 1, nil, "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail once every attempt has failed", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, errors.New("connection reset by peer")).Times(3)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-exhausted", 1, nil, "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get webhook config")
+	})
+
+	t.Run("should not retry a config that does not exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(2)).Return(nil, nil).Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-missing", 2, nil, "")
+
+		assert.ErrorIs(t, err, ErrConfigNotFound)
+	})
+
+	t.Run("should stop retrying when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		slow := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+		slow.SetConfigLookupRetry(3, time.Hour)
+
+		mockConfigRepo.EXPECT().
+			GetByID(ctx, int64(1)).
+			DoAndReturn(func(ctx context.Context, id int64) (*entities.WebhookConfig, error) {
+				cancel()
+				return nil, errors.New("connection reset by peer")
+			}).
+			Times(1)
+
+		_, err := slow.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-cancelled", 1, nil, "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "context canceled")
+	})
+}
+
+func TestWebhookProcessor_CreateWebhookEntry_URLNormalization(t *testing.T) {
+	// create creates a webhook for a config with webhookURL and returns the URL it was stored with
+	create := func(t *testing.T, webhookURL string, sortQuery bool) (string, error) {
+		ctrl := gomock.NewController(t)
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		processor.SetURLNormalization(true, sortQuery)
+
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).
+			Return(&entities.WebhookConfig{ID: 1, WebhookURL: webhookURL, IsActive: true}, nil)
+		var stored string
+		mockQueueRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
+				stored = webhook.WebhookURL
+				return nil
+			}).MaxTimes(1)
+
+		_, err := processor.CreateWebhookEntry(context.Background(), enums.EventTypeCredit, "evt-1", 1, nil, "")
+		return stored, err
+	}
+
+	t.Run("should store the URL with a lowercased host and no default port", func(t *testing.T) {
+		stored, err := create(t, "HTTPS://Hooks.Example.com:443/In?b=2&a=1", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://hooks.example.com/In?b=2&a=1", stored)
+	})
+
+	t.Run("should sort the query only when asked", func(t *testing.T) {
+		stored, err := create(t, "https://hooks.example.com/in?b=2&a=1", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://hooks.example.com/in?a=1&b=2", stored)
+	})
+
+	t.Run("should reject a config URL that cannot be parsed without creating the webhook", func(t *testing.T) {
+		stored, err := create(t, "https://hooks example.com/%zz", false)
+
+		assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+		assert.ErrorContains(t, err, "config 1")
+		assert.Empty(t, stored)
+	})
 }
 
 func TestWebhookProcessor_ProcessWebhook(t *testing.T) {
@@ -196,6 +656,9 @@ func TestWebhookProcessor_ProcessWebhook(t *testing.T) {
 
 	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
 
+	// Processing looks up the webhook's config; these tests rely on the default outcomes
+	mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
 	t.Run("should process webhook successfully", func(t *testing.T) {
 		ctx := context.Background()
 		workerID := "worker-1"
@@ -230,12 +693,12 @@ func TestWebhookProcessor_ProcessWebhook(t *testing.T) {
 			Times(1)
 
 		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 200, `{"success": true}`, "").
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, `{"success": true}`, "", enums.ErrorClassNone, gomock.Any()).
 			Times(1)
 
 		mockQueueRepo.EXPECT().
-			MarkCompleted(ctx, webhook.ID, gomock.Any()).
+			MarkCompleted(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
 			Times(1)
 
 		// Execute
@@ -279,14 +742,14 @@ func TestWebhookProcessor_ProcessWebhook(t *testing.T) {
 			Times(1)
 
 		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 500, `{"error": "internal server error"}`, gomock.Any()).
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 500, `{"error": "internal server error"}`, gomock.Any(), enums.ErrorClassHTTPStatus, gomock.Any()).
 			Times(1)
 
 		// Should schedule retry (not mark as failed)
 		mockQueueRepo.EXPECT().
-			Update(ctx, gomock.Any()).
-			DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue) error {
+			Update(ctx, gomock.Any(), workerID).
+			DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue, _ string) error {
 				assert.Equal(t, enums.WebhookStatusPending, w.Status)
 				assert.Equal(t, 3, w.RetryCount)         // Incremented
 				assert.True(t, w.NextRetryAt.After(now)) // Scheduled for future
@@ -335,12 +798,12 @@ func TestWebhookProcessor_ProcessWebhook(t *testing.T) {
 			Times(1)
 
 		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 500, `{"error": "internal server error"}`, gomock.Any()).
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 500, `{"error": "internal server error"}`, gomock.Any(), gomock.Any(), gomock.Any()).
 			Times(1)
 
 		mockQueueRepo.EXPECT().
-			MarkFailed(ctx, webhook.ID, gomock.Any()).
+			MarkFailed(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
 			Times(1)
 
 		// Execute
@@ -379,13 +842,13 @@ func TestWebhookProcessor_ProcessWebhook(t *testing.T) {
 			Times(1)
 
 		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 0, "", "connection timeout").
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 0, "", "connection timeout", gomock.Any(), gomock.Any()).
 			Times(1)
 
 		// Should schedule retry
 		mockQueueRepo.EXPECT().
-			Update(ctx, gomock.Any()).
+			Update(ctx, gomock.Any(), workerID).
 			Times(1)
 
 		// Execute
@@ -394,1057 +857,3357 @@ func TestWebhookProcessor_ProcessWebhook(t *testing.T) {
 		// Assert
 		assert.NoError(t, err)
 	})
-}
-
-func TestWebhookProcessor_GetNextWebhookForProcessing(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
-
-	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
 
-	t.Run("should get next webhook for processing", func(t *testing.T) {
+	t.Run("should record the effective attempt timeout", func(t *testing.T) {
 		ctx := context.Background()
 		workerID := "worker-1"
-		retryLevel := 0
-		now := time.Now().UTC()
 
-		expectedWebhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event-123",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusPending,
-			RetryCount:  0,
-			NextRetryAt: now.Add(-time.Minute), // ✅ Ready for processing (past time)
-			CreatedAt:   now.Add(-time.Hour),
-			UpdatedAt:   now.Add(-time.Minute),
+		webhook := &entities.WebhookQueue{
+			ID:         1,
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeCredit,
+			EventID:    "test-event-123",
+			ConfigID:   1,
+			WebhookURL: "https://example.com/webhook",
+			Status:     enums.WebhookStatusProcessing,
+			RetryCount: 3,
 		}
 
-		// Set up expectations
-		mockQueueRepo.EXPECT().
-			GetNextWebhookForProcessing(ctx, workerID, retryLevel).
-			Return(expectedWebhook, nil).
-			Times(1)
-
-		// Execute
-		webhook, err := processor.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
-
-		// Assert
-		assert.NoError(t, err)
-		assert.Equal(t, expectedWebhook, webhook)
+		response := &services.WebhookResponse{
+			StatusCode: 200,
+			Body:       `{"success": true}`,
+			Duration:   time.Millisecond * 500,
+			Timeout:    45 * time.Second,
+		}
 
-		// ✅ Additional assertions for business logic validation
-		assert.True(t, webhook.NextRetryAt.Before(time.Now().UTC().Add(time.Second)),
-			"Webhook should be ready for processing (NextRetryAt in the past)")
-		assert.Equal(t, enums.WebhookStatusPending, webhook.Status,
-			"Webhook should be in PENDING status")
-		assert.Equal(t, retryLevel, webhook.RetryCount,
-			"Webhook retry count should match requested retry level")
-	})
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
 
-	t.Run("should return nil when no webhooks available", func(t *testing.T) {
-		ctx := context.Background()
-		workerID := "worker-1"
-		retryLevel := 0
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), int64(45000), 200, `{"success": true}`, "", gomock.Any(), gomock.Any()).
+			Times(1)
 
-		// Set up expectations
 		mockQueueRepo.EXPECT().
-			GetNextWebhookForProcessing(ctx, workerID, retryLevel).
-			Return(nil, nil). // ✅ No webhooks ready for processing
+			MarkCompleted(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
 			Times(1)
 
-		// Execute
-		webhook, err := processor.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
 
-		// Assert
 		assert.NoError(t, err)
-		assert.Nil(t, webhook, "Should return nil when no webhooks are ready")
 	})
+}
 
-	t.Run("should respect retry level filtering", func(t *testing.T) {
+// TestWebhookProcessor_ProcessWebhook_InvalidURL tests that unusable URLs fail fast without consuming retries
+func TestWebhookProcessor_ProcessWebhook_InvalidURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		webhookURL string
+	}{
+		{name: "empty URL", webhookURL: ""},
+		{name: "malformed URL", webhookURL: "://invalid-url"},
+		{name: "relative URL", webhookURL: "not-a-url"},
+		{name: "unsupported scheme", webhookURL: "ftp://example.com/webhook"},
+		{name: "missing host", webhookURL: "https:///webhook"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should fail immediately on "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+			mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+			mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+			processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+
+			ctx := context.Background()
+			webhook := &entities.WebhookQueue{
+				ID:         1,
+				QueueID:    uuid.New(),
+				EventType:  enums.EventTypeCredit,
+				ConfigID:   1,
+				WebhookURL: tt.webhookURL,
+				Status:     enums.WebhookStatusProcessing,
+				RetryCount: 0,
+			}
+
+			// No send, attempt record or retry scheduling is expected
+			mockQueueRepo.EXPECT().
+				MarkFailed(ctx, webhook.ID, "worker-1", "invalid webhook URL", 0).
+				Return(nil).
+				Times(1)
+
+			err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
+			assert.NoError(t, err)
+			assert.Equal(t, 0, webhook.RetryCount)
+		})
+	}
+}
+
+// TestWebhookProcessor_ProcessWebhook_DeliveryWindow tests delivery versus rescheduling around a config's delivery window
+func TestWebhookProcessor_ProcessWebhook_DeliveryWindow(t *testing.T) {
+	processor, m := newTestProcessor(t)
+
+	// Weekdays 09:00-17:00 UTC
+	config := &entities.WebhookConfig{
+		ID:         1,
+		WebhookURL: "https://example.com/webhook",
+		IsActive:   true,
+		DeliveryWindow: &entities.DeliveryWindow{
+			Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			Hours: []entities.HourRange{{Start: 9, End: 17}},
+		},
+	}
+	m.configRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(config, nil).AnyTimes()
+
+	t.Run("should deliver inside the window", func(t *testing.T) {
 		ctx := context.Background()
-		workerID := "worker-2"
-		retryLevel := 2 // Looking for webhooks with 2 retries
-		now := time.Now().UTC()
+		webhook := testWebhook(2, config)
+		processor.now = func() time.Time { return time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC) } // Monday
 
-		expectedWebhook := &entities.WebhookQueue{
-			ID:          2,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeDebit,
-			EventID:     "retry-event-456",
-			ConfigID:    2,
-			WebhookURL:  "https://example.com/webhook/retry",
-			Status:      enums.WebhookStatusPending,
-			RetryCount:  2,                     // ✅ Matches the requested retry level
-			NextRetryAt: now.Add(-time.Minute), // Ready for processing
-			CreatedAt:   now.Add(-time.Hour),
-			UpdatedAt:   now.Add(-time.Minute),
-		}
+		m.service.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 200, Body: "ok"}, nil).
+			Times(1)
 
-		mockQueueRepo.EXPECT().
-			GetNextWebhookForProcessing(ctx, workerID, retryLevel).
-			Return(expectedWebhook, nil).
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, "worker-1", 2, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, "ok", "", gomock.Any(), gomock.Any()).
 			Times(1)
 
-		// Execute
-		webhook, err := processor.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
+		m.queueRepo.EXPECT().
+			MarkCompleted(ctx, webhook.ID, "worker-1", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
 
-		// Assert
 		assert.NoError(t, err)
-		require.NotNil(t, webhook)
-		assert.Equal(t, retryLevel, webhook.RetryCount,
-			"Returned webhook should match requested retry level")
-		assert.True(t, webhook.NextRetryAt.Before(time.Now().UTC().Add(time.Second)),
-			"Webhook should be ready for processing")
 	})
 
-	t.Run("should return error when repository fails", func(t *testing.T) {
+	t.Run("should reschedule to the next opening outside the window", func(t *testing.T) {
 		ctx := context.Background()
-		workerID := "worker-1"
-		retryLevel := 0
-
-		// Set up expectations
-		mockQueueRepo.EXPECT().
-			GetNextWebhookForProcessing(ctx, workerID, retryLevel).
-			Return(nil, errors.New("database error")).
+		webhook := testWebhook(2, config)
+		processor.now = func() time.Time { return time.Date(2024, 1, 19, 18, 30, 0, 0, time.UTC) } // Friday evening
+
+		// No send is expected; the retry count is left untouched
+		m.queueRepo.EXPECT().
+			Update(ctx, gomock.Any(), "worker-1").
+			DoAndReturn(func(ctx context.Context, updated *entities.WebhookQueue, _ string) error {
+				assert.Equal(t, enums.WebhookStatusPending, updated.Status)
+				assert.Equal(t, time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC), updated.NextRetryAt)
+				assert.Equal(t, 2, updated.RetryCount)
+				return nil
+			}).
 			Times(1)
 
-		// Execute
-		webhook, err := processor.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
 
-		// Assert
-		assert.Error(t, err)
-		assert.Nil(t, webhook)
+		assert.NoError(t, err)
 	})
 }
 
-func TestWebhookProcessor_CalculateRetryDelay(t *testing.T) {
+// TestWebhookProcessor_ProcessWebhook_HostSpacing tests requests held back by host spacing
+func TestWebhookProcessor_ProcessWebhook_HostSpacing(t *testing.T) {
+	fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	spacingErr := fmt.Errorf("%w: %w", services.ErrSpacingWaitCancelled, context.DeadlineExceeded)
+
+	t.Run("should reschedule for the host's slot without recording an attempt or consuming a retry", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return fixedNow }
+		ctx := context.Background()
+		webhook := testWebhook(2, nil)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{Error: context.DeadlineExceeded, RetryAfter: 5 * time.Second}, spacingErr)
+		// No UpdateRetryAttempt is expected
+		m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").
+			DoAndReturn(func(ctx context.Context, updated *entities.WebhookQueue, _ string) error {
+				assert.Equal(t, enums.WebhookStatusPending, updated.Status)
+				assert.Equal(t, fixedNow.Add(5*time.Second), updated.NextRetryAt)
+				assert.Equal(t, 2, updated.RetryCount)
+				return nil
+			})
+
+		assert.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+
+	t.Run("should wait at least the minimum reschedule delay for a cancelled wait", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return fixedNow }
+		ctx := context.Background()
+		webhook := testWebhook(0, nil)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{Error: context.Canceled}, fmt.Errorf("%w: %w", services.ErrSpacingWaitCancelled, context.Canceled))
+		m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").
+			DoAndReturn(func(ctx context.Context, updated *entities.WebhookQueue, _ string) error {
+				assert.Equal(t, fixedNow.Add(minRescheduleDelay), updated.NextRetryAt)
+				assert.Equal(t, 0, updated.RetryCount)
+				return nil
+			})
+
+		assert.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+}
+
+// TestWebhookProcessor_ProcessWebhook_LiveURL tests pinned versus live URL resolution after a config URL change
+func TestWebhookProcessor_ProcessWebhook_LiveURL(t *testing.T) {
+	const (
+		oldURL = "https://example.com/webhok"
+		newURL = "https://example.com/webhook"
+	)
+
 	tests := []struct {
-		name          string
-		retryCount    int
-		minDelay      time.Duration
-		maxDelay      time.Duration
-		expectInRange bool
+		name        string
+		useLiveURL  bool
+		expectedURL string
 	}{
-		{
-			name:          "retry 0 should have minimal delay",
-			retryCount:    0,
-			minDelay:      time.Second * 1,
-			maxDelay:      time.Second * 5,
-			expectInRange: true,
-		},
-		{
-			name:          "retry 3 should have moderate delay",
-			retryCount:    3,
-			minDelay:      time.Second * 8,
-			maxDelay:      time.Second * 32, // Increase tolerance for exponential backoff with jitter
-			expectInRange: true,
-		},
-		{
-			name:          "retry 6 should have maximum delay",
-			retryCount:    6,
-			minDelay:      time.Second * 60,
-			maxDelay:      time.Second * 120,
-			expectInRange: true,
-		},
+		{name: "should deliver to the pinned URL", useLiveURL: false, expectedURL: oldURL},
+		{name: "should deliver to the live URL", useLiveURL: true, expectedURL: newURL},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Test multiple times due to randomness, but be more tolerant
-			validCount := 0
-			totalTests := 10
+			processor, m := newTestProcessor(t)
+
+			ctx := context.Background()
+			webhook := testWebhook(0, nil)
+			webhook.WebhookURL = oldURL
+			config := &entities.WebhookConfig{ID: 1, WebhookURL: newURL, IsActive: true, UseLiveURL: tt.useLiveURL}
+
+			m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+			m.service.EXPECT().
+				SendWebhook(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, sent *entities.WebhookQueue) (*services.WebhookResponse, error) {
+					assert.Equal(t, tt.expectedURL, sent.WebhookURL)
+					return &services.WebhookResponse{StatusCode: 200, Body: "ok"}, nil
+				})
+			m.queueRepo.EXPECT().
+				UpdateRetryAttempt(ctx, webhook.ID, "worker-1", 0, gomock.Any(), gomock.Any(),
+					gomock.Any(), gomock.Any(), 200, "ok", "", gomock.Any(), gomock.Any())
+			m.queueRepo.EXPECT().MarkCompleted(ctx, webhook.ID, "worker-1", gomock.Any(), gomock.Any()).Return(nil)
+
+			err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
+			assert.NoError(t, err)
+		})
+	}
 
-			for i := 0; i < totalTests; i++ {
-				delay := calculateRetryDelay(tt.retryCount)
+	t.Run("should cancel a live-URL webhook whose config is inactive", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
 
-				if tt.expectInRange {
-					if delay >= tt.minDelay && delay <= tt.maxDelay {
-						validCount++
-					}
-				}
-			}
+		ctx := context.Background()
+		webhook := testWebhook(0, nil)
+		webhook.WebhookURL = oldURL
+		config := &entities.WebhookConfig{ID: 1, WebhookURL: newURL, IsActive: false, UseLiveURL: true}
 
-			// Allow at least 70% of tests to pass due to randomness in exponential backoff
-			if tt.expectInRange {
-				assert.True(t, validCount >= totalTests*7/10,
-					"At least 70%% of retry delays should be in range [%v, %v] for retry %d, got %d/%d valid",
-					tt.minDelay, tt.maxDelay, tt.retryCount, validCount, totalTests)
-			}
-		})
-	}
+		// No send is expected
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.queueRepo.EXPECT().MarkFailed(ctx, webhook.ID, "worker-1", "webhook config is inactive", 0).Return(nil)
+
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
+		assert.NoError(t, err)
+	})
 }
 
-// Helper function to test retry delay calculation (this would be in the actual implementation)
-func calculateRetryDelay(retryCount int) time.Duration {
-	// Exponential backoff with jitter: 2^retryCount * base + random jitter
-	base := time.Second * 2
-	exponential := time.Duration(1<<uint(retryCount)) * base
+// TestWebhookProcessor_ProcessWebhook_DroppedAttemptDetail tests that the last status still
+// persists when the attempt record can't be written
+func TestWebhookProcessor_ProcessWebhook_DroppedAttemptDetail(t *testing.T) {
+	t.Run("should write the last status when marking completed", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.SetMetrics(testMetrics)
+		m.configRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(gomock.Any(), int64(1), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("connection reset"))
 
-	// Cap at 60 seconds
-	if exponential > time.Second*60 {
-		exponential = time.Second * 60
-	}
+		ctx := context.Background()
+		webhook := testWebhook(0, nil)
+		before := attemptDetailDropped(t, "0")
 
-	// Add random jitter (0-50% of the exponential delay)
-	jitter := time.Duration(float64(exponential) * 0.5 * (float64(time.Now().UnixNano()%1000) / 1000.0))
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 200, Body: "ok"}, nil)
+		m.queueRepo.EXPECT().MarkCompleted(ctx, webhook.ID, "worker-1", gomock.Any(), 200).Return(nil)
 
-	return exponential + jitter
-}
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
 
-// Benchmark tests
-func BenchmarkWebhookProcessor_CreateWebhookEntry(b *testing.B) {
-	ctrl := gomock.NewController(b)
-	defer ctrl.Finish()
+		assert.NoError(t, err)
+		assert.Equal(t, before+1, attemptDetailDropped(t, "0"))
+	})
 
-	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
+	t.Run("should write the last status and error when marking failed", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.SetMetrics(testMetrics)
+		m.configRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(gomock.Any(), int64(1), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("connection reset"))
 
-	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+		ctx := context.Background()
+		webhook := testWebhook(enums.MaxRetryAttempts, nil)
 
-	config := &entities.WebhookConfig{
-		ID:         1,
-		WebhookURL: "https://example.com/webhook",
-		IsActive:   true,
-	}
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 500, Body: "boom"}, nil)
+		m.queueRepo.EXPECT().MarkFailed(ctx, webhook.ID, "worker-1", "max retries exceeded: HTTP 500", 500).Return(nil)
 
-	mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(config, nil).AnyTimes()
-	mockQueueRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
 
-	ctx := context.Background()
+		assert.NoError(t, err)
+	})
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_ = processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "test-event", 1)
-	}
-}
+	t.Run("should write the last status and error when scheduling a retry", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.SetMetrics(testMetrics)
+		m.configRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(gomock.Any(), int64(1), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("connection reset"))
 
-// TestWebhookProcessor_Constructor tests the constructor
-func TestWebhookProcessor_Constructor(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+		ctx := context.Background()
+		webhook := testWebhook(1, nil)
+
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 503, Body: "unavailable"}, nil)
+		m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").
+			DoAndReturn(func(ctx context.Context, updated *entities.WebhookQueue, _ string) error {
+				assert.Equal(t, 503, updated.LastHTTPStatus)
+				assert.Equal(t, "HTTP 503: Service Unavailable", updated.LastError)
+				return nil
+			})
 
-	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
 
-	t.Run("should create webhook processor successfully", func(t *testing.T) {
-		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
-		assert.NotNil(t, processor)
-		assert.Equal(t, mockQueueRepo, processor.webhookQueueRepo)
-		assert.Equal(t, mockConfigRepo, processor.webhookConfigRepo)
-		assert.Equal(t, mockWebhookService, processor.webhookService)
-		assert.Equal(t, logger, processor.logger)
+		assert.NoError(t, err)
 	})
 }
 
-// TestWebhookProcessor_ProcessWebhook_ComprehensiveCoverage covers all ProcessWebhook scenarios
-func TestWebhookProcessor_ProcessWebhook_ComprehensiveCoverage(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
+// TestWebhookProcessor_ProcessWebhook_StatusOutcomes tests per-config status code outcome overrides
+func TestWebhookProcessor_ProcessWebhook_StatusOutcomes(t *testing.T) {
+	processor, m := newTestProcessor(t)
 
-	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+	config := &entities.WebhookConfig{
+		ID:         1,
+		WebhookURL: testWebhookURL,
+		IsActive:   true,
+		StatusOutcomes: map[int]enums.ResponseOutcome{
+			409: enums.ResponseOutcomeSuccess,
+			400: enums.ResponseOutcomeFail,
+			503: enums.ResponseOutcomeRetry,
+		},
+	}
+	m.configRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(config, nil).AnyTimes()
 
-	t.Run("should handle successful webhook with nil response body", func(t *testing.T) {
+	t.Run("should treat mapped 409 as success", func(t *testing.T) {
 		ctx := context.Background()
-		workerID := "worker-1"
-		now := time.Now().UTC()
+		webhook := testWebhook(1, config)
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  0,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
-
-		// Response with nil fields
-		response := &services.WebhookResponse{
-			StatusCode: 200,
-			Body:       "",
-			Duration:   time.Millisecond * 500,
-			Error:      nil,
-		}
-
-		mockWebhookService.EXPECT().
+		m.service.EXPECT().
 			SendWebhook(ctx, webhook).
-			Return(response, nil).
+			Return(&services.WebhookResponse{StatusCode: 409, Body: "already processed"}, nil).
 			Times(1)
 
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 200, "", "").
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, "worker-1", 1, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 409, "already processed", "", gomock.Any(), gomock.Any()).
 			Times(1)
 
-		mockQueueRepo.EXPECT().
-			MarkCompleted(ctx, webhook.ID, gomock.Any()).
+		m.queueRepo.EXPECT().
+			MarkCompleted(ctx, webhook.ID, "worker-1", gomock.Any(), gomock.Any()).
 			Return(nil).
 			Times(1)
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
 		assert.NoError(t, err)
 	})
 
-	t.Run("should handle HTTP error status codes as failures", func(t *testing.T) {
+	t.Run("should fail mapped 400 without retrying", func(t *testing.T) {
 		ctx := context.Background()
-		workerID := "worker-1"
-		now := time.Now().UTC()
+		webhook := testWebhook(1, config)
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  1,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
+		m.service.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 400, Body: "bad request"}, nil).
+			Times(1)
 
-		// HTTP 404 - should be treated as error
-		response := &services.WebhookResponse{
-			StatusCode: 404,
-			Body:       `{"error": "not found"}`,
-			Duration:   time.Millisecond * 300,
-			Error:      nil,
-		}
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, "worker-1", 1, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 400, "bad request", "HTTP 400: Bad Request", gomock.Any(), gomock.Any()).
+			Times(1)
 
-		mockWebhookService.EXPECT().
+		m.queueRepo.EXPECT().
+			MarkFailed(ctx, webhook.ID, "worker-1", "non-retryable response: HTTP 400", 400).
+			Return(nil).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should retry mapped 503", func(t *testing.T) {
+		ctx := context.Background()
+		webhook := testWebhook(1, config)
+
+		m.service.EXPECT().
 			SendWebhook(ctx, webhook).
-			Return(response, nil).
+			Return(&services.WebhookResponse{StatusCode: 503, Body: "unavailable"}, nil).
 			Times(1)
 
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 404, `{"error": "not found"}`, gomock.Any()).
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, "worker-1", 1, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 503, "unavailable", gomock.Any(), gomock.Any(), gomock.Any()).
 			Times(1)
 
-		mockQueueRepo.EXPECT().
-			Update(ctx, gomock.Any()).
-			DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue) error {
-				assert.Equal(t, enums.WebhookStatusPending, w.Status)
-				assert.Equal(t, 2, w.RetryCount)
+		m.queueRepo.EXPECT().
+			Update(ctx, gomock.Any(), "worker-1").
+			DoAndReturn(func(ctx context.Context, updated *entities.WebhookQueue, _ string) error {
+				assert.Equal(t, 2, updated.RetryCount)
+				assert.Equal(t, enums.WebhookStatusPending, updated.Status)
 				return nil
 			}).
 			Times(1)
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
 		assert.NoError(t, err)
-		assert.Equal(t, 404, webhook.LastHTTPStatus)
-		assert.Contains(t, webhook.LastError, "HTTP 404")
 	})
 
-	t.Run("should handle UpdateRetryAttempt failure", func(t *testing.T) {
+	t.Run("should fall back to defaults for unmapped codes", func(t *testing.T) {
 		ctx := context.Background()
-		workerID := "worker-1"
-		now := time.Now().UTC()
-
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  0,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
-
-		response := &services.WebhookResponse{
-			StatusCode: 200,
-			Body:       `{"success": true}`,
-			Duration:   time.Millisecond * 500,
-			Error:      nil,
-		}
+		webhook := testWebhook(1, config)
 
-		mockWebhookService.EXPECT().
+		m.service.EXPECT().
 			SendWebhook(ctx, webhook).
-			Return(response, nil).
+			Return(&services.WebhookResponse{StatusCode: 201, Body: "created"}, nil).
 			Times(1)
 
-		// UpdateRetryAttempt fails but shouldn't stop processing
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 200, `{"success": true}`, "").
-			Return(errors.New("database update failed")).
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, "worker-1", 1, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 201, "created", "", gomock.Any(), gomock.Any()).
 			Times(1)
 
-		mockQueueRepo.EXPECT().
-			MarkCompleted(ctx, webhook.ID, gomock.Any()).
+		m.queueRepo.EXPECT().
+			MarkCompleted(ctx, webhook.ID, "worker-1", gomock.Any(), gomock.Any()).
 			Return(nil).
 			Times(1)
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
 		assert.NoError(t, err)
 	})
 
-	t.Run("should handle MarkCompleted failure", func(t *testing.T) {
+	t.Run("should use the transport's outcome for unmapped codes", func(t *testing.T) {
 		ctx := context.Background()
-		workerID := "worker-1"
-		now := time.Now().UTC()
-
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  0,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
-
-		response := &services.WebhookResponse{
-			StatusCode: 200,
-			Body:       `{"success": true}`,
-			Duration:   time.Millisecond * 500,
-			Error:      nil,
-		}
+		webhook := testWebhook(1, config)
 
-		mockWebhookService.EXPECT().
+		m.service.EXPECT().
 			SendWebhook(ctx, webhook).
-			Return(response, nil).
+			Return(&services.WebhookResponse{StatusCode: 404, Body: "no such account", Outcome: enums.ResponseOutcomeFail}, nil).
 			Times(1)
 
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 200, `{"success": true}`, "").
-			Return(nil).
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, "worker-1", 1, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 404, "no such account", gomock.Any(), gomock.Any(), gomock.Any()).
 			Times(1)
 
-		// MarkCompleted fails - should return error
-		mockQueueRepo.EXPECT().
-			MarkCompleted(ctx, webhook.ID, gomock.Any()).
-			Return(errors.New("failed to mark completed")).
+		m.queueRepo.EXPECT().
+			MarkFailed(ctx, webhook.ID, "worker-1", "non-retryable response: HTTP 404", 404).
+			Return(nil).
 			Times(1)
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to mark completed")
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
+		assert.NoError(t, err)
 	})
+}
 
-	t.Run("should handle Update failure during retry scheduling", func(t *testing.T) {
-		ctx := context.Background()
-		workerID := "worker-1"
-		now := time.Now().UTC()
+func TestWebhookProcessor_ProcessWebhook_ConfigMaxRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		retryCount int
+		retried    bool
+	}{
+		{name: "should fail a no-retry config after its first attempt", maxRetries: 0, retryCount: 0},
+		{name: "should retry below a reduced max", maxRetries: 2, retryCount: 1, retried: true},
+		{name: "should fail once a reduced max is reached", maxRetries: 2, retryCount: 2},
+		{name: "should keep retrying past the fixed levels under a raised max", maxRetries: 10, retryCount: enums.MaxRetryAttempts, retried: true},
+		{name: "should fail once a raised max is reached", maxRetries: 10, retryCount: 10},
+	}
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  2,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, m := newTestProcessor(t)
+			ctx := context.Background()
+			maxRetries := tt.maxRetries
+			config := &entities.WebhookConfig{ID: 1, WebhookURL: "https://example.com/webhook", IsActive: true, MaxRetries: &maxRetries}
+			webhook := testWebhook(tt.retryCount, config)
+
+			m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+			m.service.EXPECT().SendWebhook(ctx, webhook).
+				Return(&services.WebhookResponse{StatusCode: 503, Body: "unavailable"}, nil)
+			m.queueRepo.EXPECT().
+				UpdateRetryAttempt(ctx, int64(1), "worker-1", tt.retryCount, gomock.Any(), gomock.Any(),
+					gomock.Any(), gomock.Any(), 503, "unavailable", gomock.Any(), gomock.Any(), gomock.Any())
+			if tt.retried {
+				m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").
+					DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue, _ string) error {
+						assert.Equal(t, enums.WebhookStatusPending, w.Status)
+						assert.Equal(t, tt.retryCount+1, w.RetryCount)
+						return nil
+					})
+			} else {
+				m.queueRepo.EXPECT().MarkFailed(ctx, webhook.ID, "worker-1", "max retries exceeded: HTTP 503", 503).Return(nil)
+			}
 
-		response := &services.WebhookResponse{
-			StatusCode: 500,
-			Body:       `{"error": "server error"}`,
-			Duration:   time.Millisecond * 1000,
-			Error:      nil,
-		}
+			err := processor.ProcessWebhook(ctx, webhook, "worker-1")
 
-		mockWebhookService.EXPECT().
-			SendWebhook(ctx, webhook).
-			Return(response, nil).
-			Times(1)
+			assert.NoError(t, err)
+		})
+	}
+}
 
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 500, `{"error": "server error"}`, gomock.Any()).
-			Return(nil).
-			Times(1)
+func TestWebhookProcessor_GetConfigStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-		// Update fails during retry scheduling
-		mockQueueRepo.EXPECT().
-			Update(ctx, gomock.Any()).
-			Return(errors.New("failed to update for retry")).
-			Times(1)
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to update for retry")
-	})
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+	ctx := context.Background()
+	config := &entities.WebhookConfig{ID: 1, WebhookURL: "https://example.com/webhook", IsActive: true}
 
-	t.Run("should handle MarkFailed failure", func(t *testing.T) {
-		ctx := context.Background()
-		workerID := "worker-1"
-		now := time.Now().UTC()
+	t.Run("should return the stored rollup", func(t *testing.T) {
+		stats := &entities.WebhookConfigStats{ConfigID: 1, TotalDelivered: 9, TotalFailed: 1, SuccessRate: 0.9}
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		mockConfigRepo.EXPECT().GetStats(ctx, int64(1)).Return(stats, nil)
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  enums.MaxRetryAttempts, // At max retries
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
+		result, err := processor.GetConfigStats(ctx, 1)
 
-		response := &services.WebhookResponse{
-			StatusCode: 500,
-			Body:       `{"error": "server error"}`,
-			Duration:   time.Millisecond * 1000,
-			Error:      nil,
-		}
+		require.NoError(t, err)
+		assert.Equal(t, stats, result)
+	})
 
-		mockWebhookService.EXPECT().
-			SendWebhook(ctx, webhook).
-			Return(response, nil).
-			Times(1)
+	t.Run("should return zeroed stats before any terminal delivery", func(t *testing.T) {
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		mockConfigRepo.EXPECT().GetStats(ctx, int64(1)).Return(nil, nil)
 
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 500, `{"error": "server error"}`, gomock.Any()).
-			Return(nil).
-			Times(1)
+		result, err := processor.GetConfigStats(ctx, 1)
 
-		// MarkFailed fails
-		mockQueueRepo.EXPECT().
-			MarkFailed(ctx, webhook.ID, gomock.Any()).
-			Return(errors.New("failed to mark as failed")).
-			Times(1)
+		require.NoError(t, err)
+		assert.Equal(t, &entities.WebhookConfigStats{ConfigID: 1}, result)
+	})
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to mark as failed")
+	t.Run("should return not found for an unknown config", func(t *testing.T) {
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(404)).Return(nil, nil)
+
+		result, err := processor.GetConfigStats(ctx, 404)
+
+		assert.ErrorIs(t, err, ErrConfigNotFound)
+		assert.Nil(t, result)
 	})
+}
 
-	t.Run("should handle network error with max retries exceeded", func(t *testing.T) {
+func TestWebhookProcessor_GetNextWebhookForProcessing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	t.Run("should get next webhook for processing", func(t *testing.T) {
 		ctx := context.Background()
 		workerID := "worker-1"
+		retryLevel := 0
 		now := time.Now().UTC()
 
-		webhook := &entities.WebhookQueue{
+		expectedWebhook := &entities.WebhookQueue{
 			ID:          1,
 			QueueID:     uuid.New(),
 			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
+			EventID:     "test-event-123",
 			ConfigID:    1,
 			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  enums.MaxRetryAttempts,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
-
-		networkError := errors.New("connection refused")
-
-		mockWebhookService.EXPECT().
-			SendWebhook(ctx, webhook).
-			Return(nil, networkError).
-			Times(1)
+			Status:      enums.WebhookStatusPending,
+			RetryCount:  0,
+			NextRetryAt: now.Add(-time.Minute), // ✅ Ready for processing (past time)
+			CreatedAt:   now.Add(-time.Hour),
+			UpdatedAt:   now.Add(-time.Minute),
+		}
 
+		// Set up expectations
 		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 0, "", "connection refused").
-			Return(nil).
+			GetNextWebhookForProcessing(ctx, workerID, retryLevel).
+			Return(expectedWebhook, nil).
 			Times(1)
 
+		// Execute
+		webhook, err := processor.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedWebhook, webhook)
+
+		// ✅ Additional assertions for business logic validation
+		assert.True(t, webhook.NextRetryAt.Before(time.Now().UTC().Add(time.Second)),
+			"Webhook should be ready for processing (NextRetryAt in the past)")
+		assert.Equal(t, enums.WebhookStatusPending, webhook.Status,
+			"Webhook should be in PENDING status")
+		assert.Equal(t, retryLevel, webhook.RetryCount,
+			"Webhook retry count should match requested retry level")
+	})
+
+	t.Run("should return nil when no webhooks available", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		retryLevel := 0
+
+		// Set up expectations
 		mockQueueRepo.EXPECT().
-			MarkFailed(ctx, webhook.ID, gomock.Any()).
-			DoAndReturn(func(ctx context.Context, id int64, errorMsg string) error {
-				assert.Contains(t, errorMsg, "max retries exceeded")
-				assert.Contains(t, errorMsg, "connection refused")
-				return nil
-			}).
+			GetNextWebhookForProcessing(ctx, workerID, retryLevel).
+			Return(nil, nil). // ✅ No webhooks ready for processing
 			Times(1)
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		// Execute
+		webhook, err := processor.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
+
+		// Assert
 		assert.NoError(t, err)
+		assert.Nil(t, webhook, "Should return nil when no webhooks are ready")
 	})
 
-	t.Run("should handle HTTP error response with max retries exceeded", func(t *testing.T) {
+	t.Run("should respect retry level filtering", func(t *testing.T) {
 		ctx := context.Background()
-		workerID := "worker-1"
+		workerID := "worker-2"
+		retryLevel := 2 // Looking for webhooks with 2 retries
 		now := time.Now().UTC()
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
+		expectedWebhook := &entities.WebhookQueue{
+			ID:          2,
 			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  enums.MaxRetryAttempts,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
-
-		response := &services.WebhookResponse{
-			StatusCode: 503,
-			Body:       `{"error": "service unavailable"}`,
-			Duration:   time.Millisecond * 1000,
-			Error:      nil,
+			EventType:   enums.EventTypeDebit,
+			EventID:     "retry-event-456",
+			ConfigID:    2,
+			WebhookURL:  "https://example.com/webhook/retry",
+			Status:      enums.WebhookStatusPending,
+			RetryCount:  2,                     // ✅ Matches the requested retry level
+			NextRetryAt: now.Add(-time.Minute), // Ready for processing
+			CreatedAt:   now.Add(-time.Hour),
+			UpdatedAt:   now.Add(-time.Minute),
 		}
 
-		mockWebhookService.EXPECT().
-			SendWebhook(ctx, webhook).
-			Return(response, nil).
-			Times(1)
-
 		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 503, `{"error": "service unavailable"}`, gomock.Any()).
-			Return(nil).
+			GetNextWebhookForProcessing(ctx, workerID, retryLevel).
+			Return(expectedWebhook, nil).
 			Times(1)
 
-		mockQueueRepo.EXPECT().
-			MarkFailed(ctx, webhook.ID, gomock.Any()).
-			DoAndReturn(func(ctx context.Context, id int64, errorMsg string) error {
-				assert.Contains(t, errorMsg, "max retries exceeded")
-				assert.Contains(t, errorMsg, "HTTP 503")
-				return nil
-			}).
-			Times(1)
+		// Execute
+		webhook, err := processor.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		// Assert
 		assert.NoError(t, err)
+		require.NotNil(t, webhook)
+		assert.Equal(t, retryLevel, webhook.RetryCount,
+			"Returned webhook should match requested retry level")
+		assert.True(t, webhook.NextRetryAt.Before(time.Now().UTC().Add(time.Second)),
+			"Webhook should be ready for processing")
 	})
-}
-
-// TestWebhookProcessor_HelperMethods tests helper methods
-func TestWebhookProcessor_HelperMethods(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
 
-	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		retryLevel := 0
 
-	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+		// Set up expectations
+		mockQueueRepo.EXPECT().
+			GetNextWebhookForProcessing(ctx, workerID, retryLevel).
+			Return(nil, errors.New("database error")).
+			Times(1)
 
-	t.Run("isSuccessfulResponse should identify successful status codes", func(t *testing.T) {
-		testCases := []struct {
-			statusCode int
-			expected   bool
-		}{
-			{200, true},
-			{201, true},
-			{202, true},
-			{204, true},
-			{299, true},
-			{199, false},
-			{300, false},
-			{400, false},
-			{404, false},
-			{500, false},
-			{503, false},
-		}
+		// Execute
+		webhook, err := processor.GetNextWebhookForProcessing(ctx, workerID, retryLevel)
 
-		for _, tc := range testCases {
-			result := processor.isSuccessfulResponse(tc.statusCode)
-			assert.Equal(t, tc.expected, result, "Status code %d should return %v", tc.statusCode, tc.expected)
-		}
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, webhook)
 	})
 }
 
-// TestWebhookProcessor_CalculateNextRetryTime tests the retry time calculation
-func TestWebhookProcessor_CalculateNextRetryTime(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
-
-	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
-
+func TestWebhookProcessor_CalculateRetryDelay(t *testing.T) {
 	tests := []struct {
-		name        string
-		retryCount  int
-		expectedMin time.Duration
-		expectedMax time.Duration
+		name          string
+		retryCount    int
+		minDelay      time.Duration
+		maxDelay      time.Duration
+		expectInRange bool
 	}{
 		{
-			name:        "retry 0 should have 1 minute base delay",
-			retryCount:  0,
-			expectedMin: 45 * time.Second, // 1min - 25% jitter
-			expectedMax: 75 * time.Second, // 1min + 25% jitter
-		},
-		{
-			name:        "retry 1 should have 5 minute base delay",
-			retryCount:  1,
-			expectedMin: 225 * time.Second, // 5min - 25% jitter
-			expectedMax: 375 * time.Second, // 5min + 25% jitter
-		},
-		{
-			name:        "retry 2 should have 10 minute base delay",
-			retryCount:  2,
-			expectedMin: 450 * time.Second, // 10min - 25% jitter
-			expectedMax: 750 * time.Second, // 10min + 25% jitter
-		},
-		{
-			name:        "retry 3 should have 30 minute base delay",
-			retryCount:  3,
-			expectedMin: 1350 * time.Second, // 30min - 25% jitter
-			expectedMax: 2250 * time.Second, // 30min + 25% jitter
-		},
-		{
-			name:        "retry 4 should have 60 minute base delay",
-			retryCount:  4,
-			expectedMin: 2700 * time.Second, // 60min - 25% jitter
-			expectedMax: 4500 * time.Second, // 60min + 25% jitter
+			name:          "retry 0 should have minimal delay",
+			retryCount:    0,
+			minDelay:      time.Second * 1,
+			maxDelay:      time.Second * 5,
+			expectInRange: true,
 		},
 		{
-			name:        "retry 5 should have 120 minute base delay",
-			retryCount:  5,
-			expectedMin: 5400 * time.Second, // 120min - 25% jitter
-			expectedMax: 9000 * time.Second, // 120min + 25% jitter
+			name:          "retry 3 should have moderate delay",
+			retryCount:    3,
+			minDelay:      time.Second * 8,
+			maxDelay:      time.Second * 32, // Increase tolerance for exponential backoff with jitter
+			expectInRange: true,
 		},
 		{
-			name:        "retry 6+ should have 4 hour fallback delay",
-			retryCount:  10,
-			expectedMin: 10800 * time.Second, // 4hr - 25% jitter
-			expectedMax: 18000 * time.Second, // 4hr + 25% jitter
+			name:          "retry 6 should have maximum delay",
+			retryCount:    6,
+			minDelay:      time.Second * 60,
+			maxDelay:      time.Second * 120,
+			expectInRange: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			now := time.Now().UTC()
-
-			// Test multiple times to account for jitter
+			// Test multiple times due to randomness, but be more tolerant
 			validCount := 0
-			totalTests := 20
+			totalTests := 10
 
 			for i := 0; i < totalTests; i++ {
-				nextRetryTime := processor.calculateNextRetryTime(tt.retryCount)
-				delay := nextRetryTime.Sub(now)
+				delay := calculateRetryDelay(tt.retryCount)
 
-				if delay >= tt.expectedMin && delay <= tt.expectedMax {
-					validCount++
+				if tt.expectInRange {
+					if delay >= tt.minDelay && delay <= tt.maxDelay {
+						validCount++
+					}
 				}
 			}
 
-			// At least 70% should be within expected range due to jitter
-			assert.True(t, validCount >= totalTests*7/10,
-				"At least 70%% of retry delays should be in range [%v, %v] for retry %d, got %d/%d valid",
-				tt.expectedMin, tt.expectedMax, tt.retryCount, validCount, totalTests)
+			// Allow at least 70% of tests to pass due to randomness in exponential backoff
+			if tt.expectInRange {
+				assert.True(t, validCount >= totalTests*7/10,
+					"At least 70%% of retry delays should be in range [%v, %v] for retry %d, got %d/%d valid",
+					tt.minDelay, tt.maxDelay, tt.retryCount, validCount, totalTests)
+			}
 		})
 	}
+}
+
+// Helper function to test retry delay calculation (this would be in the actual implementation)
+func calculateRetryDelay(retryCount int) time.Duration {
+	// Exponential backoff with jitter: 2^retryCount * base + random jitter
+	base := time.Second * 2
+	exponential := time.Duration(1<<uint(retryCount)) * base
+
+	// Cap at 60 seconds
+	if exponential > time.Second*60 {
+		exponential = time.Second * 60
+	}
+
+	// Add random jitter (0-50% of the exponential delay)
+	jitter := time.Duration(float64(exponential) * 0.5 * (float64(time.Now().UnixNano()%1000) / 1000.0))
+
+	return exponential + jitter
+}
+
+// Benchmark tests
+func BenchmarkWebhookProcessor_CreateWebhookEntry(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	config := &entities.WebhookConfig{
+		ID:         1,
+		WebhookURL: "https://example.com/webhook",
+		IsActive:   true,
+	}
+
+	mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(config, nil).AnyTimes()
+	mockQueueRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "test-event", 1, nil, "")
+	}
+}
+
+// TestWebhookProcessor_Constructor tests the constructor
+func TestWebhookProcessor_Constructor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	t.Run("should create webhook processor successfully", func(t *testing.T) {
+		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+		assert.NotNil(t, processor)
+		assert.Equal(t, mockQueueRepo, processor.webhookQueueRepo)
+		assert.Equal(t, mockConfigRepo, processor.webhookConfigRepo)
+		assert.Equal(t, mockWebhookService, processor.webhookService)
+		assert.Equal(t, logger, processor.logger)
+	})
+}
+
+// TestWebhookProcessor_ProcessWebhook_ComprehensiveCoverage covers all ProcessWebhook scenarios
+func TestWebhookProcessor_ProcessWebhook_ComprehensiveCoverage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	// Processing looks up the webhook's config; these tests rely on the default outcomes
+	mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	t.Run("should handle successful webhook with nil response body", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  0,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		// Response with nil fields
+		response := &services.WebhookResponse{
+			StatusCode: 200,
+			Body:       "",
+			Duration:   time.Millisecond * 500,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, "", "", gomock.Any(), gomock.Any()).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			MarkCompleted(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should handle HTTP error status codes as failures", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  1,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		// HTTP 404 - should be treated as error
+		response := &services.WebhookResponse{
+			StatusCode: 404,
+			Body:       `{"error": "not found"}`,
+			Duration:   time.Millisecond * 300,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 404, `{"error": "not found"}`, gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			Update(ctx, gomock.Any(), workerID).
+			DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue, _ string) error {
+				assert.Equal(t, enums.WebhookStatusPending, w.Status)
+				assert.Equal(t, 2, w.RetryCount)
+				return nil
+			}).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 404, webhook.LastHTTPStatus)
+		assert.Contains(t, webhook.LastError, "HTTP 404")
+	})
+
+	t.Run("should handle UpdateRetryAttempt failure", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  0,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		response := &services.WebhookResponse{
+			StatusCode: 200,
+			Body:       `{"success": true}`,
+			Duration:   time.Millisecond * 500,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		// UpdateRetryAttempt fails but shouldn't stop processing
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, `{"success": true}`, "", gomock.Any(), gomock.Any()).
+			Return(errors.New("database update failed")).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			MarkCompleted(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should handle MarkCompleted failure", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  0,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		response := &services.WebhookResponse{
+			StatusCode: 200,
+			Body:       `{"success": true}`,
+			Duration:   time.Millisecond * 500,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, `{"success": true}`, "", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		// MarkCompleted fails - should return error
+		mockQueueRepo.EXPECT().
+			MarkCompleted(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
+			Return(errors.New("failed to mark completed")).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to mark completed")
+	})
+
+	t.Run("should handle Update failure during retry scheduling", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  2,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		response := &services.WebhookResponse{
+			StatusCode: 500,
+			Body:       `{"error": "server error"}`,
+			Duration:   time.Millisecond * 1000,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 500, `{"error": "server error"}`, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		// Update fails during retry scheduling
+		mockQueueRepo.EXPECT().
+			Update(ctx, gomock.Any(), workerID).
+			Return(errors.New("failed to update for retry")).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update for retry")
+	})
+
+	t.Run("should handle MarkFailed failure", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  enums.MaxRetryAttempts, // At max retries
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		response := &services.WebhookResponse{
+			StatusCode: 500,
+			Body:       `{"error": "server error"}`,
+			Duration:   time.Millisecond * 1000,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 500, `{"error": "server error"}`, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		// MarkFailed fails
+		mockQueueRepo.EXPECT().
+			MarkFailed(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
+			Return(errors.New("failed to mark as failed")).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to mark as failed")
+	})
+
+	t.Run("should handle network error with max retries exceeded", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  enums.MaxRetryAttempts,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		networkError := errors.New("connection refused")
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(nil, networkError).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 0, "", "connection refused", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			MarkFailed(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id int64, _ string, errorMsg string, _ int) error {
+				assert.Contains(t, errorMsg, "max retries exceeded")
+				assert.Contains(t, errorMsg, "connection refused")
+				return nil
+			}).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should handle HTTP error response with max retries exceeded", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  enums.MaxRetryAttempts,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		response := &services.WebhookResponse{
+			StatusCode: 503,
+			Body:       `{"error": "service unavailable"}`,
+			Duration:   time.Millisecond * 1000,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 503, `{"error": "service unavailable"}`, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			MarkFailed(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id int64, _ string, errorMsg string, _ int) error {
+				assert.Contains(t, errorMsg, "max retries exceeded")
+				assert.Contains(t, errorMsg, "HTTP 503")
+				return nil
+			}).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.NoError(t, err)
+	})
+}
+
+// TestWebhookProcessor_HelperMethods tests helper methods
+func TestWebhookProcessor_HelperMethods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	t.Run("isSuccessfulResponse should identify successful status codes", func(t *testing.T) {
+		testCases := []struct {
+			statusCode int
+			expected   bool
+		}{
+			{200, true},
+			{201, true},
+			{202, true},
+			{204, true},
+			{299, true},
+			{199, false},
+			{300, false},
+			{400, false},
+			{404, false},
+			{500, false},
+			{503, false},
+		}
+
+		for _, tc := range testCases {
+			result := processor.isSuccessfulResponse(tc.statusCode)
+			assert.Equal(t, tc.expected, result, "Status code %d should return %v", tc.statusCode, tc.expected)
+		}
+	})
+}
+
+// TestWebhookProcessor_CalculateNextRetryTime tests the retry time calculation
+func TestWebhookProcessor_CalculateNextRetryTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	tests := []struct {
+		name        string
+		retryCount  int
+		expectedMin time.Duration
+		expectedMax time.Duration
+	}{
+		{
+			name:        "retry 0 should have 1 minute base delay",
+			retryCount:  0,
+			expectedMin: 45 * time.Second, // 1min - 25% jitter
+			expectedMax: 75 * time.Second, // 1min + 25% jitter
+		},
+		{
+			name:        "retry 1 should have 5 minute base delay",
+			retryCount:  1,
+			expectedMin: 225 * time.Second, // 5min - 25% jitter
+			expectedMax: 375 * time.Second, // 5min + 25% jitter
+		},
+		{
+			name:        "retry 2 should have 10 minute base delay",
+			retryCount:  2,
+			expectedMin: 450 * time.Second, // 10min - 25% jitter
+			expectedMax: 750 * time.Second, // 10min + 25% jitter
+		},
+		{
+			name:        "retry 3 should have 30 minute base delay",
+			retryCount:  3,
+			expectedMin: 1350 * time.Second, // 30min - 25% jitter
+			expectedMax: 2250 * time.Second, // 30min + 25% jitter
+		},
+		{
+			name:        "retry 4 should have 60 minute base delay",
+			retryCount:  4,
+			expectedMin: 2700 * time.Second, // 60min - 25% jitter
+			expectedMax: 4500 * time.Second, // 60min + 25% jitter
+		},
+		{
+			name:        "retry 5 should have 120 minute base delay",
+			retryCount:  5,
+			expectedMin: 5400 * time.Second, // 120min - 25% jitter
+			expectedMax: 9000 * time.Second, // 120min + 25% jitter
+		},
+		{
+			name:        "retry 6+ should have 4 hour fallback delay",
+			retryCount:  10,
+			expectedMin: 10800 * time.Second, // 4hr - 25% jitter
+			expectedMax: 18000 * time.Second, // 4hr + 25% jitter
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now().UTC()
+
+			// Test multiple times to account for jitter
+			validCount := 0
+			totalTests := 20
+
+			for i := 0; i < totalTests; i++ {
+				nextRetryTime := processor.calculateNextRetryTime(nil, uuid.New(), tt.retryCount)
+				delay := nextRetryTime.Sub(now)
+
+				if delay >= tt.expectedMin && delay <= tt.expectedMax {
+					validCount++
+				}
+			}
+
+			// At least 70% should be within expected range due to jitter
+			assert.True(t, validCount >= totalTests*7/10,
+				"At least 70%% of retry delays should be in range [%v, %v] for retry %d, got %d/%d valid",
+				tt.expectedMin, tt.expectedMax, tt.retryCount, validCount, totalTests)
+		})
+	}
+
+	t.Run("should have minimum 1 minute delay even with negative jitter", func(t *testing.T) {
+		// This test ensures the minimum delay logic works
+		for i := 0; i < 100; i++ {
+			before := time.Now().UTC()
+			nextRetryTime := processor.calculateNextRetryTime(nil, uuid.New(), 0)
+			delay := nextRetryTime.Sub(before)
+			assert.True(t, delay >= time.Minute, "Delay should never be less than 1 minute, got %v", delay)
+		}
+	})
+
+	t.Run("should clamp high retry counts to the configured max delay", func(t *testing.T) {
+		capped := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+		capped.SetMaxRetryDelay(90 * time.Minute)
+
+		for _, retryCount := range []int{5, 6, 10, 100} {
+			for i := 0; i < 50; i++ {
+				before := time.Now().UTC()
+				nextRetryTime := capped.calculateNextRetryTime(nil, uuid.New(), retryCount)
+				after := time.Now().UTC()
+
+				assert.False(t, nextRetryTime.After(after.Add(90*time.Minute)),
+					"retry %d scheduled %v out, beyond the cap", retryCount, nextRetryTime.Sub(before))
+			}
+		}
+	})
+
+	t.Run("should leave delays under the cap unchanged", func(t *testing.T) {
+		capped := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+		capped.SetMaxRetryDelay(90 * time.Minute)
+
+		before := time.Now().UTC()
+		delay := capped.calculateNextRetryTime(nil, uuid.New(), 0).Sub(before)
+
+		assert.True(t, delay >= 45*time.Second && delay <= 76*time.Second, "got %v", delay)
+	})
+}
+
+func TestWebhookProcessor_SeededJitter(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should reproduce a webhook's schedule across processors", func(t *testing.T) {
+		queueID := uuid.New()
+		first, _ := newTestProcessor(t)
+		replayed, _ := newTestProcessor(t)
+		for _, processor := range []*WebhookProcessor{first, replayed} {
+			processor.now = func() time.Time { return now }
+			processor.SetSeededJitter(true)
+		}
+
+		for retryCount := 0; retryCount < enums.MaxRetryAttempts; retryCount++ {
+			assert.Equal(t, first.calculateNextRetryTime(nil, queueID, retryCount),
+				replayed.calculateNextRetryTime(nil, queueID, retryCount), "retry %d", retryCount)
+		}
+	})
+
+	t.Run("should vary the jitter between retry levels of one webhook", func(t *testing.T) {
+		queueID := uuid.New()
+
+		assert.NotEqual(t, seededJitterSample(queueID, 1), seededJitterSample(queueID, 2))
+	})
+
+	t.Run("should spread different webhooks across the jitter range", func(t *testing.T) {
+		processor, _ := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		processor.SetSeededJitter(true)
+		base := 5 * time.Minute
+		minDelay, maxDelay := base, base
+		distinct := make(map[time.Time]bool)
+
+		for i := 0; i < 200; i++ {
+			next := processor.calculateNextRetryTime(nil, uuid.New(), 1)
+			delay := next.Sub(now)
+			require.True(t, delay >= base*3/4 && delay <= base*5/4, "delay %v outside the jitter range", delay)
+			distinct[next] = true
+			if delay < minDelay {
+				minDelay = delay
+			}
+			if delay > maxDelay {
+				maxDelay = delay
+			}
+		}
+
+		assert.Greater(t, len(distinct), 190)
+		assert.Less(t, minDelay, base*4/5, "seeded jitter should reach the low end of the range")
+		assert.Greater(t, maxDelay, base*6/5, "seeded jitter should reach the high end of the range")
+	})
+
+	t.Run("should stay random by default", func(t *testing.T) {
+		processor, _ := newTestProcessor(t)
+		queueID := uuid.New()
+		distinct := make(map[time.Time]bool)
+
+		for i := 0; i < 20; i++ {
+			distinct[processor.calculateNextRetryTime(nil, queueID, 1)] = true
+		}
+
+		assert.Greater(t, len(distinct), 1)
+	})
+}
+
+// TestWebhookProcessor_ResetWebhookToPending tests the reset functionality
+func TestWebhookProcessor_ResetWebhookToPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	// claimedWebhook returns a webhook as stored while claimed, with two recorded retries
+	claimedWebhook := func(now time.Time) *entities.WebhookQueue {
+		return &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  2,
+			NextRetryAt: now,
+			CreatedAt:   now.Add(-time.Hour),
+			UpdatedAt:   now.Add(-time.Minute),
+		}
+	}
+
+	t.Run("should reset webhook to pending status", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Now().UTC()
+		stored := claimedWebhook(now)
+		webhook := *stored
+
+		mockQueueRepo.EXPECT().GetByQueueID(ctx, webhook.QueueID).Return(stored, nil).Times(1)
+		mockQueueRepo.EXPECT().
+			Update(ctx, gomock.Any(), "worker-1").
+			DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue, _ string) error {
+				assert.Equal(t, enums.WebhookStatusPending, w.Status)
+				assert.True(t, w.UpdatedAt.After(now.Add(-time.Second)))
+				// Other fields should remain unchanged
+				assert.Equal(t, webhook.ID, w.ID)
+				assert.Equal(t, webhook.QueueID, w.QueueID)
+				assert.Equal(t, webhook.EventType, w.EventType)
+				assert.Equal(t, webhook.EventID, w.EventID)
+				assert.Equal(t, webhook.ConfigID, w.ConfigID)
+				assert.Equal(t, webhook.RetryCount, w.RetryCount)
+				return nil
+			}).
+			Times(1)
+
+		err := processor.ResetWebhookToPending(ctx, &webhook, "worker-1")
+		assert.NoError(t, err)
+		assert.Equal(t, enums.WebhookStatusPending, webhook.Status)
+	})
+
+	t.Run("should keep the stored retry count when processing already advanced it", func(t *testing.T) {
+		ctx := context.Background()
+		stored := claimedWebhook(time.Now().UTC())
+		webhook := *stored
+		webhook.RetryCount = 3 // Advanced in memory by an attempt that was never recorded
+		webhook.NextRetryAt = webhook.NextRetryAt.Add(time.Hour)
+
+		mockQueueRepo.EXPECT().GetByQueueID(ctx, webhook.QueueID).Return(stored, nil).Times(1)
+		mockQueueRepo.EXPECT().
+			Update(ctx, gomock.Any(), "worker-1").
+			DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue, _ string) error {
+				assert.Equal(t, 2, w.RetryCount)
+				assert.Equal(t, stored.NextRetryAt, w.NextRetryAt)
+				return nil
+			}).
+			Times(1)
+
+		err := processor.ResetWebhookToPending(ctx, &webhook, "worker-1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, webhook.RetryCount)
+	})
+
+	t.Run("should fail without writing when the webhook is gone", func(t *testing.T) {
+		ctx := context.Background()
+		webhook := claimedWebhook(time.Now().UTC())
+
+		mockQueueRepo.EXPECT().GetByQueueID(ctx, webhook.QueueID).Return(nil, nil).Times(1)
+
+		err := processor.ResetWebhookToPending(ctx, webhook, "worker-1")
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+
+	t.Run("should handle update failure", func(t *testing.T) {
+		ctx := context.Background()
+		stored := claimedWebhook(time.Now().UTC())
+		webhook := *stored
+
+		mockQueueRepo.EXPECT().GetByQueueID(ctx, webhook.QueueID).Return(stored, nil).Times(1)
+		mockQueueRepo.EXPECT().
+			Update(ctx, gomock.Any(), "worker-1").
+			Return(errors.New("database update failed")).
+			Times(1)
+
+		err := processor.ResetWebhookToPending(ctx, &webhook, "worker-1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "database update failed")
+	})
+}
+
+// TestWebhookProcessor_FindCompletionAnomalies tests detection of COMPLETED webhooks without a successful attempt
+func TestWebhookProcessor_FindCompletionAnomalies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	t.Run("should flag rows whose attempts are not mapped to success", func(t *testing.T) {
+		ctx := context.Background()
+		since := time.Now().UTC().Add(-time.Hour)
+		serverError, conflict := 500, 409
+
+		inconsistent := &entities.WebhookQueue{ID: 1, QueueID: uuid.New(), ConfigID: 1,
+			Status: enums.WebhookStatusCompleted, Retry0HTTPStatus: &serverError}
+		mappedSuccess := &entities.WebhookQueue{ID: 2, QueueID: uuid.New(), ConfigID: 2,
+			Status: enums.WebhookStatusCompleted, Retry0HTTPStatus: &serverError, Retry1HTTPStatus: &conflict}
+		sameConfig := &entities.WebhookQueue{ID: 3, QueueID: uuid.New(), ConfigID: 1,
+			Status: enums.WebhookStatusCompleted}
+
+		mockQueueRepo.EXPECT().
+			FindCompletedWithoutSuccess(ctx, since, 100).
+			Return([]*entities.WebhookQueue{inconsistent, mappedSuccess, sameConfig}, nil).
+			Times(1)
+
+		// Configs are loaded once per config ID
+		mockConfigRepo.EXPECT().
+			GetByID(ctx, int64(1)).
+			Return(&entities.WebhookConfig{ID: 1}, nil).
+			Times(1)
+		mockConfigRepo.EXPECT().
+			GetByID(ctx, int64(2)).
+			Return(&entities.WebhookConfig{ID: 2, StatusOutcomes: map[int]enums.ResponseOutcome{
+				409: enums.ResponseOutcomeSuccess,
+			}}, nil).
+			Times(1)
+
+		anomalies, err := processor.FindCompletionAnomalies(ctx, since, 100)
+
+		require.NoError(t, err)
+		require.Len(t, anomalies, 2)
+		assert.Equal(t, int64(1), anomalies[0].ID)
+		assert.Equal(t, int64(3), anomalies[1].ID)
+	})
+
+	t.Run("should return repository error", func(t *testing.T) {
+		ctx := context.Background()
+		since := time.Now().UTC()
+
+		mockQueueRepo.EXPECT().
+			FindCompletedWithoutSuccess(ctx, since, 100).
+			Return(nil, errors.New("database error")).
+			Times(1)
+
+		anomalies, err := processor.FindCompletionAnomalies(ctx, since, 100)
+
+		assert.Error(t, err)
+		assert.Nil(t, anomalies)
+		assert.Contains(t, err.Error(), "failed to find completed webhooks without success")
+	})
+}
+
+func TestWebhookProcessor_BulkUpdateStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+	configID := int64(7)
+
+	t.Run("should fail pending webhooks for a config", func(t *testing.T) {
+		ctx := context.Background()
+		filter := repositories.WebhookQueueFilter{
+			ConfigID: &configID,
+			Statuses: []enums.WebhookStatus{enums.WebhookStatusPending},
+		}
+
+		mockQueueRepo.EXPECT().
+			BulkUpdateStatus(ctx, filter, enums.WebhookStatusFailed, "endpoint decommissioned").
+			Return(int64(12), nil).
+			Times(1)
+
+		updated, err := processor.BulkUpdateStatus(ctx, filter, enums.WebhookStatusFailed, "endpoint decommissioned")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), updated)
+	})
+
+	t.Run("should reject invalid bulk updates without touching the repository", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			filter    repositories.WebhookQueueFilter
+			newStatus enums.WebhookStatus
+			reason    string
+		}{
+			{
+				name:      "completed back to pending",
+				filter:    repositories.WebhookQueueFilter{ConfigID: &configID, Statuses: []enums.WebhookStatus{enums.WebhookStatusCompleted}},
+				newStatus: enums.WebhookStatusPending,
+				reason:    "replay",
+			},
+			{
+				name:      "processing target",
+				filter:    repositories.WebhookQueueFilter{ConfigID: &configID},
+				newStatus: enums.WebhookStatusProcessing,
+				reason:    "replay",
+			},
+			{
+				name:      "empty filter",
+				filter:    repositories.WebhookQueueFilter{},
+				newStatus: enums.WebhookStatusFailed,
+				reason:    "cleanup",
+			},
+			{
+				name:      "missing reason",
+				filter:    repositories.WebhookQueueFilter{ConfigID: &configID},
+				newStatus: enums.WebhookStatusFailed,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				updated, err := processor.BulkUpdateStatus(context.Background(), tt.filter, tt.newStatus, tt.reason)
+
+				assert.ErrorIs(t, err, ErrInvalidBulkUpdate)
+				assert.Zero(t, updated)
+			})
+		}
+	})
+
+	t.Run("should return repository error", func(t *testing.T) {
+		ctx := context.Background()
+		filter := repositories.WebhookQueueFilter{ConfigID: &configID}
+
+		mockQueueRepo.EXPECT().
+			BulkUpdateStatus(ctx, filter, enums.WebhookStatusPending, "retry after outage").
+			Return(int64(0), errors.New("database error")).
+			Times(1)
+
+		_, err := processor.BulkUpdateStatus(ctx, filter, enums.WebhookStatusPending, "retry after outage")
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidBulkUpdate)
+		assert.Contains(t, err.Error(), "failed to bulk update webhook status")
+	})
+}
+
+func TestWebhookProcessor_ForceFailWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+	queueID := uuid.New()
+
+	t.Run("should fail a pending webhook", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().
+			ForceFail(ctx, queueID, "endpoint decommissioned").
+			Return(enums.WebhookStatusPending, nil).
+			Times(1)
+
+		err := processor.ForceFailWebhook(ctx, queueID, "endpoint decommissioned")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject an already completed webhook", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().
+			ForceFail(ctx, queueID, "endpoint decommissioned").
+			Return(enums.WebhookStatus(""), nil).
+			Times(1)
+		mockQueueRepo.EXPECT().
+			GetByQueueID(ctx, queueID).
+			Return(&entities.WebhookQueue{QueueID: queueID, Status: enums.WebhookStatusCompleted}, nil).
+			Times(1)
+
+		err := processor.ForceFailWebhook(ctx, queueID, "endpoint decommissioned")
+
+		assert.ErrorIs(t, err, ErrWebhookStatusConflict)
+		assert.Contains(t, err.Error(), "already COMPLETED")
+	})
+
+	t.Run("should fail a webhook with an attempt in flight", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().
+			ForceFail(ctx, queueID, "endpoint decommissioned").
+			Return(enums.WebhookStatusProcessing, nil).
+			Times(1)
+
+		err := processor.ForceFailWebhook(ctx, queueID, "endpoint decommissioned")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found for an unknown queue ID", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().
+			ForceFail(ctx, queueID, "endpoint decommissioned").
+			Return(enums.WebhookStatus(""), nil).
+			Times(1)
+		mockQueueRepo.EXPECT().
+			GetByQueueID(ctx, queueID).
+			Return(nil, nil).
+			Times(1)
+
+		err := processor.ForceFailWebhook(ctx, queueID, "endpoint decommissioned")
+
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+
+	t.Run("should require a reason without touching the repository", func(t *testing.T) {
+		err := processor.ForceFailWebhook(context.Background(), queueID, "")
+
+		assert.ErrorIs(t, err, ErrInvalidForceFail)
+	})
+}
+
+func TestWebhookProcessor_ProcessByQueueID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+	mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	t.Run("should claim and process a pending webhook ahead of its schedule", func(t *testing.T) {
+		ctx := context.Background()
+		queueID := uuid.New()
+		claimed := &entities.WebhookQueue{
+			ID:          5,
+			QueueID:     queueID,
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  2,
+			NextRetryAt: time.Now().UTC().Add(time.Hour), // Not due yet
+		}
+
+		gomock.InOrder(
+			mockQueueRepo.EXPECT().
+				ClaimByQueueID(ctx, queueID, ManualWorkerID).
+				Return(claimed, nil),
+			mockWebhookService.EXPECT().
+				SendWebhook(ctx, claimed).
+				Return(&services.WebhookResponse{StatusCode: 200, Body: "ok"}, nil),
+			mockQueueRepo.EXPECT().
+				UpdateRetryAttempt(ctx, claimed.ID, ManualWorkerID, 2, gomock.Any(), gomock.Any(),
+					gomock.Any(), gomock.Any(), 200, "ok", "", enums.ErrorClassNone, gomock.Any()),
+			mockQueueRepo.EXPECT().
+				MarkCompleted(ctx, claimed.ID, ManualWorkerID, gomock.Any(), 200),
+			mockQueueRepo.EXPECT().
+				GetByQueueID(ctx, queueID).
+				Return(&entities.WebhookQueue{QueueID: queueID, Status: enums.WebhookStatusCompleted, RetryCount: 2, LastHTTPStatus: 200}, nil),
+		)
+
+		processed, err := processor.ProcessByQueueID(ctx, queueID)
+
+		require.NoError(t, err)
+		assert.Equal(t, enums.WebhookStatusCompleted, processed.Status)
+		assert.Equal(t, 200, processed.LastHTTPStatus)
+	})
+
+	t.Run("should reject a webhook already claimed by another worker", func(t *testing.T) {
+		ctx := context.Background()
+		queueID := uuid.New()
+
+		mockQueueRepo.EXPECT().
+			ClaimByQueueID(ctx, queueID, ManualWorkerID).
+			Return(nil, nil).
+			Times(1)
+		mockQueueRepo.EXPECT().
+			GetByQueueID(ctx, queueID).
+			Return(&entities.WebhookQueue{QueueID: queueID, Status: enums.WebhookStatusProcessing}, nil).
+			Times(1)
+
+		processed, err := processor.ProcessByQueueID(ctx, queueID)
+
+		assert.ErrorIs(t, err, ErrWebhookStatusConflict)
+		assert.Contains(t, err.Error(), "held by another worker")
+		assert.Nil(t, processed)
+	})
+
+	t.Run("should reject a webhook that already finished", func(t *testing.T) {
+		ctx := context.Background()
+		queueID := uuid.New()
+
+		mockQueueRepo.EXPECT().
+			ClaimByQueueID(ctx, queueID, ManualWorkerID).
+			Return(nil, nil).
+			Times(1)
+		mockQueueRepo.EXPECT().
+			GetByQueueID(ctx, queueID).
+			Return(&entities.WebhookQueue{QueueID: queueID, Status: enums.WebhookStatusFailed}, nil).
+			Times(1)
+
+		_, err := processor.ProcessByQueueID(ctx, queueID)
+
+		assert.ErrorIs(t, err, ErrWebhookStatusConflict)
+		assert.Contains(t, err.Error(), "already FAILED")
+	})
+
+	t.Run("should return not found for an unknown queue ID", func(t *testing.T) {
+		ctx := context.Background()
+		queueID := uuid.New()
+
+		mockQueueRepo.EXPECT().
+			ClaimByQueueID(ctx, queueID, ManualWorkerID).
+			Return(nil, nil).
+			Times(1)
+		mockQueueRepo.EXPECT().
+			GetByQueueID(ctx, queueID).
+			Return(nil, nil).
+			Times(1)
+
+		_, err := processor.ProcessByQueueID(ctx, queueID)
+
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+}
+
+func TestWebhookProcessor_DeleteWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+	queueID := uuid.New()
+
+	t.Run("should permanently delete a webhook", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().
+			HardDelete(ctx, queueID).
+			Return(true, nil).
+			Times(1)
+
+		err := processor.DeleteWebhook(ctx, queueID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject a webhook with an attempt in flight", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().
+			HardDelete(ctx, queueID).
+			Return(false, nil).
+			Times(1)
+		mockQueueRepo.EXPECT().
+			GetByQueueID(ctx, queueID).
+			Return(&entities.WebhookQueue{QueueID: queueID, Status: enums.WebhookStatusProcessing}, nil).
+			Times(1)
+
+		err := processor.DeleteWebhook(ctx, queueID)
+
+		assert.ErrorIs(t, err, ErrWebhookStatusConflict)
+		assert.Contains(t, err.Error(), "attempt in flight")
+	})
+
+	t.Run("should return not found for an unknown queue ID", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().
+			HardDelete(ctx, queueID).
+			Return(false, nil).
+			Times(1)
+		mockQueueRepo.EXPECT().
+			GetByQueueID(ctx, queueID).
+			Return(nil, nil).
+			Times(1)
+
+		err := processor.DeleteWebhook(ctx, queueID)
+
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+
+	t.Run("should return repository error", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockQueueRepo.EXPECT().
+			HardDelete(ctx, queueID).
+			Return(false, errors.New("database error")).
+			Times(1)
+
+		err := processor.DeleteWebhook(ctx, queueID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete webhook")
+	})
+}
+
+// TestWebhookProcessor_EdgeCases tests edge cases and boundary conditions
+func TestWebhookProcessor_EdgeCases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	// Processing looks up the webhook's config; these tests rely on the default outcomes
+	mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	t.Run("should handle webhook with nil response from service", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  1,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		// Service returns nil response and error
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(nil, errors.New("network error")).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 0, "", "network error", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			Update(ctx, gomock.Any(), workerID).
+			Return(nil).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, webhook.LastHTTPStatus)
+		assert.Equal(t, "network error", webhook.LastError)
+	})
+
+	t.Run("should handle empty error message gracefully", func(t *testing.T) {
+		ctx := context.Background()
+		workerID := "worker-1"
+		now := time.Now().UTC()
+
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   enums.EventTypeCredit,
+			EventID:     "test-event",
+			ConfigID:    1,
+			WebhookURL:  "https://example.com/webhook",
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  0,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		response := &services.WebhookResponse{
+			StatusCode: 200,
+			Body:       `{"success": true}`,
+			Duration:   time.Millisecond * 500,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, workerID, webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, `{"success": true}`, "", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			MarkCompleted(ctx, webhook.ID, workerID, gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		err := processor.ProcessWebhook(ctx, webhook, workerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, webhook.LastHTTPStatus)
+		assert.Equal(t, "", webhook.LastError) // Should remain empty
+	})
+}
+
+// TestWebhookProcessor_Integration tests integration scenarios
+func TestWebhookProcessor_Integration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	logger := log.NewNopLogger()
+
+	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+
+	t.Run("should handle complete workflow from creation to completion", func(t *testing.T) {
+		ctx := context.Background()
+		eventType := enums.EventTypeCredit
+		eventID := "integration-test-event"
+		configID := int64(1)
+
+		// Step 1: Create webhook entry
+		config := &entities.WebhookConfig{
+			ID:         configID,
+			Name:       "Integration Test Config",
+			EventType:  eventType,
+			WebhookURL: "https://example.com/webhook",
+			IsActive:   true,
+			TimeoutMs:  30000,
+		}
+
+		mockConfigRepo.EXPECT().
+			GetByID(ctx, configID).
+			Return(config, nil).
+			Times(2) // Once at creation, once at processing
+
+		mockQueueRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
+				webhook.ID = 1
+				webhook.QueueID = uuid.New()
+				return nil
+			}).
+			Times(1)
+
+		_, err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID, nil, "")
+		assert.NoError(t, err)
+
+		// Step 2: Process the webhook successfully
+		webhook := &entities.WebhookQueue{
+			ID:          1,
+			QueueID:     uuid.New(),
+			EventType:   eventType,
+			EventID:     eventID,
+			ConfigID:    configID,
+			WebhookURL:  config.WebhookURL,
+			Status:      enums.WebhookStatusProcessing,
+			RetryCount:  0,
+			NextRetryAt: time.Now().UTC(),
+			CreatedAt:   time.Now().UTC(),
+			UpdatedAt:   time.Now().UTC(),
+		}
+
+		response := &services.WebhookResponse{
+			StatusCode: 200,
+			Body:       `{"message": "webhook received"}`,
+			Duration:   time.Millisecond * 300,
+			Error:      nil,
+		}
+
+		mockWebhookService.EXPECT().
+			SendWebhook(ctx, webhook).
+			Return(response, nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, webhook.ID, "integration-worker", webhook.RetryCount, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, `{"message": "webhook received"}`, "", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			MarkCompleted(ctx, webhook.ID, "integration-worker", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		err = processor.ProcessWebhook(ctx, webhook, "integration-worker")
+		assert.NoError(t, err)
+	})
+}
+
+func TestWebhookProcessor_ProcessWebhook_DNSErrors(t *testing.T) {
+	notFound := &net.DNSError{Err: "no such host", Name: "exmaple.invalid", IsNotFound: true}
+	outage := &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}
+
+	tests := []struct {
+		name       string
+		sendErr    *net.DNSError
+		retryCount int
+		failed     bool
+		kind       string
+	}{
+		{name: "should retry the first unresolved attempt", sendErr: notFound, retryCount: 0, kind: "not_found"},
+		{name: "should fail fast once the host has not resolved for the configured attempts", sendErr: notFound, retryCount: 1, failed: true},
+		{name: "should keep retrying transient DNS errors", sendErr: outage, retryCount: 1, kind: "transient"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, m := newTestProcessor(t)
+			processor.SetMetrics(testMetrics)
+			processor.SetDNSNotFoundAttempts(2)
+			ctx := context.Background()
+			before := dnsErrors(t, tt.kind)
+
+			// Every earlier attempt failed to resolve the host
+			webhook := testWebhook(tt.retryCount, nil)
+			webhook.WebhookURL = "https://exmaple.invalid/webhook"
+			if tt.retryCount > 0 {
+				startedAt := time.Now().UTC()
+				dnsClass := string(enums.ErrorClassDNS)
+				webhook.Retry0StartedAt = &startedAt
+				webhook.Retry0ErrorClass = &dnsClass
+			}
+
+			m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil)
+			m.service.EXPECT().SendWebhook(ctx, webhook).
+				Return(nil, fmt.Errorf("failed to send webhook request: %w", tt.sendErr))
+			m.queueRepo.EXPECT().
+				UpdateRetryAttempt(ctx, int64(1), "worker-1", tt.retryCount, gomock.Any(), gomock.Any(),
+					gomock.Any(), gomock.Any(), 0, "", gomock.Any(), enums.ErrorClassDNS, gomock.Any()).
+				Return(nil)
+			if tt.failed {
+				m.queueRepo.EXPECT().
+					MarkFailed(ctx, int64(1), "worker-1", gomock.Any(), 0).
+					DoAndReturn(func(ctx context.Context, id int64, _ string, reason string, status int) error {
+						assert.Contains(t, reason, "non-retryable error")
+						assert.Contains(t, reason, tt.sendErr.Err)
+						return nil
+					})
+			} else {
+				m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").
+					DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue, _ string) error {
+						assert.Equal(t, enums.WebhookStatusPending, w.Status)
+						assert.Equal(t, tt.retryCount+1, w.RetryCount)
+						return nil
+					})
+			}
+
+			err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
+			assert.NoError(t, err)
+			if tt.kind != "" {
+				assert.Equal(t, before+1, dnsErrors(t, tt.kind))
+			}
+		})
+	}
+}
+
+func TestWebhookProcessor_ProcessWebhook_AmbiguousErrors(t *testing.T) {
+	// resetAfterSend is the error net/http returns when the receiver resets the connection
+	// while the response is awaited, after the whole request was written
+	resetAfterSend := fmt.Errorf("failed to send webhook request: %w", &url.Error{Op: "Post", URL: "https://example.com/webhook",
+		Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}})
+
+	tests := []struct {
+		name       string
+		policy     enums.AmbiguousErrorPolicy
+		idempotent bool
+		retried    bool
+	}{
+		{name: "unset policy retries", retried: true},
+		{name: "retry policy retries", policy: enums.AmbiguousErrorPolicyRetry, retried: true},
+		{name: "fail policy fails even an idempotent receiver", policy: enums.AmbiguousErrorPolicyFail, idempotent: true, retried: false},
+		{name: "retry-if-idempotent retries an idempotent receiver", policy: enums.AmbiguousErrorPolicyRetryIfIdempotent, idempotent: true, retried: true},
+		{name: "retry-if-idempotent fails a non-idempotent receiver", policy: enums.AmbiguousErrorPolicyRetryIfIdempotent, retried: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &entities.WebhookConfig{ID: 1, IsActive: true, AmbiguousErrorPolicy: tt.policy, Idempotent: tt.idempotent}
+			processor, m := newTestProcessor(t)
+			ctx := context.Background()
+
+			m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+			m.service.EXPECT().SendWebhook(ctx, gomock.Any()).Return(nil, resetAfterSend)
+			m.queueRepo.EXPECT().
+				UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+					gomock.Any(), gomock.Any(), 0, "", resetAfterSend.Error(), enums.ErrorClassConnection, gomock.Any()).
+				Return(nil)
+
+			if tt.retried {
+				m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").
+					DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue, _ string) error {
+						assert.Equal(t, enums.WebhookStatusPending, w.Status)
+						assert.Equal(t, 1, w.RetryCount)
+						return nil
+					})
+			} else {
+				m.queueRepo.EXPECT().
+					MarkFailed(ctx, int64(1), "worker-1", gomock.Any(), 0).
+					DoAndReturn(func(ctx context.Context, id int64, _ string, reason string, status int) error {
+						assert.Contains(t, reason, "non-retryable error")
+						assert.Contains(t, reason, "connection reset by peer")
+						return nil
+					})
+			}
+
+			err := processor.ProcessWebhook(ctx, testWebhook(0, nil), "worker-1")
+
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("should retry a refused connection whatever the policy", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		ctx := context.Background()
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).
+			Return(&entities.WebhookConfig{ID: 1, IsActive: true, AmbiguousErrorPolicy: enums.AmbiguousErrorPolicyFail}, nil)
+		m.service.EXPECT().SendWebhook(ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("failed to send webhook request: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}))
+		m.queueRepo.EXPECT().UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), 0, "", gomock.Any(), enums.ErrorClassConnection, gomock.Any()).Return(nil)
+		m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").Return(nil)
+
+		err := processor.ProcessWebhook(ctx, testWebhook(0, nil), "worker-1")
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestWebhookProcessor_ProcessWebhook_PayloadTemplateErrors(t *testing.T) {
+	t.Run("should fail the webhook at once with the render error instead of retrying", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+
+		ctx := context.Background()
+		webhook := &entities.WebhookQueue{
+			ID:         1,
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeCredit,
+			ConfigID:   1,
+			WebhookURL: "https://example.com/webhook",
+			Status:     enums.WebhookStatusProcessing,
+		}
+		renderErr := fmt.Errorf("%w to render: %w", services.ErrPayloadTemplate,
+			errors.New(`template: payload:1:14: executing "payload" at <.Missing>: can't evaluate field Missing`))
+
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, nil)
+		mockWebhookService.EXPECT().SendWebhook(gomock.Any(), gomock.Any()).
+			Return(&services.WebhookResponse{Error: renderErr}, renderErr)
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(gomock.Any(), int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 0, "", renderErr.Error(), gomock.Any(), gomock.Any()).
+			Return(nil)
+		mockQueueRepo.EXPECT().
+			MarkFailed(ctx, int64(1), "worker-1", gomock.Any(), 0).
+			DoAndReturn(func(ctx context.Context, id int64, _ string, reason string, status int) error {
+				assert.Contains(t, reason, "non-retryable error")
+				assert.Contains(t, reason, "can't evaluate field Missing")
+				return nil
+			})
+
+		err := processor.ProcessWebhook(ctx, webhook, "worker-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, renderErr.Error(), webhook.LastError)
+		assert.Zero(t, webhook.RetryCount)
+	})
+}
+
+func TestWebhookProcessor_ProcessWebhook_Throttling(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	throttled := &services.WebhookResponse{StatusCode: http.StatusTooManyRequests, Body: "slow down"}
+
+	t.Run("should reschedule a series of 429s without burning the retry budget", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		processor.SetMetrics(testMetrics)
+		processor.SetThrottleGrace(3)
+		ctx := context.Background()
+		webhook := testWebhook(0, nil)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil).AnyTimes()
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), http.StatusTooManyRequests, "slow down", gomock.Any(), enums.ErrorClassHTTPStatus, gomock.Any()).
+			Return(nil).AnyTimes()
+
+		m.service.EXPECT().SendWebhook(ctx, webhook).Return(throttled, nil).Times(4)
+		m.queueRepo.EXPECT().Update(ctx, webhook, "worker-1").Return(nil).Times(4)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+			assert.Equal(t, 0, webhook.RetryCount)
+			assert.Equal(t, i+1, webhook.ThrottledCount)
+			assert.Equal(t, enums.WebhookStatusPending, webhook.Status)
+			webhook.Status = enums.WebhookStatusProcessing
+		}
+
+		// Once the grace is used up a 429 consumes a retry like any other failure
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		assert.Equal(t, 1, webhook.RetryCount)
+		assert.Equal(t, 3, webhook.ThrottledCount)
+	})
+
+	t.Run("should reschedule a throttled final attempt instead of failing it", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		processor.SetMetrics(testMetrics)
+		processor.SetThrottleGrace(1)
+		ctx := context.Background()
+		webhook := testWebhook(enums.MaxRetryAttempts, nil)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil).AnyTimes()
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), http.StatusTooManyRequests, "slow down", gomock.Any(), enums.ErrorClassHTTPStatus, gomock.Any()).
+			Return(nil).AnyTimes()
+
+		m.service.EXPECT().SendWebhook(ctx, webhook).Return(throttled, nil)
+		m.queueRepo.EXPECT().Update(ctx, webhook, "worker-1").Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		assert.Equal(t, enums.MaxRetryAttempts, webhook.RetryCount)
+		assert.Equal(t, enums.WebhookStatusPending, webhook.Status)
+	})
+
+	t.Run("should wait for the receiver's Retry-After", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		processor.SetMetrics(testMetrics)
+		processor.SetThrottleGrace(1)
+		ctx := context.Background()
+		webhook := testWebhook(0, nil)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil).AnyTimes()
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), http.StatusTooManyRequests, "slow down", gomock.Any(), enums.ErrorClassHTTPStatus, gomock.Any()).
+			Return(nil).AnyTimes()
+
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: http.StatusTooManyRequests, Body: "slow down", RetryAfter: 20 * time.Second}, nil)
+		m.queueRepo.EXPECT().Update(ctx, webhook, "worker-1").Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		assert.Equal(t, now.Add(20*time.Second), webhook.NextRetryAt)
+	})
+
+	t.Run("should not retry sooner than Retry-After once the grace is used up", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		processor.SetMetrics(testMetrics)
+		processor.SetThrottleGrace(0)
+		ctx := context.Background()
+		webhook := testWebhook(0, nil)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil).AnyTimes()
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), http.StatusTooManyRequests, "slow down", gomock.Any(), enums.ErrorClassHTTPStatus, gomock.Any()).
+			Return(nil).AnyTimes()
+
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: http.StatusTooManyRequests, Body: "slow down", RetryAfter: 3 * time.Hour}, nil)
+		m.queueRepo.EXPECT().Update(ctx, webhook, "worker-1").Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		assert.Equal(t, 1, webhook.RetryCount)
+		assert.Equal(t, 0, webhook.ThrottledCount)
+		assert.Equal(t, now.Add(3*time.Hour), webhook.NextRetryAt)
+	})
+}
+
+func TestWebhookProcessor_ClampNextRetryAt(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	// countWarnings returns a logger that counts clamp warnings into warnings
+	countWarnings := func(warnings *int) log.Logger {
+		return log.LoggerFunc(func(keyvals ...interface{}) error {
+			for i := 0; i+1 < len(keyvals); i += 2 {
+				if keyvals[i] == "msg" && keyvals[i+1] == "next retry was scheduled too soon, clamped forward" {
+					*warnings++
+				}
+			}
+			return nil
+		})
+	}
+
+	tests := []struct {
+		name        string
+		nextRetryAt time.Time
+		want        time.Time
+		wantWarning bool
+	}{
+		{name: "zero", nextRetryAt: time.Time{}, want: now.Add(minRescheduleDelay), wantWarning: true},
+		{name: "in the past", nextRetryAt: now.Add(-time.Hour), want: now.Add(minRescheduleDelay), wantWarning: true},
+		{name: "now", nextRetryAt: now, want: now.Add(minRescheduleDelay), wantWarning: true},
+		{name: "in the future", nextRetryAt: now.Add(time.Minute), want: now.Add(time.Minute), wantWarning: false},
+	}
+
+	for _, tt := range tests {
+		t.Run("should clamp a next retry "+tt.name, func(t *testing.T) {
+			processor, _ := newTestProcessor(t)
+			processor.now = func() time.Time { return now }
+			var warnings int
+			processor.logger = countWarnings(&warnings)
+
+			got := processor.clampNextRetryAt(&entities.WebhookQueue{QueueID: uuid.New()}, tt.nextRetryAt)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantWarning, warnings > 0)
+		})
+	}
+
+	t.Run("should clamp a retry a misconfigured max delay schedules immediately", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		var warnings int
+		processor.logger = countWarnings(&warnings)
+		processor.SetMaxRetryDelay(time.Nanosecond)
+		ctx := context.Background()
+		webhook := testWebhook(0, nil)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: http.StatusInternalServerError, Body: "error"}, nil)
+		m.queueRepo.EXPECT().UpdateRetryAttempt(gomock.Any(), int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), http.StatusInternalServerError, "error", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		m.queueRepo.EXPECT().Update(ctx, webhook, "worker-1").Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		assert.Equal(t, 1, webhook.RetryCount)
+		assert.Equal(t, now.Add(minRescheduleDelay), webhook.NextRetryAt)
+		assert.Equal(t, 1, warnings)
+	})
+}
+
+func TestWebhookProcessor_ProcessWebhook_DeliveryDurationRatio(t *testing.T) {
+	tests := []struct {
+		name      string
+		configID  int64
+		duration  time.Duration
+		timeout   time.Duration
+		wantRatio float64
+	}{
+		{name: "fast delivery", configID: 4301, duration: 50 * time.Millisecond, timeout: time.Second, wantRatio: 0.05},
+		{name: "near-timeout delivery", configID: 4302, duration: 1900 * time.Millisecond, timeout: 2 * time.Second, wantRatio: 0.95},
+	}
+
+	for _, tt := range tests {
+		t.Run("should observe the ratio of a "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+			mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+			mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+			processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+			processor.SetMetrics(testMetrics)
+			ctx := context.Background()
+
+			webhook := &entities.WebhookQueue{
+				ID:         1,
+				QueueID:    uuid.New(),
+				ConfigID:   tt.configID,
+				WebhookURL: "https://example.com/webhook",
+				Status:     enums.WebhookStatusProcessing,
+			}
+			label := fmt.Sprint(tt.configID)
+			beforeCount, beforeSum := deliveryDurationRatio(t, label)
+
+			mockConfigRepo.EXPECT().GetByID(ctx, tt.configID).Return(nil, nil)
+			mockWebhookService.EXPECT().SendWebhook(ctx, webhook).
+				Return(&services.WebhookResponse{StatusCode: 200, Body: "ok", Duration: tt.duration, Timeout: tt.timeout}, nil)
+			mockQueueRepo.EXPECT().UpdateRetryAttempt(gomock.Any(), int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), tt.timeout.Milliseconds(), 200, "ok", "", gomock.Any(), gomock.Any()).Return(nil)
+			mockQueueRepo.EXPECT().MarkCompleted(ctx, int64(1), "worker-1", gomock.Any(), 200).Return(nil)
+
+			require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+			count, sum := deliveryDurationRatio(t, label)
+			assert.Equal(t, beforeCount+1, count)
+			assert.InDelta(t, tt.wantRatio, sum-beforeSum, 1e-9)
+		})
+	}
+
+	t.Run("should not observe a ratio when no timeout applied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+		processor.SetMetrics(testMetrics)
+		ctx := context.Background()
+
+		webhook := &entities.WebhookQueue{
+			ID:         1,
+			QueueID:    uuid.New(),
+			ConfigID:   4303,
+			WebhookURL: "https://example.com/webhook",
+			Status:     enums.WebhookStatusProcessing,
+		}
+
+		mockConfigRepo.EXPECT().GetByID(ctx, int64(4303)).Return(nil, nil)
+		mockWebhookService.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 200, Body: "ok", Duration: time.Second}, nil)
+		mockQueueRepo.EXPECT().UpdateRetryAttempt(gomock.Any(), gomock.Any(), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockQueueRepo.EXPECT().MarkCompleted(ctx, int64(1), "worker-1", gomock.Any(), 200).Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		count, _ := deliveryDurationRatio(t, "4303")
+		assert.Equal(t, uint64(0), count)
+	})
+}
+
+func TestWebhookProcessor_ProcessWebhook_RetrySchedule(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should schedule each retry at the config's exact delay", func(t *testing.T) {
+		config := &entities.WebhookConfig{ID: 1, RetryScheduleMs: []int{10000, 30000, 60000}}
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		ctx := context.Background()
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil).AnyTimes()
+		m.queueRepo.EXPECT().UpdateRetryAttempt(ctx, gomock.Any(), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		for retryCount, expected := range []time.Duration{10 * time.Second, 30 * time.Second, time.Minute} {
+			webhook := testWebhook(retryCount, config)
+			m.service.EXPECT().SendWebhook(ctx, webhook).
+				Return(&services.WebhookResponse{StatusCode: 503, Body: "unavailable"}, nil)
+			m.queueRepo.EXPECT().Update(ctx, webhook, "worker-1").Return(nil)
+
+			require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+			assert.Equal(t, retryCount+1, webhook.RetryCount)
+			assert.Equal(t, now.Add(expected), webhook.NextRetryAt)
+		}
+	})
+
+	t.Run("should fail once the schedule is used up", func(t *testing.T) {
+		config := &entities.WebhookConfig{ID: 1, RetryScheduleMs: []int{10000, 30000}}
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		ctx := context.Background()
+		webhook := testWebhook(2, config)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil).AnyTimes()
+		m.queueRepo.EXPECT().UpdateRetryAttempt(ctx, gomock.Any(), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 503, Body: "unavailable"}, nil)
+		m.queueRepo.EXPECT().MarkFailed(ctx, webhook.ID, "worker-1", "max retries exceeded: HTTP 503", 503).Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+
+	t.Run("should still cap a scheduled delay at the max retry delay", func(t *testing.T) {
+		config := &entities.WebhookConfig{ID: 1, RetryScheduleMs: []int{4 * 3600 * 1000}}
+		processor, _ := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		processor.SetMaxRetryDelay(time.Hour)
+
+		assert.Equal(t, now.Add(time.Hour), processor.calculateNextRetryTime(config, uuid.New(), 0))
+	})
+
+	t.Run("should use the global backoff for an invalid schedule", func(t *testing.T) {
+		config := &entities.WebhookConfig{ID: 1, RetryScheduleMs: []int{60000, 10000}}
+		processor, _ := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+
+		delay := processor.calculateNextRetryTime(config, uuid.New(), 1).Sub(now)
+
+		assert.True(t, delay >= 225*time.Second && delay <= 375*time.Second, "got %v", delay)
+	})
+
+	t.Run("should schedule the first retry at a config's first retry delay while others use the default", func(t *testing.T) {
+		firstRetryDelayMs := 5000
+		critical := &entities.WebhookConfig{ID: 1, FirstRetryDelayMs: &firstRetryDelayMs}
+		processor, m := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+		ctx := context.Background()
+		webhook := testWebhook(0, critical)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(critical, nil).AnyTimes()
+		m.queueRepo.EXPECT().UpdateRetryAttempt(ctx, gomock.Any(), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 503, Body: "unavailable"}, nil)
+		m.queueRepo.EXPECT().Update(ctx, webhook, "worker-1").Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		assert.Equal(t, 1, webhook.RetryCount)
+		assert.Equal(t, now.Add(5*time.Second), webhook.NextRetryAt)
+
+		delay := processor.calculateNextRetryTime(&entities.WebhookConfig{ID: 2}, uuid.New(), 0).Sub(now)
+		assert.True(t, delay >= 45*time.Second && delay <= 75*time.Second, "got %v", delay)
+	})
+
+	t.Run("should keep the global backoff after the first retry", func(t *testing.T) {
+		firstRetryDelayMs := 5000
+		config := &entities.WebhookConfig{ID: 1, FirstRetryDelayMs: &firstRetryDelayMs}
+		processor, _ := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+
+		delay := processor.calculateNextRetryTime(config, uuid.New(), 1).Sub(now)
+
+		assert.True(t, delay >= 225*time.Second && delay <= 375*time.Second, "got %v", delay)
+	})
+
+	t.Run("should prefer a retry schedule over the first retry delay", func(t *testing.T) {
+		firstRetryDelayMs := 5000
+		config := &entities.WebhookConfig{ID: 1, FirstRetryDelayMs: &firstRetryDelayMs, RetryScheduleMs: []int{10000}}
+		processor, _ := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+
+		assert.Equal(t, now.Add(10*time.Second), processor.calculateNextRetryTime(config, uuid.New(), 0))
+	})
+
+	t.Run("should use the global backoff for a first retry delay below the minimum", func(t *testing.T) {
+		firstRetryDelayMs := 500
+		config := &entities.WebhookConfig{ID: 1, FirstRetryDelayMs: &firstRetryDelayMs}
+		processor, _ := newTestProcessor(t)
+		processor.now = func() time.Time { return now }
+
+		delay := processor.calculateNextRetryTime(config, uuid.New(), 0).Sub(now)
+
+		assert.True(t, delay >= 45*time.Second && delay <= 75*time.Second, "got %v", delay)
+	})
+}
+
+func TestWebhookProcessor_ProcessWebhook_RetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	// process fails a level-0 webhook with a 503 carrying retryAfter and returns the rescheduled webhook
+	process := func(t *testing.T, retryAfter time.Duration) *entities.WebhookQueue {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+		processor.now = func() time.Time { return now }
+		processor.SetMaxRetryDelay(24 * time.Hour)
+
+		ctx := context.Background()
+		webhook := &entities.WebhookQueue{
+			ID:         1,
+			QueueID:    uuid.New(),
+			ConfigID:   1,
+			WebhookURL: "https://example.com/webhook",
+			Status:     enums.WebhookStatusProcessing,
+		}
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&entities.WebhookConfig{ID: 1}, nil).AnyTimes()
+		mockQueueRepo.EXPECT().UpdateRetryAttempt(gomock.Any(), gomock.Any(), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockWebhookService.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: http.StatusServiceUnavailable, Body: "unavailable", RetryAfter: retryAfter}, nil)
+		mockQueueRepo.EXPECT().Update(ctx, webhook, "worker-1").Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+		require.Equal(t, 1, webhook.RetryCount)
+		return webhook
+	}
+
+	t.Run("should wait for a Retry-After longer than the backoff", func(t *testing.T) {
+		webhook := process(t, 10*time.Minute)
+
+		assert.Equal(t, now.Add(10*time.Minute), webhook.NextRetryAt)
+	})
+
+	t.Run("should keep the backoff when Retry-After is shorter", func(t *testing.T) {
+		delay := process(t, 5*time.Second).NextRetryAt.Sub(now)
+
+		assert.True(t, delay >= 45*time.Second && delay <= 75*time.Second, "got %v", delay)
+	})
+
+	t.Run("should use the backoff without a Retry-After", func(t *testing.T) {
+		delay := process(t, 0).NextRetryAt.Sub(now)
+
+		assert.True(t, delay >= 45*time.Second && delay <= 75*time.Second, "got %v", delay)
+	})
+
+	t.Run("should cap an absurd Retry-After at the max retry delay", func(t *testing.T) {
+		webhook := process(t, math.MaxInt64)
+
+		assert.Equal(t, now.Add(24*time.Hour), webhook.NextRetryAt)
+	})
+}
+
+func TestWebhookProcessor_RemainingRetrySchedule(t *testing.T) {
+	processor, _ := newTestProcessor(t)
+
+	t.Run("should project the global backoff as jitter ranges from retry level 2", func(t *testing.T) {
+		schedule := processor.RemainingRetrySchedule(testWebhook(2, nil))
+
+		assert.Equal(t, []ProjectedRetry{
+			{RetryLevel: 3, MinDelayMs: (450 * time.Second).Milliseconds(), MaxDelayMs: (750 * time.Second).Milliseconds()},
+			{RetryLevel: 4, MinDelayMs: (1350 * time.Second).Milliseconds(), MaxDelayMs: (2250 * time.Second).Milliseconds()},
+			{RetryLevel: 5, MinDelayMs: (45 * time.Minute).Milliseconds(), MaxDelayMs: (75 * time.Minute).Milliseconds()},
+			{RetryLevel: 6, MinDelayMs: (90 * time.Minute).Milliseconds(), MaxDelayMs: (150 * time.Minute).Milliseconds()},
+		}, schedule)
+	})
+
+	t.Run("should project a config's retry schedule exactly", func(t *testing.T) {
+		config := &entities.WebhookConfig{RetryScheduleMs: []int{10000, 30000, 60000}}
+
+		schedule := processor.RemainingRetrySchedule(testWebhook(1, config))
+
+		assert.Equal(t, []ProjectedRetry{
+			{RetryLevel: 2, MinDelayMs: 30000, MaxDelayMs: 30000},
+			{RetryLevel: 3, MinDelayMs: 60000, MaxDelayMs: 60000},
+		}, schedule)
+	})
+
+	t.Run("should project a config's first retry delay exactly", func(t *testing.T) {
+		firstRetryDelayMs := 5000
+		maxRetries := 2
+		config := &entities.WebhookConfig{FirstRetryDelayMs: &firstRetryDelayMs, MaxRetries: &maxRetries}
+
+		schedule := processor.RemainingRetrySchedule(testWebhook(0, config))
+
+		assert.Equal(t, []ProjectedRetry{
+			{RetryLevel: 1, MinDelayMs: 5000, MaxDelayMs: 5000},
+			{RetryLevel: 2, MinDelayMs: (225 * time.Second).Milliseconds(), MaxDelayMs: (375 * time.Second).Milliseconds()},
+		}, schedule)
+	})
+
+	t.Run("should stop at the config's retry limit", func(t *testing.T) {
+		maxRetries := 3
+		schedule := processor.RemainingRetrySchedule(testWebhook(2, &entities.WebhookConfig{MaxRetries: &maxRetries}))
+
+		require.Len(t, schedule, 1)
+		assert.Equal(t, 3, schedule[0].RetryLevel)
+	})
+
+	t.Run("should apply the max retry delay to projected ranges", func(t *testing.T) {
+		capped, _ := newTestProcessor(t)
+		capped.SetMaxRetryDelay(time.Hour)
+
+		schedule := capped.RemainingRetrySchedule(testWebhook(5, nil))
+
+		assert.Equal(t, []ProjectedRetry{
+			{RetryLevel: 6, MinDelayMs: time.Hour.Milliseconds(), MaxDelayMs: time.Hour.Milliseconds()},
+		}, schedule)
+	})
+
+	t.Run("should project nothing for finished webhooks", func(t *testing.T) {
+		for _, status := range []enums.WebhookStatus{enums.WebhookStatusCompleted, enums.WebhookStatusFailed} {
+			webhook := testWebhook(0, nil)
+			webhook.Status = status
+
+			schedule := processor.RemainingRetrySchedule(webhook)
+
+			assert.NotNil(t, schedule)
+			assert.Empty(t, schedule)
+		}
+	})
+}
+
+func TestWebhookProcessor_ProcessWebhook_StatusCallbacks(t *testing.T) {
+	maxRetries := 1
+	config := &entities.WebhookConfig{
+		ID:           1,
+		WebhookURL:   "https://example.com/webhook",
+		IsActive:     true,
+		MaxRetries:   &maxRetries,
+		OnSuccessURL: "https://example.com/on-success",
+		OnFailureURL: "https://example.com/on-failure",
+	}
+
+	t.Run("should post the success callback once on delivery", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		mockCallbacks := mocks.NewMockStatusCallbackService(m.ctrl)
+		processor.SetStatusCallbacks(mockCallbacks)
+		ctx := context.Background()
+		webhook := testWebhook(0, config)
+		webhook.EventID = "evt-1"
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 200, Body: "body"}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, "body", gomock.Any(), gomock.Any(), gomock.Any())
+		m.queueRepo.EXPECT().MarkCompleted(ctx, webhook.ID, "worker-1", gomock.Any(), 200).Return(nil)
+		mockCallbacks.EXPECT().SendStatusCallback(gomock.Any(), "https://example.com/on-success", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url string, callback *entities.StatusCallback) error {
+				assert.Equal(t, entities.StatusCallbackSucceeded, callback.Event)
+				assert.Equal(t, webhook.QueueID.String(), callback.QueueID)
+				assert.Equal(t, "evt-1", callback.EventID)
+				assert.Equal(t, 200, callback.LastHTTPStatus)
+				assert.Empty(t, callback.Error)
+				return nil
+			}).Times(1)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+
+	t.Run("should post the failure callback once with the reason on permanent failure", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		mockCallbacks := mocks.NewMockStatusCallbackService(m.ctrl)
+		processor.SetStatusCallbacks(mockCallbacks)
+		ctx := context.Background()
+		webhook := testWebhook(1, config)
+		webhook.EventID = "evt-1"
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 503, Body: "body"}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 1, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 503, "body", gomock.Any(), gomock.Any(), gomock.Any())
+		m.queueRepo.EXPECT().MarkFailed(ctx, webhook.ID, "worker-1", "max retries exceeded: HTTP 503", 503).Return(nil)
+		mockCallbacks.EXPECT().SendStatusCallback(gomock.Any(), "https://example.com/on-failure", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, url string, callback *entities.StatusCallback) error {
+				assert.Equal(t, entities.StatusCallbackFailed, callback.Event)
+				assert.Equal(t, "max retries exceeded: HTTP 503", callback.Error)
+				assert.Equal(t, 503, callback.LastHTTPStatus)
+				return nil
+			}).Times(1)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+
+	t.Run("should not post a callback when scheduling a retry", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.SetStatusCallbacks(mocks.NewMockStatusCallbackService(m.ctrl))
+		ctx := context.Background()
+		webhook := testWebhook(0, config)
+		webhook.EventID = "evt-1"
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 503, Body: "body"}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 503, "body", gomock.Any(), gomock.Any(), gomock.Any())
+		m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+
+	t.Run("should keep the outcome when the callback fails", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		mockCallbacks := mocks.NewMockStatusCallbackService(m.ctrl)
+		processor.SetStatusCallbacks(mockCallbacks)
+		ctx := context.Background()
+		webhook := testWebhook(0, config)
+		webhook.EventID = "evt-1"
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).
+			Return(&services.WebhookResponse{StatusCode: 200, Body: "body"}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, "body", gomock.Any(), gomock.Any(), gomock.Any())
+		m.queueRepo.EXPECT().MarkCompleted(ctx, webhook.ID, "worker-1", gomock.Any(), 200).Return(nil)
+		mockCallbacks.EXPECT().SendStatusCallback(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("callback endpoint down")).Times(1)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+}
+
+func TestWebhookProcessor_ProcessWebhook_FailureNotifier(t *testing.T) {
+	maxRetries := 1
+	config := &entities.WebhookConfig{
+		ID:         1,
+		Name:       "Ledger",
+		WebhookURL: "https://example.com/webhook",
+		IsActive:   true,
+		MaxRetries: &maxRetries,
+	}
 
-	t.Run("should have minimum 1 minute delay even with negative jitter", func(t *testing.T) {
-		// This test ensures the minimum delay logic works
-		for i := 0; i < 100; i++ {
-			nextRetryTime := processor.calculateNextRetryTime(0)
-			delay := nextRetryTime.Sub(time.Now().UTC())
-			assert.True(t, delay >= time.Minute, "Delay should never be less than 1 minute, got %v", delay)
-		}
+	t.Run("should notify once with the queue ID, config and reason on permanent failure", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		mockNotifier := mocks.NewMockFailureNotifier(m.ctrl)
+		processor.SetFailureNotifier(mockNotifier)
+		ctx := context.Background()
+		webhook := testWebhook(1, config)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).Return(&services.WebhookResponse{StatusCode: 503}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 1, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 503, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		m.queueRepo.EXPECT().MarkFailed(ctx, webhook.ID, "worker-1", "max retries exceeded: HTTP 503", 503).Return(nil)
+		mockNotifier.EXPECT().NotifyFailure(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, notification *entities.FailureNotification) {
+				assert.Equal(t, webhook.QueueID.String(), notification.QueueID)
+				assert.Equal(t, int64(1), notification.ConfigID)
+				assert.Equal(t, "Ledger", notification.ConfigName)
+				assert.Equal(t, "max retries exceeded: HTTP 503", notification.Reason)
+				assert.Equal(t, 503, notification.LastHTTPStatus)
+			}).Times(1)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
 	})
-}
 
-// TestWebhookProcessor_ResetWebhookToPending tests the reset functionality
-func TestWebhookProcessor_ResetWebhookToPending(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	t.Run("should not notify when scheduling a retry", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.SetFailureNotifier(mocks.NewMockFailureNotifier(m.ctrl))
+		ctx := context.Background()
+		webhook := testWebhook(0, config)
 
-	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).Return(&services.WebhookResponse{StatusCode: 503}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 503, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").Return(nil)
 
-	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
 
-	t.Run("should reset webhook to pending status", func(t *testing.T) {
+	t.Run("should not notify when the webhook cannot be marked failed", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.SetFailureNotifier(mocks.NewMockFailureNotifier(m.ctrl))
 		ctx := context.Background()
-		now := time.Now().UTC()
+		webhook := testWebhook(1, config)
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  2,
-			NextRetryAt: now,
-			CreatedAt:   now.Add(-time.Hour),
-			UpdatedAt:   now.Add(-time.Minute),
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).Return(&services.WebhookResponse{StatusCode: 503}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 1, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 503, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		m.queueRepo.EXPECT().MarkFailed(ctx, int64(1), "worker-1", gomock.Any(), 503).Return(errors.New("database unavailable"))
+
+		assert.Error(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+}
+
+// recordingPublisher collects the events a processor publishes, in order
+type recordingPublisher struct {
+	events []*entities.TransitionEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event *entities.TransitionEvent) {
+	p.events = append(p.events, event)
+}
+
+func TestWebhookProcessor_EventPublisher(t *testing.T) {
+	maxRetries := 1
+	config := &entities.WebhookConfig{ID: 1, WebhookURL: "https://example.com/webhook", IsActive: true, MaxRetries: &maxRetries}
+
+	// sequence lists the type and statuses of each event
+	sequence := func(events []*entities.TransitionEvent) []string {
+		var steps []string
+		for _, event := range events {
+			steps = append(steps, fmt.Sprintf("%s %s->%s", event.Type, event.OldStatus, event.NewStatus))
 		}
+		return steps
+	}
 
-		mockQueueRepo.EXPECT().
-			Update(ctx, gomock.Any()).
-			DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue) error {
-				assert.Equal(t, enums.WebhookStatusPending, w.Status)
-				assert.True(t, w.UpdatedAt.After(now.Add(-time.Second)))
-				// Other fields should remain unchanged
-				assert.Equal(t, webhook.ID, w.ID)
-				assert.Equal(t, webhook.QueueID, w.QueueID)
-				assert.Equal(t, webhook.EventType, w.EventType)
-				assert.Equal(t, webhook.EventID, w.EventID)
-				assert.Equal(t, webhook.ConfigID, w.ConfigID)
-				assert.Equal(t, webhook.RetryCount, w.RetryCount)
-				return nil
-			}).
-			Times(1)
+	t.Run("should publish each transition of a webhook delivered on its first attempt", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		publisher := &recordingPublisher{}
+		processor.SetEventPublisher(publisher)
+		ctx := context.Background()
+		queueID := uuid.New()
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil).AnyTimes()
+		m.service.EXPECT().SendWebhook(ctx, gomock.Any()).Return(&services.WebhookResponse{StatusCode: 200}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+		m.queueRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, w *entities.WebhookQueue) error {
+			w.ID, w.QueueID = 1, queueID
+			return nil
+		})
+		m.queueRepo.EXPECT().MarkCompleted(ctx, int64(1), "worker-1", gomock.Any(), 200).Return(nil)
+
+		_, err := processor.CreateWebhookEntry(ctx, enums.EventTypeCredit, "evt-1", 1, nil, "")
+		require.NoError(t, err)
+		webhook := testWebhook(0, config)
+		webhook.QueueID = queueID
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		assert.Equal(t, []string{
+			"webhook.created ->PENDING",
+			"webhook.processing_started PENDING->PROCESSING",
+			"webhook.attempt_recorded PROCESSING->PROCESSING",
+			"webhook.completed PROCESSING->COMPLETED",
+		}, sequence(publisher.events))
+		for _, event := range publisher.events {
+			assert.Equal(t, queueID.String(), event.QueueID)
+			assert.False(t, event.OccurredAt.IsZero())
+		}
+	})
 
-		err := processor.ResetWebhookToPending(ctx, webhook)
-		assert.NoError(t, err)
-		assert.Equal(t, enums.WebhookStatusPending, webhook.Status)
+	t.Run("should publish each transition of a webhook that fails after its retry", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		publisher := &recordingPublisher{}
+		processor.SetEventPublisher(publisher)
+		ctx := context.Background()
+		webhook := testWebhook(0, config)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil).Times(2)
+		m.service.EXPECT().SendWebhook(ctx, webhook).Return(&services.WebhookResponse{StatusCode: 503}, nil).Times(2)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 503, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil).Times(2)
+		m.queueRepo.EXPECT().Update(ctx, gomock.Any(), "worker-1").Return(nil)
+		m.queueRepo.EXPECT().MarkFailed(ctx, int64(1), "worker-1", "max retries exceeded: HTTP 503", 503).Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+		webhook.Status = enums.WebhookStatusProcessing
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+
+		assert.Equal(t, []string{
+			"webhook.processing_started PENDING->PROCESSING",
+			"webhook.attempt_recorded PROCESSING->PROCESSING",
+			"webhook.retry_scheduled PROCESSING->PENDING",
+			"webhook.processing_started PENDING->PROCESSING",
+			"webhook.attempt_recorded PROCESSING->PROCESSING",
+			"webhook.failed PROCESSING->FAILED",
+		}, sequence(publisher.events))
+		var retryCounts []int
+		for _, event := range publisher.events {
+			retryCounts = append(retryCounts, event.RetryCount)
+		}
+		assert.Equal(t, []int{0, 0, 1, 1, 1, 1}, retryCounts, "the retry is scheduled at the next retry count")
 	})
 
-	t.Run("should handle update failure", func(t *testing.T) {
+	t.Run("should publish no outcome when the claim was lost during the attempt", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		publisher := &recordingPublisher{}
+		processor.SetEventPublisher(publisher)
 		ctx := context.Background()
-		now := time.Now().UTC()
+		webhook := testWebhook(0, config)
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  2,
-			NextRetryAt: now,
-			CreatedAt:   now.Add(-time.Hour),
-			UpdatedAt:   now.Add(-time.Minute),
-		}
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(config, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).Return(&services.WebhookResponse{StatusCode: 200}, nil)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("failed to update retry attempt: %w", repositories.ErrClaimLost))
 
-		mockQueueRepo.EXPECT().
-			Update(ctx, gomock.Any()).
-			Return(errors.New("database update failed")).
-			Times(1)
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
 
-		err := processor.ResetWebhookToPending(ctx, webhook)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "database update failed")
+		assert.Equal(t, []string{"webhook.processing_started PENDING->PROCESSING"}, sequence(publisher.events),
+			"the webhook's new claimant reports its outcome")
 	})
-}
 
-// TestWebhookProcessor_EdgeCases tests edge cases and boundary conditions
-func TestWebhookProcessor_EdgeCases(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	t.Run("should publish a cancellation when an operator force-fails a webhook", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		publisher := &recordingPublisher{}
+		processor.SetEventPublisher(publisher)
+		ctx := context.Background()
+		queueID := uuid.New()
 
-	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
+		m.queueRepo.EXPECT().ForceFail(ctx, queueID, "receiver retired").Return(enums.WebhookStatusPending, nil)
+		m.queueRepo.EXPECT().GetByQueueID(ctx, queueID).
+			Return(&entities.WebhookQueue{ID: 1, QueueID: queueID, Status: enums.WebhookStatusFailed, RetryCount: 3}, nil)
 
-	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+		require.NoError(t, processor.ForceFailWebhook(ctx, queueID, "receiver retired"))
 
-	t.Run("should handle webhook with nil response from service", func(t *testing.T) {
+		require.Len(t, publisher.events, 1)
+		assert.Equal(t, "webhook.cancelled PENDING->FAILED", sequence(publisher.events)[0])
+		assert.Equal(t, 3, publisher.events[0].RetryCount)
+	})
+	t.Run("should publish a cancellation from the status an in-flight webhook left", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		publisher := &recordingPublisher{}
+		processor.SetEventPublisher(publisher)
 		ctx := context.Background()
-		workerID := "worker-1"
-		now := time.Now().UTC()
+		queueID := uuid.New()
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  1,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
+		m.queueRepo.EXPECT().ForceFail(ctx, queueID, "receiver retired").Return(enums.WebhookStatusProcessing, nil)
+		m.queueRepo.EXPECT().GetByQueueID(ctx, queueID).
+			Return(&entities.WebhookQueue{ID: 1, QueueID: queueID, Status: enums.WebhookStatusFailed, RetryCount: 1}, nil)
 
-		// Service returns nil response and error
-		mockWebhookService.EXPECT().
-			SendWebhook(ctx, webhook).
-			Return(nil, errors.New("network error")).
-			Times(1)
+		require.NoError(t, processor.ForceFailWebhook(ctx, queueID, "receiver retired"))
 
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 0, "", "network error").
-			Return(nil).
-			Times(1)
+		assert.Equal(t, []string{"webhook.cancelled PROCESSING->FAILED"}, sequence(publisher.events))
+	})
 
-		mockQueueRepo.EXPECT().
-			Update(ctx, gomock.Any()).
-			Return(nil).
-			Times(1)
+}
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
-		assert.NoError(t, err)
-		assert.Equal(t, 0, webhook.LastHTTPStatus)
-		assert.Equal(t, "network error", webhook.LastError)
+func TestWebhookProcessor_ProcessWebhook_ClaimRenewal(t *testing.T) {
+	t.Run("should renew the claim while a delivery outlasts the claim TTL", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.SetClaimTTL(30 * time.Millisecond)
+		ctx := context.Background()
+		webhook := testWebhook(0, nil)
+
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).DoAndReturn(func(context.Context, *entities.WebhookQueue) (*services.WebhookResponse, error) {
+			time.Sleep(100 * time.Millisecond)
+			return &services.WebhookResponse{StatusCode: 200}, nil
+		})
+		m.queueRepo.EXPECT().ExtendClaim(ctx, int64(1), "worker-1").Return(nil).MinTimes(2)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+		m.queueRepo.EXPECT().MarkCompleted(ctx, int64(1), "worker-1", gomock.Any(), 200).Return(nil)
+
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
 	})
 
-	t.Run("should handle empty error message gracefully", func(t *testing.T) {
+	t.Run("should stop renewing once the claim is lost", func(t *testing.T) {
+		processor, m := newTestProcessor(t)
+		processor.SetClaimTTL(30 * time.Millisecond)
 		ctx := context.Background()
-		workerID := "worker-1"
-		now := time.Now().UTC()
+		webhook := testWebhook(0, nil)
 
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   enums.EventTypeCredit,
-			EventID:     "test-event",
-			ConfigID:    1,
-			WebhookURL:  "https://example.com/webhook",
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  0,
-			NextRetryAt: now,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
+		m.configRepo.EXPECT().GetByID(ctx, int64(1)).Return(nil, nil)
+		m.service.EXPECT().SendWebhook(ctx, webhook).DoAndReturn(func(context.Context, *entities.WebhookQueue) (*services.WebhookResponse, error) {
+			time.Sleep(100 * time.Millisecond)
+			return &services.WebhookResponse{StatusCode: 200}, nil
+		})
+		m.queueRepo.EXPECT().ExtendClaim(ctx, int64(1), "worker-1").Return(repositories.ErrClaimLost).Times(1)
+		m.queueRepo.EXPECT().
+			UpdateRetryAttempt(ctx, int64(1), "worker-1", 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), 200, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(repositories.ErrClaimLost)
 
-		response := &services.WebhookResponse{
-			StatusCode: 200,
-			Body:       `{"success": true}`,
-			Duration:   time.Millisecond * 500,
-			Error:      nil,
-		}
+		require.NoError(t, processor.ProcessWebhook(ctx, webhook, "worker-1"))
+	})
+}
 
-		mockWebhookService.EXPECT().
-			SendWebhook(ctx, webhook).
-			Return(response, nil).
-			Times(1)
+func TestWebhookProcessor_ProcessWebhook_StoreRequests(t *testing.T) {
+	sent := &entities.AttemptRequest{
+		Body:    `{"id":"evt-1"}`,
+		Headers: map[string]string{"Content-Type": "application/json"},
+	}
 
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 200, `{"success": true}`, "").
-			Return(nil).
-			Times(1)
+	// process delivers a webhook whose receiver rejects it and returns the request the attempt recorded
+	process := func(t *testing.T, store bool) *entities.AttemptRequest {
+		ctrl := gomock.NewController(t)
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+		processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+		processor.SetStoreRequests(store)
+
+		maxRetries := 0
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).
+			Return(&entities.WebhookConfig{ID: 1, IsActive: true, MaxRetries: &maxRetries}, nil)
+		mockWebhookService.EXPECT().SendWebhook(gomock.Any(), gomock.Any()).
+			Return(&services.WebhookResponse{StatusCode: 400, Body: "missing field", Request: sent}, nil)
 
+		var recorded *entities.AttemptRequest
 		mockQueueRepo.EXPECT().
-			MarkCompleted(ctx, webhook.ID, gomock.Any()).
-			Return(nil).
-			Times(1)
+			UpdateRetryAttempt(gomock.Any(), int64(1), "worker-1", 0, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				400, "missing field", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, _ string, _ int, _ time.Time, _ *time.Time, _ int64, _ int64, _ int, _, _ string, _ enums.ErrorClass, request *entities.AttemptRequest) error {
+				recorded = request
+				return nil
+			})
+		mockQueueRepo.EXPECT().MarkFailed(gomock.Any(), int64(1), "worker-1", gomock.Any(), 400).Return(nil)
 
-		err := processor.ProcessWebhook(ctx, webhook, workerID)
-		assert.NoError(t, err)
-		assert.Equal(t, 200, webhook.LastHTTPStatus)
-		assert.Equal(t, "", webhook.LastError) // Should remain empty
+		webhook := &entities.WebhookQueue{
+			ID:         1,
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeCredit,
+			ConfigID:   1,
+			WebhookURL: "https://example.com/webhook",
+			Status:     enums.WebhookStatusProcessing,
+		}
+		require.NoError(t, processor.ProcessWebhook(context.Background(), webhook, "worker-1"))
+		return recorded
+	}
+
+	t.Run("should record the sent request with the attempt when enabled", func(t *testing.T) {
+		assert.Equal(t, sent, process(t, true))
+	})
+
+	t.Run("should not record the sent request by default", func(t *testing.T) {
+		assert.Nil(t, process(t, false))
 	})
 }
 
-// TestWebhookProcessor_Integration tests integration scenarios
-func TestWebhookProcessor_Integration(t *testing.T) {
+func TestWebhookProcessor_ListProcessingWebhooks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
-	mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
-	mockWebhookService := mocks.NewMockWebhookService(ctrl)
-	logger := log.NewNopLogger()
-
-	processor := NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, logger)
+	processor := NewWebhookProcessor(mockQueueRepo, mocks.NewMockWebhookConfigRepository(ctrl),
+		mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+	processor.SetClaimTTL(5 * time.Minute)
+
+	fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	processor.now = func() time.Time { return fixedNow }
+
+	// claimed returns a PROCESSING webhook claimed by workerID age ago and never renewed
+	claimed := func(workerID string, age time.Duration) *entities.WebhookQueue {
+		expiresAt := fixedNow.Add(-age).Add(5 * time.Minute)
+		return &entities.WebhookQueue{
+			QueueID:        uuid.New(),
+			WebhookURL:     "https://example.com/webhook",
+			Status:         enums.WebhookStatusProcessing,
+			UpdatedAt:      fixedNow.Add(-age),
+			ClaimedBy:      &workerID,
+			ClaimExpiresAt: &expiresAt,
+		}
+	}
 
-	t.Run("should handle complete workflow from creation to completion", func(t *testing.T) {
+	t.Run("should age claims and flag those past the claim TTL", func(t *testing.T) {
 		ctx := context.Background()
-		eventType := enums.EventTypeCredit
-		eventID := "integration-test-event"
-		configID := int64(1)
-
-		// Step 1: Create webhook entry
-		config := &entities.WebhookConfig{
-			ID:         configID,
-			Name:       "Integration Test Config",
-			EventType:  eventType,
-			WebhookURL: "https://example.com/webhook",
-			IsActive:   true,
-			TimeoutMs:  30000,
-		}
+		mockQueueRepo.EXPECT().List(ctx, repositories.WebhookQueueFilter{
+			Statuses: []enums.WebhookStatus{enums.WebhookStatusProcessing},
+		}, 100).Return([]*entities.WebhookQueue{
+			claimed("retry-0-a", 10*time.Second),
+			claimed("retry-1-a", 5*time.Minute),
+			claimed("retry-2-a", 20*time.Minute),
+		}, nil)
+
+		processing, err := processor.ListProcessingWebhooks(ctx, 100)
+
+		require.NoError(t, err)
+		require.Len(t, processing, 3)
+		assert.Equal(t, 10*time.Second, processing[0].ClaimAge)
+		assert.Equal(t, fixedNow.Add(-10*time.Second), processing[0].ClaimedAt)
+		assert.False(t, processing[0].Overdue)
+		assert.False(t, processing[1].Overdue, "a claim exactly at its TTL is not yet reclaimable")
+		assert.Equal(t, 20*time.Minute, processing[2].ClaimAge)
+		assert.True(t, processing[2].Overdue)
+	})
 
-		mockConfigRepo.EXPECT().
-			GetByID(ctx, configID).
-			Return(config, nil).
-			Times(1)
+	t.Run("should age a renewed claim from when it was taken without flagging it", func(t *testing.T) {
+		ctx := context.Background()
+		webhook := claimed("retry-0-b", 20*time.Minute)
+		renewedUntil := fixedNow.Add(4 * time.Minute)
+		webhook.ClaimExpiresAt = &renewedUntil
+		mockQueueRepo.EXPECT().List(ctx, gomock.Any(), 100).Return([]*entities.WebhookQueue{webhook}, nil)
 
-		mockQueueRepo.EXPECT().
-			Create(ctx, gomock.Any()).
-			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) error {
-				webhook.ID = 1
-				webhook.QueueID = uuid.New()
-				return nil
-			}).
-			Times(1)
+		processing, err := processor.ListProcessingWebhooks(ctx, 100)
 
-		err := processor.CreateWebhookEntry(ctx, eventType, eventID, configID)
-		assert.NoError(t, err)
+		require.NoError(t, err)
+		require.Len(t, processing, 1)
+		assert.Equal(t, 20*time.Minute, processing[0].ClaimAge)
+		assert.False(t, processing[0].Overdue, "a delivery still renewing its claim is not hung")
+	})
 
-		// Step 2: Process the webhook successfully
-		webhook := &entities.WebhookQueue{
-			ID:          1,
-			QueueID:     uuid.New(),
-			EventType:   eventType,
-			EventID:     eventID,
-			ConfigID:    configID,
-			WebhookURL:  config.WebhookURL,
-			Status:      enums.WebhookStatusProcessing,
-			RetryCount:  0,
-			NextRetryAt: time.Now().UTC(),
-			CreatedAt:   time.Now().UTC(),
-			UpdatedAt:   time.Now().UTC(),
-		}
+	t.Run("should age an unclaimed row from its last update", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().List(ctx, gomock.Any(), 100).Return([]*entities.WebhookQueue{
+			{QueueID: uuid.New(), Status: enums.WebhookStatusProcessing, UpdatedAt: fixedNow.Add(-time.Hour)},
+		}, nil)
 
-		response := &services.WebhookResponse{
-			StatusCode: 200,
-			Body:       `{"message": "webhook received"}`,
-			Duration:   time.Millisecond * 300,
-			Error:      nil,
-		}
+		processing, err := processor.ListProcessingWebhooks(ctx, 100)
 
-		mockWebhookService.EXPECT().
-			SendWebhook(ctx, webhook).
-			Return(response, nil).
-			Times(1)
+		require.NoError(t, err)
+		require.Len(t, processing, 1)
+		assert.Equal(t, time.Hour, processing[0].ClaimAge)
+		assert.True(t, processing[0].Overdue)
+	})
 
-		mockQueueRepo.EXPECT().
-			UpdateRetryAttempt(ctx, webhook.ID, webhook.RetryCount, gomock.Any(), gomock.Any(),
-				gomock.Any(), 200, `{"message": "webhook received"}`, "").
-			Return(nil).
-			Times(1)
+	t.Run("should wrap a repository error", func(t *testing.T) {
+		ctx := context.Background()
+		mockQueueRepo.EXPECT().List(ctx, gomock.Any(), 100).Return(nil, errors.New("connection refused"))
 
-		mockQueueRepo.EXPECT().
-			MarkCompleted(ctx, webhook.ID, gomock.Any()).
-			Return(nil).
-			Times(1)
+		_, err := processor.ListProcessingWebhooks(ctx, 100)
 
-		err = processor.ProcessWebhook(ctx, webhook, "integration-worker")
-		assert.NoError(t, err)
+		assert.ErrorContains(t, err, "failed to list processing webhooks")
 	})
 }
@@ -0,0 +1,127 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/infrastructure/metrics"
+)
+
+// ClaimReaper periodically returns PROCESSING webhooks whose worker claim has expired to PENDING,
+// so webhooks held by a crashed or hung worker are retried
+type ClaimReaper struct {
+	processor *usecases.WebhookProcessor
+	logger    log.Logger
+	config    config.ClaimConfig
+	metrics   *metrics.WebhookMetrics
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	running   bool
+	mu        sync.Mutex
+
+	newTicker func(d time.Duration) (<-chan time.Time, func())
+}
+
+// NewClaimReaper creates a new claim reaper
+func NewClaimReaper(
+	processor *usecases.WebhookProcessor,
+	logger log.Logger,
+	claimConfig config.ClaimConfig,
+	metrics *metrics.WebhookMetrics,
+) *ClaimReaper {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ClaimReaper{
+		processor: processor,
+		logger:    logger,
+		config:    claimConfig,
+		metrics:   metrics,
+		ctx:       ctx,
+		cancel:    cancel,
+		newTicker: newTimeTicker,
+	}
+}
+
+// Start starts the periodic reaping of expired claims
+func (r *ClaimReaper) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running {
+		return fmt.Errorf("claim reaper is already running")
+	}
+
+	if r.config.ReapInterval <= 0 {
+		return fmt.Errorf("claim reaper has invalid interval: %v", r.config.ReapInterval)
+	}
+
+	r.running = true
+
+	r.logger.Log("level", "info", "msg", "starting claim reaper",
+		"interval", r.config.ReapInterval, "claim_ttl", r.config.TTL)
+
+	r.wg.Add(1)
+	go r.reapLoop()
+
+	return nil
+}
+
+// Stop stops the periodic reaping of expired claims
+func (r *ClaimReaper) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		return fmt.Errorf("claim reaper is not running")
+	}
+
+	r.cancel()
+	r.wg.Wait()
+	r.running = false
+
+	r.logger.Log("level", "info", "msg", "claim reaper stopped")
+
+	return nil
+}
+
+// reapLoop reaps expired claims on every tick until stopped
+func (r *ClaimReaper) reapLoop() {
+	defer r.wg.Done()
+
+	ticks, stop := r.newTicker(r.config.ReapInterval)
+	defer stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticks:
+			if _, err := r.Reap(r.ctx); err != nil {
+				r.logger.Log("level", "error", "msg", "claim reap failed", "error", err)
+			}
+		}
+	}
+}
+
+// Reap returns webhooks with expired claims to PENDING and returns how many were reclaimed
+func (r *ClaimReaper) Reap(ctx context.Context) (int64, error) {
+	reclaimed, err := r.processor.ReclaimExpiredClaims(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if reclaimed > 0 {
+		r.metrics.RecordClaimsReclaimed(reclaimed)
+		r.logger.Log("level", "warn", "msg", "reclaimed webhooks with expired processing claims",
+			"count", reclaimed)
+	}
+
+	return reclaimed, nil
+}
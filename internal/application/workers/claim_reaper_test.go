@@ -0,0 +1,94 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/mocks"
+)
+
+func TestClaimReaper_Reap(t *testing.T) {
+	claimConfig := config.ClaimConfig{TTL: 5 * time.Minute, ReapInterval: time.Minute}
+
+	t.Run("should return webhooks with expired claims to pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockQueueRepo.EXPECT().ReclaimExpiredClaims(gomock.Any(), gomock.Any()).Return(int64(2), nil)
+
+		var reclaimedLogs int
+		logger := log.LoggerFunc(func(keyvals ...interface{}) error {
+			for i := 0; i+1 < len(keyvals); i += 2 {
+				if keyvals[i] == "msg" && keyvals[i+1] == "reclaimed webhooks with expired processing claims" {
+					reclaimedLogs++
+				}
+			}
+			return nil
+		})
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mocks.NewMockWebhookConfigRepository(ctrl), mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		reaper := NewClaimReaper(processor, logger, claimConfig, testMetrics)
+
+		reclaimed, err := reaper.Reap(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), reclaimed)
+		assert.Equal(t, 1, reclaimedLogs)
+	})
+
+	t.Run("should surface repository errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockQueueRepo.EXPECT().ReclaimExpiredClaims(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("connection lost"))
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mocks.NewMockWebhookConfigRepository(ctrl), mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		reaper := NewClaimReaper(processor, log.NewNopLogger(), claimConfig, testMetrics)
+
+		_, err := reaper.Reap(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection lost")
+	})
+
+	t.Run("should reap on every tick until stopped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		reaped := make(chan struct{}, 1)
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockQueueRepo.EXPECT().ReclaimExpiredClaims(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, asOf time.Time) (int64, error) {
+				reaped <- struct{}{}
+				return 0, nil
+			})
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mocks.NewMockWebhookConfigRepository(ctrl), mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		reaper := NewClaimReaper(processor, log.NewNopLogger(), claimConfig, testMetrics)
+		ticks := make(chan time.Time)
+		reaper.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+			assert.Equal(t, time.Minute, d)
+			return ticks, func() {}
+		}
+
+		require.NoError(t, reaper.Start())
+		ticks <- time.Now()
+		select {
+		case <-reaped:
+		case <-time.After(time.Second):
+			t.Fatal("claim reaper did not reap on tick")
+		}
+		require.NoError(t, reaper.Stop())
+	})
+}
@@ -0,0 +1,143 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/infrastructure/metrics"
+)
+
+// ConsistencyChecker periodically scans for COMPLETED webhooks with no successful attempt,
+// which would indicate a bug in the success/fail state machine
+type ConsistencyChecker struct {
+	processor *usecases.WebhookProcessor
+	logger    log.Logger
+	config    config.ReconciliationConfig
+	metrics   *metrics.WebhookMetrics
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	running   bool
+	mu        sync.Mutex
+
+	// checkedUntil is the completion time already covered by previous checks
+	checkedUntil time.Time
+
+	now       func() time.Time
+	newTicker func(d time.Duration) (<-chan time.Time, func())
+}
+
+// NewConsistencyChecker creates a new consistency checker
+func NewConsistencyChecker(
+	processor *usecases.WebhookProcessor,
+	logger log.Logger,
+	reconciliationConfig config.ReconciliationConfig,
+	metrics *metrics.WebhookMetrics,
+) *ConsistencyChecker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ConsistencyChecker{
+		processor: processor,
+		logger:    logger,
+		config:    reconciliationConfig,
+		metrics:   metrics,
+		ctx:       ctx,
+		cancel:    cancel,
+		now:       func() time.Time { return time.Now().UTC() },
+		newTicker: newTimeTicker,
+	}
+}
+
+// Start starts the periodic consistency check
+func (c *ConsistencyChecker) Start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.running {
+		return fmt.Errorf("consistency checker is already running")
+	}
+
+	if c.config.Interval <= 0 {
+		return fmt.Errorf("consistency checker has invalid interval: %v", c.config.Interval)
+	}
+
+	c.running = true
+
+	c.logger.Log("level", "info", "msg", "starting consistency checker",
+		"interval", c.config.Interval, "lookback", c.config.Lookback)
+
+	c.wg.Add(1)
+	go c.checkLoop()
+
+	return nil
+}
+
+// Stop stops the periodic consistency check
+func (c *ConsistencyChecker) Stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return fmt.Errorf("consistency checker is not running")
+	}
+
+	c.cancel()
+	c.wg.Wait()
+	c.running = false
+
+	c.logger.Log("level", "info", "msg", "consistency checker stopped")
+
+	return nil
+}
+
+// checkLoop runs a check on every tick until stopped
+func (c *ConsistencyChecker) checkLoop() {
+	defer c.wg.Done()
+
+	ticks, stop := c.newTicker(c.config.Interval)
+	defer stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticks:
+			if _, err := c.Check(c.ctx); err != nil {
+				c.logger.Log("level", "error", "msg", "consistency check failed", "error", err)
+			}
+		}
+	}
+}
+
+// Check scans webhooks completed since the last check and flags those without a successful attempt
+// Returns the number of anomalies found
+func (c *ConsistencyChecker) Check(ctx context.Context) (int, error) {
+	checkStart := c.now()
+
+	since := c.checkedUntil
+	if since.IsZero() {
+		since = checkStart.Add(-c.config.Lookback)
+	}
+
+	anomalies, err := c.processor.FindCompletionAnomalies(ctx, since, c.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, webhook := range anomalies {
+		c.metrics.RecordCompletionAnomaly()
+		c.logger.Log("level", "error", "msg", "completed webhook has no successful attempt",
+			"queue_id", webhook.QueueID, "config_id", webhook.ConfigID,
+			"attempt_statuses", fmt.Sprint(webhook.AttemptHTTPStatuses()), "completed_at", webhook.CompletedAt)
+	}
+
+	c.checkedUntil = checkStart
+
+	return len(anomalies), nil
+}
@@ -0,0 +1,79 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/mocks"
+)
+
+func TestConsistencyChecker_Check(t *testing.T) {
+	t.Run("should flag a completed webhook with no successful attempt", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+
+		now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		serverError := 500
+		completedAt := now.Add(-time.Minute)
+		inconsistent := &entities.WebhookQueue{
+			ID:               1,
+			QueueID:          uuid.New(),
+			ConfigID:         1,
+			Status:           enums.WebhookStatusCompleted,
+			Retry0HTTPStatus: &serverError,
+			CompletedAt:      &completedAt,
+		}
+
+		// First check scans the lookback window, the next resumes where it stopped
+		gomock.InOrder(
+			mockQueueRepo.EXPECT().
+				FindCompletedWithoutSuccess(gomock.Any(), now.Add(-24*time.Hour), 500).
+				Return([]*entities.WebhookQueue{inconsistent}, nil),
+			mockQueueRepo.EXPECT().
+				FindCompletedWithoutSuccess(gomock.Any(), now, 500).
+				Return(nil, nil),
+		)
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&entities.WebhookConfig{ID: 1}, nil)
+
+		var flagged []interface{}
+		logger := log.LoggerFunc(func(keyvals ...interface{}) error {
+			for i := 0; i+1 < len(keyvals); i += 2 {
+				if keyvals[i] == "msg" && keyvals[i+1] == "completed webhook has no successful attempt" {
+					flagged = append(flagged, keyvals...)
+				}
+			}
+			return nil
+		})
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		checker := NewConsistencyChecker(processor, logger, config.ReconciliationConfig{
+			Interval:  time.Minute,
+			Lookback:  24 * time.Hour,
+			BatchSize: 500,
+		}, testMetrics)
+		checker.now = func() time.Time { return now }
+
+		count, err := checker.Check(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Contains(t, flagged, inconsistent.QueueID)
+
+		count, err = checker.Check(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
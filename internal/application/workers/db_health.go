@@ -0,0 +1,164 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/log"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/infrastructure/metrics"
+)
+
+// DBHealth tracks whether the database is reachable for the workers that share it
+// After FailureThreshold consecutive failed polls the database is marked down and workers skip their
+// polls instead of failing and logging on every tick; a periodic ping marks it up again once it answers
+type DBHealth struct {
+	ping    func(ctx context.Context) error
+	logger  log.Logger
+	config  config.DBHealthConfig
+	metrics *metrics.WebhookMetrics
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+	mu      sync.Mutex
+
+	// failures counts consecutive failed polls across workers
+	failures atomic.Int64
+	down     atomic.Bool
+
+	newTicker func(d time.Duration) (<-chan time.Time, func())
+}
+
+// NewDBHealth creates a database health tracker that checks recovery with ping
+func NewDBHealth(
+	ping func(ctx context.Context) error,
+	logger log.Logger,
+	dbHealthConfig config.DBHealthConfig,
+	metrics *metrics.WebhookMetrics,
+) *DBHealth {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	metrics.RecordDBReachable(true)
+	return &DBHealth{
+		ping:      ping,
+		logger:    logger,
+		config:    dbHealthConfig,
+		metrics:   metrics,
+		ctx:       ctx,
+		cancel:    cancel,
+		newTicker: newTimeTicker,
+	}
+}
+
+// Start starts pinging the database while it is marked down; a disabled check does nothing
+func (h *DBHealth) Start() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.running {
+		return fmt.Errorf("DB health check is already running")
+	}
+	if h.config.FailureThreshold <= 0 {
+		return nil
+	}
+	if h.config.PingInterval <= 0 {
+		return fmt.Errorf("DB health check has invalid ping interval: %v", h.config.PingInterval)
+	}
+
+	h.running = true
+
+	h.logger.Log("level", "info", "msg", "starting DB health check",
+		"failure_threshold", h.config.FailureThreshold, "ping_interval", h.config.PingInterval)
+
+	h.wg.Add(1)
+	go h.pingLoop()
+
+	return nil
+}
+
+// Stop stops pinging the database
+func (h *DBHealth) Stop() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.running {
+		return nil
+	}
+
+	h.cancel()
+	h.wg.Wait()
+	h.running = false
+
+	h.logger.Log("level", "info", "msg", "DB health check stopped")
+
+	return nil
+}
+
+// IsDown reports whether the database is marked unreachable; a nil tracker never is
+func (h *DBHealth) IsDown() bool {
+	return h != nil && h.down.Load()
+}
+
+// RecordFailure counts a failed poll and marks the database down once the threshold is reached
+func (h *DBHealth) RecordFailure(err error) {
+	if h == nil || h.config.FailureThreshold <= 0 {
+		return
+	}
+	failures := h.failures.Add(1)
+	if failures < int64(h.config.FailureThreshold) || !h.down.CompareAndSwap(false, true) {
+		return
+	}
+
+	h.metrics.RecordDBReachable(false)
+	h.logger.Log("level", "error", "msg", "database unreachable, workers stop polling until it recovers",
+		"consecutive_failures", failures, "ping_interval", h.config.PingInterval, "error", err)
+}
+
+// RecordSuccess resets the consecutive failure count after a poll reached the database
+func (h *DBHealth) RecordSuccess() {
+	if h == nil {
+		return
+	}
+	h.failures.Store(0)
+}
+
+// pingLoop pings the database on every tick while it is marked down
+func (h *DBHealth) pingLoop() {
+	defer h.wg.Done()
+
+	ticks, stop := h.newTicker(h.config.PingInterval)
+	defer stop()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticks:
+			if h.down.Load() {
+				h.Check(h.ctx)
+			}
+		}
+	}
+}
+
+// Check pings the database and marks it up again if it answers
+func (h *DBHealth) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, h.config.PingInterval)
+	defer cancel()
+
+	if err := h.ping(ctx); err != nil {
+		h.logger.Log("level", "debug", "msg", "database still unreachable", "error", err)
+		return
+	}
+
+	h.failures.Store(0)
+	if h.down.CompareAndSwap(true, false) {
+		h.metrics.RecordDBReachable(true)
+		h.logger.Log("level", "info", "msg", "database reachable again, workers resume polling")
+	}
+}
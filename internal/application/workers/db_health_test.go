@@ -0,0 +1,125 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/mocks"
+)
+
+func TestDBHealth_WorkerBackoff(t *testing.T) {
+	t.Run("should stop polling while the database is down and resume once a ping succeeds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// Every poll fails until the database comes back
+		dbErr := errors.New("dial tcp: connection refused")
+		var polls atomic.Int64
+		mockProcessor := mocks.NewMockWebhookProcessorIface(ctrl)
+		mockProcessor.EXPECT().
+			GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 0).
+			DoAndReturn(func(context.Context, string, int) (*entities.WebhookQueue, error) { polls.Add(1); return nil, dbErr }).
+			Times(2)
+		mockProcessor.EXPECT().
+			GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 0).
+			DoAndReturn(func(context.Context, string, int) (*entities.WebhookQueue, error) { polls.Add(1); return nil, nil }).
+			Times(1)
+
+		// The first ping still fails, the second finds the database back
+		var pings atomic.Int64
+		ping := func(ctx context.Context) error {
+			if pings.Add(1) == 1 {
+				return dbErr
+			}
+			return nil
+		}
+
+		dbHealth := NewDBHealth(ping, log.NewNopLogger(),
+			config.DBHealthConfig{FailureThreshold: 2, PingInterval: time.Hour}, testMetrics)
+		pingTicks := make(chan time.Time)
+		dbHealth.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+			return pingTicks, func() {}
+		}
+		require.NoError(t, dbHealth.Start())
+		defer dbHealth.Stop()
+
+		worker := NewWebhookWorker(0, mockProcessor, log.NewNopLogger(), time.Hour, testMetrics)
+		worker.SetDBHealth(dbHealth)
+		ticks := make(chan time.Time)
+		worker.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+			return ticks, func() {}
+		}
+		require.NoError(t, worker.Start())
+
+		// Two failed polls mark the database down; the extra tick waits for the second poll to finish
+		ticks <- time.Now()
+		ticks <- time.Now()
+		ticks <- time.Now()
+		assert.True(t, dbHealth.IsDown())
+
+		// Ticks while down are skipped without touching the database
+		ticks <- time.Now()
+		ticks <- time.Now()
+		assert.Equal(t, int64(2), polls.Load())
+
+		// A failed ping keeps workers backed off, a successful one resumes them
+		pingTicks <- time.Now()
+		pingTicks <- time.Now()
+		pingTicks <- time.Now()
+		assert.Equal(t, int64(2), pings.Load())
+		assert.False(t, dbHealth.IsDown())
+
+		ticks <- time.Now()
+		require.NoError(t, worker.Stop())
+		assert.Equal(t, int64(3), polls.Load())
+	})
+}
+
+func TestDBHealth_RecordFailure(t *testing.T) {
+	newDBHealth := func(threshold int) *DBHealth {
+		return NewDBHealth(func(context.Context) error { return nil }, log.NewNopLogger(),
+			config.DBHealthConfig{FailureThreshold: threshold, PingInterval: time.Second}, testMetrics)
+	}
+	dbErr := errors.New("connection refused")
+
+	t.Run("should only count consecutive failures", func(t *testing.T) {
+		dbHealth := newDBHealth(2)
+
+		dbHealth.RecordFailure(dbErr)
+		dbHealth.RecordSuccess()
+		dbHealth.RecordFailure(dbErr)
+		assert.False(t, dbHealth.IsDown())
+
+		dbHealth.RecordFailure(dbErr)
+		assert.True(t, dbHealth.IsDown())
+	})
+
+	t.Run("should never mark the database down when disabled", func(t *testing.T) {
+		dbHealth := newDBHealth(0)
+
+		for i := 0; i < 10; i++ {
+			dbHealth.RecordFailure(dbErr)
+		}
+
+		assert.False(t, dbHealth.IsDown())
+	})
+
+	t.Run("should treat a nil tracker as healthy", func(t *testing.T) {
+		var dbHealth *DBHealth
+
+		dbHealth.RecordFailure(dbErr)
+		dbHealth.RecordSuccess()
+
+		assert.False(t, dbHealth.IsDown())
+	})
+}
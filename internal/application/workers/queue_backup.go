@@ -0,0 +1,144 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
+)
+
+// BackupStore saves queue backups, such as files on a mounted bucket or a shared volume
+type BackupStore interface {
+	PutObject(ctx context.Context, key string, data []byte) error
+}
+
+// queueBackupKeyFormat names each backup by the UTC time it was taken, so backups sort chronologically
+const queueBackupKeyFormat = "queue-backups/queue-20060102T150405Z.jsonl"
+
+// QueueBackup periodically writes every undelivered webhook to a backup store, so the queue can be
+// restored into a fresh instance after the database is lost
+// Each backup is a new object; pruning old ones is left to the store, such as a bucket lifecycle rule
+type QueueBackup struct {
+	processor *usecases.WebhookProcessor
+	store     BackupStore
+	logger    log.Logger
+	config    config.QueueBackupConfig
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	running   bool
+	mu        sync.Mutex
+
+	now       func() time.Time
+	newTicker func(d time.Duration) (<-chan time.Time, func())
+}
+
+// NewQueueBackup creates a new queue backup writing to store
+func NewQueueBackup(
+	processor *usecases.WebhookProcessor,
+	store BackupStore,
+	logger log.Logger,
+	backupConfig config.QueueBackupConfig,
+) *QueueBackup {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &QueueBackup{
+		processor: processor,
+		store:     store,
+		logger:    logger,
+		config:    backupConfig,
+		ctx:       ctx,
+		cancel:    cancel,
+		now:       func() time.Time { return time.Now().UTC() },
+		newTicker: newTimeTicker,
+	}
+}
+
+// Start starts the periodic queue backup
+func (b *QueueBackup) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.running {
+		return fmt.Errorf("queue backup is already running")
+	}
+
+	if b.config.Interval <= 0 {
+		return fmt.Errorf("queue backup has invalid interval: %v", b.config.Interval)
+	}
+
+	b.running = true
+
+	b.logger.Log("level", "info", "msg", "starting queue backup",
+		"interval", b.config.Interval, "dir", b.config.Dir)
+
+	b.wg.Add(1)
+	go b.backupLoop()
+
+	return nil
+}
+
+// Stop stops the periodic queue backup, waiting for a backup in progress to finish or be cancelled
+func (b *QueueBackup) Stop() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.running {
+		return fmt.Errorf("queue backup is not running")
+	}
+
+	b.cancel()
+	b.wg.Wait()
+	b.running = false
+
+	b.logger.Log("level", "info", "msg", "queue backup stopped")
+
+	return nil
+}
+
+// backupLoop writes a backup on every tick until stopped
+func (b *QueueBackup) backupLoop() {
+	defer b.wg.Done()
+
+	ticks, stop := b.newTicker(b.config.Interval)
+	defer stop()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-ticks:
+			if _, err := b.Backup(b.ctx); err != nil {
+				b.logger.Log("level", "error", "msg", "queue backup failed", "error", err)
+			}
+		}
+	}
+}
+
+// Backup exports the undelivered webhooks and saves them as one new object, returning its key
+// The export is buffered so a failed export never leaves a partial backup in the store
+func (b *QueueBackup) Backup(ctx context.Context) (string, error) {
+	startedAt := b.now()
+
+	var buf bytes.Buffer
+	exported, err := b.processor.ExportQueue(ctx, &buf, b.config.BatchSize)
+	if err != nil {
+		return "", err
+	}
+
+	key := startedAt.Format(queueBackupKeyFormat)
+	if err := b.store.PutObject(ctx, key, buf.Bytes()); err != nil {
+		return "", fmt.Errorf("failed to save queue backup %s: %w", key, err)
+	}
+
+	b.logger.Log("level", "info", "msg", "queue backup written", "key", key,
+		"webhooks", exported, "bytes", buf.Len(), "duration", b.now().Sub(startedAt))
+
+	return key, nil
+}
@@ -0,0 +1,134 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/mocks"
+)
+
+// memoryBackupStore keeps saved objects in memory, failing every save with err when set
+type memoryBackupStore struct {
+	objects map[string][]byte
+	err     error
+}
+
+// PutObject records data under key
+func (s *memoryBackupStore) PutObject(_ context.Context, key string, data []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func TestQueueBackup_Backup(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	backupConfig := config.QueueBackupConfig{Dir: "/backups", Interval: time.Hour, BatchSize: 100}
+
+	setup := func(t *testing.T, store *memoryBackupStore) (*QueueBackup, *mocks.MockWebhookQueueRepository, *mocks.MockWebhookConfigRepository) {
+		ctrl := gomock.NewController(t)
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+
+		backup := NewQueueBackup(processor, store, log.NewNopLogger(), backupConfig)
+		backup.now = func() time.Time { return now }
+		return backup, mockQueueRepo, mockConfigRepo
+	}
+
+	t.Run("should save a backup named by its time that restores the pending webhooks", func(t *testing.T) {
+		store := &memoryBackupStore{objects: make(map[string][]byte)}
+		backup, mockQueueRepo, mockConfigRepo := setup(t, store)
+		pending := &entities.WebhookQueue{ID: 3, QueueID: uuid.New(), ConfigID: 1, EventID: "txn-1", Status: enums.WebhookStatusPending}
+		mockQueueRepo.EXPECT().ListAfter(gomock.Any(), gomock.Any(), int64(0), 100).Return([]*entities.WebhookQueue{pending}, nil)
+
+		key, err := backup.Backup(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "queue-backups/queue-20260301T093000Z.jsonl", key)
+		require.Contains(t, store.objects, key)
+
+		// The saved object restores the webhook it was taken from
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&entities.WebhookConfig{ID: 1}, nil)
+		mockQueueRepo.EXPECT().Restore(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, webhook *entities.WebhookQueue) (bool, error) {
+				assert.Equal(t, pending.QueueID, webhook.QueueID)
+				assert.Equal(t, "txn-1", webhook.EventID)
+				return true, nil
+			})
+		result, err := backup.processor.ImportQueue(context.Background(), bytes.NewReader(store.objects[key]))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Restored)
+	})
+
+	t.Run("should save nothing when the export fails", func(t *testing.T) {
+		store := &memoryBackupStore{objects: make(map[string][]byte)}
+		backup, mockQueueRepo, _ := setup(t, store)
+		mockQueueRepo.EXPECT().ListAfter(gomock.Any(), gomock.Any(), int64(0), 100).Return(nil, errors.New("connection refused"))
+
+		key, err := backup.Backup(context.Background())
+
+		assert.Empty(t, key)
+		assert.ErrorContains(t, err, "connection refused")
+		assert.Empty(t, store.objects)
+	})
+
+	t.Run("should return a failed save", func(t *testing.T) {
+		store := &memoryBackupStore{err: errors.New("no space left on device")}
+		backup, mockQueueRepo, _ := setup(t, store)
+		mockQueueRepo.EXPECT().ListAfter(gomock.Any(), gomock.Any(), int64(0), 100).Return(nil, nil)
+
+		_, err := backup.Backup(context.Background())
+
+		assert.ErrorContains(t, err, "failed to save queue backup")
+		assert.ErrorContains(t, err, "no space left on device")
+	})
+}
+
+func TestQueueBackup_StartStop(t *testing.T) {
+	t.Run("should back up on every tick until stopped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mocks.NewMockWebhookConfigRepository(ctrl),
+			mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		store := &memoryBackupStore{objects: make(map[string][]byte)}
+		backup := NewQueueBackup(processor, store, log.NewNopLogger(), config.QueueBackupConfig{Interval: time.Hour, BatchSize: 100})
+
+		ticks := make(chan time.Time)
+		backup.newTicker = func(time.Duration) (<-chan time.Time, func()) { return ticks, func() {} }
+		saved := make(chan struct{}, 1)
+		mockQueueRepo.EXPECT().ListAfter(gomock.Any(), gomock.Any(), int64(0), 100).
+			DoAndReturn(func(context.Context, repositories.WebhookQueueFilter, int64, int) ([]*entities.WebhookQueue, error) {
+				saved <- struct{}{}
+				return nil, nil
+			})
+
+		require.NoError(t, backup.Start())
+		assert.Error(t, backup.Start(), "a running backup cannot be started again")
+		ticks <- time.Now()
+		<-saved
+		require.NoError(t, backup.Stop())
+		assert.Error(t, backup.Stop(), "a stopped backup cannot be stopped again")
+	})
+
+	t.Run("should refuse to start without an interval", func(t *testing.T) {
+		backup := NewQueueBackup(nil, &memoryBackupStore{}, log.NewNopLogger(), config.QueueBackupConfig{})
+
+		assert.ErrorContains(t, backup.Start(), "invalid interval")
+	})
+}
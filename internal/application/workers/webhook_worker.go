@@ -2,22 +2,45 @@ package workers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/google/uuid"
 
 	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/repositories"
 	"webhook-processor/internal/infrastructure/metrics"
 )
 
+// ShutdownStats summarizes what happened to webhooks that were in flight when workers were stopped
+type ShutdownStats struct {
+	InFlight int // Webhooks being processed when the stop began
+	Drained  int // In-flight webhooks whose attempt finished and was recorded
+	Reset    int // In-flight webhooks handed back to PENDING
+}
+
+// Add accumulates another worker's shutdown stats
+func (s *ShutdownStats) Add(other ShutdownStats) {
+	s.InFlight += other.InFlight
+	s.Drained += other.Drained
+	s.Reset += other.Reset
+}
+
+// defaultDrainTimeout is how long a stopping worker lets its in-flight attempt run unless SetDrainTimeout says otherwise
+const defaultDrainTimeout = time.Minute
+
 // WebhookWorker represents a specialized webhook processing worker
 type WebhookWorker struct {
 	id           string
 	retryLevel   int
-	processor    *usecases.WebhookProcessor
+	processor    usecases.WebhookProcessorIface
 	logger       log.Logger
 	pollInterval time.Duration
 	ctx          context.Context
@@ -26,18 +49,50 @@ type WebhookWorker struct {
 	running      bool
 	mu           sync.RWMutex
 	metrics      *metrics.WebhookMetrics
+
+	// paused stops the worker from locking new webhooks while its loop keeps running
+	paused atomic.Bool
+
+	// wake makes the worker poll immediately instead of waiting for the next tick; nil never fires
+	wake <-chan struct{}
+
+	// dbHealth skips polls while the database is unreachable; nil always polls
+	dbHealth *DBHealth
+
+	// pollIntervalUpdates carries the latest SetPollInterval value to the running loop
+	pollIntervalUpdates chan time.Duration
+
+	// newTicker creates the poll and heartbeat tickers; tests replace it to drive ticks deterministically
+	newTicker func(d time.Duration) (<-chan time.Time, func())
+
+	// heartbeatInterval is how often the worker records its heartbeat; 0 disables heartbeats
+	heartbeatInterval time.Duration
+	host              string
+	startedAt         time.Time
+	now               func() time.Time
+
+	// drainTimeout is how long an attempt in flight when Stop is called may keep running
+	drainTimeout time.Duration
+
+	// shutdownStats is written by the process loop and read once Stop has waited for it
+	shutdownStats ShutdownStats
 }
 
 // NewWebhookWorker creates a new specialized webhook worker
 func NewWebhookWorker(
 	retryLevel int,
-	processor *usecases.WebhookProcessor,
+	processor usecases.WebhookProcessorIface,
 	logger log.Logger,
 	pollInterval time.Duration,
 	metrics *metrics.WebhookMetrics,
 ) *WebhookWorker {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
 	return &WebhookWorker{
 		id:           fmt.Sprintf("retry-%d-%s", retryLevel, uuid.New().String()[:8]),
 		retryLevel:   retryLevel,
@@ -47,9 +102,21 @@ func NewWebhookWorker(
 		ctx:          ctx,
 		cancel:       cancel,
 		metrics:      metrics,
+		newTicker:    newTimeTicker,
+		host:         host,
+		now:          func() time.Time { return time.Now().UTC() },
+		drainTimeout: defaultDrainTimeout,
+
+		pollIntervalUpdates: make(chan time.Duration, 1),
 	}
 }
 
+// newTimeTicker returns a real ticker's channel and its stop function
+func newTimeTicker(d time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(d)
+	return ticker.C, ticker.Stop
+}
+
 // Start starts the webhook worker
 func (w *WebhookWorker) Start() error {
 	w.mu.Lock()
@@ -59,7 +126,12 @@ func (w *WebhookWorker) Start() error {
 		return fmt.Errorf("worker %s is already running", w.id)
 	}
 
+	if w.pollInterval <= 0 {
+		return fmt.Errorf("worker %s has invalid poll interval: %v", w.id, w.pollInterval)
+	}
+
 	w.running = true
+	w.startedAt = w.now()
 
 	w.logger.Log("level", "info", "msg", "starting worker",
 		"worker_id", w.id, "retry_level", w.retryLevel, "poll_interval", w.pollInterval)
@@ -67,6 +139,11 @@ func (w *WebhookWorker) Start() error {
 	w.wg.Add(1)
 	go w.processLoop()
 
+	if w.heartbeatInterval > 0 {
+		w.wg.Add(1)
+		go w.heartbeatLoop()
+	}
+
 	return nil
 }
 
@@ -87,11 +164,66 @@ func (w *WebhookWorker) Stop() error {
 	w.running = false
 
 	w.logger.Log("level", "info", "msg", "worker stopped",
-		"worker_id", w.id, "retry_level", w.retryLevel)
+		"worker_id", w.id, "retry_level", w.retryLevel,
+		"in_flight", w.shutdownStats.InFlight, "drained", w.shutdownStats.Drained, "reset", w.shutdownStats.Reset)
 
 	return nil
 }
 
+// ShutdownStats returns what happened to in-flight work when the worker was stopped
+// Only meaningful after Stop has returned
+func (w *WebhookWorker) ShutdownStats() ShutdownStats {
+	return w.shutdownStats
+}
+
+// Pause stops the worker from picking up new webhooks; a webhook already in flight still completes
+func (w *WebhookWorker) Pause() {
+	w.paused.Store(true)
+}
+
+// IsPaused reports whether the worker has stopped picking up new webhooks
+func (w *WebhookWorker) IsPaused() bool {
+	return w.paused.Load()
+}
+
+// SetPollInterval changes how often the worker polls; a running loop switches on its next iteration
+func (w *WebhookWorker) SetPollInterval(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	// Replace any update the loop has not consumed yet so only the latest value applies
+	select {
+	case <-w.pollIntervalUpdates:
+	default:
+	}
+	w.pollIntervalUpdates <- d
+}
+
+// SetWakeSignal makes the worker poll as soon as wake receives, e.g. when a webhook is created in
+// this process, in addition to polling on its ticker; call before Start
+func (w *WebhookWorker) SetWakeSignal(wake <-chan struct{}) {
+	w.wake = wake
+}
+
+// SetDBHealth makes the worker report poll failures to dbHealth and skip polls while it reports
+// the database down; call before Start
+func (w *WebhookWorker) SetDBHealth(dbHealth *DBHealth) {
+	w.dbHealth = dbHealth
+}
+
+// EnableHeartbeat makes the worker record its heartbeat every interval while running; call before Start
+func (w *WebhookWorker) EnableHeartbeat(interval time.Duration) {
+	w.heartbeatInterval = interval
+}
+
+// SetDrainTimeout sets how long Stop lets an attempt in flight finish before cancelling it;
+// a non-positive timeout keeps the default; call before Start
+func (w *WebhookWorker) SetDrainTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		w.drainTimeout = timeout
+	}
+}
+
 // GetID returns the worker ID
 func (w *WebhookWorker) GetID() string {
 	return w.id
@@ -106,8 +238,8 @@ func (w *WebhookWorker) GetRetryLevel() int {
 func (w *WebhookWorker) processLoop() {
 	defer w.wg.Done()
 
-	ticker := time.NewTicker(w.pollInterval)
-	defer ticker.Stop()
+	ticks, stop := w.newTicker(w.pollInterval)
+	defer func() { stop() }()
 
 	for {
 		select {
@@ -115,12 +247,63 @@ func (w *WebhookWorker) processLoop() {
 			w.logger.Log("level", "info", "msg", "process loop stopped",
 				"worker_id", w.id, "retry_level", w.retryLevel)
 			return
-		case <-ticker.C:
+		case interval := <-w.pollIntervalUpdates:
+			stop()
+			ticks, stop = w.newTicker(interval)
+			w.logger.Log("level", "info", "msg", "poll interval changed",
+				"worker_id", w.id, "retry_level", w.retryLevel, "poll_interval", interval)
+		case <-ticks:
+			if w.paused.Load() || w.dbHealth.IsDown() {
+				continue
+			}
+			w.processNextWebhook()
+		case <-w.wake:
+			if w.paused.Load() || w.dbHealth.IsDown() {
+				continue
+			}
 			w.processNextWebhook()
 		}
 	}
 }
 
+// heartbeatLoop records the worker's heartbeat at start and then every heartbeat interval
+// The heartbeat is removed once the worker stops, so only workers that died leave stale heartbeats
+func (w *WebhookWorker) heartbeatLoop() {
+	defer w.wg.Done()
+
+	ticks, stop := w.newTicker(w.heartbeatInterval)
+	defer stop()
+
+	w.recordHeartbeat()
+	for {
+		select {
+		case <-w.ctx.Done():
+			if err := w.processor.RemoveWorkerHeartbeat(context.WithoutCancel(w.ctx), w.id); err != nil {
+				w.logger.Log("level", "warn", "msg", "failed to remove worker heartbeat",
+					"worker_id", w.id, "retry_level", w.retryLevel, "error", err)
+			}
+			return
+		case <-ticks:
+			w.recordHeartbeat()
+		}
+	}
+}
+
+// recordHeartbeat writes the worker's heartbeat; a failure is logged and retried on the next tick
+func (w *WebhookWorker) recordHeartbeat() {
+	heartbeat := &entities.WorkerHeartbeat{
+		WorkerID:   w.id,
+		RetryLevel: w.retryLevel,
+		Host:       w.host,
+		StartedAt:  w.startedAt,
+		LastSeenAt: w.now(),
+	}
+	if err := w.processor.RecordWorkerHeartbeat(w.ctx, heartbeat); err != nil {
+		w.logger.Log("level", "warn", "msg", "failed to record worker heartbeat",
+			"worker_id", w.id, "retry_level", w.retryLevel, "error", err)
+	}
+}
+
 // processNextWebhook atomically gets and processes the next webhook for this worker's retry level
 func (w *WebhookWorker) processNextWebhook() {
 	// Start measuring complete worker busy time
@@ -137,6 +320,20 @@ func (w *WebhookWorker) processNextWebhook() {
 
 	// Get webhook specific to this retry level
 	webhook, err := w.processor.GetNextWebhookForProcessing(w.ctx, w.id, w.retryLevel)
+	if err != nil && !errors.Is(err, repositories.ErrLockContention) {
+		if w.ctx.Err() == nil {
+			w.dbHealth.RecordFailure(err)
+		}
+	} else {
+		w.dbHealth.RecordSuccess()
+	}
+	if errors.Is(err, repositories.ErrLockContention) {
+		// Sibling workers hold every due row; frequent contention means too many workers for this level
+		w.metrics.RecordLockContention(w.retryLevel)
+		w.logger.Log("level", "debug", "msg", "all due webhooks locked by other workers",
+			"worker_id", w.id, "retry_level", w.retryLevel)
+		return
+	}
 	if err != nil {
 		w.logger.Log("level", "error", "msg", "failed to get next webhook",
 			"worker_id", w.id, "retry_level", w.retryLevel, "error", err)
@@ -147,17 +344,31 @@ func (w *WebhookWorker) processNextWebhook() {
 		// No work available for this retry level - this is normal
 		return
 	}
+	if level := webhook.CurrentRetryLevel(); level != w.retryLevel {
+		// The claim query and the entity disagree on levels; the attempt is still recorded at the webhook's own
+		w.logger.Log("level", "warn", "msg", "claimed webhook at a different retry level than the worker's",
+			"worker_id", w.id, "retry_level", w.retryLevel, "webhook_retry_level", level, "queue_id", webhook.QueueID)
+	}
+
+	w.metrics.RecordInFlight(1)
+	defer w.metrics.RecordInFlight(-1)
+
+	// The worker may have been stopped (e.g. pool start rollback) while the row was being locked;
+	// hand it straight back instead of leaving it stuck in PROCESSING
+	if w.ctx.Err() != nil {
+		w.resetToPending(webhook)
+		w.recordShutdownOutcome(false)
+		return
+	}
 
 	// Process the webhook (already locked atomically by SELECT FOR UPDATE)
-	if err := w.processor.ProcessWebhook(w.ctx, webhook, w.id); err != nil {
+	if err := w.processWebhook(webhook); err != nil {
 		w.logger.Log("level", "error", "msg", "failed to process webhook",
 			"worker_id", w.id, "retry_level", w.retryLevel, "queue_id", webhook.QueueID, "error", err)
 
 		// Reset to pending status on error
-		if resetErr := w.processor.ResetWebhookToPending(w.ctx, webhook); resetErr != nil {
-			w.logger.Log("level", "error", "msg", "failed to reset webhook to pending",
-				"worker_id", w.id, "retry_level", w.retryLevel, "queue_id", webhook.QueueID, "error", resetErr)
-		}
+		w.resetToPending(webhook)
+		w.recordShutdownOutcome(false)
 
 		// Use the last known status code from the webhook, or 500 for processing errors
 		if webhook.LastHTTPStatus != 0 {
@@ -168,5 +379,60 @@ func (w *WebhookWorker) processNextWebhook() {
 	} else {
 		// Success - use the final status code from the webhook
 		finalStatusCode = webhook.LastHTTPStatus
+		w.recordShutdownOutcome(true)
+	}
+}
+
+// processWebhook delivers a claimed webhook, turning a panic into an error so the webhook is reset
+// to PENDING and the worker keeps polling instead of its goroutine crashing the process
+func (w *WebhookWorker) processWebhook(webhook *entities.WebhookQueue) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			w.metrics.RecordWorkerPanic(w.retryLevel)
+			w.logger.Log("level", "error", "msg", "recovered from panic while processing webhook",
+				"worker_id", w.id, "retry_level", w.retryLevel, "queue_id", webhook.QueueID,
+				"panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic while processing webhook: %v", r)
+		}
+	}()
+
+	ctx, cancel := w.drainContext()
+	defer cancel()
+	return w.processor.ProcessWebhook(ctx, webhook, w.id)
+}
+
+// drainContext returns the context one attempt runs on: Stop does not cancel it, so an attempt in
+// flight can finish and record its outcome, but it is cancelled once the drain timeout has passed
+// since Stop so a hung delivery cannot hold the shutdown
+func (w *WebhookWorker) drainContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(w.ctx))
+	stopDeadline := context.AfterFunc(w.ctx, func() {
+		time.AfterFunc(w.drainTimeout, cancel)
+	})
+	return ctx, func() {
+		stopDeadline()
+		cancel()
+	}
+}
+
+// recordShutdownOutcome counts a webhook that was still in flight when the worker began stopping
+func (w *WebhookWorker) recordShutdownOutcome(drained bool) {
+	if w.ctx.Err() == nil {
+		return
+	}
+	w.shutdownStats.InFlight++
+	if drained {
+		w.shutdownStats.Drained++
+	} else {
+		w.shutdownStats.Reset++
+	}
+}
+
+// resetToPending returns a locked webhook to PENDING
+// Uses a context detached from worker cancellation so the reset still lands while stopping
+func (w *WebhookWorker) resetToPending(webhook *entities.WebhookQueue) {
+	if resetErr := w.processor.ResetWebhookToPending(context.WithoutCancel(w.ctx), webhook, w.id); resetErr != nil {
+		w.logger.Log("level", "error", "msg", "failed to reset webhook to pending",
+			"worker_id", w.id, "retry_level", w.retryLevel, "queue_id", webhook.QueueID, "error", resetErr)
 	}
 }
@@ -0,0 +1,583 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/domain/services"
+	"webhook-processor/internal/mocks"
+)
+
+func TestWebhookWorker_ProcessLoop(t *testing.T) {
+	t.Run("should process one webhook per tick", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+		const tickCount = 3
+		processed := make(chan int64, tickCount)
+		var nextID int64
+
+		mockQueueRepo.EXPECT().
+			GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 0).
+			DoAndReturn(func(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
+				nextID++
+				return &entities.WebhookQueue{
+					ID:         nextID,
+					QueueID:    uuid.New(),
+					EventType:  enums.EventTypeCredit,
+					ConfigID:   1,
+					WebhookURL: "https://example.com/webhook",
+					Status:     enums.WebhookStatusProcessing,
+				}, nil
+			}).
+			Times(tickCount)
+
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, nil).Times(tickCount)
+
+		mockWebhookService.EXPECT().
+			SendWebhook(gomock.Any(), gomock.Any()).
+			Return(&services.WebhookResponse{StatusCode: http.StatusOK, Body: "ok"}, nil).
+			Times(tickCount)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(gomock.Any(), gomock.Any(), gomock.Any(), 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), http.StatusOK, "ok", "", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(tickCount)
+
+		mockQueueRepo.EXPECT().
+			MarkCompleted(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id int64, _ string, processingStartedAt time.Time, _ int) error {
+				processed <- id
+				return nil
+			}).
+			Times(tickCount)
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+		worker := NewWebhookWorker(0, processor, log.NewNopLogger(), time.Hour, testMetrics)
+
+		ticks := make(chan time.Time)
+		stopped := false
+		worker.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+			assert.Equal(t, time.Hour, d)
+			return ticks, func() { stopped = true }
+		}
+
+		require.NoError(t, worker.Start())
+
+		for i := 1; i <= tickCount; i++ {
+			ticks <- time.Now()
+
+			select {
+			case id := <-processed:
+				assert.Equal(t, int64(i), id)
+			case <-time.After(time.Second):
+				t.Fatalf("tick %d did not process a webhook", i)
+			}
+		}
+
+		require.NoError(t, worker.Stop())
+		assert.True(t, stopped, "ticker should be stopped when the loop exits")
+		assert.Empty(t, processed)
+	})
+}
+
+func TestWebhookWorker_LockContention(t *testing.T) {
+	t.Run("should report contention when siblings hold every due row", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+		// Simulate SKIP LOCKED over a single due row: the first poll locks it, concurrent polls skip it
+		var mu sync.Mutex
+		lockedBy := ""
+		mockQueueRepo.EXPECT().
+			GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 0).
+			DoAndReturn(func(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if lockedBy != "" {
+					return nil, repositories.ErrLockContention
+				}
+				lockedBy = workerID
+				return &entities.WebhookQueue{
+					ID:         1,
+					QueueID:    uuid.New(),
+					EventType:  enums.EventTypeCredit,
+					ConfigID:   1,
+					WebhookURL: "https://example.com/webhook",
+					Status:     enums.WebhookStatusProcessing,
+				}, nil
+			}).
+			Times(3)
+
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, nil).Times(1)
+
+		// Hold the row locked until the contending polls have run
+		inFlight := make(chan struct{})
+		release := make(chan struct{})
+		mockWebhookService.EXPECT().
+			SendWebhook(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
+				close(inFlight)
+				<-release
+				return &services.WebhookResponse{StatusCode: http.StatusOK, Body: "ok"}, nil
+			}).
+			Times(1)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(gomock.Any(), int64(1), gomock.Any(), 0, gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), http.StatusOK, "ok", "", gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		processed := make(chan struct{})
+		mockQueueRepo.EXPECT().
+			MarkCompleted(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id int64, _ string, processingStartedAt time.Time, _ int) error {
+				close(processed)
+				return nil
+			}).
+			Times(1)
+
+		contended := make(chan string, 2)
+		logger := log.LoggerFunc(func(keyvals ...interface{}) error {
+			fields := map[interface{}]interface{}{}
+			for i := 0; i+1 < len(keyvals); i += 2 {
+				fields[keyvals[i]] = keyvals[i+1]
+			}
+			if fields["msg"] == "all due webhooks locked by other workers" {
+				contended <- fields["worker_id"].(string)
+			}
+			return nil
+		})
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+
+		workers := make([]*WebhookWorker, 3)
+		ticks := make([]chan time.Time, 3)
+		for i := range workers {
+			workers[i] = NewWebhookWorker(0, processor, logger, time.Hour, testMetrics)
+			ticks[i] = make(chan time.Time)
+			tickCh := ticks[i]
+			workers[i].newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+				return tickCh, func() {}
+			}
+			require.NoError(t, workers[i].Start())
+		}
+
+		ticks[0] <- time.Now()
+		<-inFlight
+
+		// Both siblings poll concurrently while the row is locked
+		var wg sync.WaitGroup
+		for _, tickCh := range ticks[1:] {
+			wg.Add(1)
+			go func(tickCh chan time.Time) {
+				defer wg.Done()
+				tickCh <- time.Now()
+			}(tickCh)
+		}
+		wg.Wait()
+
+		seen := map[string]bool{}
+		for i := 0; i < 2; i++ {
+			select {
+			case id := <-contended:
+				seen[id] = true
+			case <-time.After(time.Second):
+				t.Fatalf("expected contention to be reported by both sibling workers, got %d", len(seen))
+			}
+		}
+		assert.True(t, seen[workers[1].GetID()])
+		assert.True(t, seen[workers[2].GetID()])
+
+		close(release)
+		select {
+		case <-processed:
+		case <-time.After(time.Second):
+			t.Fatal("locked webhook was not processed")
+		}
+
+		for _, worker := range workers {
+			require.NoError(t, worker.Stop())
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, workers[0].GetID(), lockedBy)
+	})
+}
+
+func TestWebhookWorker_ResetOnProcessError(t *testing.T) {
+	t.Run("should reset the webhook to pending when processing fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProcessor := mocks.NewMockWebhookProcessorIface(ctrl)
+		webhook := &entities.WebhookQueue{
+			ID:             1,
+			QueueID:        uuid.New(),
+			Status:         enums.WebhookStatusProcessing,
+			LastHTTPStatus: http.StatusBadGateway,
+		}
+
+		mockProcessor.EXPECT().
+			GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 2).
+			Return(webhook, nil).
+			Times(1)
+		mockProcessor.EXPECT().
+			ProcessWebhook(gomock.Any(), webhook, gomock.Any()).
+			Return(errors.New("failed to update retry attempt")).
+			Times(1)
+		mockProcessor.EXPECT().
+			ResetWebhookToPending(gomock.Any(), webhook, gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		worker := NewWebhookWorker(2, mockProcessor, log.NewNopLogger(), time.Hour, testMetrics)
+		before := workerProcessingCount(t, "502", "2")
+
+		worker.processNextWebhook()
+
+		assert.Equal(t, before+1, workerProcessingCount(t, "502", "2"))
+		assert.Equal(t, ShutdownStats{}, worker.ShutdownStats())
+	})
+}
+
+func TestWebhookWorker_RecoverPanic(t *testing.T) {
+	t.Run("should reset the webhook and keep polling after processing panics", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProcessor := mocks.NewMockWebhookProcessorIface(ctrl)
+		bad := &entities.WebhookQueue{ID: 1, QueueID: uuid.New(), Status: enums.WebhookStatusProcessing}
+		good := &entities.WebhookQueue{ID: 2, QueueID: uuid.New(), Status: enums.WebhookStatusProcessing, LastHTTPStatus: http.StatusOK}
+
+		gomock.InOrder(
+			mockProcessor.EXPECT().GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 3).Return(bad, nil),
+			mockProcessor.EXPECT().ProcessWebhook(gomock.Any(), bad, gomock.Any()).
+				DoAndReturn(func(context.Context, *entities.WebhookQueue, string) error {
+					panic("nil config")
+				}),
+			mockProcessor.EXPECT().ResetWebhookToPending(gomock.Any(), bad, gomock.Any()).Return(nil),
+			mockProcessor.EXPECT().GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 3).Return(good, nil),
+			mockProcessor.EXPECT().ProcessWebhook(gomock.Any(), good, gomock.Any()).Return(nil),
+		)
+
+		worker := NewWebhookWorker(3, mockProcessor, log.NewNopLogger(), time.Hour, testMetrics)
+		before := workerPanicCount(t, "3")
+
+		require.NotPanics(t, worker.processNextWebhook)
+		worker.processNextWebhook()
+
+		assert.Equal(t, before+1, workerPanicCount(t, "3"))
+	})
+}
+
+func TestWebhookWorker_Drain(t *testing.T) {
+	// startWithWebhook starts a worker on a test ticker, ticks once and waits until ProcessWebhook has
+	// been handed the one webhook it claims
+	startWithWebhook := func(t *testing.T, worker *WebhookWorker, mockProcessor *mocks.MockWebhookProcessorIface, started <-chan struct{}) {
+		t.Helper()
+		webhook := &entities.WebhookQueue{ID: 1, QueueID: uuid.New(), Status: enums.WebhookStatusProcessing, LastHTTPStatus: http.StatusOK}
+		mockProcessor.EXPECT().GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 0).Return(webhook, nil).Times(1)
+
+		ticks := make(chan time.Time)
+		worker.newTicker = func(time.Duration) (<-chan time.Time, func()) { return ticks, func() {} }
+		require.NoError(t, worker.Start())
+		ticks <- time.Now()
+
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("webhook never went in flight")
+		}
+	}
+
+	t.Run("should let the attempt in flight finish after Stop", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProcessor := mocks.NewMockWebhookProcessorIface(ctrl)
+		worker := NewWebhookWorker(0, mockProcessor, log.NewNopLogger(), time.Hour, testMetrics)
+
+		started := make(chan struct{})
+		var attemptErr error
+		mockProcessor.EXPECT().
+			ProcessWebhook(gomock.Any(), gomock.Any(), worker.GetID()).
+			DoAndReturn(func(ctx context.Context, _ *entities.WebhookQueue, _ string) error {
+				close(started)
+				<-worker.ctx.Done()
+				attemptErr = ctx.Err()
+				return nil
+			}).
+			Times(1)
+
+		startWithWebhook(t, worker, mockProcessor, started)
+		require.NoError(t, worker.Stop())
+
+		assert.NoError(t, attemptErr, "Stop must not cancel the attempt in flight")
+		assert.Equal(t, ShutdownStats{InFlight: 1, Drained: 1}, worker.ShutdownStats())
+	})
+
+	t.Run("should cancel the attempt and reset the webhook once the drain timeout passes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProcessor := mocks.NewMockWebhookProcessorIface(ctrl)
+		worker := NewWebhookWorker(0, mockProcessor, log.NewNopLogger(), time.Hour, testMetrics)
+		worker.SetDrainTimeout(10 * time.Millisecond)
+
+		started := make(chan struct{})
+		mockProcessor.EXPECT().
+			ProcessWebhook(gomock.Any(), gomock.Any(), worker.GetID()).
+			DoAndReturn(func(ctx context.Context, _ *entities.WebhookQueue, _ string) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}).
+			Times(1)
+		mockProcessor.EXPECT().ResetWebhookToPending(gomock.Any(), gomock.Any(), worker.GetID()).Return(nil).Times(1)
+
+		startWithWebhook(t, worker, mockProcessor, started)
+		require.NoError(t, worker.Stop())
+
+		assert.Equal(t, ShutdownStats{InFlight: 1, Reset: 1}, worker.ShutdownStats())
+	})
+}
+
+func TestWebhookWorker_Pause(t *testing.T) {
+	t.Run("should let the in-flight webhook finish but lock no new ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProcessor := mocks.NewMockWebhookProcessorIface(ctrl)
+		webhook := &entities.WebhookQueue{ID: 1, QueueID: uuid.New(), Status: enums.WebhookStatusProcessing}
+
+		mockProcessor.EXPECT().
+			GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 0).
+			Return(webhook, nil).
+			Times(1)
+
+		inFlight := make(chan struct{})
+		release := make(chan struct{})
+		finished := make(chan struct{})
+		mockProcessor.EXPECT().
+			ProcessWebhook(gomock.Any(), webhook, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error {
+				close(inFlight)
+				<-release
+				webhook.Status = enums.WebhookStatusCompleted
+				webhook.LastHTTPStatus = http.StatusOK
+				close(finished)
+				return nil
+			}).
+			Times(1)
+
+		worker := NewWebhookWorker(0, mockProcessor, log.NewNopLogger(), time.Hour, testMetrics)
+		ticks := make(chan time.Time)
+		worker.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+			return ticks, func() {}
+		}
+		require.NoError(t, worker.Start())
+
+		ticks <- time.Now()
+		<-inFlight
+
+		worker.Pause()
+		assert.True(t, worker.IsPaused())
+		close(release)
+		<-finished
+
+		// Later ticks are consumed without polling for new work
+		ticks <- time.Now()
+		ticks <- time.Now()
+
+		require.NoError(t, worker.Stop())
+		assert.Equal(t, enums.WebhookStatusCompleted, webhook.Status)
+	})
+}
+
+func TestWebhookWorker_SetPollInterval(t *testing.T) {
+	t.Run("should recreate the ticker with the new interval while running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		worker := NewWebhookWorker(0, mocks.NewMockWebhookProcessorIface(ctrl), log.NewNopLogger(), time.Hour, testMetrics)
+		intervals := make(chan time.Duration, 2)
+		stopped := make(chan struct{}, 2)
+		worker.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+			intervals <- d
+			return make(chan time.Time), func() { stopped <- struct{}{} }
+		}
+		require.NoError(t, worker.Start())
+		assert.Equal(t, time.Hour, <-intervals)
+
+		worker.SetPollInterval(time.Minute)
+
+		assert.Equal(t, time.Minute, <-intervals)
+		<-stopped // the original ticker is released
+
+		require.NoError(t, worker.Stop())
+		<-stopped // and the replacement on shutdown
+	})
+}
+
+func TestWebhookWorker_Heartbeat(t *testing.T) {
+	t.Run("should upsert its heartbeat on start and every tick, and remove it on stop", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProcessor := mocks.NewMockWebhookProcessorIface(ctrl)
+		worker := NewWebhookWorker(2, mockProcessor, log.NewNopLogger(), time.Hour, testMetrics)
+		worker.EnableHeartbeat(15 * time.Second)
+		worker.host = "processor-a"
+
+		// Fake clock advanced by hand between beats
+		start := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		var clock sync.Mutex
+		now := start
+		worker.now = func() time.Time {
+			clock.Lock()
+			defer clock.Unlock()
+			return now
+		}
+
+		heartbeatTicks := make(chan time.Time)
+		worker.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+			if d == 15*time.Second {
+				return heartbeatTicks, func() {}
+			}
+			return make(chan time.Time), func() {}
+		}
+
+		beats := make(chan *entities.WorkerHeartbeat, 2)
+		mockProcessor.EXPECT().RecordWorkerHeartbeat(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, heartbeat *entities.WorkerHeartbeat) error {
+				beats <- heartbeat
+				return nil
+			}).Times(2)
+		mockProcessor.EXPECT().RemoveWorkerHeartbeat(gomock.Any(), worker.GetID()).Return(nil)
+
+		require.NoError(t, worker.Start())
+
+		first := <-beats
+		assert.Equal(t, worker.GetID(), first.WorkerID)
+		assert.Equal(t, 2, first.RetryLevel)
+		assert.Equal(t, "processor-a", first.Host)
+		assert.Equal(t, start, first.StartedAt)
+		assert.Equal(t, start, first.LastSeenAt)
+
+		clock.Lock()
+		now = start.Add(15 * time.Second)
+		clock.Unlock()
+		heartbeatTicks <- now
+
+		second := <-beats
+		assert.Equal(t, start, second.StartedAt)
+		assert.Equal(t, start.Add(15*time.Second), second.LastSeenAt)
+
+		require.NoError(t, worker.Stop())
+	})
+
+	t.Run("should keep processing when a heartbeat cannot be written", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProcessor := mocks.NewMockWebhookProcessorIface(ctrl)
+		worker := NewWebhookWorker(0, mockProcessor, log.NewNopLogger(), time.Hour, testMetrics)
+		worker.EnableHeartbeat(15 * time.Second)
+
+		pollTicks := make(chan time.Time)
+		worker.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+			if d == time.Hour {
+				return pollTicks, func() {}
+			}
+			return make(chan time.Time), func() {}
+		}
+
+		polled := make(chan struct{})
+		mockProcessor.EXPECT().RecordWorkerHeartbeat(gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+		mockProcessor.EXPECT().GetNextWebhookForProcessing(gomock.Any(), worker.GetID(), 0).
+			DoAndReturn(func(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
+				close(polled)
+				return nil, nil
+			})
+		mockProcessor.EXPECT().RemoveWorkerHeartbeat(gomock.Any(), worker.GetID()).Return(nil)
+
+		require.NoError(t, worker.Start())
+		pollTicks <- time.Now()
+		<-polled
+
+		require.NoError(t, worker.Stop())
+	})
+}
+
+// workerProcessingCount reads worker_processing_total for one status code and retry level
+func workerProcessingCount(t *testing.T, statusCode, retryLevel string) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "worker_processing_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["status_code"] == statusCode && labels["retry_level"] == retryLevel {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+// workerPanicCount reads webhook_worker_panics_total for one retry level
+func workerPanicCount(t *testing.T, retryLevel string) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "webhook_worker_panics_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "retry_level" && label.GetValue() == retryLevel {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
@@ -20,6 +20,18 @@ type WorkerPool struct {
 	running   bool
 	mu        sync.RWMutex
 	metrics   *metrics.WebhookMetrics
+
+	// startWorker starts a single worker; replaceable so tests can inject start failures
+	startWorker func(worker *WebhookWorker) error
+
+	// lastShutdown summarizes in-flight work at the most recent Stop
+	lastShutdown ShutdownStats
+
+	// draining is set once Drain has paused polling for a rolling deploy
+	draining bool
+
+	// dbHealth is shared by the pool's workers so they back off together while the database is down
+	dbHealth *DBHealth
 }
 
 // NewWorkerPool creates a new worker pool
@@ -29,15 +41,43 @@ func NewWorkerPool(
 	config config.WorkerPoolConfig,
 	metrics *metrics.WebhookMetrics,
 ) *WorkerPool {
+	if config.WakeOnCreate {
+		// One pending wake-up per level-0 worker lets a burst of creates wake all of them
+		level0Workers := 0
+		for _, worker := range config.ActiveWorkers() {
+			if worker.RetryLevel == 0 {
+				level0Workers++
+			}
+		}
+		processor.EnableCreateSignal(level0Workers)
+	}
+
 	return &WorkerPool{
 		processor: processor,
 		logger:    logger,
 		config:    config,
 		workers:   make([]*WebhookWorker, 0, len(config.Workers)),
 		metrics:   metrics,
+		startWorker: func(worker *WebhookWorker) error {
+			return worker.Start()
+		},
 	}
 }
 
+// SetDBHealth shares dbHealth with the workers started after this call
+func (wp *WorkerPool) SetDBHealth(dbHealth *DBHealth) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.dbHealth = dbHealth
+}
+
+// IsDBDown reports whether workers have backed off polling because the database is unreachable
+func (wp *WorkerPool) IsDBDown() bool {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	return wp.dbHealth.IsDown()
+}
+
 // Start starts all workers in the pool
 func (wp *WorkerPool) Start() error {
 	wp.mu.Lock()
@@ -47,11 +87,12 @@ func (wp *WorkerPool) Start() error {
 		return fmt.Errorf("worker pool is already running")
 	}
 
+	activeWorkers := wp.config.ActiveWorkers()
 	wp.logger.Log("level", "info", "msg", "starting worker pool",
-		"worker_count", len(wp.config.Workers))
+		"worker_count", len(activeWorkers), "retry_levels", fmt.Sprint(wp.config.RetryLevels))
 
-	// Create and start workers for each retry level
-	for _, workerConfig := range wp.config.Workers {
+	// Create and start workers for each selected retry level
+	for _, workerConfig := range activeWorkers {
 		worker := NewWebhookWorker(
 			workerConfig.RetryLevel,
 			wp.processor,
@@ -59,10 +100,24 @@ func (wp *WorkerPool) Start() error {
 			workerConfig.PollInterval,
 			wp.metrics,
 		)
+		worker.EnableHeartbeat(wp.config.HeartbeatInterval)
+		worker.SetDrainTimeout(wp.config.DrainTimeout)
+		worker.SetDBHealth(wp.dbHealth)
+		if workerConfig.RetryLevel == 0 {
+			worker.SetWakeSignal(wp.processor.CreatedSignal())
+		}
+
+		if wp.draining {
+			worker.Pause()
+		}
 
-		if err := worker.Start(); err != nil {
-			// Stop any workers that were already started
+		if err := wp.startWorker(worker); err != nil {
+			// Roll back workers that were already started; stopping waits for their
+			// in-flight cycle, which resets any row it locked back to PENDING
+			wp.logger.Log("level", "error", "msg", "worker failed to start, rolling back started workers",
+				"retry_level", workerConfig.RetryLevel, "started_workers", len(wp.workers), "error", err)
 			wp.stopWorkers()
+			wp.recordState()
 			return fmt.Errorf("failed to start worker for level %d: %w",
 				workerConfig.RetryLevel, err)
 		}
@@ -76,6 +131,7 @@ func (wp *WorkerPool) Start() error {
 	}
 
 	wp.running = true
+	wp.recordState()
 	wp.logger.Log("level", "info", "msg", "worker pool started successfully",
 		"total_workers", len(wp.workers))
 
@@ -93,16 +149,94 @@ func (wp *WorkerPool) Stop() error {
 
 	wp.logger.Log("level", "info", "msg", "stopping worker pool")
 
-	wp.stopWorkers()
+	stats := wp.stopWorkers()
 	wp.running = false
+	wp.lastShutdown = stats
+	wp.recordState()
+
+	wp.metrics.RecordShutdown(stats.InFlight, stats.Drained, stats.Reset)
+	wp.logger.Log("level", "info", "msg", "worker pool stopped",
+		"in_flight", stats.InFlight, "drained", stats.Drained, "reset", stats.Reset)
+
+	return nil
+}
+
+// Drain pauses polling on every worker so the instance stops locking new webhooks
+// In-flight webhooks finish normally; the pool keeps running until Stop
+func (wp *WorkerPool) Drain() {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if wp.draining {
+		return
+	}
+	wp.draining = true
 
-	wp.logger.Log("level", "info", "msg", "worker pool stopped")
+	for _, worker := range wp.workers {
+		worker.Pause()
+	}
+	wp.recordState()
+
+	wp.logger.Log("level", "info", "msg", "worker pool draining, polling paused",
+		"paused_workers", len(wp.workers))
+}
+
+// IsDraining reports whether Drain has been called
+func (wp *WorkerPool) IsDraining() bool {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	return wp.draining
+}
+
+// UpdatePollIntervals applies new poll intervals to running workers without restarting them
+// Active workers are matched to cfg by position, so cfg must have the same layout as the pool's config
+func (wp *WorkerPool) UpdatePollIntervals(cfg config.WorkerPoolConfig) error {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	current, next := wp.config.ActiveWorkers(), cfg.ActiveWorkers()
+	if len(next) != len(current) {
+		return fmt.Errorf("worker count changed from %d to %d, restart required",
+			len(current), len(next))
+	}
+	for i, workerConfig := range next {
+		if workerConfig.RetryLevel != current[i].RetryLevel {
+			return fmt.Errorf("worker %d retry level changed, restart required", i)
+		}
+	}
 
+	for i, workerConfig := range next {
+		if workerConfig.PollInterval == current[i].PollInterval {
+			continue
+		}
+		if i < len(wp.workers) {
+			wp.workers[i].SetPollInterval(workerConfig.PollInterval)
+		}
+	}
+
+	wp.config.Workers = append([]config.WorkerConfig(nil), cfg.Workers...)
+	wp.config.RetryLevels = append([]int(nil), cfg.RetryLevels...)
 	return nil
 }
 
-// stopWorkers stops all workers
-func (wp *WorkerPool) stopWorkers() {
+// LastShutdownStats returns what happened to in-flight work at the most recent Stop
+func (wp *WorkerPool) LastShutdownStats() ShutdownStats {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	return wp.lastShutdown
+}
+
+// recordState publishes the pool's running state and polling worker count; callers hold wp.mu
+func (wp *WorkerPool) recordState() {
+	active := 0
+	if wp.running && !wp.draining {
+		active = len(wp.workers)
+	}
+	wp.metrics.RecordWorkerPoolState(wp.running, active)
+}
+
+// stopWorkers stops all workers and returns the combined shutdown stats
+func (wp *WorkerPool) stopWorkers() ShutdownStats {
 	var wg sync.WaitGroup
 
 	for _, worker := range wp.workers {
@@ -117,5 +251,12 @@ func (wp *WorkerPool) stopWorkers() {
 	}
 
 	wg.Wait()
+
+	var stats ShutdownStats
+	for _, worker := range wp.workers {
+		stats.Add(worker.ShutdownStats())
+	}
+
 	wp.workers = wp.workers[:0] // Clear the slice
+	return stats
 }
@@ -0,0 +1,527 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"webhook-processor/internal/application/usecases"
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/services"
+	"webhook-processor/internal/infrastructure/metrics"
+	"webhook-processor/internal/mocks"
+)
+
+// testMetrics is shared because Prometheus collectors can only be registered once per process
+var testMetrics = metrics.NewWebhookMetrics()
+
+func TestWorkerPool_Start(t *testing.T) {
+	t.Run("should reset rows locked by started workers when a later worker fails to start", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+		var mu sync.Mutex
+		statuses := map[int64]enums.WebhookStatus{}
+		locked := make(chan struct{})
+		var lockOnce sync.Once
+		lockedWebhook := entities.WebhookQueue{
+			ID:         1,
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeCredit,
+			WebhookURL: "https://example.com/webhook",
+			Status:     enums.WebhookStatusProcessing,
+		}
+
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+		// First poll locks a row into PROCESSING, later polls find nothing
+		mockQueueRepo.EXPECT().
+			GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 0).
+			DoAndReturn(func(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
+				var webhook *entities.WebhookQueue
+				lockOnce.Do(func() {
+					mu.Lock()
+					statuses[1] = enums.WebhookStatusProcessing
+					mu.Unlock()
+					claimed := lockedWebhook
+					webhook = &claimed
+					close(locked)
+				})
+				return webhook, nil
+			}).
+			AnyTimes()
+		mockQueueRepo.EXPECT().
+			GetByQueueID(gomock.Any(), lockedWebhook.QueueID).
+			DoAndReturn(func(context.Context, uuid.UUID) (*entities.WebhookQueue, error) {
+				stored := lockedWebhook
+				return &stored, nil
+			}).
+			AnyTimes()
+
+		// Delivery is in flight until the stopped worker's drain timeout aborts it
+		mockWebhookService.EXPECT().
+			SendWebhook(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}).
+			AnyTimes()
+
+		// Writes honour context cancellation like a real database driver
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ int64, _ string, _ int, _ time.Time, _ *time.Time, _ int64, _ int64, _ int, _, _ string, _ enums.ErrorClass, _ *entities.AttemptRequest) error {
+				return ctx.Err()
+			}).
+			AnyTimes()
+
+		mockQueueRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue, _ string) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				mu.Lock()
+				statuses[webhook.ID] = webhook.Status
+				mu.Unlock()
+				return nil
+			}).
+			AnyTimes()
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+		pool := NewWorkerPool(processor, log.NewNopLogger(), config.WorkerPoolConfig{
+			Workers: []config.WorkerConfig{
+				{RetryLevel: 0, PollInterval: time.Millisecond, Description: "Level 0 Worker #1"},
+				{RetryLevel: 0, PollInterval: time.Millisecond, Description: "Level 0 Worker #2"},
+			},
+			DrainTimeout: 10 * time.Millisecond,
+		}, testMetrics)
+
+		// Fail the second worker only after the first one has locked a row
+		started := 0
+		pool.startWorker = func(worker *WebhookWorker) error {
+			started++
+			if started == 2 {
+				<-locked
+				return errors.New("injected start failure")
+			}
+			return worker.Start()
+		}
+
+		err := pool.Start()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "injected start failure")
+		assert.Empty(t, pool.workers)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Contains(t, statuses, int64(1))
+		for id, status := range statuses {
+			assert.NotEqual(t, enums.WebhookStatusProcessing, status, "webhook %d left PROCESSING", id)
+		}
+	})
+
+	t.Run("should fail to start a worker with a non-positive poll interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		processor := usecases.NewWebhookProcessor(
+			mocks.NewMockWebhookQueueRepository(ctrl),
+			mocks.NewMockWebhookConfigRepository(ctrl),
+			mocks.NewMockWebhookService(ctrl),
+			log.NewNopLogger(),
+		)
+		pool := NewWorkerPool(processor, log.NewNopLogger(), config.WorkerPoolConfig{
+			Workers: []config.WorkerConfig{
+				{RetryLevel: 1, PollInterval: 0},
+			},
+		}, testMetrics)
+
+		err := pool.Start()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid poll interval")
+		assert.Empty(t, pool.workers)
+	})
+}
+
+func TestWorkerPool_RetryLevels(t *testing.T) {
+	t.Run("should start only the workers for the selected retry levels", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		processor := usecases.NewWebhookProcessor(
+			mocks.NewMockWebhookQueueRepository(ctrl),
+			mocks.NewMockWebhookConfigRepository(ctrl),
+			mocks.NewMockWebhookService(ctrl),
+			log.NewNopLogger(),
+		)
+		pool := NewWorkerPool(processor, log.NewNopLogger(), config.WorkerPoolConfig{
+			Workers: []config.WorkerConfig{
+				{RetryLevel: 0, PollInterval: time.Hour},
+				{RetryLevel: 0, PollInterval: time.Hour},
+				{RetryLevel: 1, PollInterval: time.Hour},
+				{RetryLevel: 2, PollInterval: time.Hour},
+				{RetryLevel: 3, PollInterval: time.Hour},
+			},
+			RetryLevels: []int{1, 3},
+		}, testMetrics)
+
+		var started []int
+		pool.startWorker = func(worker *WebhookWorker) error {
+			started = append(started, worker.GetRetryLevel())
+			return nil
+		}
+
+		require.NoError(t, pool.Start())
+
+		assert.Equal(t, []int{1, 3}, started)
+		require.Len(t, pool.workers, 2)
+	})
+
+	t.Run("should match poll interval updates to the selected workers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		processor := usecases.NewWebhookProcessor(
+			mocks.NewMockWebhookQueueRepository(ctrl),
+			mocks.NewMockWebhookConfigRepository(ctrl),
+			mocks.NewMockWebhookService(ctrl),
+			log.NewNopLogger(),
+		)
+		cfg := config.WorkerPoolConfig{
+			Workers: []config.WorkerConfig{
+				{RetryLevel: 0, PollInterval: time.Hour},
+				{RetryLevel: 1, PollInterval: time.Hour},
+			},
+			RetryLevels: []int{1},
+		}
+		pool := NewWorkerPool(processor, log.NewNopLogger(), cfg, testMetrics)
+		pool.startWorker = func(worker *WebhookWorker) error { return nil }
+		require.NoError(t, pool.Start())
+
+		next := cfg
+		next.Workers = []config.WorkerConfig{
+			{RetryLevel: 0, PollInterval: time.Minute},
+			{RetryLevel: 1, PollInterval: 2 * time.Minute},
+		}
+		require.NoError(t, pool.UpdatePollIntervals(next))
+
+		require.Len(t, pool.workers, 1)
+		assert.Equal(t, 2*time.Minute, <-pool.workers[0].pollIntervalUpdates)
+	})
+}
+
+func TestWorkerPool_WakeOnCreate(t *testing.T) {
+	// newPool starts a pool with one level-0 worker that would not poll for an hour on its own
+	newPool := func(t *testing.T, wakeOnCreate bool) (*usecases.WebhookProcessor, chan struct{}) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&entities.WebhookConfig{
+			ID:         1,
+			EventType:  enums.EventTypeCredit,
+			WebhookURL: "https://example.com/webhook",
+			IsActive:   true,
+		}, nil)
+		mockQueueRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		polled := make(chan struct{}, 1)
+		mockQueueRepo.EXPECT().GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), 0).
+			DoAndReturn(func(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
+				polled <- struct{}{}
+				return nil, nil
+			}).AnyTimes()
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mocks.NewMockWebhookService(ctrl), log.NewNopLogger())
+		pool := NewWorkerPool(processor, log.NewNopLogger(), config.WorkerPoolConfig{
+			Workers:      []config.WorkerConfig{{RetryLevel: 0, PollInterval: time.Hour}},
+			WakeOnCreate: wakeOnCreate,
+		}, testMetrics)
+		require.NoError(t, pool.Start())
+		t.Cleanup(func() { require.NoError(t, pool.Stop()) })
+
+		return processor, polled
+	}
+
+	t.Run("should poll as soon as a webhook is created", func(t *testing.T) {
+		processor, polled := newPool(t, true)
+
+		_, err := processor.CreateWebhookEntry(context.Background(), enums.EventTypeCredit, "event-1", 1, nil, "")
+		require.NoError(t, err)
+
+		select {
+		case <-polled:
+		case <-time.After(time.Second):
+			t.Fatal("level 0 worker was not woken by the create")
+		}
+	})
+
+	t.Run("should wait for the next poll when disabled", func(t *testing.T) {
+		processor, polled := newPool(t, false)
+
+		_, err := processor.CreateWebhookEntry(context.Background(), enums.EventTypeCredit, "event-1", 1, nil, "")
+		require.NoError(t, err)
+
+		select {
+		case <-polled:
+			t.Fatal("level 0 worker polled before its tick")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+func TestWorkerPool_Stop(t *testing.T) {
+	t.Run("should count drained and reset webhooks that were in flight at shutdown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockWebhookQueueRepository(ctrl)
+		mockConfigRepo := mocks.NewMockWebhookConfigRepository(ctrl)
+		mockWebhookService := mocks.NewMockWebhookService(ctrl)
+
+		mockConfigRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+		// Each retry level hands out exactly one webhook: level 0 gets ID 1, level 1 gets ID 2
+		var mu sync.Mutex
+		handedOut := map[int]bool{}
+		stored := map[uuid.UUID]entities.WebhookQueue{}
+		mockQueueRepo.EXPECT().
+			GetNextWebhookForProcessing(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if handedOut[retryLevel] {
+					return nil, nil
+				}
+				handedOut[retryLevel] = true
+				webhook := entities.WebhookQueue{
+					ID:         int64(retryLevel + 1),
+					QueueID:    uuid.New(),
+					EventType:  enums.EventTypeCredit,
+					ConfigID:   1,
+					WebhookURL: "https://example.com/webhook",
+					Status:     enums.WebhookStatusProcessing,
+					RetryCount: retryLevel,
+				}
+				stored[webhook.QueueID] = webhook
+				return &webhook, nil
+			}).
+			AnyTimes()
+		mockQueueRepo.EXPECT().
+			GetByQueueID(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				webhook := stored[queueID]
+				return &webhook, nil
+			}).
+			AnyTimes()
+
+		// Both deliveries are in flight when shutdown begins; webhook 1's receiver answers while the
+		// worker drains, webhook 2's request hangs until the drain timeout aborts it
+		stopping := make(chan struct{})
+		var inFlight sync.WaitGroup
+		inFlight.Add(2)
+		mockWebhookService.EXPECT().
+			SendWebhook(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
+				inFlight.Done()
+				if webhook.ID == 1 {
+					<-stopping
+					assert.NoError(t, ctx.Err(), "stopping the worker must not abort its delivery")
+					return &services.WebhookResponse{StatusCode: 200, Body: "ok"}, nil
+				}
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}).
+			Times(2)
+
+		mockQueueRepo.EXPECT().
+			UpdateRetryAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil).
+			AnyTimes()
+
+		mockQueueRepo.EXPECT().MarkCompleted(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		// Writes honour context cancellation like a real database driver
+		statuses := map[int64]enums.WebhookStatus{}
+		mockQueueRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, webhook *entities.WebhookQueue, _ string) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				mu.Lock()
+				statuses[webhook.ID] = webhook.Status
+				mu.Unlock()
+				return nil
+			}).
+			AnyTimes()
+
+		processor := usecases.NewWebhookProcessor(mockQueueRepo, mockConfigRepo, mockWebhookService, log.NewNopLogger())
+		pool := NewWorkerPool(processor, log.NewNopLogger(), config.WorkerPoolConfig{
+			Workers: []config.WorkerConfig{
+				{RetryLevel: 0, PollInterval: time.Millisecond, Description: "Level 0 Worker"},
+				{RetryLevel: 1, PollInterval: time.Millisecond, Description: "Level 1 Worker"},
+			},
+			DrainTimeout: 50 * time.Millisecond,
+		}, testMetrics)
+
+		require.NoError(t, pool.Start())
+
+		done := make(chan struct{})
+		go func() {
+			inFlight.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("webhooks never went in flight")
+		}
+
+		// Webhook 1's receiver answers only once its worker has been told to stop
+		for _, worker := range pool.workers {
+			if worker.GetRetryLevel() == 0 {
+				go func() {
+					<-worker.ctx.Done()
+					close(stopping)
+				}()
+			}
+		}
+		require.NoError(t, pool.Stop())
+
+		assert.Equal(t, ShutdownStats{InFlight: 2, Drained: 1, Reset: 1}, pool.LastShutdownStats())
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, enums.WebhookStatusPending, statuses[2])
+	})
+}
+
+func TestWorkerPool_Drain(t *testing.T) {
+	t.Run("should pause every worker and report draining", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		processor := usecases.NewWebhookProcessor(
+			mocks.NewMockWebhookQueueRepository(ctrl),
+			mocks.NewMockWebhookConfigRepository(ctrl),
+			mocks.NewMockWebhookService(ctrl),
+			log.NewNopLogger(),
+		)
+		pool := NewWorkerPool(processor, log.NewNopLogger(), config.WorkerPoolConfig{
+			Workers: []config.WorkerConfig{
+				{RetryLevel: 0, PollInterval: time.Hour},
+				{RetryLevel: 1, PollInterval: time.Hour},
+			},
+		}, testMetrics)
+
+		// Workers are registered but never polled
+		pool.startWorker = func(worker *WebhookWorker) error { return nil }
+		require.NoError(t, pool.Start())
+		assert.False(t, pool.IsDraining())
+
+		pool.Drain()
+		pool.Drain()
+
+		assert.True(t, pool.IsDraining())
+		require.Len(t, pool.workers, 2)
+		for _, worker := range pool.workers {
+			assert.True(t, worker.IsPaused(), "worker %s still polling", worker.GetID())
+		}
+	})
+}
+
+func TestWorkerPool_StateGauges(t *testing.T) {
+	t.Run("should reflect start, drain and stop transitions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		processor := usecases.NewWebhookProcessor(
+			mocks.NewMockWebhookQueueRepository(ctrl),
+			mocks.NewMockWebhookConfigRepository(ctrl),
+			mocks.NewMockWebhookService(ctrl),
+			log.NewNopLogger(),
+		)
+		// An hour-long poll interval keeps the started workers from ever polling
+		pool := NewWorkerPool(processor, log.NewNopLogger(), config.WorkerPoolConfig{
+			Workers: []config.WorkerConfig{
+				{RetryLevel: 0, PollInterval: time.Hour},
+				{RetryLevel: 1, PollInterval: time.Hour},
+			},
+		}, testMetrics)
+
+		require.NoError(t, pool.Start())
+		assert.Equal(t, 1.0, gaugeValue(t, "webhook_worker_pool_running"))
+		assert.Equal(t, 2.0, gaugeValue(t, "webhook_workers_active"))
+
+		pool.Drain()
+		assert.Equal(t, 1.0, gaugeValue(t, "webhook_worker_pool_running"))
+		assert.Equal(t, 0.0, gaugeValue(t, "webhook_workers_active"))
+
+		require.NoError(t, pool.Stop())
+		assert.Equal(t, 0.0, gaugeValue(t, "webhook_worker_pool_running"))
+		assert.Equal(t, 0.0, gaugeValue(t, "webhook_workers_active"))
+	})
+
+	t.Run("should report a stopped pool when start rolls back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		processor := usecases.NewWebhookProcessor(
+			mocks.NewMockWebhookQueueRepository(ctrl),
+			mocks.NewMockWebhookConfigRepository(ctrl),
+			mocks.NewMockWebhookService(ctrl),
+			log.NewNopLogger(),
+		)
+		pool := NewWorkerPool(processor, log.NewNopLogger(), config.WorkerPoolConfig{
+			Workers: []config.WorkerConfig{
+				{RetryLevel: 0, PollInterval: time.Hour},
+				{RetryLevel: 1, PollInterval: 0},
+			},
+		}, testMetrics)
+
+		require.Error(t, pool.Start())
+		assert.Equal(t, 0.0, gaugeValue(t, "webhook_worker_pool_running"))
+		assert.Equal(t, 0.0, gaugeValue(t, "webhook_workers_active"))
+	})
+}
+
+// gaugeValue reads an unlabelled gauge from the default registry
+func gaugeValue(t *testing.T, name string) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() == name {
+			require.Len(t, family.GetMetric(), 1)
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("gauge %s not registered", name)
+	return 0
+}
@@ -1,12 +1,20 @@
 package config
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
+
+	"webhook-processor/internal/domain/enums"
 )
 
 // Config holds all configuration for the webhook processor
@@ -14,8 +22,41 @@ type Config struct {
 	Database   DatabaseConfig   `json:"database"`
 	HTTPClient HTTPClientConfig `json:"http_client"`
 	HTTPServer HTTPServerConfig `json:"http_server"`
+	WorkerPool WorkerPoolConfig `json:"worker_pool"`
+	Health     HealthConfig     `json:"health"`
+
+	Reconciliation ReconciliationConfig `json:"reconciliation"`
+	Resources      ResourcesConfig      `json:"resources"`
+	Retry          RetryConfig          `json:"retry"`
+	Log            LogConfig            `json:"log"`
+	Backpressure   BackpressureConfig   `json:"backpressure"`
+	Claim          ClaimConfig          `json:"claim"`
+	ConfigLookup   ConfigLookupConfig   `json:"config_lookup"`
+	DBHealth       DBHealthConfig       `json:"db_health"`
+	FailureAlert   FailureAlertConfig   `json:"failure_alert"`
+	WebhookURL     WebhookURLConfig     `json:"webhook_url"`
+	QueueBackup    QueueBackupConfig    `json:"queue_backup"`
+}
+
+// redactedValue replaces secrets when the configuration is exposed
+const redactedValue = "********"
+
+// sqlIdentifierPattern matches an unquoted lowercase PostgreSQL identifier of at most 63 bytes
+// The schema name is placed into every query, so nothing that would need quoting is accepted
+var sqlIdentifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// isSQLIdentifier reports whether name is safe to use unquoted as a schema name
+func isSQLIdentifier(name string) bool {
+	return sqlIdentifierPattern.MatchString(name)
 }
 
+// minStoredErrorBytes leaves room for the truncation marker plus some of the error itself
+const minStoredErrorBytes = 64
+
+// minClaimTTL keeps claim renewals, sent every third of the TTL while a delivery runs, from
+// hammering the database or missing the TTL under ordinary write latency
+const minClaimTTL = 30 * time.Second
+
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
 	Host            string        `json:"host"`
@@ -27,6 +68,29 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `json:"max_open_conns"`
 	MaxIdleConns    int           `json:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
+	// MaxLockingTxns bounds how many SELECT FOR UPDATE SKIP LOCKED transactions
+	// may hold a pooled connection at once, so workers can't starve other queries
+	MaxLockingTxns int `json:"max_locking_txns"`
+	// MaxStoredResponseBytes caps the response bodies stored across all attempts of
+	// one webhook; attempts past the budget keep only a snippet (0 disables the cap)
+	MaxStoredResponseBytes int `json:"max_stored_response_bytes"`
+	// MaxStoredErrorBytes caps each stored error message (last_error and the per-attempt
+	// errors); longer errors are cut with a marker recording their size (0 disables the cap)
+	MaxStoredErrorBytes int `json:"max_stored_error_bytes"`
+	// StoreRequestBodies records each attempt's sent body and redacted headers beside its response;
+	// request bodies count against MaxStoredResponseBytes
+	StoreRequestBodies bool `json:"store_request_bodies"`
+	// ResponseBodyStore is where attempt response bodies are kept: "inline" in the webhook row, or
+	// "directory" to offload them as objects under ResponseBodyDir and keep only a reference in the row
+	ResponseBodyStore string `json:"response_body_store"`
+	ResponseBodyDir   string `json:"response_body_dir"`
+	// Schema qualifies every table (e.g. tenant_a.webhook_queue) so tenants can be isolated in
+	// separate schemas; empty uses the tables on the connection's search path
+	Schema string `json:"schema"`
+	// AttemptBatchSize combines up to this many concurrent level-0 attempt writes into one UPDATE,
+	// flushing a partial batch AttemptBatchWait after its first write (1 writes every attempt alone)
+	AttemptBatchSize int           `json:"attempt_batch_size"`
+	AttemptBatchWait time.Duration `json:"attempt_batch_wait"`
 }
 
 // WorkerConfig holds configuration for a specific retry level worker
@@ -39,6 +103,54 @@ type WorkerConfig struct {
 // WorkerPoolConfig holds configuration for the worker pool
 type WorkerPoolConfig struct {
 	Workers []WorkerConfig `json:"workers"`
+	// RetryLevels limits this instance to the workers for these levels; empty runs every worker
+	// Levels above every configured worker's, used by configs that raise their retry limit, get a worker
+	// polling like the highest configured one
+	RetryLevels []int `json:"retry_levels"`
+	// HeartbeatInterval is how often each worker records its heartbeat in the database (0 disables)
+	HeartbeatInterval time.Duration `json:"heartbeat_interval"`
+	// DrainTimeout is how long a stopping worker lets the attempt it has in flight run before cancelling it
+	DrainTimeout time.Duration `json:"drain_timeout"`
+	// WakeOnCreate wakes level-0 workers as soon as a webhook is created in the same process,
+	// instead of waiting for their next poll; it has no effect across processes
+	WakeOnCreate bool `json:"wake_on_create"`
+}
+
+// ActiveWorkers returns the workers this instance runs, in configuration order
+func (c WorkerPoolConfig) ActiveWorkers() []WorkerConfig {
+	if len(c.RetryLevels) == 0 {
+		return c.Workers
+	}
+
+	selected := make(map[int]bool, len(c.RetryLevels))
+	for _, level := range c.RetryLevels {
+		selected[level] = true
+	}
+
+	active := make([]WorkerConfig, 0, len(c.Workers))
+	var highest *WorkerConfig
+	for i, worker := range c.Workers {
+		if selected[worker.RetryLevel] {
+			active = append(active, worker)
+			delete(selected, worker.RetryLevel)
+		}
+		if highest == nil || worker.RetryLevel > highest.RetryLevel {
+			highest = &c.Workers[i]
+		}
+	}
+
+	for _, level := range c.RetryLevels {
+		if !selected[level] || highest == nil || level < highest.RetryLevel {
+			continue
+		}
+		delete(selected, level)
+		active = append(active, WorkerConfig{
+			RetryLevel:   level,
+			PollInterval: highest.PollInterval,
+			Description:  fmt.Sprintf("Level %d Worker - Extended retry attempts", level),
+		})
+	}
+	return active
 }
 
 // HTTPClientConfig holds HTTP client configuration for external webhook requests
@@ -46,6 +158,64 @@ type HTTPClientConfig struct {
 	Timeout         time.Duration `json:"timeout"`
 	MaxIdleConns    int           `json:"max_idle_conns"`
 	IdleConnTimeout time.Duration `json:"idle_conn_timeout"`
+	// TimeoutGrowthFactor grows the per-attempt timeout with the retry level:
+	// timeout * (1 + retryLevel*factor), capped at MaxTimeout. 0 disables growth.
+	TimeoutGrowthFactor float64       `json:"timeout_growth_factor"`
+	MaxTimeout          time.Duration `json:"max_timeout"`
+	// MaxCachedClients caps the dedicated per-transport-profile clients kept alive
+	MaxCachedClients int `json:"max_cached_clients"`
+	// CompressionThreshold is the body size in bytes above which configs with CompressRequest gzip the body
+	CompressionThreshold int `json:"compression_threshold"`
+	// AttemptHeaders adds attempt metadata headers to outbound requests; an empty header name omits that header
+	AttemptHeaders AttemptHeadersConfig `json:"attempt_headers"`
+	// CipherSuites restricts the TLS 1.0-1.2 cipher suites offered, by crypto/tls name;
+	// empty uses Go's secure defaults. TLS 1.3 suites are fixed by Go and unaffected.
+	CipherSuites []string `json:"cipher_suites"`
+	// HostMinInterval is the least time between the start of any two requests to the same host; 0 disables it
+	HostMinInterval time.Duration `json:"host_min_interval"`
+	// AdaptiveTimeout tightens each config's attempt timeout to what its recent response times justify
+	AdaptiveTimeout AdaptiveTimeoutConfig `json:"adaptive_timeout"`
+}
+
+// AdaptiveTimeoutConfig derives a per-config attempt timeout of p99 response time * Factor from the last
+// Window responses, bounded below by Floor and above by the static attempt timeout
+type AdaptiveTimeoutConfig struct {
+	Enabled    bool          `json:"enabled"`
+	Factor     float64       `json:"factor"`
+	Floor      time.Duration `json:"floor"`
+	MinSamples int           `json:"min_samples"` // Responses observed before the static timeout is tightened
+	Window     int           `json:"window"`      // Most recent responses kept per config
+}
+
+// CipherSuiteIDs maps CipherSuites to their crypto/tls IDs
+// Only suites Go considers secure are accepted; an unknown or insecure name is an error
+func (c HTTPClientConfig) CipherSuiteIDs() ([]uint16, error) {
+	if len(c.CipherSuites) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(c.CipherSuites))
+	for _, name := range c.CipherSuites {
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure TLS cipher suite: %s", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// AttemptHeadersConfig holds the names of the attempt metadata headers sent with each delivery
+type AttemptHeadersConfig struct {
+	Enabled           bool   `json:"enabled"`
+	AttemptHeader     string `json:"attempt_header"`
+	MaxAttemptsHeader string `json:"max_attempts_header"`
+	WebhookIDHeader   string `json:"webhook_id_header"`
 }
 
 // HTTPServerConfig holds HTTP server configuration for our API server
@@ -54,11 +224,126 @@ type HTTPServerConfig struct {
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
 	IdleTimeout  time.Duration `json:"idle_timeout"`
+	AdminToken   string        `json:"admin_token"` // Bearer token for admin/debug endpoints (disabled when empty)
+
+	// MaxConcurrentProbes caps admin requests that send webhooks outside the worker pools (process now,
+	// replay-to); requests beyond it get 429, and 0 disables the cap
+	MaxConcurrentProbes int `json:"max_concurrent_probes"`
+}
+
+// HealthConfig holds thresholds used by the health endpoint
+type HealthConfig struct {
+	// BacklogDegradedAge reports "degraded" when the oldest due pending webhook has waited longer than this
+	BacklogDegradedAge time.Duration `json:"backlog_degraded_age"`
+	// WorkerStaleAfter treats a worker whose heartbeat is older than this as dead
+	WorkerStaleAfter time.Duration `json:"worker_stale_after"`
+}
+
+// ReconciliationConfig holds settings for the periodic queue consistency check
+type ReconciliationConfig struct {
+	Interval  time.Duration `json:"interval"`
+	Lookback  time.Duration `json:"lookback"`   // How far back the first check scans
+	BatchSize int           `json:"batch_size"` // Max rows inspected per check
+}
+
+// ClaimConfig holds settings for worker claims on PROCESSING webhooks
+type ClaimConfig struct {
+	TTL          time.Duration `json:"ttl"`           // How long a claim lasts unless renewed; in-flight deliveries renew it
+	ReapInterval time.Duration `json:"reap_interval"` // How often expired claims are returned to PENDING
+}
+
+// DBHealthConfig holds settings for backing workers off while the database is unreachable
+type DBHealthConfig struct {
+	// FailureThreshold is how many consecutive failed polls mark the database down; 0 disables the check
+	FailureThreshold int `json:"failure_threshold"`
+	// PingInterval is how often the database is pinged while down, and so how soon workers resume
+	PingInterval time.Duration `json:"ping_interval"`
+}
+
+// ResourcesConfig holds process resource limit settings checked at startup
+type ResourcesConfig struct {
+	// RaiseFileLimit raises the soft RLIMIT_NOFILE to the hard limit when it is below what the config needs
+	RaiseFileLimit bool `json:"raise_file_limit"`
+}
+
+// RetryConfig holds limits on retry scheduling
+type RetryConfig struct {
+	// MaxDelay is the furthest in the future NextRetryAt may be scheduled, applied after jitter
+	MaxDelay time.Duration `json:"max_delay"`
+	// SeededJitter derives each retry's jitter from the webhook's queue ID and retry count,
+	// making a webhook's schedule reproducible; the default draws it at random
+	SeededJitter bool `json:"seeded_jitter"`
+	// DNSNotFoundAttempts fails a webhook once this many consecutive attempts could not resolve its
+	// host and the last one found no such host (0 retries DNS failures like any other send error)
+	DNSNotFoundAttempts int `json:"dns_not_found_attempts"`
+	// ThrottleGrace is how many 429 responses per webhook reschedule it without consuming a retry
+	// (0 counts every 429 against the retry budget); Retry-After is honored either way
+	ThrottleGrace int `json:"throttle_grace"`
+}
+
+// BackpressureConfig holds the backlog water marks used to reject creates during overload
+type BackpressureConfig struct {
+	// HighWaterMark starts rejecting creates once this many webhooks are pending (0 disables)
+	HighWaterMark int `json:"high_water_mark"`
+	// LowWaterMark resumes accepting creates once the pending count drains to this level
+	LowWaterMark int `json:"low_water_mark"`
+	// CheckInterval is how long a pending count is reused before it is queried again
+	CheckInterval time.Duration `json:"check_interval"`
+	// RetryAfter is sent to rejected callers as the Retry-After header
+	RetryAfter time.Duration `json:"retry_after"`
+}
+
+// ConfigLookupConfig holds the retry applied when a create's webhook config lookup fails
+type ConfigLookupConfig struct {
+	// Attempts is the total number of lookups tried before the create fails (1 disables retries)
+	Attempts int `json:"attempts"`
+	// Backoff is the wait before the first retry, doubled for each retry after it
+	Backoff time.Duration `json:"backoff"`
+}
+
+// FailureAlertConfig holds the on-call alert sent when webhooks permanently fail
+type FailureAlertConfig struct {
+	// Kind is the alert format: slack, pagerduty, or empty to send no alerts
+	Kind string `json:"kind"`
+	// URL receives the alerts: a Slack incoming webhook or the PagerDuty Events API v2 endpoint
+	URL string `json:"url"`
+	// RoutingKey is the PagerDuty integration key; unused for Slack
+	RoutingKey string `json:"routing_key"`
+	// Window is how long failures after an alert are collected into one summary alert
+	Window time.Duration `json:"window"`
+}
+
+// WebhookURLConfig holds how created webhooks' URLs are validated and stored
+type WebhookURLConfig struct {
+	// Normalize rejects undeliverable URLs at create and stores the rest with a lowercased host and no default port
+	Normalize bool `json:"normalize"`
+	// SortQuery also sorts query parameters by name; only for receivers that ignore their order
+	SortQuery bool `json:"sort_query"`
+}
+
+// QueueBackupConfig holds the periodic backup of undelivered webhooks for disaster recovery
+type QueueBackupConfig struct {
+	// Dir is where backups are written, such as a mounted bucket or a shared volume; empty disables backups
+	Dir string `json:"dir"`
+	// Interval is how often a backup is written
+	Interval time.Duration `json:"interval"`
+	// BatchSize is how many rows are read per query while a backup is written
+	BatchSize int `json:"batch_size"`
+}
+
+// LogConfig holds logging settings
+type LogConfig struct {
+	// Level is the minimum level logged: debug, info, warn or error
+	Level string `json:"level"`
 }
 
 // LoadConfig loads configuration from environment variables
+// Env files from configFiles are loaded first; variables already set in the environment take precedence
 func LoadConfig() (*Config, error) {
-	_ = godotenv.Load()
+	if err := loadEnvFiles(configFiles()); err != nil {
+		return nil, err
+	}
+
 	config := &Config{
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
@@ -70,18 +355,123 @@ func LoadConfig() (*Config, error) {
 			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
 			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
 			ConnMaxLifetime: getEnvAsDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+			MaxLockingTxns:  getEnvAsInt("DB_MAX_LOCKING_TXNS", 10),
+
+			MaxStoredResponseBytes: getEnvAsInt("DB_MAX_STORED_RESPONSE_BYTES", 131072),
+			MaxStoredErrorBytes:    getEnvAsInt("DB_MAX_STORED_ERROR_BYTES", 2048),
+			StoreRequestBodies:     getEnvAsBool("DB_STORE_REQUEST_BODIES", false),
+			ResponseBodyStore:      getEnv("DB_RESPONSE_BODY_STORE", "inline"),
+			ResponseBodyDir:        getEnv("DB_RESPONSE_BODY_DIR", ""),
+			Schema:                 getEnv("DB_SCHEMA", ""),
+			AttemptBatchSize:       getEnvAsInt("DB_ATTEMPT_BATCH_SIZE", 1),
+			AttemptBatchWait:       getEnvAsDuration("DB_ATTEMPT_BATCH_WAIT", 5*time.Millisecond),
 		},
 		HTTPClient: HTTPClientConfig{
-			Timeout:         getEnvAsDuration("HTTP_CLIENT_TIMEOUT", 30*time.Second),
-			MaxIdleConns:    getEnvAsInt("HTTP_CLIENT_MAX_IDLE_CONNS", 100),
-			IdleConnTimeout: getEnvAsDuration("HTTP_CLIENT_IDLE_CONN_TIMEOUT", 90*time.Second),
+			Timeout:              getEnvAsDuration("HTTP_CLIENT_TIMEOUT", 30*time.Second),
+			MaxIdleConns:         getEnvAsInt("HTTP_CLIENT_MAX_IDLE_CONNS", 100),
+			IdleConnTimeout:      getEnvAsDuration("HTTP_CLIENT_IDLE_CONN_TIMEOUT", 90*time.Second),
+			TimeoutGrowthFactor:  getEnvAsFloat("HTTP_CLIENT_TIMEOUT_GROWTH_FACTOR", 0),
+			MaxTimeout:           getEnvAsDuration("HTTP_CLIENT_MAX_TIMEOUT", 2*time.Minute),
+			MaxCachedClients:     getEnvAsInt("HTTP_CLIENT_MAX_CACHED_CLIENTS", 32),
+			CompressionThreshold: getEnvAsInt("HTTP_CLIENT_COMPRESSION_THRESHOLD", 1024),
+			AttemptHeaders: AttemptHeadersConfig{
+				Enabled:           getEnvAsBool("HTTP_CLIENT_ATTEMPT_HEADERS_ENABLED", true),
+				AttemptHeader:     getEnv("HTTP_CLIENT_ATTEMPT_HEADER", "X-Webhook-Attempt"),
+				MaxAttemptsHeader: getEnv("HTTP_CLIENT_MAX_ATTEMPTS_HEADER", "X-Webhook-Max-Attempts"),
+				WebhookIDHeader:   getEnv("HTTP_CLIENT_WEBHOOK_ID_HEADER", "X-Webhook-Id"),
+			},
+			CipherSuites:    getEnvAsList("HTTP_CLIENT_CIPHER_SUITES"),
+			HostMinInterval: getEnvAsDuration("HTTP_CLIENT_HOST_MIN_INTERVAL", 0),
+			AdaptiveTimeout: AdaptiveTimeoutConfig{
+				Enabled:    getEnvAsBool("HTTP_CLIENT_ADAPTIVE_TIMEOUT_ENABLED", false),
+				Factor:     getEnvAsFloat("HTTP_CLIENT_ADAPTIVE_TIMEOUT_FACTOR", 3),
+				Floor:      getEnvAsDuration("HTTP_CLIENT_ADAPTIVE_TIMEOUT_FLOOR", time.Second),
+				MinSamples: getEnvAsInt("HTTP_CLIENT_ADAPTIVE_TIMEOUT_MIN_SAMPLES", 20),
+				Window:     getEnvAsInt("HTTP_CLIENT_ADAPTIVE_TIMEOUT_WINDOW", 100),
+			},
 		},
 		HTTPServer: HTTPServerConfig{
 			Port:         getEnvAsInt("API_PORT", 8080),
 			ReadTimeout:  getEnvAsDuration("HTTP_SERVER_READ_TIMEOUT", 30*time.Second),
 			WriteTimeout: getEnvAsDuration("HTTP_SERVER_WRITE_TIMEOUT", 30*time.Second),
 			IdleTimeout:  getEnvAsDuration("HTTP_SERVER_IDLE_TIMEOUT", 120*time.Second),
+			AdminToken:   getEnv("ADMIN_API_TOKEN", ""),
+
+			MaxConcurrentProbes: getEnvAsInt("HTTP_SERVER_MAX_CONCURRENT_PROBES", 4),
+		},
+		WorkerPool: GetDefaultWorkerPoolConfig(),
+		Health: HealthConfig{
+			BacklogDegradedAge: getEnvAsDuration("HEALTH_BACKLOG_DEGRADED_AGE", 15*time.Minute),
+			WorkerStaleAfter:   getEnvAsDuration("HEALTH_WORKER_STALE_AFTER", time.Minute),
+		},
+		Reconciliation: ReconciliationConfig{
+			Interval:  getEnvAsDuration("RECONCILE_INTERVAL", 10*time.Minute),
+			Lookback:  getEnvAsDuration("RECONCILE_LOOKBACK", 24*time.Hour),
+			BatchSize: getEnvAsInt("RECONCILE_BATCH_SIZE", 500),
+		},
+		Resources: ResourcesConfig{
+			RaiseFileLimit: getEnvAsBool("RESOURCES_RAISE_FILE_LIMIT", false),
+		},
+		Retry: RetryConfig{
+			MaxDelay:     getEnvAsDuration("RETRY_MAX_DELAY", 6*time.Hour),
+			SeededJitter: getEnvAsBool("RETRY_SEEDED_JITTER", false),
+
+			DNSNotFoundAttempts: getEnvAsInt("RETRY_DNS_NOT_FOUND_ATTEMPTS", 0),
+			ThrottleGrace:       getEnvAsInt("RETRY_THROTTLE_GRACE", 0),
+		},
+		Log: LogConfig{
+			Level: strings.ToLower(getEnv("LOG_LEVEL", "info")),
+		},
+		Backpressure: BackpressureConfig{
+			HighWaterMark: getEnvAsInt("BACKPRESSURE_HIGH_WATER_MARK", 0),
+			LowWaterMark:  getEnvAsInt("BACKPRESSURE_LOW_WATER_MARK", 0),
+			CheckInterval: getEnvAsDuration("BACKPRESSURE_CHECK_INTERVAL", 5*time.Second),
+			RetryAfter:    getEnvAsDuration("BACKPRESSURE_RETRY_AFTER", 30*time.Second),
+		},
+		Claim: ClaimConfig{
+			TTL:          getEnvAsDuration("CLAIM_TTL", 5*time.Minute),
+			ReapInterval: getEnvAsDuration("CLAIM_REAP_INTERVAL", time.Minute),
+		},
+		ConfigLookup: ConfigLookupConfig{
+			Attempts: getEnvAsInt("CONFIG_LOOKUP_ATTEMPTS", 3),
+			Backoff:  getEnvAsDuration("CONFIG_LOOKUP_BACKOFF", 50*time.Millisecond),
+		},
+		DBHealth: DBHealthConfig{
+			FailureThreshold: getEnvAsInt("DB_HEALTH_FAILURE_THRESHOLD", 3),
+			PingInterval:     getEnvAsDuration("DB_HEALTH_PING_INTERVAL", 5*time.Second),
 		},
+		FailureAlert: FailureAlertConfig{
+			Kind:       strings.ToLower(getEnv("FAILURE_ALERT_KIND", "")),
+			URL:        getEnv("FAILURE_ALERT_URL", ""),
+			RoutingKey: getEnv("FAILURE_ALERT_ROUTING_KEY", ""),
+			Window:     getEnvAsDuration("FAILURE_ALERT_WINDOW", time.Minute),
+		},
+		WebhookURL: WebhookURLConfig{
+			Normalize: getEnvAsBool("WEBHOOK_URL_NORMALIZE", false),
+			SortQuery: getEnvAsBool("WEBHOOK_URL_SORT_QUERY", false),
+		},
+		QueueBackup: QueueBackupConfig{
+			Dir:       getEnv("QUEUE_BACKUP_DIR", ""),
+			Interval:  getEnvAsDuration("QUEUE_BACKUP_INTERVAL", time.Hour),
+			BatchSize: getEnvAsInt("QUEUE_BACKUP_BATCH_SIZE", 500),
+		},
+	}
+
+	retryLevels, err := getEnvAsIntList("WORKER_RETRY_LEVELS")
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	config.WorkerPool.RetryLevels = retryLevels
+	config.WorkerPool.HeartbeatInterval = getEnvAsDuration("WORKER_HEARTBEAT_INTERVAL", 15*time.Second)
+	config.WorkerPool.DrainTimeout = getEnvAsDuration("WORKER_DRAIN_TIMEOUT", time.Minute)
+	config.WorkerPool.WakeOnCreate = getEnvAsBool("WORKER_WAKE_ON_CREATE", false)
+
+	// Level 0 polling drives first-attempt latency, so it is tunable
+	level0PollInterval := getEnvAsDuration("WORKER_LEVEL0_POLL_INTERVAL", 5*time.Second)
+	for i := range config.WorkerPool.Workers {
+		if config.WorkerPool.Workers[i].RetryLevel == 0 {
+			config.WorkerPool.Workers[i].PollInterval = level0PollInterval
+		}
 	}
 
 	if err := config.Validate(); err != nil {
@@ -102,15 +492,187 @@ func (c *Config) Validate() error {
 	if c.Database.DBName == "" {
 		return fmt.Errorf("database name is required")
 	}
+	if c.Database.MaxLockingTxns <= 0 {
+		return fmt.Errorf("database max locking transactions must be positive")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxLockingTxns >= c.Database.MaxOpenConns {
+		return fmt.Errorf("database max locking transactions must be less than max open connections")
+	}
+	if c.Database.MaxStoredResponseBytes < 0 {
+		return fmt.Errorf("database max stored response bytes cannot be negative")
+	}
+	if c.Database.Schema != "" && !isSQLIdentifier(c.Database.Schema) {
+		return fmt.Errorf("database schema %q must be a lowercase SQL identifier of at most 63 characters", c.Database.Schema)
+	}
+	if c.Database.MaxStoredErrorBytes != 0 && c.Database.MaxStoredErrorBytes < minStoredErrorBytes {
+		return fmt.Errorf("database max stored error bytes must be 0 or at least %d", minStoredErrorBytes)
+	}
+	switch c.Database.ResponseBodyStore {
+	case "inline":
+	case "directory":
+		if c.Database.ResponseBodyDir == "" {
+			return fmt.Errorf("database response body directory is required for the directory body store")
+		}
+	default:
+		return fmt.Errorf("database response body store must be inline or directory")
+	}
+	if c.Database.AttemptBatchSize <= 0 {
+		return fmt.Errorf("database attempt batch size must be positive")
+	}
+	if c.Database.AttemptBatchSize > 1 && c.Database.AttemptBatchWait <= 0 {
+		return fmt.Errorf("database attempt batch wait must be positive when batching attempts")
+	}
 	if c.HTTPClient.Timeout <= 0 {
 		return fmt.Errorf("HTTP client timeout must be positive")
 	}
+	if c.HTTPClient.TimeoutGrowthFactor < 0 {
+		return fmt.Errorf("HTTP client timeout growth factor cannot be negative")
+	}
+	if c.HTTPClient.MaxTimeout > 0 && c.HTTPClient.MaxTimeout < c.HTTPClient.Timeout {
+		return fmt.Errorf("HTTP client max timeout cannot be less than the base timeout")
+	}
+	if c.HTTPClient.MaxCachedClients <= 0 {
+		return fmt.Errorf("HTTP client max cached clients must be positive")
+	}
+	if c.HTTPClient.CompressionThreshold < 0 {
+		return fmt.Errorf("HTTP client compression threshold cannot be negative")
+	}
+	if _, err := c.HTTPClient.CipherSuiteIDs(); err != nil {
+		return fmt.Errorf("invalid HTTP client cipher suites: %w", err)
+	}
+	if c.HTTPClient.HostMinInterval < 0 {
+		return fmt.Errorf("HTTP client host min interval cannot be negative")
+	}
+	if adaptive := c.HTTPClient.AdaptiveTimeout; adaptive.Enabled {
+		if adaptive.Factor < 1 {
+			return fmt.Errorf("HTTP client adaptive timeout factor must be at least 1")
+		}
+		if adaptive.Floor <= 0 || adaptive.Floor > c.HTTPClient.Timeout {
+			return fmt.Errorf("HTTP client adaptive timeout floor must be positive and at most the timeout")
+		}
+		if adaptive.MinSamples <= 0 || adaptive.Window < adaptive.MinSamples {
+			return fmt.Errorf("HTTP client adaptive timeout needs positive min samples and a window at least that large")
+		}
+	}
+	if c.Health.BacklogDegradedAge <= 0 {
+		return fmt.Errorf("health backlog degraded age must be positive")
+	}
+	if c.Reconciliation.Interval <= 0 || c.Reconciliation.Lookback <= 0 || c.Reconciliation.BatchSize <= 0 {
+		return fmt.Errorf("reconciliation interval, lookback and batch size must be positive")
+	}
+	if c.WorkerPool.HeartbeatInterval < 0 {
+		return fmt.Errorf("worker heartbeat interval cannot be negative")
+	}
+	if c.WorkerPool.HeartbeatInterval > 0 && c.Health.WorkerStaleAfter <= c.WorkerPool.HeartbeatInterval {
+		return fmt.Errorf("health worker stale after must be longer than the worker heartbeat interval")
+	}
+	if c.WorkerPool.DrainTimeout <= 0 {
+		return fmt.Errorf("worker drain timeout must be positive")
+	}
+	if c.Retry.MaxDelay < time.Minute {
+		return fmt.Errorf("retry max delay must be at least 1 minute")
+	}
+	if c.Retry.DNSNotFoundAttempts < 0 {
+		return fmt.Errorf("retry DNS not found attempts cannot be negative")
+	}
+	if c.Retry.ThrottleGrace < 0 {
+		return fmt.Errorf("retry throttle grace cannot be negative")
+	}
+	if c.Backpressure.HighWaterMark < 0 {
+		return fmt.Errorf("backpressure high-water mark cannot be negative")
+	}
+	if c.Backpressure.HighWaterMark > 0 {
+		if c.Backpressure.LowWaterMark < 0 || c.Backpressure.LowWaterMark >= c.Backpressure.HighWaterMark {
+			return fmt.Errorf("backpressure low-water mark must be between 0 and the high-water mark")
+		}
+		if c.Backpressure.CheckInterval <= 0 || c.Backpressure.RetryAfter < time.Second {
+			return fmt.Errorf("backpressure check interval must be positive and retry after at least 1 second")
+		}
+	}
+	if c.Claim.TTL <= 0 || c.Claim.ReapInterval <= 0 {
+		return fmt.Errorf("claim TTL and reap interval must be positive")
+	}
+	if c.Claim.TTL < minClaimTTL {
+		return fmt.Errorf("claim TTL must be at least %s", minClaimTTL)
+	}
+	if c.ConfigLookup.Attempts < 1 || c.ConfigLookup.Backoff < 0 {
+		return fmt.Errorf("config lookup attempts must be at least 1 and backoff cannot be negative")
+	}
+	if c.WebhookURL.SortQuery && !c.WebhookURL.Normalize {
+		return fmt.Errorf("WEBHOOK_URL_SORT_QUERY requires WEBHOOK_URL_NORMALIZE")
+	}
+	if c.DBHealth.FailureThreshold < 0 {
+		return fmt.Errorf("DB health failure threshold cannot be negative")
+	}
+	if c.DBHealth.FailureThreshold > 0 && c.DBHealth.PingInterval <= 0 {
+		return fmt.Errorf("DB health ping interval must be positive")
+	}
+	switch c.FailureAlert.Kind {
+	case "":
+	case "slack", "pagerduty":
+		if c.FailureAlert.URL == "" {
+			return fmt.Errorf("failure alert URL is required for %s alerts", c.FailureAlert.Kind)
+		}
+		if c.FailureAlert.Kind == "pagerduty" && c.FailureAlert.RoutingKey == "" {
+			return fmt.Errorf("failure alert routing key is required for pagerduty alerts")
+		}
+		if c.FailureAlert.Window <= 0 {
+			return fmt.Errorf("failure alert window must be positive")
+		}
+	default:
+		return fmt.Errorf("failure alert kind must be slack, pagerduty or empty")
+	}
+	if c.QueueBackup.Dir != "" && (c.QueueBackup.Interval <= 0 || c.QueueBackup.BatchSize <= 0) {
+		return fmt.Errorf("queue backup interval and batch size must be positive")
+	}
+	for _, worker := range c.WorkerPool.Workers {
+		if worker.PollInterval <= 0 {
+			return fmt.Errorf("worker poll interval for retry level %d must be positive", worker.RetryLevel)
+		}
+	}
+	for _, level := range c.WorkerPool.RetryLevels {
+		if level < 0 || level > enums.MaxConfigurableRetries {
+			return fmt.Errorf("worker retry level %d must be between 0 and %d", level, enums.MaxConfigurableRetries)
+		}
+	}
+	if len(c.WorkerPool.RetryLevels) > 0 && len(c.WorkerPool.ActiveWorkers()) == 0 {
+		return fmt.Errorf("worker retry levels select no configured workers")
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("log level must be one of debug, info, warn or error")
+	}
 	if c.HTTPServer.Port <= 0 || c.HTTPServer.Port > 65535 {
 		return fmt.Errorf("HTTP server port must be between 1 and 65535")
 	}
+	if c.HTTPServer.MaxConcurrentProbes < 0 {
+		return fmt.Errorf("HTTP server max concurrent probes must not be negative")
+	}
 	return nil
 }
 
+// Redacted returns a copy of the configuration with secrets masked, safe to expose
+func (c *Config) Redacted() Config {
+	redacted := *c
+	redacted.Database.Password = redactSecret(c.Database.Password)
+	redacted.HTTPServer.AdminToken = redactSecret(c.HTTPServer.AdminToken)
+	redacted.FailureAlert.URL = redactSecret(c.FailureAlert.URL) // Slack webhook URLs embed their credential
+	redacted.FailureAlert.RoutingKey = redactSecret(c.FailureAlert.RoutingKey)
+	redacted.WorkerPool.Workers = append([]WorkerConfig(nil), c.WorkerPool.Workers...)
+	redacted.WorkerPool.RetryLevels = append([]int(nil), c.WorkerPool.RetryLevels...)
+	redacted.HTTPClient.CipherSuites = append([]string(nil), c.HTTPClient.CipherSuites...)
+	return redacted
+}
+
+// redactSecret masks a non-empty secret so its presence is visible but not its value
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redactedValue
+}
+
 // GetDatabaseDSN returns the database connection string
 func (c *Config) GetDatabaseDSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s timezone=UTC",
@@ -123,6 +685,73 @@ func (c *Config) GetDatabaseDSN() string {
 	)
 }
 
+// configFiles returns the env files to load in precedence order, lowest first
+// CONFIG_FILES (comma separated) replaces the default layering of .env, .env.local and .env.<ENVIRONMENT>
+func configFiles() []string {
+	if list := os.Getenv("CONFIG_FILES"); list != "" {
+		var files []string
+		for _, file := range strings.Split(list, ",") {
+			if file = strings.TrimSpace(file); file != "" {
+				files = append(files, file)
+			}
+		}
+		return files
+	}
+
+	files := []string{".env", ".env.local"}
+	if environment := os.Getenv("ENVIRONMENT"); environment != "" {
+		files = append(files, ".env."+environment)
+	}
+	return files
+}
+
+// fileEnv tracks the variables exported from env files so a reload can update them
+// without overriding variables that were set in the real environment
+var fileEnv = struct {
+	sync.Mutex
+	keys map[string]bool
+}{keys: make(map[string]bool)}
+
+// loadEnvFiles merges env files so later files override earlier ones, then exports
+// every variable not already set in the process environment; missing files are skipped
+// Variables exported by an earlier load are refreshed, or unset once no file defines them
+func loadEnvFiles(files []string) error {
+	merged := make(map[string]string)
+	for _, file := range files {
+		values, err := godotenv.Read(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read config file %s: %w", file, err)
+		}
+		for key, value := range values {
+			merged[key] = value
+		}
+	}
+
+	fileEnv.Lock()
+	defer fileEnv.Unlock()
+
+	for key := range fileEnv.keys {
+		if _, stillDefined := merged[key]; !stillDefined {
+			os.Unsetenv(key)
+			delete(fileEnv.keys, key)
+		}
+	}
+
+	for key, value := range merged {
+		if _, exists := os.LookupEnv(key); exists && !fileEnv.keys[key] {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s from config files: %w", key, err)
+		}
+		fileEnv.keys[key] = true
+	}
+	return nil
+}
+
 // Helper functions for environment variable parsing
 
 func getEnv(key, defaultValue string) string {
@@ -141,6 +770,48 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList splits a comma separated variable, dropping empty entries
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvAsIntList parses a comma separated list of integers; an entry that is not an integer is an error
+func getEnvAsIntList(key string) ([]int, error) {
+	var values []int
+	for _, value := range getEnvAsList(key) {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not an integer", key, value)
+		}
+		values = append(values, parsed)
+	}
+	return values, nil
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -0,0 +1,645 @@
+package config
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Redacted(t *testing.T) {
+	t.Run("should mask database password and admin token", func(t *testing.T) {
+		cfg := &Config{
+			Database:     DatabaseConfig{Host: "localhost", Password: "root"},
+			HTTPServer:   HTTPServerConfig{Port: 8080, AdminToken: "admin-secret"},
+			WorkerPool:   GetDefaultWorkerPoolConfig(),
+			FailureAlert: FailureAlertConfig{Kind: "slack", URL: "https://hooks.slack.com/services/T0/B0/secret"},
+		}
+
+		redacted := cfg.Redacted()
+
+		assert.Equal(t, redactedValue, redacted.Database.Password)
+		assert.Equal(t, redactedValue, redacted.HTTPServer.AdminToken)
+		assert.Equal(t, redactedValue, redacted.FailureAlert.URL)
+		assert.Equal(t, "slack", redacted.FailureAlert.Kind)
+		assert.Equal(t, "localhost", redacted.Database.Host)
+		assert.Equal(t, 8080, redacted.HTTPServer.Port)
+		assert.Equal(t, cfg.WorkerPool.Workers, redacted.WorkerPool.Workers)
+	})
+
+	t.Run("should leave the original configuration untouched", func(t *testing.T) {
+		cfg := &Config{
+			Database:   DatabaseConfig{Password: "root"},
+			WorkerPool: GetDefaultWorkerPoolConfig(),
+		}
+
+		redacted := cfg.Redacted()
+		redacted.WorkerPool.Workers[0].Description = "changed"
+
+		assert.Equal(t, "root", cfg.Database.Password)
+		assert.NotEqual(t, "changed", cfg.WorkerPool.Workers[0].Description)
+	})
+
+	t.Run("should keep empty secrets empty", func(t *testing.T) {
+		cfg := &Config{}
+
+		redacted := cfg.Redacted()
+
+		assert.Empty(t, redacted.Database.Password)
+		assert.Empty(t, redacted.HTTPServer.AdminToken)
+	})
+}
+
+// writeEnvFile writes an env file into dir and returns its path
+func writeEnvFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// unsetAfterTest removes variables that loadEnvFiles exported during a test
+func unsetAfterTest(t *testing.T, keys ...string) {
+	t.Cleanup(func() {
+		for _, key := range keys {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestLoadEnvFiles(t *testing.T) {
+	t.Run("should let later files override earlier ones", func(t *testing.T) {
+		dir := t.TempDir()
+		base := writeEnvFile(t, dir, ".env", "LAYER_TEST_A=base\nLAYER_TEST_B=base\nLAYER_TEST_C=base\n")
+		local := writeEnvFile(t, dir, ".env.local", "LAYER_TEST_B=local\nLAYER_TEST_C=local\n")
+		staging := writeEnvFile(t, dir, ".env.staging", "LAYER_TEST_C=staging\n")
+		unsetAfterTest(t, "LAYER_TEST_A", "LAYER_TEST_B", "LAYER_TEST_C")
+
+		require.NoError(t, loadEnvFiles([]string{base, local, staging}))
+
+		assert.Equal(t, "base", os.Getenv("LAYER_TEST_A"))
+		assert.Equal(t, "local", os.Getenv("LAYER_TEST_B"))
+		assert.Equal(t, "staging", os.Getenv("LAYER_TEST_C"))
+	})
+
+	t.Run("should not override variables already set in the environment", func(t *testing.T) {
+		dir := t.TempDir()
+		base := writeEnvFile(t, dir, ".env", "LAYER_TEST_PRESET=file\n")
+		t.Setenv("LAYER_TEST_PRESET", "process")
+
+		require.NoError(t, loadEnvFiles([]string{base}))
+
+		assert.Equal(t, "process", os.Getenv("LAYER_TEST_PRESET"))
+	})
+
+	t.Run("should pick up edited files on a later load", func(t *testing.T) {
+		dir := t.TempDir()
+		unsetAfterTest(t, "LAYER_TEST_RELOAD", "LAYER_TEST_REMOVED")
+
+		base := writeEnvFile(t, dir, ".env", "LAYER_TEST_RELOAD=before\nLAYER_TEST_REMOVED=set\n")
+		require.NoError(t, loadEnvFiles([]string{base}))
+		assert.Equal(t, "before", os.Getenv("LAYER_TEST_RELOAD"))
+
+		base = writeEnvFile(t, dir, ".env", "LAYER_TEST_RELOAD=after\n")
+		require.NoError(t, loadEnvFiles([]string{base}))
+
+		assert.Equal(t, "after", os.Getenv("LAYER_TEST_RELOAD"))
+		_, exists := os.LookupEnv("LAYER_TEST_REMOVED")
+		assert.False(t, exists)
+	})
+
+	t.Run("should skip missing files", func(t *testing.T) {
+		dir := t.TempDir()
+		base := writeEnvFile(t, dir, ".env", "LAYER_TEST_ONLY=base\n")
+		unsetAfterTest(t, "LAYER_TEST_ONLY")
+
+		require.NoError(t, loadEnvFiles([]string{base, filepath.Join(dir, ".env.local")}))
+
+		assert.Equal(t, "base", os.Getenv("LAYER_TEST_ONLY"))
+	})
+}
+
+func TestConfigFiles(t *testing.T) {
+	t.Run("should use conventional layering by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", "")
+		t.Setenv("ENVIRONMENT", "staging")
+
+		assert.Equal(t, []string{".env", ".env.local", ".env.staging"}, configFiles())
+	})
+
+	t.Run("should use CONFIG_FILES when set", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", "base.env, overrides.env,")
+
+		assert.Equal(t, []string{"base.env", "overrides.env"}, configFiles())
+	})
+}
+
+func TestHTTPClientConfig_CipherSuiteIDs(t *testing.T) {
+	t.Run("should map configured names to crypto/tls IDs", func(t *testing.T) {
+		cfg := HTTPClientConfig{CipherSuites: []string{
+			"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		}}
+
+		ids, err := cfg.CipherSuiteIDs()
+
+		require.NoError(t, err)
+		assert.Equal(t, []uint16{
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		}, ids)
+	})
+
+	t.Run("should leave Go's defaults when unset", func(t *testing.T) {
+		ids, err := HTTPClientConfig{}.CipherSuiteIDs()
+
+		require.NoError(t, err)
+		assert.Nil(t, ids)
+	})
+
+	t.Run("should reject unknown and insecure names", func(t *testing.T) {
+		_, err := HTTPClientConfig{CipherSuites: []string{"TLS_MADE_UP"}}.CipherSuiteIDs()
+		assert.Error(t, err)
+
+		_, err = HTTPClientConfig{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}.CipherSuiteIDs()
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail config loading on an unknown name", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("HTTP_CLIENT_CIPHER_SUITES", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_MADE_UP")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TLS_MADE_UP")
+	})
+}
+
+func TestConfig_ClaimValidation(t *testing.T) {
+	t.Run("should load the default claim settings", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, cfg.Claim.TTL)
+		assert.Equal(t, time.Minute, cfg.Claim.ReapInterval)
+	})
+
+	t.Run("should reject a claim TTL too short to renew reliably", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("CLAIM_TTL", "10s")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "claim TTL must be at least 30s")
+	})
+
+	t.Run("should accept a claim TTL shorter than the HTTP client timeouts", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("CLAIM_TTL", "90s")
+		t.Setenv("HTTP_CLIENT_MAX_TIMEOUT", "2m")
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err, "in-flight deliveries renew their claim")
+		assert.Equal(t, 90*time.Second, cfg.Claim.TTL)
+	})
+}
+
+func TestConfig_ConfigLookupValidation(t *testing.T) {
+	t.Run("should load the default config lookup retry", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, cfg.ConfigLookup.Attempts)
+		assert.Equal(t, 50*time.Millisecond, cfg.ConfigLookup.Backoff)
+	})
+
+	t.Run("should reject zero attempts", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("CONFIG_LOOKUP_ATTEMPTS", "0")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "config lookup attempts must be at least 1")
+	})
+}
+
+func TestConfig_WebhookURL(t *testing.T) {
+	t.Run("should leave URLs as configured by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.False(t, cfg.WebhookURL.Normalize)
+		assert.False(t, cfg.WebhookURL.SortQuery)
+	})
+
+	t.Run("should reject sorting the query without normalizing", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("WEBHOOK_URL_SORT_QUERY", "true")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "WEBHOOK_URL_SORT_QUERY requires WEBHOOK_URL_NORMALIZE")
+	})
+}
+
+func TestConfig_WorkerRetryLevels(t *testing.T) {
+	t.Run("should run every worker by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Empty(t, cfg.WorkerPool.RetryLevels)
+		assert.Equal(t, cfg.WorkerPool.Workers, cfg.WorkerPool.ActiveWorkers())
+	})
+
+	t.Run("should select the workers for the listed levels", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("WORKER_RETRY_LEVELS", "1, 2,3,4,5,6")
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, cfg.WorkerPool.RetryLevels)
+		active := cfg.WorkerPool.ActiveWorkers()
+		require.Len(t, active, 6)
+		for i, worker := range active {
+			assert.Equal(t, i+1, worker.RetryLevel)
+		}
+	})
+
+	t.Run("should add workers for levels above the configured ones", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("WORKER_RETRY_LEVELS", "5,6,7,8,8")
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		active := cfg.WorkerPool.ActiveWorkers()
+		require.Len(t, active, 4)
+		assert.Equal(t, []int{5, 6, 7, 8}, []int{active[0].RetryLevel, active[1].RetryLevel, active[2].RetryLevel, active[3].RetryLevel})
+		assert.Equal(t, active[1].PollInterval, active[2].PollInterval)
+		assert.Equal(t, active[1].PollInterval, active[3].PollInterval)
+	})
+
+	t.Run("should reject a level out of range", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("WORKER_RETRY_LEVELS", "0,21")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "worker retry level 21 must be between 0 and 20")
+	})
+
+	t.Run("should reject a level that is not an integer", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("WORKER_RETRY_LEVELS", "0,first")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `WORKER_RETRY_LEVELS: "first" is not an integer`)
+	})
+}
+
+func TestConfig_WorkerHeartbeat(t *testing.T) {
+	t.Run("should beat more often than workers are considered stale by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Second, cfg.WorkerPool.HeartbeatInterval)
+		assert.Equal(t, time.Minute, cfg.Health.WorkerStaleAfter)
+	})
+
+	t.Run("should reject a stale threshold a live worker could miss", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("WORKER_HEARTBEAT_INTERVAL", "1m")
+		t.Setenv("HEALTH_WORKER_STALE_AFTER", "1m")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "health worker stale after must be longer than the worker heartbeat interval")
+	})
+
+	t.Run("should allow disabling heartbeats", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("WORKER_HEARTBEAT_INTERVAL", "0")
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Zero(t, cfg.WorkerPool.HeartbeatInterval)
+	})
+}
+
+func TestConfig_WorkerDrainTimeout(t *testing.T) {
+	t.Run("should give in-flight attempts a minute to drain by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, cfg.WorkerPool.DrainTimeout)
+	})
+
+	t.Run("should reject a drain timeout that would cancel attempts at once", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("WORKER_DRAIN_TIMEOUT", "0")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "worker drain timeout must be positive")
+	})
+}
+
+func TestConfig_DBHealth(t *testing.T) {
+	t.Run("should back off after three failed polls by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, cfg.DBHealth.FailureThreshold)
+		assert.Equal(t, 5*time.Second, cfg.DBHealth.PingInterval)
+	})
+
+	t.Run("should reject a zero ping interval while enabled", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("DB_HEALTH_PING_INTERVAL", "0")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DB health ping interval must be positive")
+	})
+
+	t.Run("should allow disabling the check", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("DB_HEALTH_FAILURE_THRESHOLD", "0")
+		t.Setenv("DB_HEALTH_PING_INTERVAL", "0")
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Zero(t, cfg.DBHealth.FailureThreshold)
+	})
+}
+
+func TestConfig_MaxStoredErrorBytes(t *testing.T) {
+	t.Run("should cap stored errors at 2KB by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2048, cfg.Database.MaxStoredErrorBytes)
+	})
+
+	t.Run("should reject a cap too small for the truncation marker", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("DB_MAX_STORED_ERROR_BYTES", "16")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database max stored error bytes must be 0 or at least 64")
+	})
+
+	t.Run("should allow disabling the cap", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("DB_MAX_STORED_ERROR_BYTES", "0")
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Zero(t, cfg.Database.MaxStoredErrorBytes)
+	})
+}
+
+func TestConfig_DatabaseSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{name: "empty uses the search path", schema: ""},
+		{name: "tenant schema", schema: "tenant_a"},
+		{name: "quoted injection", schema: `tenant_a"; DROP TABLE webhook_queue; --`, wantErr: true},
+		{name: "qualified name", schema: "tenant_a.webhook_queue", wantErr: true},
+		{name: "uppercase needs quoting", schema: "TenantA", wantErr: true},
+		{name: "leading digit", schema: "1tenant", wantErr: true},
+		{name: "longer than 63 characters", schema: strings.Repeat("t", 64), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+			t.Setenv("DB_SCHEMA", tt.schema)
+
+			cfg, err := LoadConfig()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "database schema")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.schema, cfg.Database.Schema)
+		})
+	}
+}
+
+func TestConfig_AttemptBatch(t *testing.T) {
+	t.Run("should write every attempt alone by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, cfg.Database.AttemptBatchSize)
+		assert.Equal(t, 5*time.Millisecond, cfg.Database.AttemptBatchWait)
+	})
+
+	t.Run("should load a batch size and wait", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("DB_ATTEMPT_BATCH_SIZE", "32")
+		t.Setenv("DB_ATTEMPT_BATCH_WAIT", "20ms")
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, 32, cfg.Database.AttemptBatchSize)
+		assert.Equal(t, 20*time.Millisecond, cfg.Database.AttemptBatchWait)
+	})
+
+	t.Run("should reject a batch size below one", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("DB_ATTEMPT_BATCH_SIZE", "0")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database attempt batch size must be positive")
+	})
+
+	t.Run("should reject batching without a wait", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("DB_ATTEMPT_BATCH_SIZE", "8")
+		t.Setenv("DB_ATTEMPT_BATCH_WAIT", "0s")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database attempt batch wait must be positive")
+	})
+}
+
+func TestConfig_FailureAlert(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       string
+		url        string
+		routingKey string
+		window     string
+		wantErr    string
+	}{
+		{name: "disabled by default"},
+		{name: "slack", kind: "slack", url: "https://hooks.slack.com/services/T0/B0/x"},
+		{name: "pagerduty", kind: "PagerDuty", url: "https://events.pagerduty.com/v2/enqueue", routingKey: "key"},
+		{name: "slack without URL", kind: "slack", wantErr: "failure alert URL is required"},
+		{name: "pagerduty without routing key", kind: "pagerduty", url: "https://events.pagerduty.com/v2/enqueue", wantErr: "routing key is required"},
+		{name: "zero window", kind: "slack", url: "https://hooks.slack.com/services/T0/B0/x", window: "0s", wantErr: "window must be positive"},
+		{name: "unknown kind", kind: "email", wantErr: "failure alert kind"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+			t.Setenv("FAILURE_ALERT_KIND", tt.kind)
+			t.Setenv("FAILURE_ALERT_URL", tt.url)
+			t.Setenv("FAILURE_ALERT_ROUTING_KEY", tt.routingKey)
+			if tt.window != "" {
+				t.Setenv("FAILURE_ALERT_WINDOW", tt.window)
+			}
+
+			cfg, err := LoadConfig()
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, strings.ToLower(tt.kind), cfg.FailureAlert.Kind)
+			assert.Equal(t, time.Minute, cfg.FailureAlert.Window)
+		})
+	}
+}
+
+func TestConfig_AdaptiveTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "disabled by default"},
+		{name: "enabled with defaults", env: map[string]string{"HTTP_CLIENT_ADAPTIVE_TIMEOUT_ENABLED": "true"}},
+		{name: "factor below 1", env: map[string]string{
+			"HTTP_CLIENT_ADAPTIVE_TIMEOUT_ENABLED": "true", "HTTP_CLIENT_ADAPTIVE_TIMEOUT_FACTOR": "0.5",
+		}, wantErr: "factor must be at least 1"},
+		{name: "floor above the timeout", env: map[string]string{
+			"HTTP_CLIENT_ADAPTIVE_TIMEOUT_ENABLED": "true", "HTTP_CLIENT_ADAPTIVE_TIMEOUT_FLOOR": "1m",
+		}, wantErr: "floor must be positive and at most the timeout"},
+		{name: "window smaller than min samples", env: map[string]string{
+			"HTTP_CLIENT_ADAPTIVE_TIMEOUT_ENABLED": "true", "HTTP_CLIENT_ADAPTIVE_TIMEOUT_WINDOW": "10",
+		}, wantErr: "window at least that large"},
+		{name: "invalid settings ignored while disabled", env: map[string]string{"HTTP_CLIENT_ADAPTIVE_TIMEOUT_FACTOR": "0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := LoadConfig()
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.env["HTTP_CLIENT_ADAPTIVE_TIMEOUT_ENABLED"] == "true", cfg.HTTPClient.AdaptiveTimeout.Enabled)
+			assert.Equal(t, time.Second, cfg.HTTPClient.AdaptiveTimeout.Floor)
+			assert.Equal(t, 20, cfg.HTTPClient.AdaptiveTimeout.MinSamples)
+			assert.Equal(t, 100, cfg.HTTPClient.AdaptiveTimeout.Window)
+		})
+	}
+}
+
+func TestConfig_MaxConcurrentProbes(t *testing.T) {
+	t.Run("should allow 4 concurrent probes by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, cfg.HTTPServer.MaxConcurrentProbes)
+	})
+
+	t.Run("should reject a negative limit", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("HTTP_SERVER_MAX_CONCURRENT_PROBES", "-1")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "HTTP server max concurrent probes must not be negative")
+	})
+}
+
+func TestConfig_QueueBackup(t *testing.T) {
+	t.Run("should disable backups by default", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+
+		cfg, err := LoadConfig()
+
+		require.NoError(t, err)
+		assert.Empty(t, cfg.QueueBackup.Dir)
+		assert.Equal(t, time.Hour, cfg.QueueBackup.Interval)
+	})
+
+	t.Run("should reject an enabled backup without an interval", func(t *testing.T) {
+		t.Setenv("CONFIG_FILES", filepath.Join(t.TempDir(), "missing.env"))
+		t.Setenv("QUEUE_BACKUP_DIR", t.TempDir())
+		t.Setenv("QUEUE_BACKUP_INTERVAL", "0s")
+
+		_, err := LoadConfig()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "queue backup interval and batch size must be positive")
+	})
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyReload copies the settings that are safe to change at runtime from next into c:
+// the log level, worker poll intervals and the retry max delay. It returns a description
+// of each applied change, and the names of sections whose other changes need a restart
+// and were left untouched.
+func (c *Config) ApplyReload(next *Config) (changed, ignored []string) {
+	if next.Log.Level != c.Log.Level {
+		changed = append(changed, fmt.Sprintf("log.level: %s -> %s", c.Log.Level, next.Log.Level))
+		c.Log.Level = next.Log.Level
+	}
+
+	if next.Retry.MaxDelay != c.Retry.MaxDelay {
+		changed = append(changed, fmt.Sprintf("retry.max_delay: %s -> %s", c.Retry.MaxDelay, next.Retry.MaxDelay))
+		c.Retry.MaxDelay = next.Retry.MaxDelay
+	}
+
+	// Poll intervals are matched by position; adding or removing workers needs a restart
+	if sameWorkerLayout(c.WorkerPool.Workers, next.WorkerPool.Workers) {
+		// Copy first: the worker slice may be shared with a running pool's config
+		c.WorkerPool.Workers = append([]WorkerConfig(nil), c.WorkerPool.Workers...)
+		for i, worker := range next.WorkerPool.Workers {
+			current := &c.WorkerPool.Workers[i]
+			if worker.PollInterval != current.PollInterval {
+				changed = append(changed, fmt.Sprintf("worker_pool.workers[%d].poll_interval: %s -> %s",
+					i, current.PollInterval, worker.PollInterval))
+				current.PollInterval = worker.PollInterval
+			}
+		}
+	}
+
+	// Anything still different is not reloadable
+	sections := []struct {
+		name          string
+		current, next interface{}
+	}{
+		{"database", c.Database, next.Database},
+		{"http_client", c.HTTPClient, next.HTTPClient},
+		{"http_server", c.HTTPServer, next.HTTPServer},
+		{"worker_pool", c.WorkerPool, next.WorkerPool},
+		{"health", c.Health, next.Health},
+		{"reconciliation", c.Reconciliation, next.Reconciliation},
+		{"resources", c.Resources, next.Resources},
+		{"retry", c.Retry, next.Retry},
+		{"log", c.Log, next.Log},
+		{"backpressure", c.Backpressure, next.Backpressure},
+		{"claim", c.Claim, next.Claim},
+		{"config_lookup", c.ConfigLookup, next.ConfigLookup},
+		{"db_health", c.DBHealth, next.DBHealth},
+		{"failure_alert", c.FailureAlert, next.FailureAlert},
+	}
+	for _, section := range sections {
+		if !reflect.DeepEqual(section.current, section.next) {
+			ignored = append(ignored, section.name)
+		}
+	}
+
+	return changed, ignored
+}
+
+// sameWorkerLayout reports whether two worker lists have the same retry level at each position
+func sameWorkerLayout(current, next []WorkerConfig) bool {
+	if len(current) != len(next) {
+		return false
+	}
+	for i := range current {
+		if current[i].RetryLevel != next[i].RetryLevel {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ApplyReload(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Database:   DatabaseConfig{Host: "db-a", Port: 5432, User: "postgres", DBName: "webhooks"},
+			HTTPServer: HTTPServerConfig{Port: 8080},
+			WorkerPool: GetDefaultWorkerPoolConfig(),
+			Retry:      RetryConfig{MaxDelay: 6 * time.Hour},
+			Log:        LogConfig{Level: "info"},
+		}
+	}
+
+	t.Run("should apply only the reloadable settings", func(t *testing.T) {
+		current := newConfig()
+		next := newConfig()
+		next.Log.Level = "debug"
+		next.Retry.MaxDelay = 2 * time.Hour
+		next.WorkerPool.Workers[0].PollInterval = time.Second
+		next.Database.Host = "db-b"
+		next.HTTPServer.Port = 9090
+
+		changed, ignored := current.ApplyReload(next)
+
+		assert.Len(t, changed, 3)
+		assert.ElementsMatch(t, []string{"database", "http_server"}, ignored)
+
+		assert.Equal(t, "debug", current.Log.Level)
+		assert.Equal(t, 2*time.Hour, current.Retry.MaxDelay)
+		assert.Equal(t, time.Second, current.WorkerPool.Workers[0].PollInterval)
+		assert.Equal(t, "db-a", current.Database.Host)
+		assert.Equal(t, 8080, current.HTTPServer.Port)
+	})
+
+	t.Run("should not mutate a worker slice shared with the previous config", func(t *testing.T) {
+		current := newConfig()
+		shared := current.WorkerPool
+		next := newConfig()
+		next.WorkerPool.Workers[0].PollInterval = time.Second
+
+		current.ApplyReload(next)
+
+		assert.Equal(t, 5*time.Second, shared.Workers[0].PollInterval)
+	})
+
+	t.Run("should ignore a changed worker layout", func(t *testing.T) {
+		current := newConfig()
+		next := newConfig()
+		next.WorkerPool.Workers = next.WorkerPool.Workers[:2]
+
+		changed, ignored := current.ApplyReload(next)
+
+		assert.Empty(t, changed)
+		assert.Equal(t, []string{"worker_pool"}, ignored)
+		assert.Len(t, current.WorkerPool.Workers, 9)
+	})
+
+	t.Run("should report nothing when the configuration is unchanged", func(t *testing.T) {
+		changed, ignored := newConfig().ApplyReload(newConfig())
+
+		assert.Empty(t, changed)
+		assert.Empty(t, ignored)
+	})
+}
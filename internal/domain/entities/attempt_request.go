@@ -0,0 +1,10 @@
+package entities
+
+// AttemptRequest is what was sent for one delivery attempt, recorded to debug rejected deliveries
+type AttemptRequest struct {
+	// Body is the payload before any compression; empty for bodyless requests
+	Body string
+
+	// Headers are the headers sent, with credentials and signatures redacted
+	Headers map[string]string
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+// FailureNotification describes a permanently failed webhook for on-call alerting
+type FailureNotification struct {
+	QueueID        string          `json:"queue_id"`
+	EventType      enums.EventType `json:"event_type"`
+	ConfigID       int64           `json:"config_id"`
+	ConfigName     string          `json:"config_name,omitempty"`
+	RetryCount     int             `json:"retry_count"`
+	LastHTTPStatus int             `json:"last_http_status,omitempty"`
+	Reason         string          `json:"reason"`
+	FailedAt       time.Time       `json:"failed_at"`
+}
+
+// NewFailureNotification builds the notification for a webhook that permanently failed for reason
+func NewFailureNotification(webhook *WebhookQueue, reason string, failedAt time.Time) *FailureNotification {
+	notification := &FailureNotification{
+		QueueID:        webhook.QueueID.String(),
+		EventType:      webhook.EventType,
+		ConfigID:       webhook.ConfigID,
+		RetryCount:     webhook.RetryCount,
+		LastHTTPStatus: webhook.LastHTTPStatus,
+		Reason:         reason,
+		FailedAt:       failedAt,
+	}
+	if webhook.Config != nil {
+		notification.ConfigName = webhook.Config.Name
+	}
+	return notification
+}
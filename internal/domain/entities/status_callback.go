@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+// StatusCallbackEvent names the terminal transition a status callback reports
+type StatusCallbackEvent string
+
+const (
+	// StatusCallbackSucceeded is sent to a config's OnSuccessURL when a webhook is delivered
+	StatusCallbackSucceeded StatusCallbackEvent = "webhook.succeeded"
+
+	// StatusCallbackFailed is sent to a config's OnFailureURL when a webhook permanently fails
+	StatusCallbackFailed StatusCallbackEvent = "webhook.failed"
+)
+
+// StatusCallback is the payload posted to a config's on-success or on-failure URL
+type StatusCallback struct {
+	Event          StatusCallbackEvent `json:"event"`
+	QueueID        string              `json:"queue_id"`
+	EventType      enums.EventType     `json:"event_type"`
+	EventID        string              `json:"event_id"`
+	ConfigID       int64               `json:"config_id"`
+	RetryCount     int                 `json:"retry_count"`
+	LastHTTPStatus int                 `json:"last_http_status,omitempty"`
+	Error          string              `json:"error,omitempty"` // Why the webhook failed; empty on success
+	OccurredAt     time.Time           `json:"occurred_at"`
+}
+
+// NewStatusCallback builds the status callback for a webhook's terminal transition
+func NewStatusCallback(event StatusCallbackEvent, webhook *WebhookQueue, reason string, occurredAt time.Time) *StatusCallback {
+	return &StatusCallback{
+		Event:          event,
+		QueueID:        webhook.QueueID.String(),
+		EventType:      webhook.EventType,
+		EventID:        webhook.EventID,
+		ConfigID:       webhook.ConfigID,
+		RetryCount:     webhook.RetryCount,
+		LastHTTPStatus: webhook.LastHTTPStatus,
+		Error:          reason,
+		OccurredAt:     occurredAt,
+	}
+}
+
+// StatusCallbackURL returns where to send the callback for event, or "" when the config sets none
+func (c *WebhookConfig) StatusCallbackURL(event StatusCallbackEvent) string {
+	if c == nil {
+		return ""
+	}
+	switch event {
+	case StatusCallbackSucceeded:
+		return c.OnSuccessURL
+	case StatusCallbackFailed:
+		return c.OnFailureURL
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"time"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+// TransitionEventType names a step in a webhook's lifecycle reported to audit consumers
+type TransitionEventType string
+
+const (
+	// TransitionCreated is published when a webhook is queued
+	TransitionCreated TransitionEventType = "webhook.created"
+
+	// TransitionProcessingStarted is published when a worker starts processing a claimed webhook
+	TransitionProcessingStarted TransitionEventType = "webhook.processing_started"
+
+	// TransitionAttemptRecorded is published once a delivery attempt's outcome has been stored
+	TransitionAttemptRecorded TransitionEventType = "webhook.attempt_recorded"
+
+	// TransitionRetryScheduled is published when a webhook is put back to PENDING for a later attempt
+	TransitionRetryScheduled TransitionEventType = "webhook.retry_scheduled"
+
+	// TransitionCompleted is published when a webhook is delivered
+	TransitionCompleted TransitionEventType = "webhook.completed"
+
+	// TransitionFailed is published when a webhook permanently fails
+	TransitionFailed TransitionEventType = "webhook.failed"
+
+	// TransitionCancelled is published when an operator stops a webhook's retries
+	TransitionCancelled TransitionEventType = "webhook.cancelled"
+)
+
+// TransitionEvent is the lightweight audit record of one webhook state transition
+// OldStatus is empty for TransitionCreated; both statuses are equal for TransitionAttemptRecorded
+type TransitionEvent struct {
+	Type       TransitionEventType `json:"type"`
+	QueueID    string              `json:"queue_id"`
+	OldStatus  enums.WebhookStatus `json:"old_status,omitempty"`
+	NewStatus  enums.WebhookStatus `json:"new_status"`
+	RetryCount int                 `json:"retry_count"`
+	OccurredAt time.Time           `json:"occurred_at"`
+}
+
+// NewTransitionEvent builds the event for webhook moving from oldStatus to newStatus
+func NewTransitionEvent(eventType TransitionEventType, webhook *WebhookQueue, oldStatus, newStatus enums.WebhookStatus, occurredAt time.Time) *TransitionEvent {
+	return &TransitionEvent{
+		Type:       eventType,
+		QueueID:    webhook.QueueID.String(),
+		OldStatus:  oldStatus,
+		NewStatus:  newStatus,
+		RetryCount: webhook.RetryCount,
+		OccurredAt: occurredAt,
+	}
+}
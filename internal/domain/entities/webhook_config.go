@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"webhook-processor/internal/domain/enums"
@@ -16,4 +18,306 @@ type WebhookConfig struct {
 	TimeoutMs  int             `json:"timeout_ms"`
 	CreatedAt  time.Time       `json:"created_at"`
 	UpdatedAt  time.Time       `json:"updated_at"`
+
+	// StatusOutcomes overrides the default 2xx=success handling for specific status codes
+	StatusOutcomes map[int]enums.ResponseOutcome `json:"status_outcomes,omitempty"`
+
+	// Transport selects a dedicated HTTP transport; nil uses the shared default client
+	Transport *TransportSettings `json:"transport,omitempty"`
+
+	// CompressRequest gzips request bodies larger than the client's compression threshold
+	CompressRequest bool `json:"compress_request"`
+
+	// DeliveryWindow limits when deliveries may be sent; nil means any time
+	DeliveryWindow *DeliveryWindow `json:"delivery_window,omitempty"`
+
+	// UseLiveURL delivers to the config's current URL instead of the one pinned on the queue row at creation
+	UseLiveURL bool `json:"use_live_url"`
+
+	// AcceptHeader overrides the Accept header sent with deliveries; nil sends the default
+	// and an empty string omits the header
+	AcceptHeader *string `json:"accept_header,omitempty"`
+
+	// Headers are sent with every delivery, e.g. a receiver's bearer token or tenant ID; an empty value
+	// omits the header, and headers the processor controls cannot be overridden
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// IsDefault marks the config used for creates of its event type that don't name a config
+	IsDefault bool `json:"is_default"`
+
+	// MaxRetries sets the retry limit of this config's webhooks, up to enums.MaxConfigurableRetries; nil uses
+	// enums.MaxRetryAttempts and 0 fails a webhook after its first attempt. Levels above the default need
+	// workers configured for them
+	MaxRetries *int `json:"max_retries,omitempty"`
+
+	// RetryScheduleMs replaces the global backoff with the delay in milliseconds before each retry;
+	// its length also caps the config's retries, and an invalid schedule is ignored
+	RetryScheduleMs []int `json:"retry_schedule_ms,omitempty"`
+
+	// Protocol selects HTTP or gRPC delivery; empty means HTTP
+	Protocol enums.DeliveryProtocol `json:"protocol,omitempty"`
+
+	// WrapPayload sends the event in the standard JSON envelope instead of the stored payload
+	WrapPayload bool `json:"wrap_payload"`
+
+	// HTTPMethod is the method deliveries are sent with; empty means DefaultHTTPMethod
+	HTTPMethod string `json:"http_method,omitempty"`
+
+	// PayloadTemplate renders the request body with text/template instead of sending the stored payload;
+	// nil sends the stored payload
+	PayloadTemplate *string `json:"payload_template,omitempty"`
+
+	// SigningSecret signs each HTTP delivery with HMAC-SHA256 of its body; empty sends unsigned requests
+	SigningSecret string `json:"-"`
+
+	// Idempotent marks a receiver that tolerates the same delivery twice, which hedging requires
+	Idempotent bool `json:"idempotent"`
+
+	// OnSuccessURL and OnFailureURL receive a best-effort status callback when a webhook is delivered
+	// or permanently fails; empty sends none
+	OnSuccessURL string `json:"on_success_url,omitempty"`
+	OnFailureURL string `json:"on_failure_url,omitempty"`
+
+	// HedgeAfterMs sends a second, concurrent first attempt when the first has not answered in time;
+	// nil or 0 disables hedging, and it only applies to idempotent configs
+	HedgeAfterMs *int `json:"hedge_after_ms,omitempty"`
+
+	// FirstRetryDelayMs replaces the global backoff's delay before the first retry, without jitter, so
+	// critical configs can retry within seconds; nil keeps the default and a retry schedule takes precedence
+	FirstRetryDelayMs *int `json:"first_retry_delay_ms,omitempty"`
+
+	// AmbiguousErrorPolicy decides whether errors that may follow a processed delivery are retried;
+	// empty retries them
+	AmbiguousErrorPolicy enums.AmbiguousErrorPolicy `json:"ambiguous_error_policy,omitempty"`
+}
+
+// DefaultAcceptHeader is the Accept header sent when a config does not override it
+const DefaultAcceptHeader = "application/json"
+
+// Accept returns the Accept header to send for this config, or "" when it should be omitted
+func (c *WebhookConfig) Accept() string {
+	if c == nil || c.AcceptHeader == nil {
+		return DefaultAcceptHeader
+	}
+	return *c.AcceptHeader
+}
+
+// DefaultHTTPMethod is the method deliveries use when a config does not set one
+const DefaultHTTPMethod = "POST"
+
+// Method returns the HTTP method this config's webhooks are delivered with
+func (c *WebhookConfig) Method() string {
+	if c == nil || c.HTTPMethod == "" {
+		return DefaultHTTPMethod
+	}
+	return strings.ToUpper(c.HTTPMethod)
+}
+
+// DeliveryProtocol returns the protocol this config's webhooks are delivered with, defaulting to HTTP
+func (c *WebhookConfig) DeliveryProtocol() enums.DeliveryProtocol {
+	if c == nil || c.Protocol == "" {
+		return enums.DeliveryProtocolHTTP
+	}
+	return c.Protocol
+}
+
+// RetryLimit returns how many retries this config's webhooks may use: its MaxRetries, or the default
+// maximum when unset, never more than the configurable maximum or the length of a valid retry schedule
+func (c *WebhookConfig) RetryLimit() int {
+	if c == nil {
+		return enums.MaxRetryAttempts
+	}
+	limit := enums.MaxRetryAttempts
+	if c.MaxRetries != nil {
+		limit = min(max(*c.MaxRetries, 0), enums.MaxConfigurableRetries)
+	}
+	if c.hasRetrySchedule() && len(c.RetryScheduleMs) < limit {
+		limit = len(c.RetryScheduleMs)
+	}
+	return limit
+}
+
+// MinRetryScheduleDelay is the shortest delay a retry schedule entry may have
+const MinRetryScheduleDelay = time.Second
+
+// ValidateRetrySchedule checks that the schedule has no more entries than the configurable maximum retries,
+// that every delay is at least MinRetryScheduleDelay and that no delay is shorter than the one before it
+func (c *WebhookConfig) ValidateRetrySchedule() error {
+	if len(c.RetryScheduleMs) > enums.MaxConfigurableRetries {
+		return fmt.Errorf("retry schedule has %d entries, more than the maximum of %d retries",
+			len(c.RetryScheduleMs), enums.MaxConfigurableRetries)
+	}
+	for i, delayMs := range c.RetryScheduleMs {
+		if time.Duration(delayMs)*time.Millisecond < MinRetryScheduleDelay {
+			return fmt.Errorf("retry schedule delay %d is %dms, below the minimum of %s", i, delayMs, MinRetryScheduleDelay)
+		}
+		if i > 0 && delayMs < c.RetryScheduleMs[i-1] {
+			return fmt.Errorf("retry schedule delay %d is %dms, shorter than the %dms before it", i, delayMs, c.RetryScheduleMs[i-1])
+		}
+	}
+	return nil
+}
+
+// RetryDelay returns the scheduled delay after a failed attempt at retryCount, or false when the
+// config has no valid schedule and the global backoff applies
+// Levels past the end of the schedule, reached only by throttled reschedules, reuse its last delay
+func (c *WebhookConfig) RetryDelay(retryCount int) (time.Duration, bool) {
+	if !c.hasRetrySchedule() || retryCount < 0 {
+		return 0, false
+	}
+	retryCount = min(retryCount, len(c.RetryScheduleMs)-1)
+	return time.Duration(c.RetryScheduleMs[retryCount]) * time.Millisecond, true
+}
+
+// MinFirstRetryDelay is the shortest first-retry delay a config may set
+const MinFirstRetryDelay = time.Second
+
+// ValidateFirstRetryDelay checks that a first-retry delay, when set, is at least MinFirstRetryDelay
+func (c *WebhookConfig) ValidateFirstRetryDelay() error {
+	if c.FirstRetryDelayMs == nil {
+		return nil
+	}
+	if time.Duration(*c.FirstRetryDelayMs)*time.Millisecond < MinFirstRetryDelay {
+		return fmt.Errorf("first retry delay is %dms, below the minimum of %s", *c.FirstRetryDelayMs, MinFirstRetryDelay)
+	}
+	return nil
+}
+
+// FirstRetryDelay returns the config's delay before the first retry, or false when it sets no valid one
+func (c *WebhookConfig) FirstRetryDelay() (time.Duration, bool) {
+	if c == nil || c.FirstRetryDelayMs == nil || c.ValidateFirstRetryDelay() != nil {
+		return 0, false
+	}
+	return time.Duration(*c.FirstRetryDelayMs) * time.Millisecond, true
+}
+
+// hasRetrySchedule reports whether the config sets a retry schedule that passes validation
+func (c *WebhookConfig) hasRetrySchedule() bool {
+	return c != nil && len(c.RetryScheduleMs) > 0 && c.ValidateRetrySchedule() == nil
+}
+
+// HedgeAfter returns how long a first attempt may go unanswered before it is hedged, or 0 when it is never hedged
+func (c *WebhookConfig) HedgeAfter() time.Duration {
+	if c == nil || !c.Idempotent || c.HedgeAfterMs == nil || *c.HedgeAfterMs <= 0 {
+		return 0
+	}
+	return time.Duration(*c.HedgeAfterMs) * time.Millisecond
+}
+
+// RetriesAmbiguousErrors reports whether a delivery that may already have been processed is retried
+// Unset or unrecognized policies retry, as every config did before the policy existed
+func (c *WebhookConfig) RetriesAmbiguousErrors() bool {
+	if c == nil {
+		return true
+	}
+	switch c.AmbiguousErrorPolicy {
+	case enums.AmbiguousErrorPolicyFail:
+		return false
+	case enums.AmbiguousErrorPolicyRetryIfIdempotent:
+		return c.Idempotent
+	default:
+		return true
+	}
+}
+
+// OutcomeForStatus returns the configured outcome for a status code, if one is mapped
+func (c *WebhookConfig) OutcomeForStatus(statusCode int) (enums.ResponseOutcome, bool) {
+	outcome, ok := c.StatusOutcomes[statusCode]
+	return outcome, ok
+}
+
+// TransportSettings holds per-config settings that affect the outbound HTTP transport
+// Configs with identical settings share a client and its connection pool
+type TransportSettings struct {
+	ProxyURL                string `json:"proxy_url,omitempty"`
+	TLSMinVersion           string `json:"tls_min_version,omitempty"` // "1.2" or "1.3"
+	DialTimeoutMs           int    `json:"dial_timeout_ms,omitempty"`
+	TLSHandshakeTimeoutMs   int    `json:"tls_handshake_timeout_ms,omitempty"`
+	ResponseHeaderTimeoutMs int    `json:"response_header_timeout_ms,omitempty"`
+	IdleConnTimeoutMs       int    `json:"idle_conn_timeout_ms,omitempty"`
+	DisableKeepAlives       bool   `json:"disable_keep_alives,omitempty"`
+}
+
+// HourRange is a half-open range of hours [Start, End) within a day, 0 <= Start < End <= 24
+type HourRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// DeliveryWindow restricts deliveries to certain hours and days in a timezone
+type DeliveryWindow struct {
+	Timezone string         `json:"timezone"`        // IANA name, empty means UTC
+	Days     []time.Weekday `json:"days,omitempty"`  // Empty means every day
+	Hours    []HourRange    `json:"hours,omitempty"` // Empty means all day
+}
+
+// Validate checks the timezone and hour ranges
+func (w *DeliveryWindow) Validate() error {
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("invalid delivery window timezone %q: %w", w.Timezone, err)
+	}
+	for _, day := range w.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("invalid delivery window day: %d", day)
+		}
+	}
+	for _, hours := range w.Hours {
+		if hours.Start < 0 || hours.End > 24 || hours.Start >= hours.End {
+			return fmt.Errorf("invalid delivery window hours: %d-%d", hours.Start, hours.End)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether a delivery at t falls inside the window
+func (w *DeliveryWindow) Allows(t time.Time) (bool, error) {
+	if err := w.Validate(); err != nil {
+		return false, err
+	}
+	loc, _ := time.LoadLocation(w.Timezone)
+	return w.allows(t.In(loc)), nil
+}
+
+// NextOpening returns the earliest time after t at which the window is open
+func (w *DeliveryWindow) NextOpening(t time.Time) (time.Time, error) {
+	if err := w.Validate(); err != nil {
+		return time.Time{}, err
+	}
+	loc, _ := time.LoadLocation(w.Timezone)
+	local := t.In(loc)
+
+	// Windows open on hour boundaries, so checking each following hour for a week covers every case
+	for i := 1; i <= 8*24; i++ {
+		candidate := time.Date(local.Year(), local.Month(), local.Day(), local.Hour()+i, 0, 0, 0, loc)
+		if w.allows(candidate) {
+			return candidate.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("delivery window never opens")
+}
+
+// allows checks a time already converted to the window's timezone
+func (w *DeliveryWindow) allows(local time.Time) bool {
+	if len(w.Days) > 0 {
+		dayAllowed := false
+		for _, day := range w.Days {
+			if local.Weekday() == day {
+				dayAllowed = true
+				break
+			}
+		}
+		if !dayAllowed {
+			return false
+		}
+	}
+
+	if len(w.Hours) == 0 {
+		return true
+	}
+	for _, hours := range w.Hours {
+		if local.Hour() >= hours.Start && local.Hour() < hours.End {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,50 @@
+package entities
+
+import "time"
+
+// successRateWeight is how far each new outcome moves the rolling success rate
+const successRateWeight = 0.1
+
+// WebhookConfigStats is a per-config rollup of terminal delivery outcomes
+type WebhookConfigStats struct {
+	ConfigID       int64      `json:"config_id"`
+	TotalDelivered int64      `json:"total_delivered"`
+	TotalFailed    int64      `json:"total_failed"`
+	LastSuccessAt  *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt  *time.Time `json:"last_failure_at,omitempty"`
+
+	// SuccessRate is an exponentially weighted average of outcomes (1 = delivered) favouring recent ones
+	SuccessRate float64   `json:"success_rate"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// RecordOutcome folds one terminal outcome into the rollup
+func (s *WebhookConfigStats) RecordOutcome(delivered bool, at time.Time) {
+	outcome := 0.0
+	if delivered {
+		s.TotalDelivered++
+		s.LastSuccessAt = &at
+		outcome = 1
+	} else {
+		s.TotalFailed++
+		s.LastFailureAt = &at
+	}
+
+	if s.TotalDelivered+s.TotalFailed == 1 {
+		s.SuccessRate = outcome
+	} else {
+		s.SuccessRate += successRateWeight * (outcome - s.SuccessRate)
+	}
+	s.UpdatedAt = at
+}
+
+// RetractOutcome takes back one terminal outcome an operator has since undone, e.g. a FAILED webhook
+// requeued; the success rate and last outcome times stay as they are, since the average cannot be unwound
+func (s *WebhookConfigStats) RetractOutcome(delivered bool, at time.Time) {
+	if delivered && s.TotalDelivered > 0 {
+		s.TotalDelivered--
+	} else if !delivered && s.TotalFailed > 0 {
+		s.TotalFailed--
+	}
+	s.UpdatedAt = at
+}
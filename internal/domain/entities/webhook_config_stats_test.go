@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookConfigStats_RecordOutcome(t *testing.T) {
+	t.Run("should count a success", func(t *testing.T) {
+		at := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		stats := &WebhookConfigStats{ConfigID: 1}
+
+		stats.RecordOutcome(true, at)
+
+		assert.Equal(t, int64(1), stats.TotalDelivered)
+		assert.Equal(t, int64(0), stats.TotalFailed)
+		require.NotNil(t, stats.LastSuccessAt)
+		assert.Equal(t, at, *stats.LastSuccessAt)
+		assert.Nil(t, stats.LastFailureAt)
+		assert.Equal(t, 1.0, stats.SuccessRate)
+	})
+
+	t.Run("should count a failure after a success", func(t *testing.T) {
+		succeededAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		failedAt := succeededAt.Add(time.Minute)
+		stats := &WebhookConfigStats{ConfigID: 1}
+
+		stats.RecordOutcome(true, succeededAt)
+		stats.RecordOutcome(false, failedAt)
+
+		assert.Equal(t, int64(1), stats.TotalDelivered)
+		assert.Equal(t, int64(1), stats.TotalFailed)
+		assert.Equal(t, succeededAt, *stats.LastSuccessAt)
+		assert.Equal(t, failedAt, *stats.LastFailureAt)
+		assert.InDelta(t, 0.9, stats.SuccessRate, 1e-9)
+		assert.Equal(t, failedAt, stats.UpdatedAt)
+	})
+
+	t.Run("should weight recent outcomes more heavily", func(t *testing.T) {
+		at := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		stats := &WebhookConfigStats{ConfigID: 1}
+
+		for i := 0; i < 50; i++ {
+			stats.RecordOutcome(false, at)
+		}
+		for i := 0; i < 10; i++ {
+			stats.RecordOutcome(true, at)
+		}
+
+		assert.Equal(t, int64(10), stats.TotalDelivered)
+		assert.Equal(t, int64(50), stats.TotalFailed)
+		assert.Greater(t, stats.SuccessRate, 0.5, "lifetime rate is 1/6 but recent deliveries all succeeded")
+	})
+}
+
+func TestWebhookConfigStats_RetractOutcome(t *testing.T) {
+	t.Run("should take back a counted failure", func(t *testing.T) {
+		failedAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		requeuedAt := failedAt.Add(time.Hour)
+		stats := &WebhookConfigStats{ConfigID: 1}
+		stats.RecordOutcome(true, failedAt)
+		stats.RecordOutcome(false, failedAt)
+		rate := stats.SuccessRate
+
+		stats.RetractOutcome(false, requeuedAt)
+
+		assert.Equal(t, int64(1), stats.TotalDelivered)
+		assert.Equal(t, int64(0), stats.TotalFailed)
+		assert.Equal(t, failedAt, *stats.LastFailureAt)
+		assert.Equal(t, rate, stats.SuccessRate)
+		assert.Equal(t, requeuedAt, stats.UpdatedAt)
+	})
+
+	t.Run("should not count below zero", func(t *testing.T) {
+		stats := &WebhookConfigStats{ConfigID: 1}
+
+		stats.RetractOutcome(false, time.Now())
+		stats.RetractOutcome(true, time.Now())
+
+		assert.Equal(t, int64(0), stats.TotalFailed)
+		assert.Equal(t, int64(0), stats.TotalDelivered)
+	})
+}
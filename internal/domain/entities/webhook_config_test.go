@@ -0,0 +1,281 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+func TestWebhookConfig_RetryLimit(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name     string
+		config   *WebhookConfig
+		expected int
+	}{
+		{name: "nil config uses the global maximum", config: nil, expected: enums.MaxRetryAttempts},
+		{name: "no override uses the global maximum", config: &WebhookConfig{}, expected: enums.MaxRetryAttempts},
+		{name: "zero disables retries", config: &WebhookConfig{MaxRetries: intPtr(0)}, expected: 0},
+		{name: "reduced override applies", config: &WebhookConfig{MaxRetries: intPtr(2)}, expected: 2},
+		{name: "override above the default maximum applies", config: &WebhookConfig{MaxRetries: intPtr(10)}, expected: 10},
+		{name: "override above the configurable maximum is capped", config: &WebhookConfig{MaxRetries: intPtr(50)}, expected: enums.MaxConfigurableRetries},
+		{name: "retry schedule caps a raised override", config: &WebhookConfig{MaxRetries: intPtr(10), RetryScheduleMs: []int{1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000}}, expected: 8},
+		{name: "retry schedule length caps retries", config: &WebhookConfig{RetryScheduleMs: []int{10000, 30000}}, expected: 2},
+		{name: "lower override wins over a retry schedule", config: &WebhookConfig{MaxRetries: intPtr(1), RetryScheduleMs: []int{10000, 30000}}, expected: 1},
+		{name: "invalid retry schedule is ignored", config: &WebhookConfig{RetryScheduleMs: []int{30000, 10000}}, expected: enums.MaxRetryAttempts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.RetryLimit())
+		})
+	}
+}
+
+func TestWebhookConfig_HedgeAfter(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name     string
+		config   *WebhookConfig
+		expected time.Duration
+	}{
+		{name: "nil config never hedges", config: nil, expected: 0},
+		{name: "no delay never hedges", config: &WebhookConfig{Idempotent: true}, expected: 0},
+		{name: "zero delay never hedges", config: &WebhookConfig{Idempotent: true, HedgeAfterMs: intPtr(0)}, expected: 0},
+		{name: "non-idempotent config never hedges", config: &WebhookConfig{HedgeAfterMs: intPtr(200)}, expected: 0},
+		{name: "idempotent config hedges after its delay", config: &WebhookConfig{Idempotent: true, HedgeAfterMs: intPtr(200)}, expected: 200 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.HedgeAfter())
+		})
+	}
+}
+
+func TestWebhookConfig_RetriesAmbiguousErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *WebhookConfig
+		expected bool
+	}{
+		{name: "nil config retries", config: nil, expected: true},
+		{name: "unset policy retries", config: &WebhookConfig{}, expected: true},
+		{name: "retry policy retries", config: &WebhookConfig{AmbiguousErrorPolicy: enums.AmbiguousErrorPolicyRetry}, expected: true},
+		{name: "fail policy never retries", config: &WebhookConfig{AmbiguousErrorPolicy: enums.AmbiguousErrorPolicyFail, Idempotent: true}, expected: false},
+		{name: "retry-if-idempotent retries an idempotent receiver", config: &WebhookConfig{AmbiguousErrorPolicy: enums.AmbiguousErrorPolicyRetryIfIdempotent, Idempotent: true}, expected: true},
+		{name: "retry-if-idempotent fails a non-idempotent receiver", config: &WebhookConfig{AmbiguousErrorPolicy: enums.AmbiguousErrorPolicyRetryIfIdempotent}, expected: false},
+		{name: "unrecognized policy retries", config: &WebhookConfig{AmbiguousErrorPolicy: "never"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.RetriesAmbiguousErrors())
+		})
+	}
+}
+
+func TestWebhookConfig_ValidateRetrySchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule []int
+		wantErr  string
+	}{
+		{name: "empty schedule", schedule: nil},
+		{name: "increasing delays", schedule: []int{10000, 30000, 60000}},
+		{name: "repeated delays", schedule: []int{60000, 60000}},
+		{name: "zero delay", schedule: []int{0, 30000}, wantErr: "below the minimum"},
+		{name: "negative delay", schedule: []int{10000, -1}, wantErr: "below the minimum"},
+		{name: "decreasing delays", schedule: []int{60000, 30000}, wantErr: "shorter than the 60000ms before it"},
+		{name: "too many entries", schedule: slices.Repeat([]int{1000}, enums.MaxConfigurableRetries+1), wantErr: "more than the maximum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&WebhookConfig{RetryScheduleMs: tt.schedule}).ValidateRetrySchedule()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestWebhookConfig_RetryDelay(t *testing.T) {
+	config := &WebhookConfig{RetryScheduleMs: []int{10000, 30000, 60000}}
+
+	t.Run("should return the scheduled delay for each retry level", func(t *testing.T) {
+		for retryCount, expected := range []time.Duration{10 * time.Second, 30 * time.Second, time.Minute} {
+			delay, ok := config.RetryDelay(retryCount)
+			assert.True(t, ok)
+			assert.Equal(t, expected, delay)
+		}
+	})
+
+	t.Run("should reuse the last delay past the end of the schedule", func(t *testing.T) {
+		delay, ok := config.RetryDelay(5)
+		assert.True(t, ok)
+		assert.Equal(t, time.Minute, delay)
+	})
+
+	t.Run("should fall back without a valid schedule", func(t *testing.T) {
+		for _, config := range []*WebhookConfig{nil, {}, {RetryScheduleMs: []int{500}}} {
+			_, ok := config.RetryDelay(0)
+			assert.False(t, ok)
+		}
+	})
+}
+
+func TestWebhookConfig_FirstRetryDelay(t *testing.T) {
+	delayMs := func(ms int) *int { return &ms }
+
+	tests := []struct {
+		name      string
+		config    *WebhookConfig
+		wantDelay time.Duration
+		wantOK    bool
+		wantErr   string
+	}{
+		{name: "nil config"},
+		{name: "no override", config: &WebhookConfig{}},
+		{name: "five seconds", config: &WebhookConfig{FirstRetryDelayMs: delayMs(5000)}, wantDelay: 5 * time.Second, wantOK: true},
+		{name: "at the minimum", config: &WebhookConfig{FirstRetryDelayMs: delayMs(1000)}, wantDelay: time.Second, wantOK: true},
+		{name: "below the minimum", config: &WebhookConfig{FirstRetryDelayMs: delayMs(500)}, wantErr: "below the minimum"},
+		{name: "zero", config: &WebhookConfig{FirstRetryDelayMs: delayMs(0)}, wantErr: "below the minimum"},
+		{name: "negative", config: &WebhookConfig{FirstRetryDelayMs: delayMs(-5000)}, wantErr: "below the minimum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := tt.config.FirstRetryDelay()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantDelay, delay)
+
+			if tt.config == nil {
+				return
+			}
+			err := tt.config.ValidateFirstRetryDelay()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDeliveryWindow_Allows(t *testing.T) {
+	// Weekdays 09:00-17:00 New York time
+	window := &DeliveryWindow{
+		Timezone: "America/New_York",
+		Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Hours:    []HourRange{{Start: 9, End: 17}},
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{name: "inside business hours", at: time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC), expected: true},         // Mon 10:00 EST
+		{name: "before opening", at: time.Date(2024, 1, 15, 13, 59, 0, 0, time.UTC), expected: false},              // Mon 08:59 EST
+		{name: "at closing", at: time.Date(2024, 1, 15, 22, 0, 0, 0, time.UTC), expected: false},                   // Mon 17:00 EST
+		{name: "on a weekend", at: time.Date(2024, 1, 13, 15, 0, 0, 0, time.UTC), expected: false},                 // Sat 10:00 EST
+		{name: "UTC weekday but local weekend", at: time.Date(2024, 1, 15, 3, 0, 0, 0, time.UTC), expected: false}, // Sun 22:00 EST
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := window.Allows(tt.at)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+
+	t.Run("should allow any time when days and hours are empty", func(t *testing.T) {
+		allowed, err := (&DeliveryWindow{}).Allows(time.Date(2024, 1, 13, 3, 0, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("should reject invalid windows", func(t *testing.T) {
+		_, err := (&DeliveryWindow{Timezone: "Mars/Olympus"}).Allows(time.Now())
+		assert.Error(t, err)
+
+		_, err = (&DeliveryWindow{Hours: []HourRange{{Start: 17, End: 9}}}).Allows(time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestDeliveryWindow_NextOpening(t *testing.T) {
+	window := &DeliveryWindow{
+		Timezone: "America/New_York",
+		Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Hours:    []HourRange{{Start: 9, End: 12}, {Start: 13, End: 17}},
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected time.Time
+	}{
+		{
+			name:     "later the same morning",
+			at:       time.Date(2024, 1, 15, 11, 30, 0, 0, time.UTC), // Mon 06:30 EST
+			expected: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),  // Mon 09:00 EST
+		},
+		{
+			name:     "after the lunch break",
+			at:       time.Date(2024, 1, 15, 17, 15, 0, 0, time.UTC), // Mon 12:15 EST
+			expected: time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC),  // Mon 13:00 EST
+		},
+		{
+			name:     "Friday evening to Monday morning",
+			at:       time.Date(2024, 1, 19, 23, 0, 0, 0, time.UTC), // Fri 18:00 EST
+			expected: time.Date(2024, 1, 22, 14, 0, 0, 0, time.UTC), // Mon 09:00 EST
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := window.NextOpening(tt.at)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, next)
+		})
+	}
+}
+
+func TestWebhookConfig_StatusCallbackURL(t *testing.T) {
+	config := &WebhookConfig{OnSuccessURL: "https://example.com/ok", OnFailureURL: "https://example.com/failed"}
+
+	tests := []struct {
+		name     string
+		config   *WebhookConfig
+		event    StatusCallbackEvent
+		expected string
+	}{
+		{name: "nil config sends no callback", config: nil, event: StatusCallbackSucceeded, expected: ""},
+		{name: "unset URL sends no callback", config: &WebhookConfig{}, event: StatusCallbackFailed, expected: ""},
+		{name: "success uses the on-success URL", config: config, event: StatusCallbackSucceeded, expected: "https://example.com/ok"},
+		{name: "failure uses the on-failure URL", config: config, event: StatusCallbackFailed, expected: "https://example.com/failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.StatusCallbackURL(tt.event))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"fmt"
 	"time"
 
 	"webhook-processor/internal/domain/enums"
@@ -21,67 +22,121 @@ type WebhookQueue struct {
 	ConfigID   int64  `json:"config_id"`
 	WebhookURL string `json:"webhook_url"`
 
+	// Metadata holds caller-supplied labels (e.g. tenant, source) for filtering and reporting
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Payload is the request body supplied at creation, such as the original transaction data; empty for none
+	Payload string `json:"payload,omitempty"`
+
+	// Config is attached at processing time for per-config delivery options and is not persisted
+	Config *WebhookConfig `json:"-"`
+
 	// Processing status
 	Status enums.WebhookStatus `json:"status"` // WebhookStatusPending, WebhookStatusProcessing, etc.
 
 	// Retry tracking
 	RetryCount  int       `json:"retry_count"`
 	NextRetryAt time.Time `json:"next_retry_at"`
+	// ThrottledCount counts 429 responses rescheduled without consuming a retry
+	ThrottledCount int `json:"throttled_count"`
 
 	// Individual retry attempt tracking (retry_0 through retry_6)
 	Retry0StartedAt    *time.Time `json:"retry_0_started_at,omitempty"`
 	Retry0CompletedAt  *time.Time `json:"retry_0_completed_at,omitempty"`
 	Retry0DurationMs   *int64     `json:"retry_0_duration_ms,omitempty"`
+	Retry0TimeoutMs    *int64     `json:"retry_0_timeout_ms,omitempty"`
 	Retry0HTTPStatus   *int       `json:"retry_0_http_status,omitempty"`
 	Retry0ResponseBody *string    `json:"retry_0_response_body,omitempty"`
 	Retry0Error        *string    `json:"retry_0_error,omitempty"`
+	Retry0ErrorClass   *string    `json:"retry_0_error_class,omitempty"`
+
+	Retry0RequestBody    *string           `json:"retry_0_request_body,omitempty"`
+	Retry0RequestHeaders map[string]string `json:"retry_0_request_headers,omitempty"`
 
 	Retry1StartedAt    *time.Time `json:"retry_1_started_at,omitempty"`
 	Retry1CompletedAt  *time.Time `json:"retry_1_completed_at,omitempty"`
 	Retry1DurationMs   *int64     `json:"retry_1_duration_ms,omitempty"`
+	Retry1TimeoutMs    *int64     `json:"retry_1_timeout_ms,omitempty"`
 	Retry1HTTPStatus   *int       `json:"retry_1_http_status,omitempty"`
 	Retry1ResponseBody *string    `json:"retry_1_response_body,omitempty"`
 	Retry1Error        *string    `json:"retry_1_error,omitempty"`
+	Retry1ErrorClass   *string    `json:"retry_1_error_class,omitempty"`
+
+	Retry1RequestBody    *string           `json:"retry_1_request_body,omitempty"`
+	Retry1RequestHeaders map[string]string `json:"retry_1_request_headers,omitempty"`
 
 	Retry2StartedAt    *time.Time `json:"retry_2_started_at,omitempty"`
 	Retry2CompletedAt  *time.Time `json:"retry_2_completed_at,omitempty"`
 	Retry2DurationMs   *int64     `json:"retry_2_duration_ms,omitempty"`
+	Retry2TimeoutMs    *int64     `json:"retry_2_timeout_ms,omitempty"`
 	Retry2HTTPStatus   *int       `json:"retry_2_http_status,omitempty"`
 	Retry2ResponseBody *string    `json:"retry_2_response_body,omitempty"`
 	Retry2Error        *string    `json:"retry_2_error,omitempty"`
+	Retry2ErrorClass   *string    `json:"retry_2_error_class,omitempty"`
+
+	Retry2RequestBody    *string           `json:"retry_2_request_body,omitempty"`
+	Retry2RequestHeaders map[string]string `json:"retry_2_request_headers,omitempty"`
 
 	Retry3StartedAt    *time.Time `json:"retry_3_started_at,omitempty"`
 	Retry3CompletedAt  *time.Time `json:"retry_3_completed_at,omitempty"`
 	Retry3DurationMs   *int64     `json:"retry_3_duration_ms,omitempty"`
+	Retry3TimeoutMs    *int64     `json:"retry_3_timeout_ms,omitempty"`
 	Retry3HTTPStatus   *int       `json:"retry_3_http_status,omitempty"`
 	Retry3ResponseBody *string    `json:"retry_3_response_body,omitempty"`
 	Retry3Error        *string    `json:"retry_3_error,omitempty"`
+	Retry3ErrorClass   *string    `json:"retry_3_error_class,omitempty"`
+
+	Retry3RequestBody    *string           `json:"retry_3_request_body,omitempty"`
+	Retry3RequestHeaders map[string]string `json:"retry_3_request_headers,omitempty"`
 
 	Retry4StartedAt    *time.Time `json:"retry_4_started_at,omitempty"`
 	Retry4CompletedAt  *time.Time `json:"retry_4_completed_at,omitempty"`
 	Retry4DurationMs   *int64     `json:"retry_4_duration_ms,omitempty"`
+	Retry4TimeoutMs    *int64     `json:"retry_4_timeout_ms,omitempty"`
 	Retry4HTTPStatus   *int       `json:"retry_4_http_status,omitempty"`
 	Retry4ResponseBody *string    `json:"retry_4_response_body,omitempty"`
 	Retry4Error        *string    `json:"retry_4_error,omitempty"`
+	Retry4ErrorClass   *string    `json:"retry_4_error_class,omitempty"`
+
+	Retry4RequestBody    *string           `json:"retry_4_request_body,omitempty"`
+	Retry4RequestHeaders map[string]string `json:"retry_4_request_headers,omitempty"`
 
 	Retry5StartedAt    *time.Time `json:"retry_5_started_at,omitempty"`
 	Retry5CompletedAt  *time.Time `json:"retry_5_completed_at,omitempty"`
 	Retry5DurationMs   *int64     `json:"retry_5_duration_ms,omitempty"`
+	Retry5TimeoutMs    *int64     `json:"retry_5_timeout_ms,omitempty"`
 	Retry5HTTPStatus   *int       `json:"retry_5_http_status,omitempty"`
 	Retry5ResponseBody *string    `json:"retry_5_response_body,omitempty"`
 	Retry5Error        *string    `json:"retry_5_error,omitempty"`
+	Retry5ErrorClass   *string    `json:"retry_5_error_class,omitempty"`
+
+	Retry5RequestBody    *string           `json:"retry_5_request_body,omitempty"`
+	Retry5RequestHeaders map[string]string `json:"retry_5_request_headers,omitempty"`
 
 	Retry6StartedAt    *time.Time `json:"retry_6_started_at,omitempty"`
 	Retry6CompletedAt  *time.Time `json:"retry_6_completed_at,omitempty"`
 	Retry6DurationMs   *int64     `json:"retry_6_duration_ms,omitempty"`
+	Retry6TimeoutMs    *int64     `json:"retry_6_timeout_ms,omitempty"`
 	Retry6HTTPStatus   *int       `json:"retry_6_http_status,omitempty"`
 	Retry6ResponseBody *string    `json:"retry_6_response_body,omitempty"`
 	Retry6Error        *string    `json:"retry_6_error,omitempty"`
+	Retry6ErrorClass   *string    `json:"retry_6_error_class,omitempty"`
+
+	Retry6RequestBody    *string           `json:"retry_6_request_body,omitempty"`
+	Retry6RequestHeaders map[string]string `json:"retry_6_request_headers,omitempty"`
+
+	// ExtraAttempts are the attempts at retry levels above enums.MaxRetryAttempts, which have no fixed
+	// fields, in level order
+	ExtraAttempts []WebhookAttempt `json:"retry_attempts,omitempty"`
 
 	// General tracking
 	LastError      string `json:"last_error"`
 	LastHTTPStatus int    `json:"last_http_status"`
 
+	// Processing claim, held by a worker while the webhook is PROCESSING
+	ClaimedBy      *string    `json:"claimed_by,omitempty"`
+	ClaimExpiresAt *time.Time `json:"claim_expires_at,omitempty"`
+
 	// Timestamps
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
@@ -90,7 +145,95 @@ type WebhookQueue struct {
 	DeletedAt           *time.Time `json:"deleted_at"`
 }
 
-// CanRetry checks if the webhook can be retried
+// Metadata limits keep labels small enough to index and report on
+const (
+	MaxMetadataEntries     = 16
+	MaxMetadataKeyLength   = 64
+	MaxMetadataValueLength = 256
+)
+
+// ValidateMetadata checks webhook metadata against the entry, key and value limits
+func ValidateMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxMetadataEntries {
+		return fmt.Errorf("metadata has %d entries, at most %d allowed", len(metadata), MaxMetadataEntries)
+	}
+	for key, value := range metadata {
+		if key == "" || len(key) > MaxMetadataKeyLength {
+			return fmt.Errorf("metadata key %q must be 1-%d characters", key, MaxMetadataKeyLength)
+		}
+		if len(value) > MaxMetadataValueLength {
+			return fmt.Errorf("metadata value for %q exceeds %d characters", key, MaxMetadataValueLength)
+		}
+	}
+	return nil
+}
+
+// WebhookAttempt is one recorded delivery attempt of a webhook
+type WebhookAttempt struct {
+	RetryLevel   int        `json:"retry_level"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	DurationMs   *int64     `json:"duration_ms,omitempty"`
+	TimeoutMs    *int64     `json:"timeout_ms,omitempty"`
+	HTTPStatus   *int       `json:"http_status,omitempty"`
+	ResponseBody *string    `json:"response_body,omitempty"`
+	Error        *string    `json:"error,omitempty"`
+	ErrorClass   *string    `json:"error_class,omitempty"`
+
+	// RequestBody and RequestHeaders are what was sent, recorded only when request capture is enabled;
+	// credential headers are redacted
+	RequestBody    *string           `json:"request_body,omitempty"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+}
+
+// Attempts returns the recorded attempts in retry level order, skipping levels never started
+func (w *WebhookQueue) Attempts() []WebhookAttempt {
+	levels := []WebhookAttempt{
+		{0, w.Retry0StartedAt, w.Retry0CompletedAt, w.Retry0DurationMs, w.Retry0TimeoutMs, w.Retry0HTTPStatus, w.Retry0ResponseBody, w.Retry0Error, w.Retry0ErrorClass, w.Retry0RequestBody, w.Retry0RequestHeaders},
+		{1, w.Retry1StartedAt, w.Retry1CompletedAt, w.Retry1DurationMs, w.Retry1TimeoutMs, w.Retry1HTTPStatus, w.Retry1ResponseBody, w.Retry1Error, w.Retry1ErrorClass, w.Retry1RequestBody, w.Retry1RequestHeaders},
+		{2, w.Retry2StartedAt, w.Retry2CompletedAt, w.Retry2DurationMs, w.Retry2TimeoutMs, w.Retry2HTTPStatus, w.Retry2ResponseBody, w.Retry2Error, w.Retry2ErrorClass, w.Retry2RequestBody, w.Retry2RequestHeaders},
+		{3, w.Retry3StartedAt, w.Retry3CompletedAt, w.Retry3DurationMs, w.Retry3TimeoutMs, w.Retry3HTTPStatus, w.Retry3ResponseBody, w.Retry3Error, w.Retry3ErrorClass, w.Retry3RequestBody, w.Retry3RequestHeaders},
+		{4, w.Retry4StartedAt, w.Retry4CompletedAt, w.Retry4DurationMs, w.Retry4TimeoutMs, w.Retry4HTTPStatus, w.Retry4ResponseBody, w.Retry4Error, w.Retry4ErrorClass, w.Retry4RequestBody, w.Retry4RequestHeaders},
+		{5, w.Retry5StartedAt, w.Retry5CompletedAt, w.Retry5DurationMs, w.Retry5TimeoutMs, w.Retry5HTTPStatus, w.Retry5ResponseBody, w.Retry5Error, w.Retry5ErrorClass, w.Retry5RequestBody, w.Retry5RequestHeaders},
+		{6, w.Retry6StartedAt, w.Retry6CompletedAt, w.Retry6DurationMs, w.Retry6TimeoutMs, w.Retry6HTTPStatus, w.Retry6ResponseBody, w.Retry6Error, w.Retry6ErrorClass, w.Retry6RequestBody, w.Retry6RequestHeaders},
+	}
+
+	var attempts []WebhookAttempt
+	for _, attempt := range append(levels, w.ExtraAttempts...) {
+		if attempt.StartedAt != nil {
+			attempts = append(attempts, attempt)
+		}
+	}
+	return attempts
+}
+
+// AttemptHTTPStatuses returns the HTTP statuses recorded for each attempt, in retry level order
+func (w *WebhookQueue) AttemptHTTPStatuses() []int {
+	var statuses []int
+	for _, status := range []*int{
+		w.Retry0HTTPStatus, w.Retry1HTTPStatus, w.Retry2HTTPStatus, w.Retry3HTTPStatus,
+		w.Retry4HTTPStatus, w.Retry5HTTPStatus, w.Retry6HTTPStatus,
+	} {
+		if status != nil {
+			statuses = append(statuses, *status)
+		}
+	}
+	for _, attempt := range w.ExtraAttempts {
+		if attempt.HTTPStatus != nil {
+			statuses = append(statuses, *attempt.HTTPStatus)
+		}
+	}
+	return statuses
+}
+
+// CurrentRetryLevel returns the retry level the webhook's next attempt is processed at: its retry count,
+// which selects both the worker level that claims it and the retry_N_* columns the attempt is recorded in.
+// Throttled reschedules keep the level, so it only advances when an attempt consumes a retry
+func (w *WebhookQueue) CurrentRetryLevel() int {
+	return w.RetryCount
+}
+
+// CanRetry checks if the webhook can be retried within its config's retry limit
 func (w *WebhookQueue) CanRetry() bool {
-	return w.RetryCount < enums.MaxRetryAttempts && !w.Status.IsCompleted()
+	return w.RetryCount < w.Config.RetryLimit() && !w.Status.IsCompleted()
 }
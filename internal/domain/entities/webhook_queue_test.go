@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -291,3 +293,120 @@ func BenchmarkWebhookQueue_Creation(b *testing.B) {
 		}
 	}
 }
+
+func TestWebhookQueue_AttemptHTTPStatuses(t *testing.T) {
+	t.Run("should return recorded statuses in retry level order", func(t *testing.T) {
+		first, second, last := 500, 503, 200
+		webhook := &WebhookQueue{
+			Retry0HTTPStatus: &first,
+			Retry1HTTPStatus: &second,
+			Retry6HTTPStatus: &last,
+		}
+
+		assert.Equal(t, []int{500, 503, 200}, webhook.AttemptHTTPStatuses())
+	})
+
+	t.Run("should return nothing when no attempt was recorded", func(t *testing.T) {
+		webhook := &WebhookQueue{}
+
+		assert.Empty(t, webhook.AttemptHTTPStatuses())
+	})
+}
+
+func TestWebhookQueue_Attempts(t *testing.T) {
+	t.Run("should return started attempts in retry level order", func(t *testing.T) {
+		first, second := time.Now().Add(-time.Hour), time.Now()
+		status := 503
+		webhook := &WebhookQueue{
+			Retry0StartedAt:  &first,
+			Retry0HTTPStatus: &status,
+			Retry2StartedAt:  &second,
+		}
+
+		attempts := webhook.Attempts()
+
+		require.Len(t, attempts, 2)
+		assert.Equal(t, 0, attempts[0].RetryLevel)
+		assert.Equal(t, &status, attempts[0].HTTPStatus)
+		assert.Equal(t, 2, attempts[1].RetryLevel)
+		assert.Equal(t, &second, attempts[1].StartedAt)
+	})
+
+	t.Run("should follow the fixed levels with the attempts above them", func(t *testing.T) {
+		started := time.Now()
+		status := 502
+		webhook := &WebhookQueue{
+			Retry6StartedAt: &started,
+			ExtraAttempts:   []WebhookAttempt{{RetryLevel: 7, StartedAt: &started, HTTPStatus: &status}},
+		}
+
+		attempts := webhook.Attempts()
+
+		require.Len(t, attempts, 2)
+		assert.Equal(t, 6, attempts[0].RetryLevel)
+		assert.Equal(t, 7, attempts[1].RetryLevel)
+		assert.Equal(t, []int{502}, webhook.AttemptHTTPStatuses())
+	})
+
+	t.Run("should return nothing before the first attempt", func(t *testing.T) {
+		assert.Empty(t, (&WebhookQueue{}).Attempts())
+	})
+}
+
+func TestWebhookQueue_CurrentRetryLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryCount int
+		want       int
+	}{
+		{name: "first attempt", retryCount: 0, want: 0},
+		{name: "after one failed attempt", retryCount: 1, want: 1},
+		{name: "midway", retryCount: 3, want: 3},
+		{name: "last retry", retryCount: enums.MaxRetryAttempts, want: enums.MaxRetryAttempts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &WebhookQueue{RetryCount: tt.retryCount}
+
+			assert.Equal(t, tt.want, webhook.CurrentRetryLevel())
+		})
+	}
+
+	t.Run("should keep the level across a throttled reschedule", func(t *testing.T) {
+		webhook := &WebhookQueue{RetryCount: 2}
+
+		webhook.ThrottledCount++
+
+		assert.Equal(t, 2, webhook.CurrentRetryLevel())
+	})
+
+	t.Run("should record the attempt at its level's columns", func(t *testing.T) {
+		started := time.Now()
+		webhook := &WebhookQueue{RetryCount: 2, Retry2StartedAt: &started}
+
+		attempts := webhook.Attempts()
+
+		require.Len(t, attempts, 1)
+		assert.Equal(t, webhook.CurrentRetryLevel(), attempts[0].RetryLevel)
+	})
+}
+
+func TestValidateMetadata(t *testing.T) {
+	t.Run("should accept small label sets", func(t *testing.T) {
+		assert.NoError(t, ValidateMetadata(nil))
+		assert.NoError(t, ValidateMetadata(map[string]string{"tenant": "acme", "source": "api"}))
+	})
+
+	t.Run("should reject oversized metadata", func(t *testing.T) {
+		tooMany := map[string]string{}
+		for i := 0; i <= MaxMetadataEntries; i++ {
+			tooMany[fmt.Sprintf("key-%d", i)] = "v"
+		}
+
+		assert.Error(t, ValidateMetadata(tooMany))
+		assert.Error(t, ValidateMetadata(map[string]string{"": "v"}))
+		assert.Error(t, ValidateMetadata(map[string]string{strings.Repeat("k", MaxMetadataKeyLength+1): "v"}))
+		assert.Error(t, ValidateMetadata(map[string]string{"tenant": strings.Repeat("v", MaxMetadataValueLength+1)}))
+	})
+}
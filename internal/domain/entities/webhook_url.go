@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// defaultPorts maps each scheme to the port its URLs imply when none is given
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// NormalizeWebhookURL returns rawURL in a canonical form, so equivalent URLs compare equal: the scheme
+// and host are lowercased and a scheme's default port is dropped. With sortQuery the query parameters
+// are also sorted by name, keeping the order of repeated names; leave it off for receivers whose
+// signatures or routing depend on the order as sent. A URL that is not an absolute http(s) or grpc
+// URL with a host is rejected
+func NormalizeWebhookURL(rawURL string, sortQuery bool) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid webhook URL: %w", err)
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	if parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "grpc" {
+		return "", fmt.Errorf("invalid webhook URL %q: scheme must be http, https or grpc", rawURL)
+	}
+	if parsed.Hostname() == "" {
+		return "", fmt.Errorf("invalid webhook URL %q: missing host", rawURL)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	port := parsed.Port()
+	if port == "" || port == defaultPorts[parsed.Scheme] {
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]" // IPv6 literals keep their brackets without a port
+		}
+		parsed.Host = host
+	} else {
+		parsed.Host = net.JoinHostPort(host, port)
+	}
+
+	if sortQuery && parsed.RawQuery != "" {
+		query, err := url.ParseQuery(parsed.RawQuery)
+		if err != nil {
+			return "", fmt.Errorf("invalid webhook URL %q: %w", rawURL, err)
+		}
+		parsed.RawQuery = query.Encode()
+	}
+
+	return parsed.String(), nil
+}
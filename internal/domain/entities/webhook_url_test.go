@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeWebhookURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		rawURL    string
+		sortQuery bool
+		want      string
+	}{
+		{name: "strips the default https port", rawURL: "https://example.com:443/hooks", want: "https://example.com/hooks"},
+		{name: "strips the default http port", rawURL: "http://example.com:80/hooks", want: "http://example.com/hooks"},
+		{name: "keeps a non-default port", rawURL: "https://example.com:8443/hooks", want: "https://example.com:8443/hooks"},
+		{name: "keeps the http port on https", rawURL: "https://example.com:80/hooks", want: "https://example.com:80/hooks"},
+		{name: "lowercases the scheme and host but not the path", rawURL: "HTTPS://API.Example.COM/Hooks/In", want: "https://api.example.com/Hooks/In"},
+		{name: "strips the default port of an IPv6 host", rawURL: "http://[::1]:80/hooks", want: "http://[::1]/hooks"},
+		{name: "leaves the query order alone by default", rawURL: "https://example.com/hooks?b=2&a=1", want: "https://example.com/hooks?b=2&a=1"},
+		{name: "sorts the query when asked, keeping repeated names in order", rawURL: "https://example.com/hooks?b=2&a=1&b=1", sortQuery: true, want: "https://example.com/hooks?a=1&b=2&b=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeWebhookURL(tt.rawURL, tt.sortQuery)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("should reject URLs that cannot be delivered to", func(t *testing.T) {
+		for _, rawURL := range []string{"https://exa mple.com/%zz", "://missing-scheme", "ftp://example.com/hooks", "https:///hooks", ""} {
+			_, err := NormalizeWebhookURL(rawURL, false)
+
+			assert.ErrorContains(t, err, "invalid webhook URL", rawURL)
+		}
+	})
+}
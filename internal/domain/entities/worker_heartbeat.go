@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// WorkerHeartbeat is the last sign of life a webhook worker wrote to the database
+type WorkerHeartbeat struct {
+	WorkerID   string    `json:"worker_id"`
+	RetryLevel int       `json:"retry_level"`
+	Host       string    `json:"host"`
+	StartedAt  time.Time `json:"started_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// IsStale reports whether the worker has not beaten for longer than staleAfter as of now,
+// which means its process has died without removing its heartbeat
+func (h *WorkerHeartbeat) IsStale(now time.Time, staleAfter time.Duration) bool {
+	return now.Sub(h.LastSeenAt) > staleAfter
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerHeartbeat_IsStale(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		lastSeen time.Time
+		want     bool
+	}{
+		{name: "recent", lastSeen: now.Add(-10 * time.Second), want: false},
+		{name: "exactly stale-after old", lastSeen: now.Add(-time.Minute), want: false},
+		{name: "older than stale-after", lastSeen: now.Add(-time.Minute - time.Second), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			heartbeat := &WorkerHeartbeat{LastSeenAt: tt.lastSeen}
+			assert.Equal(t, tt.want, heartbeat.IsStale(now, time.Minute))
+		})
+	}
+}
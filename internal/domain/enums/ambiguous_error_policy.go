@@ -0,0 +1,39 @@
+package enums
+
+import (
+	"fmt"
+)
+
+// AmbiguousErrorPolicy decides whether a config's webhooks are retried after an error that leaves it
+// unknown whether the receiver processed the delivery, such as a connection reset after the request was sent
+type AmbiguousErrorPolicy string
+
+const (
+	// AmbiguousErrorPolicyRetry retries ambiguous errors like any other connection error
+	AmbiguousErrorPolicyRetry AmbiguousErrorPolicy = "retry"
+
+	// AmbiguousErrorPolicyFail fails the webhook rather than risk delivering it twice
+	AmbiguousErrorPolicyFail AmbiguousErrorPolicy = "fail"
+
+	// AmbiguousErrorPolicyRetryIfIdempotent retries only when the config's receiver is idempotent
+	AmbiguousErrorPolicyRetryIfIdempotent AmbiguousErrorPolicy = "retry-if-idempotent"
+)
+
+// IsValid checks if the ambiguous error policy is valid
+func (p AmbiguousErrorPolicy) IsValid() bool {
+	switch p {
+	case AmbiguousErrorPolicyRetry, AmbiguousErrorPolicyFail, AmbiguousErrorPolicyRetryIfIdempotent:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate validates the ambiguous error policy and returns an error if invalid
+func (p AmbiguousErrorPolicy) Validate() error {
+	if !p.IsValid() {
+		return fmt.Errorf("invalid ambiguous error policy: %s (must be one of: %s, %s, %s)",
+			p, AmbiguousErrorPolicyRetry, AmbiguousErrorPolicyFail, AmbiguousErrorPolicyRetryIfIdempotent)
+	}
+	return nil
+}
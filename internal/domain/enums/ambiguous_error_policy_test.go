@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmbiguousErrorPolicy_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   AmbiguousErrorPolicy
+		expected bool
+	}{
+		{name: "retry is valid", policy: AmbiguousErrorPolicyRetry, expected: true},
+		{name: "fail is valid", policy: AmbiguousErrorPolicyFail, expected: true},
+		{name: "retry-if-idempotent is valid", policy: AmbiguousErrorPolicyRetryIfIdempotent, expected: true},
+		{name: "empty is invalid", policy: AmbiguousErrorPolicy(""), expected: false},
+		{name: "underscored name is invalid", policy: AmbiguousErrorPolicy("retry_if_idempotent"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.policy.IsValid())
+			if tt.expected {
+				assert.NoError(t, tt.policy.Validate())
+			} else {
+				assert.Error(t, tt.policy.Validate())
+			}
+		})
+	}
+}
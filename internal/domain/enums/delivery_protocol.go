@@ -0,0 +1,35 @@
+package enums
+
+import (
+	"fmt"
+)
+
+// DeliveryProtocol selects how a config's webhooks are delivered to the receiver
+type DeliveryProtocol string
+
+const (
+	// DeliveryProtocolHTTP delivers with an HTTP request to the webhook URL
+	DeliveryProtocolHTTP DeliveryProtocol = "http"
+
+	// DeliveryProtocolGRPC delivers with the DeliveryService.Deliver RPC to the webhook URL's host
+	DeliveryProtocolGRPC DeliveryProtocol = "grpc"
+)
+
+// IsValid checks if the delivery protocol is valid
+func (p DeliveryProtocol) IsValid() bool {
+	switch p {
+	case DeliveryProtocolHTTP, DeliveryProtocolGRPC:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate validates the delivery protocol and returns an error if invalid
+func (p DeliveryProtocol) Validate() error {
+	if !p.IsValid() {
+		return fmt.Errorf("invalid delivery protocol: %s (must be one of: %s, %s)",
+			p, DeliveryProtocolHTTP, DeliveryProtocolGRPC)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package enums
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryProtocol_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol DeliveryProtocol
+		expected bool
+	}{
+		{name: "http is valid", protocol: DeliveryProtocolHTTP, expected: true},
+		{name: "grpc is valid", protocol: DeliveryProtocolGRPC, expected: true},
+		{name: "empty is invalid", protocol: DeliveryProtocol(""), expected: false},
+		{name: "wrong case is invalid", protocol: DeliveryProtocol("GRPC"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.protocol.IsValid())
+			if tt.expected {
+				assert.NoError(t, tt.protocol.Validate())
+			} else {
+				assert.Error(t, tt.protocol.Validate())
+			}
+		})
+	}
+}
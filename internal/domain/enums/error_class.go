@@ -0,0 +1,37 @@
+package enums
+
+// ErrorClass categorizes why a delivery attempt failed
+type ErrorClass string
+
+const (
+	// ErrorClassNone marks an attempt that did not fail
+	ErrorClassNone ErrorClass = ""
+
+	// ErrorClassTimeout marks an attempt that ran out of time before a response arrived
+	ErrorClassTimeout ErrorClass = "timeout"
+
+	// ErrorClassDNS marks an attempt whose host name could not be resolved
+	ErrorClassDNS ErrorClass = "dns"
+
+	// ErrorClassConnection marks an attempt whose connection was refused, reset or closed
+	ErrorClassConnection ErrorClass = "connection"
+
+	// ErrorClassTLS marks an attempt that failed the TLS handshake or certificate verification
+	ErrorClassTLS ErrorClass = "tls"
+
+	// ErrorClassHTTPStatus marks an attempt that got a response whose status is not treated as success
+	ErrorClassHTTPStatus ErrorClass = "http_status"
+
+	// ErrorClassInternal marks an attempt that failed inside the processor before or after the exchange
+	ErrorClassInternal ErrorClass = "internal"
+)
+
+// IsValid checks if the error class is one of the known failure classes
+func (c ErrorClass) IsValid() bool {
+	switch c {
+	case ErrorClassTimeout, ErrorClassDNS, ErrorClassConnection, ErrorClassTLS, ErrorClassHTTPStatus, ErrorClassInternal:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,30 @@
+package enums
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorClass_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		class    ErrorClass
+		expected bool
+	}{
+		{name: "timeout is valid", class: ErrorClassTimeout, expected: true},
+		{name: "dns is valid", class: ErrorClassDNS, expected: true},
+		{name: "connection is valid", class: ErrorClassConnection, expected: true},
+		{name: "tls is valid", class: ErrorClassTLS, expected: true},
+		{name: "http_status is valid", class: ErrorClassHTTPStatus, expected: true},
+		{name: "internal is valid", class: ErrorClassInternal, expected: true},
+		{name: "none is invalid", class: ErrorClassNone, expected: false},
+		{name: "unknown is invalid", class: ErrorClass("socket"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.class.IsValid())
+		})
+	}
+}
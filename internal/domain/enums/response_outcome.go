@@ -0,0 +1,38 @@
+package enums
+
+import (
+	"fmt"
+)
+
+// ResponseOutcome represents how a delivery attempt's HTTP response is treated
+type ResponseOutcome string
+
+const (
+	// ResponseOutcomeSuccess marks the webhook as completed
+	ResponseOutcomeSuccess ResponseOutcome = "success"
+
+	// ResponseOutcomeRetry schedules another attempt if retries remain
+	ResponseOutcomeRetry ResponseOutcome = "retry"
+
+	// ResponseOutcomeFail marks the webhook as permanently failed without further retries
+	ResponseOutcomeFail ResponseOutcome = "fail"
+)
+
+// IsValid checks if the response outcome is valid
+func (o ResponseOutcome) IsValid() bool {
+	switch o {
+	case ResponseOutcomeSuccess, ResponseOutcomeRetry, ResponseOutcomeFail:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate validates the response outcome and returns an error if invalid
+func (o ResponseOutcome) Validate() error {
+	if !o.IsValid() {
+		return fmt.Errorf("invalid response outcome: %s (must be one of: %s, %s, %s)",
+			o, ResponseOutcomeSuccess, ResponseOutcomeRetry, ResponseOutcomeFail)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseOutcome_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		outcome  ResponseOutcome
+		expected bool
+	}{
+		{name: "success is valid", outcome: ResponseOutcomeSuccess, expected: true},
+		{name: "retry is valid", outcome: ResponseOutcomeRetry, expected: true},
+		{name: "fail is valid", outcome: ResponseOutcomeFail, expected: true},
+		{name: "empty is invalid", outcome: ResponseOutcome(""), expected: false},
+		{name: "wrong case is invalid", outcome: ResponseOutcome("SUCCESS"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.outcome.IsValid())
+			if tt.expected {
+				assert.NoError(t, tt.outcome.Validate())
+			} else {
+				assert.Error(t, tt.outcome.Validate())
+			}
+		})
+	}
+}
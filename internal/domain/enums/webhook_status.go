@@ -17,11 +17,51 @@ const (
 	WebhookStatusFailed WebhookStatus = "FAILED"
 )
 
-// MaxRetryAttempts defines the maximum number of retry attempts
-// This is fixed by the database schema (retry_0 through retry_6 = 7 total attempts)
+// MaxRetryAttempts is the retry limit of configs that don't set their own, and the highest retry level
+// recorded in the fixed retry_0 through retry_6 attempt columns
 const MaxRetryAttempts = 6
 
+// MaxConfigurableRetries is the highest retry limit a config may set; attempts above MaxRetryAttempts
+// are recorded in the webhook's retry_attempts list instead of fixed columns
+const MaxConfigurableRetries = 20
+
+// IsValid checks if the status is one of the known webhook statuses
+func (s WebhookStatus) IsValid() bool {
+	switch s {
+	case WebhookStatusPending, WebhookStatusProcessing, WebhookStatusCompleted, WebhookStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
 // IsCompleted checks if the status is completed
 func (s WebhookStatus) IsCompleted() bool {
 	return s == WebhookStatusCompleted
 }
+
+// BulkTransitionSources returns the statuses an administrative bulk update may move to s from
+// PROCESSING is never a source or target so bulk operations cannot race in-flight attempts
+func (s WebhookStatus) BulkTransitionSources() []WebhookStatus {
+	switch s {
+	case WebhookStatusFailed:
+		return []WebhookStatus{WebhookStatusPending}
+	case WebhookStatusPending:
+		return []WebhookStatus{WebhookStatusFailed}
+	case WebhookStatusCompleted:
+		// Only rows that were attempted at least once; enforced by the repository
+		return []WebhookStatus{WebhookStatusPending, WebhookStatusFailed}
+	default:
+		return nil
+	}
+}
+
+// CanBulkTransitionTo checks if an administrative bulk update may move a webhook from s to target
+func (s WebhookStatus) CanBulkTransitionTo(target WebhookStatus) bool {
+	for _, source := range target.BulkTransitionSources() {
+		if source == s {
+			return true
+		}
+	}
+	return false
+}
@@ -181,3 +181,35 @@ func BenchmarkWebhookStatus_IsCompleted_Invalid(b *testing.B) {
 		_ = status.IsCompleted()
 	}
 }
+
+func TestWebhookStatus_CanBulkTransitionTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     WebhookStatus
+		to       WebhookStatus
+		expected bool
+	}{
+		{name: "pending to failed", from: WebhookStatusPending, to: WebhookStatusFailed, expected: true},
+		{name: "failed to pending", from: WebhookStatusFailed, to: WebhookStatusPending, expected: true},
+		{name: "pending to completed", from: WebhookStatusPending, to: WebhookStatusCompleted, expected: true},
+		{name: "failed to completed", from: WebhookStatusFailed, to: WebhookStatusCompleted, expected: true},
+		{name: "processing to failed", from: WebhookStatusProcessing, to: WebhookStatusFailed, expected: false},
+		{name: "completed to pending", from: WebhookStatusCompleted, to: WebhookStatusPending, expected: false},
+		{name: "pending to processing", from: WebhookStatusPending, to: WebhookStatusProcessing, expected: false},
+		{name: "to an invalid status", from: WebhookStatusPending, to: WebhookStatus("UNKNOWN"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.from.CanBulkTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestWebhookStatus_IsValid(t *testing.T) {
+	for _, status := range []WebhookStatus{WebhookStatusPending, WebhookStatusProcessing, WebhookStatusCompleted, WebhookStatusFailed} {
+		assert.True(t, status.IsValid(), string(status))
+	}
+	assert.False(t, WebhookStatus("").IsValid())
+	assert.False(t, WebhookStatus("failed").IsValid())
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// BodyStore keeps the response bodies of delivery attempts
+// The attempt's response body column holds whatever Put returns: the body itself, or a reference to it
+type BodyStore interface {
+	// Put stores the response body of one attempt and returns the value to keep in the row
+	// Bodies are keyed by queue ID, which unlike the row ID is never reused by a restore or another database
+	Put(ctx context.Context, queueID uuid.UUID, retryLevel int, body string) (string, error)
+
+	// Resolve returns the response body behind a value kept in the row
+	// Values that are not references, such as bodies stored before offloading was enabled, are returned as-is
+	Resolve(ctx context.Context, stored string) (string, error)
+
+	// Delete removes the bodies behind values kept in a row that is being erased
+	// Values that are not references are skipped, as they go with the row
+	Delete(ctx context.Context, stored []string) error
+}
@@ -4,10 +4,36 @@ import (
 	"context"
 
 	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
 )
 
 // WebhookConfigRepository defines the interface for webhook config operations
 type WebhookConfigRepository interface {
-	// GetByID retrieves a webhook config by ID (ONLY method actually used)
+	// GetByID retrieves a webhook config by ID
 	GetByID(ctx context.Context, id int64) (*entities.WebhookConfig, error)
+
+	// GetDefaultForEventType retrieves the active default config for an event type (nil if there is none)
+	GetDefaultForEventType(ctx context.Context, eventType enums.EventType) (*entities.WebhookConfig, error)
+
+	// GetActiveByEventType retrieves every active, non-deleted config registered for an event type
+	GetActiveByEventType(ctx context.Context, eventType enums.EventType) ([]*entities.WebhookConfig, error)
+
+	// ListActive retrieves every active, non-deleted config
+	ListActive(ctx context.Context) ([]*entities.WebhookConfig, error)
+
+	// GetStats retrieves the delivery statistics rollup for a config (nil if nothing has been recorded)
+	GetStats(ctx context.Context, configID int64) (*entities.WebhookConfigStats, error)
+
+	// Create inserts a config, setting its ID and timestamps
+	Create(ctx context.Context, config *entities.WebhookConfig) error
+
+	// Update replaces a non-deleted config's name, event type, URL, timeout and active flag;
+	// false means no such config exists
+	Update(ctx context.Context, config *entities.WebhookConfig) (bool, error)
+
+	// Delete soft-deletes and deactivates a config; false means no such config exists
+	Delete(ctx context.Context, id int64) (bool, error)
+
+	// List retrieves every non-deleted config, active or not
+	List(ctx context.Context) ([]*entities.WebhookConfig, error)
 }
@@ -2,29 +2,128 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/google/uuid"
+
 	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
 )
 
+// ErrLockContention is returned when due webhooks exist but all are locked by other workers
+var ErrLockContention = errors.New("all due webhooks are locked by other workers")
+
+// ErrClaimLost is returned when a worker's write finds the webhook no longer PROCESSING under its claim,
+// e.g. because the claim expired and the reaper handed the webhook to another worker
+var ErrClaimLost = errors.New("webhook is no longer claimed by this worker")
+
+// WebhookQueueFilter selects webhooks for administrative operations; unset fields match everything
+type WebhookQueueFilter struct {
+	ConfigID      *int64                `json:"config_id,omitempty"`
+	Statuses      []enums.WebhookStatus `json:"statuses,omitempty"`
+	EventType     enums.EventType       `json:"event_type,omitempty"`
+	CreatedAfter  *time.Time            `json:"created_after,omitempty"` // Inclusive
+	CreatedBefore *time.Time            `json:"created_before,omitempty"`
+	Metadata      map[string]string     `json:"metadata,omitempty"` // Every key/value must be present
+}
+
+// IsEmpty reports whether the filter would match every webhook
+func (f WebhookQueueFilter) IsEmpty() bool {
+	return f.ConfigID == nil && len(f.Statuses) == 0 && f.EventType == "" && f.CreatedAfter == nil &&
+		f.CreatedBefore == nil && len(f.Metadata) == 0
+}
+
 // WebhookQueueRepository defines the interface for webhook queue operations
 type WebhookQueueRepository interface {
 	// Create creates a new webhook queue entry
 	Create(ctx context.Context, webhook *entities.WebhookQueue) error
 
-	// Update updates a webhook queue entry
-	Update(ctx context.Context, webhook *entities.WebhookQueue) error
+	// CreateBatch creates every webhook in one transaction, setting their IDs and queue IDs;
+	// if any insert fails none are created
+	CreateBatch(ctx context.Context, webhooks []*entities.WebhookQueue) error
+
+	// Update writes back a webhook claimed by workerID, only while it is still PROCESSING under that claim;
+	// returns ErrClaimLost otherwise
+	Update(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error
+
+	// GetByQueueID retrieves a webhook by its public queue ID (nil if not found)
+	GetByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error)
+
+	// GetByEventID retrieves every webhook created for an event ID, one per config it fanned out to,
+	// ordered by config; empty if there are none
+	GetByEventID(ctx context.Context, eventID string) ([]*entities.WebhookQueue, error)
 
 	// GetNextWebhookForProcessing atomically gets and locks ONE webhook for a specific retry level
 	// Uses PostgreSQL's SELECT FOR UPDATE SKIP LOCKED for optimal concurrency
+	// The row is claimed for workerID until the repository's claim TTL passes
+	// Returns ErrLockContention instead of nil when every due row was skipped as locked
 	GetNextWebhookForProcessing(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error)
 
-	// UpdateRetryAttempt updates retry attempt information
-	UpdateRetryAttempt(ctx context.Context, webhookID int64, retryLevel int, startedAt time.Time, completedAt *time.Time, durationMs int64, httpStatus int, responseBody, errorMsg string) error
+	// ClaimByQueueID atomically claims one PENDING webhook for workerID regardless of its next_retry_at
+	// Returns nil when no PENDING webhook has the queue ID or the row is locked by another worker
+	ClaimByQueueID(ctx context.Context, queueID uuid.UUID, workerID string) (*entities.WebhookQueue, error)
+
+	// UpdateRetryAttempt updates retry attempt information, including the effective timeout used
+	// and the error class of a failed attempt (empty when the attempt succeeded)
+	// retryLevel must be the webhook's stored retry count; a mismatch is rejected rather than written
+	// request is the attempt's sent body and headers, recorded when non-nil
+	// Returns ErrClaimLost unless the webhook is still PROCESSING under workerID's claim at that retry count
+	UpdateRetryAttempt(ctx context.Context, webhookID int64, workerID string, retryLevel int, startedAt time.Time, completedAt *time.Time, durationMs int64, timeoutMs int64, httpStatus int, responseBody, errorMsg string, errorClass enums.ErrorClass, request *entities.AttemptRequest) error
+
+	// ExtendClaim renews workerID's claim on a PROCESSING webhook for another claim TTL from now, so a
+	// delivery that outlasts the TTL is not reclaimed; returns ErrClaimLost when the claim is no longer held
+	ExtendClaim(ctx context.Context, webhookID int64, workerID string) error
+
+	// ReclaimExpiredClaims returns PROCESSING webhooks whose claim expired before asOf to PENDING
+	// Returns the number of webhooks reclaimed
+	ReclaimExpiredClaims(ctx context.Context, asOf time.Time) (int64, error)
+
+	// MarkCompleted marks a webhook claimed by workerID as completed
+	// A non-zero lastHTTPStatus is written too, so the outcome survives a lost attempt record
+	// Returns ErrClaimLost, counting nothing, unless the webhook is still PROCESSING under workerID's claim
+	MarkCompleted(ctx context.Context, webhookID int64, workerID string, processingStartedAt time.Time, lastHTTPStatus int) error
+
+	// MarkFailed marks a webhook claimed by workerID as failed
+	// A non-zero lastHTTPStatus is written too, so the outcome survives a lost attempt record
+	// Returns ErrClaimLost, counting nothing, unless the webhook is still PROCESSING under workerID's claim
+	MarkFailed(ctx context.Context, webhookID int64, workerID string, errorMsg string, lastHTTPStatus int) error
+
+	// ForceFail moves one PENDING or PROCESSING webhook to FAILED with reason and returns the status it
+	// left, empty when no such webhook has the queue ID; a PROCESSING webhook's claim is released, so its
+	// in-flight attempt's outcome is dropped with ErrClaimLost rather than overwriting the failure
+	ForceFail(ctx context.Context, queueID uuid.UUID, reason string) (enums.WebhookStatus, error)
+
+	// HardDelete permanently removes one webhook, including its stored response bodies, unless it is
+	// PROCESSING; returns false when no such webhook was deleted
+	HardDelete(ctx context.Context, queueID uuid.UUID) (bool, error)
+
+	// List returns up to limit webhooks matching the filter, oldest first
+	List(ctx context.Context, filter WebhookQueueFilter, limit int) ([]*entities.WebhookQueue, error)
+
+	// ListAfter returns up to limit webhooks matching the filter with an ID above afterID, in ID order,
+	// so the whole queue can be scanned in batches
+	ListAfter(ctx context.Context, filter WebhookQueueFilter, afterID int64, limit int) ([]*entities.WebhookQueue, error)
+
+	// Restore inserts a webhook from a backup as-is, keeping its queue ID; it returns false without
+	// changing anything when a webhook with that queue ID already exists
+	Restore(ctx context.Context, webhook *entities.WebhookQueue) (bool, error)
+
+	// ListPage returns one page of webhooks matching the filter, newest first, and how many match in total
+	ListPage(ctx context.Context, filter WebhookQueueFilter, limit, offset int) ([]*entities.WebhookQueue, int64, error)
+
+	// CountByStatus returns how many webhooks currently have the given status
+	CountByStatus(ctx context.Context, status enums.WebhookStatus) (int64, error)
+
+	// FindOldestOverdue returns the pending webhook that has been due the longest as of asOf (nil if none)
+	FindOldestOverdue(ctx context.Context, asOf time.Time) (*entities.WebhookQueue, error)
 
-	// MarkCompleted marks a webhook as completed
-	MarkCompleted(ctx context.Context, webhookID int64, processingStartedAt time.Time) error
+	// FindCompletedWithoutSuccess returns up to limit COMPLETED webhooks finished since the given time
+	// whose recorded attempts contain no 2xx status
+	FindCompletedWithoutSuccess(ctx context.Context, since time.Time, limit int) ([]*entities.WebhookQueue, error)
 
-	// MarkFailed marks a webhook as failed
-	MarkFailed(ctx context.Context, webhookID int64, errorMsg string) error
+	// BulkUpdateStatus moves every webhook matching the filter to newStatus in one transaction,
+	// only from statuses allowed by newStatus.BulkTransitionSources, and returns the affected count
+	// Config stats change with it: moved terminal rows are counted and a requeued FAILED row uncounted
+	BulkUpdateStatus(ctx context.Context, filter WebhookQueueFilter, newStatus enums.WebhookStatus, reason string) (int64, error)
 }
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"webhook-processor/internal/domain/entities"
+)
+
+// WorkerHeartbeatRepository defines the interface for worker heartbeat operations
+type WorkerHeartbeatRepository interface {
+	// Upsert writes a worker's heartbeat, replacing the previous one for the same worker ID
+	Upsert(ctx context.Context, heartbeat *entities.WorkerHeartbeat) error
+
+	// List returns every recorded heartbeat across instances, ordered by host, retry level and worker ID
+	List(ctx context.Context) ([]*entities.WorkerHeartbeat, error)
+
+	// Delete removes a worker's heartbeat, e.g. when it stops cleanly
+	Delete(ctx context.Context, workerID string) error
+}
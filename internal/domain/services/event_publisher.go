@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"webhook-processor/internal/domain/entities"
+)
+
+// EventPublisher emits webhook state transitions to an internal audit topic
+// Publishing is best-effort: implementations log their own errors and never block delivery
+type EventPublisher interface {
+	// Publish reports one state transition
+	Publish(ctx context.Context, event *entities.TransitionEvent)
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"webhook-processor/internal/domain/entities"
+)
+
+// FailureNotifier alerts on-call when a webhook permanently fails
+// Notifications are best-effort: implementations log their own errors and may aggregate bursts
+type FailureNotifier interface {
+	// NotifyFailure reports a permanently failed webhook
+	NotifyFailure(ctx context.Context, notification *entities.FailureNotification)
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"webhook-processor/internal/domain/entities"
+)
+
+// StatusCallbackService sends a config's on-success and on-failure callbacks
+// Callbacks are sent directly and never enqueued, so they are not retried and cannot trigger callbacks of their own
+type StatusCallbackService interface {
+	// SendStatusCallback posts the callback to url and returns an error unless it is acknowledged with a 2xx
+	SendStatusCallback(ctx context.Context, url string, callback *entities.StatusCallback) error
+}
@@ -2,15 +2,36 @@ package services
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
 )
 
+// ErrPayloadTemplate marks a delivery whose config payload template failed to parse or render
+// Resending cannot fix the template, so such deliveries fail without being retried
+var ErrPayloadTemplate = errors.New("payload template failed")
+
+// ErrSpacingWaitCancelled marks a delivery that was never sent because its wait for the host's spacing
+// slot would outlast the attempt deadline or was cancelled; nothing reached the receiver, so it is not an
+// attempt. The response's RetryAfter says how long until the host's slot comes up
+var ErrSpacingWaitCancelled = errors.New("host spacing wait cancelled before the request was sent")
+
 // WebhookService defines the interface for webhook processing operations
 type WebhookService interface {
 	// SendWebhook sends a webhook request and returns the response
 	SendWebhook(ctx context.Context, webhook *entities.WebhookQueue) (*WebhookResponse, error)
+
+	// Ping checks that the receiver at a webhook URL answers within timeout, without delivering an event
+	// Any answer counts as reachable; DNS, connection, TLS and timeout failures are returned as errors
+	Ping(ctx context.Context, webhookURL string, timeout time.Duration) (*PingResult, error)
+}
+
+// PingResult is how a receiver answered a Ping
+type PingResult struct {
+	StatusCode int           `json:"status_code,omitempty"` // 0 for gRPC receivers, which are only dialed
+	Duration   time.Duration `json:"duration"`
 }
 
 // WebhookResponse represents the response from a webhook call
@@ -18,5 +39,16 @@ type WebhookResponse struct {
 	StatusCode int           `json:"status_code"`
 	Body       string        `json:"body"`
 	Duration   time.Duration `json:"duration"`
+	Timeout    time.Duration `json:"timeout"` // Effective timeout applied to this attempt
 	Error      error         `json:"error"`
+
+	// RetryAfter is the receiver's Retry-After hint, 0 when it sent none
+	RetryAfter time.Duration `json:"retry_after,omitempty"`
+
+	// Request is what was sent, with credential headers redacted; nil when the request was never built
+	Request *entities.AttemptRequest `json:"-"`
+
+	// Outcome is the transport's own verdict on the response, such as a mapped gRPC status code;
+	// empty leaves it to the status code, and a config's status outcomes still take precedence
+	Outcome enums.ResponseOutcome `json:"outcome,omitempty"`
 }
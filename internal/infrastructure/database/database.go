@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm/logger"
 
 	"webhook-processor/internal/config"
+	"webhook-processor/internal/infrastructure/models"
 )
 
 // NewDatabase creates a new database connection
@@ -17,7 +18,8 @@ func NewDatabase(cfg *config.Config) (*gorm.DB, error) {
 
 	// Open database connection
 	db, err := gorm.Open(postgres.Open(cfg.GetDatabaseDSN()), &gorm.Config{
-		Logger: gormLogger,
+		Logger:         gormLogger,
+		NamingStrategy: models.NamingStrategy(cfg.Database.Schema),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -0,0 +1,67 @@
+package limits
+
+import (
+	"errors"
+
+	"github.com/go-kit/log"
+
+	"webhook-processor/internal/config"
+)
+
+// reservedFileDescriptors covers log output, listeners, DNS lookups and other incidental files
+const reservedFileDescriptors = 64
+
+// errUnsupported is returned on platforms without RLIMIT_NOFILE
+var errUnsupported = errors.New("open file limits are not supported on this platform")
+
+// FileLimit is the process's soft and hard RLIMIT_NOFILE
+type FileLimit struct {
+	Soft uint64
+	Hard uint64
+}
+
+// RequiredFileDescriptors estimates the descriptors the processor may hold at once:
+// one outbound connection per worker, the idle HTTP pool, the database pool and a fixed reserve
+func RequiredFileDescriptors(cfg *config.Config) uint64 {
+	required := uint64(reservedFileDescriptors)
+	required += uint64(len(cfg.WorkerPool.Workers))
+	if cfg.HTTPClient.MaxIdleConns > 0 {
+		required += uint64(cfg.HTTPClient.MaxIdleConns)
+	}
+	if cfg.Database.MaxOpenConns > 0 {
+		required += uint64(cfg.Database.MaxOpenConns)
+	}
+	return required
+}
+
+// CheckFileLimit logs the current open file limits and warns when the soft limit is below required
+// When raise is set, a low soft limit is first raised as far as the hard limit allows
+func CheckFileLimit(logger log.Logger, required uint64, raise bool) (FileLimit, error) {
+	limit, err := readFileLimit()
+	if err != nil {
+		logger.Log("level", "warn", "msg", "could not read open file limit", "error", err)
+		return FileLimit{}, err
+	}
+
+	if raise && limit.Soft < required && limit.Soft < limit.Hard {
+		raised, err := raiseFileLimit(limit)
+		if err != nil {
+			logger.Log("level", "warn", "msg", "failed to raise open file limit",
+				"soft", limit.Soft, "hard", limit.Hard, "error", err)
+		} else {
+			logger.Log("level", "info", "msg", "raised open file limit",
+				"from", limit.Soft, "to", raised.Soft)
+			limit = raised
+		}
+	}
+
+	logger.Log("level", "info", "msg", "open file limit",
+		"soft", limit.Soft, "hard", limit.Hard, "required", required)
+
+	if limit.Soft < required {
+		logger.Log("level", "warn", "msg", "open file limit is below what the configuration may need; deliveries may fail with too many open files",
+			"soft", limit.Soft, "hard", limit.Hard, "required", required)
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,71 @@
+//go:build linux
+
+package limits
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"webhook-processor/internal/config"
+)
+
+// captureWarnings returns a logger that records the msg of every warn-level entry
+func captureWarnings() (log.Logger, *[]string) {
+	var warnings []string
+	logger := log.LoggerFunc(func(keyvals ...interface{}) error {
+		fields := map[interface{}]interface{}{}
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			fields[keyvals[i]] = keyvals[i+1]
+		}
+		if fields["level"] == "warn" {
+			warnings = append(warnings, fields["msg"].(string))
+		}
+		return nil
+	})
+	return logger, &warnings
+}
+
+func TestCheckFileLimit(t *testing.T) {
+	t.Run("should read the current limits", func(t *testing.T) {
+		limit, err := readFileLimit()
+
+		require.NoError(t, err)
+		assert.Positive(t, limit.Soft)
+		assert.LessOrEqual(t, limit.Soft, limit.Hard)
+	})
+
+	t.Run("should not warn when the soft limit covers the requirement", func(t *testing.T) {
+		logger, warnings := captureWarnings()
+
+		limit, err := CheckFileLimit(logger, 1, false)
+
+		require.NoError(t, err)
+		assert.Positive(t, limit.Soft)
+		assert.Empty(t, *warnings)
+	})
+
+	t.Run("should warn when the soft limit is below the requirement", func(t *testing.T) {
+		logger, warnings := captureWarnings()
+		current, err := readFileLimit()
+		require.NoError(t, err)
+
+		_, err = CheckFileLimit(logger, current.Soft+1, false)
+
+		require.NoError(t, err)
+		require.Len(t, *warnings, 1)
+		assert.Contains(t, (*warnings)[0], "too many open files")
+	})
+}
+
+func TestRequiredFileDescriptors(t *testing.T) {
+	cfg := &config.Config{
+		Database:   config.DatabaseConfig{MaxOpenConns: 25},
+		HTTPClient: config.HTTPClientConfig{MaxIdleConns: 100},
+		WorkerPool: config.WorkerPoolConfig{Workers: make([]config.WorkerConfig, 7)},
+	}
+
+	assert.Equal(t, uint64(reservedFileDescriptors+7+100+25), RequiredFileDescriptors(cfg))
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package limits
+
+// readFileLimit is unsupported on this platform
+func readFileLimit() (FileLimit, error) {
+	return FileLimit{}, errUnsupported
+}
+
+// raiseFileLimit is unsupported on this platform
+func raiseFileLimit(limit FileLimit) (FileLimit, error) {
+	return limit, errUnsupported
+}
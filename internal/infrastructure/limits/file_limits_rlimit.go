@@ -0,0 +1,26 @@
+//go:build linux || darwin
+
+package limits
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// readFileLimit reads the process's RLIMIT_NOFILE
+func readFileLimit() (FileLimit, error) {
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		return FileLimit{}, fmt.Errorf("failed to read RLIMIT_NOFILE: %w", err)
+	}
+	return FileLimit{Soft: uint64(rlimit.Cur), Hard: uint64(rlimit.Max)}, nil
+}
+
+// raiseFileLimit raises the soft RLIMIT_NOFILE to the hard limit
+func raiseFileLimit(limit FileLimit) (FileLimit, error) {
+	rlimit := syscall.Rlimit{Cur: limit.Hard, Max: limit.Hard}
+	if err := syscall.Setrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		return limit, fmt.Errorf("failed to raise RLIMIT_NOFILE: %w", err)
+	}
+	return readFileLimit()
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// levelRanks orders the supported log levels from most to least verbose
+var levelRanks = map[string]int{
+	"debug": 0,
+	"info":  1,
+	"warn":  2,
+	"error": 3,
+}
+
+// LevelLogger drops events below a minimum level that can be changed at runtime
+// Both level.Info(logger)-style values and plain "level" string values are honoured
+type LevelLogger struct {
+	next log.Logger
+	min  atomic.Int32
+}
+
+// NewLevelLogger creates a LevelLogger that forwards events at or above lvl to next
+func NewLevelLogger(next log.Logger, lvl string) (*LevelLogger, error) {
+	l := &LevelLogger{next: next}
+	if err := l.SetLevel(lvl); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SetLevel changes the minimum level; it is safe to call while logging
+func (l *LevelLogger) SetLevel(lvl string) error {
+	rank, ok := levelRanks[strings.ToLower(lvl)]
+	if !ok {
+		return fmt.Errorf("unknown log level %q", lvl)
+	}
+	l.min.Store(int32(rank))
+	return nil
+}
+
+// Log forwards the event unless its level is below the minimum; events without a level always pass
+func (l *LevelLogger) Log(keyvals ...interface{}) error {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] != level.Key() && keyvals[i] != "level" {
+			continue
+		}
+		if rank, ok := levelRanks[fmt.Sprint(keyvals[i+1])]; ok && int32(rank) < l.min.Load() {
+			return nil
+		}
+		break
+	}
+	return l.next.Log(keyvals...)
+}
+
+// ValidLevel reports whether lvl is a supported log level
+func ValidLevel(lvl string) bool {
+	_, ok := levelRanks[strings.ToLower(lvl)]
+	return ok
+}
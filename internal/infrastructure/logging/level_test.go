@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLevelLogger(log.NewLogfmtLogger(&buf), "info")
+	require.NoError(t, err)
+
+	t.Run("should drop events below the minimum level", func(t *testing.T) {
+		buf.Reset()
+
+		level.Debug(logger).Log("msg", "typed debug")
+		logger.Log("level", "debug", "msg", "string debug")
+
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("should forward events at or above the minimum level", func(t *testing.T) {
+		buf.Reset()
+
+		level.Info(logger).Log("msg", "typed info")
+		logger.Log("level", "error", "msg", "string error")
+		logger.Log("msg", "no level")
+
+		assert.Contains(t, buf.String(), "typed info")
+		assert.Contains(t, buf.String(), "string error")
+		assert.Contains(t, buf.String(), "no level")
+	})
+
+	t.Run("should apply a level change at runtime", func(t *testing.T) {
+		buf.Reset()
+		require.NoError(t, logger.SetLevel("debug"))
+
+		logger.Log("level", "debug", "msg", "now visible")
+
+		assert.Contains(t, buf.String(), "now visible")
+	})
+
+	t.Run("should reject unknown levels", func(t *testing.T) {
+		assert.Error(t, logger.SetLevel("verbose"))
+		assert.False(t, ValidLevel("verbose"))
+		assert.True(t, ValidLevel("WARN"))
+	})
+}
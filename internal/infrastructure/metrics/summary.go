@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Summary is a compact reading of the delivery collectors, for consumers that do not scrape Prometheus
+type Summary struct {
+	Deliveries           float64 // Queue items processed by workers since start
+	SuccessfulDeliveries float64 // Processed items whose final status code was 2xx
+	InFlight             float64 // Webhooks currently being processed by workers
+}
+
+// SuccessRate returns the share of deliveries that succeeded, or 0 before any delivery
+func (s Summary) SuccessRate() float64 {
+	if s.Deliveries == 0 {
+		return 0
+	}
+	return s.SuccessfulDeliveries / s.Deliveries
+}
+
+// Summarize reads the worker collectors from gatherer
+// Collectors that have not been registered yet read as zero
+func Summarize(gatherer prometheus.Gatherer) (Summary, error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	var summary Summary
+	for _, family := range families {
+		switch family.GetName() {
+		case "worker_processing_total":
+			for _, metric := range family.GetMetric() {
+				count := metric.GetCounter().GetValue()
+				summary.Deliveries += count
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == "status_code" && strings.HasPrefix(label.GetValue(), "2") {
+						summary.SuccessfulDeliveries += count
+					}
+				}
+			}
+		case "worker_in_flight":
+			for _, metric := range family.GetMetric() {
+				summary.InFlight += metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return summary, nil
+}
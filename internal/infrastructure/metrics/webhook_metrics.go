@@ -15,6 +15,68 @@ type WebhookMetrics struct {
 
 	// Counter for total queue items processed by workers by status code and retry level
 	workerProcessingTotal prometheus.CounterVec
+
+	// Histogram for time spent waiting for a free locking-transaction slot
+	lockingTxnWaitDuration prometheus.Histogram
+
+	// Histogram for time from opening a claim transaction to its row being selected and locked, by retry level
+	lockWaitDuration prometheus.HistogramVec
+
+	// Gauge for webhooks currently being delivered by workers
+	workerInFlight prometheus.Gauge
+
+	// Gauges for whether the worker pool is running and how many of its workers are polling,
+	// so a paused or stopped pool can be told apart from an idle one
+	workerPoolRunning prometheus.Gauge
+	workersActive     prometheus.Gauge
+
+	// Gauge for whether workers consider the database reachable
+	dbReachable prometheus.Gauge
+
+	// Counter for polls that found due work but every row was locked by another worker
+	lockContentionTotal prometheus.CounterVec
+
+	// Counter for panics recovered while a worker processed a webhook, by retry level
+	workerPanicsTotal prometheus.CounterVec
+
+	// Counter for COMPLETED webhooks found without a successful attempt
+	completionAnomaliesTotal prometheus.Counter
+
+	// Counter for PROCESSING webhooks returned to PENDING after their claim expired
+	claimsReclaimedTotal prometheus.Counter
+
+	// Counter for response bodies truncated to fit the stored response budget, by config
+	responseTruncatedTotal prometheus.CounterVec
+
+	// Counter for connections used by deliveries, by whether they were reused from the pool
+	connectionsTotal prometheus.CounterVec
+
+	// Counter for attempts that failed to resolve the receiver's host, by whether the host was not found
+	dnsErrorsTotal prometheus.CounterVec
+
+	// Counter for 429 responses rescheduled without consuming a retry, by retry level
+	throttledReschedulesTotal prometheus.CounterVec
+
+	// Counter for status callbacks sent, by event and whether the receiver acknowledged them
+	statusCallbacksTotal prometheus.CounterVec
+
+	// Counter for hedge requests sent because a first attempt was slow to respond
+	hedgesTotal prometheus.Counter
+
+	// Histogram for how much of its timeout each delivery used, by config
+	deliveryDurationRatio prometheus.HistogramVec
+
+	// Histograms for the size of delivery request bodies sent and response bodies received
+	requestBodyBytes  prometheus.Histogram
+	responseBodyBytes prometheus.Histogram
+
+	// Counter for attempts whose detail could not be recorded, by retry level
+	attemptDetailDroppedTotal prometheus.CounterVec
+
+	// Counters for webhooks in flight when the worker pool stopped, and how each was handled
+	shutdownInFlightTotal prometheus.Counter
+	shutdownDrainedTotal  prometheus.Counter
+	shutdownResetTotal    prometheus.Counter
 }
 
 // NewWebhookMetrics creates and registers simplified worker processing metrics
@@ -38,6 +100,185 @@ func NewWebhookMetrics() *WebhookMetrics {
 			},
 			[]string{"status_code", "retry_level"},
 		),
+
+		// Time spent waiting for the locking-transaction semaphore
+		workerInFlight: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "worker_in_flight",
+				Help: "Number of webhooks currently being processed by workers",
+			},
+		),
+
+		workerPoolRunning: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "webhook_worker_pool_running",
+				Help: "1 while the worker pool is started, 0 once it has stopped",
+			},
+		),
+		workersActive: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "webhook_workers_active",
+				Help: "Number of workers polling for webhooks; paused workers are not counted",
+			},
+		),
+
+		dbReachable: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "webhook_db_reachable",
+				Help: "1 while workers consider the database reachable, 0 while they have backed off polling",
+			},
+		),
+
+		lockingTxnWaitDuration: promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "db_locking_txn_wait_seconds",
+				Help:    "Time spent waiting for a free slot before opening a SELECT FOR UPDATE SKIP LOCKED transaction",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 5}, // seconds
+			},
+		),
+
+		lockWaitDuration: *promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "webhook_lock_wait_seconds",
+				Help:    "Time from opening a claim transaction to the next due row being selected and locked, by retry level",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 5}, // seconds
+			},
+			[]string{"retry_level"},
+		),
+
+		// SKIP LOCKED contention by retry level
+		lockContentionTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "worker_lock_contention_total",
+				Help: "Number of polls where due webhooks existed but all were locked by other workers, by retry level",
+			},
+			[]string{"retry_level"},
+		),
+
+		// Recovered worker panics by retry level
+		workerPanicsTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_worker_panics_total",
+				Help: "Number of panics recovered while a worker processed a webhook, by retry level",
+			},
+			[]string{"retry_level"},
+		),
+
+		// Expired processing claims
+		claimsReclaimedTotal: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "webhook_claims_reclaimed_total",
+				Help: "Number of PROCESSING webhooks returned to PENDING because their worker claim expired",
+			},
+		),
+
+		// Response bodies cut down by the stored response budget
+		responseTruncatedTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_response_truncated_total",
+				Help: "Number of response bodies truncated to fit the per-webhook stored response budget, by config",
+			},
+			[]string{"config_id"},
+		),
+
+		// Delivery connections, new versus kept alive
+		connectionsTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_connections_total",
+				Help: "Number of connections used by webhook deliveries, by whether they were reused from the idle pool",
+			},
+			[]string{"reused"},
+		),
+		dnsErrorsTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_dns_errors_total",
+				Help: "Number of delivery attempts whose host could not be resolved, by kind (not_found or transient)",
+			},
+			[]string{"kind"},
+		),
+		throttledReschedulesTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_throttled_reschedules_total",
+				Help: "Number of 429 responses rescheduled without consuming a retry, by retry level",
+			},
+			[]string{"retry_level"},
+		),
+		statusCallbacksTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_status_callbacks_total",
+				Help: "Number of on-success and on-failure status callbacks sent, by event and result (ok or failed)",
+			},
+			[]string{"event", "result"},
+		),
+		hedgesTotal: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "webhook_hedges_total",
+				Help: "Number of hedge requests sent because a first attempt had not responded within its hedge delay",
+			},
+		),
+
+		// Attempt duration over its timeout; ratios near 1.0 mean the timeout is tight or the receiver slow
+		deliveryDurationRatio: *promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "webhook_delivery_duration_ratio",
+				Help:    "Delivery attempt duration divided by the timeout applied to it, by config",
+				Buckets: []float64{0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 1},
+			},
+			[]string{"config_id"},
+		),
+
+		// Delivery body sizes, 64B to 1MiB
+		requestBodyBytes: promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "webhook_request_body_bytes",
+				Help:    "Size in bytes of webhook request bodies as sent, after any compression",
+				Buckets: prometheus.ExponentialBuckets(64, 4, 8),
+			},
+		),
+		responseBodyBytes: promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "webhook_response_body_bytes",
+				Help:    "Size in bytes of response bodies received from webhook endpoints, before any truncation",
+				Buckets: prometheus.ExponentialBuckets(64, 4, 8),
+			},
+		),
+
+		// Attempt records lost to a failed write
+		attemptDetailDroppedTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_attempt_detail_dropped_total",
+				Help: "Number of delivery attempts whose detail could not be recorded, by retry level",
+			},
+			[]string{"retry_level"},
+		),
+
+		// Consistency check anomalies
+		completionAnomaliesTotal: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "webhook_completion_anomalies_total",
+				Help: "Number of COMPLETED webhooks found by the consistency check with no successful attempt",
+			},
+		),
+
+		// Graceful shutdown outcomes
+		shutdownInFlightTotal: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_shutdown_in_flight_total",
+				Help: "Number of webhooks in flight when the worker pool was stopped",
+			},
+		),
+		shutdownDrainedTotal: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_shutdown_drained_total",
+				Help: "Number of in-flight webhooks whose attempt finished during shutdown",
+			},
+		),
+		shutdownResetTotal: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_shutdown_reset_total",
+				Help: "Number of in-flight webhooks reset to PENDING during shutdown",
+			},
+		),
 	}
 }
 
@@ -52,3 +293,126 @@ func (m *WebhookMetrics) RecordWorkerProcessing(statusCode int, retryLevel int,
 	// Record processing count by status code and retry level
 	m.workerProcessingTotal.WithLabelValues(statusCodeStr, retryLevelStr).Inc()
 }
+
+// RecordInFlight adjusts the number of webhooks workers are currently processing by delta
+func (m *WebhookMetrics) RecordInFlight(delta int) {
+	m.workerInFlight.Add(float64(delta))
+}
+
+// RecordWorkerPoolState records whether the worker pool is running and how many workers are polling
+func (m *WebhookMetrics) RecordWorkerPoolState(running bool, activeWorkers int) {
+	if running {
+		m.workerPoolRunning.Set(1)
+	} else {
+		m.workerPoolRunning.Set(0)
+	}
+	m.workersActive.Set(float64(activeWorkers))
+}
+
+// RecordDBReachable records whether workers consider the database reachable
+func (m *WebhookMetrics) RecordDBReachable(reachable bool) {
+	if reachable {
+		m.dbReachable.Set(1)
+	} else {
+		m.dbReachable.Set(0)
+	}
+}
+
+// RecordLockingTxnWait records how long a worker waited for a locking-transaction slot
+func (m *WebhookMetrics) RecordLockingTxnWait(duration time.Duration) {
+	m.lockingTxnWaitDuration.Observe(duration.Seconds())
+}
+
+// RecordLockWait records how long a claim took to select and lock a row, whether or not one was found
+func (m *WebhookMetrics) RecordLockWait(retryLevel int, duration time.Duration) {
+	m.lockWaitDuration.WithLabelValues(strconv.Itoa(retryLevel)).Observe(duration.Seconds())
+}
+
+// RecordLockContention records a poll that found due work but no unlocked row
+func (m *WebhookMetrics) RecordLockContention(retryLevel int) {
+	m.lockContentionTotal.WithLabelValues(strconv.Itoa(retryLevel)).Inc()
+}
+
+// RecordWorkerPanic records a panic recovered while a worker processed a webhook
+func (m *WebhookMetrics) RecordWorkerPanic(retryLevel int) {
+	m.workerPanicsTotal.WithLabelValues(strconv.Itoa(retryLevel)).Inc()
+}
+
+// RecordCompletionAnomaly records a COMPLETED webhook found without a successful attempt
+func (m *WebhookMetrics) RecordCompletionAnomaly() {
+	m.completionAnomaliesTotal.Inc()
+}
+
+// RecordClaimsReclaimed records webhooks returned to PENDING after their claim expired
+func (m *WebhookMetrics) RecordClaimsReclaimed(count int64) {
+	m.claimsReclaimedTotal.Add(float64(count))
+}
+
+// RecordResponseTruncated records a response body truncated to fit the stored response budget
+func (m *WebhookMetrics) RecordResponseTruncated(configID int64) {
+	m.responseTruncatedTotal.WithLabelValues(strconv.FormatInt(configID, 10)).Inc()
+}
+
+// RecordConnection records the connection a delivery was sent on and whether it was reused
+func (m *WebhookMetrics) RecordConnection(reused bool) {
+	m.connectionsTotal.WithLabelValues(strconv.FormatBool(reused)).Inc()
+}
+
+// RecordDNSError records an attempt whose host could not be resolved
+func (m *WebhookMetrics) RecordDNSError(notFound bool) {
+	kind := "transient"
+	if notFound {
+		kind = "not_found"
+	}
+	m.dnsErrorsTotal.WithLabelValues(kind).Inc()
+}
+
+// RecordThrottledReschedule records a 429 response rescheduled without consuming a retry
+func (m *WebhookMetrics) RecordThrottledReschedule(retryLevel int) {
+	m.throttledReschedulesTotal.WithLabelValues(strconv.Itoa(retryLevel)).Inc()
+}
+
+// RecordStatusCallback records a status callback sent for event and whether it was acknowledged
+func (m *WebhookMetrics) RecordStatusCallback(event string, ok bool) {
+	result := "failed"
+	if ok {
+		result = "ok"
+	}
+	m.statusCallbacksTotal.WithLabelValues(event, result).Inc()
+}
+
+// RecordHedge records a hedge request sent alongside a slow first attempt
+func (m *WebhookMetrics) RecordHedge() {
+	m.hedgesTotal.Inc()
+}
+
+// RecordDeliveryDurationRatio records how much of its timeout a delivery attempt used
+// Attempts without a timeout are skipped
+func (m *WebhookMetrics) RecordDeliveryDurationRatio(configID int64, duration, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	m.deliveryDurationRatio.WithLabelValues(strconv.FormatInt(configID, 10)).Observe(float64(duration) / float64(timeout))
+}
+
+// RecordRequestBodySize records the size of a delivery request body as sent
+func (m *WebhookMetrics) RecordRequestBodySize(bytes int64) {
+	m.requestBodyBytes.Observe(float64(bytes))
+}
+
+// RecordResponseBodySize records the size of a response body received from a webhook endpoint
+func (m *WebhookMetrics) RecordResponseBodySize(bytes int) {
+	m.responseBodyBytes.Observe(float64(bytes))
+}
+
+// RecordAttemptDetailDropped records a delivery attempt whose detail could not be written
+func (m *WebhookMetrics) RecordAttemptDetailDropped(retryLevel int) {
+	m.attemptDetailDroppedTotal.WithLabelValues(strconv.Itoa(retryLevel)).Inc()
+}
+
+// RecordShutdown records how in-flight webhooks were handled when the worker pool stopped
+func (m *WebhookMetrics) RecordShutdown(inFlight, drained, reset int) {
+	m.shutdownInFlightTotal.Add(float64(inFlight))
+	m.shutdownDrainedTotal.Add(float64(drained))
+	m.shutdownResetTotal.Add(float64(reset))
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"gorm.io/gorm/schema"
+)
+
+// NamingStrategy returns GORM's naming strategy with tables qualified by dbSchema, so one binary can
+// serve tenants isolated in separate schemas; an empty dbSchema leaves tables on the search path
+// dbSchema must already be validated as a plain identifier, since it is placed into every query
+func NamingStrategy(dbSchema string) schema.NamingStrategy {
+	strategy := schema.NamingStrategy{IdentifierMaxLength: 64}
+	if dbSchema != "" {
+		strategy.TablePrefix = dbSchema + "."
+	}
+	return strategy
+}
+
+// qualifiedTableName prefixes table with the schema carried by namer's table prefix, if any
+// The models name their tables explicitly, so GORM's pluralizing table namer is bypassed
+func qualifiedTableName(namer schema.Namer, table string) string {
+	if strategy, ok := namer.(schema.NamingStrategy); ok {
+		return strategy.TablePrefix + table
+	}
+	return table
+}
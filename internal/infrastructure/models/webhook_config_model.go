@@ -1,11 +1,15 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"webhook-processor/internal/domain/enums"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 // WebhookConfigModel represents the GORM model for webhook_configs table
@@ -19,11 +23,211 @@ type WebhookConfigModel struct {
 	CreatedAt  time.Time       `gorm:"default:NOW()" json:"created_at"`
 	UpdatedAt  time.Time       `gorm:"default:NOW()" json:"updated_at"`
 	DeletedAt  *time.Time      `gorm:"index" json:"deleted_at"`
+
+	StatusOutcomes StatusOutcomeMap `gorm:"type:jsonb" json:"status_outcomes"`
+
+	Transport *TransportSettingsModel `gorm:"column:transport_settings;type:jsonb" json:"transport_settings"`
+
+	CompressRequest bool `gorm:"default:false" json:"compress_request"`
+
+	DeliveryWindow *DeliveryWindowModel `gorm:"type:jsonb" json:"delivery_window"`
+
+	UseLiveURL bool `gorm:"column:use_live_url;default:false" json:"use_live_url"`
+
+	AcceptHeader *string `gorm:"type:varchar(255)" json:"accept_header"`
+
+	Headers HeaderMap `gorm:"type:jsonb" json:"headers"`
+
+	IsDefault bool `gorm:"default:false" json:"is_default"`
+
+	MaxRetries *int `json:"max_retries"`
+
+	RetryScheduleMs RetryScheduleModel `gorm:"type:jsonb" json:"retry_schedule_ms"`
+
+	Protocol enums.DeliveryProtocol `gorm:"type:varchar(16);default:'http'" json:"protocol"`
+
+	WrapPayload bool `gorm:"default:false" json:"wrap_payload"`
+
+	HTTPMethod      string  `gorm:"column:http_method;type:varchar(10);default:'POST'" json:"http_method"`
+	PayloadTemplate *string `gorm:"type:text" json:"payload_template"`
+
+	SigningSecret string `gorm:"type:text" json:"-"`
+
+	Idempotent bool `gorm:"default:false" json:"idempotent"`
+
+	HedgeAfterMs *int `json:"hedge_after_ms"`
+
+	FirstRetryDelayMs *int `json:"first_retry_delay_ms"`
+
+	AmbiguousErrorPolicy *enums.AmbiguousErrorPolicy `gorm:"type:varchar(32)" json:"ambiguous_error_policy"`
+
+	OnSuccessURL string `gorm:"type:text" json:"on_success_url"`
+	OnFailureURL string `gorm:"type:text" json:"on_failure_url"`
+}
+
+// StatusOutcomeMap stores per-status-code outcome overrides as JSONB
+type StatusOutcomeMap map[int]enums.ResponseOutcome
+
+// Value implements driver.Valuer
+func (m StatusOutcomeMap) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal status outcomes: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (m *StatusOutcomeMap) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for status outcomes: %T", value)
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("failed to unmarshal status outcomes: %w", err)
+	}
+	return nil
+}
+
+// RetryScheduleModel stores per-config retry delays in milliseconds as JSONB
+type RetryScheduleModel []int
+
+// Value implements driver.Valuer
+func (r RetryScheduleModel) Value() (driver.Value, error) {
+	if len(r) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal retry schedule: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (r *RetryScheduleModel) Scan(value interface{}) error {
+	if value == nil {
+		*r = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for retry schedule: %T", value)
+	}
+
+	if err := json.Unmarshal(data, r); err != nil {
+		return fmt.Errorf("failed to unmarshal retry schedule: %w", err)
+	}
+	return nil
+}
+
+// TransportSettingsModel stores per-config transport settings as JSONB
+type TransportSettingsModel struct {
+	ProxyURL                string `json:"proxy_url,omitempty"`
+	TLSMinVersion           string `json:"tls_min_version,omitempty"`
+	DialTimeoutMs           int    `json:"dial_timeout_ms,omitempty"`
+	TLSHandshakeTimeoutMs   int    `json:"tls_handshake_timeout_ms,omitempty"`
+	ResponseHeaderTimeoutMs int    `json:"response_header_timeout_ms,omitempty"`
+	IdleConnTimeoutMs       int    `json:"idle_conn_timeout_ms,omitempty"`
+	DisableKeepAlives       bool   `json:"disable_keep_alives,omitempty"`
+}
+
+// Value implements driver.Valuer
+func (t *TransportSettingsModel) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transport settings: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (t *TransportSettingsModel) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for transport settings: %T", value)
+	}
+
+	if err := json.Unmarshal(data, t); err != nil {
+		return fmt.Errorf("failed to unmarshal transport settings: %w", err)
+	}
+	return nil
+}
+
+// DeliveryWindowModel stores a per-config delivery window as JSONB
+type DeliveryWindowModel struct {
+	Timezone string           `json:"timezone"`
+	Days     []int            `json:"days,omitempty"`
+	Hours    []HourRangeModel `json:"hours,omitempty"`
+}
+
+// HourRangeModel is a stored [Start, End) hour range
+type HourRangeModel struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Value implements driver.Valuer
+func (d *DeliveryWindowModel) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal delivery window: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (d *DeliveryWindowModel) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for delivery window: %T", value)
+	}
+
+	if err := json.Unmarshal(data, d); err != nil {
+		return fmt.Errorf("failed to unmarshal delivery window: %w", err)
+	}
+	return nil
 }
 
-// TableName returns the table name for GORM
-func (WebhookConfigModel) TableName() string {
-	return "webhook_configs"
+// TableName returns the table name for GORM, in the configured schema
+func (WebhookConfigModel) TableName(namer schema.Namer) string {
+	return qualifiedTableName(namer, "webhook_configs")
 }
 
 // BeforeUpdate is a GORM hook that runs before updating a record
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// WebhookConfigStatsModel represents the GORM model for webhook_config_stats table
+type WebhookConfigStatsModel struct {
+	ConfigID       int64      `gorm:"primaryKey;autoIncrement:false" json:"config_id"`
+	TotalDelivered int64      `gorm:"not null;default:0" json:"total_delivered"`
+	TotalFailed    int64      `gorm:"not null;default:0" json:"total_failed"`
+	LastSuccessAt  *time.Time `json:"last_success_at"`
+	LastFailureAt  *time.Time `json:"last_failure_at"`
+	SuccessRate    float64    `gorm:"not null;default:0" json:"success_rate"`
+	UpdatedAt      time.Time  `gorm:"default:NOW()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM, in the configured schema
+func (WebhookConfigStatsModel) TableName(namer schema.Namer) string {
+	return qualifiedTableName(namer, "webhook_config_stats")
+}
@@ -1,12 +1,16 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"webhook-processor/internal/domain/enums"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 // WebhookQueueModel represents the GORM model for webhook_queue table
@@ -22,67 +26,116 @@ type WebhookQueueModel struct {
 	ConfigID   int64  `gorm:"not null" json:"config_id"`
 	WebhookURL string `gorm:"type:text;not null" json:"webhook_url"`
 
+	// Caller-supplied labels for filtering and reporting
+	Metadata MetadataMap `gorm:"type:jsonb" json:"metadata"`
+
+	// Request body supplied at creation
+	Payload string `gorm:"type:text" json:"payload"`
+
 	// Processing status
 	Status enums.WebhookStatus `gorm:"type:webhook_status;not null;default:'PENDING'" json:"status"`
 
 	// Retry tracking
-	RetryCount  int       `gorm:"not null;default:0" json:"retry_count"`
-	NextRetryAt time.Time `gorm:"not null;default:NOW()" json:"next_retry_at"`
+	RetryCount     int       `gorm:"not null;default:0" json:"retry_count"`
+	NextRetryAt    time.Time `gorm:"not null;default:NOW()" json:"next_retry_at"`
+	ThrottledCount int       `gorm:"not null;default:0" json:"throttled_count"`
 
 	// Individual retry attempt columns
 	Retry0StartedAt    *time.Time `gorm:"column:retry_0_started_at" json:"retry_0_started_at"`
 	Retry0CompletedAt  *time.Time `gorm:"column:retry_0_completed_at" json:"retry_0_completed_at"`
 	Retry0DurationMs   *int64     `gorm:"column:retry_0_duration_ms" json:"retry_0_duration_ms"`
+	Retry0TimeoutMs    *int64     `gorm:"column:retry_0_timeout_ms" json:"retry_0_timeout_ms"`
 	Retry0HTTPStatus   *int       `gorm:"column:retry_0_http_status" json:"retry_0_http_status"`
 	Retry0ResponseBody *string    `gorm:"column:retry_0_response_body;type:text" json:"retry_0_response_body"`
 	Retry0Error        *string    `gorm:"column:retry_0_error;type:text" json:"retry_0_error"`
+	Retry0ErrorClass   *string    `gorm:"column:retry_0_error_class;type:varchar(32)" json:"retry_0_error_class"`
+
+	Retry0RequestBody    *string   `gorm:"column:retry_0_request_body;type:text" json:"retry_0_request_body"`
+	Retry0RequestHeaders HeaderMap `gorm:"column:retry_0_request_headers;type:jsonb" json:"retry_0_request_headers"`
 
 	Retry1StartedAt    *time.Time `gorm:"column:retry_1_started_at" json:"retry_1_started_at"`
 	Retry1CompletedAt  *time.Time `gorm:"column:retry_1_completed_at" json:"retry_1_completed_at"`
 	Retry1DurationMs   *int64     `gorm:"column:retry_1_duration_ms" json:"retry_1_duration_ms"`
+	Retry1TimeoutMs    *int64     `gorm:"column:retry_1_timeout_ms" json:"retry_1_timeout_ms"`
 	Retry1HTTPStatus   *int       `gorm:"column:retry_1_http_status" json:"retry_1_http_status"`
 	Retry1ResponseBody *string    `gorm:"column:retry_1_response_body;type:text" json:"retry_1_response_body"`
 	Retry1Error        *string    `gorm:"column:retry_1_error;type:text" json:"retry_1_error"`
+	Retry1ErrorClass   *string    `gorm:"column:retry_1_error_class;type:varchar(32)" json:"retry_1_error_class"`
+
+	Retry1RequestBody    *string   `gorm:"column:retry_1_request_body;type:text" json:"retry_1_request_body"`
+	Retry1RequestHeaders HeaderMap `gorm:"column:retry_1_request_headers;type:jsonb" json:"retry_1_request_headers"`
 
 	Retry2StartedAt    *time.Time `gorm:"column:retry_2_started_at" json:"retry_2_started_at"`
 	Retry2CompletedAt  *time.Time `gorm:"column:retry_2_completed_at" json:"retry_2_completed_at"`
 	Retry2DurationMs   *int64     `gorm:"column:retry_2_duration_ms" json:"retry_2_duration_ms"`
+	Retry2TimeoutMs    *int64     `gorm:"column:retry_2_timeout_ms" json:"retry_2_timeout_ms"`
 	Retry2HTTPStatus   *int       `gorm:"column:retry_2_http_status" json:"retry_2_http_status"`
 	Retry2ResponseBody *string    `gorm:"column:retry_2_response_body;type:text" json:"retry_2_response_body"`
 	Retry2Error        *string    `gorm:"column:retry_2_error;type:text" json:"retry_2_error"`
+	Retry2ErrorClass   *string    `gorm:"column:retry_2_error_class;type:varchar(32)" json:"retry_2_error_class"`
+
+	Retry2RequestBody    *string   `gorm:"column:retry_2_request_body;type:text" json:"retry_2_request_body"`
+	Retry2RequestHeaders HeaderMap `gorm:"column:retry_2_request_headers;type:jsonb" json:"retry_2_request_headers"`
 
 	Retry3StartedAt    *time.Time `gorm:"column:retry_3_started_at" json:"retry_3_started_at"`
 	Retry3CompletedAt  *time.Time `gorm:"column:retry_3_completed_at" json:"retry_3_completed_at"`
 	Retry3DurationMs   *int64     `gorm:"column:retry_3_duration_ms" json:"retry_3_duration_ms"`
+	Retry3TimeoutMs    *int64     `gorm:"column:retry_3_timeout_ms" json:"retry_3_timeout_ms"`
 	Retry3HTTPStatus   *int       `gorm:"column:retry_3_http_status" json:"retry_3_http_status"`
 	Retry3ResponseBody *string    `gorm:"column:retry_3_response_body;type:text" json:"retry_3_response_body"`
 	Retry3Error        *string    `gorm:"column:retry_3_error;type:text" json:"retry_3_error"`
+	Retry3ErrorClass   *string    `gorm:"column:retry_3_error_class;type:varchar(32)" json:"retry_3_error_class"`
+
+	Retry3RequestBody    *string   `gorm:"column:retry_3_request_body;type:text" json:"retry_3_request_body"`
+	Retry3RequestHeaders HeaderMap `gorm:"column:retry_3_request_headers;type:jsonb" json:"retry_3_request_headers"`
 
 	Retry4StartedAt    *time.Time `gorm:"column:retry_4_started_at" json:"retry_4_started_at"`
 	Retry4CompletedAt  *time.Time `gorm:"column:retry_4_completed_at" json:"retry_4_completed_at"`
 	Retry4DurationMs   *int64     `gorm:"column:retry_4_duration_ms" json:"retry_4_duration_ms"`
+	Retry4TimeoutMs    *int64     `gorm:"column:retry_4_timeout_ms" json:"retry_4_timeout_ms"`
 	Retry4HTTPStatus   *int       `gorm:"column:retry_4_http_status" json:"retry_4_http_status"`
 	Retry4ResponseBody *string    `gorm:"column:retry_4_response_body;type:text" json:"retry_4_response_body"`
 	Retry4Error        *string    `gorm:"column:retry_4_error;type:text" json:"retry_4_error"`
+	Retry4ErrorClass   *string    `gorm:"column:retry_4_error_class;type:varchar(32)" json:"retry_4_error_class"`
+
+	Retry4RequestBody    *string   `gorm:"column:retry_4_request_body;type:text" json:"retry_4_request_body"`
+	Retry4RequestHeaders HeaderMap `gorm:"column:retry_4_request_headers;type:jsonb" json:"retry_4_request_headers"`
 
 	Retry5StartedAt    *time.Time `gorm:"column:retry_5_started_at" json:"retry_5_started_at"`
 	Retry5CompletedAt  *time.Time `gorm:"column:retry_5_completed_at" json:"retry_5_completed_at"`
 	Retry5DurationMs   *int64     `gorm:"column:retry_5_duration_ms" json:"retry_5_duration_ms"`
+	Retry5TimeoutMs    *int64     `gorm:"column:retry_5_timeout_ms" json:"retry_5_timeout_ms"`
 	Retry5HTTPStatus   *int       `gorm:"column:retry_5_http_status" json:"retry_5_http_status"`
 	Retry5ResponseBody *string    `gorm:"column:retry_5_response_body;type:text" json:"retry_5_response_body"`
 	Retry5Error        *string    `gorm:"column:retry_5_error;type:text" json:"retry_5_error"`
+	Retry5ErrorClass   *string    `gorm:"column:retry_5_error_class;type:varchar(32)" json:"retry_5_error_class"`
+
+	Retry5RequestBody    *string   `gorm:"column:retry_5_request_body;type:text" json:"retry_5_request_body"`
+	Retry5RequestHeaders HeaderMap `gorm:"column:retry_5_request_headers;type:jsonb" json:"retry_5_request_headers"`
 
 	Retry6StartedAt    *time.Time `gorm:"column:retry_6_started_at" json:"retry_6_started_at"`
 	Retry6CompletedAt  *time.Time `gorm:"column:retry_6_completed_at" json:"retry_6_completed_at"`
 	Retry6DurationMs   *int64     `gorm:"column:retry_6_duration_ms" json:"retry_6_duration_ms"`
+	Retry6TimeoutMs    *int64     `gorm:"column:retry_6_timeout_ms" json:"retry_6_timeout_ms"`
 	Retry6HTTPStatus   *int       `gorm:"column:retry_6_http_status" json:"retry_6_http_status"`
 	Retry6ResponseBody *string    `gorm:"column:retry_6_response_body;type:text" json:"retry_6_response_body"`
 	Retry6Error        *string    `gorm:"column:retry_6_error;type:text" json:"retry_6_error"`
+	Retry6ErrorClass   *string    `gorm:"column:retry_6_error_class;type:varchar(32)" json:"retry_6_error_class"`
+
+	Retry6RequestBody    *string   `gorm:"column:retry_6_request_body;type:text" json:"retry_6_request_body"`
+	Retry6RequestHeaders HeaderMap `gorm:"column:retry_6_request_headers;type:jsonb" json:"retry_6_request_headers"`
+
+	// Attempts at retry levels above the fixed columns
+	RetryAttempts RetryAttemptList `gorm:"type:jsonb" json:"retry_attempts"`
 
 	// General tracking
 	LastError      string `gorm:"type:text" json:"last_error"`
 	LastHTTPStatus int    `json:"last_http_status"`
 
+	// Processing claim, held by a worker while the webhook is PROCESSING
+	ClaimedBy      *string    `gorm:"type:varchar(100)" json:"claimed_by"`
+	ClaimExpiresAt *time.Time `json:"claim_expires_at"`
+
 	// Timestamps
 	CreatedAt           time.Time  `gorm:"default:NOW()" json:"created_at"`
 	UpdatedAt           time.Time  `gorm:"default:NOW()" json:"updated_at"`
@@ -91,9 +144,9 @@ type WebhookQueueModel struct {
 	DeletedAt           *time.Time `gorm:"index" json:"deleted_at"`
 }
 
-// TableName returns the table name for GORM
-func (WebhookQueueModel) TableName() string {
-	return "webhook_queue"
+// TableName returns the table name for GORM, in the configured schema
+func (WebhookQueueModel) TableName(namer schema.Namer) string {
+	return qualifiedTableName(namer, "webhook_queue")
 }
 
 // BeforeCreate is a GORM hook that runs before creating a record
@@ -109,3 +162,98 @@ func (w *WebhookQueueModel) BeforeUpdate(tx *gorm.DB) error {
 	w.UpdatedAt = time.Now().UTC()
 	return nil
 }
+
+// MetadataMap stores webhook metadata labels as JSONB
+type MetadataMap map[string]string
+
+// HeaderMap stores request headers as JSONB, encoded like metadata: an attempt's redacted headers or a
+// config's custom headers
+type HeaderMap = MetadataMap
+
+// Value implements driver.Valuer
+func (m MetadataMap) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (m *MetadataMap) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for metadata: %T", value)
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	return nil
+}
+
+// RetryAttemptModel is one attempt stored in the retry_attempts list, with the fields of a retry_N column set
+type RetryAttemptModel struct {
+	RetryLevel     int               `json:"retry_level"`
+	StartedAt      *time.Time        `json:"started_at,omitempty"`
+	CompletedAt    *time.Time        `json:"completed_at,omitempty"`
+	DurationMs     *int64            `json:"duration_ms,omitempty"`
+	TimeoutMs      *int64            `json:"timeout_ms,omitempty"`
+	HTTPStatus     *int              `json:"http_status,omitempty"`
+	ResponseBody   *string           `json:"response_body,omitempty"`
+	Error          *string           `json:"error,omitempty"`
+	ErrorClass     *string           `json:"error_class,omitempty"`
+	RequestBody    *string           `json:"request_body,omitempty"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+}
+
+// RetryAttemptList stores the attempts at retry levels above the fixed columns as a JSONB array
+type RetryAttemptList []RetryAttemptModel
+
+// Value implements driver.Valuer
+func (l RetryAttemptList) Value() (driver.Value, error) {
+	if len(l) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal retry attempts: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (l *RetryAttemptList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for retry attempts: %T", value)
+	}
+
+	if err := json.Unmarshal(data, l); err != nil {
+		return fmt.Errorf("failed to unmarshal retry attempts: %w", err)
+	}
+	return nil
+}
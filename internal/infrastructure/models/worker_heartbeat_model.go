@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// WorkerHeartbeatModel represents the GORM model for worker_heartbeats table
+type WorkerHeartbeatModel struct {
+	WorkerID   string    `gorm:"primaryKey;type:varchar(100)" json:"worker_id"`
+	RetryLevel int       `gorm:"not null" json:"retry_level"`
+	Host       string    `gorm:"type:varchar(255);not null" json:"host"`
+	StartedAt  time.Time `gorm:"not null" json:"started_at"`
+	LastSeenAt time.Time `gorm:"not null" json:"last_seen_at"`
+}
+
+// TableName returns the table name for GORM, in the configured schema
+func (WorkerHeartbeatModel) TableName(namer schema.Namer) string {
+	return qualifiedTableName(namer, "worker_heartbeats")
+}
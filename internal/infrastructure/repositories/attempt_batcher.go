@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/infrastructure/models"
+)
+
+// attemptWrite is one level-0 attempt's column updates for its row, written only under workerID's claim
+type attemptWrite struct {
+	webhookID int64
+	workerID  string
+	updates   map[string]interface{}
+}
+
+// queuedAttempt is an attempt write waiting in a batch, with where its outcome is reported
+type queuedAttempt struct {
+	write *attemptWrite
+	done  chan error
+}
+
+// attemptBatcher collects attempt writes from concurrent workers and hands them to write together,
+// once size have arrived or wait has passed since the first one; each caller blocks until its own write landed
+type attemptBatcher struct {
+	size  int
+	wait  time.Duration
+	write func(ctx context.Context, writes []*attemptWrite) []error
+
+	mu      sync.Mutex
+	pending []*queuedAttempt
+	timer   *time.Timer // Non-nil while a partial batch waits
+}
+
+// newAttemptBatcher creates a batcher flushing every size writes, or wait after the first of a partial batch
+func newAttemptBatcher(size int, wait time.Duration, write func(ctx context.Context, writes []*attemptWrite) []error) *attemptBatcher {
+	return &attemptBatcher{size: size, wait: wait, write: write}
+}
+
+// submit queues write and returns its outcome once its batch is written
+func (b *attemptBatcher) submit(write *attemptWrite) error {
+	queued := &queuedAttempt{write: write, done: make(chan error, 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, queued)
+	var full []*queuedAttempt
+	if len(b.pending) >= b.size {
+		full = b.take()
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(b.wait, b.flushPending)
+	}
+	b.mu.Unlock()
+
+	// The caller completing a batch writes it, so a full batch never waits for the timer
+	if full != nil {
+		b.flush(full)
+	}
+	return <-queued.done
+}
+
+// take removes the pending batch and stops its timer; b.mu must be held
+func (b *attemptBatcher) take() []*queuedAttempt {
+	batch := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	return batch
+}
+
+// flushPending writes whatever partial batch is waiting once its wait has passed
+func (b *attemptBatcher) flushPending() {
+	b.mu.Lock()
+	batch := b.take()
+	b.mu.Unlock()
+
+	if len(batch) > 0 {
+		b.flush(batch)
+	}
+}
+
+// flush writes batch and reports each write's outcome to its caller
+func (b *attemptBatcher) flush(batch []*queuedAttempt) {
+	writes := make([]*attemptWrite, len(batch))
+	for i, queued := range batch {
+		writes[i] = queued.write
+	}
+
+	// The batch outlives any one caller's context, so an attempt already made is still recorded while stopping
+	errs := b.write(context.Background(), writes)
+	for i, queued := range batch {
+		queued.done <- errs[i]
+	}
+}
+
+// writeAttemptBatch writes level-0 attempts for several rows in one statement, setting each column through a
+// CASE on the row id; a row without a column in its updates keeps its current value.
+// If the statement fails or misses a row, every write is retried alone, which is safe as level-0 columns are
+// set outright, so each caller gets its own row's outcome and no attempt is lost to another row's problem
+func (r *webhookQueueRepositoryImpl) writeAttemptBatch(ctx context.Context, writes []*attemptWrite) []error {
+	errs := make([]error, len(writes))
+	if len(writes) == 1 {
+		errs[0] = r.writeAttempt(ctx, writes[0].webhookID, writes[0].workerID, 0, writes[0].updates)
+		return errs
+	}
+
+	updates, claims := batchedAttemptUpdates(writes)
+	result := r.db.WithContext(ctx).
+		Model(&models.WebhookQueueModel{}).
+		Where("(id, claimed_by) IN ? AND status = ? AND retry_count = ?", claims, enums.WebhookStatusProcessing, 0).
+		Updates(updates)
+	if result.Error == nil && result.RowsAffected == int64(len(writes)) {
+		return errs
+	}
+
+	r.logger.Log("level", "warn", "msg", "batched attempt write incomplete, writing attempts one by one",
+		"batch_size", len(writes), "rows_affected", result.RowsAffected, "error", result.Error)
+	for i, write := range writes {
+		errs[i] = r.writeAttempt(ctx, write.webhookID, write.workerID, 0, write.updates)
+	}
+	return errs
+}
+
+// batchedAttemptUpdates merges writes into one update setting each column per row id, and returns the
+// (id, claimed_by) pair each row must still have
+func batchedAttemptUpdates(writes []*attemptWrite) (map[string]interface{}, [][]interface{}) {
+	claims := make([][]interface{}, 0, len(writes))
+	var columns []string
+	for _, write := range writes {
+		claims = append(claims, []interface{}{write.webhookID, write.workerID})
+		for column := range write.updates {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+	slices.Sort(columns)
+
+	updates := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		var sql strings.Builder
+		var vars []interface{}
+		sql.WriteString("CASE id")
+		for _, write := range writes {
+			if value, ok := write.updates[column]; ok {
+				sql.WriteString(" WHEN ? THEN ?")
+				vars = append(vars, write.webhookID, value)
+			}
+		}
+		fmt.Fprintf(&sql, " ELSE %s END", column)
+		updates[column] = gorm.Expr(sql.String(), vars...)
+	}
+	return updates, claims
+}
@@ -0,0 +1,253 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+)
+
+// capturedUpdate is one UPDATE statement a test repository ran
+type capturedUpdate struct {
+	sql     string
+	vars    []interface{}
+	updates map[string]interface{}
+}
+
+// testAttempt is the outcome of one level-0 attempt, distinct per webhook
+type testAttempt struct {
+	webhookID  int64
+	httpStatus int
+	body       string
+	errorMsg   string
+	request    *entities.AttemptRequest
+}
+
+// newAttemptBatchRepo returns a repository batching up to size attempts, recording every UPDATE it runs;
+// rowsAffected decides what each statement reports, and an error fails it
+func newAttemptBatchRepo(t *testing.T, size int, wait time.Duration, rowsAffected func(update capturedUpdate) (int64, error)) (*webhookQueueRepositoryImpl, func() []capturedUpdate) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var captured []capturedUpdate
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_attempts", func(tx *gorm.DB) {
+		updates, _ := tx.Statement.Dest.(map[string]interface{})
+		update := capturedUpdate{sql: tx.Statement.SQL.String(), vars: tx.Statement.Vars, updates: updates}
+		mu.Lock()
+		captured = append(captured, update)
+		mu.Unlock()
+
+		affected, err := rowsAffected(update)
+		if err != nil {
+			tx.AddError(err)
+			return
+		}
+		tx.RowsAffected = affected
+	}))
+
+	repo := &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger()}
+	if size > 1 {
+		repo.attemptBatcher = newAttemptBatcher(size, wait, repo.writeAttemptBatch)
+	}
+	return repo, func() []capturedUpdate {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]capturedUpdate(nil), captured...)
+	}
+}
+
+// everyRowMatches reports each statement as updating every row it names
+func everyRowMatches(update capturedUpdate) (int64, error) {
+	if !isBatch(update) {
+		return 1, nil
+	}
+	claims := update.sql[strings.Index(update.sql, "claimed_by) IN (")+len("claimed_by) IN ("):]
+	return int64(strings.Count(claims[:strings.Index(claims, "))")], "),(") + 1), nil
+}
+
+// isBatch says whether update is a batched statement rather than one row's
+func isBatch(update capturedUpdate) bool {
+	return strings.Contains(update.sql, "(id, claimed_by) IN")
+}
+
+// attemptRowID returns the row a single attempt write targets; its conditions end with the row's id,
+// status, claimant and retry count
+func attemptRowID(update capturedUpdate) int64 {
+	return update.vars[len(update.vars)-4].(int64)
+}
+
+// attemptWorkerID is the worker holding each test webhook's claim
+func attemptWorkerID(webhookID int64) string {
+	return fmt.Sprintf("worker-%d", webhookID)
+}
+
+// recordAttempts records attempts concurrently, returning each one's error by webhook id
+func recordAttempts(repo *webhookQueueRepositoryImpl, startedAt time.Time, attempts []testAttempt) map[int64]error {
+	var mu sync.Mutex
+	errs := make(map[int64]error)
+	var wg sync.WaitGroup
+	for _, attempt := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			completedAt := startedAt.Add(time.Duration(attempt.webhookID) * time.Millisecond)
+			err := repo.UpdateRetryAttempt(context.Background(), attempt.webhookID, attemptWorkerID(attempt.webhookID), 0, startedAt, &completedAt,
+				attempt.webhookID*10, 5000, attempt.httpStatus, attempt.body, attempt.errorMsg, enums.ErrorClassHTTPStatus, attempt.request)
+			mu.Lock()
+			errs[attempt.webhookID] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// applyUpdate applies a captured statement to rows the way Postgres would, resolving each batched
+// CASE expression to the row's own value or, without one, to the value the row already has
+func applyUpdate(rows map[int64]map[string]interface{}, id int64, update capturedUpdate) {
+	for column, value := range update.updates {
+		if column == "updated_at" {
+			continue
+		}
+		expr, ok := value.(clause.Expr)
+		if !ok || !strings.HasPrefix(expr.SQL, "CASE id") {
+			rows[id][column] = value
+			continue
+		}
+		for i := 0; i < len(expr.Vars); i += 2 {
+			if expr.Vars[i].(int64) == id {
+				rows[id][column] = expr.Vars[i+1]
+			}
+		}
+	}
+}
+
+func TestWebhookQueueRepositoryImpl_AttemptBatching(t *testing.T) {
+	startedAt := time.Now().UTC()
+	attempts := []testAttempt{
+		{webhookID: 1, httpStatus: 200, body: "ok"},
+		{webhookID: 2, httpStatus: 503, body: "unavailable", errorMsg: "HTTP 503"},
+		{webhookID: 3, httpStatus: 400, body: "bad request", errorMsg: "HTTP 400",
+			request: &entities.AttemptRequest{Body: `{"id":"evt-3"}`, Headers: map[string]string{"Content-Type": "application/json"}}},
+	}
+
+	// initialRows is every row before its attempt, holding values an attempt may leave untouched
+	initialRows := func() map[int64]map[string]interface{} {
+		rows := make(map[int64]map[string]interface{})
+		for _, attempt := range attempts {
+			rows[attempt.webhookID] = map[string]interface{}{"last_error": "earlier error", "retry_0_error": nil}
+		}
+		return rows
+	}
+
+	t.Run("should leave every row as per-row writes do while running one statement", func(t *testing.T) {
+		perRowRepo, perRowUpdates := newAttemptBatchRepo(t, 1, 0, everyRowMatches)
+		batchRepo, batchUpdates := newAttemptBatchRepo(t, len(attempts), time.Hour, everyRowMatches)
+
+		for id, err := range recordAttempts(perRowRepo, startedAt, attempts) {
+			require.NoError(t, err, "webhook %d", id)
+		}
+		for id, err := range recordAttempts(batchRepo, startedAt, attempts) {
+			require.NoError(t, err, "webhook %d", id)
+		}
+
+		perRow := initialRows()
+		updates := perRowUpdates()
+		require.Len(t, updates, len(attempts))
+		for _, update := range updates {
+			applyUpdate(perRow, attemptRowID(update), update)
+		}
+
+		batched := initialRows()
+		updates = batchUpdates()
+		require.Len(t, updates, 1, "a full batch is one statement")
+		assert.Contains(t, updates[0].sql, "retry_count = ")
+		assert.Contains(t, updates[0].sql, "status = ")
+		for _, attempt := range attempts {
+			assert.Contains(t, updates[0].vars, attemptWorkerID(attempt.webhookID), "each row must still be claimed by its own worker")
+		}
+		for id := range batched {
+			applyUpdate(batched, id, updates[0])
+		}
+
+		assert.Equal(t, perRow, batched)
+		assert.Equal(t, "earlier error", batched[1]["last_error"], "a row without an error keeps its earlier one")
+		assert.Equal(t, "HTTP 503", batched[2]["retry_0_error"])
+		assert.Equal(t, `{"id":"evt-3"}`, batched[3]["retry_0_request_body"])
+		assert.NotContains(t, batched[1], "retry_0_request_body")
+	})
+
+	t.Run("should write a partial batch once the wait has passed", func(t *testing.T) {
+		repo, captured := newAttemptBatchRepo(t, 10, 10*time.Millisecond, everyRowMatches)
+
+		errs := recordAttempts(repo, startedAt, attempts[:1])
+
+		require.NoError(t, errs[1])
+		updates := captured()
+		require.Len(t, updates, 1)
+		assert.False(t, isBatch(updates[0]), "a lone attempt is written as its own row")
+	})
+
+	t.Run("should write each attempt alone when the batch misses a row", func(t *testing.T) {
+		repo, captured := newAttemptBatchRepo(t, len(attempts), time.Hour, func(update capturedUpdate) (int64, error) {
+			if isBatch(update) {
+				return int64(len(attempts) - 1), nil
+			}
+			// Webhook 2 was reclaimed by another worker, so its own write matches nothing
+			if attemptRowID(update) == 2 {
+				return 0, nil
+			}
+			return 1, nil
+		})
+
+		errs := recordAttempts(repo, startedAt, attempts)
+
+		assert.NoError(t, errs[1])
+		assert.ErrorIs(t, errs[2], repositories.ErrClaimLost)
+		assert.ErrorContains(t, errs[2], "webhook 2 is missing, not claimed by worker-2 or its retry count is not 0")
+		assert.NoError(t, errs[3])
+		assert.Len(t, captured(), 1+len(attempts))
+	})
+
+	t.Run("should write each attempt alone when the batch statement fails", func(t *testing.T) {
+		repo, captured := newAttemptBatchRepo(t, len(attempts), time.Hour, func(update capturedUpdate) (int64, error) {
+			if isBatch(update) {
+				return 0, errors.New("canceling statement due to statement timeout")
+			}
+			return 1, nil
+		})
+
+		errs := recordAttempts(repo, startedAt, attempts)
+
+		for id, err := range errs {
+			assert.NoError(t, err, "webhook %d", id)
+		}
+		assert.Len(t, captured(), 1+len(attempts))
+	})
+
+	t.Run("should write attempts above level 0 without waiting for a batch", func(t *testing.T) {
+		repo, captured := newAttemptBatchRepo(t, 10, time.Hour, everyRowMatches)
+
+		err := repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 2, startedAt, nil, 10, 0, 500, "", "HTTP 500", enums.ErrorClassHTTPStatus, nil)
+
+		require.NoError(t, err)
+		require.Len(t, captured(), 1)
+		assert.Contains(t, captured()[0].updates, "retry_2_http_status")
+	})
+}
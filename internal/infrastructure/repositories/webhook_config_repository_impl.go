@@ -3,10 +3,12 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
 	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
 	"webhook-processor/internal/domain/repositories"
 	"webhook-processor/internal/infrastructure/models"
 )
@@ -36,9 +38,203 @@ func (r *webhookConfigRepositoryImpl) GetByID(ctx context.Context, id int64) (*e
 	return r.modelToEntity(&model), nil
 }
 
+// GetDefaultForEventType retrieves the active default config for an event type
+func (r *webhookConfigRepositoryImpl) GetDefaultForEventType(ctx context.Context, eventType enums.EventType) (*entities.WebhookConfig, error) {
+	var model models.WebhookConfigModel
+	if err := r.db.WithContext(ctx).
+		Where("event_type = ? AND is_default = ? AND is_active = ? AND deleted_at IS NULL", eventType, true, true).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get default webhook config for %s: %w", eventType, err)
+	}
+	return r.modelToEntity(&model), nil
+}
+
+// GetActiveByEventType retrieves every active, non-deleted config for an event type ordered by ID
+func (r *webhookConfigRepositoryImpl) GetActiveByEventType(ctx context.Context, eventType enums.EventType) ([]*entities.WebhookConfig, error) {
+	var modelList []models.WebhookConfigModel
+	if err := r.db.WithContext(ctx).
+		Where("event_type = ? AND is_active = ? AND deleted_at IS NULL", eventType, true).
+		Order("id").
+		Find(&modelList).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active webhook configs for %s: %w", eventType, err)
+	}
+	configs := make([]*entities.WebhookConfig, 0, len(modelList))
+	for i := range modelList {
+		configs = append(configs, r.modelToEntity(&modelList[i]))
+	}
+	return configs, nil
+}
+
+// ListActive retrieves every active, non-deleted config ordered by ID
+func (r *webhookConfigRepositoryImpl) ListActive(ctx context.Context) ([]*entities.WebhookConfig, error) {
+	var modelList []models.WebhookConfigModel
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ? AND deleted_at IS NULL", true).
+		Order("id").
+		Find(&modelList).Error; err != nil {
+		return nil, fmt.Errorf("failed to list active webhook configs: %w", err)
+	}
+	configs := make([]*entities.WebhookConfig, 0, len(modelList))
+	for i := range modelList {
+		configs = append(configs, r.modelToEntity(&modelList[i]))
+	}
+	return configs, nil
+}
+
+// GetStats retrieves the delivery statistics rollup for a config
+func (r *webhookConfigRepositoryImpl) GetStats(ctx context.Context, configID int64) (*entities.WebhookConfigStats, error) {
+	var model models.WebhookConfigStatsModel
+	if err := r.db.WithContext(ctx).Where("config_id = ?", configID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook config stats: %w", err)
+	}
+	return configStatsModelToEntity(&model), nil
+}
+
+// Create inserts a config, setting its ID and timestamps
+// Every column is written, so an inactive config is not turned active by the column default
+func (r *webhookConfigRepositoryImpl) Create(ctx context.Context, config *entities.WebhookConfig) error {
+	model := r.entityToModel(config)
+	now := time.Now().UTC()
+	model.CreatedAt, model.UpdatedAt = now, now
+	model.Protocol = config.DeliveryProtocol()
+	model.HTTPMethod = config.Method()
+
+	if err := r.db.WithContext(ctx).Select("*").Omit("ID", "DeletedAt").Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create webhook config: %w", err)
+	}
+
+	config.ID = model.ID
+	config.CreatedAt = model.CreatedAt
+	config.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Update replaces a non-deleted config's name, event type, URL, timeout and active flag
+// Delivery settings such as headers and retry policy are left as they are
+func (r *webhookConfigRepositoryImpl) Update(ctx context.Context, config *entities.WebhookConfig) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.WebhookConfigModel{}).
+		Where("id = ? AND deleted_at IS NULL", config.ID).
+		Updates(map[string]interface{}{
+			"name":        config.Name,
+			"event_type":  config.EventType,
+			"webhook_url": config.WebhookURL,
+			"timeout_ms":  config.TimeoutMs,
+			"is_active":   config.IsActive,
+			"updated_at":  time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update webhook config: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Delete soft-deletes a config and deactivates it, so no new webhooks are created for it
+// Webhooks already queued keep their config's delivery settings
+func (r *webhookConfigRepositoryImpl) Delete(ctx context.Context, id int64) (bool, error) {
+	now := time.Now().UTC()
+	result := r.db.WithContext(ctx).
+		Model(&models.WebhookConfigModel{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": now,
+			"is_active":  false,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete webhook config: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// List retrieves every non-deleted config ordered by ID
+func (r *webhookConfigRepositoryImpl) List(ctx context.Context) ([]*entities.WebhookConfig, error) {
+	var modelList []models.WebhookConfigModel
+	if err := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL").
+		Order("id").
+		Find(&modelList).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook configs: %w", err)
+	}
+	configs := make([]*entities.WebhookConfig, 0, len(modelList))
+	for i := range modelList {
+		configs = append(configs, r.modelToEntity(&modelList[i]))
+	}
+	return configs, nil
+}
+
+// entityToModel converts domain entity to GORM model
+func (r *webhookConfigRepositoryImpl) entityToModel(config *entities.WebhookConfig) *models.WebhookConfigModel {
+	model := &models.WebhookConfigModel{
+		ID:         config.ID,
+		Name:       config.Name,
+		EventType:  config.EventType,
+		WebhookURL: config.WebhookURL,
+		IsActive:   config.IsActive,
+		TimeoutMs:  config.TimeoutMs,
+		CreatedAt:  config.CreatedAt,
+		UpdatedAt:  config.UpdatedAt,
+
+		StatusOutcomes:    config.StatusOutcomes,
+		CompressRequest:   config.CompressRequest,
+		UseLiveURL:        config.UseLiveURL,
+		AcceptHeader:      config.AcceptHeader,
+		Headers:           config.Headers,
+		IsDefault:         config.IsDefault,
+		MaxRetries:        config.MaxRetries,
+		RetryScheduleMs:   config.RetryScheduleMs,
+		Protocol:          config.Protocol,
+		WrapPayload:       config.WrapPayload,
+		HTTPMethod:        config.HTTPMethod,
+		PayloadTemplate:   config.PayloadTemplate,
+		SigningSecret:     config.SigningSecret,
+		Idempotent:        config.Idempotent,
+		HedgeAfterMs:      config.HedgeAfterMs,
+		FirstRetryDelayMs: config.FirstRetryDelayMs,
+		OnSuccessURL:      config.OnSuccessURL,
+		OnFailureURL:      config.OnFailureURL,
+	}
+
+	if config.AmbiguousErrorPolicy != "" {
+		policy := config.AmbiguousErrorPolicy
+		model.AmbiguousErrorPolicy = &policy
+	}
+
+	if config.Transport != nil {
+		model.Transport = &models.TransportSettingsModel{
+			ProxyURL:                config.Transport.ProxyURL,
+			TLSMinVersion:           config.Transport.TLSMinVersion,
+			DialTimeoutMs:           config.Transport.DialTimeoutMs,
+			TLSHandshakeTimeoutMs:   config.Transport.TLSHandshakeTimeoutMs,
+			ResponseHeaderTimeoutMs: config.Transport.ResponseHeaderTimeoutMs,
+			IdleConnTimeoutMs:       config.Transport.IdleConnTimeoutMs,
+			DisableKeepAlives:       config.Transport.DisableKeepAlives,
+		}
+	}
+
+	if config.DeliveryWindow != nil {
+		window := &models.DeliveryWindowModel{Timezone: config.DeliveryWindow.Timezone}
+		for _, day := range config.DeliveryWindow.Days {
+			window.Days = append(window.Days, int(day))
+		}
+		for _, hours := range config.DeliveryWindow.Hours {
+			window.Hours = append(window.Hours, models.HourRangeModel{Start: hours.Start, End: hours.End})
+		}
+		model.DeliveryWindow = window
+	}
+
+	return model
+}
+
 // modelToEntity converts GORM model to domain entity
 func (r *webhookConfigRepositoryImpl) modelToEntity(model *models.WebhookConfigModel) *entities.WebhookConfig {
-	return &entities.WebhookConfig{
+	config := &entities.WebhookConfig{
 		ID:         model.ID,
 		Name:       model.Name,
 		EventType:  model.EventType,
@@ -47,5 +243,53 @@ func (r *webhookConfigRepositoryImpl) modelToEntity(model *models.WebhookConfigM
 		TimeoutMs:  model.TimeoutMs,
 		CreatedAt:  model.CreatedAt,
 		UpdatedAt:  model.UpdatedAt,
+
+		StatusOutcomes:    model.StatusOutcomes,
+		CompressRequest:   model.CompressRequest,
+		UseLiveURL:        model.UseLiveURL,
+		AcceptHeader:      model.AcceptHeader,
+		Headers:           model.Headers,
+		IsDefault:         model.IsDefault,
+		MaxRetries:        model.MaxRetries,
+		RetryScheduleMs:   model.RetryScheduleMs,
+		Protocol:          model.Protocol,
+		WrapPayload:       model.WrapPayload,
+		HTTPMethod:        model.HTTPMethod,
+		PayloadTemplate:   model.PayloadTemplate,
+		SigningSecret:     model.SigningSecret,
+		Idempotent:        model.Idempotent,
+		HedgeAfterMs:      model.HedgeAfterMs,
+		FirstRetryDelayMs: model.FirstRetryDelayMs,
+		OnSuccessURL:      model.OnSuccessURL,
+		OnFailureURL:      model.OnFailureURL,
+	}
+
+	if model.AmbiguousErrorPolicy != nil {
+		config.AmbiguousErrorPolicy = *model.AmbiguousErrorPolicy
 	}
+
+	if model.Transport != nil {
+		config.Transport = &entities.TransportSettings{
+			ProxyURL:                model.Transport.ProxyURL,
+			TLSMinVersion:           model.Transport.TLSMinVersion,
+			DialTimeoutMs:           model.Transport.DialTimeoutMs,
+			TLSHandshakeTimeoutMs:   model.Transport.TLSHandshakeTimeoutMs,
+			ResponseHeaderTimeoutMs: model.Transport.ResponseHeaderTimeoutMs,
+			IdleConnTimeoutMs:       model.Transport.IdleConnTimeoutMs,
+			DisableKeepAlives:       model.Transport.DisableKeepAlives,
+		}
+	}
+
+	if model.DeliveryWindow != nil {
+		window := &entities.DeliveryWindow{Timezone: model.DeliveryWindow.Timezone}
+		for _, day := range model.DeliveryWindow.Days {
+			window.Days = append(window.Days, time.Weekday(day))
+		}
+		for _, hours := range model.DeliveryWindow.Hours {
+			window.Hours = append(window.Hours, entities.HourRange{Start: hours.Start, End: hours.End})
+		}
+		config.DeliveryWindow = window
+	}
+
+	return config
 }
@@ -1,13 +1,16 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	"webhook-processor/internal/domain/entities"
@@ -151,6 +154,19 @@ func TestWebhookConfigRepositoryImpl_ModelToEntity(t *testing.T) {
 				assert.Equal(t, 25000, entity.TimeoutMs)
 			},
 		},
+		{
+			name: "should carry custom headers",
+			model: &models.WebhookConfigModel{
+				ID:         5,
+				Name:       "Headers Config",
+				EventType:  enums.EventTypeCredit,
+				WebhookURL: "https://example.com/webhook",
+				Headers:    models.HeaderMap{"Authorization": "Bearer token-1", "X-Tenant-Id": "acme"},
+			},
+			verify: func(t *testing.T, entity *entities.WebhookConfig) {
+				assert.Equal(t, map[string]string{"Authorization": "Bearer token-1", "X-Tenant-Id": "acme"}, entity.Headers)
+			},
+		},
 		{
 			name: "should handle long webhook URLs",
 			model: &models.WebhookConfigModel{
@@ -501,6 +517,26 @@ func TestWebhookConfigRepositoryImpl_TimestampPrecision(t *testing.T) {
 	})
 }
 
+// TestWebhookConfigRepositoryImpl_AmbiguousErrorPolicy tests that the policy is stored as NULL when unset
+func TestWebhookConfigRepositoryImpl_AmbiguousErrorPolicy(t *testing.T) {
+	repo := &webhookConfigRepositoryImpl{}
+
+	t.Run("should store an unset policy as NULL", func(t *testing.T) {
+		model := repo.entityToModel(&entities.WebhookConfig{ID: 1})
+
+		assert.Nil(t, model.AmbiguousErrorPolicy)
+		assert.Empty(t, repo.modelToEntity(model).AmbiguousErrorPolicy)
+	})
+
+	t.Run("should round-trip a set policy", func(t *testing.T) {
+		model := repo.entityToModel(&entities.WebhookConfig{ID: 1, AmbiguousErrorPolicy: enums.AmbiguousErrorPolicyRetryIfIdempotent})
+
+		require.NotNil(t, model.AmbiguousErrorPolicy)
+		assert.Equal(t, enums.AmbiguousErrorPolicyRetryIfIdempotent, *model.AmbiguousErrorPolicy)
+		assert.Equal(t, enums.AmbiguousErrorPolicyRetryIfIdempotent, repo.modelToEntity(model).AmbiguousErrorPolicy)
+	})
+}
+
 // TestWebhookConfigRepositoryImpl_DataIntegrity tests data integrity
 func TestWebhookConfigRepositoryImpl_DataIntegrity(t *testing.T) {
 	repo := &webhookConfigRepositoryImpl{}
@@ -567,3 +603,141 @@ func TestWebhookConfigRepositoryImpl_EdgeCases(t *testing.T) {
 		assert.Equal(t, 0, entity.TimeoutMs)
 	})
 }
+
+// newDryRunConfigRepo returns a config repository whose statements are captured instead of run;
+// rowsAffected is what every update reports
+func newDryRunConfigRepo(t *testing.T, rowsAffected int64) (*webhookConfigRepositoryImpl, *string, *[]interface{}) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	var statement string
+	var vars []interface{}
+	capture := func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+		vars = tx.Statement.Vars
+	}
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:capture_create", func(tx *gorm.DB) {
+		capture(tx)
+		if model, ok := tx.Statement.Dest.(*models.WebhookConfigModel); ok {
+			model.ID = 9
+		}
+	}))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_update", func(tx *gorm.DB) {
+		capture(tx)
+		tx.RowsAffected = rowsAffected
+	}))
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture_query", capture))
+
+	return &webhookConfigRepositoryImpl{db: db}, &statement, &vars
+}
+
+func TestWebhookConfigRepositoryImpl_Create(t *testing.T) {
+	t.Run("should insert every column so an inactive config stays inactive", func(t *testing.T) {
+		repo, statement, vars := newDryRunConfigRepo(t, 1)
+		config := &entities.WebhookConfig{
+			Name:       "Ledger",
+			EventType:  enums.EventTypeCredit,
+			WebhookURL: "https://ledger.example.com/hooks",
+			TimeoutMs:  5000,
+			IsActive:   false,
+		}
+
+		err := repo.Create(context.Background(), config)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(9), config.ID)
+		assert.False(t, config.CreatedAt.IsZero())
+		assert.Contains(t, *statement, `"is_active"`)
+		assert.NotContains(t, *statement, `"deleted_at"`)
+		assert.Contains(t, *statement, `RETURNING "id"`)
+		assert.Contains(t, *vars, false)
+		assert.Contains(t, *vars, "POST", "the default method is stored explicitly")
+		assert.Contains(t, *vars, enums.DeliveryProtocolHTTP)
+	})
+}
+
+func TestWebhookConfigRepositoryImpl_Update(t *testing.T) {
+	config := &entities.WebhookConfig{
+		ID:         4,
+		Name:       "Ledger v2",
+		EventType:  enums.EventTypeDebit,
+		WebhookURL: "https://ledger.example.com/v2",
+		TimeoutMs:  10000,
+		IsActive:   true,
+	}
+
+	t.Run("should replace only the managed columns of a live config", func(t *testing.T) {
+		repo, statement, vars := newDryRunConfigRepo(t, 1)
+
+		found, err := repo.Update(context.Background(), config)
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Contains(t, *statement, "deleted_at IS NULL")
+		assert.NotContains(t, *statement, "headers")
+		assert.NotContains(t, *statement, "signing_secret")
+		assert.Contains(t, *vars, "https://ledger.example.com/v2")
+	})
+
+	t.Run("should report a missing or deleted config", func(t *testing.T) {
+		repo, _, _ := newDryRunConfigRepo(t, 0)
+
+		found, err := repo.Update(context.Background(), config)
+
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestWebhookConfigRepositoryImpl_Delete(t *testing.T) {
+	t.Run("should soft-delete and deactivate the config", func(t *testing.T) {
+		repo, statement, vars := newDryRunConfigRepo(t, 1)
+
+		found, err := repo.Delete(context.Background(), 4)
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.True(t, strings.HasPrefix(*statement, "UPDATE"), "configs are never removed")
+		assert.Contains(t, *statement, `"deleted_at"=`)
+		assert.Contains(t, *statement, `"is_active"=`)
+		assert.Contains(t, *vars, false)
+	})
+
+	t.Run("should report a config that is already deleted", func(t *testing.T) {
+		repo, _, _ := newDryRunConfigRepo(t, 0)
+
+		found, err := repo.Delete(context.Background(), 4)
+
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestWebhookConfigRepositoryImpl_List(t *testing.T) {
+	t.Run("should list inactive configs but not deleted ones", func(t *testing.T) {
+		repo, statement, _ := newDryRunConfigRepo(t, 0)
+
+		configs, err := repo.List(context.Background())
+
+		require.NoError(t, err)
+		assert.NotNil(t, configs)
+		assert.Contains(t, *statement, "deleted_at IS NULL")
+		assert.NotContains(t, *statement, "is_active")
+		assert.Contains(t, *statement, "ORDER BY id")
+	})
+}
+
+func TestWebhookConfigRepositoryImpl_GetActiveByEventType(t *testing.T) {
+	t.Run("should select only active, non-deleted configs for the event type", func(t *testing.T) {
+		repo, statement, vars := newDryRunConfigRepo(t, 0)
+
+		configs, err := repo.GetActiveByEventType(context.Background(), enums.EventTypeCredit)
+
+		require.NoError(t, err)
+		assert.NotNil(t, configs)
+		assert.Contains(t, *statement, "event_type = $1 AND is_active = $2 AND deleted_at IS NULL")
+		assert.Contains(t, *statement, "ORDER BY id")
+		assert.Equal(t, []interface{}{enums.EventTypeCredit, true}, *vars)
+	})
+}
@@ -2,9 +2,14 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/go-kit/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -12,20 +17,96 @@ import (
 	"webhook-processor/internal/domain/entities"
 	"webhook-processor/internal/domain/enums"
 	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/infrastructure/metrics"
 	"webhook-processor/internal/infrastructure/models"
 )
 
 // webhookQueueRepositoryImpl implements the WebhookQueueRepository interface
 type webhookQueueRepositoryImpl struct {
 	db *gorm.DB
+
+	// lockingSlots bounds concurrent SELECT FOR UPDATE SKIP LOCKED transactions
+	lockingSlots chan struct{}
+	metrics      *metrics.WebhookMetrics
+	logger       log.Logger
+
+	// maxStoredResponseBytes is the per-row response body budget; 0 disables it
+	maxStoredResponseBytes int
+
+	// maxStoredErrorBytes caps each stored error message; 0 disables it
+	maxStoredErrorBytes int
+
+	// bodyStore keeps attempt response bodies; nil keeps them inline in the row
+	bodyStore repositories.BodyStore
+
+	// claimTTL is how long a worker's claim on a PROCESSING row lasts before it may be reclaimed
+	claimTTL time.Duration
+
+	// attemptBatcher coalesces concurrent level-0 attempt writes; nil writes every attempt alone
+	attemptBatcher *attemptBatcher
+}
+
+// responseBodySnippetBytes is how much of a body is kept once the row's budget is spent
+const responseBodySnippetBytes = 256
+
+// QueueRepositoryOptions holds the limits and collaborators of the webhook queue repository
+type QueueRepositoryOptions struct {
+	// MaxLockingTxns caps how many locking transactions may hold a connection at once
+	MaxLockingTxns int
+	// MaxStoredResponseBytes caps the response bodies stored per webhook (0 disables it)
+	MaxStoredResponseBytes int
+	// MaxStoredErrorBytes caps each stored error message (0 disables it)
+	MaxStoredErrorBytes int
+	// BodyStore keeps attempt response bodies, inline in the row when nil
+	BodyStore repositories.BodyStore
+	// ClaimTTL is how long a claimed row stays PROCESSING before the reaper may reclaim it
+	ClaimTTL time.Duration
+	// AttemptBatchSize level-0 attempt writes arriving within AttemptBatchWait share one statement (1 disables it)
+	AttemptBatchSize int
+	AttemptBatchWait time.Duration
 }
 
 // NewWebhookQueueRepository creates a new webhook queue repository
-func NewWebhookQueueRepository(db *gorm.DB) (repositories.WebhookQueueRepository, error) {
+// webhookMetrics may be nil when the caller does not expose metrics (e.g. the API)
+func NewWebhookQueueRepository(db *gorm.DB, opts QueueRepositoryOptions, webhookMetrics *metrics.WebhookMetrics, logger log.Logger) (repositories.WebhookQueueRepository, error) {
 	if db == nil {
 		return nil, fmt.Errorf("database cannot be nil")
 	}
-	return &webhookQueueRepositoryImpl{db: db}, nil
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+	if opts.MaxLockingTxns <= 0 {
+		return nil, fmt.Errorf("max locking transactions must be positive")
+	}
+	if opts.MaxStoredResponseBytes < 0 {
+		return nil, fmt.Errorf("max stored response bytes cannot be negative")
+	}
+	if opts.MaxStoredErrorBytes < 0 {
+		return nil, fmt.Errorf("max stored error bytes cannot be negative")
+	}
+	if opts.ClaimTTL <= 0 {
+		return nil, fmt.Errorf("claim TTL must be positive")
+	}
+	if opts.AttemptBatchSize <= 0 {
+		return nil, fmt.Errorf("attempt batch size must be positive")
+	}
+	if opts.AttemptBatchSize > 1 && opts.AttemptBatchWait <= 0 {
+		return nil, fmt.Errorf("attempt batch wait must be positive when batching")
+	}
+	repo := &webhookQueueRepositoryImpl{
+		db:                     db,
+		lockingSlots:           make(chan struct{}, opts.MaxLockingTxns),
+		metrics:                webhookMetrics,
+		logger:                 logger,
+		maxStoredResponseBytes: opts.MaxStoredResponseBytes,
+		maxStoredErrorBytes:    opts.MaxStoredErrorBytes,
+		bodyStore:              opts.BodyStore,
+		claimTTL:               opts.ClaimTTL,
+	}
+	if opts.AttemptBatchSize > 1 {
+		repo.attemptBatcher = newAttemptBatcher(opts.AttemptBatchSize, opts.AttemptBatchWait, repo.writeAttemptBatch)
+	}
+	return repo, nil
 }
 
 // Create creates a new webhook queue entry
@@ -39,8 +120,89 @@ func (r *webhookQueueRepositoryImpl) Create(ctx context.Context, webhook *entiti
 	return nil
 }
 
-// Update updates a webhook queue entry with intelligent field merging
-func (r *webhookQueueRepositoryImpl) Update(ctx context.Context, webhook *entities.WebhookQueue) error {
+// CreateBatch creates every webhook with one multi-row insert, which succeeds or fails as a whole
+func (r *webhookQueueRepositoryImpl) CreateBatch(ctx context.Context, webhooks []*entities.WebhookQueue) error {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	webhookModels := make([]*models.WebhookQueueModel, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		webhookModels = append(webhookModels, r.entityToModel(webhook))
+	}
+	if err := r.db.WithContext(ctx).Create(webhookModels).Error; err != nil {
+		return fmt.Errorf("failed to create %d webhook queue entries: %w", len(webhooks), err)
+	}
+	for i, webhook := range webhooks {
+		webhook.ID = webhookModels[i].ID
+		webhook.QueueID = webhookModels[i].QueueID
+	}
+	return nil
+}
+
+// GetByQueueID retrieves a webhook by its public queue ID, returning nil if it does not exist
+func (r *webhookQueueRepositoryImpl) GetByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error) {
+	var model models.WebhookQueueModel
+	if err := r.db.WithContext(ctx).Where("queue_id = ?", queueID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook by queue id: %w", err)
+	}
+	webhook := r.modelToEntity(&model)
+	r.resolveResponseBodies(ctx, webhook)
+	return webhook, nil
+}
+
+// GetByEventID retrieves every webhook created for an event ID, ordered by config then creation
+// Response bodies are left as stored, since callers only need each webhook's outcome
+func (r *webhookQueueRepositoryImpl) GetByEventID(ctx context.Context, eventID string) ([]*entities.WebhookQueue, error) {
+	var webhookModels []models.WebhookQueueModel
+	if err := r.db.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("config_id ASC, id ASC").
+		Find(&webhookModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhooks by event id: %w", err)
+	}
+
+	webhooks := make([]*entities.WebhookQueue, 0, len(webhookModels))
+	for i := range webhookModels {
+		webhooks = append(webhooks, r.modelToEntity(&webhookModels[i]))
+	}
+	return webhooks, nil
+}
+
+// resolveResponseBodies replaces offloaded response body references with the bodies they point to
+// A body that cannot be fetched is logged and left as its reference
+func (r *webhookQueueRepositoryImpl) resolveResponseBodies(ctx context.Context, webhook *entities.WebhookQueue) {
+	if r.bodyStore == nil {
+		return
+	}
+	resolve := func(level int, body *string) {
+		if body == nil || *body == "" {
+			return
+		}
+		resolved, err := r.bodyStore.Resolve(ctx, *body)
+		if err != nil {
+			r.logger.Log("level", "warn", "msg", "failed to resolve stored response body",
+				"queue_id", webhook.QueueID, "retry_level", level, "error", err)
+			return
+		}
+		*body = resolved
+	}
+	for level, body := range []*string{
+		webhook.Retry0ResponseBody, webhook.Retry1ResponseBody, webhook.Retry2ResponseBody, webhook.Retry3ResponseBody,
+		webhook.Retry4ResponseBody, webhook.Retry5ResponseBody, webhook.Retry6ResponseBody,
+	} {
+		resolve(level, body)
+	}
+	for _, attempt := range webhook.ExtraAttempts {
+		resolve(attempt.RetryLevel, attempt.ResponseBody)
+	}
+}
+
+// Update updates a webhook queue entry with intelligent field merging, while workerID still holds its claim
+func (r *webhookQueueRepositoryImpl) Update(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error {
 	var currentModel models.WebhookQueueModel
 	if err := r.db.WithContext(ctx).Where("id = ?", webhook.ID).First(&currentModel).Error; err != nil {
 		return fmt.Errorf("failed to get current webhook state: %w", err)
@@ -48,18 +210,47 @@ func (r *webhookQueueRepositoryImpl) Update(ctx context.Context, webhook *entiti
 
 	r.mergeWebhookIntoModel(&currentModel, webhook)
 
-	if err := r.db.WithContext(ctx).Save(&currentModel).Error; err != nil {
-		return fmt.Errorf("failed to update webhook queue entry: %w", err)
+	// Save would insert the row again when the claim check matches nothing, so every column is updated instead
+	result := r.db.WithContext(ctx).
+		Model(&currentModel).
+		Where("status = ? AND claimed_by = ?", enums.WebhookStatusProcessing, workerID).
+		Select("*").
+		Updates(&currentModel)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook queue entry: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to update webhook %d: %w", webhook.ID, repositories.ErrClaimLost)
 	}
 	return nil
 }
 
 // GetNextWebhookForProcessing atomically gets and locks ONE webhook for a specific retry level
 // Uses PostgreSQL's SELECT FOR UPDATE SKIP LOCKED for optimal concurrency
+// A claim interrupted by ctx being cancelled returns no webhook and no error, so shutdown stays quiet
 func (r *webhookQueueRepositoryImpl) GetNextWebhookForProcessing(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
+	webhook, err := r.claimNextWebhook(ctx, workerID, retryLevel)
+	if err != nil && ctx.Err() != nil {
+		// The deferred rollback has already released any row lock taken by the abandoned transaction
+		r.logger.Log("level", "debug", "msg", "claim abandoned: context cancelled",
+			"worker_id", workerID, "retry_level", retryLevel, "error", err)
+		return nil, nil
+	}
+	return webhook, err
+}
+
+// claimNextWebhook selects and claims the next due webhook for retryLevel in one transaction
+func (r *webhookQueueRepositoryImpl) claimNextWebhook(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
 	var model models.WebhookQueueModel
 
+	// Wait for a locking slot so these transactions can't exhaust the connection pool
+	if err := r.acquireLockingSlot(ctx); err != nil {
+		return nil, fmt.Errorf("failed to acquire locking slot for retry level %d: %w", retryLevel, err)
+	}
+	defer r.releaseLockingSlot()
+
 	// Start transaction for atomic operation
+	txStart := time.Now()
 	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, fmt.Errorf("failed to start transaction: %w", tx.Error)
@@ -69,27 +260,32 @@ func (r *webhookQueueRepositoryImpl) GetNextWebhookForProcessing(ctx context.Con
 	// Atomically select and lock ONE webhook for the specific retry level using GORM's clause.Locking
 	now := time.Now().UTC()
 
-	err := tx.
-		Where("status = ? AND retry_count = ? AND next_retry_at <= ?",
-			enums.WebhookStatusPending, retryLevel, now).
-		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
-		Order("next_retry_at ASC").
-		First(&model).Error
+	err := r.lockNextDueWebhook(tx, retryLevel, txStart, now, &model)
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			tx.Commit() // No work available for this retry level
-			return nil, nil
+			// Distinguish an empty queue from due rows that were all skipped as locked by siblings
+			var dueCount int64
+			if err := tx.Model(&models.WebhookQueueModel{}).
+				Where("status = ? AND retry_count = ? AND next_retry_at <= ?",
+					enums.WebhookStatusPending, retryLevel, now).
+				Count(&dueCount).Error; err != nil {
+				return nil, fmt.Errorf("failed to count due webhooks for retry level %d: %w", retryLevel, err)
+			}
+
+			tx.Commit()
+			if dueCount > 0 {
+				return nil, repositories.ErrLockContention
+			}
+			return nil, nil // No work available for this retry level
 		}
 		return nil, fmt.Errorf("failed to get next webhook for retry level %d: %w", retryLevel, err)
 	}
 
-	// Update the selected webhook to PROCESSING status atomically
+	// Update the selected webhook to PROCESSING status and claim it for this worker atomically
+	claimExpiresAt := now.Add(r.claimTTL)
 	if err := tx.Model(&model).
-		Updates(map[string]interface{}{
-			"status":     enums.WebhookStatusProcessing,
-			"updated_at": now,
-		}).Error; err != nil {
+		Updates(claimUpdates(workerID, now, claimExpiresAt)).Error; err != nil {
 		return nil, fmt.Errorf("failed to update webhook status for retry level %d: %w", retryLevel, err)
 	}
 
@@ -101,12 +297,194 @@ func (r *webhookQueueRepositoryImpl) GetNextWebhookForProcessing(ctx context.Con
 	// Update model in memory and convert to entity
 	model.Status = enums.WebhookStatusProcessing
 	model.UpdatedAt = now
+	model.ClaimedBy = &workerID
+	model.ClaimExpiresAt = &claimExpiresAt
+
+	return r.modelToEntity(&model), nil
+}
+
+// lockNextDueWebhook selects and locks the next due webhook at retryLevel into model, recording how long
+// after txStart the lock was taken; an attempt that finds no row is recorded too
+func (r *webhookQueueRepositoryImpl) lockNextDueWebhook(tx *gorm.DB, retryLevel int, txStart, now time.Time, model *models.WebhookQueueModel) error {
+	err := nextDueWebhook(tx, retryLevel, now).First(model).Error
+	if r.metrics != nil && (err == nil || err == gorm.ErrRecordNotFound) {
+		// Slow lock waits mean too many workers competing for too few rows, or a slow database
+		r.metrics.RecordLockWait(retryLevel, time.Since(txStart))
+	}
+	return err
+}
+
+// nextDueWebhook selects the due PENDING webhooks at retryLevel in claim order, skipping rows locked by siblings
+// A webhook's level is its retry_count, as entities.WebhookQueue.CurrentRetryLevel defines
+// Rows due at the same time (e.g. a batch created together) are taken in ID order so none starve
+func nextDueWebhook(tx *gorm.DB, retryLevel int, now time.Time) *gorm.DB {
+	return tx.
+		Where("status = ? AND retry_count = ? AND next_retry_at <= ?",
+			enums.WebhookStatusPending, retryLevel, now).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Order("next_retry_at ASC, id ASC")
+}
+
+// ClaimByQueueID locks and claims one PENDING webhook for workerID, skipping it if another worker holds the row
+func (r *webhookQueueRepositoryImpl) ClaimByQueueID(ctx context.Context, queueID uuid.UUID, workerID string) (*entities.WebhookQueue, error) {
+	var model models.WebhookQueueModel
+
+	if err := r.acquireLockingSlot(ctx); err != nil {
+		return nil, fmt.Errorf("failed to acquire locking slot for webhook %s: %w", queueID, err)
+	}
+	defer r.releaseLockingSlot()
+
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", tx.Error)
+	}
+	defer tx.Rollback()
+
+	err := tx.
+		Where("queue_id = ? AND status = ?", queueID, enums.WebhookStatusPending).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim webhook %s: %w", queueID, err)
+	}
+
+	now := time.Now().UTC()
+	claimExpiresAt := now.Add(r.claimTTL)
+	if err := tx.Model(&model).
+		Updates(claimUpdates(workerID, now, claimExpiresAt)).Error; err != nil {
+		return nil, fmt.Errorf("failed to update webhook status for %s: %w", queueID, err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for webhook %s: %w", queueID, err)
+	}
+
+	model.Status = enums.WebhookStatusProcessing
+	model.UpdatedAt = now
+	model.ClaimedBy = &workerID
+	model.ClaimExpiresAt = &claimExpiresAt
 
 	return r.modelToEntity(&model), nil
 }
 
+// acquireLockingSlot blocks until a locking-transaction slot is free or ctx is done
+func (r *webhookQueueRepositoryImpl) acquireLockingSlot(ctx context.Context) error {
+	waitStart := time.Now()
+	select {
+	case r.lockingSlots <- struct{}{}:
+		if r.metrics != nil {
+			r.metrics.RecordLockingTxnWait(time.Since(waitStart))
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releaseLockingSlot frees a slot taken by acquireLockingSlot
+func (r *webhookQueueRepositoryImpl) releaseLockingSlot() {
+	<-r.lockingSlots
+}
+
+// claimUpdates moves a row to PROCESSING, claimed by workerID until expiresAt
+func claimUpdates(workerID string, now, expiresAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"status":           enums.WebhookStatusProcessing,
+		"claimed_by":       workerID,
+		"claim_expires_at": expiresAt,
+		"updated_at":       now,
+	}
+}
+
+// releaseClaim adds the column updates that clear a row's processing claim
+func releaseClaim(updates map[string]interface{}) map[string]interface{} {
+	updates["claimed_by"] = nil
+	updates["claim_expires_at"] = nil
+	return updates
+}
+
+// ReclaimExpiredClaims returns PROCESSING webhooks whose claim expired before asOf to PENDING
+// They keep their retry level and next_retry_at, so they are picked up again immediately
+func (r *webhookQueueRepositoryImpl) ReclaimExpiredClaims(ctx context.Context, asOf time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.WebhookQueueModel{}).
+		Where("status = ? AND claim_expires_at < ?", enums.WebhookStatusProcessing, asOf).
+		Updates(releaseClaim(map[string]interface{}{
+			"status":     enums.WebhookStatusPending,
+			"updated_at": asOf,
+		}))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to reclaim expired processing claims: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ExtendClaim pushes a held claim's expiry to a full claim TTL from now
+// updated_at is left alone, so a renewed claim is still dated from when it was taken
+func (r *webhookQueueRepositoryImpl) ExtendClaim(ctx context.Context, webhookID int64, workerID string) error {
+	result := claimedBy(r.db.WithContext(ctx).Model(&models.WebhookQueueModel{}), webhookID, workerID).
+		UpdateColumn("claim_expires_at", time.Now().UTC().Add(r.claimTTL))
+	if result.Error != nil {
+		return fmt.Errorf("failed to extend claim on webhook %d: %w", webhookID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to extend claim on webhook %d: %w", webhookID, repositories.ErrClaimLost)
+	}
+	return nil
+}
+
 // UpdateRetryAttempt updates retry attempt information
-func (r *webhookQueueRepositoryImpl) UpdateRetryAttempt(ctx context.Context, webhookID int64, retryLevel int, startedAt time.Time, completedAt *time.Time, durationMs int64, httpStatus int, responseBody, errorMsg string) error {
+// The response body goes through the body store; once the row's stored response bodies exceed
+// the configured budget, only a snippet is kept. The error is capped like every stored error.
+// A recorded request body is kept inline and counts against the same budget as response bodies
+func (r *webhookQueueRepositoryImpl) UpdateRetryAttempt(ctx context.Context, webhookID int64, workerID string, retryLevel int, startedAt time.Time, completedAt *time.Time, durationMs int64, timeoutMs int64, httpStatus int, responseBody, errorMsg string, errorClass enums.ErrorClass, request *entities.AttemptRequest) error {
+	if retryLevel < 0 || retryLevel > enums.MaxConfigurableRetries {
+		return fmt.Errorf("invalid retry level %d: must be between 0 and %d", retryLevel, enums.MaxConfigurableRetries)
+	}
+	errorMsg = r.storedError(errorMsg)
+
+	var requestBody string
+	if request != nil {
+		requestBody = request.Body
+	}
+
+	offload := r.bodyStore != nil && responseBody != ""
+	budget := r.maxStoredResponseBytes > 0 && (responseBody != "" || requestBody != "")
+	var stored storedResponses
+	if offload || budget {
+		var err error
+		if stored, err = r.storedResponseBytes(ctx, webhookID, retryLevel); err != nil {
+			return err
+		}
+	}
+
+	// An unreachable store falls back to the row so the attempt's body is not lost
+	if offload && stored.QueueID != uuid.Nil {
+		if ref, err := r.bodyStore.Put(ctx, stored.QueueID, retryLevel, responseBody); err != nil {
+			r.logger.Log("level", "warn", "msg", "failed to offload response body, storing it inline",
+				"webhook_id", webhookID, "retry_level", retryLevel, "error", err)
+		} else {
+			responseBody = ref
+		}
+	}
+
+	if budget {
+		// The response is budgeted first, as it is what most debugging starts from
+		if budgeted := budgetResponseBody(responseBody, stored.Bytes, r.maxStoredResponseBytes); budgeted != responseBody {
+			// Repeated truncation for one config points at a receiver returning huge bodies
+			if r.metrics != nil {
+				r.metrics.RecordResponseTruncated(stored.ConfigID)
+			}
+			r.logger.Log("level", "debug", "msg", "response body truncated to fit the stored response budget",
+				"webhook_id", webhookID, "config_id", stored.ConfigID, "retry_level", retryLevel,
+				"body_bytes", len(responseBody), "stored_bytes", stored.Bytes, "budget", r.maxStoredResponseBytes)
+			responseBody = budgeted
+		}
+		requestBody = budgetResponseBody(requestBody, stored.Bytes+int64(len(responseBody)), r.maxStoredResponseBytes)
+	}
+
 	updates := map[string]interface{}{
 		"updated_at":       time.Now().UTC(),
 		"last_http_status": httpStatus,
@@ -124,120 +502,792 @@ func (r *webhookQueueRepositoryImpl) UpdateRetryAttempt(ctx context.Context, web
 			updates["retry_0_completed_at"] = *completedAt
 		}
 		updates["retry_0_duration_ms"] = durationMs
+		updates["retry_0_timeout_ms"] = timeoutMs
 		updates["retry_0_http_status"] = httpStatus
 		updates["retry_0_response_body"] = responseBody
 		if errorMsg != "" {
 			updates["retry_0_error"] = errorMsg
 		}
+		if errorClass != "" {
+			updates["retry_0_error_class"] = string(errorClass)
+		}
+		if request != nil {
+			updates["retry_0_request_body"] = requestBody
+			updates["retry_0_request_headers"] = models.HeaderMap(request.Headers)
+		}
 	case 1:
 		updates["retry_1_started_at"] = startedAt
 		if completedAt != nil {
 			updates["retry_1_completed_at"] = *completedAt
 		}
 		updates["retry_1_duration_ms"] = durationMs
+		updates["retry_1_timeout_ms"] = timeoutMs
 		updates["retry_1_http_status"] = httpStatus
 		updates["retry_1_response_body"] = responseBody
 		if errorMsg != "" {
 			updates["retry_1_error"] = errorMsg
 		}
+		if errorClass != "" {
+			updates["retry_1_error_class"] = string(errorClass)
+		}
+		if request != nil {
+			updates["retry_1_request_body"] = requestBody
+			updates["retry_1_request_headers"] = models.HeaderMap(request.Headers)
+		}
 	case 2:
 		updates["retry_2_started_at"] = startedAt
 		if completedAt != nil {
 			updates["retry_2_completed_at"] = *completedAt
 		}
 		updates["retry_2_duration_ms"] = durationMs
+		updates["retry_2_timeout_ms"] = timeoutMs
 		updates["retry_2_http_status"] = httpStatus
 		updates["retry_2_response_body"] = responseBody
 		if errorMsg != "" {
 			updates["retry_2_error"] = errorMsg
 		}
+		if errorClass != "" {
+			updates["retry_2_error_class"] = string(errorClass)
+		}
+		if request != nil {
+			updates["retry_2_request_body"] = requestBody
+			updates["retry_2_request_headers"] = models.HeaderMap(request.Headers)
+		}
 	case 3:
 		updates["retry_3_started_at"] = startedAt
 		if completedAt != nil {
 			updates["retry_3_completed_at"] = *completedAt
 		}
 		updates["retry_3_duration_ms"] = durationMs
+		updates["retry_3_timeout_ms"] = timeoutMs
 		updates["retry_3_http_status"] = httpStatus
 		updates["retry_3_response_body"] = responseBody
 		if errorMsg != "" {
 			updates["retry_3_error"] = errorMsg
 		}
+		if errorClass != "" {
+			updates["retry_3_error_class"] = string(errorClass)
+		}
+		if request != nil {
+			updates["retry_3_request_body"] = requestBody
+			updates["retry_3_request_headers"] = models.HeaderMap(request.Headers)
+		}
 	case 4:
 		updates["retry_4_started_at"] = startedAt
 		if completedAt != nil {
 			updates["retry_4_completed_at"] = *completedAt
 		}
 		updates["retry_4_duration_ms"] = durationMs
+		updates["retry_4_timeout_ms"] = timeoutMs
 		updates["retry_4_http_status"] = httpStatus
 		updates["retry_4_response_body"] = responseBody
 		if errorMsg != "" {
 			updates["retry_4_error"] = errorMsg
 		}
+		if errorClass != "" {
+			updates["retry_4_error_class"] = string(errorClass)
+		}
+		if request != nil {
+			updates["retry_4_request_body"] = requestBody
+			updates["retry_4_request_headers"] = models.HeaderMap(request.Headers)
+		}
 	case 5:
 		updates["retry_5_started_at"] = startedAt
 		if completedAt != nil {
 			updates["retry_5_completed_at"] = *completedAt
 		}
 		updates["retry_5_duration_ms"] = durationMs
+		updates["retry_5_timeout_ms"] = timeoutMs
 		updates["retry_5_http_status"] = httpStatus
 		updates["retry_5_response_body"] = responseBody
 		if errorMsg != "" {
 			updates["retry_5_error"] = errorMsg
 		}
+		if errorClass != "" {
+			updates["retry_5_error_class"] = string(errorClass)
+		}
+		if request != nil {
+			updates["retry_5_request_body"] = requestBody
+			updates["retry_5_request_headers"] = models.HeaderMap(request.Headers)
+		}
 	case 6:
 		updates["retry_6_started_at"] = startedAt
 		if completedAt != nil {
 			updates["retry_6_completed_at"] = *completedAt
 		}
 		updates["retry_6_duration_ms"] = durationMs
+		updates["retry_6_timeout_ms"] = timeoutMs
 		updates["retry_6_http_status"] = httpStatus
 		updates["retry_6_response_body"] = responseBody
 		if errorMsg != "" {
 			updates["retry_6_error"] = errorMsg
 		}
+		if errorClass != "" {
+			updates["retry_6_error_class"] = string(errorClass)
+		}
+		if request != nil {
+			updates["retry_6_request_body"] = requestBody
+			updates["retry_6_request_headers"] = models.HeaderMap(request.Headers)
+		}
+	default:
+		// Levels above the fixed columns are kept in the retry_attempts list, one entry per level
+		attempt := models.RetryAttemptModel{
+			RetryLevel:   retryLevel,
+			StartedAt:    &startedAt,
+			CompletedAt:  completedAt,
+			DurationMs:   &durationMs,
+			TimeoutMs:    &timeoutMs,
+			HTTPStatus:   &httpStatus,
+			ResponseBody: &responseBody,
+		}
+		if errorMsg != "" {
+			attempt.Error = &errorMsg
+		}
+		if errorClass != "" {
+			class := string(errorClass)
+			attempt.ErrorClass = &class
+		}
+		if request != nil {
+			attempt.RequestBody = &requestBody
+			attempt.RequestHeaders = request.Headers
+		}
+		encoded, err := json.Marshal(models.RetryAttemptList{attempt})
+		if err != nil {
+			return fmt.Errorf("failed to marshal retry attempt: %w", err)
+		}
+		// A throttled webhook is retried at the same level, so its earlier entry is replaced like the
+		// fixed columns would be rather than left as a duplicate
+		updates["retry_attempts"] = gorm.Expr("COALESCE((SELECT jsonb_agg(attempt ORDER BY position) "+
+			"FROM jsonb_array_elements(retry_attempts) WITH ORDINALITY AS elements(attempt, position) "+
+			"WHERE (attempt->>'retry_level')::int <> ?), '[]'::jsonb) || ?::jsonb", retryLevel, string(encoded))
 	}
 
+	// Level-0 attempts are the bulk of the write load, and their columns are set outright, so several
+	// rows' attempts can be combined without changing what each row ends up with
+	if r.attemptBatcher != nil && retryLevel == 0 {
+		return r.attemptBatcher.submit(&attemptWrite{webhookID: webhookID, workerID: workerID, updates: updates})
+	}
+	return r.writeAttempt(ctx, webhookID, workerID, retryLevel, updates)
+}
+
+// writeAttempt applies one attempt's column updates to its row, while workerID still holds its claim
+func (r *webhookQueueRepositoryImpl) writeAttempt(ctx context.Context, webhookID int64, workerID string, retryLevel int, updates map[string]interface{}) error {
+	// The attempt belongs to the level the webhook was picked up at, which is still its stored retry_count
+	// (see entities.WebhookQueue.CurrentRetryLevel);
+	// matching on it keeps an off-by-one caller from writing into another attempt's columns
+	result := claimedBy(r.db.WithContext(ctx).Model(&models.WebhookQueueModel{}), webhookID, workerID).
+		Where("retry_count = ?", retryLevel).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update retry attempt: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to update retry attempt: webhook %d is missing, not claimed by %s or its retry count is not %d: %w",
+			webhookID, workerID, retryLevel, repositories.ErrClaimLost)
+	}
+
+	return nil
+}
+
+// claimedBy restricts a write to the webhook while it is still PROCESSING under workerID's claim, so a worker
+// whose claim was reclaimed cannot overwrite what the webhook's new claimant records
+func claimedBy(query *gorm.DB, webhookID int64, workerID string) *gorm.DB {
+	return query.Where("id = ? AND status = ? AND claimed_by = ?", webhookID, enums.WebhookStatusProcessing, workerID)
+}
+
+// storedResponses is the response body size already stored for a webhook, with its config and queue ID
+type storedResponses struct {
+	Bytes    int64
+	ConfigID int64
+	QueueID  uuid.UUID
+}
+
+// storedResponseBytes sums the response and request bodies already stored for a webhook, excluding retryLevel
+// Attempts above the fixed columns are counted by the size of their entries in the list that stores them
+func (r *webhookQueueRepositoryImpl) storedResponseBytes(ctx context.Context, webhookID int64, retryLevel int) (storedResponses, error) {
+	lengths := []string{"COALESCE((SELECT sum(octet_length(attempt::text)) FROM jsonb_array_elements(retry_attempts) AS attempt " +
+		"WHERE (attempt->>'retry_level')::int <> ?)::bigint, 0)"}
+	for level := 0; level <= enums.MaxRetryAttempts; level++ {
+		if level == retryLevel {
+			continue
+		}
+		lengths = append(lengths, fmt.Sprintf("COALESCE(octet_length(retry_%d_response_body), 0)", level),
+			fmt.Sprintf("COALESCE(octet_length(retry_%d_request_body), 0)", level))
+	}
+
+	var stored storedResponses
 	if err := r.db.WithContext(ctx).
 		Model(&models.WebhookQueueModel{}).
+		Select(strings.Join(lengths, " + ")+" AS bytes, config_id, queue_id", retryLevel).
 		Where("id = ?", webhookID).
-		Updates(updates).Error; err != nil {
-		return fmt.Errorf("failed to update retry attempt: %w", err)
+		Find(&stored).Error; err != nil {
+		return storedResponses{}, fmt.Errorf("failed to read stored response size: %w", err)
 	}
+	return stored, nil
+}
 
-	return nil
+// budgetResponseBody returns body unchanged while it fits in what remains of budget after
+// stored bytes, and otherwise a snippet that records the original size
+func budgetResponseBody(body string, stored int64, budget int) string {
+	if stored+int64(len(body)) <= int64(budget) || len(body) <= responseBodySnippetBytes {
+		return body
+	}
+
+	// Back off to a rune boundary so the snippet stays valid UTF-8 for the text column
+	cut := responseBodySnippetBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... [truncated, %d bytes]", body[:cut], len(body))
 }
 
-// MarkCompleted marks a webhook as completed
-func (r *webhookQueueRepositoryImpl) MarkCompleted(ctx context.Context, webhookID int64, processingStartedAt time.Time) error {
+// storedError returns msg capped to the configured stored error length
+func (r *webhookQueueRepositoryImpl) storedError(msg string) string {
+	return truncateError(msg, r.maxStoredErrorBytes)
+}
+
+// truncateError returns msg unchanged while it fits in limit bytes, and otherwise cuts it so that
+// it plus a marker recording the original size fits in limit (0 disables the cap)
+func truncateError(msg string, limit int) string {
+	if limit <= 0 || len(msg) <= limit {
+		return msg
+	}
+
+	marker := fmt.Sprintf("... [truncated, %d bytes]", len(msg))
+	cut := max(limit-len(marker), 0)
+	// Back off to a rune boundary so the error stays valid UTF-8 for the text column
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return msg[:cut] + marker
+}
+
+// MarkCompleted marks a webhook as completed and counts the delivery in its config's stats
+// Nothing is written or counted unless workerID still holds the webhook's claim
+func (r *webhookQueueRepositoryImpl) MarkCompleted(ctx context.Context, webhookID int64, workerID string, processingStartedAt time.Time, lastHTTPStatus int) error {
 	now := time.Now().UTC()
-	if err := r.db.WithContext(ctx).
-		Model(&models.WebhookQueueModel{}).
+	updates := releaseClaim(map[string]interface{}{
+		"status":                enums.WebhookStatusCompleted,
+		"processing_started_at": processingStartedAt,
+		"completed_at":          now,
+		"updated_at":            now,
+	})
+	if lastHTTPStatus != 0 {
+		updates["last_http_status"] = lastHTTPStatus
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return finishClaimed(tx, webhookID, workerID, updates, true, now)
+	})
+}
+
+// MarkFailed marks a webhook as failed and counts the failure in its config's stats
+// Nothing is written or counted unless workerID still holds the webhook's claim
+func (r *webhookQueueRepositoryImpl) MarkFailed(ctx context.Context, webhookID int64, workerID string, errorMsg string, lastHTTPStatus int) error {
+	now := time.Now().UTC()
+	updates := releaseClaim(map[string]interface{}{
+		"status":     enums.WebhookStatusFailed,
+		"last_error": r.storedError(errorMsg),
+		"updated_at": now,
+	})
+	if lastHTTPStatus != 0 {
+		updates["last_http_status"] = lastHTTPStatus
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return finishClaimed(tx, webhookID, workerID, updates, false, now)
+	})
+}
+
+// finishClaimed writes a terminal outcome within tx and counts it in the config's stats, but only while
+// workerID still holds the webhook's claim; a lost claim writes and counts nothing
+func finishClaimed(tx *gorm.DB, webhookID int64, workerID string, updates map[string]interface{}, delivered bool, at time.Time) error {
+	status := updates["status"]
+	result := claimedBy(tx.Model(&models.WebhookQueueModel{}), webhookID, workerID).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark webhook as %s: %w", status, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to mark webhook %d as %s: %w", webhookID, status, repositories.ErrClaimLost)
+	}
+	return recordConfigOutcome(tx, webhookID, delivered, at)
+}
+
+// recordConfigOutcome folds a terminal outcome into the webhook's config stats within tx
+// The stats row is created on first use and locked so concurrent outcomes for one config serialize
+func recordConfigOutcome(tx *gorm.DB, webhookID int64, delivered bool, at time.Time) error {
+	var configIDs []int64
+	if err := tx.Model(&models.WebhookQueueModel{}).
 		Where("id = ?", webhookID).
+		Pluck("config_id", &configIDs).Error; err != nil {
+		return fmt.Errorf("failed to look up webhook config for stats: %w", err)
+	}
+	if len(configIDs) == 0 {
+		return nil
+	}
+
+	stats, err := lockConfigStats(tx, configIDs[0], at)
+	if err != nil {
+		return err
+	}
+	stats.RecordOutcome(delivered, at)
+	return saveConfigStats(tx, stats)
+}
+
+// recordBulkOutcomes adjusts the stats of each config whose webhooks a bulk update moved from one
+// status to another within tx, given how many moved per config: a FAILED row is no longer counted as
+// failed, and a row moved to a terminal status is counted as that outcome
+// Configs are locked in ID order so concurrent bulk updates cannot deadlock on their stats rows
+func recordBulkOutcomes(tx *gorm.DB, moved map[int64]int64, from, to enums.WebhookStatus, at time.Time) error {
+	retract := from == enums.WebhookStatusFailed
+	record := to == enums.WebhookStatusCompleted || to == enums.WebhookStatusFailed
+	if !retract && !record {
+		return nil
+	}
+
+	configIDs := make([]int64, 0, len(moved))
+	for configID := range moved {
+		configIDs = append(configIDs, configID)
+	}
+	slices.Sort(configIDs)
+
+	for _, configID := range configIDs {
+		stats, err := lockConfigStats(tx, configID, at)
+		if err != nil {
+			return err
+		}
+		for i := int64(0); i < moved[configID]; i++ {
+			if retract {
+				stats.RetractOutcome(false, at)
+			}
+			if record {
+				stats.RecordOutcome(to == enums.WebhookStatusCompleted, at)
+			}
+		}
+		if err := saveConfigStats(tx, stats); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockConfigStats returns a config's stats locked for update within tx, creating the row on first use
+func lockConfigStats(tx *gorm.DB, configID int64, at time.Time) (*entities.WebhookConfigStats, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.WebhookConfigStatsModel{ConfigID: configID, UpdatedAt: at}).Error; err != nil {
+		return nil, fmt.Errorf("failed to create webhook config stats: %w", err)
+	}
+
+	var model models.WebhookConfigStatsModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("config_id = ?", configID).
+		First(&model).Error; err != nil {
+		return nil, fmt.Errorf("failed to lock webhook config stats: %w", err)
+	}
+	return configStatsModelToEntity(&model), nil
+}
+
+// saveConfigStats writes back stats locked by lockConfigStats
+func saveConfigStats(tx *gorm.DB, stats *entities.WebhookConfigStats) error {
+	if err := tx.Model(&models.WebhookConfigStatsModel{}).
+		Where("config_id = ?", stats.ConfigID).
 		Updates(map[string]interface{}{
-			"status":                enums.WebhookStatusCompleted,
-			"processing_started_at": processingStartedAt,
-			"completed_at":          now,
-			"updated_at":            now,
+			"total_delivered": stats.TotalDelivered,
+			"total_failed":    stats.TotalFailed,
+			"last_success_at": stats.LastSuccessAt,
+			"last_failure_at": stats.LastFailureAt,
+			"success_rate":    stats.SuccessRate,
+			"updated_at":      stats.UpdatedAt,
 		}).Error; err != nil {
-		return fmt.Errorf("failed to mark webhook as completed: %w", err)
+		return fmt.Errorf("failed to update webhook config stats: %w", err)
 	}
 	return nil
 }
 
-// MarkFailed marks a webhook as failed
-func (r *webhookQueueRepositoryImpl) MarkFailed(ctx context.Context, webhookID int64, errorMsg string) error {
+// configStatsModelToEntity converts a stats GORM model to its domain entity
+func configStatsModelToEntity(model *models.WebhookConfigStatsModel) *entities.WebhookConfigStats {
+	return &entities.WebhookConfigStats{
+		ConfigID:       model.ConfigID,
+		TotalDelivered: model.TotalDelivered,
+		TotalFailed:    model.TotalFailed,
+		LastSuccessAt:  model.LastSuccessAt,
+		LastFailureAt:  model.LastFailureAt,
+		SuccessRate:    model.SuccessRate,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+// BulkUpdateStatus moves all webhooks matching the filter to newStatus and adjusts their configs' stats
+// in one transaction, so the rollup matches the rows whether the update commits or not
+func (r *webhookQueueRepositoryImpl) BulkUpdateStatus(ctx context.Context, filter repositories.WebhookQueueFilter, newStatus enums.WebhookStatus, reason string) (int64, error) {
+	sources, err := bulkSourceStatuses(filter, newStatus)
+	if err != nil {
+		return 0, err
+	}
+
 	now := time.Now().UTC()
+	updates := bulkStatusUpdates(newStatus, r.storedError(reason), now)
+	var affected int64
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Each source is moved on its own, so the stats know which status every moved row left
+		for _, source := range sources {
+			moved, err := bulkMoveStatus(tx, filter, source, newStatus, updates)
+			if err != nil {
+				return err
+			}
+			for _, count := range moved {
+				affected += count
+			}
+			if err := recordBulkOutcomes(tx, moved, source, newStatus, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// bulkMoveStatus moves every webhook matching the filter from source to newStatus within tx and
+// returns how many moved per config
+func bulkMoveStatus(tx *gorm.DB, filter repositories.WebhookQueueFilter, source, newStatus enums.WebhookStatus, updates map[string]interface{}) (map[int64]int64, error) {
+	filter.Statuses = []enums.WebhookStatus{source}
+	var moved []models.WebhookQueueModel
+	query, err := applyQueueFilter(tx.Model(&moved), filter)
+	if err != nil {
+		return nil, err
+	}
+	if newStatus == enums.WebhookStatusCompleted {
+		// Never complete a webhook that was not attempted
+		query = query.Where("retry_0_started_at IS NOT NULL")
+	}
+
+	result := query.Clauses(clause.Returning{Columns: []clause.Column{{Name: "config_id"}}}).Updates(updates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to bulk update webhook status from %s to %s: %w", source, newStatus, result.Error)
+	}
+
+	counts := make(map[int64]int64)
+	for _, model := range moved {
+		counts[model.ConfigID]++
+	}
+	return counts, nil
+}
+
+// HardDelete removes one webhook row outright, then the response bodies it offloaded to the body store
+// The status guard leaves a PROCESSING row for its worker to finish
+func (r *webhookQueueRepositoryImpl) HardDelete(ctx context.Context, queueID uuid.UUID) (bool, error) {
+	// Offloaded bodies are only found through the row, so read their references before it is gone
+	var bodies []string
+	if r.bodyStore != nil {
+		var model models.WebhookQueueModel
+		result := r.db.WithContext(ctx).
+			Select(append(responseBodyColumns(), "retry_attempts")).
+			Where("queue_id = ? AND status <> ?", queueID, enums.WebhookStatusProcessing).
+			Limit(1).
+			Find(&model)
+		if result.Error != nil {
+			return false, fmt.Errorf("failed to read response bodies of webhook %s: %w", queueID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return false, nil
+		}
+		bodies = storedResponseBodies(&model)
+	}
+
+	result := r.db.WithContext(ctx).
+		Where("queue_id = ? AND status <> ?", queueID, enums.WebhookStatusProcessing).
+		Delete(&models.WebhookQueueModel{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete webhook %s: %w", queueID, result.Error)
+	}
+	if result.RowsAffected == 0 || len(bodies) == 0 {
+		return result.RowsAffected > 0, nil
+	}
+
+	if err := r.bodyStore.Delete(ctx, bodies); err != nil {
+		return true, fmt.Errorf("deleted webhook %s but not all of its response bodies: %w", queueID, err)
+	}
+	return true, nil
+}
+
+// responseBodyColumns lists the response body columns of the fixed attempt levels
+func responseBodyColumns() []string {
+	columns := make([]string, 0, enums.MaxRetryAttempts+1)
+	for level := 0; level <= enums.MaxRetryAttempts; level++ {
+		columns = append(columns, fmt.Sprintf("retry_%d_response_body", level))
+	}
+	return columns
+}
+
+// storedResponseBodies returns every non-empty response body value kept in a row, at any level
+func storedResponseBodies(model *models.WebhookQueueModel) []string {
+	var bodies []string
+	for _, body := range []*string{
+		model.Retry0ResponseBody, model.Retry1ResponseBody, model.Retry2ResponseBody, model.Retry3ResponseBody,
+		model.Retry4ResponseBody, model.Retry5ResponseBody, model.Retry6ResponseBody,
+	} {
+		if body != nil && *body != "" {
+			bodies = append(bodies, *body)
+		}
+	}
+	for _, attempt := range model.RetryAttempts {
+		if attempt.ResponseBody != nil && *attempt.ResponseBody != "" {
+			bodies = append(bodies, *attempt.ResponseBody)
+		}
+	}
+	return bodies
+}
+
+// ForceFail moves one PENDING or PROCESSING webhook to FAILED and counts the failure in its config's stats
+// The claim is released with the status, so an in-flight attempt's later writes find it lost and are dropped
+func (r *webhookQueueRepositoryImpl) ForceFail(ctx context.Context, queueID uuid.UUID, reason string) (enums.WebhookStatus, error) {
+	var previous enums.WebhookStatus
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		previous, err = forceFailWithin(tx, queueID, r.storedError(reason), time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return previous, nil
+}
+
+// forceFailWithin force-fails the webhook within tx and returns the status it left, empty when none matched
+func forceFailWithin(tx *gorm.DB, queueID uuid.UUID, reason string, now time.Time) (enums.WebhookStatus, error) {
+	// Locking the row first tells which status it leaves and keeps a worker's terminal write from interleaving
+	var model models.WebhookQueueModel
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "status").
+		Where("queue_id = ? AND status IN ?", queueID,
+			[]enums.WebhookStatus{enums.WebhookStatusPending, enums.WebhookStatusProcessing}).
+		Limit(1).
+		Find(&model)
+	if result.Error != nil {
+		return "", fmt.Errorf("failed to look up webhook %s to force-fail: %w", queueID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return "", nil
+	}
+
+	if err := tx.Model(&models.WebhookQueueModel{}).
+		Where("id = ?", model.ID).
+		Updates(bulkStatusUpdates(enums.WebhookStatusFailed, reason, now)).Error; err != nil {
+		return "", fmt.Errorf("failed to force-fail webhook %s: %w", queueID, err)
+	}
+	if err := recordConfigOutcome(tx, model.ID, false, now); err != nil {
+		return "", err
+	}
+	return model.Status, nil
+}
+
+// List returns up to limit webhooks matching the filter, oldest first
+func (r *webhookQueueRepositoryImpl) List(ctx context.Context, filter repositories.WebhookQueueFilter, limit int) ([]*entities.WebhookQueue, error) {
+	query, err := applyQueueFilter(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhookModels []models.WebhookQueueModel
+	if err := query.Order("id ASC").Limit(limit).Find(&webhookModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	webhooks := make([]*entities.WebhookQueue, 0, len(webhookModels))
+	for i := range webhookModels {
+		webhooks = append(webhooks, r.modelToEntity(&webhookModels[i]))
+	}
+	return webhooks, nil
+}
+
+// ListAfter returns up to limit webhooks matching the filter with an ID above afterID, in ID order
+func (r *webhookQueueRepositoryImpl) ListAfter(ctx context.Context, filter repositories.WebhookQueueFilter, afterID int64, limit int) ([]*entities.WebhookQueue, error) {
+	query, err := applyQueueFilter(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhookModels []models.WebhookQueueModel
+	if err := query.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&webhookModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	webhooks := make([]*entities.WebhookQueue, 0, len(webhookModels))
+	for i := range webhookModels {
+		webhooks = append(webhooks, r.modelToEntity(&webhookModels[i]))
+	}
+	return webhooks, nil
+}
+
+// Restore inserts a backed-up webhook under a new ID, skipping it when its queue ID is already present
+func (r *webhookQueueRepositoryImpl) Restore(ctx context.Context, webhook *entities.WebhookQueue) (bool, error) {
+	if webhook.QueueID == uuid.Nil {
+		return false, fmt.Errorf("restored webhook has no queue ID")
+	}
+
+	model := r.entityToModel(webhook)
+	model.ID = 0
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "queue_id"}}, DoNothing: true}).
+		Create(model)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to restore webhook %s: %w", webhook.QueueID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	webhook.ID = model.ID
+	return true, nil
+}
+
+// ListPage counts the webhooks matching the filter and returns those at [offset, offset+limit), newest first
+// An offset past the last match skips the row query and returns an empty page with the total
+func (r *webhookQueueRepositoryImpl) ListPage(ctx context.Context, filter repositories.WebhookQueueFilter, limit, offset int) ([]*entities.WebhookQueue, int64, error) {
+	countQuery, err := applyQueueFilter(r.db.WithContext(ctx).Model(&models.WebhookQueueModel{}), filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	var total int64
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+
+	webhooks := []*entities.WebhookQueue{}
+	if int64(offset) >= total {
+		return webhooks, total, nil
+	}
+
+	query, err := applyQueueFilter(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	var webhookModels []models.WebhookQueueModel
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&webhookModels).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	for i := range webhookModels {
+		webhooks = append(webhooks, r.modelToEntity(&webhookModels[i]))
+	}
+	return webhooks, total, nil
+}
+
+// applyQueueFilter adds the filter's conditions to query; metadata uses JSONB containment
+func applyQueueFilter(query *gorm.DB, filter repositories.WebhookQueueFilter) (*gorm.DB, error) {
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.ConfigID != nil {
+		query = query.Where("config_id = ?", *filter.ConfigID)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if len(filter.Metadata) > 0 {
+		metadata, err := json.Marshal(filter.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata filter: %w", err)
+		}
+		query = query.Where("metadata @> ?", string(metadata))
+	}
+	return query, nil
+}
+
+// bulkSourceStatuses narrows the filter's statuses to those allowed to move to newStatus
+func bulkSourceStatuses(filter repositories.WebhookQueueFilter, newStatus enums.WebhookStatus) ([]enums.WebhookStatus, error) {
+	allowed := newStatus.BulkTransitionSources()
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("bulk transition to %s is not allowed", newStatus)
+	}
+	if len(filter.Statuses) == 0 {
+		return allowed, nil
+	}
+
+	for _, status := range filter.Statuses {
+		if !status.CanBulkTransitionTo(newStatus) {
+			return nil, fmt.Errorf("bulk transition from %s to %s is not allowed", status, newStatus)
+		}
+	}
+	return filter.Statuses, nil
+}
+
+// bulkStatusUpdates returns the columns written by a bulk transition to newStatus
+func bulkStatusUpdates(newStatus enums.WebhookStatus, reason string, now time.Time) map[string]interface{} {
+	updates := releaseClaim(map[string]interface{}{
+		"status":     newStatus,
+		"last_error": reason,
+		"updated_at": now,
+	})
+	switch newStatus {
+	case enums.WebhookStatusCompleted:
+		updates["completed_at"] = now
+	case enums.WebhookStatusPending:
+		updates["next_retry_at"] = now
+	}
+	return updates
+}
+
+// FindOldestOverdue returns the pending webhook that has been due the longest as of asOf
+func (r *webhookQueueRepositoryImpl) FindOldestOverdue(ctx context.Context, asOf time.Time) (*entities.WebhookQueue, error) {
+	var model models.WebhookQueueModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_retry_at <= ?", enums.WebhookStatusPending, asOf).
+		Order("next_retry_at ASC").
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find oldest overdue webhook: %w", err)
+	}
+	return r.modelToEntity(&model), nil
+}
+
+// CountByStatus returns how many webhooks currently have the given status
+func (r *webhookQueueRepositoryImpl) CountByStatus(ctx context.Context, status enums.WebhookStatus) (int64, error) {
+	var count int64
 	if err := r.db.WithContext(ctx).
 		Model(&models.WebhookQueueModel{}).
-		Where("id = ?", webhookID).
-		Updates(map[string]interface{}{
-			"status":     enums.WebhookStatusFailed,
-			"last_error": errorMsg,
-			"updated_at": now,
-		}).Error; err != nil {
-		return fmt.Errorf("failed to mark webhook as failed: %w", err)
+		Where("status = ?", status).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count webhooks by status: %w", err)
 	}
-	return nil
+	return count, nil
+}
+
+// FindCompletedWithoutSuccess returns COMPLETED webhooks with no 2xx attempt status
+func (r *webhookQueueRepositoryImpl) FindCompletedWithoutSuccess(ctx context.Context, since time.Time, limit int) ([]*entities.WebhookQueue, error) {
+	successChecks := make([]string, 0, enums.MaxRetryAttempts+2)
+	for level := 0; level <= enums.MaxRetryAttempts; level++ {
+		successChecks = append(successChecks,
+			fmt.Sprintf("COALESCE(retry_%d_http_status, 0) BETWEEN 200 AND 299", level))
+	}
+	successChecks = append(successChecks, "EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE(retry_attempts, '[]'::jsonb)) AS attempt "+
+		"WHERE (attempt->>'http_status')::int BETWEEN 200 AND 299)")
+
+	var webhookModels []models.WebhookQueueModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND completed_at >= ?", enums.WebhookStatusCompleted, since).
+		Where("NOT (" + strings.Join(successChecks, " OR ") + ")").
+		Order("completed_at ASC").
+		Limit(limit).
+		Find(&webhookModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find completed webhooks without success: %w", err)
+	}
+
+	webhooks := make([]*entities.WebhookQueue, 0, len(webhookModels))
+	for i := range webhookModels {
+		webhooks = append(webhooks, r.modelToEntity(&webhookModels[i]))
+	}
+	return webhooks, nil
 }
 
 func (r *webhookQueueRepositoryImpl) mergeWebhookIntoModel(model *models.WebhookQueueModel, update *entities.WebhookQueue) {
@@ -271,14 +1321,24 @@ func (r *webhookQueueRepositoryImpl) mergeWebhookIntoModel(model *models.Webhook
 		model.NextRetryAt = update.NextRetryAt
 	}
 
+	if update.ThrottledCount != 0 {
+		model.ThrottledCount = update.ThrottledCount
+	}
+
 	if update.LastError != "" {
-		model.LastError = update.LastError
+		model.LastError = r.storedError(update.LastError)
 	}
 
 	if update.LastHTTPStatus != 0 {
 		model.LastHTTPStatus = update.LastHTTPStatus
 	}
 
+	// Leaving PROCESSING releases the worker's claim
+	if update.Status != "" && update.Status != enums.WebhookStatusProcessing {
+		model.ClaimedBy = nil
+		model.ClaimExpiresAt = nil
+	}
+
 	// Timestamp fields - update if non-zero in update entity
 	if !update.UpdatedAt.IsZero() {
 		model.UpdatedAt = update.UpdatedAt
@@ -296,6 +1356,10 @@ func (r *webhookQueueRepositoryImpl) mergeWebhookIntoModel(model *models.Webhook
 		model.DeletedAt = update.DeletedAt
 	}
 
+	if update.Metadata != nil {
+		model.Metadata = update.Metadata
+	}
+
 }
 
 // entityToModel converts domain entity to GORM model
@@ -308,11 +1372,16 @@ func (r *webhookQueueRepositoryImpl) entityToModel(webhook *entities.WebhookQueu
 		EventID:             webhook.EventID,
 		ConfigID:            webhook.ConfigID,
 		WebhookURL:          webhook.WebhookURL,
+		Metadata:            webhook.Metadata,
+		Payload:             webhook.Payload,
 		Status:              webhook.Status,
 		RetryCount:          webhook.RetryCount,
+		ThrottledCount:      webhook.ThrottledCount,
 		NextRetryAt:         webhook.NextRetryAt,
 		LastError:           webhook.LastError,
 		LastHTTPStatus:      webhook.LastHTTPStatus,
+		ClaimedBy:           webhook.ClaimedBy,
+		ClaimExpiresAt:      webhook.ClaimExpiresAt,
 		CreatedAt:           webhook.CreatedAt,
 		UpdatedAt:           webhook.UpdatedAt,
 		ProcessingStartedAt: webhook.ProcessingStartedAt,
@@ -323,51 +1392,88 @@ func (r *webhookQueueRepositoryImpl) entityToModel(webhook *entities.WebhookQueu
 		Retry0StartedAt:    webhook.Retry0StartedAt,
 		Retry0CompletedAt:  webhook.Retry0CompletedAt,
 		Retry0DurationMs:   webhook.Retry0DurationMs,
+		Retry0TimeoutMs:    webhook.Retry0TimeoutMs,
 		Retry0HTTPStatus:   webhook.Retry0HTTPStatus,
 		Retry0ResponseBody: webhook.Retry0ResponseBody,
 		Retry0Error:        webhook.Retry0Error,
+		Retry0ErrorClass:   webhook.Retry0ErrorClass,
+
+		Retry0RequestBody:    webhook.Retry0RequestBody,
+		Retry0RequestHeaders: webhook.Retry0RequestHeaders,
 
 		Retry1StartedAt:    webhook.Retry1StartedAt,
 		Retry1CompletedAt:  webhook.Retry1CompletedAt,
 		Retry1DurationMs:   webhook.Retry1DurationMs,
+		Retry1TimeoutMs:    webhook.Retry1TimeoutMs,
 		Retry1HTTPStatus:   webhook.Retry1HTTPStatus,
 		Retry1ResponseBody: webhook.Retry1ResponseBody,
 		Retry1Error:        webhook.Retry1Error,
+		Retry1ErrorClass:   webhook.Retry1ErrorClass,
+
+		Retry1RequestBody:    webhook.Retry1RequestBody,
+		Retry1RequestHeaders: webhook.Retry1RequestHeaders,
 
 		Retry2StartedAt:    webhook.Retry2StartedAt,
 		Retry2CompletedAt:  webhook.Retry2CompletedAt,
 		Retry2DurationMs:   webhook.Retry2DurationMs,
+		Retry2TimeoutMs:    webhook.Retry2TimeoutMs,
 		Retry2HTTPStatus:   webhook.Retry2HTTPStatus,
 		Retry2ResponseBody: webhook.Retry2ResponseBody,
 		Retry2Error:        webhook.Retry2Error,
+		Retry2ErrorClass:   webhook.Retry2ErrorClass,
+
+		Retry2RequestBody:    webhook.Retry2RequestBody,
+		Retry2RequestHeaders: webhook.Retry2RequestHeaders,
 
 		Retry3StartedAt:    webhook.Retry3StartedAt,
 		Retry3CompletedAt:  webhook.Retry3CompletedAt,
 		Retry3DurationMs:   webhook.Retry3DurationMs,
+		Retry3TimeoutMs:    webhook.Retry3TimeoutMs,
 		Retry3HTTPStatus:   webhook.Retry3HTTPStatus,
 		Retry3ResponseBody: webhook.Retry3ResponseBody,
 		Retry3Error:        webhook.Retry3Error,
+		Retry3ErrorClass:   webhook.Retry3ErrorClass,
+
+		Retry3RequestBody:    webhook.Retry3RequestBody,
+		Retry3RequestHeaders: webhook.Retry3RequestHeaders,
 
 		Retry4StartedAt:    webhook.Retry4StartedAt,
 		Retry4CompletedAt:  webhook.Retry4CompletedAt,
 		Retry4DurationMs:   webhook.Retry4DurationMs,
+		Retry4TimeoutMs:    webhook.Retry4TimeoutMs,
 		Retry4HTTPStatus:   webhook.Retry4HTTPStatus,
 		Retry4ResponseBody: webhook.Retry4ResponseBody,
 		Retry4Error:        webhook.Retry4Error,
+		Retry4ErrorClass:   webhook.Retry4ErrorClass,
+
+		Retry4RequestBody:    webhook.Retry4RequestBody,
+		Retry4RequestHeaders: webhook.Retry4RequestHeaders,
 
 		Retry5StartedAt:    webhook.Retry5StartedAt,
 		Retry5CompletedAt:  webhook.Retry5CompletedAt,
 		Retry5DurationMs:   webhook.Retry5DurationMs,
+		Retry5TimeoutMs:    webhook.Retry5TimeoutMs,
 		Retry5HTTPStatus:   webhook.Retry5HTTPStatus,
 		Retry5ResponseBody: webhook.Retry5ResponseBody,
 		Retry5Error:        webhook.Retry5Error,
+		Retry5ErrorClass:   webhook.Retry5ErrorClass,
+
+		Retry5RequestBody:    webhook.Retry5RequestBody,
+		Retry5RequestHeaders: webhook.Retry5RequestHeaders,
 
 		Retry6StartedAt:    webhook.Retry6StartedAt,
 		Retry6CompletedAt:  webhook.Retry6CompletedAt,
 		Retry6DurationMs:   webhook.Retry6DurationMs,
+		Retry6TimeoutMs:    webhook.Retry6TimeoutMs,
 		Retry6HTTPStatus:   webhook.Retry6HTTPStatus,
 		Retry6ResponseBody: webhook.Retry6ResponseBody,
 		Retry6Error:        webhook.Retry6Error,
+		Retry6ErrorClass:   webhook.Retry6ErrorClass,
+
+		Retry6RequestBody:    webhook.Retry6RequestBody,
+		Retry6RequestHeaders: webhook.Retry6RequestHeaders,
+
+		RetryAttempts: retryAttemptsToModel(webhook.ExtraAttempts),
 	}
 }
 
@@ -381,11 +1487,16 @@ func (r *webhookQueueRepositoryImpl) modelToEntity(model *models.WebhookQueueMod
 		EventID:             model.EventID,
 		ConfigID:            model.ConfigID,
 		WebhookURL:          model.WebhookURL,
+		Metadata:            model.Metadata,
+		Payload:             model.Payload,
 		Status:              model.Status,
 		RetryCount:          model.RetryCount,
+		ThrottledCount:      model.ThrottledCount,
 		NextRetryAt:         model.NextRetryAt,
 		LastError:           model.LastError,
 		LastHTTPStatus:      model.LastHTTPStatus,
+		ClaimedBy:           model.ClaimedBy,
+		ClaimExpiresAt:      model.ClaimExpiresAt,
 		CreatedAt:           model.CreatedAt,
 		UpdatedAt:           model.UpdatedAt,
 		ProcessingStartedAt: model.ProcessingStartedAt,
@@ -396,50 +1507,111 @@ func (r *webhookQueueRepositoryImpl) modelToEntity(model *models.WebhookQueueMod
 		Retry0StartedAt:    model.Retry0StartedAt,
 		Retry0CompletedAt:  model.Retry0CompletedAt,
 		Retry0DurationMs:   model.Retry0DurationMs,
+		Retry0TimeoutMs:    model.Retry0TimeoutMs,
 		Retry0HTTPStatus:   model.Retry0HTTPStatus,
 		Retry0ResponseBody: model.Retry0ResponseBody,
 		Retry0Error:        model.Retry0Error,
+		Retry0ErrorClass:   model.Retry0ErrorClass,
+
+		Retry0RequestBody:    model.Retry0RequestBody,
+		Retry0RequestHeaders: model.Retry0RequestHeaders,
 
 		Retry1StartedAt:    model.Retry1StartedAt,
 		Retry1CompletedAt:  model.Retry1CompletedAt,
 		Retry1DurationMs:   model.Retry1DurationMs,
+		Retry1TimeoutMs:    model.Retry1TimeoutMs,
 		Retry1HTTPStatus:   model.Retry1HTTPStatus,
 		Retry1ResponseBody: model.Retry1ResponseBody,
 		Retry1Error:        model.Retry1Error,
+		Retry1ErrorClass:   model.Retry1ErrorClass,
+
+		Retry1RequestBody:    model.Retry1RequestBody,
+		Retry1RequestHeaders: model.Retry1RequestHeaders,
 
 		Retry2StartedAt:    model.Retry2StartedAt,
 		Retry2CompletedAt:  model.Retry2CompletedAt,
 		Retry2DurationMs:   model.Retry2DurationMs,
+		Retry2TimeoutMs:    model.Retry2TimeoutMs,
 		Retry2HTTPStatus:   model.Retry2HTTPStatus,
 		Retry2ResponseBody: model.Retry2ResponseBody,
 		Retry2Error:        model.Retry2Error,
+		Retry2ErrorClass:   model.Retry2ErrorClass,
+
+		Retry2RequestBody:    model.Retry2RequestBody,
+		Retry2RequestHeaders: model.Retry2RequestHeaders,
 
 		Retry3StartedAt:    model.Retry3StartedAt,
 		Retry3CompletedAt:  model.Retry3CompletedAt,
 		Retry3DurationMs:   model.Retry3DurationMs,
+		Retry3TimeoutMs:    model.Retry3TimeoutMs,
 		Retry3HTTPStatus:   model.Retry3HTTPStatus,
 		Retry3ResponseBody: model.Retry3ResponseBody,
 		Retry3Error:        model.Retry3Error,
+		Retry3ErrorClass:   model.Retry3ErrorClass,
+
+		Retry3RequestBody:    model.Retry3RequestBody,
+		Retry3RequestHeaders: model.Retry3RequestHeaders,
 
 		Retry4StartedAt:    model.Retry4StartedAt,
 		Retry4CompletedAt:  model.Retry4CompletedAt,
 		Retry4DurationMs:   model.Retry4DurationMs,
+		Retry4TimeoutMs:    model.Retry4TimeoutMs,
 		Retry4HTTPStatus:   model.Retry4HTTPStatus,
 		Retry4ResponseBody: model.Retry4ResponseBody,
 		Retry4Error:        model.Retry4Error,
+		Retry4ErrorClass:   model.Retry4ErrorClass,
+
+		Retry4RequestBody:    model.Retry4RequestBody,
+		Retry4RequestHeaders: model.Retry4RequestHeaders,
 
 		Retry5StartedAt:    model.Retry5StartedAt,
 		Retry5CompletedAt:  model.Retry5CompletedAt,
 		Retry5DurationMs:   model.Retry5DurationMs,
+		Retry5TimeoutMs:    model.Retry5TimeoutMs,
 		Retry5HTTPStatus:   model.Retry5HTTPStatus,
 		Retry5ResponseBody: model.Retry5ResponseBody,
 		Retry5Error:        model.Retry5Error,
+		Retry5ErrorClass:   model.Retry5ErrorClass,
+
+		Retry5RequestBody:    model.Retry5RequestBody,
+		Retry5RequestHeaders: model.Retry5RequestHeaders,
 
 		Retry6StartedAt:    model.Retry6StartedAt,
 		Retry6CompletedAt:  model.Retry6CompletedAt,
 		Retry6DurationMs:   model.Retry6DurationMs,
+		Retry6TimeoutMs:    model.Retry6TimeoutMs,
 		Retry6HTTPStatus:   model.Retry6HTTPStatus,
 		Retry6ResponseBody: model.Retry6ResponseBody,
 		Retry6Error:        model.Retry6Error,
+		Retry6ErrorClass:   model.Retry6ErrorClass,
+
+		Retry6RequestBody:    model.Retry6RequestBody,
+		Retry6RequestHeaders: model.Retry6RequestHeaders,
+
+		ExtraAttempts: retryAttemptsToEntity(model.RetryAttempts),
+	}
+}
+
+// retryAttemptsToModel converts the attempts above the fixed retry columns for storage
+func retryAttemptsToModel(attempts []entities.WebhookAttempt) models.RetryAttemptList {
+	if len(attempts) == 0 {
+		return nil
+	}
+	list := make(models.RetryAttemptList, len(attempts))
+	for i, attempt := range attempts {
+		list[i] = models.RetryAttemptModel(attempt)
+	}
+	return list
+}
+
+// retryAttemptsToEntity converts the stored attempts above the fixed retry columns
+func retryAttemptsToEntity(list models.RetryAttemptList) []entities.WebhookAttempt {
+	if len(list) == 0 {
+		return nil
+	}
+	attempts := make([]entities.WebhookAttempt, len(list))
+	for i, attempt := range list {
+		attempts[i] = entities.WebhookAttempt(attempt)
 	}
+	return attempts
 }
@@ -1,45 +1,124 @@
 package repositories
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
+	"github.com/go-kit/log"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"webhook-processor/internal/domain/entities"
 	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/infrastructure/metrics"
 	"webhook-processor/internal/infrastructure/models"
 )
 
+// testMetrics is shared because Prometheus collectors can only be registered once per process
+var testMetrics = metrics.NewWebhookMetrics()
+
+// responseTruncated reads the truncated response counter for a config
+func responseTruncated(t *testing.T, configID string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "webhook_response_truncated_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "config_id" && label.GetValue() == configID {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// lockWaitCount reads how many lock waits were observed for a retry level
+func lockWaitCount(t *testing.T, retryLevel string) uint64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "webhook_lock_wait_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "retry_level" && label.GetValue() == retryLevel {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return 0
+}
+
 // TestWebhookQueueRepositoryImpl_Constructor tests repository construction
 func TestWebhookQueueRepositoryImpl_Constructor(t *testing.T) {
 	tests := []struct {
-		name        string
-		db          *gorm.DB
-		expectError bool
-		errorMsg    string
+		name           string
+		db             *gorm.DB
+		maxLockingTxns int
+		claimTTL       time.Duration
+		expectError    bool
+		errorMsg       string
 	}{
 		{
-			name:        "should create repository with valid db",
-			db:          &gorm.DB{},
-			expectError: false,
+			name:           "should create repository with valid db",
+			db:             &gorm.DB{},
+			maxLockingTxns: 10,
+			claimTTL:       5 * time.Minute,
+			expectError:    false,
+		},
+		{
+			name:           "should return error with nil db",
+			db:             nil,
+			maxLockingTxns: 10,
+			claimTTL:       5 * time.Minute,
+			expectError:    true,
+			errorMsg:       "database cannot be nil",
+		},
+		{
+			name:           "should return error with non-positive locking limit",
+			db:             &gorm.DB{},
+			maxLockingTxns: 0,
+			claimTTL:       5 * time.Minute,
+			expectError:    true,
+			errorMsg:       "max locking transactions must be positive",
 		},
 		{
-			name:        "should return error with nil db",
-			db:          nil,
-			expectError: true,
-			errorMsg:    "database cannot be nil",
+			name:           "should return error with non-positive claim TTL",
+			db:             &gorm.DB{},
+			maxLockingTxns: 10,
+			claimTTL:       0,
+			expectError:    true,
+			errorMsg:       "claim TTL must be positive",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			repo, err := NewWebhookQueueRepository(tt.db)
+			repo, err := NewWebhookQueueRepository(tt.db, QueueRepositoryOptions{
+				MaxLockingTxns:   tt.maxLockingTxns,
+				ClaimTTL:         tt.claimTTL,
+				AttemptBatchSize: 1,
+			}, nil, log.NewNopLogger())
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -458,6 +537,160 @@ func TestWebhookQueueRepositoryImpl_UpdateRetryAttemptLogic(t *testing.T) {
 	}
 }
 
+func TestWebhookQueueRepositoryImpl_UpdateRetryAttemptColumns(t *testing.T) {
+	// newRepo captures the columns and SQL of the attempt update; matched says whether the row matched
+	newRepo := func(t *testing.T, matched bool) (*webhookQueueRepositoryImpl, *map[string]interface{}, *string, *[]interface{}) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var updates map[string]interface{}
+		var statement string
+		var vars []interface{}
+		require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_columns", func(tx *gorm.DB) {
+			updates, _ = tx.Statement.Dest.(map[string]interface{})
+			statement = tx.Statement.SQL.String()
+			vars = tx.Statement.Vars
+			if matched {
+				tx.RowsAffected = 1
+			}
+		}))
+
+		return &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger()}, &updates, &statement, &vars
+	}
+
+	t.Run("should write a level-3 pickup into the retry_3 columns only", func(t *testing.T) {
+		repo, updates, statement, vars := newRepo(t, true)
+		completedAt := time.Now().UTC()
+
+		err := repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 3, completedAt.Add(-time.Second), &completedAt,
+			1000, 5000, 503, "unavailable", "HTTP 503: Service Unavailable", enums.ErrorClassHTTPStatus, nil)
+
+		require.NoError(t, err)
+		for _, column := range []string{"started_at", "completed_at", "duration_ms", "timeout_ms", "http_status", "response_body", "error", "error_class"} {
+			assert.Contains(t, *updates, "retry_3_"+column)
+		}
+		assert.Equal(t, 503, (*updates)["retry_3_http_status"])
+		assert.Contains(t, *statement, "retry_count = $")
+		assert.Equal(t, 3, (*vars)[len(*vars)-1], "update should only match a row still at retry count 3")
+	})
+
+	t.Run("should never write another level's columns", func(t *testing.T) {
+		for level := 0; level <= enums.MaxRetryAttempts; level++ {
+			repo, updates, _, _ := newRepo(t, true)
+
+			require.NoError(t, repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", level, time.Now(), nil, 10, 0, 500, "", "boom", enums.ErrorClassHTTPStatus, nil))
+
+			prefix := fmt.Sprintf("retry_%d_", level)
+			for column := range *updates {
+				if strings.HasPrefix(column, "retry_") {
+					assert.True(t, strings.HasPrefix(column, prefix), "level %d wrote %s", level, column)
+				}
+			}
+			assert.Contains(t, *updates, prefix+"started_at")
+		}
+	})
+
+	t.Run("should write the sent request into the level's columns when given", func(t *testing.T) {
+		repo, updates, _, _ := newRepo(t, true)
+		request := &entities.AttemptRequest{
+			Body:    `{"id":"evt-1"}`,
+			Headers: map[string]string{"Content-Type": "application/json", "Authorization": "REDACTED"},
+		}
+
+		err := repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 2, time.Now(), nil, 10, 0, 400, "bad request", "HTTP 400", enums.ErrorClassHTTPStatus, request)
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":"evt-1"}`, (*updates)["retry_2_request_body"])
+		assert.Equal(t, models.HeaderMap(request.Headers), (*updates)["retry_2_request_headers"])
+	})
+
+	t.Run("should leave the request columns alone when no request is given", func(t *testing.T) {
+		repo, updates, _, _ := newRepo(t, true)
+
+		require.NoError(t, repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 2, time.Now(), nil, 10, 0, 400, "", "HTTP 400", enums.ErrorClassHTTPStatus, nil))
+
+		assert.NotContains(t, *updates, "retry_2_request_body")
+		assert.NotContains(t, *updates, "retry_2_request_headers")
+	})
+
+	t.Run("should store a level above the fixed columns in retry_attempts only", func(t *testing.T) {
+		repo, updates, _, _ := newRepo(t, true)
+		request := &entities.AttemptRequest{Body: `{"id":"evt-1"}`}
+
+		err := repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 8, time.Now(), nil, 10, 0, 503, "", "HTTP 503", enums.ErrorClassHTTPStatus, request)
+
+		require.NoError(t, err)
+		for column := range *updates {
+			assert.False(t, strings.HasPrefix(column, "retry_") && column != "retry_attempts", "level 8 wrote %s", column)
+		}
+		expr, ok := (*updates)["retry_attempts"].(clause.Expr)
+		require.True(t, ok)
+		assert.Contains(t, expr.SQL, "jsonb_array_elements(retry_attempts)")
+		var appended models.RetryAttemptList
+		require.NoError(t, json.Unmarshal([]byte(expr.Vars[1].(string)), &appended))
+		require.Len(t, appended, 1)
+		assert.Equal(t, 8, appended[0].RetryLevel)
+		assert.Equal(t, 503, *appended[0].HTTPStatus)
+		assert.Equal(t, `{"id":"evt-1"}`, *appended[0].RequestBody)
+	})
+
+	t.Run("should keep one entry per level when a throttled level is written again", func(t *testing.T) {
+		repo, updates, _, _ := newRepo(t, true)
+
+		// The dry run cannot evaluate the expression, so apply it as Postgres would: keep the entries
+		// of other levels, then append the new one
+		var stored models.RetryAttemptList
+		apply := func() {
+			expr, ok := (*updates)["retry_attempts"].(clause.Expr)
+			require.True(t, ok)
+			require.Contains(t, expr.SQL, "WHERE (attempt->>'retry_level')::int <> ?")
+			var kept models.RetryAttemptList
+			for _, attempt := range stored {
+				if attempt.RetryLevel != expr.Vars[0].(int) {
+					kept = append(kept, attempt)
+				}
+			}
+			var added models.RetryAttemptList
+			require.NoError(t, json.Unmarshal([]byte(expr.Vars[1].(string)), &added))
+			stored = append(kept, added...)
+		}
+
+		for _, durationMs := range []int64{10, 20} {
+			require.NoError(t, repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 7, time.Now(), nil, durationMs, 0,
+				429, "", "HTTP 429", enums.ErrorClassHTTPStatus, nil))
+			apply()
+		}
+		require.Len(t, stored, 1, "a second throttle at level 7 replaces the first")
+		assert.Equal(t, int64(20), *stored[0].DurationMs)
+
+		require.NoError(t, repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 8, time.Now(), nil, 30, 0,
+			503, "", "HTTP 503", enums.ErrorClassHTTPStatus, nil))
+		apply()
+		require.Len(t, stored, 2)
+		assert.Equal(t, []int{7, 8}, []int{stored[0].RetryLevel, stored[1].RetryLevel})
+	})
+
+	t.Run("should reject a level outside the configurable retry range", func(t *testing.T) {
+		repo, updates, _, _ := newRepo(t, true)
+
+		for _, level := range []int{-1, enums.MaxConfigurableRetries + 1} {
+			err := repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", level, time.Now(), nil, 10, 0, 500, "", "", enums.ErrorClassNone, nil)
+
+			assert.ErrorContains(t, err, "invalid retry level")
+		}
+		assert.Nil(t, *updates)
+	})
+
+	t.Run("should report an attempt whose level does not match the stored retry count", func(t *testing.T) {
+		repo, _, _, _ := newRepo(t, false)
+
+		err := repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 3, time.Now(), nil, 10, 0, 500, "", "", enums.ErrorClassNone, nil)
+
+		assert.ErrorContains(t, err, "retry count is not 3")
+	})
+}
+
 // TestWebhookQueueRepositoryImpl_MarkCompletedLogic tests MarkCompleted logic
 func TestWebhookQueueRepositoryImpl_MarkCompletedLogic(t *testing.T) {
 	tests := []struct {
@@ -573,6 +806,1070 @@ func TestWebhookQueueRepositoryImpl_GetNextWebhookLogic(t *testing.T) {
 	}
 }
 
+// TestWebhookQueueRepositoryImpl_ProcessingClaim tests setting and releasing worker claims
+func TestWebhookQueueRepositoryImpl_ProcessingClaim(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(5 * time.Minute)
+
+	t.Run("should claim the row for the worker until the TTL expires", func(t *testing.T) {
+		updates := claimUpdates("worker-level-0-1", now, expiresAt)
+
+		assert.Equal(t, enums.WebhookStatusProcessing, updates["status"])
+		assert.Equal(t, "worker-level-0-1", updates["claimed_by"])
+		assert.Equal(t, expiresAt, updates["claim_expires_at"])
+		assert.Equal(t, now, updates["updated_at"])
+	})
+
+	t.Run("should release the claim on terminal and bulk transitions", func(t *testing.T) {
+		completed := releaseClaim(map[string]interface{}{"status": enums.WebhookStatusCompleted})
+		assert.Contains(t, completed, "claimed_by")
+		assert.Nil(t, completed["claimed_by"])
+		assert.Contains(t, completed, "claim_expires_at")
+		assert.Nil(t, completed["claim_expires_at"])
+
+		failed := bulkStatusUpdates(enums.WebhookStatusFailed, "manual", now)
+		assert.Contains(t, failed, "claimed_by")
+		assert.Nil(t, failed["claimed_by"])
+	})
+
+	t.Run("should release the claim when an update moves the webhook out of PROCESSING", func(t *testing.T) {
+		repo := &webhookQueueRepositoryImpl{}
+		workerID := "worker-level-0-1"
+		model := &models.WebhookQueueModel{
+			Status:         enums.WebhookStatusProcessing,
+			ClaimedBy:      &workerID,
+			ClaimExpiresAt: &expiresAt,
+		}
+
+		repo.mergeWebhookIntoModel(model, &entities.WebhookQueue{Status: enums.WebhookStatusPending})
+
+		assert.Nil(t, model.ClaimedBy)
+		assert.Nil(t, model.ClaimExpiresAt)
+	})
+
+	t.Run("should keep the claim while the webhook stays PROCESSING", func(t *testing.T) {
+		repo := &webhookQueueRepositoryImpl{}
+		workerID := "worker-level-0-1"
+		model := &models.WebhookQueueModel{
+			Status:         enums.WebhookStatusProcessing,
+			ClaimedBy:      &workerID,
+			ClaimExpiresAt: &expiresAt,
+		}
+
+		repo.mergeWebhookIntoModel(model, &entities.WebhookQueue{LastError: "timeout"})
+
+		require.NotNil(t, model.ClaimedBy)
+		assert.Equal(t, workerID, *model.ClaimedBy)
+		assert.Equal(t, &expiresAt, model.ClaimExpiresAt)
+	})
+
+	t.Run("should reclaim only PROCESSING rows whose claim has expired", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var sql string
+		require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_sql", func(tx *gorm.DB) {
+			sql = tx.Statement.SQL.String()
+		}))
+
+		repo := &webhookQueueRepositoryImpl{db: db}
+		_, err = repo.ReclaimExpiredClaims(context.Background(), now)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, `"status"=$`)
+		assert.Contains(t, sql, `"claimed_by"=$`)
+		assert.Contains(t, sql, `"claim_expires_at"=$`)
+		assert.Contains(t, sql, `WHERE status = $`)
+		assert.Contains(t, sql, `AND claim_expires_at < $`)
+	})
+
+	t.Run("should map the claim between entity and model", func(t *testing.T) {
+		repo := &webhookQueueRepositoryImpl{}
+		workerID := "worker-level-1-2"
+		model := repo.entityToModel(&entities.WebhookQueue{ClaimedBy: &workerID, ClaimExpiresAt: &expiresAt})
+
+		entity := repo.modelToEntity(model)
+
+		assert.Equal(t, &workerID, entity.ClaimedBy)
+		assert.Equal(t, &expiresAt, entity.ClaimExpiresAt)
+	})
+}
+
+// TestWebhookQueueRepositoryImpl_ClaimGuard tests that a worker's writes only land while it holds the claim
+func TestWebhookQueueRepositoryImpl_ClaimGuard(t *testing.T) {
+	// newRepo captures every UPDATE's SQL and vars; matched says whether the claim check matched the row
+	newRepo := func(t *testing.T, matched bool) (*webhookQueueRepositoryImpl, *[]string, *[]interface{}) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var statements []string
+		var vars []interface{}
+		require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_claim", func(tx *gorm.DB) {
+			statements = append(statements, tx.Statement.SQL.String())
+			vars = tx.Statement.Vars
+			if matched {
+				tx.RowsAffected = 1
+			}
+		}))
+
+		return &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger()}, &statements, &vars
+	}
+
+	t.Run("should write a terminal outcome only under the worker's claim", func(t *testing.T) {
+		repo, statements, vars := newRepo(t, true)
+
+		err := finishClaimed(repo.db, 42, "worker-1", map[string]interface{}{"status": enums.WebhookStatusCompleted}, true, time.Now())
+
+		require.NoError(t, err)
+		require.NotEmpty(t, *statements)
+		assert.Contains(t, (*statements)[0], "id = $")
+		assert.Contains(t, (*statements)[0], "status = $")
+		assert.Contains(t, (*statements)[0], "claimed_by = $")
+		assert.Contains(t, *vars, "worker-1")
+	})
+
+	t.Run("should report a lost claim without counting the outcome", func(t *testing.T) {
+		repo, statements, vars := newRepo(t, false)
+
+		err := finishClaimed(repo.db, 42, "worker-1", map[string]interface{}{"status": enums.WebhookStatusFailed}, false, time.Now())
+
+		assert.ErrorIs(t, err, repositories.ErrClaimLost)
+		assert.Len(t, *statements, 1, "the config stats must not be touched")
+		assert.Equal(t, []interface{}{int64(42), enums.WebhookStatusProcessing, "worker-1"}, (*vars)[len(*vars)-3:])
+	})
+
+	t.Run("should renew only the worker's own claim", func(t *testing.T) {
+		repo, statements, vars := newRepo(t, true)
+		repo.claimTTL = 5 * time.Minute
+
+		require.NoError(t, repo.ExtendClaim(context.Background(), 42, "worker-1"))
+
+		require.Len(t, *statements, 1)
+		assert.Contains(t, (*statements)[0], `"claim_expires_at"=$`)
+		assert.NotContains(t, (*statements)[0], "updated_at", "a renewal must not redate the claim")
+		assert.Equal(t, []interface{}{int64(42), enums.WebhookStatusProcessing, "worker-1"}, (*vars)[len(*vars)-3:])
+	})
+
+	t.Run("should report a lost claim when renewing", func(t *testing.T) {
+		repo, _, _ := newRepo(t, false)
+
+		assert.ErrorIs(t, repo.ExtendClaim(context.Background(), 42, "worker-1"), repositories.ErrClaimLost)
+	})
+
+	t.Run("should report a lost claim when writing back a webhook", func(t *testing.T) {
+		repo, statements, vars := newRepo(t, false)
+
+		err := repo.Update(context.Background(), &entities.WebhookQueue{ID: 42, Status: enums.WebhookStatusPending}, "worker-1")
+
+		assert.ErrorIs(t, err, repositories.ErrClaimLost)
+		require.Len(t, *statements, 1)
+		assert.Contains(t, (*statements)[0], "status = $")
+		assert.Contains(t, (*statements)[0], "claimed_by = $")
+		assert.Contains(t, *vars, "worker-1")
+	})
+}
+
+// TestWebhookQueueRepositoryImpl_LockingSlots tests the locking-transaction semaphore
+func TestWebhookQueueRepositoryImpl_LockingSlots(t *testing.T) {
+	t.Run("should serialize lock acquisition under a small pool without deadlock", func(t *testing.T) {
+		repo := &webhookQueueRepositoryImpl{lockingSlots: make(chan struct{}, 1)}
+
+		var inFlight, maxInFlight int32
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, repo.acquireLockingSlot(context.Background()))
+				defer repo.releaseLockingSlot()
+
+				current := atomic.AddInt32(&inFlight, 1)
+				for {
+					observed := atomic.LoadInt32(&maxInFlight)
+					if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+			}()
+		}
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("lock acquisition deadlocked")
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&maxInFlight))
+		assert.Len(t, repo.lockingSlots, 0)
+	})
+
+	t.Run("should give up waiting when context is cancelled", func(t *testing.T) {
+		repo := &webhookQueueRepositoryImpl{lockingSlots: make(chan struct{}, 1)}
+		require.NoError(t, repo.acquireLockingSlot(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := repo.acquireLockingSlot(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		repo.releaseLockingSlot()
+		assert.NoError(t, repo.acquireLockingSlot(context.Background()))
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_GetNextWebhookOrdering(t *testing.T) {
+	t.Run("should take rows due at the same time in ID order", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var statement string
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+			if statement == "" {
+				statement = tx.Statement.SQL.String()
+			}
+		}))
+
+		var model models.WebhookQueueModel
+		require.NoError(t, nextDueWebhook(db, 2, time.Now()).First(&model).Error)
+
+		assert.Contains(t, statement, "ORDER BY next_retry_at ASC, id ASC")
+		assert.Contains(t, statement, "FOR UPDATE SKIP LOCKED")
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_GetNextWebhookCancelled(t *testing.T) {
+	newRepo := func(t *testing.T) *webhookQueueRepositoryImpl {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+		return &webhookQueueRepositoryImpl{db: db, lockingSlots: make(chan struct{}, 1), logger: log.NewNopLogger(), claimTTL: time.Minute}
+	}
+
+	t.Run("should return cleanly when cancelled while waiting for a locking slot", func(t *testing.T) {
+		repo := newRepo(t)
+		require.NoError(t, repo.acquireLockingSlot(context.Background()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		type result struct {
+			webhook *entities.WebhookQueue
+			err     error
+		}
+		done := make(chan result, 1)
+		go func() {
+			webhook, err := repo.GetNextWebhookForProcessing(ctx, "worker-1", 0)
+			done <- result{webhook, err}
+		}()
+
+		cancel()
+		select {
+		case res := <-done:
+			assert.NoError(t, res.err)
+			assert.Nil(t, res.webhook)
+		case <-time.After(5 * time.Second):
+			t.Fatal("claim did not return after cancellation")
+		}
+
+		repo.releaseLockingSlot()
+		assert.Len(t, repo.lockingSlots, 0)
+	})
+
+	t.Run("should return cleanly and release the slot when cancelled before the transaction starts", func(t *testing.T) {
+		repo := newRepo(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		webhook, err := repo.GetNextWebhookForProcessing(ctx, "worker-1", 0)
+
+		assert.NoError(t, err)
+		assert.Nil(t, webhook)
+		assert.Len(t, repo.lockingSlots, 0, "locking slot should be released")
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_GetByQueueID(t *testing.T) {
+	// newRepo returns a repository whose select fills the row with model, or fails with queryErr when set
+	newRepo := func(t *testing.T, model *models.WebhookQueueModel, queryErr error) (*webhookQueueRepositoryImpl, *string) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+		var statement string
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:find_row", func(tx *gorm.DB) {
+			statement = tx.Statement.SQL.String()
+			switch {
+			case queryErr != nil:
+				tx.AddError(queryErr)
+			case model == nil:
+				tx.AddError(gorm.ErrRecordNotFound)
+			default:
+				*tx.Statement.Dest.(*models.WebhookQueueModel) = *model
+			}
+		}))
+		return &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger()}, &statement
+	}
+
+	t.Run("should return the webhook with the queue ID", func(t *testing.T) {
+		queueID := uuid.New()
+		repo, statement := newRepo(t, &models.WebhookQueueModel{ID: 7, QueueID: queueID, Status: enums.WebhookStatusPending, RetryCount: 2}, nil)
+
+		webhook, err := repo.GetByQueueID(context.Background(), queueID)
+
+		require.NoError(t, err)
+		require.NotNil(t, webhook)
+		assert.Equal(t, int64(7), webhook.ID)
+		assert.Equal(t, queueID, webhook.QueueID)
+		assert.Equal(t, 2, webhook.RetryCount)
+		assert.Contains(t, *statement, "queue_id = $1")
+	})
+
+	t.Run("should return nil without an error when no webhook has the queue ID", func(t *testing.T) {
+		repo, _ := newRepo(t, nil, nil)
+
+		webhook, err := repo.GetByQueueID(context.Background(), uuid.New())
+
+		assert.NoError(t, err)
+		assert.Nil(t, webhook)
+	})
+
+	t.Run("should wrap a database error", func(t *testing.T) {
+		dbErr := errors.New("connection refused")
+		repo, _ := newRepo(t, nil, dbErr)
+
+		webhook, err := repo.GetByQueueID(context.Background(), uuid.New())
+
+		assert.Nil(t, webhook)
+		assert.ErrorIs(t, err, dbErr)
+		assert.ErrorContains(t, err, "failed to get webhook by queue id")
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_ListPage(t *testing.T) {
+	// newRepo returns a repository whose count query reports total and whose row query returns rows,
+	// capturing every statement with its variables; queryErr fails every query when set
+	newRepo := func(t *testing.T, total int64, rows []models.WebhookQueueModel, queryErr error) (*webhookQueueRepositoryImpl, *[]string, *[][]interface{}) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+		var statements []string
+		var vars [][]interface{}
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:list_page", func(tx *gorm.DB) {
+			statements = append(statements, tx.Statement.SQL.String())
+			vars = append(vars, tx.Statement.Vars)
+			if queryErr != nil {
+				tx.AddError(queryErr)
+				return
+			}
+			switch dest := tx.Statement.Dest.(type) {
+			case *int64:
+				// Count reads the scanned value only when exactly one row came back
+				*dest, tx.RowsAffected = total, 1
+			case *[]models.WebhookQueueModel:
+				*dest = rows
+			}
+		}))
+		return &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger()}, &statements, &vars
+	}
+
+	t.Run("should count and page the webhooks matching every filter, newest first", func(t *testing.T) {
+		configID := int64(3)
+		after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		before := after.Add(time.Hour)
+		rows := []models.WebhookQueueModel{{ID: 9, QueueID: uuid.New(), Status: enums.WebhookStatusFailed}}
+		repo, statements, vars := newRepo(t, 25, rows, nil)
+
+		webhooks, total, err := repo.ListPage(context.Background(), repositories.WebhookQueueFilter{
+			ConfigID:      &configID,
+			Statuses:      []enums.WebhookStatus{enums.WebhookStatusFailed},
+			EventType:     enums.EventTypeDebit,
+			CreatedAfter:  &after,
+			CreatedBefore: &before,
+		}, 10, 20)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(25), total)
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, int64(9), webhooks[0].ID)
+
+		require.Len(t, *statements, 2)
+		assert.Contains(t, (*statements)[0], "SELECT count(*)")
+		for i, statement := range *statements {
+			assert.Contains(t, statement, "status IN ($1)")
+			assert.Contains(t, statement, "config_id = $2")
+			assert.Contains(t, statement, "event_type = $3")
+			assert.Contains(t, statement, "created_at >= $4")
+			assert.Contains(t, statement, "created_at < $5")
+			assert.Equal(t, []interface{}{enums.WebhookStatusFailed, configID, enums.EventTypeDebit, after, before}, (*vars)[i][:5])
+		}
+		assert.Contains(t, (*statements)[1], "ORDER BY created_at DESC, id DESC LIMIT $6 OFFSET $7")
+	})
+
+	t.Run("should apply only the filters that are set", func(t *testing.T) {
+		repo, statements, _ := newRepo(t, 1, nil, nil)
+
+		_, _, err := repo.ListPage(context.Background(), repositories.WebhookQueueFilter{
+			Statuses: []enums.WebhookStatus{enums.WebhookStatusPending},
+		}, 50, 0)
+
+		require.NoError(t, err)
+		require.Len(t, *statements, 2)
+		assert.Contains(t, (*statements)[1], "WHERE status IN ($1) ORDER BY")
+		assert.NotContains(t, (*statements)[1], "event_type")
+	})
+
+	t.Run("should return an empty page without a row query when nothing matches", func(t *testing.T) {
+		repo, statements, _ := newRepo(t, 0, nil, nil)
+
+		webhooks, total, err := repo.ListPage(context.Background(), repositories.WebhookQueueFilter{}, 50, 0)
+
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.NotNil(t, webhooks)
+		assert.Empty(t, webhooks)
+		assert.Len(t, *statements, 1)
+	})
+
+	t.Run("should return an empty page with the total for an offset past the end", func(t *testing.T) {
+		repo, statements, _ := newRepo(t, 12, nil, nil)
+
+		webhooks, total, err := repo.ListPage(context.Background(), repositories.WebhookQueueFilter{}, 50, 12)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), total)
+		assert.Empty(t, webhooks)
+		assert.Len(t, *statements, 1)
+	})
+
+	t.Run("should wrap a database error", func(t *testing.T) {
+		dbErr := errors.New("connection refused")
+		repo, _, _ := newRepo(t, 0, nil, dbErr)
+
+		webhooks, _, err := repo.ListPage(context.Background(), repositories.WebhookQueueFilter{}, 50, 0)
+
+		assert.Nil(t, webhooks)
+		assert.ErrorIs(t, err, dbErr)
+		assert.ErrorContains(t, err, "failed to count webhooks")
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_LockWaitMetric(t *testing.T) {
+	// newRepo returns a repository whose locking select finds a row when found is set
+	newRepo := func(t *testing.T, found bool) *webhookQueueRepositoryImpl {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:find_row", func(tx *gorm.DB) {
+			if !found {
+				tx.AddError(gorm.ErrRecordNotFound)
+			}
+		}))
+		return &webhookQueueRepositoryImpl{db: db, metrics: testMetrics, logger: log.NewNopLogger()}
+	}
+
+	t.Run("should observe the lock wait when a row is locked", func(t *testing.T) {
+		repo := newRepo(t, true)
+		before := lockWaitCount(t, "4")
+
+		var model models.WebhookQueueModel
+		err := repo.lockNextDueWebhook(repo.db, 4, time.Now(), time.Now(), &model)
+
+		require.NoError(t, err)
+		assert.Equal(t, before+1, lockWaitCount(t, "4"))
+	})
+
+	t.Run("should observe the lock wait when no row is found", func(t *testing.T) {
+		repo := newRepo(t, false)
+		before := lockWaitCount(t, "5")
+
+		var model models.WebhookQueueModel
+		err := repo.lockNextDueWebhook(repo.db, 5, time.Now(), time.Now(), &model)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Equal(t, before+1, lockWaitCount(t, "5"))
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_Schema(t *testing.T) {
+	// newRepo captures every statement run against a database using dbSchema
+	newRepo := func(t *testing.T, dbSchema string) (*webhookQueueRepositoryImpl, *[]string) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true,
+				NamingStrategy: models.NamingStrategy(dbSchema)})
+		require.NoError(t, err)
+
+		var statements []string
+		capture := func(tx *gorm.DB) { statements = append(statements, tx.Statement.SQL.String()) }
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture_query", capture))
+		require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_update", func(tx *gorm.DB) {
+			capture(tx)
+			tx.RowsAffected = 1
+		}))
+		return &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger()}, &statements
+	}
+
+	t.Run("should qualify queue queries with the configured schema", func(t *testing.T) {
+		repo, statements := newRepo(t, "tenant_a")
+
+		_, err := repo.List(context.Background(), repositories.WebhookQueueFilter{}, 10)
+		require.NoError(t, err)
+		require.NoError(t, repo.UpdateRetryAttempt(context.Background(), 1, "worker-1", 0, time.Now(), nil, 10, 0, 500, "", "boom", enums.ErrorClassHTTPStatus, nil))
+
+		require.Len(t, *statements, 2)
+		for _, statement := range *statements {
+			assert.Contains(t, statement, `"tenant_a"."webhook_queue"`)
+		}
+	})
+
+	t.Run("should qualify config tables with the configured schema", func(t *testing.T) {
+		repo, statements := newRepo(t, "tenant_b")
+
+		var config models.WebhookConfigModel
+		require.NoError(t, repo.db.First(&config, 1).Error)
+
+		require.Len(t, *statements, 1)
+		assert.Contains(t, (*statements)[0], `FROM "tenant_b"."webhook_configs"`)
+	})
+
+	t.Run("should leave tables unqualified without a schema", func(t *testing.T) {
+		repo, statements := newRepo(t, "")
+
+		_, err := repo.List(context.Background(), repositories.WebhookQueueFilter{}, 10)
+		require.NoError(t, err)
+
+		require.Len(t, *statements, 1)
+		assert.Contains(t, (*statements)[0], `FROM "webhook_queue"`)
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_Metadata(t *testing.T) {
+	repo := &webhookQueueRepositoryImpl{}
+	metadata := map[string]string{"tenant": "acme", "source": "api"}
+
+	t.Run("should round-trip metadata through the model", func(t *testing.T) {
+		model := repo.entityToModel(&entities.WebhookQueue{QueueID: uuid.New(), Metadata: metadata})
+
+		value, err := model.Metadata.Value()
+		require.NoError(t, err)
+
+		var scanned models.MetadataMap
+		require.NoError(t, scanned.Scan([]byte(value.(string))))
+		model.Metadata = scanned
+
+		assert.Equal(t, metadata, repo.modelToEntity(model).Metadata)
+	})
+
+	t.Run("should store no metadata as NULL", func(t *testing.T) {
+		value, err := models.MetadataMap(nil).Value()
+
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("should filter by metadata containment", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true})
+		require.NoError(t, err)
+
+		configID := int64(3)
+		filter := repositories.WebhookQueueFilter{
+			ConfigID: &configID,
+			Statuses: []enums.WebhookStatus{enums.WebhookStatusFailed},
+			Metadata: map[string]string{"tenant": "acme"},
+		}
+
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			query, err := applyQueueFilter(tx, filter)
+			require.NoError(t, err)
+			var webhookModels []models.WebhookQueueModel
+			return query.Limit(10).Find(&webhookModels)
+		})
+
+		assert.Contains(t, sql, `status IN ('FAILED')`)
+		assert.Contains(t, sql, `config_id = 3`)
+		assert.Contains(t, sql, `metadata @> '{"tenant":"acme"}'`)
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_BulkUpdateLogic(t *testing.T) {
+	t.Run("should default to every allowed source status", func(t *testing.T) {
+		statuses, err := bulkSourceStatuses(repositories.WebhookQueueFilter{}, enums.WebhookStatusCompleted)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []enums.WebhookStatus{enums.WebhookStatusPending, enums.WebhookStatusFailed}, statuses)
+	})
+
+	t.Run("should reject disallowed transitions", func(t *testing.T) {
+		_, err := bulkSourceStatuses(repositories.WebhookQueueFilter{
+			Statuses: []enums.WebhookStatus{enums.WebhookStatusCompleted},
+		}, enums.WebhookStatusPending)
+		assert.Error(t, err)
+
+		_, err = bulkSourceStatuses(repositories.WebhookQueueFilter{}, enums.WebhookStatusProcessing)
+		assert.Error(t, err)
+	})
+
+	t.Run("should write target specific columns", func(t *testing.T) {
+		now := time.Now().UTC()
+
+		completed := bulkStatusUpdates(enums.WebhookStatusCompleted, "manual", now)
+		assert.Equal(t, now, completed["completed_at"])
+		assert.NotContains(t, completed, "next_retry_at")
+
+		pending := bulkStatusUpdates(enums.WebhookStatusPending, "manual", now)
+		assert.Equal(t, now, pending["next_retry_at"])
+		assert.Equal(t, "manual", pending["last_error"])
+	})
+}
+
+// TestWebhookQueueRepositoryImpl_BulkUpdateStats tests that bulk updates keep config stats in step
+func TestWebhookQueueRepositoryImpl_BulkUpdateStats(t *testing.T) {
+	at := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	// newRepo captures every UPDATE's SQL and, in order, the stats each stats UPDATE writes; a dry run
+	// reads every stats row as empty
+	newRepo := func(t *testing.T) (*webhookQueueRepositoryImpl, *[]string, *[]map[string]interface{}) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var statements []string
+		var written []map[string]interface{}
+		require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_stats", func(tx *gorm.DB) {
+			statements = append(statements, tx.Statement.SQL.String())
+			if updates, ok := tx.Statement.Dest.(map[string]interface{}); ok && strings.Contains(tx.Statement.SQL.String(), "webhook_config_stats") {
+				written = append(written, updates)
+			}
+		}))
+
+		return &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger()}, &statements, &written
+	}
+
+	t.Run("should move one source status at a time, returning each row's config", func(t *testing.T) {
+		repo, statements, _ := newRepo(t)
+		configID := int64(3)
+
+		_, err := bulkMoveStatus(repo.db, repositories.WebhookQueueFilter{ConfigID: &configID},
+			enums.WebhookStatusFailed, enums.WebhookStatusCompleted, bulkStatusUpdates(enums.WebhookStatusCompleted, "", at))
+
+		require.NoError(t, err)
+		require.Len(t, *statements, 1)
+		assert.Contains(t, (*statements)[0], "status IN ($")
+		assert.Contains(t, (*statements)[0], "retry_0_started_at IS NOT NULL")
+		assert.Contains(t, (*statements)[0], `RETURNING "config_id"`)
+	})
+
+	t.Run("should count webhooks force-failed in bulk as failures", func(t *testing.T) {
+		repo, _, written := newRepo(t)
+
+		err := recordBulkOutcomes(repo.db, map[int64]int64{2: 1, 1: 2}, enums.WebhookStatusPending, enums.WebhookStatusFailed, at)
+
+		require.NoError(t, err)
+		require.Len(t, *written, 2, "configs are written in ID order")
+		assert.Equal(t, int64(2), (*written)[0]["total_failed"])
+		assert.Equal(t, int64(0), (*written)[0]["total_delivered"])
+		assert.Equal(t, int64(1), (*written)[1]["total_failed"])
+	})
+
+	t.Run("should stop counting requeued webhooks as failed", func(t *testing.T) {
+		repo, _, written := newRepo(t)
+
+		err := recordBulkOutcomes(repo.db, map[int64]int64{1: 1}, enums.WebhookStatusFailed, enums.WebhookStatusPending, at)
+
+		require.NoError(t, err)
+		require.Len(t, *written, 1)
+		assert.Equal(t, int64(0), (*written)[0]["total_failed"], "a stats row that never counted the failure stays at zero")
+		assert.Equal(t, int64(0), (*written)[0]["total_delivered"])
+	})
+
+	t.Run("should move completed failures from failed to delivered", func(t *testing.T) {
+		repo, _, written := newRepo(t)
+
+		err := recordBulkOutcomes(repo.db, map[int64]int64{1: 2}, enums.WebhookStatusFailed, enums.WebhookStatusCompleted, at)
+
+		require.NoError(t, err)
+		require.Len(t, *written, 1)
+		assert.Equal(t, int64(2), (*written)[0]["total_delivered"])
+		assert.Equal(t, int64(0), (*written)[0]["total_failed"])
+	})
+
+	t.Run("should leave stats alone when nothing terminal changes", func(t *testing.T) {
+		repo, statements, _ := newRepo(t)
+
+		err := recordBulkOutcomes(repo.db, map[int64]int64{1: 5}, enums.WebhookStatusPending, enums.WebhookStatusPending, at)
+
+		require.NoError(t, err)
+		assert.Empty(t, *statements)
+	})
+}
+
+// TestWebhookQueueRepositoryImpl_ForceFail tests which webhooks an operator can force-fail
+func TestWebhookQueueRepositoryImpl_ForceFail(t *testing.T) {
+	t.Run("should lock a pending or in-flight webhook before failing it", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var sql string
+		var vars []interface{}
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture_lookup", func(tx *gorm.DB) {
+			sql = tx.Statement.SQL.String()
+			vars = tx.Statement.Vars
+		}))
+
+		previous, err := forceFailWithin(db, uuid.New(), "receiver retired", time.Now())
+
+		require.NoError(t, err)
+		assert.Empty(t, previous, "a dry run matches no row")
+		assert.Contains(t, sql, "status IN ($2,$3)")
+		assert.Contains(t, sql, "FOR UPDATE")
+		assert.Contains(t, vars, enums.WebhookStatusPending)
+		assert.Contains(t, vars, enums.WebhookStatusProcessing)
+	})
+
+	t.Run("should release the claim so the in-flight attempt's writes are dropped", func(t *testing.T) {
+		updates := bulkStatusUpdates(enums.WebhookStatusFailed, "receiver retired", time.Now())
+
+		assert.Contains(t, updates, "claimed_by")
+		assert.Nil(t, updates["claimed_by"])
+		assert.Contains(t, updates, "claim_expires_at")
+		assert.Nil(t, updates["claim_expires_at"])
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_ResponseBodyBudget(t *testing.T) {
+	t.Run("should snippet later attempts once the row budget is spent", func(t *testing.T) {
+		const budget = 10 * 1024
+		body := strings.Repeat("x", 4*1024)
+
+		var stored int64
+		var kept []string
+		for attempt := 0; attempt <= enums.MaxRetryAttempts; attempt++ {
+			storedBody := budgetResponseBody(body, stored, budget)
+			kept = append(kept, storedBody)
+			stored += int64(len(storedBody))
+		}
+
+		assert.Equal(t, body, kept[0])
+		assert.Equal(t, body, kept[1])
+		for _, snippet := range kept[2:] {
+			assert.Less(t, len(snippet), responseBodySnippetBytes+64)
+			assert.True(t, strings.HasSuffix(snippet, "... [truncated, 4096 bytes]"))
+		}
+		assert.LessOrEqual(t, stored, int64(budget))
+	})
+
+	t.Run("should keep short bodies even past the budget", func(t *testing.T) {
+		assert.Equal(t, "ok", budgetResponseBody("ok", 1<<20, 1024))
+	})
+
+	t.Run("should not split multi-byte characters", func(t *testing.T) {
+		body := strings.Repeat("é", responseBodySnippetBytes)
+
+		snippet := budgetResponseBody(body, 0, 1)
+
+		assert.True(t, utf8.ValidString(snippet))
+	})
+
+	t.Run("should count and log a response truncated by the budget", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var storedBody string
+		require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_vars", func(tx *gorm.DB) {
+			tx.RowsAffected = 1
+			for _, v := range tx.Statement.Vars {
+				if body, ok := v.(string); ok && strings.Contains(body, "truncated") {
+					storedBody = body
+				}
+			}
+		}))
+
+		var truncatedLogs int
+		logger := log.LoggerFunc(func(keyvals ...interface{}) error {
+			for i := 0; i+1 < len(keyvals); i += 2 {
+				if keyvals[i] == "msg" && keyvals[i+1] == "response body truncated to fit the stored response budget" {
+					truncatedLogs++
+				}
+			}
+			return nil
+		})
+
+		repo := &webhookQueueRepositoryImpl{db: db, metrics: testMetrics, logger: logger, maxStoredResponseBytes: 1024}
+		before := responseTruncated(t, "0")
+
+		body := strings.Repeat("x", 4*1024)
+		err = repo.UpdateRetryAttempt(context.Background(), 1, "worker-1", 0, time.Now(), nil, 10, 0, 200, body, "", enums.ErrorClassNone, nil)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(storedBody, "... [truncated, 4096 bytes]"))
+		assert.Equal(t, before+1, responseTruncated(t, "0"))
+		assert.Equal(t, 1, truncatedLogs)
+	})
+
+	t.Run("should snippet a request body that does not fit beside the response", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var updates map[string]interface{}
+		require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_columns", func(tx *gorm.DB) {
+			tx.RowsAffected = 1
+			updates, _ = tx.Statement.Dest.(map[string]interface{})
+		}))
+
+		repo := &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger(), maxStoredResponseBytes: 1024}
+		request := &entities.AttemptRequest{Body: strings.Repeat("r", 4*1024)}
+
+		err = repo.UpdateRetryAttempt(context.Background(), 1, "worker-1", 0, time.Now(), nil, 10, 0, 400, "rejected", "HTTP 400", enums.ErrorClassHTTPStatus, request)
+
+		require.NoError(t, err)
+		assert.Equal(t, "rejected", updates["retry_0_response_body"])
+		assert.True(t, strings.HasSuffix(updates["retry_0_request_body"].(string), "... [truncated, 4096 bytes]"))
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_StoredErrorCap(t *testing.T) {
+	const limit = 2048
+	longError := strings.Repeat("x", 10*1024)
+
+	t.Run("should store a normal error intact", func(t *testing.T) {
+		assert.Equal(t, "HTTP 503: Service Unavailable", truncateError("HTTP 503: Service Unavailable", limit))
+	})
+
+	t.Run("should truncate an over-length error to the cap with a marker", func(t *testing.T) {
+		stored := truncateError(longError, limit)
+
+		assert.Len(t, stored, limit)
+		assert.True(t, strings.HasSuffix(stored, "... [truncated, 10240 bytes]"))
+	})
+
+	t.Run("should keep any error when the cap is disabled", func(t *testing.T) {
+		assert.Equal(t, longError, truncateError(longError, 0))
+	})
+
+	t.Run("should not split multi-byte characters", func(t *testing.T) {
+		stored := truncateError(strings.Repeat("é", limit), limit)
+
+		assert.True(t, utf8.ValidString(stored))
+		assert.LessOrEqual(t, len(stored), limit)
+	})
+
+	t.Run("should cap the attempt and last errors written by UpdateRetryAttempt", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var updates map[string]interface{}
+		require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_columns", func(tx *gorm.DB) {
+			updates, _ = tx.Statement.Dest.(map[string]interface{})
+			tx.RowsAffected = 1
+		}))
+		repo := &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger(), maxStoredErrorBytes: limit}
+
+		err = repo.UpdateRetryAttempt(context.Background(), 1, "worker-1", 2, time.Now(), nil, 10, 0, 500, "", longError, enums.ErrorClassHTTPStatus, nil)
+
+		require.NoError(t, err)
+		for _, column := range []string{"last_error", "retry_2_error"} {
+			assert.Len(t, updates[column], limit, column)
+		}
+	})
+
+	t.Run("should cap the last error merged by Update", func(t *testing.T) {
+		repo := &webhookQueueRepositoryImpl{maxStoredErrorBytes: limit}
+		model := &models.WebhookQueueModel{}
+
+		repo.mergeWebhookIntoModel(model, &entities.WebhookQueue{LastError: longError})
+
+		assert.Len(t, model.LastError, limit)
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_HardDelete(t *testing.T) {
+	t.Run("should delete the row outright and never a PROCESSING one", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		var statement string
+		var vars []interface{}
+		require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:capture_sql", func(tx *gorm.DB) {
+			statement = tx.Statement.SQL.String()
+			vars = tx.Statement.Vars
+		}))
+
+		repo := &webhookQueueRepositoryImpl{db: db}
+		queueID := uuid.New()
+
+		_, err = repo.HardDelete(context.Background(), queueID)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(statement, `DELETE FROM "webhook_queue"`), statement)
+		assert.Contains(t, statement, "queue_id = $1 AND status <> $2")
+		assert.Equal(t, []interface{}{queueID, enums.WebhookStatusProcessing}, vars)
+	})
+
+	t.Run("should delete the response bodies offloaded for the row", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		store := &memoryBodyStore{bodies: map[string]string{
+			"mem:1/0": "service unavailable",
+			"mem:1/8": "bad gateway",
+			"mem:2/0": "another webhook's body",
+		}}
+		inline, level0, level8 := "ok", "mem:1/0", "mem:1/8"
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:find_row", func(tx *gorm.DB) {
+			model := tx.Statement.Dest.(*models.WebhookQueueModel)
+			model.Retry0ResponseBody = &level0
+			model.Retry1ResponseBody = &inline
+			model.RetryAttempts = models.RetryAttemptList{{RetryLevel: 8, ResponseBody: &level8}}
+			tx.RowsAffected = 1
+		}))
+		require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:delete_row", func(tx *gorm.DB) {
+			tx.RowsAffected = 1
+		}))
+		repo := &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger(), bodyStore: store}
+
+		deleted, err := repo.HardDelete(context.Background(), uuid.New())
+
+		require.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, map[string]string{"mem:2/0": "another webhook's body"}, store.bodies)
+	})
+
+	t.Run("should keep the bodies when the row is not deleted", func(t *testing.T) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+
+		store := &memoryBodyStore{bodies: map[string]string{"mem:1/0": "service unavailable"}}
+		repo := &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger(), bodyStore: store}
+
+		deleted, err := repo.HardDelete(context.Background(), uuid.New())
+
+		require.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Len(t, store.bodies, 1)
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_CreateBatch(t *testing.T) {
+	newRepo := func(t *testing.T) (*webhookQueueRepositoryImpl, *[]string) {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+		var statements []string
+		require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:capture_sql", func(tx *gorm.DB) {
+			statements = append(statements, tx.Statement.SQL.String())
+		}))
+		return &webhookQueueRepositoryImpl{db: db}, &statements
+	}
+
+	t.Run("should insert every webhook in one statement and give each a queue ID", func(t *testing.T) {
+		repo, statements := newRepo(t)
+		webhooks := []*entities.WebhookQueue{
+			{EventType: enums.EventTypeCredit, EventID: "txn-1", ConfigID: 1, Status: enums.WebhookStatusPending},
+			{EventType: enums.EventTypeCredit, EventID: "txn-1", ConfigID: 2, Status: enums.WebhookStatusPending},
+		}
+
+		err := repo.CreateBatch(context.Background(), webhooks)
+
+		require.NoError(t, err)
+		require.Len(t, *statements, 1)
+		assert.Equal(t, 1, strings.Count((*statements)[0], "),("), "both rows are in the one INSERT")
+		assert.NotEqual(t, uuid.Nil, webhooks[0].QueueID)
+		assert.NotEqual(t, webhooks[0].QueueID, webhooks[1].QueueID)
+	})
+
+	t.Run("should run nothing for no webhooks", func(t *testing.T) {
+		repo, statements := newRepo(t)
+
+		require.NoError(t, repo.CreateBatch(context.Background(), nil))
+		assert.Empty(t, *statements)
+	})
+}
+
+func TestWebhookQueueRepositoryImpl_Backup(t *testing.T) {
+	newDB := func(t *testing.T) *gorm.DB {
+		db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+			&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+		require.NoError(t, err)
+		return db
+	}
+
+	t.Run("should list the next batch after an ID in ID order", func(t *testing.T) {
+		db := newDB(t)
+		var statement string
+		var vars []interface{}
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+			statement, vars = tx.Statement.SQL.String(), tx.Statement.Vars
+		}))
+		repo := &webhookQueueRepositoryImpl{db: db}
+
+		_, err := repo.ListAfter(context.Background(), repositories.WebhookQueueFilter{
+			Statuses: []enums.WebhookStatus{enums.WebhookStatusPending},
+		}, 42, 100)
+
+		require.NoError(t, err)
+		assert.Contains(t, statement, "status IN ($1) AND id > $2 ORDER BY id ASC LIMIT $3")
+		assert.Equal(t, []interface{}{enums.WebhookStatusPending, int64(42), 100}, vars)
+	})
+
+	t.Run("should restore under a new ID and skip a queue ID already present", func(t *testing.T) {
+		db := newDB(t)
+		var statement string
+		var vars []interface{}
+		var rowsAffected int64
+		require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:capture_sql", func(tx *gorm.DB) {
+			statement, vars = tx.Statement.SQL.String(), tx.Statement.Vars
+			tx.RowsAffected = rowsAffected
+		}))
+		repo := &webhookQueueRepositoryImpl{db: db}
+		createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		nextRetryAt := createdAt.Add(time.Hour)
+		webhook := &entities.WebhookQueue{ID: 7, QueueID: uuid.New(), ConfigID: 1, Status: enums.WebhookStatusPending,
+			NextRetryAt: nextRetryAt, CreatedAt: createdAt, UpdatedAt: createdAt}
+
+		rowsAffected = 1
+		restored, err := repo.Restore(context.Background(), webhook)
+
+		require.NoError(t, err)
+		assert.True(t, restored)
+		assert.Contains(t, statement, `ON CONFLICT ("queue_id") DO NOTHING`)
+		assert.True(t, strings.HasPrefix(statement, `INSERT INTO "webhook_queue" ("event_type"`), "the backed-up ID is not reused")
+		assert.Contains(t, vars, webhook.QueueID)
+		assert.Contains(t, vars, nextRetryAt, "a restored webhook keeps its schedule")
+		assert.Contains(t, vars, createdAt, "a restored webhook keeps its creation time")
+
+		rowsAffected = 0
+		restored, err = repo.Restore(context.Background(), webhook)
+
+		require.NoError(t, err)
+		assert.False(t, restored)
+	})
+
+	t.Run("should reject a webhook without a queue ID", func(t *testing.T) {
+		repo := &webhookQueueRepositoryImpl{db: newDB(t)}
+
+		restored, err := repo.Restore(context.Background(), &entities.WebhookQueue{ConfigID: 1})
+
+		assert.False(t, restored)
+		assert.ErrorContains(t, err, "no queue ID")
+	})
+}
+
 // TestWebhookQueueRepositoryImpl_ErrorFormatting tests error message formatting
 func TestWebhookQueueRepositoryImpl_ErrorFormatting(t *testing.T) {
 	tests := []struct {
@@ -1079,3 +2376,91 @@ func TestWebhookQueueRepository_MergeWebhookIntoModel_ComprehensiveCoverage(t *t
 		assert.Nil(t, existingModel.DeletedAt)                          // Original nil preserved
 	})
 }
+
+// memoryBodyStore offloads bodies to a map, keeping a reference in the row
+type memoryBodyStore struct {
+	bodies map[string]string
+}
+
+func (s *memoryBodyStore) Put(_ context.Context, queueID uuid.UUID, retryLevel int, body string) (string, error) {
+	ref := fmt.Sprintf("mem:%s/%d", queueID, retryLevel)
+	s.bodies[ref] = body
+	return ref, nil
+}
+
+func (s *memoryBodyStore) Resolve(_ context.Context, stored string) (string, error) {
+	if body, ok := s.bodies[stored]; ok {
+		return body, nil
+	}
+	return stored, nil
+}
+
+func (s *memoryBodyStore) Delete(_ context.Context, stored []string) error {
+	for _, ref := range stored {
+		delete(s.bodies, ref)
+	}
+	return nil
+}
+
+func TestWebhookQueueRepositoryImpl_BodyStore(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	var updates map[string]interface{}
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture_body", func(tx *gorm.DB) {
+		updates, _ = tx.Statement.Dest.(map[string]interface{})
+		tx.RowsAffected = 1
+	}))
+
+	// Each row's queue ID is read along with its stored response size
+	queueIDs := map[int64]uuid.UUID{42: uuid.New(), 43: uuid.New(), 44: uuid.New()}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:find_row", func(tx *gorm.DB) {
+		if stored, ok := tx.Statement.Dest.(*storedResponses); ok {
+			stored.QueueID = queueIDs[tx.Statement.Vars[len(tx.Statement.Vars)-1].(int64)]
+			tx.RowsAffected = 1
+		}
+	}))
+
+	store := &memoryBodyStore{bodies: map[string]string{}}
+	repo := &webhookQueueRepositoryImpl{db: db, logger: log.NewNopLogger(), bodyStore: store}
+
+	t.Run("should keep only the reference in the row and resolve it on read", func(t *testing.T) {
+		body := strings.Repeat("x", 4096)
+
+		err := repo.UpdateRetryAttempt(context.Background(), 42, "worker-1", 1, time.Now(), nil, 10, 0, 502, body, "HTTP 502", enums.ErrorClassHTTPStatus, nil)
+		require.NoError(t, err)
+
+		stored, ok := updates["retry_1_response_body"].(string)
+		require.True(t, ok)
+		assert.Equal(t, fmt.Sprintf("mem:%s/1", queueIDs[42]), stored, "keyed by queue ID, not row ID")
+
+		inline := "ok"
+		webhook := &entities.WebhookQueue{Retry0ResponseBody: &inline, Retry1ResponseBody: &stored}
+		repo.resolveResponseBodies(context.Background(), webhook)
+
+		assert.Equal(t, "ok", *webhook.Retry0ResponseBody)
+		assert.Equal(t, body, *webhook.Retry1ResponseBody)
+	})
+
+	t.Run("should resolve the references of attempts above the fixed levels", func(t *testing.T) {
+		body := strings.Repeat("y", 4096)
+
+		err := repo.UpdateRetryAttempt(context.Background(), 44, "worker-1", 9, time.Now(), nil, 10, 0, 502, body, "HTTP 502", enums.ErrorClassHTTPStatus, nil)
+		require.NoError(t, err)
+
+		stored := fmt.Sprintf("mem:%s/9", queueIDs[44])
+		webhook := &entities.WebhookQueue{ExtraAttempts: []entities.WebhookAttempt{{RetryLevel: 9, ResponseBody: &stored}}}
+		repo.resolveResponseBodies(context.Background(), webhook)
+
+		assert.Equal(t, body, *webhook.ExtraAttempts[0].ResponseBody)
+	})
+
+	t.Run("should not offload an empty body", func(t *testing.T) {
+		err := repo.UpdateRetryAttempt(context.Background(), 43, "worker-1", 0, time.Now(), nil, 10, 0, 0, "", "timeout", enums.ErrorClassTimeout, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "", updates["retry_0_response_body"])
+		assert.Empty(t, store.bodies[fmt.Sprintf("mem:%s/0", queueIDs[43])])
+	})
+}
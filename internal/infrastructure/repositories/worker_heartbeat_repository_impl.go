@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/repositories"
+	"webhook-processor/internal/infrastructure/models"
+)
+
+// workerHeartbeatRepositoryImpl implements the WorkerHeartbeatRepository interface
+type workerHeartbeatRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewWorkerHeartbeatRepository creates a new worker heartbeat repository
+func NewWorkerHeartbeatRepository(db *gorm.DB) (repositories.WorkerHeartbeatRepository, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database cannot be nil")
+	}
+	return &workerHeartbeatRepositoryImpl{db: db}, nil
+}
+
+// Upsert inserts the worker's heartbeat or refreshes the existing row in one statement
+// started_at is kept from the first insert
+func (r *workerHeartbeatRepositoryImpl) Upsert(ctx context.Context, heartbeat *entities.WorkerHeartbeat) error {
+	model := models.WorkerHeartbeatModel{
+		WorkerID:   heartbeat.WorkerID,
+		RetryLevel: heartbeat.RetryLevel,
+		Host:       heartbeat.Host,
+		StartedAt:  heartbeat.StartedAt,
+		LastSeenAt: heartbeat.LastSeenAt,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "worker_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"retry_level", "host", "last_seen_at"}),
+	}).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to upsert heartbeat for worker %s: %w", heartbeat.WorkerID, err)
+	}
+	return nil
+}
+
+// List returns every recorded heartbeat
+func (r *workerHeartbeatRepositoryImpl) List(ctx context.Context) ([]*entities.WorkerHeartbeat, error) {
+	var rows []models.WorkerHeartbeatModel
+	if err := r.db.WithContext(ctx).Order("host, retry_level, worker_id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list worker heartbeats: %w", err)
+	}
+
+	heartbeats := make([]*entities.WorkerHeartbeat, 0, len(rows))
+	for _, row := range rows {
+		heartbeats = append(heartbeats, &entities.WorkerHeartbeat{
+			WorkerID:   row.WorkerID,
+			RetryLevel: row.RetryLevel,
+			Host:       row.Host,
+			StartedAt:  row.StartedAt,
+			LastSeenAt: row.LastSeenAt,
+		})
+	}
+	return heartbeats, nil
+}
+
+// Delete removes the worker's heartbeat; a missing row is not an error
+func (r *workerHeartbeatRepositoryImpl) Delete(ctx context.Context, workerID string) error {
+	if err := r.db.WithContext(ctx).Where("worker_id = ?", workerID).Delete(&models.WorkerHeartbeatModel{}).Error; err != nil {
+		return fmt.Errorf("failed to delete heartbeat for worker %s: %w", workerID, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"webhook-processor/internal/domain/entities"
+)
+
+func TestWorkerHeartbeatRepositoryImpl_Upsert(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	var statement string
+	var vars []interface{}
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:capture_upsert", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+		vars = tx.Statement.Vars
+	}))
+
+	repo := &workerHeartbeatRepositoryImpl{db: db}
+	startedAt := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	lastSeenAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	err = repo.Upsert(context.Background(), &entities.WorkerHeartbeat{
+		WorkerID:   "retry-2-abcd1234",
+		RetryLevel: 2,
+		Host:       "processor-a",
+		StartedAt:  startedAt,
+		LastSeenAt: lastSeenAt,
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, statement, `INSERT INTO "worker_heartbeats"`)
+	assert.Contains(t, statement, `ON CONFLICT ("worker_id") DO UPDATE SET`)
+	assert.Contains(t, statement, `"last_seen_at"="excluded"."last_seen_at"`)
+	assert.NotContains(t, statement, `"started_at"="excluded"."started_at"`, "a refreshed heartbeat keeps its start time")
+	assert.Equal(t, []interface{}{"retry-2-abcd1234", 2, "processor-a", startedAt, lastSeenAt}, vars)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"webhook-processor/internal/config"
+)
+
+// adaptiveTimeoutPercentile is the response time percentile the adaptive timeout is scaled from
+const adaptiveTimeoutPercentile = 0.99
+
+// responseTimeTracker keeps each config's most recent response times and derives a tighter attempt
+// timeout from them, so a receiver that degrades is given up on sooner than the static timeout allows
+type responseTimeTracker struct {
+	settings config.AdaptiveTimeoutConfig
+
+	mu      sync.Mutex
+	configs map[int64]*responseTimeWindow
+}
+
+// responseTimeWindow is a ring of a config's most recent response times
+type responseTimeWindow struct {
+	samples []time.Duration
+	next    int // Index overwritten by the next sample once the window is full
+}
+
+// newResponseTimeTracker creates a tracker; when settings are disabled it observes nothing and never
+// changes a timeout
+func newResponseTimeTracker(settings config.AdaptiveTimeoutConfig) *responseTimeTracker {
+	return &responseTimeTracker{settings: settings, configs: make(map[int64]*responseTimeWindow)}
+}
+
+// observe records how long a config's receiver took to respond
+// Only answered requests are observed: a timeout says nothing about how long the answer would have taken
+func (t *responseTimeTracker) observe(configID int64, responseTime time.Duration) {
+	if !t.settings.Enabled {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	window, ok := t.configs[configID]
+	if !ok {
+		window = &responseTimeWindow{samples: make([]time.Duration, 0, t.settings.Window)}
+		t.configs[configID] = window
+	}
+	if len(window.samples) < t.settings.Window {
+		window.samples = append(window.samples, responseTime)
+		return
+	}
+	window.samples[window.next] = responseTime
+	window.next = (window.next + 1) % t.settings.Window
+}
+
+// timeout returns the attempt timeout for a config: its p99 response time times the factor, grown like
+// the static timeout by grow, bounded by the floor and by ceiling, the static timeout of the attempt
+// Until enough responses have been observed the ceiling is returned unchanged
+func (t *responseTimeTracker) timeout(configID int64, ceiling time.Duration, grow func(time.Duration) time.Duration) time.Duration {
+	if !t.settings.Enabled {
+		return ceiling
+	}
+
+	t.mu.Lock()
+	window, ok := t.configs[configID]
+	if !ok || len(window.samples) < t.settings.MinSamples {
+		t.mu.Unlock()
+		return ceiling
+	}
+	samples := slices.Clone(window.samples)
+	t.mu.Unlock()
+
+	slices.Sort(samples)
+	index := int(math.Ceil(adaptiveTimeoutPercentile*float64(len(samples)))) - 1
+	adaptive := grow(time.Duration(float64(samples[max(index, 0)]) * t.settings.Factor))
+	return min(max(adaptive, t.settings.Floor), ceiling)
+}
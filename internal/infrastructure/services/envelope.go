@@ -0,0 +1,54 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"webhook-processor/internal/domain/entities"
+)
+
+// deliveryEnvelope is the standard body sent to receivers whose config wraps the payload
+type deliveryEnvelope struct {
+	ID        string            `json:"id"` // Queue ID, the same on every attempt so receivers can deduplicate
+	Type      string            `json:"type"`
+	EventID   string            `json:"event_id"`
+	Attempt   int               `json:"attempt"`
+	CreatedAt time.Time         `json:"created_at"`
+	Data      json.RawMessage   `json:"data"`     // The payload stored with the webhook, null when there is none
+	Metadata  map[string]string `json:"metadata"` // The caller-supplied labels stored with the webhook
+}
+
+// wrapPayload builds the JSON envelope body for a webhook
+func wrapPayload(webhook *entities.WebhookQueue) ([]byte, error) {
+	metadata := webhook.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	body, err := json.Marshal(deliveryEnvelope{
+		ID:        webhook.QueueID.String(),
+		Type:      string(webhook.EventType),
+		EventID:   webhook.EventID,
+		Attempt:   webhook.RetryCount,
+		CreatedAt: webhook.CreatedAt,
+		Data:      envelopeData(webhook.Payload),
+		Metadata:  metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal delivery envelope: %w", err)
+	}
+	return body, nil
+}
+
+// envelopeData embeds a stored payload in the envelope as-is when it is JSON, and as a JSON string otherwise
+func envelopeData(payload string) json.RawMessage {
+	if payload == "" {
+		return json.RawMessage("null")
+	}
+	if json.Valid([]byte(payload)) {
+		return json.RawMessage(payload)
+	}
+	encoded, _ := json.Marshal(payload)
+	return encoded
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/services"
+)
+
+// NewNoopEventPublisher creates a publisher that drops every event, the default until a broker is configured
+func NewNoopEventPublisher() services.EventPublisher {
+	return noopEventPublisher{}
+}
+
+// noopEventPublisher drops every event
+type noopEventPublisher struct{}
+
+// Publish does nothing
+func (noopEventPublisher) Publish(context.Context, *entities.TransitionEvent) {}
+
+// ChannelEventPublisher publishes events to an in-memory channel, for tests and in-process consumers
+// Events published while the buffer is full are dropped and counted rather than blocking delivery
+type ChannelEventPublisher struct {
+	events  chan *entities.TransitionEvent
+	dropped atomic.Int64
+}
+
+// NewChannelEventPublisher creates a publisher buffering up to buffer unread events
+func NewChannelEventPublisher(buffer int) *ChannelEventPublisher {
+	return &ChannelEventPublisher{events: make(chan *entities.TransitionEvent, buffer)}
+}
+
+// Publish queues event for Events, or drops it when the buffer is full
+func (p *ChannelEventPublisher) Publish(_ context.Context, event *entities.TransitionEvent) {
+	select {
+	case p.events <- event:
+	default:
+		p.dropped.Add(1)
+	}
+}
+
+// Events receives the published events in publish order
+func (p *ChannelEventPublisher) Events() <-chan *entities.TransitionEvent {
+	return p.events
+}
+
+// Dropped returns how many events were dropped because the buffer was full
+func (p *ChannelEventPublisher) Dropped() int64 {
+	return p.dropped.Load()
+}
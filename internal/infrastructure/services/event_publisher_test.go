@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+)
+
+func TestChannelEventPublisher(t *testing.T) {
+	webhook := &entities.WebhookQueue{QueueID: uuid.New(), RetryCount: 2}
+	event := func(eventType entities.TransitionEventType) *entities.TransitionEvent {
+		return entities.NewTransitionEvent(eventType, webhook, enums.WebhookStatusProcessing, enums.WebhookStatusPending, time.Now())
+	}
+
+	t.Run("should deliver events in publish order", func(t *testing.T) {
+		publisher := NewChannelEventPublisher(2)
+
+		publisher.Publish(context.Background(), event(entities.TransitionAttemptRecorded))
+		publisher.Publish(context.Background(), event(entities.TransitionRetryScheduled))
+
+		first, second := <-publisher.Events(), <-publisher.Events()
+		assert.Equal(t, entities.TransitionAttemptRecorded, first.Type)
+		assert.Equal(t, entities.TransitionRetryScheduled, second.Type)
+		assert.Equal(t, webhook.QueueID.String(), second.QueueID)
+		assert.Equal(t, 2, second.RetryCount)
+		assert.Zero(t, publisher.Dropped())
+	})
+
+	t.Run("should drop and count events instead of blocking when the buffer is full", func(t *testing.T) {
+		publisher := NewChannelEventPublisher(1)
+
+		publisher.Publish(context.Background(), event(entities.TransitionAttemptRecorded))
+		publisher.Publish(context.Background(), event(entities.TransitionFailed))
+
+		require.Len(t, publisher.Events(), 1)
+		assert.Equal(t, entities.TransitionAttemptRecorded, (<-publisher.Events()).Type)
+		assert.Equal(t, int64(1), publisher.Dropped())
+	})
+}
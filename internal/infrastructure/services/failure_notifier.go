@@ -0,0 +1,252 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/services"
+)
+
+// maxListedFailures bounds how many failures a summary alert lists; the rest are only counted
+const maxListedFailures = 10
+
+// NewFailureNotifier creates the notifier selected by alertConfig.Kind, or a no-op when no kind is set
+// Alerts are posted with the default delivery client settings and aggregated per alertConfig.Window
+func NewFailureNotifier(alertConfig config.FailureAlertConfig, clientConfig config.HTTPClientConfig, logger log.Logger) services.FailureNotifier {
+	poster := &failureAlertPoster{
+		clients: newHTTPClientCache(clientConfig),
+		timeout: clientConfig.Timeout,
+		url:     alertConfig.URL,
+	}
+
+	var send failureAlertSender
+	switch alertConfig.Kind {
+	case "slack":
+		send = func(ctx context.Context, alert *failureAlert) error {
+			return poster.post(ctx, slackAlertPayload(alert))
+		}
+	case "pagerduty":
+		send = func(ctx context.Context, alert *failureAlert) error {
+			return poster.post(ctx, pagerDutyAlertPayload(alertConfig.RoutingKey, alert))
+		}
+	default:
+		return noopFailureNotifier{}
+	}
+
+	return newAggregatingFailureNotifier(send, alertConfig.Window, log.With(logger, "alert_kind", alertConfig.Kind))
+}
+
+// noopFailureNotifier drops every notification
+type noopFailureNotifier struct{}
+
+// NotifyFailure does nothing
+func (noopFailureNotifier) NotifyFailure(context.Context, *entities.FailureNotification) {}
+
+// failureAlert is one alert: the failure that opened a window, or a summary of those collected during it
+type failureAlert struct {
+	Failures []*entities.FailureNotification // At most maxListedFailures
+	Total    int                             // Every failure the alert covers, listed or not
+}
+
+// add counts a failure and lists it while there is room
+func (a *failureAlert) add(notification *entities.FailureNotification) {
+	a.Total++
+	if len(a.Failures) < maxListedFailures {
+		a.Failures = append(a.Failures, notification)
+	}
+}
+
+// failureAlertSender sends one alert in a specific format
+type failureAlertSender func(ctx context.Context, alert *failureAlert) error
+
+// aggregatingFailureNotifier alerts on the first failure at once, then collects failures for a window
+// and sends them as one summary, so a mass failure raises one alert per window instead of one per webhook
+type aggregatingFailureNotifier struct {
+	send   failureAlertSender
+	window time.Duration
+	logger log.Logger
+
+	mu      sync.Mutex
+	pending *failureAlert // Failures collected since the window opened
+	timer   *time.Timer   // Non-nil while a window is open
+}
+
+// newAggregatingFailureNotifier creates a notifier that sends through send at most once per window
+func newAggregatingFailureNotifier(send failureAlertSender, window time.Duration, logger log.Logger) *aggregatingFailureNotifier {
+	return &aggregatingFailureNotifier{send: send, window: window, logger: logger}
+}
+
+// NotifyFailure alerts immediately when no window is open, otherwise adds the failure to the next summary
+func (n *aggregatingFailureNotifier) NotifyFailure(ctx context.Context, notification *entities.FailureNotification) {
+	n.mu.Lock()
+	if n.timer != nil {
+		if n.pending == nil {
+			n.pending = &failureAlert{}
+		}
+		n.pending.add(notification)
+		n.mu.Unlock()
+		return
+	}
+	n.timer = time.AfterFunc(n.window, n.flush)
+	n.mu.Unlock()
+
+	alert := &failureAlert{}
+	alert.add(notification)
+	// Detached from worker cancellation so the alert for a drained webhook still goes out
+	n.deliver(context.WithoutCancel(ctx), alert)
+}
+
+// flush ends a window, sending a summary of what it collected; a window that collected anything is
+// followed by another, so alerts stay one per window for as long as failures keep arriving
+func (n *aggregatingFailureNotifier) flush() {
+	n.mu.Lock()
+	alert := n.pending
+	n.pending = nil
+	if alert == nil {
+		n.timer = nil
+		n.mu.Unlock()
+		return
+	}
+	n.timer = time.AfterFunc(n.window, n.flush)
+	n.mu.Unlock()
+
+	n.deliver(context.Background(), alert)
+}
+
+// deliver sends an alert, logging rather than returning a failure since alerts never affect delivery
+func (n *aggregatingFailureNotifier) deliver(ctx context.Context, alert *failureAlert) {
+	if err := n.send(ctx, alert); err != nil {
+		n.logger.Log("level", "warn", "msg", "failure alert not sent", "failures", alert.Total, "error", err)
+		return
+	}
+	n.logger.Log("level", "debug", "msg", "failure alert sent", "failures", alert.Total)
+}
+
+// failureAlertPoster posts alert payloads as JSON to one URL
+type failureAlertPoster struct {
+	clients *httpClientCache
+	timeout time.Duration
+	url     string
+}
+
+// post sends payload and treats any non-2xx response as a failure
+func (p *failureAlertPoster) post(ctx context.Context, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal failure alert: %w", err)
+	}
+
+	httpClient, err := p.clients.Get(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get HTTP client: %w", err)
+	}
+
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create failure alert request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Webhook-Processor/1.0")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send failure alert: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failure alert rejected: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// failureAlertSummary is the one-line description of an alert
+func failureAlertSummary(alert *failureAlert) string {
+	if alert.Total == 1 && len(alert.Failures) == 1 {
+		failure := alert.Failures[0]
+		return fmt.Sprintf("Webhook %s for config %d permanently failed: %s", failure.QueueID, failure.ConfigID, failure.Reason)
+	}
+	return fmt.Sprintf("%d more webhooks permanently failed", alert.Total)
+}
+
+// failureAlertLine describes one listed failure
+func failureAlertLine(failure *entities.FailureNotification) string {
+	config := fmt.Sprintf("config %d", failure.ConfigID)
+	if failure.ConfigName != "" {
+		config = fmt.Sprintf("config %d (%s)", failure.ConfigID, failure.ConfigName)
+	}
+	return fmt.Sprintf("%s %s, %s, %d retries: %s", failure.QueueID, failure.EventType, config, failure.RetryCount, failure.Reason)
+}
+
+// slackAlertPayload formats an alert for a Slack incoming webhook
+func slackAlertPayload(alert *failureAlert) map[string]string {
+	lines := []string{":rotating_light: " + failureAlertSummary(alert)}
+	if alert.Total > 1 {
+		for _, failure := range alert.Failures {
+			lines = append(lines, "• "+failureAlertLine(failure))
+		}
+		if unlisted := alert.Total - len(alert.Failures); unlisted > 0 {
+			lines = append(lines, fmt.Sprintf("…and %d more", unlisted))
+		}
+	}
+	return map[string]string{"text": strings.Join(lines, "\n")}
+}
+
+// pagerDutyEvent is a PagerDuty Events API v2 trigger
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key,omitempty"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+// pagerDutyPayload is the incident detail of a PagerDuty event
+type pagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Timestamp     time.Time              `json:"timestamp"`
+	CustomDetails map[string]interface{} `json:"custom_details"`
+}
+
+// pagerDutyAlertPayload formats an alert as a PagerDuty trigger; a single failure is deduplicated by queue ID
+func pagerDutyAlertPayload(routingKey string, alert *failureAlert) *pagerDutyEvent {
+	event := &pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		Payload: pagerDutyPayload{
+			Summary:  failureAlertSummary(alert),
+			Source:   "webhook-processor",
+			Severity: "error",
+			CustomDetails: map[string]interface{}{
+				"total":    alert.Total,
+				"failures": alert.Failures,
+			},
+		},
+	}
+	if len(alert.Failures) > 0 {
+		event.Payload.Timestamp = alert.Failures[0].FailedAt
+	}
+	if alert.Total == 1 && len(alert.Failures) == 1 {
+		event.DedupKey = "webhook-failed-" + alert.Failures[0].QueueID
+	}
+	return event
+}
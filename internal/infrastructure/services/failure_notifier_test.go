@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+)
+
+// newTestFailureNotification returns the notification for a webhook of config 7 that failed with reason
+func newTestFailureNotification(reason string) *entities.FailureNotification {
+	webhook := &entities.WebhookQueue{
+		QueueID:        uuid.New(),
+		EventType:      enums.EventTypeCredit,
+		ConfigID:       7,
+		Config:         &entities.WebhookConfig{ID: 7, Name: "Ledger"},
+		RetryCount:     6,
+		LastHTTPStatus: 503,
+	}
+	return entities.NewFailureNotification(webhook, reason, time.Now().UTC())
+}
+
+func TestNewFailureNotifier(t *testing.T) {
+	clientConfig := config.HTTPClientConfig{Timeout: 5 * time.Second}
+
+	// receive starts a server that decodes every alert it is sent
+	receive := func(t *testing.T) (string, <-chan map[string]interface{}) {
+		received := make(chan map[string]interface{}, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			received <- payload
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+		return server.URL, received
+	}
+
+	t.Run("should post a Slack message for a permanent failure", func(t *testing.T) {
+		url, received := receive(t)
+		notifier := NewFailureNotifier(config.FailureAlertConfig{Kind: "slack", URL: url, Window: time.Minute},
+			clientConfig, log.NewNopLogger())
+		notification := newTestFailureNotification("max retries exceeded: HTTP 503")
+
+		notifier.NotifyFailure(context.Background(), notification)
+
+		payload := <-received
+		assert.Contains(t, payload["text"], notification.QueueID)
+		assert.Contains(t, payload["text"], "config 7")
+		assert.Contains(t, payload["text"], "max retries exceeded: HTTP 503")
+	})
+
+	t.Run("should trigger a PagerDuty event deduplicated by queue ID", func(t *testing.T) {
+		url, received := receive(t)
+		notifier := NewFailureNotifier(config.FailureAlertConfig{Kind: "pagerduty", URL: url, RoutingKey: "key-1", Window: time.Minute},
+			clientConfig, log.NewNopLogger())
+		notification := newTestFailureNotification("non-retryable response: HTTP 410")
+
+		notifier.NotifyFailure(context.Background(), notification)
+
+		payload := <-received
+		assert.Equal(t, "key-1", payload["routing_key"])
+		assert.Equal(t, "trigger", payload["event_action"])
+		assert.Equal(t, "webhook-failed-"+notification.QueueID, payload["dedup_key"])
+		details := payload["payload"].(map[string]interface{})
+		assert.Equal(t, "error", details["severity"])
+		assert.Contains(t, details["summary"], "non-retryable response: HTTP 410")
+	})
+
+	t.Run("should return a no-op notifier when no kind is set", func(t *testing.T) {
+		notifier := NewFailureNotifier(config.FailureAlertConfig{}, clientConfig, log.NewNopLogger())
+
+		assert.IsType(t, noopFailureNotifier{}, notifier)
+		notifier.NotifyFailure(context.Background(), newTestFailureNotification("max retries exceeded"))
+	})
+}
+
+func TestAggregatingFailureNotifier(t *testing.T) {
+	// record returns a sender that keeps every alert it is asked to send
+	record := func() (failureAlertSender, func() []*failureAlert) {
+		var mu sync.Mutex
+		var alerts []*failureAlert
+		send := func(ctx context.Context, alert *failureAlert) error {
+			mu.Lock()
+			defer mu.Unlock()
+			alerts = append(alerts, alert)
+			return nil
+		}
+		sent := func() []*failureAlert {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]*failureAlert(nil), alerts...)
+		}
+		return send, sent
+	}
+
+	t.Run("should alert on the first failure and aggregate the rest of a burst into one summary", func(t *testing.T) {
+		send, sent := record()
+		notifier := newAggregatingFailureNotifier(send, 50*time.Millisecond, log.NewNopLogger())
+
+		for i := 0; i < 5; i++ {
+			notifier.NotifyFailure(context.Background(), newTestFailureNotification(fmt.Sprintf("failure %d", i)))
+		}
+
+		require.Len(t, sent(), 1, "only the first failure is sent immediately")
+		assert.Equal(t, 1, sent()[0].Total)
+		assert.Equal(t, "failure 0", sent()[0].Failures[0].Reason)
+
+		require.Eventually(t, func() bool { return len(sent()) == 2 }, time.Second, 5*time.Millisecond)
+		summary := sent()[1]
+		assert.Equal(t, 4, summary.Total)
+		assert.Len(t, summary.Failures, 4)
+		assert.Contains(t, slackAlertPayload(summary)["text"], "4 more webhooks permanently failed")
+	})
+
+	t.Run("should list at most maxListedFailures but count them all", func(t *testing.T) {
+		send, sent := record()
+		notifier := newAggregatingFailureNotifier(send, 50*time.Millisecond, log.NewNopLogger())
+
+		for i := 0; i < maxListedFailures+6; i++ {
+			notifier.NotifyFailure(context.Background(), newTestFailureNotification("max retries exceeded"))
+		}
+
+		require.Eventually(t, func() bool { return len(sent()) == 2 }, time.Second, 5*time.Millisecond)
+		summary := sent()[1]
+		assert.Equal(t, maxListedFailures+5, summary.Total)
+		assert.Len(t, summary.Failures, maxListedFailures)
+		assert.Contains(t, slackAlertPayload(summary)["text"], "and 5 more")
+	})
+
+	t.Run("should alert immediately again once a quiet window has passed", func(t *testing.T) {
+		send, sent := record()
+		notifier := newAggregatingFailureNotifier(send, 20*time.Millisecond, log.NewNopLogger())
+
+		notifier.NotifyFailure(context.Background(), newTestFailureNotification("first"))
+		require.Eventually(t, func() bool {
+			notifier.mu.Lock()
+			defer notifier.mu.Unlock()
+			return notifier.timer == nil
+		}, time.Second, 5*time.Millisecond)
+		notifier.NotifyFailure(context.Background(), newTestFailureNotification("second"))
+
+		alerts := sent()
+		require.Len(t, alerts, 2)
+		assert.Equal(t, "second", alerts[1].Failures[0].Reason)
+	})
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/services"
+	"webhook-processor/internal/infrastructure/services/deliverypb"
+)
+
+// grpcWebhookServiceImpl delivers webhooks to internal receivers with the DeliveryService.Deliver RPC
+type grpcWebhookServiceImpl struct {
+	// attemptTimeout bounds each attempt like HTTP deliveries: the config's timeout grown with the retry level
+	attemptTimeout func(webhook *entities.WebhookQueue) time.Duration
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// newGRPCWebhookService creates a gRPC delivery service; connections are opened per receiver and reused
+func newGRPCWebhookService(attemptTimeout func(webhook *entities.WebhookQueue) time.Duration) *grpcWebhookServiceImpl {
+	return &grpcWebhookServiceImpl{
+		attemptTimeout: attemptTimeout,
+		conns:          make(map[string]*grpc.ClientConn),
+	}
+}
+
+// SendWebhook calls Deliver on the receiver at the webhook URL's host (grpc://host:port)
+// A non-OK status is returned as a response, not an error, with its outcome and an HTTP-equivalent status code
+func (s *grpcWebhookServiceImpl) SendWebhook(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
+	startTime := time.Now().UTC()
+
+	timeout := s.attemptTimeout(webhook)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := s.conn(webhook.WebhookURL)
+	if err != nil {
+		return &services.WebhookResponse{
+			Error:    err,
+			Duration: time.Since(startTime),
+			Timeout:  timeout,
+		}, fmt.Errorf("failed to get gRPC connection: %w", err)
+	}
+
+	ack, err := deliverypb.NewDeliveryServiceClient(conn).Deliver(ctx, &deliverypb.Event{
+		QueueId:    webhook.QueueID.String(),
+		EventType:  string(webhook.EventType),
+		EventId:    webhook.EventID,
+		Attempt:    int32(webhook.RetryCount),
+		MaxRetries: int32(webhook.Config.RetryLimit()),
+		Metadata:   webhook.Metadata,
+	})
+	duration := time.Since(startTime)
+
+	// Our own deadline or cancellation is a send failure, classified like an HTTP timeout
+	if err != nil && ctx.Err() != nil {
+		return &services.WebhookResponse{
+			Error:    err,
+			Duration: duration,
+			Timeout:  timeout,
+		}, fmt.Errorf("failed to send webhook RPC: %w", ctx.Err())
+	}
+
+	rpcStatus := status.Convert(err)
+	body := rpcStatus.Message()
+	if err == nil {
+		body = ack.GetMessage()
+	}
+
+	return &services.WebhookResponse{
+		StatusCode: httpStatusFromCode(rpcStatus.Code()),
+		Body:       body,
+		Duration:   duration,
+		Timeout:    timeout,
+		Outcome:    outcomeFromCode(rpcStatus.Code()),
+	}, nil
+}
+
+// conn returns the cached connection for the receiver at rawURL, opening one on first use
+// Internal receivers are reached in plaintext
+func (s *grpcWebhookServiceImpl) conn(rawURL string) (*grpc.ClientConn, error) {
+	host, err := grpcHost(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if conn, ok := s.conns[host]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", host, err)
+	}
+	s.conns[host] = conn
+	return conn, nil
+}
+
+// Ping opens a TCP connection to the receiver at the webhook URL's host; receivers need not serve
+// the gRPC health service, so being able to connect is what counts as reachable
+func (s *grpcWebhookServiceImpl) Ping(ctx context.Context, webhookURL string, timeout time.Duration) (*services.PingResult, error) {
+	host, err := grpcHost(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, fmt.Errorf("ping failed: %w", err)
+	}
+	conn.Close()
+
+	return &services.PingResult{Duration: time.Since(startTime)}, nil
+}
+
+// grpcHost returns the host:port of a grpc://host:port webhook URL
+func grpcHost(rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid gRPC webhook URL: %w", err)
+	}
+	if parsed.Scheme != "grpc" || parsed.Host == "" {
+		return "", fmt.Errorf("invalid gRPC webhook URL %q: must be grpc://host:port", rawURL)
+	}
+	return parsed.Host, nil
+}
+
+// outcomeFromCode maps a gRPC status code to a delivery outcome
+// Codes that can clear on their own are retried; codes that will not change on a resend fail the webhook
+func outcomeFromCode(code codes.Code) enums.ResponseOutcome {
+	switch code {
+	case codes.OK:
+		return enums.ResponseOutcomeSuccess
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.FailedPrecondition, codes.OutOfRange, codes.Unimplemented, codes.Unauthenticated:
+		return enums.ResponseOutcomeFail
+	default:
+		return enums.ResponseOutcomeRetry
+	}
+}
+
+// httpStatusFromCode maps a gRPC status code to the HTTP status recorded for the attempt,
+// so stats and per-config status outcomes treat both protocols alike
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return 200
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return 400
+	case codes.Unauthenticated:
+		return 401
+	case codes.PermissionDenied:
+		return 403
+	case codes.NotFound:
+		return 404
+	case codes.AlreadyExists, codes.Aborted:
+		return 409
+	case codes.ResourceExhausted:
+		return 429
+	case codes.Unimplemented:
+		return 501
+	case codes.Unavailable:
+		return 503
+	case codes.DeadlineExceeded:
+		return 504
+	default:
+		return 500
+	}
+}
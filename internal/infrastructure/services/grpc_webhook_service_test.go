@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/infrastructure/services/deliverypb"
+)
+
+// fakeDeliveryServer records the events it receives and answers with a fixed ack or error
+type fakeDeliveryServer struct {
+	deliverypb.UnimplementedDeliveryServiceServer
+	events chan *deliverypb.Event
+	err    error
+}
+
+func (s *fakeDeliveryServer) Deliver(ctx context.Context, event *deliverypb.Event) (*deliverypb.Ack, error) {
+	s.events <- event
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &deliverypb.Ack{Message: "accepted"}, nil
+}
+
+// startDeliveryServer serves receiver in-process and returns its grpc:// URL
+func startDeliveryServer(t *testing.T, receiver *fakeDeliveryServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	deliverypb.RegisterDeliveryServiceServer(server, receiver)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return "grpc://" + listener.Addr().String()
+}
+
+func TestWebhookServiceImpl_GRPCDelivery(t *testing.T) {
+	service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+	maxRetries := 2
+
+	newWebhook := func(url string) *entities.WebhookQueue {
+		return &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeCredit,
+			EventID:    "evt-1",
+			WebhookURL: url,
+			RetryCount: 1,
+			Metadata:   map[string]string{"tenant": "acme"},
+			Config:     &entities.WebhookConfig{Protocol: enums.DeliveryProtocolGRPC, MaxRetries: &maxRetries},
+		}
+	}
+
+	t.Run("should deliver the event and report success", func(t *testing.T) {
+		receiver := &fakeDeliveryServer{events: make(chan *deliverypb.Event, 1)}
+		webhook := newWebhook(startDeliveryServer(t, receiver))
+
+		response, err := service.SendWebhook(context.Background(), webhook)
+
+		require.NoError(t, err)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, "accepted", response.Body)
+		assert.Equal(t, enums.ResponseOutcomeSuccess, response.Outcome)
+
+		event := <-receiver.events
+		assert.Equal(t, webhook.QueueID.String(), event.GetQueueId())
+		assert.Equal(t, string(enums.EventTypeCredit), event.GetEventType())
+		assert.Equal(t, "evt-1", event.GetEventId())
+		assert.Equal(t, int32(1), event.GetAttempt())
+		assert.Equal(t, int32(2), event.GetMaxRetries())
+		assert.Equal(t, map[string]string{"tenant": "acme"}, event.GetMetadata())
+	})
+
+	t.Run("should map a failing RPC to its outcome", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			err        error
+			statusCode int
+			outcome    enums.ResponseOutcome
+		}{
+			{name: "unavailable is retried", err: status.Error(codes.Unavailable, "overloaded"), statusCode: 503, outcome: enums.ResponseOutcomeRetry},
+			{name: "invalid argument fails", err: status.Error(codes.InvalidArgument, "unknown account"), statusCode: 400, outcome: enums.ResponseOutcomeFail},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				receiver := &fakeDeliveryServer{events: make(chan *deliverypb.Event, 1), err: tt.err}
+				webhook := newWebhook(startDeliveryServer(t, receiver))
+
+				response, err := service.SendWebhook(context.Background(), webhook)
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.statusCode, response.StatusCode)
+				assert.Equal(t, tt.outcome, response.Outcome)
+				assert.Equal(t, status.Convert(tt.err).Message(), response.Body)
+			})
+		}
+	})
+
+	t.Run("should reject a webhook URL that is not grpc://", func(t *testing.T) {
+		response, err := service.SendWebhook(context.Background(), newWebhook("https://example.com/webhook"))
+
+		assert.ErrorContains(t, err, "must be grpc://host:port")
+		require.NotNil(t, response)
+		assert.Error(t, response.Error)
+	})
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/services"
+)
+
+// hedgeResult is the outcome of one of the requests raced by sendHedged
+type hedgeResult struct {
+	response *services.WebhookResponse
+	err      error
+}
+
+// sendHedged sends the webhook and, if no response has arrived after hedgeAfter, sends it again concurrently
+// The first response wins and the other request is cancelled. A request that fails to send only wins
+// when no other request is still outstanding, so a failed connection does not mask a hedge that may succeed.
+func (s *webhookServiceImpl) sendHedged(ctx context.Context, webhook *entities.WebhookQueue, hedgeAfter time.Duration) (*services.WebhookResponse, error) {
+	// Cancelling on return aborts the losing request
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffered so the losing request never blocks once the winner has returned
+	results := make(chan hedgeResult, 2)
+	launch := func() {
+		go func() {
+			response, err := s.send(ctx, webhook)
+			results <- hedgeResult{response: response, err: err}
+		}()
+	}
+
+	launch()
+	outstanding := 1
+
+	hedgeTimer := time.NewTimer(hedgeAfter)
+	defer hedgeTimer.Stop()
+	hedgeTimerC := hedgeTimer.C
+
+	for {
+		select {
+		case <-hedgeTimerC:
+			hedgeTimerC = nil
+			launch()
+			outstanding++
+			if s.metrics != nil {
+				s.metrics.RecordHedge()
+			}
+		case result := <-results:
+			outstanding--
+			if result.err != nil && outstanding > 0 {
+				continue
+			}
+			return result.response, result.err
+		}
+	}
+}
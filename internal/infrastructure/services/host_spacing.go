@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// hostSpacingSweepSize is how many hosts are tracked before slots already in the past are dropped
+const hostSpacingSweepSize = 1024
+
+// hostSpacer keeps a minimum gap between the start of any two requests to the same host
+// It smooths bursts from many workers without limiting the sustained rate beyond one request per gap
+type hostSpacer struct {
+	minGap time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time // Earliest start of the next request to each host
+}
+
+// newHostSpacer creates a host spacer; a non-positive gap disables spacing
+func newHostSpacer(minGap time.Duration) *hostSpacer {
+	return &hostSpacer{minGap: minGap, next: make(map[string]time.Time)}
+}
+
+// wait reserves the next free slot for the URL's host and blocks until it starts or ctx ends
+// A slot starting after ctx's deadline is not reserved and is not waited for; either way a wait that
+// does not finish returns ctx's error and how long until the host's next slot
+// A slot abandoned by a cancelled wait is not handed back, which only ever widens the gap
+func (h *hostSpacer) wait(ctx context.Context, rawURL string) (time.Duration, error) {
+	if h.minGap <= 0 {
+		return 0, nil
+	}
+	host := spacingHost(rawURL)
+
+	h.mu.Lock()
+	now := time.Now()
+	slot := now
+	if next, ok := h.next[host]; ok && next.After(now) {
+		slot = next
+	}
+	if deadline, ok := ctx.Deadline(); ok && slot.After(deadline) {
+		h.mu.Unlock()
+		return slot.Sub(now), context.DeadlineExceeded
+	}
+	h.next[host] = slot.Add(h.minGap)
+	if len(h.next) > hostSpacingSweepSize {
+		for tracked, next := range h.next {
+			if next.Before(now) {
+				delete(h.next, tracked)
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return 0, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return time.Until(slot), ctx.Err()
+	case <-timer.C:
+		return 0, nil
+	}
+}
+
+// spacingHost returns the lowercased host name requests are spaced by; ports are ignored
+// An unparseable URL is spaced by its raw text
+func spacingHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return rawURL
+	}
+	return strings.ToLower(parsed.Hostname())
+}
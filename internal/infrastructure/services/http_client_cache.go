@@ -0,0 +1,196 @@
+package services
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+)
+
+// httpClientCache keeps one http.Client per distinct transport profile, evicting the least recently used
+type httpClientCache struct {
+	mu            sync.Mutex
+	clientConfig  config.HTTPClientConfig
+	defaultClient *http.Client
+	maxClients    int
+	entries       map[string]*list.Element
+	lru           *list.List
+
+	// cipherSuites restricts TLS cipher suites on every transport; nil keeps Go's defaults
+	cipherSuites []uint16
+	// cipherSuitesErr fails every request closed when the configured suites are invalid
+	cipherSuitesErr error
+}
+
+// httpClientCacheEntry is a cached client and the key it was stored under
+type httpClientCacheEntry struct {
+	key    string
+	client *http.Client
+}
+
+// newHTTPClientCache creates a client cache; the default client serves webhooks without transport settings
+// Invalid cipher suites are normally rejected by config validation; if they get here, Get fails closed
+func newHTTPClientCache(clientConfig config.HTTPClientConfig) *httpClientCache {
+	cipherSuites, cipherSuitesErr := clientConfig.CipherSuiteIDs()
+
+	return &httpClientCache{
+		clientConfig: clientConfig,
+		defaultClient: &http.Client{
+			Transport: &http.Transport{
+				MaxIdleConns:    clientConfig.MaxIdleConns,
+				IdleConnTimeout: clientConfig.IdleConnTimeout,
+				TLSClientConfig: tlsConfig(0, cipherSuites),
+			},
+		},
+		maxClients:      clientConfig.MaxCachedClients,
+		entries:         make(map[string]*list.Element),
+		lru:             list.New(),
+		cipherSuites:    cipherSuites,
+		cipherSuitesErr: cipherSuitesErr,
+	}
+}
+
+// Get returns the client for the given transport settings, building and caching it on first use
+func (c *httpClientCache) Get(settings *entities.TransportSettings) (*http.Client, error) {
+	if c.cipherSuitesErr != nil {
+		return nil, c.cipherSuitesErr
+	}
+	if settings == nil {
+		return c.defaultClient, nil
+	}
+
+	key, err := transportKey(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		return elem.Value.(*httpClientCacheEntry).client, nil
+	}
+
+	transport, err := c.buildTransport(settings)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: transport}
+
+	c.entries[key] = c.lru.PushFront(&httpClientCacheEntry{key: key, client: client})
+	for c.maxClients > 0 && c.lru.Len() > c.maxClients {
+		c.evictOldest()
+	}
+
+	return client, nil
+}
+
+// Len returns the number of cached dedicated clients
+func (c *httpClientCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// evictOldest removes the least recently used client and closes its idle connections
+func (c *httpClientCache) evictOldest() {
+	elem := c.lru.Back()
+	if elem == nil {
+		return
+	}
+	entry := c.lru.Remove(elem).(*httpClientCacheEntry)
+	delete(c.entries, entry.key)
+	entry.client.CloseIdleConnections()
+}
+
+// buildTransport creates a transport from the settings, falling back to the client config defaults
+func (c *httpClientCache) buildTransport(settings *entities.TransportSettings) (*http.Transport, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if settings.DialTimeoutMs > 0 {
+		dialer.Timeout = time.Duration(settings.DialTimeoutMs) * time.Millisecond
+	}
+
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          c.clientConfig.MaxIdleConns,
+		IdleConnTimeout:       c.clientConfig.IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: time.Duration(settings.ResponseHeaderTimeoutMs) * time.Millisecond,
+		DisableKeepAlives:     settings.DisableKeepAlives,
+	}
+	if settings.TLSHandshakeTimeoutMs > 0 {
+		transport.TLSHandshakeTimeout = time.Duration(settings.TLSHandshakeTimeoutMs) * time.Millisecond
+	}
+	if settings.IdleConnTimeoutMs > 0 {
+		transport.IdleConnTimeout = time.Duration(settings.IdleConnTimeoutMs) * time.Millisecond
+	}
+
+	if settings.ProxyURL != "" {
+		proxyURL, err := url.Parse(settings.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	var minVersion uint16
+	if settings.TLSMinVersion != "" {
+		version, err := parseTLSVersion(settings.TLSMinVersion)
+		if err != nil {
+			return nil, err
+		}
+		minVersion = version
+	}
+	transport.TLSClientConfig = tlsConfig(minVersion, c.cipherSuites)
+
+	return transport, nil
+}
+
+// tlsConfig builds a TLS config for the given minimum version and cipher suites
+// It returns nil when neither is set so the transport keeps Go's defaults
+func tlsConfig(minVersion uint16, cipherSuites []uint16) *tls.Config {
+	if minVersion == 0 && len(cipherSuites) == 0 {
+		return nil
+	}
+	return &tls.Config{MinVersion: minVersion, CipherSuites: cipherSuites}
+}
+
+// transportKey hashes the transport-affecting settings into a cache key
+func transportKey(settings *entities.TransportSettings) (string, error) {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal transport settings: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// parseTLSVersion converts a version string such as "1.2" to its tls constant
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version: %s", version)
+	}
+}
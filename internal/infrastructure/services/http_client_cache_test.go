@@ -0,0 +1,136 @@
+package services
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+)
+
+func TestHTTPClientCache_Get(t *testing.T) {
+	clientConfig := config.HTTPClientConfig{
+		Timeout:          time.Second * 30,
+		MaxIdleConns:     10,
+		IdleConnTimeout:  time.Second * 90,
+		MaxCachedClients: 2,
+	}
+
+	t.Run("should share a client between configs with identical transport settings", func(t *testing.T) {
+		cache := newHTTPClientCache(clientConfig)
+
+		first, err := cache.Get(&entities.TransportSettings{ProxyURL: "http://proxy.internal:3128", DialTimeoutMs: 500})
+		require.NoError(t, err)
+		second, err := cache.Get(&entities.TransportSettings{ProxyURL: "http://proxy.internal:3128", DialTimeoutMs: 500})
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, cache.Len())
+	})
+
+	t.Run("should isolate configs with differing transport settings", func(t *testing.T) {
+		cache := newHTTPClientCache(clientConfig)
+
+		first, err := cache.Get(&entities.TransportSettings{TLSMinVersion: "1.2"})
+		require.NoError(t, err)
+		second, err := cache.Get(&entities.TransportSettings{TLSMinVersion: "1.3"})
+		require.NoError(t, err)
+
+		assert.NotSame(t, first, second)
+		assert.Equal(t, 2, cache.Len())
+
+		transport, ok := second.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, uint16(tls.VersionTLS13), transport.TLSClientConfig.MinVersion)
+	})
+
+	t.Run("should use the default client when no transport settings are given", func(t *testing.T) {
+		cache := newHTTPClientCache(clientConfig)
+
+		client, err := cache.Get(nil)
+
+		require.NoError(t, err)
+		assert.Same(t, cache.defaultClient, client)
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("should apply configured cipher suites to default and dedicated clients", func(t *testing.T) {
+		restricted := clientConfig
+		restricted.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}
+		cache := newHTTPClientCache(restricted)
+		expected := []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+
+		defaultClient, err := cache.Get(nil)
+		require.NoError(t, err)
+		dedicated, err := cache.Get(&entities.TransportSettings{TLSMinVersion: "1.2"})
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, defaultClient.Transport.(*http.Transport).TLSClientConfig.CipherSuites)
+		dedicatedTLS := dedicated.Transport.(*http.Transport).TLSClientConfig
+		assert.Equal(t, expected, dedicatedTLS.CipherSuites)
+		assert.Equal(t, uint16(tls.VersionTLS12), dedicatedTLS.MinVersion)
+	})
+
+	t.Run("should keep Go's default cipher suites when none are configured", func(t *testing.T) {
+		client, err := newHTTPClientCache(clientConfig).Get(nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, client.Transport.(*http.Transport).TLSClientConfig)
+	})
+
+	t.Run("should fail closed on unknown cipher suites", func(t *testing.T) {
+		invalid := clientConfig
+		invalid.CipherSuites = []string{"TLS_MADE_UP"}
+
+		_, err := newHTTPClientCache(invalid).Get(nil)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should evict the least recently used client beyond the cap", func(t *testing.T) {
+		cache := newHTTPClientCache(clientConfig)
+		a := &entities.TransportSettings{DialTimeoutMs: 100}
+		b := &entities.TransportSettings{DialTimeoutMs: 200}
+		c := &entities.TransportSettings{DialTimeoutMs: 300}
+
+		clientA, err := cache.Get(a)
+		require.NoError(t, err)
+		clientB, err := cache.Get(b)
+		require.NoError(t, err)
+
+		// Touch a so b becomes the least recently used
+		_, err = cache.Get(a)
+		require.NoError(t, err)
+		_, err = cache.Get(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, cache.Len())
+
+		again, err := cache.Get(a)
+		require.NoError(t, err)
+		assert.Same(t, clientA, again)
+
+		rebuilt, err := cache.Get(b)
+		require.NoError(t, err)
+		assert.NotSame(t, clientB, rebuilt)
+	})
+
+	t.Run("should reject invalid transport settings", func(t *testing.T) {
+		cache := newHTTPClientCache(clientConfig)
+
+		_, err := cache.Get(&entities.TransportSettings{TLSMinVersion: "2.0"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported TLS version")
+
+		_, err = cache.Get(&entities.TransportSettings{ProxyURL: "://bad"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid proxy URL")
+
+		assert.Equal(t, 0, cache.Len())
+	})
+}
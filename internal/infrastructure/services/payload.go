@@ -0,0 +1,67 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/services"
+)
+
+// payloadTemplateFuncs are available to config payload templates
+var payloadTemplateFuncs = template.FuncMap{
+	// json encodes a value, e.g. {{json .EventID}} for a quoted and escaped string
+	"json": func(value interface{}) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	},
+}
+
+// payloadTemplateData is what a config payload template renders
+type payloadTemplateData struct {
+	QueueID   string
+	EventType string
+	EventID   string
+	Attempt   int
+	CreatedAt time.Time
+	Metadata  map[string]string
+	Payload   string // The payload stored with the webhook, as supplied at creation
+}
+
+// requestPayload builds the body of a delivery: the standard envelope when the config wraps the
+// payload, the config's rendered template when it has one, otherwise the payload stored at creation
+func requestPayload(webhook *entities.WebhookQueue) ([]byte, error) {
+	if webhook.Config != nil && webhook.Config.WrapPayload {
+		return wrapPayload(webhook)
+	}
+	if webhook.Config != nil && webhook.Config.PayloadTemplate != nil {
+		return renderPayloadTemplate(*webhook.Config.PayloadTemplate, webhook)
+	}
+	return []byte(webhook.Payload), nil
+}
+
+// renderPayloadTemplate executes a config's payload template for a webhook
+// Parse and render errors wrap services.ErrPayloadTemplate, since a retry would fail the same way
+func renderPayloadTemplate(text string, webhook *entities.WebhookQueue) ([]byte, error) {
+	tmpl, err := template.New("payload").Funcs(payloadTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w to parse: %w", services.ErrPayloadTemplate, err)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, payloadTemplateData{
+		QueueID:   webhook.QueueID.String(),
+		EventType: string(webhook.EventType),
+		EventID:   webhook.EventID,
+		Attempt:   webhook.RetryCount,
+		CreatedAt: webhook.CreatedAt,
+		Metadata:  webhook.Metadata,
+		Payload:   webhook.Payload,
+	}); err != nil {
+		return nil, fmt.Errorf("%w to render: %w", services.ErrPayloadTemplate, err)
+	}
+	return body.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/services"
+)
+
+// statusCallbackServiceImpl posts status callbacks with the default delivery client settings
+type statusCallbackServiceImpl struct {
+	clients *httpClientCache
+	timeout time.Duration
+}
+
+// NewStatusCallbackService creates a status callback service
+// Each callback is bounded by the client timeout and shares the global TLS and pooling settings
+func NewStatusCallbackService(clientConfig config.HTTPClientConfig) services.StatusCallbackService {
+	return &statusCallbackServiceImpl{
+		clients: newHTTPClientCache(clientConfig),
+		timeout: clientConfig.Timeout,
+	}
+}
+
+// SendStatusCallback posts the callback as JSON and treats any non-2xx response as a failure
+func (s *statusCallbackServiceImpl) SendStatusCallback(ctx context.Context, url string, callback *entities.StatusCallback) error {
+	payload, err := json.Marshal(callback)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status callback: %w", err)
+	}
+
+	httpClient, err := s.clients.Get(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get HTTP client: %w", err)
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create status callback request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Webhook-Processor/1.0")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send status callback: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status callback rejected: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
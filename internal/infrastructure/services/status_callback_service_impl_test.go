@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
+)
+
+func TestStatusCallbackServiceImpl_SendStatusCallback(t *testing.T) {
+	webhook := &entities.WebhookQueue{
+		QueueID:        uuid.New(),
+		EventType:      enums.EventTypeCredit,
+		EventID:        "evt-1",
+		ConfigID:       7,
+		RetryCount:     3,
+		LastHTTPStatus: 503,
+	}
+	callback := entities.NewStatusCallback(entities.StatusCallbackFailed, webhook, "max retries exceeded: HTTP 503", time.Now())
+
+	t.Run("should post the callback as JSON", func(t *testing.T) {
+		var received entities.StatusCallback
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		service := NewStatusCallbackService(config.HTTPClientConfig{Timeout: 5 * time.Second})
+
+		require.NoError(t, service.SendStatusCallback(context.Background(), server.URL, callback))
+		assert.Equal(t, entities.StatusCallbackFailed, received.Event)
+		assert.Equal(t, webhook.QueueID.String(), received.QueueID)
+		assert.Equal(t, int64(7), received.ConfigID)
+		assert.Equal(t, 503, received.LastHTTPStatus)
+		assert.Equal(t, "max retries exceeded: HTTP 503", received.Error)
+	})
+
+	t.Run("should return an error on a non-2xx response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		service := NewStatusCallbackService(config.HTTPClientConfig{Timeout: 5 * time.Second})
+
+		err := service.SendStatusCallback(context.Background(), server.URL, callback)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "HTTP 500")
+	})
+}
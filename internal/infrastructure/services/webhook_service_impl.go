@@ -1,80 +1,447 @@
 package services
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/http/httptrace"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"webhook-processor/internal/config"
 	"webhook-processor/internal/domain/entities"
+	"webhook-processor/internal/domain/enums"
 	"webhook-processor/internal/domain/services"
+	"webhook-processor/internal/infrastructure/metrics"
 )
 
 // webhookServiceImpl implements the WebhookService interface
 type webhookServiceImpl struct {
-	httpClient *http.Client
+	clients             *httpClientCache
+	timeout             time.Duration
+	timeoutGrowthFactor float64
+	maxTimeout          time.Duration
+
+	compressionThreshold int
+	attemptHeaders       config.AttemptHeadersConfig
+
+	metrics *metrics.WebhookMetrics
+
+	// spacer holds back requests that would follow another to the same host too closely
+	spacer *hostSpacer
+
+	// responseTimes tightens attempt timeouts to each config's observed response times when enabled
+	responseTimes *responseTimeTracker
+
+	// grpc delivers webhooks whose config selects the gRPC protocol
+	grpc services.WebhookService
 }
 
 // NewWebhookService creates a new webhook service
-func NewWebhookService(clientConfig config.HTTPClientConfig) services.WebhookService {
-	return &webhookServiceImpl{
-		httpClient: &http.Client{
-			Timeout: clientConfig.Timeout,
-			Transport: &http.Transport{
-				MaxIdleConns:    clientConfig.MaxIdleConns,
-				IdleConnTimeout: clientConfig.IdleConnTimeout,
-			},
-		},
+// The timeout is applied per attempt via the request context so it can grow with the retry level
+// Connection reuse and body sizes are recorded when webhookMetrics is non-nil
+// Webhooks whose config selects gRPC are delivered over gRPC with the same attempt timeouts
+// HTTP attempt timeouts tighten to each config's response times when the adaptive timeout is enabled
+func NewWebhookService(clientConfig config.HTTPClientConfig, webhookMetrics *metrics.WebhookMetrics) services.WebhookService {
+	service := &webhookServiceImpl{
+		clients:             newHTTPClientCache(clientConfig),
+		timeout:             clientConfig.Timeout,
+		timeoutGrowthFactor: clientConfig.TimeoutGrowthFactor,
+		maxTimeout:          clientConfig.MaxTimeout,
+
+		compressionThreshold: clientConfig.CompressionThreshold,
+		attemptHeaders:       clientConfig.AttemptHeaders,
+
+		metrics:       webhookMetrics,
+		spacer:        newHostSpacer(clientConfig.HostMinInterval),
+		responseTimes: newResponseTimeTracker(clientConfig.AdaptiveTimeout),
 	}
+	service.grpc = newGRPCWebhookService(service.attemptTimeout)
+	return service
 }
 
 // SendWebhook sends a webhook request and returns the response
+// The request waits until the host's minimum interval since the last request to it has passed, but no
+// longer than the attempt's timeout; a wait cut short sends nothing and returns ErrSpacingWaitCancelled
+// First attempts for configs with a hedge delay are hedged with a second concurrent request
 func (s *webhookServiceImpl) SendWebhook(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
+	waitStart := time.Now()
+	waitCtx, cancel := context.WithTimeout(ctx, s.attemptTimeout(webhook))
+	untilSlot, err := s.spacer.wait(waitCtx, webhook.WebhookURL)
+	cancel()
+	if err != nil {
+		return &services.WebhookResponse{
+			Error:      err,
+			Duration:   time.Since(waitStart),
+			RetryAfter: untilSlot,
+		}, fmt.Errorf("%w: %w", services.ErrSpacingWaitCancelled, err)
+	}
+
+	if hedgeAfter := webhook.Config.HedgeAfter(); hedgeAfter > 0 && webhook.CurrentRetryLevel() == 0 {
+		return s.sendHedged(ctx, webhook, hedgeAfter)
+	}
+	return s.send(ctx, webhook)
+}
+
+// Ping sends a HEAD request to the URL and reports the status the receiver answered with
+// gRPC URLs are pinged by opening a connection to the receiver
+func (s *webhookServiceImpl) Ping(ctx context.Context, webhookURL string, timeout time.Duration) (*services.PingResult, error) {
+	if strings.HasPrefix(webhookURL, "grpc://") {
+		return s.grpc.Ping(ctx, webhookURL, timeout)
+	}
+
+	startTime := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpClient, err := s.clients.Get(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HTTP client: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, webhookURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ping request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Webhook-Processor/1.0")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ping failed: %w", err)
+	}
+	resp.Body.Close()
+
+	return &services.PingResult{StatusCode: resp.StatusCode, Duration: time.Since(startTime)}, nil
+}
+
+// send delivers a single request over the protocol the webhook's config selects
+func (s *webhookServiceImpl) send(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
+	if webhook.Config.DeliveryProtocol() == enums.DeliveryProtocolGRPC {
+		return s.grpc.SendWebhook(ctx, webhook)
+	}
+	return s.sendHTTP(ctx, webhook)
+}
+
+// sendHTTP delivers a single HTTP request
+func (s *webhookServiceImpl) sendHTTP(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
 	startTime := time.Now().UTC()
 
+	// Bound this attempt by its effective timeout, tightened to the config's response times when adaptive
+	timeout := s.responseTimes.timeout(webhook.ConfigID, s.attemptTimeout(webhook),
+		func(base time.Duration) time.Duration { return s.growTimeout(base, webhook.CurrentRetryLevel()) })
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Per-config delivery options, when the config was loaded
+	var transport *entities.TransportSettings
+	var compress bool
+	var headers map[string]string
+	accept := entities.DefaultAcceptHeader
+	if webhook.Config != nil {
+		transport = webhook.Config.Transport
+		compress = webhook.Config.CompressRequest
+		headers = webhook.Config.Headers
+		accept = webhook.Config.Accept()
+	}
+
+	// Pick the client for this config's transport profile
+	httpClient, err := s.clients.Get(transport)
+	if err != nil {
+		return &services.WebhookResponse{
+			Error:    err,
+			Duration: time.Since(startTime),
+			Timeout:  timeout,
+		}, fmt.Errorf("failed to get HTTP client: %w", err)
+	}
+
 	// Use the complete webhook URL directly
 	fullURL := webhook.WebhookURL
 
+	// GET deliveries never carry a body; other methods send the payload when there is one
+	var payload []byte
+	method := webhook.Config.Method()
+	if method != http.MethodGet {
+		if payload, err = requestPayload(webhook); err != nil {
+			return &services.WebhookResponse{
+				Error:    err,
+				Duration: time.Since(startTime),
+				Timeout:  timeout,
+			}, err
+		}
+	}
+	requestBody, contentEncoding, err := s.encodeBody(payload, compress)
+	if err != nil {
+		return &services.WebhookResponse{
+			Error:    err,
+			Duration: time.Since(startTime),
+			Timeout:  timeout,
+		}, fmt.Errorf("failed to encode request body: %w", err)
+	}
+
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, requestBody)
 	if err != nil {
 		return &services.WebhookResponse{
 			Error:    err,
 			Duration: time.Since(startTime),
+			Timeout:  timeout,
 		}, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	// Set headers
 	req.Header.Set("User-Agent", "Webhook-Processor/1.0")
-	req.Header.Set("Accept", "application/json")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if len(payload) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	setConfigHeaders(req, headers)
+	s.setAttemptHeaders(req, webhook)
+	if webhook.Config != nil {
+		setSignatureHeaders(req, webhook.Config.SigningSecret, payload, time.Now())
+	}
+	sent := &entities.AttemptRequest{Body: string(payload), Headers: redactRequestHeaders(req.Header)}
+	req = s.traceConnection(req)
+
+	if s.metrics != nil {
+		s.metrics.RecordRequestBodySize(req.ContentLength)
+	}
 
 	// Send the request
-	resp, err := s.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	duration := time.Since(startTime)
+	if err == nil {
+		s.responseTimes.observe(webhook.ConfigID, duration)
+	}
 
 	if err != nil {
 		return &services.WebhookResponse{
 			Error:    err,
 			Duration: duration,
+			Timeout:  timeout,
+			Request:  sent,
 		}, fmt.Errorf("failed to send webhook request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	body, err := readResponseBody(resp)
 	if err != nil {
 		return &services.WebhookResponse{
 			StatusCode: resp.StatusCode,
 			Error:      err,
 			Duration:   duration,
+			Timeout:    timeout,
+			Request:    sent,
 		}, fmt.Errorf("failed to read response body: %w", err)
 	}
+	if s.metrics != nil {
+		s.metrics.RecordResponseBodySize(len(body))
+	}
 
 	return &services.WebhookResponse{
 		StatusCode: resp.StatusCode,
 		Body:       string(body),
 		Duration:   duration,
+		Timeout:    timeout,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Request:    sent,
 	}, nil
 }
+
+// readResponseBody reads a complete response body, whether it is sized by Content-Length, chunked or
+// delimited by the connection closing
+// A body cut off before its declared length or its terminating chunk is reported as truncated
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		encoding := "Content-Length"
+		if slices.Contains(resp.TransferEncoding, "chunked") {
+			encoding = "chunked"
+		}
+		return body, fmt.Errorf("%s response body truncated after %d bytes: %w", encoding, len(body), err)
+	}
+	return body, err
+}
+
+// redactedHeaderValue replaces the value of a stored request header that carries a secret
+const redactedHeaderValue = "REDACTED"
+
+// redactedHeaderWords mark a header as carrying a credential or signature when its name contains one
+var redactedHeaderWords = []string{"authorization", "cookie", "signature", "token", "secret", "api-key", "apikey"}
+
+// redactRequestHeaders flattens sent headers for storage, masking any that carry a credential or signature
+func redactRequestHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		lower := strings.ToLower(name)
+		for _, word := range redactedHeaderWords {
+			if strings.Contains(lower, word) {
+				value = redactedHeaderValue
+				break
+			}
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// attemptTimeout returns the timeout for a webhook's next attempt: its config's TimeoutMs, or the client
+// timeout when the config sets none, grown by the configured factor for the retry level and capped at the
+// max timeout
+func (s *webhookServiceImpl) attemptTimeout(webhook *entities.WebhookQueue) time.Duration {
+	base := s.timeout
+	if webhook.Config != nil && webhook.Config.TimeoutMs > 0 {
+		base = time.Duration(webhook.Config.TimeoutMs) * time.Millisecond
+	}
+	return s.growTimeout(base, webhook.CurrentRetryLevel())
+}
+
+// growTimeout grows a base timeout by the configured factor for the retry level, capped at the max timeout
+func (s *webhookServiceImpl) growTimeout(base time.Duration, retryLevel int) time.Duration {
+	timeout := base
+	if s.timeoutGrowthFactor > 0 && retryLevel > 0 {
+		timeout = time.Duration(float64(base) * (1 + float64(retryLevel)*s.timeoutGrowthFactor))
+	}
+	if s.maxTimeout > 0 && timeout > s.maxTimeout {
+		timeout = s.maxTimeout
+	}
+	return timeout
+}
+
+// parseRetryAfter reads a Retry-After header given as delay-seconds or an HTTP date
+// Missing, malformed and past values return 0; values too large for a Duration saturate, leaving the
+// processor's max retry delay to cap them
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+		if seconds <= 0 {
+			return 0
+		}
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return math.MaxInt64
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// traceConnection records whether the request went out on a new or a kept-alive connection
+func (s *webhookServiceImpl) traceConnection(req *http.Request) *http.Request {
+	if s.metrics == nil {
+		return req
+	}
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			s.metrics.RecordConnection(info.Reused)
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// reservedConfigHeaders are set by the processor and cannot be overridden by a config's custom headers:
+// they describe the connection and body or authenticate the request
+var reservedConfigHeaders = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Content-Encoding":  true,
+	"Transfer-Encoding": true,
+	"Connection":        true,
+	signatureHeader:     true,
+	timestampHeader:     true,
+}
+
+// setConfigHeaders sets a config's custom headers, overriding defaults like User-Agent and Accept
+// A header with an empty value is omitted; reserved headers are skipped
+func setConfigHeaders(req *http.Request, headers map[string]string) {
+	for name, value := range headers {
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if name == "" || reservedConfigHeaders[name] {
+			continue
+		}
+		if value == "" {
+			req.Header.Del(name)
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+}
+
+// setAttemptHeaders tells the receiver which attempt this is, out of how many, and for which webhook
+func (s *webhookServiceImpl) setAttemptHeaders(req *http.Request, webhook *entities.WebhookQueue) {
+	if !s.attemptHeaders.Enabled {
+		return
+	}
+	if s.attemptHeaders.AttemptHeader != "" {
+		req.Header.Set(s.attemptHeaders.AttemptHeader, strconv.Itoa(webhook.RetryCount))
+	}
+	if s.attemptHeaders.MaxAttemptsHeader != "" {
+		req.Header.Set(s.attemptHeaders.MaxAttemptsHeader, strconv.Itoa(webhook.Config.RetryLimit()))
+	}
+	if s.attemptHeaders.WebhookIDHeader != "" {
+		req.Header.Set(s.attemptHeaders.WebhookIDHeader, webhook.QueueID.String())
+	}
+}
+
+// Headers a signed delivery carries
+const (
+	signatureHeader = "X-Webhook-Signature"
+	timestampHeader = "X-Webhook-Timestamp"
+)
+
+// setSignatureHeaders signs the request with the config's secret as hex-encoded HMAC-SHA256 of
+// "<timestamp>.<body>", where timestamp is the Unix time sent in X-Webhook-Timestamp
+// Binding the timestamp into the signature lets receivers verify the sender and reject stale replays,
+// since a captured delivery cannot be resent with a fresh timestamp
+// The signature covers the body before compression; nothing is set when the secret is empty
+func setSignatureHeaders(req *http.Request, secret string, payload []byte, now time.Time) {
+	if secret == "" {
+		return
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	req.Header.Set(signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set(timestampHeader, timestamp)
+}
+
+// encodeBody returns the request body reader and its Content-Encoding
+// Bodies over the compression threshold are gzipped when compression is enabled; small bodies are sent as-is
+func (s *webhookServiceImpl) encodeBody(payload []byte, compress bool) (io.Reader, string, error) {
+	if len(payload) == 0 {
+		return nil, "", nil
+	}
+	if !compress || len(payload) <= s.compressionThreshold {
+		return bytes.NewReader(payload), "", nil
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(payload); err != nil {
+		return nil, "", fmt.Errorf("failed to gzip request body: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to gzip request body: %w", err)
+	}
+	return &buf, "gzip", nil
+}
@@ -1,27 +1,224 @@
 package services
 
 import (
+	"compress/gzip"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"webhook-processor/internal/config"
 	"webhook-processor/internal/domain/entities"
 	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/domain/services"
+	"webhook-processor/internal/infrastructure/metrics"
 )
 
+// testMetrics is shared because Prometheus collectors can only be registered once per process
+var testMetrics = metrics.NewWebhookMetrics()
+
+func TestWebhookServiceImpl_HostMinInterval(t *testing.T) {
+	const gap = 150 * time.Millisecond
+	// jitter allows for connection setup delaying an arrival after its send was spaced
+	const jitter = 10 * time.Millisecond
+
+	// newRecordingServer returns a server that records when each request arrived
+	newRecordingServer := func(t *testing.T) (*httptest.Server, func() []time.Time) {
+		var mu sync.Mutex
+		var arrivals []time.Time
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			arrivals = append(arrivals, time.Now())
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+		return server, func() []time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]time.Time(nil), arrivals...)
+		}
+	}
+
+	newWebhook := func(url string) *entities.WebhookQueue {
+		return &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: url}
+	}
+
+	t.Run("should space two rapid sends to the same host by the gap", func(t *testing.T) {
+		server, arrivals := newRecordingServer(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second, HostMinInterval: gap}, nil)
+
+		for i := 0; i < 2; i++ {
+			_, err := service.SendWebhook(context.Background(), newWebhook(server.URL+"/hook"))
+			require.NoError(t, err)
+		}
+
+		got := arrivals()
+		require.Len(t, got, 2)
+		assert.GreaterOrEqual(t, got[1].Sub(got[0]), gap-jitter)
+	})
+
+	t.Run("should space concurrent sends to the same host one gap apart", func(t *testing.T) {
+		server, arrivals := newRecordingServer(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second, HostMinInterval: gap}, nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.SendWebhook(context.Background(), newWebhook(server.URL))
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		got := arrivals()
+		require.Len(t, got, 3)
+		sort.Slice(got, func(i, j int) bool { return got[i].Before(got[j]) })
+		assert.GreaterOrEqual(t, got[2].Sub(got[0]), 2*gap-jitter)
+	})
+
+	t.Run("should not space sends to different hosts", func(t *testing.T) {
+		server, arrivals := newRecordingServer(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second, HostMinInterval: time.Minute}, nil)
+		port := server.URL[strings.LastIndex(server.URL, ":")+1:]
+
+		start := time.Now()
+		for _, host := range []string{"127.0.0.1", "localhost"} {
+			_, err := service.SendWebhook(context.Background(), newWebhook("http://"+host+":"+port))
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, arrivals(), 2)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("should stop waiting when the context ends", func(t *testing.T) {
+		server, arrivals := newRecordingServer(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second, HostMinInterval: time.Minute}, nil)
+
+		_, err := service.SendWebhook(context.Background(), newWebhook(server.URL))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		response, err := service.SendWebhook(ctx, newWebhook(server.URL))
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, err, services.ErrSpacingWaitCancelled)
+		require.NotNil(t, response)
+		assert.Len(t, arrivals(), 1)
+	})
+
+	t.Run("should not wait for a slot past the attempt timeout", func(t *testing.T) {
+		server, arrivals := newRecordingServer(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 100 * time.Millisecond, HostMinInterval: time.Minute}, nil)
+
+		_, err := service.SendWebhook(context.Background(), newWebhook(server.URL))
+		require.NoError(t, err)
+
+		start := time.Now()
+		response, err := service.SendWebhook(context.Background(), newWebhook(server.URL))
+
+		assert.ErrorIs(t, err, services.ErrSpacingWaitCancelled)
+		assert.Less(t, time.Since(start), 100*time.Millisecond, "a slot the attempt cannot reach is not waited for")
+		require.NotNil(t, response)
+		assert.Greater(t, response.RetryAfter, 59*time.Second, "the caller learns when the host's slot comes up")
+		assert.Len(t, arrivals(), 1)
+	})
+
+	t.Run("should send immediately when spacing is disabled", func(t *testing.T) {
+		server, arrivals := newRecordingServer(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			_, err := service.SendWebhook(context.Background(), newWebhook(server.URL))
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, arrivals(), 3)
+		assert.Less(t, time.Since(start), gap)
+	})
+}
+
+func TestSpacingHost(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://Hooks.Example.com/in?token=x", want: "hooks.example.com"},
+		{url: "https://hooks.example.com:8443/in", want: "hooks.example.com"},
+		{url: "http://[::1]:9000/", want: "::1"},
+		{url: "not a url", want: "not a url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.want, spacingHost(tt.url))
+		})
+	}
+}
+
+// connectionsTotal reads the delivery connection counter for new or reused connections
+func connectionsTotal(t *testing.T, reused string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "webhook_connections_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "reused" && label.GetValue() == reused {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// bodySizeHistogram reads the sample count and sum of a body size histogram
+func bodySizeHistogram(t *testing.T, name string) (uint64, float64) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+		}
+	}
+	return 0, 0
+}
+
 func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 	t.Run("should send webhook successfully", func(t *testing.T) {
 		// Create test server
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Verify request
-			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, "POST", r.Method)
 			assert.Equal(t, "/webhook", r.URL.Path)
 			assert.Equal(t, "value", r.URL.Query().Get("param"))
 			assert.Equal(t, "Webhook-Processor/1.0", r.Header.Get("User-Agent"))
@@ -39,7 +236,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook
 		webhook := &entities.WebhookQueue{
@@ -85,7 +282,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook
 		webhook := &entities.WebhookQueue{
@@ -119,7 +316,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook with invalid URL that will timeout
 		webhook := &entities.WebhookQueue{
@@ -153,7 +350,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook with invalid URL
 		webhook := &entities.WebhookQueue{
@@ -196,7 +393,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook
 		webhook := &entities.WebhookQueue{
@@ -223,11 +420,14 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 		assert.NotNil(t, response.Error)
 	})
 
-	t.Run("should handle different HTTP methods correctly", func(t *testing.T) {
+	t.Run("should POST without a body when the webhook has no payload", func(t *testing.T) {
 		// Create test server
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Verify it's always GET
-			assert.Equal(t, "GET", r.Method)
+			// Verify the default method and that no body or Content-Type was sent
+			assert.Equal(t, "POST", r.Method)
+			assert.Empty(t, r.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(r.Body)
+			assert.Empty(t, body)
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte(`{"method": "` + r.Method + `"}`))
 		}))
@@ -239,7 +439,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook
 		webhook := &entities.WebhookQueue{
@@ -261,7 +461,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 		assert.NoError(t, err)
 		require.NotNil(t, response)
 		assert.Equal(t, http.StatusOK, response.StatusCode)
-		assert.Contains(t, response.Body, `"method": "GET"`)
+		assert.Contains(t, response.Body, `"method": "POST"`)
 	})
 
 	t.Run("should set correct headers", func(t *testing.T) {
@@ -282,7 +482,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook
 		webhook := &entities.WebhookQueue{
@@ -328,7 +528,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook
 		webhook := &entities.WebhookQueue{
@@ -383,7 +583,7 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 			MaxIdleConns:    10,
 			IdleConnTimeout: time.Second * 90,
 		}
-		service := NewWebhookService(clientConfig)
+		service := NewWebhookService(clientConfig, nil)
 
 		// Create webhook
 		webhook := &entities.WebhookQueue{
@@ -410,6 +610,87 @@ func TestWebhookServiceImpl_SendWebhook(t *testing.T) {
 		assert.NotNil(t, response.Error)
 		assert.Contains(t, err.Error(), "failed to read response body")
 	})
+
+	// rawServer starts a server that answers every request with raw, then closes the connection
+	rawServer := func(t *testing.T, raw string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hj, ok := w.(http.Hijacker)
+			require.True(t, ok)
+			conn, buf, err := hj.Hijack()
+			require.NoError(t, err)
+			defer conn.Close()
+			buf.WriteString(raw)
+			buf.Flush()
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+
+	t.Run("should read a complete chunked response", func(t *testing.T) {
+		url := rawServer(t, "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n"+
+			"6\r\n{\"ok\":\r\n5\r\ntrue}\r\n0\r\n\r\n")
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		response, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: url})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, `{"ok":true}`, response.Body)
+		assert.Nil(t, response.Error)
+	})
+
+	t.Run("should read a streamed response flushed in several chunks", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			for i := 0; i < 3; i++ {
+				fmt.Fprintf(w, "part-%d;", i)
+				w.(http.Flusher).Flush()
+			}
+		}))
+		defer server.Close()
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		response, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: server.URL})
+
+		require.NoError(t, err)
+		assert.Equal(t, "part-0;part-1;part-2;", response.Body)
+	})
+
+	t.Run("should read a response delimited by the connection closing", func(t *testing.T) {
+		url := rawServer(t, "HTTP/1.1 200 OK\r\nConnection: close\r\n\r\naccepted")
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		response, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: url})
+
+		require.NoError(t, err)
+		assert.Equal(t, "accepted", response.Body)
+	})
+
+	t.Run("should report a chunked response closed mid-chunk as truncated", func(t *testing.T) {
+		url := rawServer(t, "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n"+
+			"6\r\n{\"ok\":\r\n5\r\ntr")
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		response, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: url})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Contains(t, err.Error(), "failed to read response body: chunked response body truncated")
+		require.NotNil(t, response)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.NotNil(t, response.Error)
+	})
+
+	t.Run("should report a response shorter than its Content-Length as truncated", func(t *testing.T) {
+		url := rawServer(t, "HTTP/1.1 200 OK\r\nContent-Length: 20\r\n\r\n{\"ok\":")
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: url})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Contains(t, err.Error(), "Content-Length response body truncated after 6 bytes")
+	})
 }
 
 func TestWebhookServiceImpl_URLParsing(t *testing.T) {
@@ -483,7 +764,7 @@ func TestWebhookServiceImpl_URLParsing(t *testing.T) {
 				MaxIdleConns:    10,
 				IdleConnTimeout: time.Second * 90,
 			}
-			service := NewWebhookService(clientConfig)
+			service := NewWebhookService(clientConfig, nil)
 
 			// Create webhook
 			webhook := &entities.WebhookQueue{
@@ -520,6 +801,129 @@ func TestWebhookServiceImpl_URLParsing(t *testing.T) {
 }
 
 // Benchmark tests
+func TestWebhookServiceImpl_AttemptTimeout(t *testing.T) {
+	// atLevel returns a webhook without a config at a retry level
+	atLevel := func(retryLevel int) *entities.WebhookQueue {
+		return &entities.WebhookQueue{RetryCount: retryLevel}
+	}
+
+	t.Run("should increase timeout with retry level and respect the cap", func(t *testing.T) {
+		service := NewWebhookService(config.HTTPClientConfig{
+			Timeout:             10 * time.Second,
+			TimeoutGrowthFactor: 0.5,
+			MaxTimeout:          30 * time.Second,
+		}, nil).(*webhookServiceImpl)
+
+		assert.Equal(t, 10*time.Second, service.attemptTimeout(atLevel(0)))
+		assert.Equal(t, 15*time.Second, service.attemptTimeout(atLevel(1)))
+		assert.Equal(t, 20*time.Second, service.attemptTimeout(atLevel(2)))
+		assert.Equal(t, 25*time.Second, service.attemptTimeout(atLevel(3)))
+		assert.Equal(t, 30*time.Second, service.attemptTimeout(atLevel(4)))
+		assert.Equal(t, 30*time.Second, service.attemptTimeout(atLevel(6))) // Capped
+	})
+
+	t.Run("should keep base timeout when growth is disabled", func(t *testing.T) {
+		service := NewWebhookService(config.HTTPClientConfig{
+			Timeout:    10 * time.Second,
+			MaxTimeout: 30 * time.Second,
+		}, nil).(*webhookServiceImpl)
+
+		for retryLevel := 0; retryLevel <= enums.MaxRetryAttempts; retryLevel++ {
+			assert.Equal(t, 10*time.Second, service.attemptTimeout(atLevel(retryLevel)))
+		}
+	})
+
+	t.Run("should report the effective timeout on the response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{
+			Timeout:             2 * time.Second,
+			TimeoutGrowthFactor: 1,
+			MaxTimeout:          10 * time.Second,
+		}, nil)
+
+		webhook := &entities.WebhookQueue{
+			ID:         1,
+			WebhookURL: server.URL,
+			RetryCount: 2,
+		}
+
+		response, err := service.SendWebhook(context.Background(), webhook)
+
+		assert.NoError(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, 6*time.Second, response.Timeout)
+	})
+
+	t.Run("should time out slow receivers using the effective timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{
+			Timeout: 20 * time.Millisecond,
+		}, nil)
+
+		webhook := &entities.WebhookQueue{ID: 1, WebhookURL: server.URL}
+
+		response, err := service.SendWebhook(context.Background(), webhook)
+
+		assert.Error(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, 20*time.Millisecond, response.Timeout)
+	})
+
+	t.Run("should use the config's timeout in place of the client timeout", func(t *testing.T) {
+		service := NewWebhookService(config.HTTPClientConfig{
+			Timeout:             10 * time.Second,
+			TimeoutGrowthFactor: 0.5,
+			MaxTimeout:          time.Minute,
+		}, nil).(*webhookServiceImpl)
+
+		for _, tt := range []struct {
+			timeoutMs  int
+			retryLevel int
+			want       time.Duration
+		}{
+			{timeoutMs: 5000, want: 5 * time.Second},
+			{timeoutMs: 5000, retryLevel: 2, want: 10 * time.Second},
+			{timeoutMs: 120000, want: time.Minute}, // Capped
+			{timeoutMs: 0, want: 10 * time.Second},
+			{timeoutMs: -1, want: 10 * time.Second},
+		} {
+			webhook := &entities.WebhookQueue{RetryCount: tt.retryLevel, Config: &entities.WebhookConfig{TimeoutMs: tt.timeoutMs}}
+			assert.Equal(t, tt.want, service.attemptTimeout(webhook), "timeout_ms %d at level %d", tt.timeoutMs, tt.retryLevel)
+		}
+	})
+
+	t.Run("should time out a slow receiver at the config's timeout when the client timeout is large", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: time.Minute}, nil)
+		webhook := &entities.WebhookQueue{ID: 1, WebhookURL: server.URL, Config: &entities.WebhookConfig{ID: 1, TimeoutMs: 50}}
+
+		start := time.Now()
+		response, err := service.SendWebhook(context.Background(), webhook)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NotNil(t, response)
+		assert.Equal(t, 50*time.Millisecond, response.Timeout)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
 func BenchmarkWebhookServiceImpl_SendWebhook(b *testing.B) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -534,7 +938,7 @@ func BenchmarkWebhookServiceImpl_SendWebhook(b *testing.B) {
 		MaxIdleConns:    10,
 		IdleConnTimeout: time.Second * 90,
 	}
-	service := NewWebhookService(clientConfig)
+	service := NewWebhookService(clientConfig, nil)
 
 	// Create webhook
 	webhook := &entities.WebhookQueue{
@@ -575,7 +979,7 @@ func BenchmarkWebhookServiceImpl_SendWebhook_LargeResponse(b *testing.B) {
 		MaxIdleConns:    10,
 		IdleConnTimeout: time.Second * 90,
 	}
-	service := NewWebhookService(clientConfig)
+	service := NewWebhookService(clientConfig, nil)
 
 	// Create webhook
 	webhook := &entities.WebhookQueue{
@@ -595,3 +999,977 @@ func BenchmarkWebhookServiceImpl_SendWebhook_LargeResponse(b *testing.B) {
 		_, _ = service.SendWebhook(ctx, webhook)
 	}
 }
+
+func TestWebhookServiceImpl_AdaptiveTimeout(t *testing.T) {
+	// The server answers at once until slow is set, then takes 500ms
+	var slow atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow.Load() {
+			select {
+			case <-time.After(500 * time.Millisecond):
+			case <-r.Context().Done():
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	service := NewWebhookService(config.HTTPClientConfig{
+		Timeout: 2 * time.Second,
+		AdaptiveTimeout: config.AdaptiveTimeoutConfig{
+			Enabled: true, Factor: 3, Floor: 50 * time.Millisecond, MinSamples: 5, Window: 10,
+		},
+	}, nil)
+	webhook := &entities.WebhookQueue{QueueID: uuid.New(), ConfigID: 7, WebhookURL: server.URL}
+
+	for i := 0; i < 5; i++ {
+		response, err := service.SendWebhook(context.Background(), webhook)
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Second, response.Timeout, "the static timeout applies until enough responses are observed")
+	}
+
+	slow.Store(true)
+	start := time.Now()
+	response, err := service.SendWebhook(context.Background(), webhook)
+	elapsed := time.Since(start)
+
+	require.Error(t, err)
+	assert.Equal(t, 50*time.Millisecond, response.Timeout, "fast responses tighten the timeout down to the floor")
+	assert.Less(t, elapsed, 500*time.Millisecond, "the slow response is given up on before it arrives")
+
+	t.Run("should keep the static timeout for other configs", func(t *testing.T) {
+		slow.Store(false)
+		other := &entities.WebhookQueue{QueueID: uuid.New(), ConfigID: 8, WebhookURL: server.URL}
+
+		response, err := service.SendWebhook(context.Background(), other)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Second, response.Timeout)
+	})
+}
+
+func TestResponseTimeTracker(t *testing.T) {
+	settings := config.AdaptiveTimeoutConfig{Enabled: true, Factor: 2, Floor: 100 * time.Millisecond, MinSamples: 3, Window: 4}
+	noGrowth := func(base time.Duration) time.Duration { return base }
+
+	t.Run("should scale the p99 response time by the factor", func(t *testing.T) {
+		tracker := newResponseTimeTracker(settings)
+		for _, responseTime := range []time.Duration{200, 300, 400} {
+			tracker.observe(1, responseTime*time.Millisecond)
+		}
+
+		assert.Equal(t, 800*time.Millisecond, tracker.timeout(1, 10*time.Second, noGrowth))
+	})
+
+	t.Run("should bound the timeout by the floor and the ceiling", func(t *testing.T) {
+		tracker := newResponseTimeTracker(settings)
+		for i := 0; i < 3; i++ {
+			tracker.observe(1, time.Millisecond)
+			tracker.observe(2, time.Second)
+		}
+
+		assert.Equal(t, 100*time.Millisecond, tracker.timeout(1, 10*time.Second, noGrowth))
+		assert.Equal(t, 1500*time.Millisecond, tracker.timeout(2, 1500*time.Millisecond, noGrowth))
+	})
+
+	t.Run("should only keep the most recent responses", func(t *testing.T) {
+		tracker := newResponseTimeTracker(settings)
+		tracker.observe(1, 5*time.Second)
+		for i := 0; i < 4; i++ {
+			tracker.observe(1, 200*time.Millisecond)
+		}
+
+		assert.Equal(t, 400*time.Millisecond, tracker.timeout(1, 10*time.Second, noGrowth))
+	})
+
+	t.Run("should grow the adaptive timeout like the static one", func(t *testing.T) {
+		tracker := newResponseTimeTracker(settings)
+		for i := 0; i < 3; i++ {
+			tracker.observe(1, 200*time.Millisecond)
+		}
+		double := func(base time.Duration) time.Duration { return 2 * base }
+
+		assert.Equal(t, 800*time.Millisecond, tracker.timeout(1, 10*time.Second, double))
+	})
+
+	t.Run("should leave the timeout unchanged when disabled", func(t *testing.T) {
+		tracker := newResponseTimeTracker(config.AdaptiveTimeoutConfig{})
+		for i := 0; i < 10; i++ {
+			tracker.observe(1, time.Millisecond)
+		}
+
+		assert.Equal(t, 10*time.Second, tracker.timeout(1, 10*time.Second, noGrowth))
+	})
+}
+
+func TestWebhookServiceImpl_EncodeBody(t *testing.T) {
+	service := NewWebhookService(config.HTTPClientConfig{
+		Timeout:              time.Second * 5,
+		CompressionThreshold: 64,
+	}, nil).(*webhookServiceImpl)
+
+	// send posts the encoded body to a server that decodes it according to Content-Encoding
+	send := func(t *testing.T, payload []byte, compress bool) (string, []byte) {
+		var encoding string
+		var received []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding = r.Header.Get("Content-Encoding")
+			reader := io.Reader(r.Body)
+			if encoding == "gzip" {
+				gz, err := gzip.NewReader(r.Body)
+				require.NoError(t, err)
+				defer gz.Close()
+				reader = gz
+			}
+			var err error
+			received, err = io.ReadAll(reader)
+			require.NoError(t, err)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		body, contentEncoding, err := service.encodeBody(payload, compress)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", server.URL, body)
+		require.NoError(t, err)
+		if contentEncoding != "" {
+			req.Header.Set("Content-Encoding", contentEncoding)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		return encoding, received
+	}
+
+	t.Run("should gzip bodies over the threshold", func(t *testing.T) {
+		payload := []byte(strings.Repeat(`{"amount":100,"currency":"USD"}`, 10))
+
+		encoding, received := send(t, payload, true)
+
+		assert.Equal(t, "gzip", encoding)
+		assert.Equal(t, payload, received)
+	})
+
+	t.Run("should send small bodies uncompressed", func(t *testing.T) {
+		payload := []byte(`{"amount":100}`)
+
+		encoding, received := send(t, payload, true)
+
+		assert.Empty(t, encoding)
+		assert.Equal(t, payload, received)
+	})
+
+	t.Run("should not compress when the config does not ask for it", func(t *testing.T) {
+		payload := []byte(strings.Repeat("x", 1024))
+
+		encoding, received := send(t, payload, false)
+
+		assert.Empty(t, encoding)
+		assert.Equal(t, payload, received)
+	})
+
+	t.Run("should not set Content-Encoding on bodyless deliveries", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Content-Encoding"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		webhook := &entities.WebhookQueue{
+			ID:         1,
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeCredit,
+			ConfigID:   1,
+			WebhookURL: server.URL,
+			Status:     enums.WebhookStatusProcessing,
+			Config:     &entities.WebhookConfig{ID: 1, CompressRequest: true},
+		}
+
+		response, err := service.SendWebhook(context.Background(), webhook)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+}
+
+func TestWebhookServiceImpl_AttemptHeaders(t *testing.T) {
+	attemptHeaders := config.AttemptHeadersConfig{
+		Enabled:           true,
+		AttemptHeader:     "X-Webhook-Attempt",
+		MaxAttemptsHeader: "X-Webhook-Max-Attempts",
+		WebhookIDHeader:   "X-Webhook-Id",
+	}
+
+	for _, retryCount := range []int{0, 3} {
+		t.Run(fmt.Sprintf("should send attempt %d", retryCount), func(t *testing.T) {
+			var received http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Clone()
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			service := NewWebhookService(config.HTTPClientConfig{
+				Timeout:        time.Second * 5,
+				AttemptHeaders: attemptHeaders,
+			}, nil)
+
+			webhook := &entities.WebhookQueue{
+				ID:         1,
+				QueueID:    uuid.New(),
+				EventType:  enums.EventTypeCredit,
+				WebhookURL: server.URL,
+				Status:     enums.WebhookStatusProcessing,
+				RetryCount: retryCount,
+			}
+
+			_, err := service.SendWebhook(context.Background(), webhook)
+
+			require.NoError(t, err)
+			assert.Equal(t, strconv.Itoa(retryCount), received.Get("X-Webhook-Attempt"))
+			assert.Equal(t, strconv.Itoa(enums.MaxRetryAttempts), received.Get("X-Webhook-Max-Attempts"))
+			assert.Equal(t, webhook.QueueID.String(), received.Get("X-Webhook-Id"))
+		})
+	}
+
+	t.Run("should use configured header names and skip empty ones", func(t *testing.T) {
+		var received http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Clone()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{
+			Timeout: time.Second * 5,
+			AttemptHeaders: config.AttemptHeadersConfig{
+				Enabled:       true,
+				AttemptHeader: "X-Delivery-Attempt",
+			},
+		}, nil)
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: server.URL,
+			RetryCount: 2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2", received.Get("X-Delivery-Attempt"))
+		assert.Empty(t, received.Get("X-Webhook-Attempt"))
+		assert.Empty(t, received.Get("X-Webhook-Max-Attempts"))
+		assert.Empty(t, received.Get("X-Webhook-Id"))
+	})
+
+	t.Run("should not send attempt headers when disabled", func(t *testing.T) {
+		var received http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Clone()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		disabled := attemptHeaders
+		disabled.Enabled = false
+		service := NewWebhookService(config.HTTPClientConfig{
+			Timeout:        time.Second * 5,
+			AttemptHeaders: disabled,
+		}, nil)
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: server.URL,
+			RetryCount: 1,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, received.Get("X-Webhook-Attempt"))
+		assert.Empty(t, received.Get("X-Webhook-Id"))
+	})
+}
+
+func TestWebhookServiceImpl_ConnectionReuse(t *testing.T) {
+	t.Run("should reuse the connection for a second request to the same host", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success": true}`))
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{
+			Timeout:         time.Second * 5,
+			MaxIdleConns:    10,
+			IdleConnTimeout: time.Second * 90,
+		}, testMetrics)
+		webhook := &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: server.URL}
+
+		newBefore, reusedBefore := connectionsTotal(t, "false"), connectionsTotal(t, "true")
+
+		_, err := service.SendWebhook(context.Background(), webhook)
+		require.NoError(t, err)
+		assert.Equal(t, newBefore+1, connectionsTotal(t, "false"))
+		assert.Equal(t, reusedBefore, connectionsTotal(t, "true"))
+
+		_, err = service.SendWebhook(context.Background(), webhook)
+		require.NoError(t, err)
+		assert.Equal(t, newBefore+1, connectionsTotal(t, "false"))
+		assert.Equal(t, reusedBefore+1, connectionsTotal(t, "true"))
+	})
+}
+
+func TestWebhookServiceImpl_AcceptHeader(t *testing.T) {
+	vendorType := "application/vnd.example.v2+json"
+	omitted := ""
+
+	tests := []struct {
+		name         string
+		config       *entities.WebhookConfig
+		wantAccept   string
+		wantHasValue bool
+	}{
+		{name: "default without a config", config: nil, wantAccept: "application/json", wantHasValue: true},
+		{name: "default when not overridden", config: &entities.WebhookConfig{ID: 1}, wantAccept: "application/json", wantHasValue: true},
+		{name: "overridden", config: &entities.WebhookConfig{ID: 1, AcceptHeader: &vendorType}, wantAccept: vendorType, wantHasValue: true},
+		{name: "omitted", config: &entities.WebhookConfig{ID: 1, AcceptHeader: &omitted}, wantHasValue: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Clone()
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			service := NewWebhookService(config.HTTPClientConfig{Timeout: time.Second * 5}, nil)
+
+			_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+				QueueID:    uuid.New(),
+				WebhookURL: server.URL,
+				Config:     tt.config,
+			})
+
+			require.NoError(t, err)
+			values, ok := received["Accept"]
+			assert.Equal(t, tt.wantHasValue, ok)
+			if tt.wantHasValue {
+				assert.Equal(t, []string{tt.wantAccept}, values)
+			}
+		})
+	}
+}
+
+func TestWebhookServiceImpl_Signing(t *testing.T) {
+	// receive starts a server that records each request's headers and body
+	receive := func(t *testing.T) (string, <-chan *http.Request, <-chan []byte) {
+		requests := make(chan *http.Request, 1)
+		bodies := make(chan []byte, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			requests <- r
+			bodies <- body
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+		return server.URL, requests, bodies
+	}
+
+	// sign recomputes the signature a receiver expects for body sent at timestamp
+	sign := func(secret, timestamp string, body []byte) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "."))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	t.Run("should sign the request body with the config's secret", func(t *testing.T) {
+		url, requests, bodies := receive(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+		before := time.Now().Unix()
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: url,
+			Payload:    `{"amount":100}`,
+			Config:     &entities.WebhookConfig{ID: 1, SigningSecret: "whsec_123"},
+		})
+
+		require.NoError(t, err)
+		req, body := <-requests, <-bodies
+		assert.Equal(t, `{"amount":100}`, string(body))
+		assert.True(t, hmac.Equal([]byte(sign("whsec_123", req.Header.Get("X-Webhook-Timestamp"), body)),
+			[]byte(req.Header.Get("X-Webhook-Signature"))))
+		timestamp, err := strconv.ParseInt(req.Header.Get("X-Webhook-Timestamp"), 10, 64)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, timestamp, before)
+		assert.LessOrEqual(t, timestamp, time.Now().Unix())
+	})
+
+	t.Run("should sign the uncompressed body of a gzipped request", func(t *testing.T) {
+		url, requests, bodies := receive(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second, CompressionThreshold: 8}, nil)
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: url,
+			Payload:    `{"amount":100,"currency":"USD"}`,
+			Config:     &entities.WebhookConfig{ID: 1, SigningSecret: "whsec_123", CompressRequest: true},
+		})
+
+		require.NoError(t, err)
+		req, body := <-requests, <-bodies
+		require.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+		reader, err := gzip.NewReader(strings.NewReader(string(body)))
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, sign("whsec_123", req.Header.Get("X-Webhook-Timestamp"), decompressed), req.Header.Get("X-Webhook-Signature"))
+	})
+
+	t.Run("should sign the empty body of a GET request", func(t *testing.T) {
+		url, requests, _ := receive(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: url,
+			Payload:    `{"amount":100}`,
+			Config:     &entities.WebhookConfig{ID: 1, SigningSecret: "whsec_123", HTTPMethod: http.MethodGet},
+		})
+
+		require.NoError(t, err)
+		req := <-requests
+		assert.Equal(t, sign("whsec_123", req.Header.Get("X-Webhook-Timestamp"), nil), req.Header.Get("X-Webhook-Signature"))
+	})
+
+	t.Run("should not verify a delivery replayed with a fresh timestamp", func(t *testing.T) {
+		url, requests, bodies := receive(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: url,
+			Payload:    `{"amount":100}`,
+			Config:     &entities.WebhookConfig{ID: 1, SigningSecret: "whsec_123"},
+		})
+
+		require.NoError(t, err)
+		req, body := <-requests, <-bodies
+		timestamp, err := strconv.ParseInt(req.Header.Get("X-Webhook-Timestamp"), 10, 64)
+		require.NoError(t, err)
+		replayed := strconv.FormatInt(timestamp+600, 10)
+		assert.False(t, hmac.Equal([]byte(sign("whsec_123", replayed, body)), []byte(req.Header.Get("X-Webhook-Signature"))))
+	})
+
+	t.Run("should not add signature headers without a secret", func(t *testing.T) {
+		url, requests, _ := receive(t)
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: url,
+			Payload:    `{"amount":100}`,
+			Config:     &entities.WebhookConfig{ID: 1},
+		})
+
+		require.NoError(t, err)
+		req := <-requests
+		assert.Empty(t, req.Header.Values("X-Webhook-Signature"))
+		assert.Empty(t, req.Header.Values("X-Webhook-Timestamp"))
+	})
+}
+
+func TestWebhookServiceImpl_ConfigHeaders(t *testing.T) {
+	// send delivers a webhook with the given custom headers and returns the request the server received
+	send := func(t *testing.T, headers map[string]string, signingSecret string) *http.Request {
+		received := make(chan *http.Request, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received <- r
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: server.URL,
+			Payload:    `{"amount":100}`,
+			Config:     &entities.WebhookConfig{ID: 1, Headers: headers, SigningSecret: signingSecret},
+		})
+
+		require.NoError(t, err)
+		return <-received
+	}
+
+	t.Run("should send each custom header", func(t *testing.T) {
+		req := send(t, map[string]string{"Authorization": "Bearer token-1", "x-tenant-id": "acme"}, "")
+
+		assert.Equal(t, "Bearer token-1", req.Header.Get("Authorization"))
+		assert.Equal(t, "acme", req.Header.Get("X-Tenant-Id"))
+		assert.Equal(t, "Webhook-Processor/1.0", req.Header.Get("User-Agent"))
+	})
+
+	t.Run("should send only the default headers without custom ones", func(t *testing.T) {
+		req := send(t, map[string]string{}, "")
+
+		assert.Equal(t, "Webhook-Processor/1.0", req.Header.Get("User-Agent"))
+		assert.Equal(t, "application/json", req.Header.Get("Accept"))
+		assert.Empty(t, req.Header.Values("Authorization"))
+	})
+
+	t.Run("should override a default header and omit one with an empty value", func(t *testing.T) {
+		req := send(t, map[string]string{"User-Agent": "Acme-Relay/2.0", "Accept": "", "X-Empty": ""}, "")
+
+		assert.Equal(t, "Acme-Relay/2.0", req.Header.Get("User-Agent"))
+		assert.Empty(t, req.Header.Values("Accept"))
+		assert.Empty(t, req.Header.Values("X-Empty"))
+	})
+
+	t.Run("should not let custom headers override the host or the signature", func(t *testing.T) {
+		req := send(t, map[string]string{
+			"Host":                "evil.example.com",
+			"X-Webhook-Signature": "forged",
+			"x-webhook-timestamp": "0",
+			"Content-Length":      "1",
+		}, "whsec_123")
+
+		assert.NotEqual(t, "evil.example.com", req.Host)
+		assert.NotEqual(t, "forged", req.Header.Get("X-Webhook-Signature"))
+		assert.NotEmpty(t, req.Header.Get("X-Webhook-Signature"))
+		assert.NotEqual(t, "0", req.Header.Get("X-Webhook-Timestamp"))
+		assert.Equal(t, int64(len(`{"amount":100}`)), req.ContentLength)
+	})
+
+	t.Run("should not add the signature headers a custom header names when unsigned", func(t *testing.T) {
+		req := send(t, map[string]string{"X-Webhook-Signature": "forged"}, "")
+
+		assert.Empty(t, req.Header.Values("X-Webhook-Signature"))
+	})
+}
+
+func TestWebhookServiceImpl_BodySizeMetrics(t *testing.T) {
+	t.Run("should observe the request and response body sizes", func(t *testing.T) {
+		responseBody := strings.Repeat("r", 1500)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(responseBody))
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: time.Second * 5}, testMetrics)
+
+		requestCount, requestSum := bodySizeHistogram(t, "webhook_request_body_bytes")
+		responseCount, responseSum := bodySizeHistogram(t, "webhook_response_body_bytes")
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: server.URL})
+		require.NoError(t, err)
+
+		count, sum := bodySizeHistogram(t, "webhook_request_body_bytes")
+		assert.Equal(t, requestCount+1, count)
+		assert.Equal(t, requestSum, sum) // Deliveries are bodyless GETs
+
+		count, sum = bodySizeHistogram(t, "webhook_response_body_bytes")
+		assert.Equal(t, responseCount+1, count)
+		assert.Equal(t, responseSum+1500, sum)
+	})
+
+	t.Run("should not observe a response when the request fails", func(t *testing.T) {
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: time.Second * 5}, testMetrics)
+
+		responseCount, _ := bodySizeHistogram(t, "webhook_response_body_bytes")
+
+		_, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{QueueID: uuid.New(), WebhookURL: "http://127.0.0.1:1/unreachable"})
+		require.Error(t, err)
+
+		count, _ := bodySizeHistogram(t, "webhook_response_body_bytes")
+		assert.Equal(t, responseCount, count)
+	})
+}
+
+func TestWebhookServiceImpl_WrapPayload(t *testing.T) {
+	type received struct {
+		method      string
+		contentType string
+		body        []byte
+	}
+
+	send := func(t *testing.T, webhook *entities.WebhookQueue) received {
+		var got received
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got.method = r.Method
+			got.contentType = r.Header.Get("Content-Type")
+			got.body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		webhook.WebhookURL = server.URL
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: time.Second * 5}, nil)
+
+		_, err := service.SendWebhook(context.Background(), webhook)
+
+		require.NoError(t, err)
+		return got
+	}
+
+	newWebhook := func(wrap bool) *entities.WebhookQueue {
+		return &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeDebit,
+			EventID:    "evt-42",
+			RetryCount: 2,
+			CreatedAt:  time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+			Metadata:   map[string]string{"tenant": "acme"},
+			Config:     &entities.WebhookConfig{ID: 1, WrapPayload: wrap},
+		}
+	}
+
+	t.Run("should POST the standard envelope when wrapping", func(t *testing.T) {
+		webhook := newWebhook(true)
+		webhook.Payload = `{"amount":100,"currency":"USD"}`
+
+		got := send(t, webhook)
+
+		assert.Equal(t, http.MethodPost, got.method)
+		assert.Equal(t, "application/json", got.contentType)
+
+		var envelope map[string]interface{}
+		require.NoError(t, json.Unmarshal(got.body, &envelope))
+		assert.Equal(t, webhook.QueueID.String(), envelope["id"])
+		assert.Equal(t, string(enums.EventTypeDebit), envelope["type"])
+		assert.Equal(t, "evt-42", envelope["event_id"])
+		assert.Equal(t, float64(2), envelope["attempt"])
+		assert.Equal(t, "2024-03-01T12:00:00Z", envelope["created_at"])
+		assert.Equal(t, map[string]interface{}{"amount": float64(100), "currency": "USD"}, envelope["data"], "the stored payload")
+		assert.Equal(t, map[string]interface{}{"tenant": "acme"}, envelope["metadata"])
+	})
+
+	t.Run("should wrap a webhook without a payload with null data", func(t *testing.T) {
+		got := send(t, newWebhook(true))
+
+		var envelope map[string]interface{}
+		require.NoError(t, json.Unmarshal(got.body, &envelope))
+		assert.Contains(t, envelope, "data")
+		assert.Nil(t, envelope["data"])
+	})
+
+	t.Run("should wrap a payload that is not JSON as a string", func(t *testing.T) {
+		webhook := newWebhook(true)
+		webhook.Payload = "amount=100"
+
+		got := send(t, webhook)
+
+		var envelope map[string]interface{}
+		require.NoError(t, json.Unmarshal(got.body, &envelope))
+		assert.Equal(t, "amount=100", envelope["data"])
+	})
+
+	t.Run("should send the raw payload without an envelope when not wrapping", func(t *testing.T) {
+		webhook := newWebhook(false)
+		webhook.Payload = `{"amount":100}`
+
+		got := send(t, webhook)
+
+		assert.Equal(t, http.MethodPost, got.method)
+		assert.Equal(t, "application/json", got.contentType)
+		assert.JSONEq(t, `{"amount":100}`, string(got.body))
+	})
+}
+
+func TestWebhookServiceImpl_HTTPMethod(t *testing.T) {
+	// The server echoes the method, Content-Type and body it received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"method":       r.Method,
+			"content_type": r.Header.Get("Content-Type"),
+			"body":         string(body),
+		})
+	}))
+	defer server.Close()
+
+	service := NewWebhookService(config.HTTPClientConfig{Timeout: time.Second * 5}, nil)
+
+	// send delivers a webhook carrying payload through config and decodes the echo
+	send := func(t *testing.T, webhookConfig *entities.WebhookConfig, payload string) map[string]string {
+		webhook := &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			EventType:  enums.EventTypeCredit,
+			EventID:    "evt-7",
+			WebhookURL: server.URL,
+			Payload:    payload,
+			Config:     webhookConfig,
+		}
+
+		response, err := service.SendWebhook(context.Background(), webhook)
+
+		require.NoError(t, err)
+		var echo map[string]string
+		require.NoError(t, json.Unmarshal([]byte(response.Body), &echo))
+		return echo
+	}
+
+	t.Run("should POST the stored payload by default", func(t *testing.T) {
+		echo := send(t, &entities.WebhookConfig{ID: 1}, `{"amount":100}`)
+
+		assert.Equal(t, http.MethodPost, echo["method"])
+		assert.Equal(t, "application/json", echo["content_type"])
+		assert.Equal(t, `{"amount":100}`, echo["body"])
+	})
+
+	t.Run("should PUT the stored payload when the config selects PUT", func(t *testing.T) {
+		echo := send(t, &entities.WebhookConfig{ID: 1, HTTPMethod: "put"}, `{"amount":100}`)
+
+		assert.Equal(t, http.MethodPut, echo["method"])
+		assert.Equal(t, "application/json", echo["content_type"])
+		assert.Equal(t, `{"amount":100}`, echo["body"])
+	})
+
+	t.Run("should send GET requests without a body", func(t *testing.T) {
+		echo := send(t, &entities.WebhookConfig{ID: 1, HTTPMethod: http.MethodGet}, `{"amount":100}`)
+
+		assert.Equal(t, http.MethodGet, echo["method"])
+		assert.Empty(t, echo["content_type"])
+		assert.Empty(t, echo["body"])
+	})
+
+	t.Run("should render the config's payload template around the stored payload", func(t *testing.T) {
+		template := `{"event_id":{{json .EventID}},"type":{{json .EventType}},"data":{{.Payload}}}`
+
+		echo := send(t, &entities.WebhookConfig{ID: 1, PayloadTemplate: &template}, `{"amount":100}`)
+
+		assert.Equal(t, http.MethodPost, echo["method"])
+		assert.JSONEq(t, `{"event_id":"evt-7","type":"CREDIT","data":{"amount":100}}`, echo["body"])
+	})
+
+	t.Run("should fail the attempt when the payload template is invalid", func(t *testing.T) {
+		template := `{"event_id":{{.Missing}}}`
+		webhook := &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: server.URL,
+			Config:     &entities.WebhookConfig{ID: 1, PayloadTemplate: &template},
+		}
+
+		response, err := service.SendWebhook(context.Background(), webhook)
+
+		assert.ErrorIs(t, err, services.ErrPayloadTemplate)
+		assert.ErrorContains(t, err, "payload template failed to render")
+		require.NotNil(t, response)
+		assert.Zero(t, response.StatusCode)
+	})
+}
+
+func TestWebhookServiceImpl_SentRequest(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+	webhook := &entities.WebhookQueue{
+		QueueID:    uuid.New(),
+		EventType:  enums.EventTypeCredit,
+		WebhookURL: server.URL,
+		Config:     &entities.WebhookConfig{ID: 1, WrapPayload: true},
+	}
+
+	t.Run("should return the sent body and headers with the response", func(t *testing.T) {
+		response, err := service.SendWebhook(context.Background(), webhook)
+
+		require.NoError(t, err)
+		require.NotNil(t, response.Request)
+		assert.Equal(t, string(body), response.Request.Body)
+		assert.Equal(t, "application/json", response.Request.Headers["Content-Type"])
+		assert.Equal(t, "Webhook-Processor/1.0", response.Request.Headers["User-Agent"])
+	})
+
+	t.Run("should redact credential and signature headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Authorization", "Bearer secret")
+		header.Set("X-Webhook-Signature", "sha256=abc")
+		header.Set("X-Api-Key", "key")
+		header.Set("Content-Type", "application/json")
+
+		redacted := redactRequestHeaders(header)
+
+		assert.Equal(t, map[string]string{
+			"Authorization":       redactedHeaderValue,
+			"X-Webhook-Signature": redactedHeaderValue,
+			"X-Api-Key":           redactedHeaderValue,
+			"Content-Type":        "application/json",
+		}, redacted)
+	})
+}
+
+func TestWebhookServiceImpl_Hedging(t *testing.T) {
+	hedgeAfterMs := 50
+
+	newWebhook := func(url string, idempotent bool) *entities.WebhookQueue {
+		return &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: url,
+			Config:     &entities.WebhookConfig{ID: 1, Idempotent: idempotent, HedgeAfterMs: &hedgeAfterMs},
+		}
+	}
+
+	t.Run("should take the hedge response and cancel a slow first request", func(t *testing.T) {
+		var requests atomic.Int32
+		firstCancelled := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) == 1 {
+				// The first request never answers on its own
+				<-r.Context().Done()
+				close(firstCancelled)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("hedge"))
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		response, err := service.SendWebhook(context.Background(), newWebhook(server.URL, true))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "hedge", response.Body)
+		assert.Equal(t, int32(2), requests.Load())
+		select {
+		case <-firstCancelled:
+		case <-time.After(time.Second):
+			t.Fatal("slow first request was not cancelled")
+		}
+	})
+
+	t.Run("should not hedge when the first request answers in time", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		response, err := service.SendWebhook(context.Background(), newWebhook(server.URL, true))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		time.Sleep(time.Duration(2*hedgeAfterMs) * time.Millisecond)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("should not hedge a config that is not idempotent", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			time.Sleep(time.Duration(2*hedgeAfterMs) * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+		response, err := service.SendWebhook(context.Background(), newWebhook(server.URL, false))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
+
+func TestWebhookServiceImpl_RetryAfter(t *testing.T) {
+	t.Run("should report the receiver's Retry-After with a 429", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		service := NewWebhookService(config.HTTPClientConfig{Timeout: time.Second * 5}, nil)
+
+		response, err := service.SendWebhook(context.Background(), &entities.WebhookQueue{
+			QueueID:    uuid.New(),
+			WebhookURL: server.URL,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.Equal(t, 2*time.Minute, response.RetryAfter)
+	})
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{name: "absent", header: "", want: 0},
+		{name: "delay seconds", header: "30", want: 30 * time.Second},
+		{name: "zero seconds", header: "0", want: 0},
+		{name: "negative seconds", header: "-5", want: 0},
+		{name: "HTTP date", header: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second},
+		{name: "past HTTP date", header: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{name: "malformed", header: "soon", want: 0},
+		{name: "seconds overflowing a duration", header: "99999999999", want: math.MaxInt64},
+		{name: "seconds overflowing an integer", header: "99999999999999999999", want: math.MaxInt64},
+		{name: "far future HTTP date", header: "Fri, 31 Dec 9999 23:59:59 GMT", want: math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.header, now))
+		})
+	}
+}
+
+func TestWebhookServiceImpl_Ping(t *testing.T) {
+	service := NewWebhookService(config.HTTPClientConfig{Timeout: 5 * time.Second}, nil)
+
+	t.Run("should report any answer as reachable without delivering a body", func(t *testing.T) {
+		var method string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}))
+		defer server.Close()
+
+		result, err := service.Ping(context.Background(), server.URL, time.Second)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodHead, method)
+		assert.Equal(t, http.StatusMethodNotAllowed, result.StatusCode)
+	})
+
+	t.Run("should return an error when nothing answers", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		result, err := service.Ping(context.Background(), server.URL, time.Second)
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "ping failed")
+	})
+
+	t.Run("should return an error when the receiver does not answer within the timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+
+		result, err := service.Ping(context.Background(), server.URL, 50*time.Millisecond)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
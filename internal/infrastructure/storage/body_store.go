@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/repositories"
+)
+
+// Response body store backends
+const (
+	BodyStoreInline    = "inline"
+	BodyStoreDirectory = "directory"
+)
+
+// objectRefPrefix marks a row value as a reference to an offloaded body rather than the body itself
+const objectRefPrefix = "object:"
+
+// NewBodyStore creates the response body store selected by the database configuration
+func NewBodyStore(cfg config.DatabaseConfig) (repositories.BodyStore, error) {
+	switch cfg.ResponseBodyStore {
+	case "", BodyStoreInline:
+		return InlineBodyStore{}, nil
+	case BodyStoreDirectory:
+		client, err := NewDirectoryObjectClient(cfg.ResponseBodyDir)
+		if err != nil {
+			return nil, err
+		}
+		return NewObjectBodyStore(client), nil
+	default:
+		return nil, fmt.Errorf("unknown response body store %q", cfg.ResponseBodyStore)
+	}
+}
+
+// InlineBodyStore keeps response bodies in the webhook row
+type InlineBodyStore struct{}
+
+// Put returns the body unchanged so it is stored in the row
+func (InlineBodyStore) Put(_ context.Context, _ uuid.UUID, _ int, body string) (string, error) {
+	return body, nil
+}
+
+// Resolve returns the stored body unchanged
+func (InlineBodyStore) Resolve(_ context.Context, stored string) (string, error) {
+	return stored, nil
+}
+
+// Delete does nothing, as inline bodies are removed with their row
+func (InlineBodyStore) Delete(_ context.Context, _ []string) error {
+	return nil
+}
+
+// ObjectClient reads and writes objects in a bucket-like store such as S3 or GCS
+type ObjectClient interface {
+	PutObject(ctx context.Context, key string, data []byte) error
+	GetObject(ctx context.Context, key string) ([]byte, error)
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// ObjectBodyStore offloads response bodies to an object store and keeps only their key in the row
+type ObjectBodyStore struct {
+	client ObjectClient
+}
+
+// NewObjectBodyStore creates a body store that writes bodies through client
+func NewObjectBodyStore(client ObjectClient) *ObjectBodyStore {
+	return &ObjectBodyStore{client: client}
+}
+
+// Put writes the body under a key derived from the webhook's queue ID and retry level and returns a reference to it
+func (s *ObjectBodyStore) Put(ctx context.Context, queueID uuid.UUID, retryLevel int, body string) (string, error) {
+	key := fmt.Sprintf("webhooks/%s/attempt-%d", queueID, retryLevel)
+	if err := s.client.PutObject(ctx, key, []byte(body)); err != nil {
+		return "", fmt.Errorf("failed to offload response body to %s: %w", key, err)
+	}
+	return objectRefPrefix + key, nil
+}
+
+// Resolve fetches the body behind a reference; inline bodies are returned as-is
+func (s *ObjectBodyStore) Resolve(ctx context.Context, stored string) (string, error) {
+	key, isRef := strings.CutPrefix(stored, objectRefPrefix)
+	if !isRef {
+		return stored, nil
+	}
+	data, err := s.client.GetObject(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch response body %s: %w", key, err)
+	}
+	return string(data), nil
+}
+
+// Delete removes the objects behind references; inline bodies are skipped
+// Every object is attempted, so one failure does not leave the rest behind
+func (s *ObjectBodyStore) Delete(ctx context.Context, stored []string) error {
+	var errs []error
+	for _, value := range stored {
+		key, isRef := strings.CutPrefix(value, objectRefPrefix)
+		if !isRef {
+			continue
+		}
+		if err := s.client.DeleteObject(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete response body %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DirectoryObjectClient stores objects as files under a root directory,
+// such as a mounted bucket or a shared volume
+type DirectoryObjectClient struct {
+	root string
+}
+
+// NewDirectoryObjectClient creates an object client rooted at dir, creating it if needed
+func NewDirectoryObjectClient(dir string) (*DirectoryObjectClient, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("response body directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create response body directory: %w", err)
+	}
+	return &DirectoryObjectClient{root: dir}, nil
+}
+
+// PutObject writes data to the file for key
+func (c *DirectoryObjectClient) PutObject(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(c.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o640)
+}
+
+// GetObject reads the file for key
+func (c *DirectoryObjectClient) GetObject(_ context.Context, key string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(c.root, filepath.FromSlash(key)))
+}
+
+// DeleteObject removes the file for key; a file that is already gone is not an error
+func (c *DirectoryObjectClient) DeleteObject(_ context.Context, key string) error {
+	if err := os.Remove(filepath.Join(c.root, filepath.FromSlash(key))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"webhook-processor/internal/config"
+)
+
+// memoryObjectClient keeps objects in a map
+type memoryObjectClient struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newMemoryObjectClient() *memoryObjectClient {
+	return &memoryObjectClient{objects: map[string][]byte{}}
+}
+
+func (c *memoryObjectClient) PutObject(_ context.Context, key string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (c *memoryObjectClient) GetObject(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.objects[key]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+	return data, nil
+}
+
+func (c *memoryObjectClient) DeleteObject(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.objects, key)
+	return nil
+}
+
+func TestObjectBodyStore(t *testing.T) {
+	ctx := context.Background()
+	queueID := uuid.MustParse("6f1c2a4e-8b3d-4e5f-9a0b-1c2d3e4f5a6b")
+	otherQueueID := uuid.MustParse("0a9b8c7d-6e5f-4a3b-8c1d-0e9f8a7b6c5d")
+
+	t.Run("should offload a body and resolve its reference", func(t *testing.T) {
+		client := newMemoryObjectClient()
+		store := NewObjectBodyStore(client)
+
+		stored, err := store.Put(ctx, queueID, 3, `{"status":"accepted"}`)
+		require.NoError(t, err)
+
+		key := "webhooks/6f1c2a4e-8b3d-4e5f-9a0b-1c2d3e4f5a6b/attempt-3"
+		assert.Equal(t, "object:"+key, stored)
+		assert.Equal(t, []byte(`{"status":"accepted"}`), client.objects[key])
+
+		body, err := store.Resolve(ctx, stored)
+		require.NoError(t, err)
+		assert.Equal(t, `{"status":"accepted"}`, body)
+	})
+
+	t.Run("should return bodies stored inline before offloading as-is", func(t *testing.T) {
+		store := NewObjectBodyStore(newMemoryObjectClient())
+
+		body, err := store.Resolve(ctx, "ok")
+
+		require.NoError(t, err)
+		assert.Equal(t, "ok", body)
+	})
+
+	t.Run("should fail to resolve a missing object", func(t *testing.T) {
+		store := NewObjectBodyStore(newMemoryObjectClient())
+
+		_, err := store.Resolve(ctx, "object:webhooks/1/attempt-0")
+
+		assert.ErrorContains(t, err, "failed to fetch response body webhooks/1/attempt-0")
+	})
+
+	t.Run("should delete offloaded bodies and skip inline ones", func(t *testing.T) {
+		client := newMemoryObjectClient()
+		store := NewObjectBodyStore(client)
+		first, err := store.Put(ctx, queueID, 0, "service unavailable")
+		require.NoError(t, err)
+		second, err := store.Put(ctx, queueID, 1, "bad gateway")
+		require.NoError(t, err)
+		_, err = store.Put(ctx, otherQueueID, 0, "kept")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Delete(ctx, []string{first, "ok", second}))
+
+		assert.Equal(t, []string{"webhooks/0a9b8c7d-6e5f-4a3b-8c1d-0e9f8a7b6c5d/attempt-0"}, slices.Collect(maps.Keys(client.objects)))
+	})
+}
+
+func TestNewBodyStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep bodies inline by default", func(t *testing.T) {
+		store, err := NewBodyStore(config.DatabaseConfig{ResponseBodyStore: BodyStoreInline})
+		require.NoError(t, err)
+
+		stored, err := store.Put(ctx, uuid.New(), 0, "ok")
+		require.NoError(t, err)
+		assert.Equal(t, "ok", stored)
+	})
+
+	t.Run("should offload bodies to files under the directory", func(t *testing.T) {
+		store, err := NewBodyStore(config.DatabaseConfig{ResponseBodyStore: BodyStoreDirectory, ResponseBodyDir: t.TempDir()})
+		require.NoError(t, err)
+
+		queueID := uuid.New()
+		stored, err := store.Put(ctx, queueID, 1, "service unavailable")
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("object:webhooks/%s/attempt-1", queueID), stored)
+
+		body, err := store.Resolve(ctx, stored)
+		require.NoError(t, err)
+		assert.Equal(t, "service unavailable", body)
+
+		require.NoError(t, store.Delete(ctx, []string{stored}))
+		_, err = store.Resolve(ctx, stored)
+		assert.Error(t, err, "the file is removed")
+		assert.NoError(t, store.Delete(ctx, []string{stored}), "deleting it again is not an error")
+	})
+
+	t.Run("should reject an unknown store", func(t *testing.T) {
+		_, err := NewBodyStore(config.DatabaseConfig{ResponseBodyStore: "s3"})
+
+		assert.ErrorContains(t, err, `unknown response body store "s3"`)
+	})
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal\domain\services\failure_notifier.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal\domain\services\failure_notifier.go -destination internal\mocks\mock_failure_notifier.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "webhook-processor/internal/domain/entities"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFailureNotifier is a mock of FailureNotifier interface.
+type MockFailureNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockFailureNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockFailureNotifierMockRecorder is the mock recorder for MockFailureNotifier.
+type MockFailureNotifierMockRecorder struct {
+	mock *MockFailureNotifier
+}
+
+// NewMockFailureNotifier creates a new mock instance.
+func NewMockFailureNotifier(ctrl *gomock.Controller) *MockFailureNotifier {
+	mock := &MockFailureNotifier{ctrl: ctrl}
+	mock.recorder = &MockFailureNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFailureNotifier) EXPECT() *MockFailureNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyFailure mocks base method.
+func (m *MockFailureNotifier) NotifyFailure(ctx context.Context, notification *entities.FailureNotification) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyFailure", ctx, notification)
+}
+
+// NotifyFailure indicates an expected call of NotifyFailure.
+func (mr *MockFailureNotifierMockRecorder) NotifyFailure(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyFailure", reflect.TypeOf((*MockFailureNotifier)(nil).NotifyFailure), ctx, notification)
+}
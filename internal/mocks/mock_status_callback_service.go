@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal\domain\services\status_callback_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal\domain\services\status_callback_service.go -destination internal\mocks\mock_status_callback_service.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "webhook-processor/internal/domain/entities"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatusCallbackService is a mock of StatusCallbackService interface.
+type MockStatusCallbackService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusCallbackServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusCallbackServiceMockRecorder is the mock recorder for MockStatusCallbackService.
+type MockStatusCallbackServiceMockRecorder struct {
+	mock *MockStatusCallbackService
+}
+
+// NewMockStatusCallbackService creates a new mock instance.
+func NewMockStatusCallbackService(ctrl *gomock.Controller) *MockStatusCallbackService {
+	mock := &MockStatusCallbackService{ctrl: ctrl}
+	mock.recorder = &MockStatusCallbackServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusCallbackService) EXPECT() *MockStatusCallbackServiceMockRecorder {
+	return m.recorder
+}
+
+// SendStatusCallback mocks base method.
+func (m *MockStatusCallbackService) SendStatusCallback(ctx context.Context, url string, callback *entities.StatusCallback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendStatusCallback", ctx, url, callback)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendStatusCallback indicates an expected call of SendStatusCallback.
+func (mr *MockStatusCallbackServiceMockRecorder) SendStatusCallback(ctx, url, callback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendStatusCallback", reflect.TypeOf((*MockStatusCallbackService)(nil).SendStatusCallback), ctx, url, callback)
+}
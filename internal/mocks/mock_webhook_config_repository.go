@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 	entities "webhook-processor/internal/domain/entities"
+	enums "webhook-processor/internal/domain/enums"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,50 @@ func (m *MockWebhookConfigRepository) EXPECT() *MockWebhookConfigRepositoryMockR
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockWebhookConfigRepository) Create(ctx context.Context, config *entities.WebhookConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookConfigRepositoryMockRecorder) Create(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookConfigRepository)(nil).Create), ctx, config)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookConfigRepository) Delete(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookConfigRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookConfigRepository)(nil).Delete), ctx, id)
+}
+
+// GetActiveByEventType mocks base method.
+func (m *MockWebhookConfigRepository) GetActiveByEventType(ctx context.Context, eventType enums.EventType) ([]*entities.WebhookConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByEventType", ctx, eventType)
+	ret0, _ := ret[0].([]*entities.WebhookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByEventType indicates an expected call of GetActiveByEventType.
+func (mr *MockWebhookConfigRepositoryMockRecorder) GetActiveByEventType(ctx, eventType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByEventType", reflect.TypeOf((*MockWebhookConfigRepository)(nil).GetActiveByEventType), ctx, eventType)
+}
+
 // GetByID mocks base method.
 func (m *MockWebhookConfigRepository) GetByID(ctx context.Context, id int64) (*entities.WebhookConfig, error) {
 	m.ctrl.T.Helper()
@@ -55,3 +100,78 @@ func (mr *MockWebhookConfigRepositoryMockRecorder) GetByID(ctx, id any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookConfigRepository)(nil).GetByID), ctx, id)
 }
+
+// GetDefaultForEventType mocks base method.
+func (m *MockWebhookConfigRepository) GetDefaultForEventType(ctx context.Context, eventType enums.EventType) (*entities.WebhookConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultForEventType", ctx, eventType)
+	ret0, _ := ret[0].(*entities.WebhookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultForEventType indicates an expected call of GetDefaultForEventType.
+func (mr *MockWebhookConfigRepositoryMockRecorder) GetDefaultForEventType(ctx, eventType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultForEventType", reflect.TypeOf((*MockWebhookConfigRepository)(nil).GetDefaultForEventType), ctx, eventType)
+}
+
+// GetStats mocks base method.
+func (m *MockWebhookConfigRepository) GetStats(ctx context.Context, configID int64) (*entities.WebhookConfigStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, configID)
+	ret0, _ := ret[0].(*entities.WebhookConfigStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockWebhookConfigRepositoryMockRecorder) GetStats(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockWebhookConfigRepository)(nil).GetStats), ctx, configID)
+}
+
+// List mocks base method.
+func (m *MockWebhookConfigRepository) List(ctx context.Context) ([]*entities.WebhookConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entities.WebhookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookConfigRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookConfigRepository)(nil).List), ctx)
+}
+
+// ListActive mocks base method.
+func (m *MockWebhookConfigRepository) ListActive(ctx context.Context) ([]*entities.WebhookConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx)
+	ret0, _ := ret[0].([]*entities.WebhookConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockWebhookConfigRepositoryMockRecorder) ListActive(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockWebhookConfigRepository)(nil).ListActive), ctx)
+}
+
+// Update mocks base method.
+func (m *MockWebhookConfigRepository) Update(ctx context.Context, config *entities.WebhookConfig) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, config)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookConfigRepositoryMockRecorder) Update(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookConfigRepository)(nil).Update), ctx, config)
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal\application\usecases\webhook_processor_iface.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal\application\usecases\webhook_processor_iface.go -destination internal\mocks\mock_webhook_processor.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "webhook-processor/internal/domain/entities"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookProcessorIface is a mock of WebhookProcessorIface interface.
+type MockWebhookProcessorIface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookProcessorIfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookProcessorIfaceMockRecorder is the mock recorder for MockWebhookProcessorIface.
+type MockWebhookProcessorIfaceMockRecorder struct {
+	mock *MockWebhookProcessorIface
+}
+
+// NewMockWebhookProcessorIface creates a new mock instance.
+func NewMockWebhookProcessorIface(ctrl *gomock.Controller) *MockWebhookProcessorIface {
+	mock := &MockWebhookProcessorIface{ctrl: ctrl}
+	mock.recorder = &MockWebhookProcessorIfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookProcessorIface) EXPECT() *MockWebhookProcessorIfaceMockRecorder {
+	return m.recorder
+}
+
+// GetNextWebhookForProcessing mocks base method.
+func (m *MockWebhookProcessorIface) GetNextWebhookForProcessing(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextWebhookForProcessing", ctx, workerID, retryLevel)
+	ret0, _ := ret[0].(*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextWebhookForProcessing indicates an expected call of GetNextWebhookForProcessing.
+func (mr *MockWebhookProcessorIfaceMockRecorder) GetNextWebhookForProcessing(ctx, workerID, retryLevel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextWebhookForProcessing", reflect.TypeOf((*MockWebhookProcessorIface)(nil).GetNextWebhookForProcessing), ctx, workerID, retryLevel)
+}
+
+// ProcessWebhook mocks base method.
+func (m *MockWebhookProcessorIface) ProcessWebhook(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessWebhook", ctx, webhook, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessWebhook indicates an expected call of ProcessWebhook.
+func (mr *MockWebhookProcessorIfaceMockRecorder) ProcessWebhook(ctx, webhook, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessWebhook", reflect.TypeOf((*MockWebhookProcessorIface)(nil).ProcessWebhook), ctx, webhook, workerID)
+}
+
+// RecordWorkerHeartbeat mocks base method.
+func (m *MockWebhookProcessorIface) RecordWorkerHeartbeat(ctx context.Context, heartbeat *entities.WorkerHeartbeat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWorkerHeartbeat", ctx, heartbeat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWorkerHeartbeat indicates an expected call of RecordWorkerHeartbeat.
+func (mr *MockWebhookProcessorIfaceMockRecorder) RecordWorkerHeartbeat(ctx, heartbeat any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWorkerHeartbeat", reflect.TypeOf((*MockWebhookProcessorIface)(nil).RecordWorkerHeartbeat), ctx, heartbeat)
+}
+
+// RemoveWorkerHeartbeat mocks base method.
+func (m *MockWebhookProcessorIface) RemoveWorkerHeartbeat(ctx context.Context, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWorkerHeartbeat", ctx, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveWorkerHeartbeat indicates an expected call of RemoveWorkerHeartbeat.
+func (mr *MockWebhookProcessorIfaceMockRecorder) RemoveWorkerHeartbeat(ctx, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWorkerHeartbeat", reflect.TypeOf((*MockWebhookProcessorIface)(nil).RemoveWorkerHeartbeat), ctx, workerID)
+}
+
+// ResetWebhookToPending mocks base method.
+func (m *MockWebhookProcessorIface) ResetWebhookToPending(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetWebhookToPending", ctx, webhook, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetWebhookToPending indicates an expected call of ResetWebhookToPending.
+func (mr *MockWebhookProcessorIfaceMockRecorder) ResetWebhookToPending(ctx, webhook, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetWebhookToPending", reflect.TypeOf((*MockWebhookProcessorIface)(nil).ResetWebhookToPending), ctx, webhook, workerID)
+}
@@ -14,7 +14,10 @@ import (
 	reflect "reflect"
 	time "time"
 	entities "webhook-processor/internal/domain/entities"
+	enums "webhook-processor/internal/domain/enums"
+	repositories "webhook-processor/internal/domain/repositories"
 
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,6 +45,51 @@ func (m *MockWebhookQueueRepository) EXPECT() *MockWebhookQueueRepositoryMockRec
 	return m.recorder
 }
 
+// BulkUpdateStatus mocks base method.
+func (m *MockWebhookQueueRepository) BulkUpdateStatus(ctx context.Context, filter repositories.WebhookQueueFilter, newStatus enums.WebhookStatus, reason string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateStatus", ctx, filter, newStatus, reason)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateStatus indicates an expected call of BulkUpdateStatus.
+func (mr *MockWebhookQueueRepositoryMockRecorder) BulkUpdateStatus(ctx, filter, newStatus, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateStatus", reflect.TypeOf((*MockWebhookQueueRepository)(nil).BulkUpdateStatus), ctx, filter, newStatus, reason)
+}
+
+// ClaimByQueueID mocks base method.
+func (m *MockWebhookQueueRepository) ClaimByQueueID(ctx context.Context, queueID uuid.UUID, workerID string) (*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimByQueueID", ctx, queueID, workerID)
+	ret0, _ := ret[0].(*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimByQueueID indicates an expected call of ClaimByQueueID.
+func (mr *MockWebhookQueueRepositoryMockRecorder) ClaimByQueueID(ctx, queueID, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimByQueueID", reflect.TypeOf((*MockWebhookQueueRepository)(nil).ClaimByQueueID), ctx, queueID, workerID)
+}
+
+// CountByStatus mocks base method.
+func (m *MockWebhookQueueRepository) CountByStatus(ctx context.Context, status enums.WebhookStatus) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStatus", ctx, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStatus indicates an expected call of CountByStatus.
+func (mr *MockWebhookQueueRepositoryMockRecorder) CountByStatus(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStatus", reflect.TypeOf((*MockWebhookQueueRepository)(nil).CountByStatus), ctx, status)
+}
+
 // Create mocks base method.
 func (m *MockWebhookQueueRepository) Create(ctx context.Context, webhook *entities.WebhookQueue) error {
 	m.ctrl.T.Helper()
@@ -56,6 +104,109 @@ func (mr *MockWebhookQueueRepositoryMockRecorder) Create(ctx, webhook any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookQueueRepository)(nil).Create), ctx, webhook)
 }
 
+// CreateBatch mocks base method.
+func (m *MockWebhookQueueRepository) CreateBatch(ctx context.Context, webhooks []*entities.WebhookQueue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, webhooks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockWebhookQueueRepositoryMockRecorder) CreateBatch(ctx, webhooks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockWebhookQueueRepository)(nil).CreateBatch), ctx, webhooks)
+}
+
+// ExtendClaim mocks base method.
+func (m *MockWebhookQueueRepository) ExtendClaim(ctx context.Context, webhookID int64, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendClaim", ctx, webhookID, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendClaim indicates an expected call of ExtendClaim.
+func (mr *MockWebhookQueueRepositoryMockRecorder) ExtendClaim(ctx, webhookID, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendClaim", reflect.TypeOf((*MockWebhookQueueRepository)(nil).ExtendClaim), ctx, webhookID, workerID)
+}
+
+// FindCompletedWithoutSuccess mocks base method.
+func (m *MockWebhookQueueRepository) FindCompletedWithoutSuccess(ctx context.Context, since time.Time, limit int) ([]*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCompletedWithoutSuccess", ctx, since, limit)
+	ret0, _ := ret[0].([]*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCompletedWithoutSuccess indicates an expected call of FindCompletedWithoutSuccess.
+func (mr *MockWebhookQueueRepositoryMockRecorder) FindCompletedWithoutSuccess(ctx, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCompletedWithoutSuccess", reflect.TypeOf((*MockWebhookQueueRepository)(nil).FindCompletedWithoutSuccess), ctx, since, limit)
+}
+
+// FindOldestOverdue mocks base method.
+func (m *MockWebhookQueueRepository) FindOldestOverdue(ctx context.Context, asOf time.Time) (*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOldestOverdue", ctx, asOf)
+	ret0, _ := ret[0].(*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOldestOverdue indicates an expected call of FindOldestOverdue.
+func (mr *MockWebhookQueueRepositoryMockRecorder) FindOldestOverdue(ctx, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOldestOverdue", reflect.TypeOf((*MockWebhookQueueRepository)(nil).FindOldestOverdue), ctx, asOf)
+}
+
+// ForceFail mocks base method.
+func (m *MockWebhookQueueRepository) ForceFail(ctx context.Context, queueID uuid.UUID, reason string) (enums.WebhookStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceFail", ctx, queueID, reason)
+	ret0, _ := ret[0].(enums.WebhookStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceFail indicates an expected call of ForceFail.
+func (mr *MockWebhookQueueRepositoryMockRecorder) ForceFail(ctx, queueID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceFail", reflect.TypeOf((*MockWebhookQueueRepository)(nil).ForceFail), ctx, queueID, reason)
+}
+
+// GetByEventID mocks base method.
+func (m *MockWebhookQueueRepository) GetByEventID(ctx context.Context, eventID string) ([]*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEventID", ctx, eventID)
+	ret0, _ := ret[0].([]*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEventID indicates an expected call of GetByEventID.
+func (mr *MockWebhookQueueRepositoryMockRecorder) GetByEventID(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEventID", reflect.TypeOf((*MockWebhookQueueRepository)(nil).GetByEventID), ctx, eventID)
+}
+
+// GetByQueueID mocks base method.
+func (m *MockWebhookQueueRepository) GetByQueueID(ctx context.Context, queueID uuid.UUID) (*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByQueueID", ctx, queueID)
+	ret0, _ := ret[0].(*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByQueueID indicates an expected call of GetByQueueID.
+func (mr *MockWebhookQueueRepositoryMockRecorder) GetByQueueID(ctx, queueID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByQueueID", reflect.TypeOf((*MockWebhookQueueRepository)(nil).GetByQueueID), ctx, queueID)
+}
+
 // GetNextWebhookForProcessing mocks base method.
 func (m *MockWebhookQueueRepository) GetNextWebhookForProcessing(ctx context.Context, workerID string, retryLevel int) (*entities.WebhookQueue, error) {
 	m.ctrl.T.Helper()
@@ -71,58 +222,149 @@ func (mr *MockWebhookQueueRepositoryMockRecorder) GetNextWebhookForProcessing(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextWebhookForProcessing", reflect.TypeOf((*MockWebhookQueueRepository)(nil).GetNextWebhookForProcessing), ctx, workerID, retryLevel)
 }
 
+// HardDelete mocks base method.
+func (m *MockWebhookQueueRepository) HardDelete(ctx context.Context, queueID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDelete", ctx, queueID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HardDelete indicates an expected call of HardDelete.
+func (mr *MockWebhookQueueRepositoryMockRecorder) HardDelete(ctx, queueID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDelete", reflect.TypeOf((*MockWebhookQueueRepository)(nil).HardDelete), ctx, queueID)
+}
+
+// List mocks base method.
+func (m *MockWebhookQueueRepository) List(ctx context.Context, filter repositories.WebhookQueueFilter, limit int) ([]*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, limit)
+	ret0, _ := ret[0].([]*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookQueueRepositoryMockRecorder) List(ctx, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookQueueRepository)(nil).List), ctx, filter, limit)
+}
+
+// ListAfter mocks base method.
+func (m *MockWebhookQueueRepository) ListAfter(ctx context.Context, filter repositories.WebhookQueueFilter, afterID int64, limit int) ([]*entities.WebhookQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAfter", ctx, filter, afterID, limit)
+	ret0, _ := ret[0].([]*entities.WebhookQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAfter indicates an expected call of ListAfter.
+func (mr *MockWebhookQueueRepositoryMockRecorder) ListAfter(ctx, filter, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAfter", reflect.TypeOf((*MockWebhookQueueRepository)(nil).ListAfter), ctx, filter, afterID, limit)
+}
+
+// ListPage mocks base method.
+func (m *MockWebhookQueueRepository) ListPage(ctx context.Context, filter repositories.WebhookQueueFilter, limit, offset int) ([]*entities.WebhookQueue, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*entities.WebhookQueue)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockWebhookQueueRepositoryMockRecorder) ListPage(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockWebhookQueueRepository)(nil).ListPage), ctx, filter, limit, offset)
+}
+
 // MarkCompleted mocks base method.
-func (m *MockWebhookQueueRepository) MarkCompleted(ctx context.Context, webhookID int64, processingStartedAt time.Time) error {
+func (m *MockWebhookQueueRepository) MarkCompleted(ctx context.Context, webhookID int64, workerID string, processingStartedAt time.Time, lastHTTPStatus int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkCompleted", ctx, webhookID, processingStartedAt)
+	ret := m.ctrl.Call(m, "MarkCompleted", ctx, webhookID, workerID, processingStartedAt, lastHTTPStatus)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkCompleted indicates an expected call of MarkCompleted.
-func (mr *MockWebhookQueueRepositoryMockRecorder) MarkCompleted(ctx, webhookID, processingStartedAt any) *gomock.Call {
+func (mr *MockWebhookQueueRepositoryMockRecorder) MarkCompleted(ctx, webhookID, workerID, processingStartedAt, lastHTTPStatus any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCompleted", reflect.TypeOf((*MockWebhookQueueRepository)(nil).MarkCompleted), ctx, webhookID, processingStartedAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCompleted", reflect.TypeOf((*MockWebhookQueueRepository)(nil).MarkCompleted), ctx, webhookID, workerID, processingStartedAt, lastHTTPStatus)
 }
 
 // MarkFailed mocks base method.
-func (m *MockWebhookQueueRepository) MarkFailed(ctx context.Context, webhookID int64, errorMsg string) error {
+func (m *MockWebhookQueueRepository) MarkFailed(ctx context.Context, webhookID int64, workerID, errorMsg string, lastHTTPStatus int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkFailed", ctx, webhookID, errorMsg)
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, webhookID, workerID, errorMsg, lastHTTPStatus)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkFailed indicates an expected call of MarkFailed.
-func (mr *MockWebhookQueueRepositoryMockRecorder) MarkFailed(ctx, webhookID, errorMsg any) *gomock.Call {
+func (mr *MockWebhookQueueRepositoryMockRecorder) MarkFailed(ctx, webhookID, workerID, errorMsg, lastHTTPStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockWebhookQueueRepository)(nil).MarkFailed), ctx, webhookID, workerID, errorMsg, lastHTTPStatus)
+}
+
+// ReclaimExpiredClaims mocks base method.
+func (m *MockWebhookQueueRepository) ReclaimExpiredClaims(ctx context.Context, asOf time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReclaimExpiredClaims", ctx, asOf)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReclaimExpiredClaims indicates an expected call of ReclaimExpiredClaims.
+func (mr *MockWebhookQueueRepositoryMockRecorder) ReclaimExpiredClaims(ctx, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReclaimExpiredClaims", reflect.TypeOf((*MockWebhookQueueRepository)(nil).ReclaimExpiredClaims), ctx, asOf)
+}
+
+// Restore mocks base method.
+func (m *MockWebhookQueueRepository) Restore(ctx context.Context, webhook *entities.WebhookQueue) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, webhook)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockWebhookQueueRepositoryMockRecorder) Restore(ctx, webhook any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockWebhookQueueRepository)(nil).MarkFailed), ctx, webhookID, errorMsg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockWebhookQueueRepository)(nil).Restore), ctx, webhook)
 }
 
 // Update mocks base method.
-func (m *MockWebhookQueueRepository) Update(ctx context.Context, webhook *entities.WebhookQueue) error {
+func (m *MockWebhookQueueRepository) Update(ctx context.Context, webhook *entities.WebhookQueue, workerID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, webhook)
+	ret := m.ctrl.Call(m, "Update", ctx, webhook, workerID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockWebhookQueueRepositoryMockRecorder) Update(ctx, webhook any) *gomock.Call {
+func (mr *MockWebhookQueueRepositoryMockRecorder) Update(ctx, webhook, workerID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookQueueRepository)(nil).Update), ctx, webhook)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookQueueRepository)(nil).Update), ctx, webhook, workerID)
 }
 
 // UpdateRetryAttempt mocks base method.
-func (m *MockWebhookQueueRepository) UpdateRetryAttempt(ctx context.Context, webhookID int64, retryLevel int, startedAt time.Time, completedAt *time.Time, durationMs int64, httpStatus int, responseBody, errorMsg string) error {
+func (m *MockWebhookQueueRepository) UpdateRetryAttempt(ctx context.Context, webhookID int64, workerID string, retryLevel int, startedAt time.Time, completedAt *time.Time, durationMs, timeoutMs int64, httpStatus int, responseBody, errorMsg string, errorClass enums.ErrorClass, request *entities.AttemptRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRetryAttempt", ctx, webhookID, retryLevel, startedAt, completedAt, durationMs, httpStatus, responseBody, errorMsg)
+	ret := m.ctrl.Call(m, "UpdateRetryAttempt", ctx, webhookID, workerID, retryLevel, startedAt, completedAt, durationMs, timeoutMs, httpStatus, responseBody, errorMsg, errorClass, request)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateRetryAttempt indicates an expected call of UpdateRetryAttempt.
-func (mr *MockWebhookQueueRepositoryMockRecorder) UpdateRetryAttempt(ctx, webhookID, retryLevel, startedAt, completedAt, durationMs, httpStatus, responseBody, errorMsg any) *gomock.Call {
+func (mr *MockWebhookQueueRepositoryMockRecorder) UpdateRetryAttempt(ctx, webhookID, workerID, retryLevel, startedAt, completedAt, durationMs, timeoutMs, httpStatus, responseBody, errorMsg, errorClass, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetryAttempt", reflect.TypeOf((*MockWebhookQueueRepository)(nil).UpdateRetryAttempt), ctx, webhookID, retryLevel, startedAt, completedAt, durationMs, httpStatus, responseBody, errorMsg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetryAttempt", reflect.TypeOf((*MockWebhookQueueRepository)(nil).UpdateRetryAttempt), ctx, webhookID, workerID, retryLevel, startedAt, completedAt, durationMs, timeoutMs, httpStatus, responseBody, errorMsg, errorClass, request)
 }
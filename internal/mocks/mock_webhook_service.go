@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	entities "webhook-processor/internal/domain/entities"
 	services "webhook-processor/internal/domain/services"
 
@@ -42,6 +43,21 @@ func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
 	return m.recorder
 }
 
+// Ping mocks base method.
+func (m *MockWebhookService) Ping(ctx context.Context, webhookURL string, timeout time.Duration) (*services.PingResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx, webhookURL, timeout)
+	ret0, _ := ret[0].(*services.PingResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockWebhookServiceMockRecorder) Ping(ctx, webhookURL, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockWebhookService)(nil).Ping), ctx, webhookURL, timeout)
+}
+
 // SendWebhook mocks base method.
 func (m *MockWebhookService) SendWebhook(ctx context.Context, webhook *entities.WebhookQueue) (*services.WebhookResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal\domain\repositories\worker_heartbeat_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal\domain\repositories\worker_heartbeat_repository.go -destination internal\mocks\mock_worker_heartbeat_repository.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "webhook-processor/internal/domain/entities"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWorkerHeartbeatRepository is a mock of WorkerHeartbeatRepository interface.
+type MockWorkerHeartbeatRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkerHeartbeatRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkerHeartbeatRepositoryMockRecorder is the mock recorder for MockWorkerHeartbeatRepository.
+type MockWorkerHeartbeatRepositoryMockRecorder struct {
+	mock *MockWorkerHeartbeatRepository
+}
+
+// NewMockWorkerHeartbeatRepository creates a new mock instance.
+func NewMockWorkerHeartbeatRepository(ctrl *gomock.Controller) *MockWorkerHeartbeatRepository {
+	mock := &MockWorkerHeartbeatRepository{ctrl: ctrl}
+	mock.recorder = &MockWorkerHeartbeatRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkerHeartbeatRepository) EXPECT() *MockWorkerHeartbeatRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWorkerHeartbeatRepository) Delete(ctx context.Context, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWorkerHeartbeatRepositoryMockRecorder) Delete(ctx, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWorkerHeartbeatRepository)(nil).Delete), ctx, workerID)
+}
+
+// List mocks base method.
+func (m *MockWorkerHeartbeatRepository) List(ctx context.Context) ([]*entities.WorkerHeartbeat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entities.WorkerHeartbeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWorkerHeartbeatRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWorkerHeartbeatRepository)(nil).List), ctx)
+}
+
+// Upsert mocks base method.
+func (m *MockWorkerHeartbeatRepository) Upsert(ctx context.Context, heartbeat *entities.WorkerHeartbeat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, heartbeat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockWorkerHeartbeatRepositoryMockRecorder) Upsert(ctx, heartbeat any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockWorkerHeartbeatRepository)(nil).Upsert), ctx, heartbeat)
+}
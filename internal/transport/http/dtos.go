@@ -1,9 +1,13 @@
 package http
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
+
 	"webhook-processor/internal/application/services"
+	"webhook-processor/internal/config"
 	"webhook-processor/internal/domain/enums"
 )
 
@@ -12,9 +16,11 @@ import (
 
 // CreateWebhookRequest represents an HTTP request to create a webhook
 type CreateWebhookRequest struct {
-	EventType enums.EventType `json:"event_type" validate:"required"`
-	EventID   string          `json:"event_id"`
-	ConfigID  int64           `json:"config_id" validate:"required,min=1"`
+	EventType enums.EventType   `json:"event_type" validate:"required"`
+	EventID   string            `json:"event_id"`
+	ConfigID  int64             `json:"config_id" validate:"omitempty,min=1"` // Omit to use the event type's default config
+	Metadata  map[string]string `json:"metadata,omitempty"`                   // Labels such as tenant or source
+	Payload   json.RawMessage   `json:"payload,omitempty"`                    // JSON body delivered by configs that send one
 }
 
 // CreateWebhookResponse represents an HTTP response after creating a webhook
@@ -31,7 +37,191 @@ type HealthResponse struct {
 	Version      string            `json:"version"`
 	Timestamp    string            `json:"timestamp"` // ISO 8601 string for HTTP
 	Dependencies map[string]string `json:"dependencies"`
-	Uptime       string            `json:"uptime"` // Duration string for HTTP
+	Uptime       string            `json:"uptime"`      // Duration string for HTTP
+	BacklogAge   string            `json:"backlog_age"` // Duration string for HTTP
+}
+
+// DebugConfigResponse represents HTTP response for the effective (redacted) configuration
+type DebugConfigResponse struct {
+	Config config.Config `json:"config"`
+}
+
+// BulkUpdateStatusRequest represents an HTTP request to move matching webhooks to a new status
+type BulkUpdateStatusRequest struct {
+	ConfigID      *int64                `json:"config_id,omitempty"`
+	Statuses      []enums.WebhookStatus `json:"statuses,omitempty"`
+	CreatedBefore *time.Time            `json:"created_before,omitempty"` // ISO 8601
+	Metadata      map[string]string     `json:"metadata,omitempty"`
+	Status        enums.WebhookStatus   `json:"status" validate:"required"`
+	Reason        string                `json:"reason" validate:"required"`
+}
+
+// BulkUpdateStatusResponse represents an HTTP response after a bulk status update
+type BulkUpdateStatusResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Updated int64  `json:"updated"`
+}
+
+// ForceFailWebhookRequest represents an HTTP request to mark one webhook as failed
+type ForceFailWebhookRequest struct {
+	QueueID uuid.UUID `json:"-"` // Taken from the request path
+	Reason  string    `json:"reason" validate:"required"`
+}
+
+// ForceFailWebhookResponse represents an HTTP response after force-failing a webhook
+type ForceFailWebhookResponse struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	QueueID string              `json:"queue_id"`
+	Status  enums.WebhookStatus `json:"status,omitempty"`
+}
+
+// ProcessWebhookRequest represents an HTTP request to run one delivery attempt immediately
+type ProcessWebhookRequest struct {
+	QueueID uuid.UUID `json:"queue_id"`
+}
+
+// ProcessWebhookResponse represents an HTTP response with a webhook's state after an on-demand attempt
+type ProcessWebhookResponse struct {
+	Success        bool                `json:"success"`
+	Message        string              `json:"message"`
+	QueueID        string              `json:"queue_id"`
+	Status         enums.WebhookStatus `json:"status,omitempty"`
+	RetryCount     int                 `json:"retry_count"`
+	NextRetryAt    string              `json:"next_retry_at,omitempty"` // ISO 8601
+	LastHTTPStatus int                 `json:"last_http_status,omitempty"`
+	LastError      string              `json:"last_error,omitempty"`
+}
+
+// DeleteWebhookRequest represents an HTTP request to permanently delete a webhook
+type DeleteWebhookRequest struct {
+	QueueID uuid.UUID `json:"queue_id"`
+}
+
+// DeleteWebhookResponse represents an HTTP response after permanently deleting a webhook
+type DeleteWebhookResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	QueueID string `json:"queue_id"`
+}
+
+// GetConfigStatsRequest represents an HTTP request for a config's delivery statistics
+type GetConfigStatsRequest struct {
+	ConfigID int64 `json:"config_id"`
+}
+
+// SaveConfigRequest represents an HTTP request to create a webhook config or replace an existing one
+type SaveConfigRequest struct {
+	ID         int64           `json:"-"` // Taken from the request path on update
+	Name       string          `json:"name" validate:"required"`
+	EventType  enums.EventType `json:"event_type" validate:"required"`
+	WebhookURL string          `json:"webhook_url" validate:"required"`
+	TimeoutMs  int             `json:"timeout_ms" validate:"required,min=1"`
+	IsActive   *bool           `json:"is_active,omitempty"` // Omit to make the config active
+
+	Validate         bool `json:"-"` // ?validate=true pings the URL and reports the result
+	RequireReachable bool `json:"-"` // ?require_reachable=true refuses to save when the URL does not answer
+}
+
+// ConfigResponse represents an HTTP response with a webhook config
+type ConfigResponse struct {
+	services.ConfigResult
+}
+
+// DeleteConfigRequest represents an HTTP request to delete a webhook config
+type DeleteConfigRequest struct {
+	ConfigID int64 `json:"config_id"`
+}
+
+// DeleteConfigResponse represents an HTTP response after deleting a webhook config
+type DeleteConfigResponse struct {
+	services.DeleteConfigResult
+}
+
+// ConfigListResponse represents an HTTP response with every webhook config that has not been deleted
+type ConfigListResponse struct {
+	services.ConfigListResult
+}
+
+// GetEventStatusRequest represents an HTTP request for the aggregated delivery status of an event
+type GetEventStatusRequest struct {
+	EventID string `json:"event_id"`
+}
+
+// EventStatusResponse represents an HTTP response with an event's delivery status across its configs
+type EventStatusResponse struct {
+	services.EventStatusResult
+}
+
+// ConfigStatsResponse represents an HTTP response with a config's delivery statistics
+type ConfigStatsResponse struct {
+	ConfigID       int64      `json:"config_id"`
+	TotalDelivered int64      `json:"total_delivered"`
+	TotalFailed    int64      `json:"total_failed"`
+	LastSuccessAt  *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt  *time.Time `json:"last_failure_at,omitempty"`
+	SuccessRate    float64    `json:"success_rate"`
+}
+
+// GetWebhookStatusRequest represents an HTTP request for one webhook's status
+type GetWebhookStatusRequest struct {
+	QueueID uuid.UUID `json:"queue_id"`
+}
+
+// WebhookStatusResponse represents an HTTP response with a webhook's status and attempt timings
+type WebhookStatusResponse struct {
+	services.WebhookStatusResult
+}
+
+// ListWebhooksRequest represents an HTTP request for one page of webhooks, decoded from the query string
+type ListWebhooksRequest struct {
+	Status        enums.WebhookStatus `json:"status,omitempty"`
+	EventType     enums.EventType     `json:"event_type,omitempty"`
+	ConfigID      *int64              `json:"config_id,omitempty"`
+	CreatedAfter  *time.Time          `json:"created_after,omitempty"`
+	CreatedBefore *time.Time          `json:"created_before,omitempty"`
+	Limit         int                 `json:"limit,omitempty"`
+	Offset        int                 `json:"offset,omitempty"`
+}
+
+// WebhookListResponse represents an HTTP response with one page of webhooks and its pagination
+type WebhookListResponse struct {
+	services.WebhookListResult
+}
+
+// ExportWebhookRequest represents an HTTP request to export a webhook's lifecycle
+type ExportWebhookRequest struct {
+	QueueID uuid.UUID `json:"queue_id"`
+}
+
+// WebhookExportResponse represents HTTP response with a webhook's full lifecycle (secrets redacted)
+type WebhookExportResponse struct {
+	services.WebhookExportResult
+}
+
+// WorkerClusterResponse represents an HTTP response with the workers alive across instances
+type WorkerClusterResponse struct {
+	services.WorkerClusterResult
+}
+
+// ReplayToRequest represents an HTTP request to re-send matching webhooks to a different URL
+type ReplayToRequest struct {
+	ConfigID      *int64                `json:"config_id,omitempty"`
+	Statuses      []enums.WebhookStatus `json:"statuses,omitempty"`
+	CreatedBefore *time.Time            `json:"created_before,omitempty"` // ISO 8601
+	Metadata      map[string]string     `json:"metadata,omitempty"`
+	URL           string                `json:"url" validate:"required"`
+}
+
+// ReplayToResponse represents an HTTP response with the outcome of each replayed webhook
+type ReplayToResponse struct {
+	services.ReplayToResult
+}
+
+// ProcessingWebhooksResponse represents an HTTP response with the webhooks currently in PROCESSING
+type ProcessingWebhooksResponse struct {
+	services.ProcessingWebhooksResult
 }
 
 // Conversion functions between HTTP DTOs and Application DTOs
@@ -42,6 +232,8 @@ func (r CreateWebhookRequest) ToApplicationCommand() services.CreateWebhookComma
 		EventType: r.EventType,
 		EventID:   r.EventID,
 		ConfigID:  r.ConfigID,
+		Metadata:  r.Metadata,
+		Payload:   r.Payload,
 	}
 }
 
@@ -62,4 +254,160 @@ func (r *HealthResponse) FromApplicationResult(result *services.HealthResult) {
 	r.Timestamp = result.Timestamp.Format(time.RFC3339)
 	r.Dependencies = result.Dependencies
 	r.Uptime = result.Uptime.String()
+	r.BacklogAge = result.BacklogAge.String()
+}
+
+// ToApplicationCommand converts HTTP request to application command
+func (r BulkUpdateStatusRequest) ToApplicationCommand() services.BulkUpdateStatusCommand {
+	return services.BulkUpdateStatusCommand{
+		ConfigID:      r.ConfigID,
+		Statuses:      r.Statuses,
+		CreatedBefore: r.CreatedBefore,
+		Metadata:      r.Metadata,
+		Status:        r.Status,
+		Reason:        r.Reason,
+	}
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *BulkUpdateStatusResponse) FromApplicationResult(result *services.BulkUpdateStatusResult) {
+	r.Success = result.Success
+	r.Message = result.Message
+	r.Updated = result.Updated
+}
+
+// ToApplicationCommand converts HTTP request to application command
+func (r ForceFailWebhookRequest) ToApplicationCommand() services.ForceFailWebhookCommand {
+	return services.ForceFailWebhookCommand{
+		QueueID: r.QueueID,
+		Reason:  r.Reason,
+	}
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *ForceFailWebhookResponse) FromApplicationResult(result *services.ForceFailWebhookResult) {
+	r.Success = result.Success
+	r.Message = result.Message
+	r.QueueID = result.QueueID
+	r.Status = result.Status
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *ProcessWebhookResponse) FromApplicationResult(result *services.ProcessWebhookResult) {
+	r.Success = result.Success
+	r.Message = result.Message
+	r.QueueID = result.QueueID
+	r.Status = result.Status
+	r.RetryCount = result.RetryCount
+	if result.NextRetryAt != nil {
+		r.NextRetryAt = result.NextRetryAt.Format(time.RFC3339)
+	}
+	r.LastHTTPStatus = result.LastHTTPStatus
+	r.LastError = result.LastError
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *DeleteWebhookResponse) FromApplicationResult(result *services.DeleteWebhookResult) {
+	r.Success = result.Success
+	r.Message = result.Message
+	r.QueueID = result.QueueID
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *EventStatusResponse) FromApplicationResult(result *services.EventStatusResult) {
+	r.EventStatusResult = *result
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *ConfigStatsResponse) FromApplicationResult(result *services.ConfigStatsResult) {
+	r.ConfigID = result.ConfigID
+	r.TotalDelivered = result.TotalDelivered
+	r.TotalFailed = result.TotalFailed
+	r.LastSuccessAt = result.LastSuccessAt
+	r.LastFailureAt = result.LastFailureAt
+	r.SuccessRate = result.SuccessRate
+}
+
+// ToApplicationCommand converts HTTP request to application command
+func (r SaveConfigRequest) ToApplicationCommand() services.SaveConfigCommand {
+	return services.SaveConfigCommand{
+		ID:         r.ID,
+		Name:       r.Name,
+		EventType:  r.EventType,
+		WebhookURL: r.WebhookURL,
+		TimeoutMs:  r.TimeoutMs,
+		IsActive:   r.IsActive,
+
+		Validate:         r.Validate,
+		RequireReachable: r.RequireReachable,
+	}
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *ConfigResponse) FromApplicationResult(result *services.ConfigResult) {
+	r.ConfigResult = *result
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *DeleteConfigResponse) FromApplicationResult(result *services.DeleteConfigResult) {
+	r.DeleteConfigResult = *result
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *ConfigListResponse) FromApplicationResult(result *services.ConfigListResult) {
+	r.ConfigListResult = *result
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *WebhookStatusResponse) FromApplicationResult(result *services.WebhookStatusResult) {
+	r.WebhookStatusResult = *result
+}
+
+// ToApplicationQuery converts HTTP request to application query
+func (r ListWebhooksRequest) ToApplicationQuery() services.ListWebhooksQuery {
+	return services.ListWebhooksQuery{
+		Status:        r.Status,
+		EventType:     r.EventType,
+		ConfigID:      r.ConfigID,
+		CreatedAfter:  r.CreatedAfter,
+		CreatedBefore: r.CreatedBefore,
+		Limit:         r.Limit,
+		Offset:        r.Offset,
+	}
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *WebhookListResponse) FromApplicationResult(result *services.WebhookListResult) {
+	r.WebhookListResult = *result
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *WebhookExportResponse) FromApplicationResult(result *services.WebhookExportResult) {
+	r.WebhookExportResult = *result
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *WorkerClusterResponse) FromApplicationResult(result *services.WorkerClusterResult) {
+	r.WorkerClusterResult = *result
+}
+
+// ToApplicationCommand converts HTTP request to application command
+func (r ReplayToRequest) ToApplicationCommand() services.ReplayToCommand {
+	return services.ReplayToCommand{
+		ConfigID:      r.ConfigID,
+		Statuses:      r.Statuses,
+		CreatedBefore: r.CreatedBefore,
+		Metadata:      r.Metadata,
+		URL:           r.URL,
+	}
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *ReplayToResponse) FromApplicationResult(result *services.ReplayToResult) {
+	r.ReplayToResult = *result
+}
+
+// FromApplicationResult converts application result to HTTP response
+func (r *ProcessingWebhooksResponse) FromApplicationResult(result *services.ProcessingWebhooksResult) {
+	r.ProcessingWebhooksResult = *result
 }
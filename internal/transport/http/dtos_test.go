@@ -1,10 +1,12 @@
 package http
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"webhook-processor/internal/application/services"
 	"webhook-processor/internal/domain/enums"
@@ -28,6 +30,29 @@ func TestCreateWebhookRequest_ToApplicationCommand(t *testing.T) {
 		assert.Equal(t, req.ConfigID, cmd.ConfigID)
 	})
 
+	t.Run("should pass metadata through", func(t *testing.T) {
+		req := CreateWebhookRequest{
+			EventType: enums.EventTypeCredit,
+			EventID:   "test-event-123",
+			ConfigID:  1,
+			Metadata:  map[string]string{"tenant": "acme"},
+		}
+
+		cmd := req.ToApplicationCommand()
+
+		assert.Equal(t, map[string]string{"tenant": "acme"}, cmd.Metadata)
+	})
+
+	t.Run("should pass the payload through as raw JSON", func(t *testing.T) {
+		var req CreateWebhookRequest
+		err := json.Unmarshal([]byte(`{"event_type":"CREDIT","event_id":"tx_123","payload":{"amount":100}}`), &req)
+		require.NoError(t, err)
+
+		cmd := req.ToApplicationCommand()
+
+		assert.JSONEq(t, `{"amount":100}`, string(cmd.Payload))
+	})
+
 	t.Run("should handle debit event type", func(t *testing.T) {
 		// Arrange
 		req := CreateWebhookRequest{
@@ -9,15 +9,63 @@ import (
 
 // Endpoints holds all the service endpoints
 type Endpoints struct {
-	CreateWebhookEndpoint endpoint.Endpoint
-	GetHealthEndpoint     endpoint.Endpoint
+	CreateWebhookEndpoint  endpoint.Endpoint
+	GetHealthEndpoint      endpoint.Endpoint
+	GetDebugConfigEndpoint endpoint.Endpoint
+
+	BulkUpdateStatusEndpoint endpoint.Endpoint
+	ForceFailWebhookEndpoint endpoint.Endpoint
+	ProcessWebhookEndpoint   endpoint.Endpoint
+	DeleteWebhookEndpoint    endpoint.Endpoint
+
+	GetConfigStatsEndpoint endpoint.Endpoint
+	GetEventStatusEndpoint endpoint.Endpoint
+
+	CreateConfigEndpoint endpoint.Endpoint
+	UpdateConfigEndpoint endpoint.Endpoint
+	DeleteConfigEndpoint endpoint.Endpoint
+	ListConfigsEndpoint  endpoint.Endpoint
+
+	GetWebhookStatusEndpoint endpoint.Endpoint
+	ListWebhooksEndpoint     endpoint.Endpoint
+	ExportWebhookEndpoint    endpoint.Endpoint
+
+	GetWorkerClusterEndpoint endpoint.Endpoint
+
+	GetProcessingWebhooksEndpoint endpoint.Endpoint
+
+	ReplayToEndpoint endpoint.Endpoint
 }
 
 // MakeEndpoints creates all service endpoints (middleware applied at HTTP level)
 func MakeEndpoints(svc Service, logger log.Logger) Endpoints {
 	return Endpoints{
-		CreateWebhookEndpoint: makeCreateWebhookEndpoint(svc),
-		GetHealthEndpoint:     makeGetHealthEndpoint(svc),
+		CreateWebhookEndpoint:  makeCreateWebhookEndpoint(svc),
+		GetHealthEndpoint:      makeGetHealthEndpoint(svc),
+		GetDebugConfigEndpoint: makeGetDebugConfigEndpoint(svc),
+
+		BulkUpdateStatusEndpoint: makeBulkUpdateStatusEndpoint(svc),
+		ForceFailWebhookEndpoint: makeForceFailWebhookEndpoint(svc),
+		ProcessWebhookEndpoint:   makeProcessWebhookEndpoint(svc),
+		DeleteWebhookEndpoint:    makeDeleteWebhookEndpoint(svc),
+
+		GetConfigStatsEndpoint: makeGetConfigStatsEndpoint(svc),
+		GetEventStatusEndpoint: makeGetEventStatusEndpoint(svc),
+
+		CreateConfigEndpoint: makeCreateConfigEndpoint(svc),
+		UpdateConfigEndpoint: makeUpdateConfigEndpoint(svc),
+		DeleteConfigEndpoint: makeDeleteConfigEndpoint(svc),
+		ListConfigsEndpoint:  makeListConfigsEndpoint(svc),
+
+		GetWebhookStatusEndpoint: makeGetWebhookStatusEndpoint(svc),
+		ListWebhooksEndpoint:     makeListWebhooksEndpoint(svc),
+		ExportWebhookEndpoint:    makeExportWebhookEndpoint(svc),
+
+		GetWorkerClusterEndpoint: makeGetWorkerClusterEndpoint(svc),
+
+		GetProcessingWebhooksEndpoint: makeGetProcessingWebhooksEndpoint(svc),
+
+		ReplayToEndpoint: makeReplayToEndpoint(svc),
 	}
 }
 
@@ -43,3 +91,203 @@ func makeGetHealthEndpoint(svc Service) endpoint.Endpoint {
 		return response, nil
 	}
 }
+
+// makeGetDebugConfigEndpoint creates the effective configuration endpoint
+func makeGetDebugConfigEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		response, err := svc.GetDebugConfig(ctx)
+		if err != nil {
+			return response, err
+		}
+		return response, nil
+	}
+}
+
+// makeBulkUpdateStatusEndpoint creates the bulk status update endpoint
+func makeBulkUpdateStatusEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(BulkUpdateStatusRequest)
+		response, err := svc.BulkUpdateStatus(ctx, req)
+		if err != nil {
+			return response, err
+		}
+		return response, nil
+	}
+}
+
+// makeForceFailWebhookEndpoint creates the force-fail webhook endpoint
+func makeForceFailWebhookEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ForceFailWebhookRequest)
+		response, err := svc.ForceFailWebhook(ctx, req)
+		if err != nil {
+			return response, err
+		}
+		return response, nil
+	}
+}
+
+// makeProcessWebhookEndpoint creates the on-demand webhook processing endpoint
+func makeProcessWebhookEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ProcessWebhookRequest)
+		response, err := svc.ProcessWebhook(ctx, req)
+		if err != nil {
+			return response, err
+		}
+		return response, nil
+	}
+}
+
+// makeDeleteWebhookEndpoint creates the permanent webhook deletion endpoint
+func makeDeleteWebhookEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteWebhookRequest)
+		response, err := svc.DeleteWebhook(ctx, req)
+		if err != nil {
+			return response, err
+		}
+		return response, nil
+	}
+}
+
+// makeGetConfigStatsEndpoint creates the config statistics endpoint
+func makeGetConfigStatsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetConfigStatsRequest)
+		response, err := svc.GetConfigStats(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeCreateConfigEndpoint creates the webhook config creation endpoint
+func makeCreateConfigEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SaveConfigRequest)
+		response, err := svc.CreateConfig(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeUpdateConfigEndpoint creates the webhook config update endpoint
+func makeUpdateConfigEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SaveConfigRequest)
+		response, err := svc.UpdateConfig(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeDeleteConfigEndpoint creates the webhook config deletion endpoint
+func makeDeleteConfigEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteConfigRequest)
+		response, err := svc.DeleteConfig(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeListConfigsEndpoint creates the webhook config listing endpoint
+func makeListConfigsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		response, err := svc.ListConfigs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeGetEventStatusEndpoint creates the event delivery status endpoint
+func makeGetEventStatusEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetEventStatusRequest)
+		response, err := svc.GetEventStatus(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeGetWebhookStatusEndpoint creates the single webhook status endpoint
+func makeGetWebhookStatusEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetWebhookStatusRequest)
+		response, err := svc.GetWebhookStatus(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeListWebhooksEndpoint creates the webhook listing endpoint
+func makeListWebhooksEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListWebhooksRequest)
+		response, err := svc.ListWebhooks(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeExportWebhookEndpoint creates the webhook lifecycle export endpoint
+func makeExportWebhookEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ExportWebhookRequest)
+		response, err := svc.ExportWebhook(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeGetWorkerClusterEndpoint creates the worker cluster endpoint
+func makeGetWorkerClusterEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		response, err := svc.GetWorkerCluster(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeGetProcessingWebhooksEndpoint creates the processing webhooks listing endpoint
+func makeGetProcessingWebhooksEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		response, err := svc.GetProcessingWebhooks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+// makeReplayToEndpoint creates the replay to a different URL endpoint
+func makeReplayToEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ReplayToRequest)
+		response, err := svc.ReplayTo(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
@@ -3,16 +3,31 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/go-kit/log"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"webhook-processor/internal/application/services"
+	"webhook-processor/internal/domain/enums"
 )
 
+// ErrBadRequest is returned by decoders when a request cannot be parsed
+var ErrBadRequest = errors.New("bad request")
+
 // NewHTTPHandler creates a new HTTP handler with all routes
-func NewHTTPHandler(svc Service, logger log.Logger) http.Handler {
+// Admin/debug routes require adminToken as a bearer token and are disabled when it is empty
+// At most maxConcurrentProbes admin requests that send webhooks run at once; 0 disables the cap
+func NewHTTPHandler(svc Service, logger log.Logger, adminToken string, maxConcurrentProbes int) http.Handler {
 	endpoints := MakeEndpoints(svc, logger)
 
 	// Create HTTP handlers using Go-Kit transport
@@ -21,6 +36,7 @@ func NewHTTPHandler(svc Service, logger log.Logger) http.Handler {
 		decodeCreateWebhookRequest,
 		encodeResponse,
 		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
 	)
 
 	getHealthHandler := httptransport.NewServer(
@@ -30,12 +46,183 @@ func NewHTTPHandler(svc Service, logger log.Logger) http.Handler {
 		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 	)
 
+	getDebugConfigHandler := httptransport.NewServer(
+		endpoints.GetDebugConfigEndpoint,
+		decodeGetDebugConfigRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+	)
+
+	bulkUpdateStatusHandler := httptransport.NewServer(
+		endpoints.BulkUpdateStatusEndpoint,
+		decodeBulkUpdateStatusRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	forceFailWebhookHandler := httptransport.NewServer(
+		endpoints.ForceFailWebhookEndpoint,
+		decodeForceFailWebhookRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	processWebhookHandler := httptransport.NewServer(
+		endpoints.ProcessWebhookEndpoint,
+		decodeProcessWebhookRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	deleteWebhookHandler := httptransport.NewServer(
+		endpoints.DeleteWebhookEndpoint,
+		decodeDeleteWebhookRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	getConfigStatsHandler := httptransport.NewServer(
+		endpoints.GetConfigStatsEndpoint,
+		decodeGetConfigStatsRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	createConfigHandler := httptransport.NewServer(
+		endpoints.CreateConfigEndpoint,
+		decodeCreateConfigRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	updateConfigHandler := httptransport.NewServer(
+		endpoints.UpdateConfigEndpoint,
+		decodeUpdateConfigRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	deleteConfigHandler := httptransport.NewServer(
+		endpoints.DeleteConfigEndpoint,
+		decodeDeleteConfigRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	listConfigsHandler := httptransport.NewServer(
+		endpoints.ListConfigsEndpoint,
+		decodeListConfigsRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	getEventStatusHandler := httptransport.NewServer(
+		endpoints.GetEventStatusEndpoint,
+		decodeGetEventStatusRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	getWebhookStatusHandler := httptransport.NewServer(
+		endpoints.GetWebhookStatusEndpoint,
+		decodeGetWebhookStatusRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	listWebhooksHandler := httptransport.NewServer(
+		endpoints.ListWebhooksEndpoint,
+		decodeListWebhooksRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	exportWebhookHandler := httptransport.NewServer(
+		endpoints.ExportWebhookEndpoint,
+		decodeExportWebhookRequest,
+		encodeExportWebhookResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	getWorkerClusterHandler := httptransport.NewServer(
+		endpoints.GetWorkerClusterEndpoint,
+		decodeGetWorkerClusterRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	getProcessingWebhooksHandler := httptransport.NewServer(
+		endpoints.GetProcessingWebhooksEndpoint,
+		decodeGetProcessingWebhooksRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	replayToHandler := httptransport.NewServer(
+		endpoints.ReplayToEndpoint,
+		decodeReplayToRequest,
+		encodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(encodeError),
+	)
+
+	// Admin requests that send webhooks share one limit, separate from the worker pools
+	probeLimit := probeLimitMiddleware(maxConcurrentProbes)
+
 	router := mux.NewRouter()
 
 	// Register routes
 	router.Handle("/webhooks", createWebhookHandler).Methods("POST")
+	router.Handle("/webhooks", listWebhooksHandler).Methods("GET")
 	router.Handle("/health", getHealthHandler).Methods("GET")
+	router.Handle("/configs/{id}/stats", getConfigStatsHandler).Methods("GET")
+	router.Handle("/events/{eventID}", getEventStatusHandler).Methods("GET")
+	router.Handle("/webhooks/{queueID}", getWebhookStatusHandler).Methods("GET")
+	router.Handle("/webhooks/{queueID}/export", exportWebhookHandler).Methods("GET")
+	router.Handle("/workers/cluster", getWorkerClusterHandler).Methods("GET")
 	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	router.HandleFunc("/openapi.json", openAPIHandler()).Methods("GET")
+	router.Handle("/webhooks/{queueID}/fail", adminAuthMiddleware(adminToken)(forceFailWebhookHandler)).Methods("POST")
+	router.Handle("/webhooks/{queueID}/process", adminAuthMiddleware(adminToken)(probeLimit(processWebhookHandler))).Methods("POST")
+	router.Handle("/webhooks/{queueID}", adminAuthMiddleware(adminToken)(deleteWebhookHandler)).Methods("DELETE")
+
+	// Configs carry receivers' URLs and credentials, so managing them is an admin operation
+	// Saves can ping the receiver, so they count against the probe limit
+	router.Handle("/configs", adminAuthMiddleware(adminToken)(listConfigsHandler)).Methods("GET")
+	router.Handle("/configs", adminAuthMiddleware(adminToken)(probeLimit(createConfigHandler))).Methods("POST")
+	router.Handle("/configs/{id}", adminAuthMiddleware(adminToken)(probeLimit(updateConfigHandler))).Methods("PUT")
+	router.Handle("/configs/{id}", adminAuthMiddleware(adminToken)(deleteConfigHandler)).Methods("DELETE")
+
+	// Register admin/debug routes
+	debugRouter := router.PathPrefix("/debug").Subrouter()
+	debugRouter.Use(adminAuthMiddleware(adminToken))
+	debugRouter.Handle("/config", getDebugConfigHandler).Methods("GET")
+
+	adminRouter := router.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(adminAuthMiddleware(adminToken))
+	adminRouter.Handle("/webhooks/bulk-status", bulkUpdateStatusHandler).Methods("POST")
+	adminRouter.Handle("/processing", getProcessingWebhooksHandler).Methods("GET")
+	adminRouter.Handle("/replay-to", probeLimit(replayToHandler)).Methods("POST")
+
+	// CORS preflights match a path but no route's method, so corsMiddleware answers them here
+	router.MethodNotAllowedHandler = corsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
 
 	// Add HTTP middleware
 	router.Use(loggingMiddleware(logger))
@@ -61,6 +248,219 @@ func decodeGetHealthRequest(_ context.Context, r *http.Request) (interface{}, er
 	return nil, nil
 }
 
+// decodeGetDebugConfigRequest decodes the effective configuration request (no body)
+func decodeGetDebugConfigRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+// decodeGetWorkerClusterRequest decodes the worker cluster request (no body)
+func decodeGetWorkerClusterRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+// decodeGetProcessingWebhooksRequest decodes the processing webhooks listing request (no body)
+func decodeGetProcessingWebhooksRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+// decodeBulkUpdateStatusRequest decodes the bulk status update request
+func decodeBulkUpdateStatusRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req BulkUpdateStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidBulkUpdate, err)
+	}
+	return req, nil
+}
+
+// decodeReplayToRequest decodes the replay to a different URL request
+func decodeReplayToRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req ReplayToRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", services.ErrInvalidReplay, err)
+	}
+	return req, nil
+}
+
+// decodeForceFailWebhookRequest decodes the queue ID from the request path and the reason from the body
+func decodeForceFailWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	raw := mux.Vars(r)["queueID"]
+	queueID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid queue id %q", ErrBadRequest, raw)
+	}
+
+	var req ForceFailWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	req.QueueID = queueID
+	return req, nil
+}
+
+// decodeProcessWebhookRequest decodes the queue ID from the request path (no body)
+func decodeProcessWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	raw := mux.Vars(r)["queueID"]
+	queueID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid queue id %q", ErrBadRequest, raw)
+	}
+	return ProcessWebhookRequest{QueueID: queueID}, nil
+}
+
+// decodeDeleteWebhookRequest decodes the queue ID from the request path (no body)
+func decodeDeleteWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	raw := mux.Vars(r)["queueID"]
+	queueID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid queue id %q", ErrBadRequest, raw)
+	}
+	return DeleteWebhookRequest{QueueID: queueID}, nil
+}
+
+// decodeGetConfigStatsRequest decodes the config ID from the request path
+func decodeGetConfigStatsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	configID, err := decodeConfigID(r)
+	if err != nil {
+		return nil, err
+	}
+	return GetConfigStatsRequest{ConfigID: configID}, nil
+}
+
+// decodeConfigID decodes the config ID from the request path
+func decodeConfigID(r *http.Request) (int64, error) {
+	raw := mux.Vars(r)["id"]
+	configID, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || configID <= 0 {
+		return 0, fmt.Errorf("%w: invalid config id %q", ErrBadRequest, raw)
+	}
+	return configID, nil
+}
+
+// decodeConfigPingFlags decodes the validate and require_reachable query flags of a config save
+func decodeConfigPingFlags(r *http.Request, req *SaveConfigRequest) error {
+	query := r.URL.Query()
+	for name, dest := range map[string]*bool{"validate": &req.Validate, "require_reachable": &req.RequireReachable} {
+		if raw := query.Get(name); raw != "" {
+			flag, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("%w: invalid %s %q", ErrBadRequest, name, raw)
+			}
+			*dest = flag
+		}
+	}
+	return nil
+}
+
+// decodeCreateConfigRequest decodes a new config from the body and its ping flags from the query string
+func decodeCreateConfigRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req SaveConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	if err := decodeConfigPingFlags(r, &req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decodeUpdateConfigRequest decodes the config ID from the request path, its new fields from the body
+// and its ping flags from the query string
+func decodeUpdateConfigRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	configID, err := decodeConfigID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req SaveConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	req.ID = configID
+	if err := decodeConfigPingFlags(r, &req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decodeDeleteConfigRequest decodes the config ID from the request path (no body)
+func decodeDeleteConfigRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	configID, err := decodeConfigID(r)
+	if err != nil {
+		return nil, err
+	}
+	return DeleteConfigRequest{ConfigID: configID}, nil
+}
+
+// decodeListConfigsRequest decodes the config listing request (no body)
+func decodeListConfigsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+// decodeGetEventStatusRequest decodes the event ID from the request path
+func decodeGetEventStatusRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	eventID := mux.Vars(r)["eventID"]
+	if strings.TrimSpace(eventID) == "" {
+		return nil, fmt.Errorf("%w: event id is required", ErrBadRequest)
+	}
+	return GetEventStatusRequest{EventID: eventID}, nil
+}
+
+// decodeGetWebhookStatusRequest decodes the queue ID from the request path
+func decodeGetWebhookStatusRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	raw := mux.Vars(r)["queueID"]
+	queueID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid queue id %q", ErrBadRequest, raw)
+	}
+	return GetWebhookStatusRequest{QueueID: queueID}, nil
+}
+
+// decodeListWebhooksRequest decodes the filters and page from the query string; times are RFC 3339
+func decodeListWebhooksRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	query := r.URL.Query()
+	req := ListWebhooksRequest{
+		Status:    enums.WebhookStatus(query.Get("status")),
+		EventType: enums.EventType(query.Get("event_type")),
+	}
+
+	if raw := query.Get("config_id"); raw != "" {
+		configID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || configID <= 0 {
+			return nil, fmt.Errorf("%w: invalid config id %q", ErrBadRequest, raw)
+		}
+		req.ConfigID = &configID
+	}
+	for name, dest := range map[string]**time.Time{"created_after": &req.CreatedAfter, "created_before": &req.CreatedBefore} {
+		if raw := query.Get(name); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid %s %q", ErrBadRequest, name, raw)
+			}
+			*dest = &t
+		}
+	}
+	for name, dest := range map[string]*int{"limit": &req.Limit, "offset": &req.Offset} {
+		if raw := query.Get(name); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%w: invalid %s %q", ErrBadRequest, name, raw)
+			}
+			*dest = n
+		}
+	}
+	return req, nil
+}
+
+// decodeExportWebhookRequest decodes the queue ID from the request path
+func decodeExportWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	raw := mux.Vars(r)["queueID"]
+	queueID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid queue id %q", ErrBadRequest, raw)
+	}
+	return ExportWebhookRequest{QueueID: queueID}, nil
+}
+
 // Response encoder
 
 // encodeResponse encodes the response as JSON
@@ -68,3 +468,40 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	w.Header().Set("Content-Type", "application/json")
 	return json.NewEncoder(w).Encode(response)
 }
+
+// encodeExportWebhookResponse encodes the export as a downloadable JSON document
+func encodeExportWebhookResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if export, ok := response.(WebhookExportResponse); ok {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"webhook-%s.json\"", export.QueueID))
+	}
+	return encodeResponse(ctx, w, response)
+}
+
+// encodeError encodes an error as JSON, mapping rejected requests to 400, missing resources to 404,
+// status conflicts to 409, unreachable config URLs to 422 and backlog rejections to 503 with a Retry-After header
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	status := http.StatusInternalServerError
+	var backlogFull *services.BacklogFullError
+	switch {
+	case errors.As(err, &backlogFull):
+		status = http.StatusServiceUnavailable
+		retryAfter := int(math.Ceil(backlogFull.RetryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	case errors.Is(err, ErrBadRequest), errors.Is(err, services.ErrInvalidBulkUpdate),
+		errors.Is(err, services.ErrInvalidForceFail), errors.Is(err, services.ErrInvalidReplay),
+		errors.Is(err, services.ErrInvalidWebhookURL), errors.Is(err, services.ErrInvalidListQuery),
+		errors.Is(err, services.ErrInvalidConfig):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrConfigNotFound), errors.Is(err, services.ErrWebhookNotFound),
+		errors.Is(err, services.ErrEventNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrWebhookStatusConflict):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrUnreachableConfig):
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+}
@@ -4,16 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-kit/log"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"webhook-processor/internal/application/services"
+	"webhook-processor/internal/config"
+	"webhook-processor/internal/domain/entities"
 	"webhook-processor/internal/domain/enums"
 )
 
@@ -21,6 +26,29 @@ import (
 type mockWebhookApplicationService struct {
 	createWebhookFunc func(ctx context.Context, cmd services.CreateWebhookCommand) (*services.CreateWebhookResult, error)
 	getHealthFunc     func(ctx context.Context) (*services.HealthResult, error)
+	bulkUpdateFunc    func(ctx context.Context, cmd services.BulkUpdateStatusCommand) (*services.BulkUpdateStatusResult, error)
+	forceFailFunc     func(ctx context.Context, cmd services.ForceFailWebhookCommand) (*services.ForceFailWebhookResult, error)
+	processNowFunc    func(ctx context.Context, queueID uuid.UUID) (*services.ProcessWebhookResult, error)
+	deleteWebhookFunc func(ctx context.Context, queueID uuid.UUID) (*services.DeleteWebhookResult, error)
+
+	getConfigStatsFunc func(ctx context.Context, configID int64) (*services.ConfigStatsResult, error)
+
+	getWebhookStatusFunc func(ctx context.Context, queueID uuid.UUID) (*services.WebhookStatusResult, error)
+	exportWebhookFunc    func(ctx context.Context, queueID uuid.UUID) (*services.WebhookExportResult, error)
+	listWebhooksFunc     func(ctx context.Context, query services.ListWebhooksQuery) (*services.WebhookListResult, error)
+
+	getWorkerClusterFunc func(ctx context.Context) (*services.WorkerClusterResult, error)
+
+	getProcessingWebhooksFunc func(ctx context.Context) (*services.ProcessingWebhooksResult, error)
+
+	replayToFunc func(ctx context.Context, cmd services.ReplayToCommand) (*services.ReplayToResult, error)
+
+	createConfigFunc func(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error)
+	updateConfigFunc func(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error)
+	deleteConfigFunc func(ctx context.Context, configID int64) (*services.DeleteConfigResult, error)
+	listConfigsFunc  func(ctx context.Context) (*services.ConfigListResult, error)
+
+	getEventStatusFunc func(ctx context.Context, eventID string) (*services.EventStatusResult, error)
 }
 
 func (m *mockWebhookApplicationService) CreateWebhook(ctx context.Context, cmd services.CreateWebhookCommand) (*services.CreateWebhookResult, error) {
@@ -51,14 +79,126 @@ func (m *mockWebhookApplicationService) GetHealth(ctx context.Context) (*service
 	}, nil
 }
 
+func (m *mockWebhookApplicationService) GetWebhookStatus(ctx context.Context, queueID uuid.UUID) (*services.WebhookStatusResult, error) {
+	if m.getWebhookStatusFunc != nil {
+		return m.getWebhookStatusFunc(ctx, queueID)
+	}
+	return nil, services.ErrWebhookNotFound
+}
+
+func (m *mockWebhookApplicationService) ListWebhooks(ctx context.Context, query services.ListWebhooksQuery) (*services.WebhookListResult, error) {
+	if m.listWebhooksFunc != nil {
+		return m.listWebhooksFunc(ctx, query)
+	}
+	return &services.WebhookListResult{Items: []services.WebhookListItem{}}, nil
+}
+
+func (m *mockWebhookApplicationService) ExportWebhook(ctx context.Context, queueID uuid.UUID) (*services.WebhookExportResult, error) {
+	if m.exportWebhookFunc != nil {
+		return m.exportWebhookFunc(ctx, queueID)
+	}
+	return nil, services.ErrWebhookNotFound
+}
+
+func (m *mockWebhookApplicationService) GetConfigStats(ctx context.Context, configID int64) (*services.ConfigStatsResult, error) {
+	if m.getConfigStatsFunc != nil {
+		return m.getConfigStatsFunc(ctx, configID)
+	}
+	return &services.ConfigStatsResult{ConfigID: configID}, nil
+}
+
+func (m *mockWebhookApplicationService) BulkUpdateStatus(ctx context.Context, cmd services.BulkUpdateStatusCommand) (*services.BulkUpdateStatusResult, error) {
+	if m.bulkUpdateFunc != nil {
+		return m.bulkUpdateFunc(ctx, cmd)
+	}
+	return &services.BulkUpdateStatusResult{Success: true}, nil
+}
+
+func (m *mockWebhookApplicationService) ForceFailWebhook(ctx context.Context, cmd services.ForceFailWebhookCommand) (*services.ForceFailWebhookResult, error) {
+	if m.forceFailFunc != nil {
+		return m.forceFailFunc(ctx, cmd)
+	}
+	return &services.ForceFailWebhookResult{Success: true, QueueID: cmd.QueueID.String(), Status: enums.WebhookStatusFailed}, nil
+}
+
+func (m *mockWebhookApplicationService) ProcessWebhookNow(ctx context.Context, queueID uuid.UUID) (*services.ProcessWebhookResult, error) {
+	if m.processNowFunc != nil {
+		return m.processNowFunc(ctx, queueID)
+	}
+	return &services.ProcessWebhookResult{Success: true, QueueID: queueID.String(), Status: enums.WebhookStatusCompleted}, nil
+}
+
+func (m *mockWebhookApplicationService) DeleteWebhook(ctx context.Context, queueID uuid.UUID) (*services.DeleteWebhookResult, error) {
+	if m.deleteWebhookFunc != nil {
+		return m.deleteWebhookFunc(ctx, queueID)
+	}
+	return &services.DeleteWebhookResult{Success: true, QueueID: queueID.String()}, nil
+}
+
+func (m *mockWebhookApplicationService) GetWorkerCluster(ctx context.Context) (*services.WorkerClusterResult, error) {
+	if m.getWorkerClusterFunc != nil {
+		return m.getWorkerClusterFunc(ctx)
+	}
+	return &services.WorkerClusterResult{Instances: []services.WorkerInstance{}}, nil
+}
+
+func (m *mockWebhookApplicationService) GetProcessingWebhooks(ctx context.Context) (*services.ProcessingWebhooksResult, error) {
+	if m.getProcessingWebhooksFunc != nil {
+		return m.getProcessingWebhooksFunc(ctx)
+	}
+	return &services.ProcessingWebhooksResult{Webhooks: []services.ProcessingWebhook{}}, nil
+}
+
+func (m *mockWebhookApplicationService) ReplayTo(ctx context.Context, cmd services.ReplayToCommand) (*services.ReplayToResult, error) {
+	if m.replayToFunc != nil {
+		return m.replayToFunc(ctx, cmd)
+	}
+	return &services.ReplayToResult{Success: true}, nil
+}
+
+func (m *mockWebhookApplicationService) CreateConfig(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error) {
+	if m.createConfigFunc != nil {
+		return m.createConfigFunc(ctx, cmd)
+	}
+	return &services.ConfigResult{ID: 1, Name: cmd.Name, EventType: cmd.EventType, WebhookURL: cmd.WebhookURL, TimeoutMs: cmd.TimeoutMs}, nil
+}
+
+func (m *mockWebhookApplicationService) UpdateConfig(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error) {
+	if m.updateConfigFunc != nil {
+		return m.updateConfigFunc(ctx, cmd)
+	}
+	return &services.ConfigResult{ID: cmd.ID, Name: cmd.Name, EventType: cmd.EventType, WebhookURL: cmd.WebhookURL, TimeoutMs: cmd.TimeoutMs}, nil
+}
+
+func (m *mockWebhookApplicationService) DeleteConfig(ctx context.Context, configID int64) (*services.DeleteConfigResult, error) {
+	if m.deleteConfigFunc != nil {
+		return m.deleteConfigFunc(ctx, configID)
+	}
+	return &services.DeleteConfigResult{Success: true, ConfigID: configID}, nil
+}
+
+func (m *mockWebhookApplicationService) ListConfigs(ctx context.Context) (*services.ConfigListResult, error) {
+	if m.listConfigsFunc != nil {
+		return m.listConfigsFunc(ctx)
+	}
+	return &services.ConfigListResult{Configs: []services.ConfigResult{}}, nil
+}
+
+func (m *mockWebhookApplicationService) GetEventStatus(ctx context.Context, eventID string) (*services.EventStatusResult, error) {
+	if m.getEventStatusFunc != nil {
+		return m.getEventStatusFunc(ctx, eventID)
+	}
+	return nil, services.ErrEventNotFound
+}
+
 func TestHTTPHandler_Integration(t *testing.T) {
 	// Create mock application service
 	mockAppService := &mockWebhookApplicationService{}
 
 	// Create HTTP service and handler
-	httpService := NewService(mockAppService)
+	httpService := NewService(mockAppService, nil)
 	logger := log.NewNopLogger()
-	handler := NewHTTPHandler(httpService, logger)
+	handler := NewHTTPHandler(httpService, logger, "", 0)
 
 	t.Run("should handle POST /webhooks successfully", func(t *testing.T) {
 		// Arrange
@@ -141,6 +281,23 @@ func TestHTTPHandler_Integration(t *testing.T) {
 		assert.True(t, recorder.Code >= 400, "Should return an error status code")
 	})
 
+	t.Run("should return service unavailable with Retry-After when the backlog is full", func(t *testing.T) {
+		mockAppService.createWebhookFunc = func(ctx context.Context, cmd services.CreateWebhookCommand) (*services.CreateWebhookResult, error) {
+			return &services.CreateWebhookResult{Success: false},
+				&services.BacklogFullError{Pending: 5000, RetryAfter: 1500 * time.Millisecond}
+		}
+		defer func() { mockAppService.createWebhookFunc = nil }()
+
+		body := `{"event_type": "CREDIT", "event_id": "overload", "config_id": 1}`
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/webhooks", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+		assert.Contains(t, recorder.Body.String(), `"success":false`)
+	})
+
 	t.Run("should handle application service error", func(t *testing.T) {
 		// Arrange - Mock service to return error
 		mockAppService.createWebhookFunc = func(ctx context.Context, cmd services.CreateWebhookCommand) (*services.CreateWebhookResult, error) {
@@ -218,8 +375,25 @@ func TestHTTPHandler_Integration(t *testing.T) {
 
 		// Assert
 		assert.Equal(t, http.StatusOK, recorder.Code)
-		// Check for CORS headers (if implemented in middleware)
-		// This depends on the actual CORS middleware implementation
+		assert.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", recorder.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("should answer preflights without admin auth, including for a config update", func(t *testing.T) {
+		for _, path := range []string{"/configs/4", "/configs", "/webhooks"} {
+			// Arrange
+			req := httptest.NewRequest("OPTIONS", path, nil)
+			req.Header.Set("Origin", "https://example.com")
+			req.Header.Set("Access-Control-Request-Method", "PUT")
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, recorder.Code, path)
+			assert.Contains(t, recorder.Header().Get("Access-Control-Allow-Methods"), "PUT", path)
+		}
 	})
 }
 
@@ -228,9 +402,9 @@ func TestHTTPHandler_Middleware(t *testing.T) {
 	mockAppService := &mockWebhookApplicationService{}
 
 	// Create HTTP service and handler
-	httpService := NewService(mockAppService)
+	httpService := NewService(mockAppService, nil)
 	logger := log.NewNopLogger()
-	handler := NewHTTPHandler(httpService, logger)
+	handler := NewHTTPHandler(httpService, logger, "", 0)
 
 	t.Run("should recover from panics", func(t *testing.T) {
 		// Arrange - Mock service to panic
@@ -278,13 +452,1008 @@ func TestHTTPHandler_Middleware(t *testing.T) {
 	})
 }
 
+func TestHTTPHandler_DebugConfig(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{
+			Host:     "db.internal",
+			User:     "postgres",
+			Password: "super-secret-password",
+			DBName:   "webhook_processor",
+		},
+		HTTPServer: config.HTTPServerConfig{
+			Port:       8080,
+			AdminToken: "admin-token",
+		},
+		WorkerPool: config.GetDefaultWorkerPoolConfig(),
+	}
+
+	httpService := NewService(&mockWebhookApplicationService{}, cfg)
+	handler := NewHTTPHandler(httpService, log.NewNopLogger(), cfg.HTTPServer.AdminToken, 0)
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/debug/config", nil)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), "super-secret-password")
+	})
+
+	t.Run("should return redacted configuration with the admin token", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/debug/config", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), "super-secret-password")
+		assert.NotContains(t, recorder.Body.String(), "admin-token")
+
+		var response DebugConfigResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "db.internal", response.Config.Database.Host)
+		assert.Equal(t, "********", response.Config.Database.Password)
+		assert.Equal(t, "********", response.Config.HTTPServer.AdminToken)
+		assert.Len(t, response.Config.WorkerPool.Workers, len(cfg.WorkerPool.Workers))
+	})
+
+	t.Run("should disable debug routes when no admin token is configured", func(t *testing.T) {
+		disabledHandler := NewHTTPHandler(httpService, log.NewNopLogger(), "", 0)
+
+		req := httptest.NewRequest("GET", "/debug/config", nil)
+		req.Header.Set("Authorization", "Bearer ")
+		recorder := httptest.NewRecorder()
+
+		disabledHandler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_BulkUpdateStatus(t *testing.T) {
+	mockAppService := &mockWebhookApplicationService{
+		bulkUpdateFunc: func(ctx context.Context, cmd services.BulkUpdateStatusCommand) (*services.BulkUpdateStatusResult, error) {
+			if cmd.Status == enums.WebhookStatusProcessing {
+				return &services.BulkUpdateStatusResult{Success: false},
+					fmt.Errorf("%w: cannot bulk transition to PROCESSING", services.ErrInvalidBulkUpdate)
+			}
+			return &services.BulkUpdateStatusResult{
+				Success: true,
+				Message: "Updated 3 webhooks to " + string(cmd.Status),
+				Updated: 3,
+			}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 0)
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		body := `{"config_id": 1, "status": "FAILED", "reason": "endpoint decommissioned"}`
+		req := httptest.NewRequest("POST", "/admin/webhooks/bulk-status", strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should return the updated count for a valid transition", func(t *testing.T) {
+		body := `{"config_id": 1, "statuses": ["PENDING"], "status": "FAILED", "reason": "endpoint decommissioned"}`
+		req := httptest.NewRequest("POST", "/admin/webhooks/bulk-status", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response BulkUpdateStatusResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		assert.Equal(t, int64(3), response.Updated)
+	})
+
+	t.Run("should return bad request for a rejected transition", func(t *testing.T) {
+		body := `{"config_id": 1, "status": "PROCESSING", "reason": "retry everything"}`
+		req := httptest.NewRequest("POST", "/admin/webhooks/bulk-status", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"success":false`)
+	})
+
+	t.Run("should return bad request for a malformed body", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/admin/webhooks/bulk-status", strings.NewReader("{"))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_ForceFailWebhook(t *testing.T) {
+	pendingID := uuid.New()
+	completedID := uuid.New()
+	var gotReason string
+	mockAppService := &mockWebhookApplicationService{
+		forceFailFunc: func(ctx context.Context, cmd services.ForceFailWebhookCommand) (*services.ForceFailWebhookResult, error) {
+			switch cmd.QueueID {
+			case pendingID:
+				gotReason = cmd.Reason
+				return &services.ForceFailWebhookResult{
+					Success: true,
+					Message: "Webhook marked as failed",
+					QueueID: cmd.QueueID.String(),
+					Status:  enums.WebhookStatusFailed,
+				}, nil
+			case completedID:
+				return &services.ForceFailWebhookResult{Success: false, QueueID: cmd.QueueID.String()},
+					fmt.Errorf("%w: webhook %s is already COMPLETED", services.ErrWebhookStatusConflict, cmd.QueueID)
+			}
+			return nil, fmt.Errorf("%w: %s", services.ErrWebhookNotFound, cmd.QueueID)
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 0)
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/webhooks/"+pendingID.String()+"/fail", strings.NewReader(`{"reason": "endpoint decommissioned"}`))
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Empty(t, gotReason)
+	})
+
+	t.Run("should force-fail a pending webhook", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/webhooks/"+pendingID.String()+"/fail", strings.NewReader(`{"reason": "endpoint decommissioned"}`))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ForceFailWebhookResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		assert.Equal(t, pendingID.String(), response.QueueID)
+		assert.Equal(t, enums.WebhookStatusFailed, response.Status)
+		assert.Equal(t, "endpoint decommissioned", gotReason)
+	})
+
+	t.Run("should return conflict for a completed webhook", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/webhooks/"+completedID.String()+"/fail", strings.NewReader(`{"reason": "endpoint decommissioned"}`))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"success":false`)
+	})
+
+	t.Run("should return not found for an unknown queue ID", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/webhooks/"+uuid.NewString()+"/fail", strings.NewReader(`{"reason": "endpoint decommissioned"}`))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("should return bad request for a malformed body", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/webhooks/"+pendingID.String()+"/fail", strings.NewReader("{"))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_ProcessWebhook(t *testing.T) {
+	pendingID := uuid.New()
+	claimedID := uuid.New()
+	mockAppService := &mockWebhookApplicationService{
+		processNowFunc: func(ctx context.Context, queueID uuid.UUID) (*services.ProcessWebhookResult, error) {
+			if queueID == claimedID {
+				return &services.ProcessWebhookResult{Success: false, QueueID: queueID.String()},
+					fmt.Errorf("%w: webhook %s is held by another worker", services.ErrWebhookStatusConflict, queueID)
+			}
+			return &services.ProcessWebhookResult{
+				Success:        true,
+				QueueID:        queueID.String(),
+				Status:         enums.WebhookStatusCompleted,
+				LastHTTPStatus: 200,
+			}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 0)
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/webhooks/"+pendingID.String()+"/process", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should return the outcome of the attempt", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/webhooks/"+pendingID.String()+"/process", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ProcessWebhookResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		assert.Equal(t, enums.WebhookStatusCompleted, response.Status)
+		assert.Equal(t, 200, response.LastHTTPStatus)
+	})
+
+	t.Run("should return conflict for a webhook held by another worker", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/webhooks/"+claimedID.String()+"/process", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_DeleteWebhook(t *testing.T) {
+	finishedID := uuid.New()
+	processingID := uuid.New()
+	var deleted []uuid.UUID
+	mockAppService := &mockWebhookApplicationService{
+		deleteWebhookFunc: func(ctx context.Context, queueID uuid.UUID) (*services.DeleteWebhookResult, error) {
+			switch queueID {
+			case finishedID:
+				deleted = append(deleted, queueID)
+				return &services.DeleteWebhookResult{Success: true, Message: "Webhook permanently deleted", QueueID: queueID.String()}, nil
+			case processingID:
+				return &services.DeleteWebhookResult{Success: false, QueueID: queueID.String()},
+					fmt.Errorf("%w: webhook %s has an attempt in flight", services.ErrWebhookStatusConflict, queueID)
+			}
+			return nil, fmt.Errorf("%w: %s", services.ErrWebhookNotFound, queueID)
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 0)
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("DELETE", "/webhooks/"+finishedID.String(), nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("should delete a finished webhook", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/webhooks/"+finishedID.String(), nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response DeleteWebhookResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		assert.Equal(t, []uuid.UUID{finishedID}, deleted)
+	})
+
+	t.Run("should return conflict for a processing webhook", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/webhooks/"+processingID.String(), nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+
+	t.Run("should return not found for an unknown queue ID", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/webhooks/"+uuid.NewString(), nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_GetConfigStats(t *testing.T) {
+	mockAppService := &mockWebhookApplicationService{
+		getConfigStatsFunc: func(ctx context.Context, configID int64) (*services.ConfigStatsResult, error) {
+			if configID != 1 {
+				return nil, fmt.Errorf("%w: %d", services.ErrConfigNotFound, configID)
+			}
+			return &services.ConfigStatsResult{ConfigID: 1, TotalDelivered: 4, TotalFailed: 1, SuccessRate: 0.8}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "", 0)
+
+	t.Run("should return the delivery counts for a config", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/configs/1/stats", nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ConfigStatsResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, int64(1), response.ConfigID)
+		assert.Equal(t, int64(4), response.TotalDelivered)
+		assert.Equal(t, int64(1), response.TotalFailed)
+		assert.InDelta(t, 0.8, response.SuccessRate, 1e-9)
+	})
+
+	t.Run("should return not found for an unknown config", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/configs/99/stats", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("should return bad request for a non-numeric id", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/configs/abc/stats", nil))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_GetWorkerCluster(t *testing.T) {
+	lastSeen := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	var clusterErr error
+	mockAppService := &mockWebhookApplicationService{
+		getWorkerClusterFunc: func(ctx context.Context) (*services.WorkerClusterResult, error) {
+			if clusterErr != nil {
+				return nil, clusterErr
+			}
+			return &services.WorkerClusterResult{
+				LiveWorkers:      1,
+				LiveByRetryLevel: map[int]int{0: 1, 1: 0},
+				Instances: []services.WorkerInstance{{
+					Host:        "processor-a",
+					LiveWorkers: 1,
+					Workers:     []services.WorkerStatus{{WorkerID: "retry-0-abcd1234", LastSeenAt: lastSeen}},
+				}},
+			}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "", 0)
+
+	t.Run("should return the workers of every instance", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/workers/cluster", nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response WorkerClusterResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.LiveWorkers)
+		assert.Equal(t, 0, response.LiveByRetryLevel[1])
+		require.Len(t, response.Instances, 1)
+		assert.Equal(t, "processor-a", response.Instances[0].Host)
+		assert.Equal(t, lastSeen, response.Instances[0].Workers[0].LastSeenAt)
+	})
+
+	t.Run("should return an error when heartbeats cannot be read", func(t *testing.T) {
+		clusterErr = fmt.Errorf("failed to list worker heartbeats: connection refused")
+		defer func() { clusterErr = nil }()
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/workers/cluster", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_GetProcessingWebhooks(t *testing.T) {
+	claimedAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	mockAppService := &mockWebhookApplicationService{
+		getProcessingWebhooksFunc: func(ctx context.Context) (*services.ProcessingWebhooksResult, error) {
+			return &services.ProcessingWebhooksResult{
+				ClaimTTLMs: 300000,
+				Count:      1,
+				Overdue:    1,
+				Webhooks: []services.ProcessingWebhook{{
+					ClaimedBy:  "retry-0-abcd1234",
+					ClaimedAt:  claimedAt,
+					ClaimAgeMs: 600000,
+					Overdue:    true,
+				}},
+			}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 0)
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/admin/processing", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should return the processing webhooks with overdue claims flagged", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/admin/processing", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ProcessingWebhooksResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.Overdue)
+		require.Len(t, response.Webhooks, 1)
+		assert.Equal(t, "retry-0-abcd1234", response.Webhooks[0].ClaimedBy)
+		assert.Equal(t, claimedAt, response.Webhooks[0].ClaimedAt)
+		assert.True(t, response.Webhooks[0].Overdue)
+	})
+}
+
+func TestHTTPHandler_Configs(t *testing.T) {
+	var saved []services.SaveConfigCommand
+	mockAppService := &mockWebhookApplicationService{
+		createConfigFunc: func(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error) {
+			if cmd.TimeoutMs <= 0 {
+				return nil, fmt.Errorf("%w: timeout must be positive", services.ErrInvalidConfig)
+			}
+			if cmd.RequireReachable && strings.Contains(cmd.WebhookURL, "unreachable") {
+				return nil, fmt.Errorf("%w: connection refused", services.ErrUnreachableConfig)
+			}
+			saved = append(saved, cmd)
+			return &services.ConfigResult{ID: 12, Name: cmd.Name, EventType: cmd.EventType, WebhookURL: cmd.WebhookURL,
+				TimeoutMs: cmd.TimeoutMs, IsActive: true}, nil
+		},
+		updateConfigFunc: func(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error) {
+			if cmd.ID != 12 {
+				return nil, fmt.Errorf("%w: %d", services.ErrConfigNotFound, cmd.ID)
+			}
+			saved = append(saved, cmd)
+			return &services.ConfigResult{ID: cmd.ID, Name: cmd.Name, IsActive: *cmd.IsActive}, nil
+		},
+		deleteConfigFunc: func(ctx context.Context, configID int64) (*services.DeleteConfigResult, error) {
+			if configID != 12 {
+				return nil, fmt.Errorf("%w: %d", services.ErrConfigNotFound, configID)
+			}
+			return &services.DeleteConfigResult{Success: true, ConfigID: configID}, nil
+		},
+		listConfigsFunc: func(ctx context.Context) (*services.ConfigListResult, error) {
+			return &services.ConfigListResult{Configs: []services.ConfigResult{{ID: 12, Name: "Ledger"}}}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 0)
+
+	// serve sends an admin request with an optional JSON body
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/configs", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should create a config and return its ID", func(t *testing.T) {
+		recorder := serve("POST", "/configs",
+			`{"name":"Ledger","event_type":"CREDIT","webhook_url":"https://ledger.example.com/hooks","timeout_ms":5000}`)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var response ConfigResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, int64(12), response.ID)
+		assert.Equal(t, "https://ledger.example.com/hooks", saved[len(saved)-1].WebhookURL)
+		assert.Nil(t, saved[len(saved)-1].IsActive, "omitted is_active is left to the service")
+	})
+
+	t.Run("should reject an invalid config", func(t *testing.T) {
+		recorder := serve("POST", "/configs", `{"name":"Ledger","event_type":"CREDIT","webhook_url":"https://ledger.example.com","timeout_ms":0}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "timeout must be positive")
+	})
+
+	t.Run("should reject a malformed body", func(t *testing.T) {
+		recorder := serve("POST", "/configs", `{"name":`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should pass the ping flags from the query string", func(t *testing.T) {
+		createRecorder := serve("POST", "/configs?validate=true",
+			`{"name":"Ledger","event_type":"CREDIT","webhook_url":"https://ledger.example.com/hooks","timeout_ms":5000}`)
+		require.Equal(t, http.StatusOK, createRecorder.Code)
+		assert.True(t, saved[len(saved)-1].Validate)
+		assert.False(t, saved[len(saved)-1].RequireReachable)
+
+		updateRecorder := serve("PUT", "/configs/12?require_reachable=true",
+			`{"name":"Ledger","event_type":"CREDIT","webhook_url":"https://ledger.example.com/hooks","timeout_ms":5000,"is_active":true}`)
+		require.Equal(t, http.StatusOK, updateRecorder.Code)
+		assert.True(t, saved[len(saved)-1].RequireReachable)
+	})
+
+	t.Run("should reject a malformed ping flag", func(t *testing.T) {
+		recorder := serve("POST", "/configs?validate=maybe",
+			`{"name":"Ledger","event_type":"CREDIT","webhook_url":"https://ledger.example.com/hooks","timeout_ms":5000}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "invalid validate")
+	})
+
+	t.Run("should refuse a config whose URL is required to be reachable and is not", func(t *testing.T) {
+		recorder := serve("POST", "/configs?require_reachable=true",
+			`{"name":"Ledger","event_type":"CREDIT","webhook_url":"https://unreachable.example.com/hooks","timeout_ms":5000}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "webhook URL is unreachable")
+	})
+
+	t.Run("should update the config named in the path", func(t *testing.T) {
+		recorder := serve("PUT", "/configs/12",
+			`{"id":99,"name":"Ledger v2","event_type":"DEBIT","webhook_url":"https://ledger.example.com/v2","timeout_ms":1000,"is_active":false}`)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var response ConfigResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, int64(12), response.ID, "the path ID wins over the body")
+		assert.False(t, response.IsActive)
+	})
+
+	t.Run("should return not found for an unknown config", func(t *testing.T) {
+		update := serve("PUT", "/configs/99", `{"name":"Ledger","event_type":"CREDIT","webhook_url":"https://ledger.example.com","timeout_ms":1000}`)
+		deletion := serve("DELETE", "/configs/99", "")
+
+		assert.Equal(t, http.StatusNotFound, update.Code)
+		assert.Equal(t, http.StatusNotFound, deletion.Code)
+	})
+
+	t.Run("should reject an invalid config ID", func(t *testing.T) {
+		recorder := serve("DELETE", "/configs/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should delete a config", func(t *testing.T) {
+		recorder := serve("DELETE", "/configs/12", "")
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var response DeleteConfigResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		assert.Equal(t, int64(12), response.ConfigID)
+	})
+
+	t.Run("should list configs", func(t *testing.T) {
+		recorder := serve("GET", "/configs", "")
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var response ConfigListResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		require.Len(t, response.Configs, 1)
+		assert.Equal(t, "Ledger", response.Configs[0].Name)
+	})
+}
+
+func TestHTTPHandler_ReplayTo(t *testing.T) {
+	var received services.ReplayToCommand
+	mockAppService := &mockWebhookApplicationService{
+		replayToFunc: func(ctx context.Context, cmd services.ReplayToCommand) (*services.ReplayToResult, error) {
+			if cmd.ConfigID == nil {
+				return nil, fmt.Errorf("%w: filter must narrow the selection", services.ErrInvalidReplay)
+			}
+			received = cmd
+			return &services.ReplayToResult{Success: true, Replayed: 1, Delivered: 1}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 0)
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/admin/replay-to", strings.NewReader(`{"config_id": 3, "url": "https://new.example.com/in"}`)))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should replay the matching webhooks to the requested URL", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/admin/replay-to", strings.NewReader(`{"config_id": 3, "statuses": ["COMPLETED"], "url": "https://new.example.com/in"}`))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, int64(3), *received.ConfigID)
+		assert.Equal(t, []enums.WebhookStatus{enums.WebhookStatusCompleted}, received.Statuses)
+		assert.Equal(t, "https://new.example.com/in", received.URL)
+
+		var response ReplayToResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.Delivered)
+	})
+
+	t.Run("should reject an invalid replay with 400", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/admin/replay-to", strings.NewReader(`{"url": "https://new.example.com/in"}`))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_ProbeLimit(t *testing.T) {
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	mockAppService := &mockWebhookApplicationService{
+		replayToFunc: func(ctx context.Context, cmd services.ReplayToCommand) (*services.ReplayToResult, error) {
+			started <- struct{}{}
+			<-release
+			return &services.ReplayToResult{Success: true}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 2)
+
+	// serve sends an authorized admin request and returns its response code
+	serve := func(method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	replay := func() int {
+		return serve("POST", "/admin/replay-to", `{"config_id": 3, "url": "https://new.example.com/in"}`)
+	}
+
+	// Fill the limit with replays that block until released
+	codes := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func() { codes <- replay() }()
+	}
+	for i := 0; i < 2; i++ {
+		<-started
+	}
+
+	t.Run("should reject replays beyond the limit with 429", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/admin/replay-to", strings.NewReader(`{"config_id": 3, "url": "https://new.example.com/in"}`))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("should share the limit with processing a webhook now", func(t *testing.T) {
+		assert.Equal(t, http.StatusTooManyRequests, serve("POST", "/webhooks/"+uuid.New().String()+"/process", ""))
+	})
+
+	t.Run("should not count requests that fail authorization", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/admin/replay-to", strings.NewReader(`{}`)))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should accept requests again once probes finish", func(t *testing.T) {
+		close(release)
+		assert.Equal(t, http.StatusOK, <-codes)
+		assert.Equal(t, http.StatusOK, <-codes)
+
+		assert.Equal(t, http.StatusOK, replay())
+		assert.Equal(t, http.StatusOK, serve("POST", "/webhooks/"+uuid.New().String()+"/process", ""))
+	})
+}
+
+func TestHTTPHandler_ProbeLimit_ConfigSaves(t *testing.T) {
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	mockAppService := &mockWebhookApplicationService{
+		createConfigFunc: func(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error) {
+			started <- struct{}{}
+			<-release
+			return &services.ConfigResult{ID: 12}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "admin-token", 2)
+
+	// serve sends an authorized admin request and returns its response code
+	serve := func(method, path string) int {
+		req := httptest.NewRequest(method, path,
+			strings.NewReader(`{"name":"Ledger","event_type":"CREDIT","webhook_url":"https://ledger.example.com/hooks","timeout_ms":5000}`))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	// Fill the limit with validated creates that block until released
+	codes := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func() { codes <- serve("POST", "/configs?validate=true") }()
+	}
+	for i := 0; i < 2; i++ {
+		<-started
+	}
+
+	t.Run("should reject validated saves beyond the limit with 429", func(t *testing.T) {
+		assert.Equal(t, http.StatusTooManyRequests, serve("POST", "/configs?validate=true"))
+		assert.Equal(t, http.StatusTooManyRequests, serve("PUT", "/configs/12?require_reachable=true"))
+	})
+
+	t.Run("should share the limit with other probes", func(t *testing.T) {
+		assert.Equal(t, http.StatusTooManyRequests, serve("POST", "/webhooks/"+uuid.New().String()+"/process"))
+	})
+
+	t.Run("should accept saves again once the pings finish", func(t *testing.T) {
+		close(release)
+		assert.Equal(t, http.StatusOK, <-codes)
+		assert.Equal(t, http.StatusOK, <-codes)
+
+		assert.Equal(t, http.StatusOK, serve("POST", "/configs?validate=true"))
+	})
+}
+
+func TestHTTPHandler_ExportWebhook(t *testing.T) {
+	knownID := uuid.New()
+	status := 200
+	mockAppService := &mockWebhookApplicationService{
+		exportWebhookFunc: func(ctx context.Context, queueID uuid.UUID) (*services.WebhookExportResult, error) {
+			if queueID != knownID {
+				return nil, fmt.Errorf("%w: %s", services.ErrWebhookNotFound, queueID)
+			}
+			return &services.WebhookExportResult{
+				QueueID:  queueID.String(),
+				Status:   enums.WebhookStatusCompleted,
+				Attempts: []entities.WebhookAttempt{{RetryLevel: 0, HTTPStatus: &status}},
+				Config:   &services.ConfigSnapshot{ID: 1, WebhookURL: "https://hooks.example.com/in?token=REDACTED"},
+			}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "", 0)
+
+	t.Run("should return the export as a downloadable document", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/webhooks/"+knownID.String()+"/export", nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Header().Get("Content-Disposition"), "webhook-"+knownID.String()+".json")
+
+		var response WebhookExportResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, enums.WebhookStatusCompleted, response.Status)
+		require.Len(t, response.Attempts, 1)
+		assert.Equal(t, 200, *response.Attempts[0].HTTPStatus)
+		assert.Equal(t, "https://hooks.example.com/in?token=REDACTED", response.Config.WebhookURL)
+	})
+
+	t.Run("should return not found for an unknown queue ID", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/webhooks/"+uuid.NewString()+"/export", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("should return bad request for a malformed queue ID", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/webhooks/not-a-uuid/export", nil))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_GetWebhookStatus(t *testing.T) {
+	knownID := uuid.New()
+	status, durationMs := 503, int64(120)
+	nextRetryAt := time.Now().Add(5 * time.Minute).UTC()
+	mockAppService := &mockWebhookApplicationService{
+		getWebhookStatusFunc: func(ctx context.Context, queueID uuid.UUID) (*services.WebhookStatusResult, error) {
+			if queueID != knownID {
+				return nil, fmt.Errorf("%w: %s", services.ErrWebhookNotFound, queueID)
+			}
+			return &services.WebhookStatusResult{
+				QueueID:        queueID,
+				Status:         enums.WebhookStatusPending,
+				RetryCount:     1,
+				LastHTTPStatus: 503,
+				LastError:      "HTTP 503: Service Unavailable",
+				NextRetryAt:    &nextRetryAt,
+				Attempts:       []services.AttemptTiming{{RetryLevel: 0, DurationMs: &durationMs, HTTPStatus: &status}},
+			}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "", 0)
+
+	t.Run("should return the webhook's status and attempt timings", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/webhooks/"+knownID.String(), nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var response WebhookStatusResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, knownID, response.QueueID)
+		assert.Equal(t, enums.WebhookStatusPending, response.Status)
+		assert.Equal(t, 1, response.RetryCount)
+		assert.Equal(t, 503, response.LastHTTPStatus)
+		assert.Equal(t, "HTTP 503: Service Unavailable", response.LastError)
+		require.Len(t, response.Attempts, 1)
+		assert.Equal(t, int64(120), *response.Attempts[0].DurationMs)
+	})
+
+	t.Run("should return not found for an unknown queue ID", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/webhooks/"+uuid.NewString(), nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("should return bad request for a malformed queue ID", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/webhooks/not-a-uuid", nil))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestHTTPHandler_ListWebhooks(t *testing.T) {
+	var received services.ListWebhooksQuery
+	queueID := uuid.New()
+	mockAppService := &mockWebhookApplicationService{
+		listWebhooksFunc: func(ctx context.Context, query services.ListWebhooksQuery) (*services.WebhookListResult, error) {
+			received = query
+			if query.Status == "LOST" {
+				return nil, fmt.Errorf("%w: unknown status %q", services.ErrInvalidListQuery, query.Status)
+			}
+			return &services.WebhookListResult{
+				Items:      []services.WebhookListItem{{QueueID: queueID, Status: enums.WebhookStatusFailed, RetryCount: 6}},
+				Pagination: services.Pagination{Limit: 50, Offset: 0, Total: 1},
+			}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "", 0)
+
+	t.Run("should decode the filters and page and return the items with pagination", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET",
+			"/webhooks?status=FAILED&event_type=CREDIT&config_id=4&created_after=2026-01-01T00:00:00Z&created_before=2026-01-02T00:00:00Z&limit=50&offset=0", nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, enums.WebhookStatusFailed, received.Status)
+		assert.Equal(t, enums.EventTypeCredit, received.EventType)
+		require.NotNil(t, received.ConfigID)
+		assert.Equal(t, int64(4), *received.ConfigID)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *received.CreatedAfter)
+		assert.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), *received.CreatedBefore)
+		assert.Equal(t, 50, received.Limit)
+
+		var response WebhookListResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		require.Len(t, response.Items, 1)
+		assert.Equal(t, queueID, response.Items[0].QueueID)
+		assert.Equal(t, int64(1), response.Pagination.Total)
+	})
+
+	t.Run("should list without filters", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/webhooks", nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, services.ListWebhooksQuery{}, received)
+	})
+
+	for name, target := range map[string]string{
+		"a malformed config ID":        "/webhooks?config_id=abc",
+		"a malformed time":             "/webhooks?created_after=yesterday",
+		"a negative offset":            "/webhooks?offset=-1",
+		"a non-numeric page size":      "/webhooks?limit=all",
+		"a status the service rejects": "/webhooks?status=LOST",
+	} {
+		t.Run("should return bad request for "+name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, httptest.NewRequest("GET", target, nil))
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		})
+	}
+}
+
+func TestHTTPHandler_GetEventStatus(t *testing.T) {
+	mockAppService := &mockWebhookApplicationService{
+		getEventStatusFunc: func(ctx context.Context, eventID string) (*services.EventStatusResult, error) {
+			if eventID != "evt-1" {
+				return nil, fmt.Errorf("%w: %s", services.ErrEventNotFound, eventID)
+			}
+			return &services.EventStatusResult{
+				EventID:   eventID,
+				AnyFailed: true,
+				Total:     2,
+				Succeeded: 1,
+				Failed:    1,
+				Webhooks: []services.EventWebhook{
+					{QueueID: uuid.New(), ConfigID: 1, Status: enums.WebhookStatusCompleted},
+					{QueueID: uuid.New(), ConfigID: 2, Status: enums.WebhookStatusFailed, LastHTTPStatus: 503},
+				},
+			}, nil
+		},
+	}
+	handler := NewHTTPHandler(NewService(mockAppService, nil), log.NewNopLogger(), "", 0)
+
+	t.Run("should return the aggregated status with per-config detail", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/events/evt-1", nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var response EventStatusResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "evt-1", response.EventID)
+		assert.False(t, response.AllSucceeded)
+		assert.True(t, response.AnyFailed)
+		require.Len(t, response.Webhooks, 2)
+		assert.Equal(t, enums.WebhookStatusFailed, response.Webhooks[1].Status)
+		assert.Equal(t, 503, response.Webhooks[1].LastHTTPStatus)
+	})
+
+	t.Run("should return not found for an unknown event", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/events/evt-missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
 // Benchmark tests
 func BenchmarkHTTPHandler_CreateWebhook(b *testing.B) {
 	// Setup
 	mockAppService := &mockWebhookApplicationService{}
-	httpService := NewService(mockAppService)
+	httpService := NewService(mockAppService, nil)
 	logger := log.NewNopLogger()
-	handler := NewHTTPHandler(httpService, logger)
+	handler := NewHTTPHandler(httpService, logger, "", 0)
 
 	reqBody := CreateWebhookRequest{
 		EventType: enums.EventTypeCredit,
@@ -307,9 +1476,9 @@ func BenchmarkHTTPHandler_CreateWebhook(b *testing.B) {
 func BenchmarkHTTPHandler_GetHealth(b *testing.B) {
 	// Setup
 	mockAppService := &mockWebhookApplicationService{}
-	httpService := NewService(mockAppService)
+	httpService := NewService(mockAppService, nil)
 	logger := log.NewNopLogger()
-	handler := NewHTTPHandler(httpService, logger)
+	handler := NewHTTPHandler(httpService, logger, "", 0)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"time"
 
@@ -49,7 +50,7 @@ func loggingMiddleware(logger log.Logger) func(http.Handler) http.Handler {
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 		if r.Method == "OPTIONS" {
@@ -82,3 +83,48 @@ func recoveryMiddleware(logger log.Logger) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// probeLimitMiddleware caps concurrent requests that send webhooks outside the worker pools, so a script
+// hitting them in a loop cannot open unbounded outbound connections; excess requests get 429 at once
+// Every handler wrapped by one returned middleware shares its limit, which is disabled when not positive
+func probeLimitMiddleware(limit int) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	slots := make(chan struct{}, limit)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error": "Too many concurrent outbound probes", "success": false}`))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// adminAuthMiddleware requires the admin bearer token; admin routes are disabled when the token is empty
+func adminAuthMiddleware(adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := r.Header.Get("Authorization")
+			expected := "Bearer " + adminToken
+
+			if adminToken == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) != 1 {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": "Unauthorized", "success": false}`))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"webhook-processor/internal/application/services"
+	"webhook-processor/internal/domain/enums"
+)
+
+// ErrorResponse is the JSON body of every failed API request
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Success bool   `json:"success"`
+}
+
+// openAPIRoute describes one operation of the API for the OpenAPI document
+type openAPIRoute struct {
+	method      string
+	path        string
+	summary     string
+	admin       bool                // Requires the admin bearer token
+	query       []openAPIQueryParam // Optional query string parameters
+	request     interface{}         // JSON body DTO, nil when the route takes no body
+	response    interface{}         // 200 response DTO
+	contentType string              // 200 response content type, defaults to application/json
+	errors      []int               // Error statuses answered with ErrorResponse
+}
+
+// openAPIQueryParam is one optional query string parameter of a route
+type openAPIQueryParam struct {
+	name   string
+	schema map[string]interface{}
+}
+
+// listWebhooksQuery describes the filters and page of GET /webhooks
+var listWebhooksQuery = []openAPIQueryParam{
+	{name: "status", schema: map[string]interface{}{"type": "string", "enum": openAPIEnums[reflect.TypeOf(enums.WebhookStatus(""))]}},
+	{name: "event_type", schema: map[string]interface{}{"type": "string", "enum": openAPIEnums[reflect.TypeOf(enums.EventType(""))]}},
+	{name: "config_id", schema: map[string]interface{}{"type": "integer", "format": "int64", "minimum": 1}},
+	{name: "created_after", schema: map[string]interface{}{"type": "string", "format": "date-time"}},
+	{name: "created_before", schema: map[string]interface{}{"type": "string", "format": "date-time"}},
+	{name: "limit", schema: map[string]interface{}{"type": "integer", "minimum": 0, "maximum": services.MaxWebhookPageSize}},
+	{name: "offset", schema: map[string]interface{}{"type": "integer", "minimum": 0}},
+}
+
+// saveConfigQuery describes the ping flags of POST /configs and PUT /configs/{id}
+var saveConfigQuery = []openAPIQueryParam{
+	{name: "validate", schema: map[string]interface{}{"type": "boolean"}},
+	{name: "require_reachable", schema: map[string]interface{}{"type": "boolean"}},
+}
+
+// openAPIRoutes lists every route registered by NewHTTPHandler
+var openAPIRoutes = []openAPIRoute{
+	{method: "POST", path: "/webhooks", summary: "Queue a webhook for delivery",
+		request: CreateWebhookRequest{}, response: CreateWebhookResponse{}, errors: []int{400, 404, 500, 503}},
+	{method: "GET", path: "/webhooks", summary: "Page through webhooks, newest first, filtered by status, event type, config and creation time",
+		query: listWebhooksQuery, response: WebhookListResponse{}, errors: []int{400, 500}},
+	{method: "GET", path: "/health", summary: "Service health", response: HealthResponse{}},
+	{method: "GET", path: "/configs/{id}/stats", summary: "Delivery statistics of a webhook config",
+		response: ConfigStatsResponse{}, errors: []int{400, 404, 500}},
+	{method: "GET", path: "/events/{eventID}", summary: "Delivery status of an event across every config it fanned out to",
+		response: EventStatusResponse{}, errors: []int{400, 404, 500}},
+	{method: "GET", path: "/webhooks/{queueID}", summary: "Status of a webhook with its attempt timings and remaining retries",
+		response: WebhookStatusResponse{}, errors: []int{400, 404, 500}},
+	{method: "GET", path: "/webhooks/{queueID}/export", summary: "Export a webhook's lifecycle with secrets redacted",
+		response: WebhookExportResponse{}, errors: []int{400, 404, 500}},
+	{method: "GET", path: "/workers/cluster", summary: "Workers alive across instances, from their heartbeats",
+		response: WorkerClusterResponse{}, errors: []int{500}},
+	{method: "GET", path: "/metrics", summary: "Prometheus metrics", contentType: "text/plain"},
+	{method: "GET", path: "/openapi.json", summary: "This OpenAPI document", response: map[string]interface{}{}},
+	{method: "POST", path: "/webhooks/{queueID}/fail", summary: "Mark a webhook as failed", admin: true,
+		request: ForceFailWebhookRequest{}, response: ForceFailWebhookResponse{}, errors: []int{400, 404, 409, 500}},
+	{method: "POST", path: "/webhooks/{queueID}/process", summary: "Run one delivery attempt immediately", admin: true,
+		response: ProcessWebhookResponse{}, errors: []int{400, 404, 409, 429, 500}},
+	{method: "DELETE", path: "/webhooks/{queueID}", summary: "Permanently delete a webhook", admin: true,
+		response: DeleteWebhookResponse{}, errors: []int{400, 404, 500}},
+	{method: "GET", path: "/configs", summary: "Every webhook config that has not been deleted", admin: true,
+		response: ConfigListResponse{}, errors: []int{500}},
+	{method: "POST", path: "/configs", summary: "Register a webhook config", admin: true,
+		query: saveConfigQuery, request: SaveConfigRequest{}, response: ConfigResponse{}, errors: []int{400, 422, 500}},
+	{method: "PUT", path: "/configs/{id}", summary: "Replace a webhook config's name, event type, URL, timeout and active flag", admin: true,
+		query: saveConfigQuery, request: SaveConfigRequest{}, response: ConfigResponse{}, errors: []int{400, 404, 422, 500}},
+	{method: "DELETE", path: "/configs/{id}", summary: "Soft-delete a webhook config; queued webhooks are still delivered", admin: true,
+		response: DeleteConfigResponse{}, errors: []int{400, 404, 500}},
+	{method: "GET", path: "/debug/config", summary: "Effective configuration with secrets redacted", admin: true,
+		response: DebugConfigResponse{}},
+	{method: "POST", path: "/admin/webhooks/bulk-status", summary: "Move matching webhooks to a new status", admin: true,
+		request: BulkUpdateStatusRequest{}, response: BulkUpdateStatusResponse{}, errors: []int{400, 500}},
+	{method: "GET", path: "/admin/processing", summary: "Webhooks currently in PROCESSING, flagging overdue claims", admin: true,
+		response: ProcessingWebhooksResponse{}, errors: []int{500}},
+	{method: "POST", path: "/admin/replay-to", summary: "Re-send matching webhooks once to a different URL, leaving them untouched", admin: true,
+		request: ReplayToRequest{}, response: ReplayToResponse{}, errors: []int{400, 429, 500}},
+}
+
+// openAPIEnums lists the allowed values of string enums used in the DTOs
+var openAPIEnums = map[reflect.Type][]string{
+	reflect.TypeOf(enums.EventType("")): {string(enums.EventTypeCredit), string(enums.EventTypeDebit)},
+	reflect.TypeOf(enums.WebhookStatus("")): {
+		string(enums.WebhookStatusPending), string(enums.WebhookStatusProcessing),
+		string(enums.WebhookStatusCompleted), string(enums.WebhookStatusFailed),
+	},
+}
+
+// openAPIHandler serves the OpenAPI 3 document describing the API; the document is built once
+func openAPIHandler() http.HandlerFunc {
+	document, err := buildOpenAPIDocument()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			encodeError(r.Context(), fmt.Errorf("failed to build OpenAPI document: %w", err), w)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(document)
+	}
+}
+
+// buildOpenAPIDocument renders the OpenAPI 3 document for openAPIRoutes
+// Schemas are derived from the DTO structs' JSON tags so they cannot drift from the wire format
+func buildOpenAPIDocument() ([]byte, error) {
+	schemas := newSchemaRegistry()
+	errorSchema := schemas.schemaFor(reflect.TypeOf(ErrorResponse{}))
+
+	paths := map[string]map[string]interface{}{}
+	for _, route := range openAPIRoutes {
+		operation := map[string]interface{}{
+			"summary":   route.summary,
+			"responses": openAPIResponses(route, schemas, errorSchema),
+		}
+		params := openAPIPathParameters(route.path)
+		for _, param := range route.query {
+			params = append(params, map[string]interface{}{"name": param.name, "in": "query", "schema": param.schema})
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+		if route.request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(route.request))},
+				},
+			}
+		}
+		if route.admin {
+			operation["security"] = []map[string][]string{{"adminToken": {}}}
+		}
+
+		if paths[route.path] == nil {
+			paths[route.path] = map[string]interface{}{}
+		}
+		paths[route.path][strings.ToLower(route.method)] = operation
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Webhook Processor API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				"adminToken": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+	})
+}
+
+// openAPIResponses describes the success and error responses of a route
+func openAPIResponses(route openAPIRoute, schemas *schemaRegistry, errorSchema map[string]interface{}) map[string]interface{} {
+	contentType := route.contentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	success := map[string]interface{}{"description": "OK"}
+	if route.response != nil {
+		success["content"] = map[string]interface{}{
+			contentType: map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(route.response))},
+		}
+	} else {
+		success["content"] = map[string]interface{}{
+			contentType: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+		}
+	}
+
+	responses := map[string]interface{}{"200": success}
+	statuses := route.errors
+	if route.admin {
+		statuses = append([]int{http.StatusUnauthorized}, statuses...)
+	}
+	for _, status := range statuses {
+		responses[strconv.Itoa(status)] = map[string]interface{}{
+			"description": http.StatusText(status),
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": errorSchema},
+			},
+		}
+	}
+	return responses
+}
+
+// openAPIPathParameters describes the {name} segments of a route path
+func openAPIPathParameters(path string) []map[string]interface{} {
+	var params []map[string]interface{}
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.Trim(segment, "{}")
+		schema := map[string]interface{}{"type": "string", "format": "uuid"}
+		switch name {
+		case "id":
+			schema = map[string]interface{}{"type": "integer", "format": "int64", "minimum": 1}
+		case "eventID":
+			schema = map[string]interface{}{"type": "string"}
+		}
+		params = append(params, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   schema,
+		})
+	}
+	return params
+}
+
+// schemaRegistry builds JSON schemas for Go types, collecting named structs as reusable components
+type schemaRegistry struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+// newSchemaRegistry creates an empty schema registry
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		components: map[string]interface{}{},
+		names:      map[reflect.Type]string{},
+	}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+)
+
+// schemaFor returns the schema of t; named structs are returned as a $ref to their component
+func (s *schemaRegistry) schemaFor(t reflect.Type) map[string]interface{} {
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case durationType:
+		return map[string]interface{}{"type": "integer", "format": "int64", "description": "Duration in nanoseconds"}
+	case uuidType:
+		return map[string]interface{}{"type": "string", "format": "uuid"}
+	}
+	if values, ok := openAPIEnums[t]; ok {
+		return map[string]interface{}{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := s.schemaFor(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return s.structRef(t)
+	default:
+		// interface{} and anything else JSON can carry
+		return map[string]interface{}{}
+	}
+}
+
+// structRef registers t as a component and returns a reference to it
+func (s *schemaRegistry) structRef(t reflect.Type) map[string]interface{} {
+	name, ok := s.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := s.components[name]; taken || name == "" {
+			name = strings.ReplaceAll(t.String(), ".", "_")
+		}
+		s.names[t] = name
+		// Reserve the name first so self-referencing types terminate
+		s.components[name] = map[string]interface{}{}
+		s.components[name] = s.structSchema(t)
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// structSchema describes a struct's JSON fields; embedded structs without a tag are flattened like encoding/json does
+func (s *schemaRegistry) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	s.addFields(t, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// addFields adds the exported JSON fields of t to properties
+func (s *schemaRegistry) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.addFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = s.schemaFor(field.Type)
+		if strings.Contains(field.Tag.Get("validate"), "required") {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_OpenAPI(t *testing.T) {
+	handler := NewHTTPHandler(NewService(&mockWebhookApplicationService{}, nil), log.NewNopLogger(), "", 0)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var spec struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &spec))
+
+	t.Run("should be an OpenAPI 3 document listing the core routes", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(spec.OpenAPI, "3."))
+		assert.Contains(t, spec.Paths["/webhooks"], "post")
+		assert.Contains(t, spec.Paths["/health"], "get")
+	})
+
+	t.Run("should describe every registered route and nothing else", func(t *testing.T) {
+		var registered []string
+		err := handler.(*mux.Router).Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+			path, err := route.GetPathTemplate()
+			if err != nil {
+				return err
+			}
+			methods, err := route.GetMethods()
+			if err != nil {
+				// Path prefixes for subrouters have no methods of their own
+				return nil
+			}
+			for _, method := range methods {
+				registered = append(registered, strings.ToLower(method)+" "+path)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		var documented []string
+		for path, operations := range spec.Paths {
+			for method := range operations {
+				documented = append(documented, method+" "+path)
+			}
+		}
+
+		sort.Strings(registered)
+		sort.Strings(documented)
+		assert.Equal(t, registered, documented)
+	})
+
+	t.Run("should resolve every schema reference", func(t *testing.T) {
+		for _, ref := range strings.Split(recorder.Body.String(), `"$ref":"#/components/schemas/`)[1:] {
+			name := ref[:strings.Index(ref, `"`)]
+			assert.Contains(t, spec.Components.Schemas, name)
+		}
+	})
+
+	t.Run("should reuse the DTOs and the error envelope", func(t *testing.T) {
+		var request struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+			Required   []string                   `json:"required"`
+		}
+		require.NoError(t, json.Unmarshal(spec.Components.Schemas["CreateWebhookRequest"], &request))
+		assert.Contains(t, request.Properties, "event_type")
+		assert.Contains(t, request.Properties, "metadata")
+		assert.Equal(t, []string{"event_type"}, request.Required)
+
+		var errorResponse struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(spec.Components.Schemas["ErrorResponse"], &errorResponse))
+		assert.Contains(t, errorResponse.Properties, "error")
+		assert.Contains(t, errorResponse.Properties, "success")
+	})
+
+	t.Run("should describe event IDs as free-form path parameters", func(t *testing.T) {
+		var operation struct {
+			Parameters []struct {
+				Name   string                 `json:"name"`
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"parameters"`
+		}
+		require.NoError(t, json.Unmarshal(spec.Paths["/events/{eventID}"]["get"], &operation))
+		require.Len(t, operation.Parameters, 1)
+		assert.Equal(t, "eventID", operation.Parameters[0].Name)
+		assert.Equal(t, map[string]interface{}{"type": "string"}, operation.Parameters[0].Schema)
+	})
+}
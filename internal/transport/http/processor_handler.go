@@ -0,0 +1,142 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"webhook-processor/internal/domain/enums"
+	"webhook-processor/internal/infrastructure/metrics"
+)
+
+// Drainer is implemented by components that can stop taking new work ahead of a shutdown
+type Drainer interface {
+	Drain()
+	IsDraining() bool
+}
+
+// DatabaseHealth reports whether workers have stopped polling because the database is unreachable
+type DatabaseHealth interface {
+	IsDBDown() bool
+}
+
+// QueueDepthCounter reports how many webhooks currently have a given status
+type QueueDepthCounter interface {
+	CountByStatus(ctx context.Context, status enums.WebhookStatus) (int64, error)
+}
+
+// queueDepthStatuses are the statuses reported in the JSON metrics snapshot
+var queueDepthStatuses = []enums.WebhookStatus{
+	enums.WebhookStatusPending,
+	enums.WebhookStatusProcessing,
+	enums.WebhookStatusCompleted,
+	enums.WebhookStatusFailed,
+}
+
+// NewProcessorHandler creates the processor's operational handler: metrics, readiness and drain
+// The drain route requires adminToken as a bearer token and is disabled when it is empty
+func NewProcessorHandler(drainer Drainer, dbHealth DatabaseHealth, queueDepth QueueDepthCounter, logger log.Logger, adminToken string) http.Handler {
+	router := mux.NewRouter()
+
+	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	router.HandleFunc("/metrics.json", metricsJSONHandler(prometheus.DefaultGatherer, queueDepth, logger)).Methods("GET")
+	router.HandleFunc("/ready", readyHandler(drainer, dbHealth)).Methods("GET")
+
+	adminRouter := router.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(adminAuthMiddleware(adminToken))
+	adminRouter.HandleFunc("/drain", drainHandler(drainer, logger)).Methods("POST")
+
+	router.Use(loggingMiddleware(logger))
+	router.Use(recoveryMiddleware(logger))
+
+	return router
+}
+
+// readyHandler reports 503 while draining or while the database is unreachable, so load balancers
+// stop routing to the instance
+func readyHandler(drainer Drainer, dbHealth DatabaseHealth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, code := "ready", http.StatusOK
+		if drainer.IsDraining() {
+			status, code = "draining", http.StatusServiceUnavailable
+		} else if dbHealth.IsDBDown() {
+			status, code = "database_unreachable", http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]string{"status": status})
+	}
+}
+
+// drainHandler puts the instance into drain mode; repeated calls are no-ops
+func drainHandler(drainer Drainer, logger log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		drainer.Drain()
+		logger.Log("level", "info", "msg", "drain requested", "remote_addr", r.RemoteAddr)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "draining: new webhooks will not be picked up",
+		})
+	}
+}
+
+// metricsJSONHandler serves a compact JSON snapshot of the key delivery metrics for consumers
+// that do not scrape Prometheus; it reads the same collectors as /metrics
+// The last interval covers the time since the previous snapshot was served
+func metricsJSONHandler(gatherer prometheus.Gatherer, queueDepth QueueDepthCounter, logger log.Logger) http.HandlerFunc {
+	var (
+		mu             sync.Mutex
+		lastDeliveries float64
+		lastServedAt   = time.Now().UTC()
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		depth := make(map[string]int64, len(queueDepthStatuses))
+		for _, status := range queueDepthStatuses {
+			count, err := queueDepth.CountByStatus(r.Context(), status)
+			if err != nil {
+				logger.Log("level", "error", "msg", "failed to count webhooks for metrics snapshot",
+					"status", status, "error", err)
+				encodeError(r.Context(), err, w)
+				return
+			}
+			depth[string(status)] = count
+		}
+
+		summary, err := metrics.Summarize(gatherer)
+		if err != nil {
+			logger.Log("level", "error", "msg", "failed to summarize metrics", "error", err)
+			encodeError(r.Context(), err, w)
+			return
+		}
+
+		mu.Lock()
+		now := time.Now().UTC()
+		interval := now.Sub(lastServedAt)
+		deliveriesInInterval := summary.Deliveries - lastDeliveries
+		lastDeliveries, lastServedAt = summary.Deliveries, now
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"queue_depth":              depth,
+			"deliveries_total":         summary.Deliveries,
+			"deliveries_last_interval": deliveriesInInterval,
+			"interval_seconds":         interval.Seconds(),
+			"success_rate":             summary.SuccessRate(),
+			"in_flight":                summary.InFlight,
+		})
+	}
+}
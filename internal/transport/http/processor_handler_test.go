@@ -0,0 +1,141 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"webhook-processor/internal/domain/enums"
+)
+
+// fakeDrainer records drain requests for handler tests
+type fakeDrainer struct {
+	draining atomic.Bool
+}
+
+func (d *fakeDrainer) Drain()           { d.draining.Store(true) }
+func (d *fakeDrainer) IsDraining() bool { return d.draining.Load() }
+
+// fakeDatabaseHealth reports a settable database state for handler tests
+type fakeDatabaseHealth struct {
+	down atomic.Bool
+}
+
+func (h *fakeDatabaseHealth) IsDBDown() bool { return h.down.Load() }
+
+// fakeQueueDepth reports a fixed count per status for handler tests
+type fakeQueueDepth map[enums.WebhookStatus]int64
+
+func (f fakeQueueDepth) CountByStatus(ctx context.Context, status enums.WebhookStatus) (int64, error) {
+	return f[status], nil
+}
+
+func TestProcessorHandler_Drain(t *testing.T) {
+	drainer := &fakeDrainer{}
+	handler := NewProcessorHandler(drainer, &fakeDatabaseHealth{}, fakeQueueDepth{}, log.NewNopLogger(), "admin-token")
+
+	ready := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/ready", nil))
+		return recorder
+	}
+
+	t.Run("should report ready before draining", func(t *testing.T) {
+		recorder := ready()
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"ready"`)
+	})
+
+	t.Run("should reject drain requests without the admin token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/admin/drain", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.False(t, drainer.IsDraining())
+	})
+
+	t.Run("should drain and report not ready", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/admin/drain", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusAccepted, recorder.Code)
+		assert.True(t, drainer.IsDraining())
+
+		recorder = ready()
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"draining"`)
+	})
+
+	t.Run("should keep serving metrics while draining", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestProcessorHandler_ReadyDatabaseHealth(t *testing.T) {
+	dbHealth := &fakeDatabaseHealth{}
+	handler := NewProcessorHandler(&fakeDrainer{}, dbHealth, fakeQueueDepth{}, log.NewNopLogger(), "admin-token")
+
+	ready := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/ready", nil))
+		return recorder
+	}
+
+	t.Run("should report not ready while the database is unreachable", func(t *testing.T) {
+		dbHealth.down.Store(true)
+
+		recorder := ready()
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"database_unreachable"`)
+	})
+
+	t.Run("should report ready once the database recovers", func(t *testing.T) {
+		dbHealth.down.Store(false)
+
+		recorder := ready()
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"status":"ready"`)
+	})
+}
+
+func TestProcessorHandler_MetricsJSON(t *testing.T) {
+	queueDepth := fakeQueueDepth{enums.WebhookStatusPending: 7, enums.WebhookStatusFailed: 2}
+	handler := NewProcessorHandler(&fakeDrainer{}, &fakeDatabaseHealth{}, queueDepth, log.NewNopLogger(), "admin-token")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics.json", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+	for _, key := range []string{"deliveries_total", "deliveries_last_interval", "interval_seconds", "success_rate", "in_flight"} {
+		assert.IsType(t, float64(0), body[key], "%s should be numeric", key)
+	}
+
+	depth, ok := body["queue_depth"].(map[string]interface{})
+	require.True(t, ok, "queue_depth should be an object")
+	for _, status := range []string{"PENDING", "PROCESSING", "COMPLETED", "FAILED"} {
+		assert.IsType(t, float64(0), depth[status], "queue_depth.%s should be numeric", status)
+	}
+	assert.Equal(t, float64(7), depth["PENDING"])
+	assert.Equal(t, float64(2), depth["FAILED"])
+}
@@ -2,8 +2,10 @@ package http
 
 import (
 	"context"
+	"fmt"
 
 	"webhook-processor/internal/application/services"
+	"webhook-processor/internal/config"
 )
 
 // Service defines the interface for HTTP transport operations
@@ -13,17 +15,70 @@ type Service interface {
 
 	// GetHealth handles health check requests
 	GetHealth(ctx context.Context) (HealthResponse, error)
+
+	// GetDebugConfig returns the effective configuration with secrets redacted
+	GetDebugConfig(ctx context.Context) (DebugConfigResponse, error)
+
+	// BulkUpdateStatus handles administrative bulk status update requests
+	BulkUpdateStatus(ctx context.Context, req BulkUpdateStatusRequest) (BulkUpdateStatusResponse, error)
+
+	// ForceFailWebhook handles administrative requests to mark one webhook as failed
+	ForceFailWebhook(ctx context.Context, req ForceFailWebhookRequest) (ForceFailWebhookResponse, error)
+
+	// ProcessWebhook handles administrative requests to run one delivery attempt immediately
+	ProcessWebhook(ctx context.Context, req ProcessWebhookRequest) (ProcessWebhookResponse, error)
+
+	// DeleteWebhook handles administrative requests to permanently delete a webhook
+	DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) (DeleteWebhookResponse, error)
+
+	// GetConfigStats handles webhook config delivery statistics requests
+	GetConfigStats(ctx context.Context, req GetConfigStatsRequest) (ConfigStatsResponse, error)
+
+	// GetEventStatus handles event delivery status requests
+	GetEventStatus(ctx context.Context, req GetEventStatusRequest) (EventStatusResponse, error)
+
+	// GetWebhookStatus handles single webhook status requests
+	GetWebhookStatus(ctx context.Context, req GetWebhookStatusRequest) (WebhookStatusResponse, error)
+
+	// ListWebhooks handles paginated webhook listing requests
+	ListWebhooks(ctx context.Context, req ListWebhooksRequest) (WebhookListResponse, error)
+
+	// ExportWebhook handles webhook lifecycle export requests
+	ExportWebhook(ctx context.Context, req ExportWebhookRequest) (WebhookExportResponse, error)
+
+	// GetWorkerCluster handles requests for the workers alive across instances
+	GetWorkerCluster(ctx context.Context) (WorkerClusterResponse, error)
+
+	// GetProcessingWebhooks handles administrative requests for the webhooks currently in PROCESSING
+	GetProcessingWebhooks(ctx context.Context) (ProcessingWebhooksResponse, error)
+
+	// ReplayTo handles administrative requests to re-send webhooks to a different URL
+	ReplayTo(ctx context.Context, req ReplayToRequest) (ReplayToResponse, error)
+
+	// CreateConfig handles administrative requests to register a webhook config
+	CreateConfig(ctx context.Context, req SaveConfigRequest) (ConfigResponse, error)
+
+	// UpdateConfig handles administrative requests to replace a webhook config
+	UpdateConfig(ctx context.Context, req SaveConfigRequest) (ConfigResponse, error)
+
+	// DeleteConfig handles administrative requests to delete a webhook config
+	DeleteConfig(ctx context.Context, req DeleteConfigRequest) (DeleteConfigResponse, error)
+
+	// ListConfigs handles administrative requests for every webhook config
+	ListConfigs(ctx context.Context) (ConfigListResponse, error)
 }
 
 // service implements the Service interface
 type service struct {
 	appService services.WebhookApplicationService
+	cfg        *config.Config
 }
 
 // NewService creates a new HTTP transport service
-func NewService(appService services.WebhookApplicationService) Service {
+func NewService(appService services.WebhookApplicationService, cfg *config.Config) Service {
 	return &service{
 		appService: appService,
+		cfg:        cfg,
 	}
 }
 
@@ -62,3 +117,235 @@ func (s *service) GetHealth(ctx context.Context) (HealthResponse, error) {
 
 	return response, nil
 }
+
+// GetDebugConfig handles HTTP effective configuration requests
+func (s *service) GetDebugConfig(ctx context.Context) (DebugConfigResponse, error) {
+	if s.cfg == nil {
+		return DebugConfigResponse{}, fmt.Errorf("configuration not available")
+	}
+
+	return DebugConfigResponse{Config: s.cfg.Redacted()}, nil
+}
+
+// BulkUpdateStatus handles HTTP bulk status update requests
+func (s *service) BulkUpdateStatus(ctx context.Context, req BulkUpdateStatusRequest) (BulkUpdateStatusResponse, error) {
+	result, err := s.appService.BulkUpdateStatus(ctx, req.ToApplicationCommand())
+	if err != nil {
+		return BulkUpdateStatusResponse{
+			Success: false,
+			Message: "Failed to update webhook status: " + err.Error(),
+		}, err
+	}
+
+	var response BulkUpdateStatusResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// ForceFailWebhook handles HTTP requests to mark one webhook as failed
+func (s *service) ForceFailWebhook(ctx context.Context, req ForceFailWebhookRequest) (ForceFailWebhookResponse, error) {
+	result, err := s.appService.ForceFailWebhook(ctx, req.ToApplicationCommand())
+	if err != nil {
+		return ForceFailWebhookResponse{
+			Success: false,
+			Message: "Failed to force-fail webhook: " + err.Error(),
+			QueueID: req.QueueID.String(),
+		}, err
+	}
+
+	var response ForceFailWebhookResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// ProcessWebhook handles HTTP requests to run one delivery attempt immediately
+func (s *service) ProcessWebhook(ctx context.Context, req ProcessWebhookRequest) (ProcessWebhookResponse, error) {
+	result, err := s.appService.ProcessWebhookNow(ctx, req.QueueID)
+	if err != nil {
+		return ProcessWebhookResponse{
+			Success: false,
+			Message: "Failed to process webhook: " + err.Error(),
+			QueueID: req.QueueID.String(),
+		}, err
+	}
+
+	var response ProcessWebhookResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// DeleteWebhook handles HTTP requests to permanently delete a webhook
+func (s *service) DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) (DeleteWebhookResponse, error) {
+	result, err := s.appService.DeleteWebhook(ctx, req.QueueID)
+	if err != nil {
+		return DeleteWebhookResponse{
+			Success: false,
+			Message: "Failed to delete webhook: " + err.Error(),
+			QueueID: req.QueueID.String(),
+		}, err
+	}
+
+	var response DeleteWebhookResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// GetConfigStats handles HTTP webhook config statistics requests
+func (s *service) GetConfigStats(ctx context.Context, req GetConfigStatsRequest) (ConfigStatsResponse, error) {
+	result, err := s.appService.GetConfigStats(ctx, req.ConfigID)
+	if err != nil {
+		return ConfigStatsResponse{}, err
+	}
+
+	var response ConfigStatsResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// CreateConfig handles HTTP requests to register a webhook config
+func (s *service) CreateConfig(ctx context.Context, req SaveConfigRequest) (ConfigResponse, error) {
+	result, err := s.appService.CreateConfig(ctx, req.ToApplicationCommand())
+	if err != nil {
+		return ConfigResponse{}, err
+	}
+
+	var response ConfigResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// UpdateConfig handles HTTP requests to replace a webhook config
+func (s *service) UpdateConfig(ctx context.Context, req SaveConfigRequest) (ConfigResponse, error) {
+	result, err := s.appService.UpdateConfig(ctx, req.ToApplicationCommand())
+	if err != nil {
+		return ConfigResponse{}, err
+	}
+
+	var response ConfigResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// DeleteConfig handles HTTP requests to delete a webhook config
+func (s *service) DeleteConfig(ctx context.Context, req DeleteConfigRequest) (DeleteConfigResponse, error) {
+	result, err := s.appService.DeleteConfig(ctx, req.ConfigID)
+	if err != nil {
+		return DeleteConfigResponse{}, err
+	}
+
+	var response DeleteConfigResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// ListConfigs handles HTTP requests for every webhook config
+func (s *service) ListConfigs(ctx context.Context) (ConfigListResponse, error) {
+	result, err := s.appService.ListConfigs(ctx)
+	if err != nil {
+		return ConfigListResponse{}, err
+	}
+
+	var response ConfigListResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// GetEventStatus handles HTTP requests for an event's aggregated delivery status
+func (s *service) GetEventStatus(ctx context.Context, req GetEventStatusRequest) (EventStatusResponse, error) {
+	result, err := s.appService.GetEventStatus(ctx, req.EventID)
+	if err != nil {
+		return EventStatusResponse{}, err
+	}
+
+	var response EventStatusResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// GetWebhookStatus handles HTTP requests for one webhook's status
+func (s *service) GetWebhookStatus(ctx context.Context, req GetWebhookStatusRequest) (WebhookStatusResponse, error) {
+	result, err := s.appService.GetWebhookStatus(ctx, req.QueueID)
+	if err != nil {
+		return WebhookStatusResponse{}, err
+	}
+
+	var response WebhookStatusResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// ListWebhooks handles HTTP requests for one page of webhooks
+func (s *service) ListWebhooks(ctx context.Context, req ListWebhooksRequest) (WebhookListResponse, error) {
+	result, err := s.appService.ListWebhooks(ctx, req.ToApplicationQuery())
+	if err != nil {
+		return WebhookListResponse{}, err
+	}
+
+	var response WebhookListResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// ExportWebhook handles HTTP webhook lifecycle export requests
+func (s *service) ExportWebhook(ctx context.Context, req ExportWebhookRequest) (WebhookExportResponse, error) {
+	result, err := s.appService.ExportWebhook(ctx, req.QueueID)
+	if err != nil {
+		return WebhookExportResponse{}, err
+	}
+
+	var response WebhookExportResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// GetWorkerCluster handles HTTP worker cluster requests
+func (s *service) GetWorkerCluster(ctx context.Context) (WorkerClusterResponse, error) {
+	result, err := s.appService.GetWorkerCluster(ctx)
+	if err != nil {
+		return WorkerClusterResponse{}, err
+	}
+
+	var response WorkerClusterResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// GetProcessingWebhooks handles HTTP requests for the webhooks currently in PROCESSING
+func (s *service) GetProcessingWebhooks(ctx context.Context) (ProcessingWebhooksResponse, error) {
+	result, err := s.appService.GetProcessingWebhooks(ctx)
+	if err != nil {
+		return ProcessingWebhooksResponse{}, err
+	}
+
+	var response ProcessingWebhooksResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
+
+// ReplayTo handles HTTP requests to re-send matching webhooks to a different URL
+func (s *service) ReplayTo(ctx context.Context, req ReplayToRequest) (ReplayToResponse, error) {
+	result, err := s.appService.ReplayTo(ctx, req.ToApplicationCommand())
+	if err != nil {
+		return ReplayToResponse{}, err
+	}
+
+	var response ReplayToResponse
+	response.FromApplicationResult(result)
+
+	return response, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	"webhook-processor/internal/application/services"
@@ -50,6 +51,70 @@ func (m *unitTestMockWebhookApplicationService) GetHealth(ctx context.Context) (
 	}, nil
 }
 
+func (m *unitTestMockWebhookApplicationService) GetWebhookStatus(ctx context.Context, queueID uuid.UUID) (*services.WebhookStatusResult, error) {
+	return nil, services.ErrWebhookNotFound
+}
+
+func (m *unitTestMockWebhookApplicationService) ListWebhooks(ctx context.Context, query services.ListWebhooksQuery) (*services.WebhookListResult, error) {
+	return &services.WebhookListResult{Items: []services.WebhookListItem{}}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) ExportWebhook(ctx context.Context, queueID uuid.UUID) (*services.WebhookExportResult, error) {
+	return nil, services.ErrWebhookNotFound
+}
+
+func (m *unitTestMockWebhookApplicationService) GetConfigStats(ctx context.Context, configID int64) (*services.ConfigStatsResult, error) {
+	return &services.ConfigStatsResult{ConfigID: configID}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) BulkUpdateStatus(ctx context.Context, cmd services.BulkUpdateStatusCommand) (*services.BulkUpdateStatusResult, error) {
+	return &services.BulkUpdateStatusResult{Success: true}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) ForceFailWebhook(ctx context.Context, cmd services.ForceFailWebhookCommand) (*services.ForceFailWebhookResult, error) {
+	return &services.ForceFailWebhookResult{Success: true, QueueID: cmd.QueueID.String()}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) ProcessWebhookNow(ctx context.Context, queueID uuid.UUID) (*services.ProcessWebhookResult, error) {
+	return &services.ProcessWebhookResult{Success: true, QueueID: queueID.String()}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) DeleteWebhook(ctx context.Context, queueID uuid.UUID) (*services.DeleteWebhookResult, error) {
+	return &services.DeleteWebhookResult{Success: true, QueueID: queueID.String()}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) GetWorkerCluster(ctx context.Context) (*services.WorkerClusterResult, error) {
+	return &services.WorkerClusterResult{Instances: []services.WorkerInstance{}}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) GetProcessingWebhooks(ctx context.Context) (*services.ProcessingWebhooksResult, error) {
+	return &services.ProcessingWebhooksResult{Webhooks: []services.ProcessingWebhook{}}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) ReplayTo(ctx context.Context, cmd services.ReplayToCommand) (*services.ReplayToResult, error) {
+	return &services.ReplayToResult{Success: true}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) CreateConfig(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error) {
+	return &services.ConfigResult{ID: 1, Name: cmd.Name}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) UpdateConfig(ctx context.Context, cmd services.SaveConfigCommand) (*services.ConfigResult, error) {
+	return &services.ConfigResult{ID: cmd.ID, Name: cmd.Name}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) DeleteConfig(ctx context.Context, configID int64) (*services.DeleteConfigResult, error) {
+	return &services.DeleteConfigResult{Success: true, ConfigID: configID}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) ListConfigs(ctx context.Context) (*services.ConfigListResult, error) {
+	return &services.ConfigListResult{Configs: []services.ConfigResult{}}, nil
+}
+
+func (m *unitTestMockWebhookApplicationService) GetEventStatus(ctx context.Context, eventID string) (*services.EventStatusResult, error) {
+	return nil, services.ErrEventNotFound
+}
+
 func TestHTTPService_CreateWebhook_Unit(t *testing.T) {
 	t.Run("should create webhook successfully", func(t *testing.T) {
 		// Arrange
@@ -62,7 +127,7 @@ func TestHTTPService_CreateWebhook_Unit(t *testing.T) {
 			},
 		}
 
-		httpService := NewService(mockAppService)
+		httpService := NewService(mockAppService, nil)
 		ctx := context.Background()
 
 		req := CreateWebhookRequest{
@@ -92,7 +157,7 @@ func TestHTTPService_CreateWebhook_Unit(t *testing.T) {
 			createWebhookError: errors.New("config not found"),
 		}
 
-		httpService := NewService(mockAppService)
+		httpService := NewService(mockAppService, nil)
 		ctx := context.Background()
 
 		req := CreateWebhookRequest{
@@ -127,7 +192,7 @@ func TestHTTPService_CreateWebhook_Unit(t *testing.T) {
 				},
 			}
 
-			httpService := NewService(mockAppService)
+			httpService := NewService(mockAppService, nil)
 			ctx := context.Background()
 
 			req := CreateWebhookRequest{
@@ -166,7 +231,7 @@ func TestHTTPService_GetHealth_Unit(t *testing.T) {
 			},
 		}
 
-		httpService := NewService(mockAppService)
+		httpService := NewService(mockAppService, nil)
 		ctx := context.Background()
 
 		// Act
@@ -189,7 +254,7 @@ func TestHTTPService_GetHealth_Unit(t *testing.T) {
 			healthError:  errors.New("database connection failed"),
 		}
 
-		httpService := NewService(mockAppService)
+		httpService := NewService(mockAppService, nil)
 		ctx := context.Background()
 
 		// Act
@@ -206,7 +271,7 @@ func TestNewService_Unit(t *testing.T) {
 		mockAppService := &unitTestMockWebhookApplicationService{}
 
 		// Act
-		service := NewService(mockAppService)
+		service := NewService(mockAppService, nil)
 
 		// Assert
 		assert.NotNil(t, service)